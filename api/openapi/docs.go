@@ -15,6 +15,357 @@ const docTemplate = `{
     "host": "{{.Host}}",
     "basePath": "{{.BasePath}}",
     "paths": {
+        "/admin/config": {
+            "get": {
+                "description": "回傳合併預設值、設定檔與環境變數後的有效設定及每個值的來源；密碼、token 等敏感值以遮罩顯示",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "admin"
+                ],
+                "summary": "Show effective configuration",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/github_com_blackhorseya_go-ddd_internal_adapter_http_response.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/internal_adapter_http_handler.ConfigSetting"
+                                            }
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_blackhorseya_go-ddd_internal_adapter_http_response.Response"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_blackhorseya_go-ddd_internal_adapter_http_response.Response"
+                        }
+                    }
+                },
+                "security": [
+                    {
+                        "Bearer": []
+                    }
+                ]
+            }
+        },
+        "/api/v1/exports/jobs/{id}": {
+            "get": {
+                "description": "查詢匯出工作狀態；完成時附上有時效的下載 URL",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "exports"
+                ],
+                "summary": "Get export job status",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Job ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/github_com_blackhorseya_go-ddd_internal_adapter_http_response.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/internal_adapter_http_handler.ExportJob"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_blackhorseya_go-ddd_internal_adapter_http_response.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/exports/jobs/{id}/download": {
+            "get": {
+                "description": "導向匯出檔案的預簽章下載 URL",
+                "tags": [
+                    "exports"
+                ],
+                "summary": "Download an export",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Job ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "302": {
+                        "description": "Found"
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_blackhorseya_go-ddd_internal_adapter_http_response.Response"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_blackhorseya_go-ddd_internal_adapter_http_response.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/exports/{exporter}": {
+            "post": {
+                "description": "建立匯出工作，由 worker 於背景產生 CSV 或 Parquet 檔案；完成後可透過預簽章 URL 下載",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "exports"
+                ],
+                "summary": "Request an export",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Exporter name",
+                        "name": "exporter",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Export request",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/internal_adapter_http_handler.ExportRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "202": {
+                        "description": "Accepted",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/github_com_blackhorseya_go-ddd_internal_adapter_http_response.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/internal_adapter_http_handler.ExportJob"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_blackhorseya_go-ddd_internal_adapter_http_response.Response"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_blackhorseya_go-ddd_internal_adapter_http_response.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/imports/jobs/{id}": {
+            "get": {
+                "description": "查詢匯入工作進度（已處理、成功、失敗筆數）",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "imports"
+                ],
+                "summary": "Get import job status",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Job ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/github_com_blackhorseya_go-ddd_internal_adapter_http_response.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/github_com_blackhorseya_go-ddd_internal_application_importer.Job"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_blackhorseya_go-ddd_internal_adapter_http_response.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/imports/jobs/{id}/errors": {
+            "get": {
+                "description": "下載匯入失敗列的錯誤報告（CSV：line, field, message）",
+                "produces": [
+                    "text/csv"
+                ],
+                "tags": [
+                    "imports"
+                ],
+                "summary": "Download import error report",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Job ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "file"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_blackhorseya_go-ddd_internal_adapter_http_response.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/imports/{importer}": {
+            "post": {
+                "description": "上傳 CSV 或 JSONL 檔案並於背景匯入；可用 multipart 欄位 file 或直接以 request body 上傳。格式由 format 參數、副檔名或 Content-Type 判斷",
+                "consumes": [
+                    "multipart/form-data",
+                    "text/csv",
+                    "application/x-ndjson"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "imports"
+                ],
+                "summary": "Start a bulk import",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Importer name",
+                        "name": "importer",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "File format (csv, jsonl)",
+                        "name": "format",
+                        "in": "query"
+                    },
+                    {
+                        "type": "file",
+                        "description": "Import file",
+                        "name": "file",
+                        "in": "formData"
+                    }
+                ],
+                "responses": {
+                    "202": {
+                        "description": "Accepted",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/github_com_blackhorseya_go-ddd_internal_adapter_http_response.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/github_com_blackhorseya_go-ddd_internal_application_importer.Job"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_blackhorseya_go-ddd_internal_adapter_http_response.Response"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_blackhorseya_go-ddd_internal_adapter_http_response.Response"
+                        }
+                    }
+                }
+            }
+        },
         "/healthz": {
             "get": {
                 "description": "檢查服務是否存活",
@@ -49,7 +400,7 @@ const docTemplate = `{
         },
         "/readyz": {
             "get": {
-                "description": "檢查服務是否準備好接收流量",
+                "description": "檢查服務啟動完成且其關鍵依賴（資料庫、快取等）準備好接收流量；非關鍵依賴失敗時回傳 degraded",
                 "produces": [
                     "application/json"
                 ],
@@ -75,12 +426,70 @@ const docTemplate = `{
                                 }
                             ]
                         }
+                    },
+                    "503": {
+                        "description": "Service Unavailable",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_blackhorseya_go-ddd_internal_adapter_http_response.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/startupz": {
+            "get": {
+                "description": "檢查所有啟動元件（資料庫遷移、快取預熱、消費者訂閱等）是否已完成",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "health"
+                ],
+                "summary": "Startup probe",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/github_com_blackhorseya_go-ddd_internal_adapter_http_response.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/internal_adapter_http_handler.HealthStatus"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "503": {
+                        "description": "Service Unavailable",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_blackhorseya_go-ddd_internal_adapter_http_response.Response"
+                        }
                     }
                 }
             }
         }
     },
     "definitions": {
+        "github_com_blackhorseya_go-ddd_internal_adapter_http_response.Cursor": {
+            "type": "object",
+            "properties": {
+                "has_more": {
+                    "type": "boolean"
+                },
+                "next_cursor": {
+                    "type": "string"
+                },
+                "prev_cursor": {
+                    "type": "string"
+                }
+            }
+        },
         "github_com_blackhorseya_go-ddd_internal_adapter_http_response.Error": {
             "type": "object",
             "properties": {
@@ -106,12 +515,18 @@ const docTemplate = `{
                 },
                 "message": {
                     "type": "string"
+                },
+                "rule": {
+                    "type": "string"
                 }
             }
         },
         "github_com_blackhorseya_go-ddd_internal_adapter_http_response.Meta": {
             "type": "object",
             "properties": {
+                "cursor": {
+                    "$ref": "#/definitions/github_com_blackhorseya_go-ddd_internal_adapter_http_response.Cursor"
+                },
                 "pagination": {
                     "$ref": "#/definitions/github_com_blackhorseya_go-ddd_internal_adapter_http_response.Pagination"
                 },
@@ -126,6 +541,9 @@ const docTemplate = `{
         "github_com_blackhorseya_go-ddd_internal_adapter_http_response.Pagination": {
             "type": "object",
             "properties": {
+                "has_next": {
+                    "type": "boolean"
+                },
                 "page": {
                     "type": "integer"
                 },
@@ -137,6 +555,9 @@ const docTemplate = `{
                 },
                 "total_pages": {
                     "type": "integer"
+                },
+                "total_unknown": {
+                    "type": "boolean"
                 }
             }
         },
@@ -155,13 +576,237 @@ const docTemplate = `{
                 }
             }
         },
+        "github_com_blackhorseya_go-ddd_internal_application_exporter.Format": {
+            "type": "string",
+            "enum": [
+                "csv",
+                "parquet"
+            ],
+            "x-enum-varnames": [
+                "FormatCSV",
+                "FormatParquet"
+            ]
+        },
+        "github_com_blackhorseya_go-ddd_internal_application_exporter.Status": {
+            "type": "string",
+            "enum": [
+                "pending",
+                "running",
+                "completed",
+                "failed"
+            ],
+            "x-enum-varnames": [
+                "StatusPending",
+                "StatusRunning",
+                "StatusCompleted",
+                "StatusFailed"
+            ]
+        },
+        "github_com_blackhorseya_go-ddd_internal_application_importer.Format": {
+            "type": "string",
+            "enum": [
+                "csv",
+                "jsonl"
+            ],
+            "x-enum-varnames": [
+                "FormatCSV",
+                "FormatJSONL"
+            ]
+        },
+        "github_com_blackhorseya_go-ddd_internal_application_importer.Job": {
+            "type": "object",
+            "properties": {
+                "created_at": {
+                    "type": "string"
+                },
+                "error": {
+                    "description": "set when the whole job failed",
+                    "type": "string"
+                },
+                "failed": {
+                    "type": "integer"
+                },
+                "finished_at": {
+                    "type": "string"
+                },
+                "format": {
+                    "$ref": "#/definitions/github_com_blackhorseya_go-ddd_internal_application_importer.Format"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "imported": {
+                    "type": "integer"
+                },
+                "importer": {
+                    "type": "string"
+                },
+                "processed": {
+                    "type": "integer"
+                },
+                "status": {
+                    "$ref": "#/definitions/github_com_blackhorseya_go-ddd_internal_application_importer.Status"
+                }
+            }
+        },
+        "github_com_blackhorseya_go-ddd_internal_application_importer.Status": {
+            "type": "string",
+            "enum": [
+                "pending",
+                "running",
+                "completed",
+                "failed"
+            ],
+            "x-enum-varnames": [
+                "StatusPending",
+                "StatusRunning",
+                "StatusCompleted",
+                "StatusFailed"
+            ]
+        },
+        "github_com_blackhorseya_go-ddd_pkg_healthx.CheckResult": {
+            "type": "object",
+            "properties": {
+                "checked_at": {
+                    "description": "CheckedAt is when the check ran; it predates the report for cached results.",
+                    "type": "string"
+                },
+                "duration": {
+                    "$ref": "#/definitions/time.Duration"
+                },
+                "error": {
+                    "type": "string"
+                },
+                "severity": {
+                    "$ref": "#/definitions/github_com_blackhorseya_go-ddd_pkg_healthx.Severity"
+                },
+                "status": {
+                    "$ref": "#/definitions/github_com_blackhorseya_go-ddd_pkg_healthx.Status"
+                }
+            }
+        },
+        "github_com_blackhorseya_go-ddd_pkg_healthx.Severity": {
+            "type": "string",
+            "enum": [
+                "critical",
+                "degraded"
+            ],
+            "x-enum-varnames": [
+                "SeverityCritical",
+                "SeverityDegraded"
+            ]
+        },
+        "github_com_blackhorseya_go-ddd_pkg_healthx.Status": {
+            "type": "string",
+            "enum": [
+                "up",
+                "down",
+                "degraded"
+            ],
+            "x-enum-varnames": [
+                "StatusUp",
+                "StatusDown",
+                "StatusDegraded"
+            ]
+        },
+        "internal_adapter_http_handler.ConfigSetting": {
+            "type": "object",
+            "properties": {
+                "key": {
+                    "type": "string"
+                },
+                "source": {
+                    "description": "default, file:\u003cpath\u003e or env:\u003cVAR\u003e",
+                    "type": "string"
+                },
+                "value": {
+                    "type": "string"
+                }
+            }
+        },
+        "internal_adapter_http_handler.ExportJob": {
+            "type": "object",
+            "properties": {
+                "created_at": {
+                    "type": "string"
+                },
+                "download_url": {
+                    "type": "string"
+                },
+                "error": {
+                    "type": "string"
+                },
+                "exporter": {
+                    "type": "string"
+                },
+                "finished_at": {
+                    "type": "string"
+                },
+                "format": {
+                    "$ref": "#/definitions/github_com_blackhorseya_go-ddd_internal_application_exporter.Format"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "rows": {
+                    "type": "integer"
+                },
+                "status": {
+                    "$ref": "#/definitions/github_com_blackhorseya_go-ddd_internal_application_exporter.Status"
+                }
+            }
+        },
+        "internal_adapter_http_handler.ExportRequest": {
+            "type": "object",
+            "properties": {
+                "format": {
+                    "description": "csv or parquet",
+                    "type": "string",
+                    "example": "csv"
+                },
+                "params": {
+                    "description": "exporter-specific filters",
+                    "type": "object"
+                }
+            }
+        },
         "internal_adapter_http_handler.HealthStatus": {
             "type": "object",
             "properties": {
+                "checks": {
+                    "type": "object",
+                    "additionalProperties": {
+                        "$ref": "#/definitions/github_com_blackhorseya_go-ddd_pkg_healthx.CheckResult"
+                    }
+                },
                 "status": {
                     "type": "string"
                 }
             }
+        },
+        "time.Duration": {
+            "type": "integer",
+            "format": "int64",
+            "enum": [
+                -9223372036854775808,
+                9223372036854775807,
+                1,
+                1000,
+                1000000,
+                1000000000,
+                60000000000,
+                3600000000000
+            ],
+            "x-enum-varnames": [
+                "minDuration",
+                "maxDuration",
+                "Nanosecond",
+                "Microsecond",
+                "Millisecond",
+                "Second",
+                "Minute",
+                "Hour"
+            ]
         }
     },
     "securityDefinitions": {
@@ -176,7 +821,7 @@ const docTemplate = `{
 
 // SwaggerInfo holds exported Swagger Info so clients can modify it
 var SwaggerInfo = &swag.Spec{
-	Version:          "1.0",
+	Version:          "1.2.0",
 	Host:             "",
 	BasePath:         "",
 	Schemes:          []string{},
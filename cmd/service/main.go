@@ -14,13 +14,28 @@ import (
 	"context"
 	"flag"
 	"log"
+	"net/http"
 	"os"
 	"os/signal"
 	"syscall"
+	"time"
 
+	"github.com/gin-gonic/gin"
+
+	"github.com/blackhorseya/go-ddd/internal/adapter/graphql"
 	httpserver "github.com/blackhorseya/go-ddd/internal/adapter/http"
+	"github.com/blackhorseya/go-ddd/internal/adapter/http/handler"
+	"github.com/blackhorseya/go-ddd/internal/adapter/http/middleware"
+	"github.com/blackhorseya/go-ddd/internal/application/exporter"
+	"github.com/blackhorseya/go-ddd/internal/application/importer"
 	"github.com/blackhorseya/go-ddd/internal/infrastructure/config"
+	"github.com/blackhorseya/go-ddd/internal/infrastructure/exportstore"
+	"github.com/blackhorseya/go-ddd/internal/infrastructure/objectstore"
+	"github.com/blackhorseya/go-ddd/internal/infrastructure/persistence/migrations"
+	"github.com/blackhorseya/go-ddd/internal/infrastructure/persistence/postgres"
+	"github.com/blackhorseya/go-ddd/internal/infrastructure/persistence/seed"
 	"github.com/blackhorseya/go-ddd/pkg/contextx"
+	"github.com/blackhorseya/go-ddd/pkg/healthx"
 	"github.com/blackhorseya/go-ddd/pkg/logx"
 	"github.com/blackhorseya/go-ddd/pkg/otelx"
 )
@@ -35,10 +50,25 @@ var (
 func main() {
 	// Parse command line flags
 	configPath := flag.String("config", "", "path to config file")
+	strictConfig := flag.Bool("strict-config", false, "fail on unknown keys in config files")
+	config.BindFlags(flag.CommandLine) // -<key>=<value> overrides any config key, e.g. -log.level=debug
 	flag.Parse()
+	configOpts := []config.Option{config.WithFlags(flag.CommandLine)}
+	if *strictConfig {
+		configOpts = append(configOpts, config.Strict())
+	}
+
+	// Subcommand: service [-config path] config show [-json] | schema | encrypt; runs before
+	// Load so an invalid configuration can still be inspected
+	if flag.Arg(0) == "config" {
+		if err := config.Command(context.Background(), *configPath, flag.Args()[1:], os.Stdout, configOpts...); err != nil {
+			log.Fatalf("config: %v", err)
+		}
+		return
+	}
 
 	// Load configuration
-	cfg, err := config.Load(*configPath)
+	cfg, err := config.Load(*configPath, configOpts...)
 	if err != nil {
 		log.Fatalf("failed to load config: %v", err)
 	}
@@ -49,7 +79,15 @@ func main() {
 		Format:    cfg.Log.Format,
 		Output:    cfg.Log.Output,
 		AddSource: cfg.Log.AddSource,
+		File: logx.FileConfig{
+			Path:       cfg.Log.File.Path,
+			MaxSize:    int64(cfg.Log.File.MaxSize),
+			MaxAge:     cfg.Log.File.MaxAge,
+			MaxBackups: cfg.Log.File.MaxBackups,
+			Compress:   cfg.Log.File.Compress,
+		},
 	})
+	defer logger.Close()
 	logger.SetAsDefault()
 
 	// Create base context with service info
@@ -57,10 +95,41 @@ func main() {
 		WithService(cfg.App.Name).
 		WithEnvironment(cfg.App.Env)
 
+	// Subcommand: service [-config path] migrate up | down [N] | status
+	if flag.Arg(0) == "migrate" {
+		if err := migrations.Command(ctx, cfg.Database, flag.Args()[1:], os.Stdout); err != nil {
+			log.Fatalf("migrate: %v", err)
+		}
+		return
+	}
+
+	// Subcommand: service [-config path] seed [env]
+	if flag.Arg(0) == "seed" {
+		if err := seed.Command(ctx, cfg, flag.Args()[1:], os.Stdout); err != nil {
+			log.Fatalf("seed: %v", err)
+		}
+		return
+	}
+
+	// The service's own stores are PostgreSQL-only; seed above also supports MySQL
+	if err := cfg.RequirePostgres(); err != nil {
+		log.Fatalf("failed to load config: %v", err)
+	}
+
 	// Initialize OpenTelemetry tracing
-	otelCfg := otelx.DefaultConfig()
-	otelCfg.ServiceName = cfg.App.Name
-	otelCfg.Environment = cfg.App.Env
+	otelCfg := otelx.Config{
+		Enabled:        cfg.Otel.Enabled,
+		ServiceName:    cfg.App.Name,
+		ServiceVersion: Version,
+		Environment:    cfg.App.Env,
+		Exporter:       cfg.Otel.Exporter,
+		SampleRate:     cfg.Otel.SampleRate,
+		OTLP: otelx.OTLPConfig{
+			Endpoint: cfg.Otel.OTLP.Endpoint,
+			Insecure: cfg.Otel.OTLP.Insecure,
+			Protocol: cfg.Otel.OTLP.Protocol,
+		},
+	}
 	tp, err := otelx.Setup(ctx, otelCfg)
 	if err != nil {
 		log.Fatalf("failed to setup tracing: %v", err)
@@ -81,6 +150,11 @@ func main() {
 		"grpc_port", cfg.Server.GRPC.Port,
 	)
 
+	// Apply pending migrations before serving when database.auto_migrate is set (non-production only)
+	if err := migrations.AutoMigrate(ctx, cfg); err != nil {
+		log.Fatalf("failed to migrate database: %v", err)
+	}
+
 	// Setup signal handling
 	signals := make(chan os.Signal, 1)
 	signal.Notify(signals, syscall.SIGINT, syscall.SIGTERM)
@@ -88,13 +162,109 @@ func main() {
 	// Create cancellable context for graceful shutdown
 	runCtx, cancel := context.WithCancel(ctx)
 
+	// Reload the config file and remote store on change; settings that can
+	// apply live subscribe to their keys, while restart-bound ones such as
+	// ports are ignored.
+	if *configPath != "" || cfg.Remote.Provider != "" {
+		reloader := config.NewReloader(*configPath, cfg, config.DefaultSecrets(), configOpts...)
+		reloader.Subscribe("log.level", func(c config.Change) {
+			if err := logger.SetLevel(c.New.Log.Level); err != nil {
+				ctx.Warn("failed to apply log level", "error", err)
+			}
+		})
+		go func() {
+			if err := reloader.Run(runCtx); err != nil {
+				ctx.Error("config reload stopped", "error", err)
+			}
+		}()
+	}
+
+	// Register dependency health checks for readiness probe; results are
+	// cached briefly so frequent probes do not hammer the dependencies.
+	// Losing the collector only loses telemetry, so it degrades readiness.
+	health := healthx.NewRegistry()
+
+	// Export jobs are shared with the worker that runs them, so they live in
+	// the database; the in-memory store is only a fallback for a service
+	// whose database settings are unusable, where requested exports are
+	// never run.
+	var exportJobs exporter.JobStore = exporter.NewMemoryJobStore()
+	if db, err := postgres.Open(cfg.Database); err != nil {
+		ctx.Warn("no usable database, export jobs are kept in memory", "error", err)
+	} else {
+		defer db.Close()
+		exportJobs = exportstore.NewStore(db)
+		health.Register(healthx.Database(db), healthx.WithTimeout(2*time.Second), healthx.WithCacheTTL(5*time.Second))
+	}
+	if otelCfg.Enabled && otelCfg.Exporter == "otlp" {
+		health.Register(healthx.OTLP(otelCfg.OTLP.Endpoint), healthx.WithTimeout(2*time.Second),
+			healthx.WithCacheTTL(5*time.Second), healthx.WithSeverity(healthx.SeverityDegraded))
+	}
+
+	// Startup gate: components (migrations, cache warm-up, consumers) register
+	// with startup.Add and signal readiness when they finish initializing.
+	startup := healthx.NewGate()
+
+	// List endpoints open and issue cursors with server.Cursors(), signed
+	// with pagination.cursor_keys and expiring after pagination.cursor_ttl.
+	cursorKeys := make([][]byte, len(cfg.Pagination.CursorKeys))
+	for i, key := range cfg.Pagination.CursorKeys {
+		cursorKeys[i] = []byte(key)
+	}
+
 	// Initialize HTTP server
 	server := httpserver.NewServer(httpserver.ServerConfig{
 		Host:         cfg.Server.HTTP.Host,
 		Port:         cfg.Server.HTTP.Port,
 		ReadTimeout:  cfg.Server.HTTP.ReadTimeout,
 		WriteTimeout: cfg.Server.HTTP.WriteTimeout,
-	}, cfg.App.Name)
+		MaxBodySize:  int64(cfg.Server.HTTP.MaxBodySize),
+		Mode:         cfg.Server.HTTP.Mode,
+		GraphQL: graphql.HandlerConfig{
+			Playground:    cfg.Server.HTTP.GraphQL.Playground,
+			Introspection: cfg.Server.HTTP.GraphQL.Introspection,
+		},
+		Mirror: middleware.MirrorConfig{
+			Upstream:       cfg.Server.HTTP.Mirror.Upstream,
+			Percentage:     cfg.Server.HTTP.Mirror.Percentage,
+			Timeout:        cfg.Server.HTTP.Mirror.Timeout,
+			MaxInFlight:    cfg.Server.HTTP.Mirror.MaxInFlight,
+			ForwardHeaders: cfg.Server.HTTP.Mirror.ForwardHeaders,
+		},
+		CursorKeys: cursorKeys,
+		CursorTTL:  cfg.Pagination.CursorTTL,
+	}, cfg.App.Name, health, startup)
+
+	// Admin endpoints are only served when server.http.admin_token is set
+	if token := cfg.Server.HTTP.AdminToken; token != "" {
+		handler.NewAdminHandler(token, func(ctx context.Context) ([]handler.ConfigSetting, error) {
+			settings, err := config.Describe(ctx, *configPath, config.DefaultSecrets(), configOpts...)
+			if err != nil {
+				return nil, err
+			}
+			out := make([]handler.ConfigSetting, len(settings))
+			for i, s := range settings {
+				out[i] = handler.ConfigSetting(s)
+			}
+			return out, nil
+		}).Register(server.Router())
+	}
+
+	// Bulk imports: register importers here, e.g. importer.New(productsDef, uow, importJobs).
+	importJobs := importer.NewMemoryJobStore()
+	handler.NewImportHandler(importJobs).Register(server.Router())
+
+	// Exports: register exporters here, e.g. exporter.New(ordersExport, exportJobs,
+	// files, queue); the worker runs their tasks against the same job store.
+	files, downloads, err := objectstore.New(runCtx, cfg.Storage)
+	if err != nil {
+		log.Fatalf("failed to create object store: %v", err)
+	}
+	exports := exporter.NewService(exportJobs, files, cfg.Storage.URLTTL)
+	handler.NewExportHandler(exports).Register(server.Router())
+	if downloads != nil {
+		server.Router().GET("/files/*key", gin.WrapH(http.StripPrefix("/files", downloads)))
+	}
 
 	// Start HTTP server in goroutine
 	errCh := make(chan error, 1)
@@ -0,0 +1,407 @@
+// Package main is the entry point of the background worker.
+// The worker runs background processing only — the transactional outbox
+// relay, read-model projections, cron jobs, the async task consumer and
+// the message bus consumer —
+// and serves health probes but no business routes, so it scales
+// independently of the API. Components are toggled in the worker config.
+package main
+
+import (
+	"context"
+	"database/sql"
+	"flag"
+	"log"
+	"os"
+	"os/signal"
+	"sync"
+	"syscall"
+	"time"
+
+	httpserver "github.com/blackhorseya/go-ddd/internal/adapter/http"
+	"github.com/blackhorseya/go-ddd/internal/application/exporter"
+	"github.com/blackhorseya/go-ddd/internal/application/message"
+	"github.com/blackhorseya/go-ddd/internal/application/saga"
+	"github.com/blackhorseya/go-ddd/internal/application/storage"
+	"github.com/blackhorseya/go-ddd/internal/application/task"
+	"github.com/blackhorseya/go-ddd/internal/infrastructure/config"
+	"github.com/blackhorseya/go-ddd/internal/infrastructure/exportstore"
+	"github.com/blackhorseya/go-ddd/internal/infrastructure/messaging/kafka"
+	"github.com/blackhorseya/go-ddd/internal/infrastructure/objectstore"
+	"github.com/blackhorseya/go-ddd/internal/infrastructure/outbox"
+	"github.com/blackhorseya/go-ddd/internal/infrastructure/persistence/migrations"
+	"github.com/blackhorseya/go-ddd/internal/infrastructure/persistence/poolstats"
+	"github.com/blackhorseya/go-ddd/internal/infrastructure/persistence/postgres"
+	redisx "github.com/blackhorseya/go-ddd/internal/infrastructure/persistence/redis"
+	"github.com/blackhorseya/go-ddd/internal/infrastructure/persistence/sqldb"
+	"github.com/blackhorseya/go-ddd/internal/infrastructure/projection"
+	"github.com/blackhorseya/go-ddd/internal/infrastructure/sagastore"
+	"github.com/blackhorseya/go-ddd/internal/infrastructure/scheduler"
+	"github.com/blackhorseya/go-ddd/internal/infrastructure/taskqueue"
+	"github.com/blackhorseya/go-ddd/pkg/contextx"
+	"github.com/blackhorseya/go-ddd/pkg/healthx"
+	"github.com/blackhorseya/go-ddd/pkg/lockx"
+	"github.com/blackhorseya/go-ddd/pkg/logx"
+	"github.com/blackhorseya/go-ddd/pkg/otelx"
+	"github.com/blackhorseya/go-ddd/pkg/runx"
+)
+
+// 版本資訊，由 GoReleaser ldflags 注入
+var (
+	Version = "dev"
+	Commit  = "none"
+	Date    = "unknown"
+)
+
+// component is a long-running part of the worker.
+type component struct {
+	name string
+	run  func(ctx context.Context) error
+
+	// started, when set, is closed once the component has finished
+	// starting (subscribed, connected, first poll done); until then the
+	// startup gate holds readiness. Components without it count as
+	// started when run is called.
+	started <-chan struct{}
+}
+
+func main() {
+	// Parse command line flags
+	configPath := flag.String("config", "", "path to config file")
+	strictConfig := flag.Bool("strict-config", false, "fail on unknown keys in config files")
+	config.BindFlags(flag.CommandLine) // -<key>=<value> overrides any config key, e.g. -log.level=debug
+	flag.Parse()
+	configOpts := []config.Option{config.WithFlags(flag.CommandLine)}
+	if *strictConfig {
+		configOpts = append(configOpts, config.Strict())
+	}
+
+	// Load configuration
+	cfg, err := config.Load(*configPath, configOpts...)
+	if err != nil {
+		log.Fatalf("failed to load config: %v", err)
+	}
+	// Every worker store (outbox, projections, sagas, export jobs) is PostgreSQL-only
+	if err := cfg.RequirePostgres(); err != nil {
+		log.Fatalf("failed to load config: %v", err)
+	}
+
+	// Initialize logger
+	logger := logx.MustNew(&logx.Config{
+		Level:     cfg.Log.Level,
+		Format:    cfg.Log.Format,
+		Output:    cfg.Log.Output,
+		AddSource: cfg.Log.AddSource,
+		File: logx.FileConfig{
+			Path:       cfg.Log.File.Path,
+			MaxSize:    int64(cfg.Log.File.MaxSize),
+			MaxAge:     cfg.Log.File.MaxAge,
+			MaxBackups: cfg.Log.File.MaxBackups,
+			Compress:   cfg.Log.File.Compress,
+		},
+	})
+	defer logger.Close()
+	logger.SetAsDefault()
+
+	// Create base context with service info
+	serviceName := cfg.App.Name + "-worker"
+	ctx := contextx.Background().
+		WithService(serviceName).
+		WithEnvironment(cfg.App.Env)
+
+	// Initialize OpenTelemetry tracing
+	otelCfg := otelx.Config{
+		Enabled:        cfg.Otel.Enabled,
+		ServiceName:    serviceName,
+		ServiceVersion: Version,
+		Environment:    cfg.App.Env,
+		Exporter:       cfg.Otel.Exporter,
+		SampleRate:     cfg.Otel.SampleRate,
+		OTLP: otelx.OTLPConfig{
+			Endpoint: cfg.Otel.OTLP.Endpoint,
+			Insecure: cfg.Otel.OTLP.Insecure,
+			Protocol: cfg.Otel.OTLP.Protocol,
+		},
+	}
+	tp, err := otelx.Setup(ctx, otelCfg)
+	if err != nil {
+		log.Fatalf("failed to setup tracing: %v", err)
+	}
+	defer func() {
+		if err := tp.Shutdown(context.Background()); err != nil {
+			ctx.Error("failed to shutdown tracer provider", "error", err)
+		}
+	}()
+
+	ctx.Info("worker starting",
+		"version", Version,
+		"commit", Commit,
+		"build_date", Date,
+		"components", cfg.Worker.Components,
+	)
+
+	// Apply pending migrations when database.auto_migrate is set (non-production only)
+	if err := migrations.AutoMigrate(ctx, cfg); err != nil {
+		log.Fatalf("failed to migrate database: %v", err)
+	}
+
+	// Open database; the pool connects lazily and readiness reports its health
+	db, err := postgres.Open(cfg.Database)
+	if err != nil {
+		log.Fatalf("failed to open database: %v", err)
+	}
+	defer db.Close()
+
+	// Open Redis; the connection is established lazily and closed after the components stop
+	rdb, err := redisx.NewClient(cfg.Redis)
+	if err != nil {
+		log.Fatalf("failed to create redis client: %v", err)
+	}
+	defer rdb.Close()
+
+	// Redis backs the task queue and the locks taken by the outbox relay,
+	// the scheduler and leader election
+	enabled := cfg.Worker.Components
+	usesRedis := enabled.Tasks || enabled.Outbox || enabled.Scheduler || cfg.Worker.LeaderElection
+
+	// Register dependency health checks for readiness probe; results are
+	// cached briefly so frequent probes do not hammer the dependencies
+	health := healthx.NewRegistry()
+	health.Register(healthx.Database(db), healthx.WithTimeout(2*time.Second), healthx.WithCacheTTL(5*time.Second))
+	if usesRedis {
+		health.Register(redisx.Checker(rdb), healthx.WithTimeout(2*time.Second), healthx.WithCacheTTL(5*time.Second))
+	}
+	if len(cfg.Kafka.Brokers) > 0 {
+		// Only the consumer stalls without a broker; the outbox keeps
+		// messages until it is back
+		severity := healthx.SeverityDegraded
+		if cfg.Worker.Components.Consumer {
+			severity = healthx.SeverityCritical
+		}
+		health.Register(kafka.Checker(cfg.Kafka.Brokers), healthx.WithTimeout(2*time.Second),
+			healthx.WithCacheTTL(5*time.Second), healthx.WithSeverity(severity))
+	}
+	startup := healthx.NewGate()
+
+	locker := lockx.New(lockx.Options{}, rdb)
+	uow := sqldb.NewTxManager(db, nil)
+	store := outbox.NewStore(db)
+
+	// With leader election, singleton components run on the elected replica
+	// only; the others stand by and take over when it stops. A standby
+	// replica counts as started once it is campaigning.
+	singleton := func(name string, run func(ctx context.Context) error, started <-chan struct{}) component {
+		if !cfg.Worker.LeaderElection {
+			return component{name: name, run: run, started: started}
+		}
+		return component{name: name, run: runx.Leader(runx.NewLockElector(locker), runx.LeaderOptions{
+			Name:      serviceName + ":" + name,
+			OnElected: func(ctx context.Context) { contextx.From(ctx).Info("leadership gained", "component", name) },
+			OnLost:    func(ctx context.Context) { contextx.From(ctx).Info("leadership ended", "component", name) },
+		}, run)}
+	}
+
+	kafkaCfg := kafka.Config{
+		Brokers:      cfg.Kafka.Brokers,
+		GroupID:      cfg.Kafka.GroupID,
+		TopicPrefix:  cfg.Kafka.TopicPrefix,
+		DLQSuffix:    cfg.Kafka.DLQSuffix,
+		MaxRetries:   cfg.Kafka.MaxRetries,
+		RetryBackoff: cfg.Kafka.RetryBackoff,
+		BatchTimeout: cfg.Kafka.BatchTimeout,
+	}
+
+	var components []component
+	if cfg.Worker.Components.Outbox {
+		// Without brokers the relay only logs messages, which suits local development
+		var publisher outbox.Publisher = outbox.LogPublisher{}
+		if len(cfg.Kafka.Brokers) > 0 {
+			producer := kafka.NewProducer(kafkaCfg)
+			defer producer.Close()
+			publisher = outbox.NewBusPublisher(producer)
+		}
+		relay := outbox.NewRelay(store, publisher, outbox.RelayConfig{
+			PollInterval: cfg.Outbox.PollInterval,
+			BatchSize:    cfg.Outbox.BatchSize,
+			Locker:       locker,
+			MaxAttempts:  cfg.Outbox.MaxAttempts,
+		})
+		components = append(components, singleton("outbox", relay.Run, relay.Started()))
+	}
+	if cfg.Worker.Components.Projections {
+		runner := projection.NewRunner(store, projection.NewCheckpoints(db), uow, projection.RunnerConfig{},
+			projections(db)...)
+		components = append(components, component{name: "projections", run: runner.Run, started: runner.Started()})
+	}
+	if cfg.Worker.Components.Sagas {
+		// Versioned saves let every replica resume sagas without leader election
+		sagas := saga.NewOrchestrator(sagastore.NewStore(db))
+		registerSagas(sagas)
+		resumer := saga.NewResumer(sagas, saga.ResumerConfig{})
+		components = append(components, component{name: "sagas", run: resumer.Run, started: resumer.Started()})
+	}
+	if cfg.Worker.Components.Scheduler {
+		jobs := scheduler.New(scheduler.Config{
+			Schedules: cfg.Scheduler.Jobs,
+			LockTTL:   cfg.Scheduler.LockTTL,
+		}, locker)
+		registerJobs(jobs, db)
+		components = append(components, singleton("scheduler", jobs.Run, jobs.Started()))
+	}
+	if cfg.Worker.Components.Tasks {
+		files, _, err := objectstore.New(ctx, cfg.Storage)
+		if err != nil {
+			log.Fatalf("failed to create object store: %v", err)
+		}
+		tasks := taskqueue.NewServer(rdb, taskqueue.Config{
+			Concurrency:     cfg.TaskQueue.Concurrency,
+			Queues:          cfg.TaskQueue.Queues,
+			RetryBaseDelay:  cfg.TaskQueue.RetryBaseDelay,
+			RetryMaxDelay:   cfg.TaskQueue.RetryMaxDelay,
+			ShutdownTimeout: cfg.TaskQueue.ShutdownTimeout,
+		})
+		registerTasks(tasks, exporters(exportstore.NewStore(db), files)...)
+		components = append(components, component{name: "tasks", run: tasks.Run, started: tasks.Started()})
+	}
+	if cfg.Worker.Components.Consumer {
+		if len(cfg.Kafka.Brokers) == 0 {
+			log.Fatal("worker.components.consumer requires kafka.brokers")
+		}
+		consumer := kafka.NewConsumer(kafkaCfg)
+		registerConsumers(consumer)
+		components = append(components, component{name: "consumer", run: consumer.Run, started: consumer.Started()})
+	}
+
+	// Export pool saturation (open, idle, waits) of the shared clients
+	pools := []poolstats.Source{poolstats.SQL("database", db)}
+	if usesRedis {
+		pools = append(pools, poolstats.Redis("redis", rdb))
+	}
+	components = append(components, component{name: "pool-metrics", run: poolstats.NewReporter(poolstats.DefaultInterval, pools...).Run})
+
+	// Reload the config file and remote store on change; settings that can apply live subscribe to their keys
+	if *configPath != "" || cfg.Remote.Provider != "" {
+		reloader := config.NewReloader(*configPath, cfg, config.DefaultSecrets(), configOpts...)
+		reloader.Subscribe("log.level", func(c config.Change) {
+			if err := logger.SetLevel(c.New.Log.Level); err != nil {
+				ctx.Warn("failed to apply log level", "error", err)
+			}
+		})
+		components = append(components, component{name: "config-reload", run: reloader.Run})
+	}
+
+	server := httpserver.NewHealthServer(httpserver.ServerConfig{
+		Host: cfg.Worker.Host,
+		Port: cfg.Worker.Port,
+		Mode: cfg.Server.HTTP.Mode,
+	}, serviceName, health, startup)
+	components = append(components, component{name: "health", run: server.Run})
+
+	// Setup signal handling
+	runCtx, stop := signal.NotifyContext(ctx, os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	run(contextx.From(runCtx), startup, components)
+
+	ctx.Info("worker shutdown complete")
+}
+
+// run starts all components and blocks until every one has returned. A
+// component returning nil has finished and the others keep running; one
+// returning an error stops them all, as does cancelling ctx. Each
+// component is marked ready on the startup gate once it has started, or
+// once it has finished without error.
+func run(ctx context.Context, startup *healthx.Gate, components []component) {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	var wg sync.WaitGroup
+	for _, c := range components {
+		ready := startup.Add(c.name)
+		finished := make(chan struct{})
+		if c.started == nil {
+			ready()
+		} else {
+			go func() {
+				select {
+				case <-c.started:
+					ready()
+				case <-finished:
+					ready()
+				case <-ctx.Done():
+				}
+			}()
+		}
+
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+
+			if err := c.run(ctx); err != nil {
+				contextx.From(ctx).Error("component failed", "component", c.name, "error", err)
+				cancel()
+				return
+			}
+			if ctx.Err() == nil {
+				contextx.From(ctx).Info("component finished", "component", c.name)
+				close(finished)
+			}
+		}()
+	}
+	wg.Wait()
+}
+
+// projections returns the read-model projections kept up to date by the worker.
+func projections(_ *sql.DB) []projection.Projection {
+	return nil
+}
+
+// registerJobs registers cron jobs; schedules come from scheduler.jobs in config:
+//
+//	purger := sqldb.NewPurger(db, sqldb.PurgerConfig{Tables: []string{"orders"}})
+//	s.Register("purge_soft_deleted", func(ctx context.Context) error {
+//		_, err := purger.PurgeOnce(ctx)
+//		return err
+//	})
+func registerJobs(_ *scheduler.Scheduler, _ *sql.DB) {}
+
+// registerSagas registers the saga definitions whose stalled instances the
+// worker resumes. They match the definitions the service starts:
+//
+//	saga.Register(o, checkoutSaga(orders, payments))
+func registerSagas(_ *saga.Orchestrator) {}
+
+// exporters returns the exporters whose tasks the worker runs. They match
+// the exporters the service requests and share its job store:
+//
+//	return []exporter.TaskHandler{exporter.New(ordersExport, jobs, files, nil)}
+func exporters(_ exporter.JobStore, _ storage.Store) []exporter.TaskHandler {
+	return nil
+}
+
+// registerTasks registers async task handlers with task.Handle, starting
+// with the export tasks.
+//
+// Email is sent from tasks so the request path never waits on the mail
+// provider; permanent rejections skip the remaining retries:
+//
+//	mails, err := mailer.New(ctx, cfg.Mail)
+//	task.Handle(r, SendWelcome, func(ctx context.Context, u Welcome) error {
+//		err := mails.Send(ctx, mail.Message{To: []string{u.Email}, Subject: "Welcome", Template: "welcome", Data: u})
+//		if errors.Is(err, mail.ErrRejected) {
+//			return fmt.Errorf("%w: %w", task.ErrSkipRetry, err)
+//		}
+//		return err
+//	})
+func registerTasks(r task.Registrar, exports ...exporter.TaskHandler) {
+	for _, e := range exports {
+		e.Handle(r)
+	}
+}
+
+// registerConsumers registers message handlers with message.Handle.
+// Delivery is at-least-once, so handlers deduplicate on the envelope ID:
+//
+//	message.Handle(s, orders.PlacedTopic, func(ctx context.Context, env message.Envelope, e orders.PlacedV1) error {
+//		return reserveStock(ctx, env.ID, e)
+//	})
+func registerConsumers(_ message.Subscriber) {}
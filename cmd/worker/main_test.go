@@ -0,0 +1,144 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/blackhorseya/go-ddd/internal/adapter/http/handler"
+	"github.com/blackhorseya/go-ddd/internal/application/exporter"
+	"github.com/blackhorseya/go-ddd/internal/application/task"
+	"github.com/blackhorseya/go-ddd/pkg/healthx"
+)
+
+func TestRun_ReadinessWaitsForStartedComponents(t *testing.T) {
+	// Arrange
+	gin.SetMode(gin.TestMode)
+	startup := healthx.NewGate()
+	r := gin.New()
+	handler.NewHealthHandler(healthx.NewRegistry(), startup).Register(r)
+	readyz := func() int {
+		w := httptest.NewRecorder()
+		r.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/readyz", nil))
+		return w.Code
+	}
+
+	subscribed := make(chan struct{})
+	block := func(ctx context.Context) error {
+		<-ctx.Done()
+		return nil
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		run(ctx, startup, []component{
+			{name: "consumer", run: block, started: subscribed},
+			{name: "health", run: block},
+		})
+	}()
+	defer func() {
+		cancel()
+		<-done
+	}()
+
+	// Assert: the consumer is running but has not subscribed yet
+	time.Sleep(20 * time.Millisecond)
+	if code := readyz(); code != http.StatusServiceUnavailable {
+		t.Fatalf("/readyz before subscribe = %d, want %d", code, http.StatusServiceUnavailable)
+	}
+	if pending := startup.Pending(); len(pending) != 1 || pending[0] != "consumer" {
+		t.Errorf("Pending() = %v, want [consumer]", pending)
+	}
+
+	// Act
+	close(subscribed)
+
+	// Assert
+	deadline := time.Now().Add(time.Second)
+	for readyz() != http.StatusOK {
+		if time.Now().After(deadline) {
+			t.Fatal("/readyz did not become ready after the component started")
+		}
+		time.Sleep(5 * time.Millisecond)
+	}
+}
+
+func TestRun_OnlyErrorsStopOtherComponents(t *testing.T) {
+	tests := []struct {
+		name     string
+		err      error
+		wantStop bool
+	}{
+		{name: "finished component leaves the others running"},
+		{name: "failed component stops the others", err: errors.New("boom"), wantStop: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Arrange
+			startup := healthx.NewGate()
+			stopped := make(chan struct{})
+			block := func(ctx context.Context) error {
+				<-ctx.Done()
+				close(stopped)
+				return nil
+			}
+			ctx, cancel := context.WithCancel(context.Background())
+			defer cancel()
+			done := make(chan struct{})
+
+			// Act
+			go func() {
+				defer close(done)
+				run(ctx, startup, []component{
+					{name: "oneshot", run: func(context.Context) error { return tt.err }, started: make(chan struct{})},
+					{name: "health", run: block},
+				})
+			}()
+
+			// Assert
+			select {
+			case <-stopped:
+				if !tt.wantStop {
+					t.Fatal("other component stopped after one finished without error")
+				}
+			case <-time.After(50 * time.Millisecond):
+				if tt.wantStop {
+					t.Fatal("other component still running after one failed")
+				}
+				if pending := startup.Pending(); len(pending) != 0 {
+					t.Errorf("Pending() = %v, want a finished component counted as started", pending)
+				}
+				cancel()
+			}
+			<-done
+		})
+	}
+}
+
+// registrar records the registered task types.
+type registrar []string
+
+func (r *registrar) HandleFunc(taskType string, _ task.HandlerFunc) {
+	*r = append(*r, taskType)
+}
+
+func TestRegisterTasks_Exporters(t *testing.T) {
+	// Arrange
+	orders := exporter.New(exporter.Definition[struct{}]{Name: "orders"}, exporter.NewMemoryJobStore(), nil, nil)
+	var r registrar
+
+	// Act
+	registerTasks(&r, orders)
+
+	// Assert
+	if len(r) != 1 || r[0] != "export:orders" {
+		t.Errorf("registered tasks = %v, want [export:orders]", r)
+	}
+}
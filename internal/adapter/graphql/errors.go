@@ -0,0 +1,58 @@
+package graphql
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"runtime/debug"
+
+	"github.com/99designs/gqlgen/graphql"
+	"github.com/vektah/gqlparser/v2/gqlerror"
+
+	"github.com/blackhorseya/go-ddd/internal/adapter/http/response"
+	"github.com/blackhorseya/go-ddd/pkg/contextx"
+)
+
+// errorPresenter maps resolver errors to GraphQL errors using the shared
+// domain error mapping table. The error code is exposed in extensions.code,
+// and internal errors are replaced with a safe message.
+func errorPresenter(ctx context.Context, err error) *gqlerror.Error {
+	gqlErr := graphql.DefaultErrorPresenter(ctx, err)
+
+	// Errors raised by gqlgen itself (parsing, validation) are already safe.
+	var resolverErr *gqlerror.Error
+	if errors.As(err, &resolverErr) && resolverErr.Err == nil {
+		return gqlErr
+	}
+
+	cause := errors.Unwrap(gqlErr)
+	if cause == nil {
+		cause = err
+	}
+
+	_, code := response.Classify(cause)
+	if code == response.CodeInternalError {
+		contextx.From(ctx).Error("graphql resolver error",
+			"path", gqlErr.Path.String(),
+			"error", cause,
+		)
+	}
+
+	gqlErr.Message = response.SafeMessage(cause)
+	if gqlErr.Extensions == nil {
+		gqlErr.Extensions = make(map[string]any)
+	}
+	gqlErr.Extensions["code"] = code
+
+	return gqlErr
+}
+
+// recoverFunc logs a resolver panic and turns it into an internal error.
+func recoverFunc(ctx context.Context, rec any) error {
+	contextx.From(ctx).Error("graphql panic recovered",
+		"trace_id", contextx.GetTraceID(ctx),
+		"error", fmt.Sprintf("%v", rec),
+		"stack", string(debug.Stack()),
+	)
+	return errors.New("internal server error")
+}
@@ -0,0 +1,44 @@
+package graphql
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/blackhorseya/go-ddd/internal/adapter/http/response"
+	"github.com/blackhorseya/go-ddd/internal/domain"
+)
+
+func TestErrorPresenter(t *testing.T) {
+	tests := []struct {
+		name        string
+		err         error
+		wantCode    string
+		wantMessage string
+	}{
+		{
+			name:        "domain error",
+			err:         fmt.Errorf("list: %w", domain.ErrInvalidPageSize),
+			wantCode:    response.CodeBadRequest,
+			wantMessage: "list: " + domain.ErrInvalidPageSize.Error(),
+		},
+		{
+			name:        "internal error is hidden",
+			err:         errors.New("dial tcp: connection refused"),
+			wantCode:    response.CodeInternalError,
+			wantMessage: "internal server error",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			gqlErr := errorPresenter(context.Background(), tt.err)
+
+			assert.Equal(t, tt.wantCode, gqlErr.Extensions["code"])
+			assert.Equal(t, tt.wantMessage, gqlErr.Message)
+		})
+	}
+}
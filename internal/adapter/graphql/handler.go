@@ -0,0 +1,55 @@
+package graphql
+
+import (
+	gqlhandler "github.com/99designs/gqlgen/graphql/handler"
+	"github.com/99designs/gqlgen/graphql/handler/extension"
+	"github.com/99designs/gqlgen/graphql/handler/transport"
+	"github.com/99designs/gqlgen/graphql/playground"
+	"github.com/gin-gonic/gin"
+)
+
+// Endpoint is the path the GraphQL API is served under.
+const Endpoint = "/graphql"
+
+// HandlerConfig configures the GraphQL Handler. Both tools expose the
+// schema, so they are off unless enabled.
+type HandlerConfig struct {
+	Playground    bool // serve the GraphQL Playground at Endpoint+"/playground"
+	Introspection bool // answer __schema and __type queries
+}
+
+// Handler serves the GraphQL API alongside the REST routes.
+// Routes are registered on the shared Gin engine, so the global tracing,
+// logging, and recovery middleware apply to GraphQL requests as well.
+type Handler struct {
+	server *gqlhandler.Server
+	cfg    HandlerConfig
+}
+
+// NewHandler creates a new GraphQL Handler.
+func NewHandler(resolver *Resolver, cfg HandlerConfig) *Handler {
+	srv := gqlhandler.New(NewExecutableSchema(Config{Resolvers: resolver}))
+	srv.AddTransport(transport.GET{})
+	srv.AddTransport(transport.POST{})
+	if cfg.Introspection {
+		srv.Use(extension.Introspection{})
+	}
+	srv.SetErrorPresenter(errorPresenter)
+	srv.SetRecoverFunc(recoverFunc)
+
+	return &Handler{server: srv, cfg: cfg}
+}
+
+// Register registers GraphQL routes.
+func (h *Handler) Register(r *gin.Engine) {
+	r.GET(Endpoint, h.Query)
+	r.POST(Endpoint, h.Query)
+	if h.cfg.Playground {
+		r.GET(Endpoint+"/playground", gin.WrapH(playground.Handler("GraphQL Playground", Endpoint)))
+	}
+}
+
+// Query executes a GraphQL operation.
+func (h *Handler) Query(c *gin.Context) {
+	h.server.ServeHTTP(c.Writer, c.Request)
+}
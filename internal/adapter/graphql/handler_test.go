@@ -0,0 +1,126 @@
+package graphql_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/blackhorseya/go-ddd/internal/adapter/graphql"
+	"github.com/blackhorseya/go-ddd/pkg/healthx"
+)
+
+func init() {
+	gin.SetMode(gin.TestMode)
+}
+
+type gqlResponse struct {
+	Data struct {
+		Health struct {
+			Status string `json:"status"`
+			Checks []struct {
+				Name   string  `json:"name"`
+				Status string  `json:"status"`
+				Error  *string `json:"error"`
+			} `json:"checks"`
+		} `json:"health"`
+	} `json:"data"`
+	Errors []struct {
+		Message    string         `json:"message"`
+		Extensions map[string]any `json:"extensions"`
+	} `json:"errors"`
+}
+
+func query(t *testing.T, registry *healthx.Registry, body string, wantStatus int) gqlResponse {
+	t.Helper()
+
+	r := gin.New()
+	graphql.NewHandler(graphql.NewResolver(registry), graphql.HandlerConfig{}).Register(r)
+
+	w := httptest.NewRecorder()
+	req := httptest.NewRequest(http.MethodPost, graphql.Endpoint, strings.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+	r.ServeHTTP(w, req)
+
+	require.Equal(t, wantStatus, w.Code)
+
+	var resp gqlResponse
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &resp))
+	return resp
+}
+
+func TestHandler_Health(t *testing.T) {
+	registry := healthx.NewRegistry()
+	registry.Register(healthx.NewChecker("database", func(context.Context) error { return nil }))
+	registry.Register(healthx.NewChecker("redis", func(context.Context) error { return errors.New("refused") }))
+
+	resp := query(t, registry, `{"query":"{ health { status checks { name status error } } }"}`, http.StatusOK)
+
+	require.Empty(t, resp.Errors)
+	assert.Equal(t, string(healthx.StatusDown), resp.Data.Health.Status)
+	require.Len(t, resp.Data.Health.Checks, 2)
+	assert.Equal(t, "database", resp.Data.Health.Checks[0].Name)
+	assert.Equal(t, "redis", resp.Data.Health.Checks[1].Name)
+	require.NotNil(t, resp.Data.Health.Checks[1].Error)
+	assert.Equal(t, "refused", *resp.Data.Health.Checks[1].Error)
+}
+
+func TestHandler_ValidationError(t *testing.T) {
+	resp := query(t, nil, `{"query":"{ unknown }"}`, http.StatusUnprocessableEntity)
+
+	require.Len(t, resp.Errors, 1)
+	assert.Equal(t, "GRAPHQL_VALIDATION_FAILED", resp.Errors[0].Extensions["code"])
+}
+
+func TestHandler_DevTools(t *testing.T) {
+	tests := []struct {
+		name           string
+		cfg            graphql.HandlerConfig
+		wantPlayground int
+		wantSchema     bool
+	}{
+		{name: "off by default", wantPlayground: http.StatusNotFound},
+		{
+			name:           "enabled",
+			cfg:            graphql.HandlerConfig{Playground: true, Introspection: true},
+			wantPlayground: http.StatusOK,
+			wantSchema:     true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := gin.New()
+			graphql.NewHandler(graphql.NewResolver(nil), tt.cfg).Register(r)
+
+			w := httptest.NewRecorder()
+			r.ServeHTTP(w, httptest.NewRequest(http.MethodGet, graphql.Endpoint+"/playground", nil))
+			assert.Equal(t, tt.wantPlayground, w.Code)
+
+			w = httptest.NewRecorder()
+			req := httptest.NewRequest(http.MethodPost, graphql.Endpoint,
+				strings.NewReader(`{"query":"{ __schema { queryType { name } } }"}`))
+			req.Header.Set("Content-Type", "application/json")
+			r.ServeHTTP(w, req)
+
+			var resp struct {
+				Data   json.RawMessage `json:"data"`
+				Errors []any           `json:"errors"`
+			}
+			require.NoError(t, json.Unmarshal(w.Body.Bytes(), &resp))
+			if tt.wantSchema {
+				assert.Empty(t, resp.Errors)
+				assert.Contains(t, string(resp.Data), `"Query"`)
+			} else {
+				assert.NotEmpty(t, resp.Errors, "introspection should be rejected")
+			}
+		})
+	}
+}
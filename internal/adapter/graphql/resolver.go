@@ -0,0 +1,20 @@
+package graphql
+
+//go:generate go tool gqlgen generate --config gqlgen.yml
+
+import (
+	"github.com/blackhorseya/go-ddd/pkg/healthx"
+)
+
+// Resolver is the root resolver. It holds the dependencies shared by all resolvers.
+type Resolver struct {
+	health *healthx.Registry
+}
+
+// NewResolver creates a new root Resolver.
+func NewResolver(health *healthx.Registry) *Resolver {
+	if health == nil {
+		health = healthx.NewRegistry()
+	}
+	return &Resolver{health: health}
+}
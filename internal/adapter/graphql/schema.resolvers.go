@@ -0,0 +1,35 @@
+package graphql
+
+// This file will be automatically regenerated based on the schema, any resolver
+// implementations
+// will be copied through when generating and any unknown code will be moved to the end.
+// Code generated by github.com/99designs/gqlgen version v0.17.86
+
+import (
+	"context"
+	"sort"
+
+	"github.com/blackhorseya/go-ddd/internal/adapter/graphql/model"
+)
+
+// Health is the resolver for the health field.
+func (r *queryResolver) Health(ctx context.Context) (*model.Health, error) {
+	report := r.health.Check(ctx)
+
+	checks := make([]*model.HealthCheck, 0, len(report.Checks))
+	for name, result := range report.Checks {
+		check := &model.HealthCheck{Name: name, Status: string(result.Status)}
+		if result.Error != "" {
+			check.Error = &result.Error
+		}
+		checks = append(checks, check)
+	}
+	sort.Slice(checks, func(i, j int) bool { return checks[i].Name < checks[j].Name })
+
+	return &model.Health{Status: string(report.Status), Checks: checks}, nil
+}
+
+// Query returns QueryResolver implementation.
+func (r *Resolver) Query() QueryResolver { return &queryResolver{r} }
+
+type queryResolver struct{ *Resolver }
@@ -1,6 +1,11 @@
 package http
 
-import "time"
+import (
+	"time"
+
+	"github.com/blackhorseya/go-ddd/internal/adapter/graphql"
+	"github.com/blackhorseya/go-ddd/internal/adapter/http/middleware"
+)
 
 // ServerConfig contains HTTP server configuration.
 // This is defined in the adapter layer to avoid dependency on infrastructure layer.
@@ -9,4 +14,15 @@ type ServerConfig struct {
 	Port         int
 	ReadTimeout  time.Duration
 	WriteTimeout time.Duration
+	MaxBodySize  int64  // bytes; 0 disables the limit
+	Mode         string // gin mode; empty means release
+	Mirror       middleware.MirrorConfig
+	GraphQL      graphql.HandlerConfig
+
+	// CursorKeys sign the cursors of list endpoints; the first key signs
+	// and all keys verify. Empty leaves cursors unsigned.
+	CursorKeys [][]byte
+
+	// CursorTTL rejects cursors issued longer ago; 0 disables expiry.
+	CursorTTL time.Duration
 }
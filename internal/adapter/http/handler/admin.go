@@ -0,0 +1,66 @@
+package handler
+
+import (
+	"context"
+	"crypto/subtle"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/blackhorseya/go-ddd/internal/adapter/http/response"
+)
+
+// ConfigSetting is one effective configuration value and where it came from.
+type ConfigSetting struct {
+	Key    string `json:"key"`
+	Value  string `json:"value"`
+	Source string `json:"source"` // default, file:<path> or env:<VAR>
+}
+
+// AdminHandler serves operational endpoints guarded by a static bearer token.
+type AdminHandler struct {
+	token    string
+	settings func(ctx context.Context) ([]ConfigSetting, error)
+}
+
+// NewAdminHandler creates a new AdminHandler. settings returns the effective
+// configuration with sensitive values already masked.
+func NewAdminHandler(token string, settings func(ctx context.Context) ([]ConfigSetting, error)) *AdminHandler {
+	return &AdminHandler{token: token, settings: settings}
+}
+
+// Register registers admin routes.
+func (h *AdminHandler) Register(r gin.IRouter) {
+	g := r.Group("/admin", h.authorize)
+	g.GET("/config", h.Config)
+}
+
+func (h *AdminHandler) authorize(c *gin.Context) {
+	token, ok := strings.CutPrefix(c.GetHeader("Authorization"), "Bearer ")
+	if !ok || h.token == "" || subtle.ConstantTimeCompare([]byte(token), []byte(h.token)) != 1 {
+		response.Unauthorized(c, "invalid admin token")
+		c.Abort()
+		return
+	}
+	c.Next()
+}
+
+// Config returns the effective configuration.
+//
+//	@Summary		Show effective configuration
+//	@Description	回傳合併預設值、設定檔與環境變數後的有效設定及每個值的來源；密碼、token 等敏感值以遮罩顯示
+//	@Tags			admin
+//	@Produce		json
+//	@Security		Bearer
+//	@Success		200	{object}	response.Response{data=[]ConfigSetting}
+//	@Failure		401	{object}	response.Response
+//	@Failure		500	{object}	response.Response
+//	@Router			/admin/config [get]
+func (h *AdminHandler) Config(c *gin.Context) {
+	settings, err := h.settings(c.Request.Context())
+	if err != nil {
+		response.InternalError(c, "failed to describe configuration")
+		return
+	}
+	response.OK(c, settings)
+}
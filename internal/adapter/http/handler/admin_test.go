@@ -0,0 +1,62 @@
+package handler_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/blackhorseya/go-ddd/internal/adapter/http/handler"
+)
+
+func TestAdminHandler_Config(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	settings := []handler.ConfigSetting{
+		{Key: "database.password", Value: "******", Source: "env:APP_DATABASE_PASSWORD"},
+		{Key: "server.http.port", Value: "8080", Source: "default"},
+	}
+	r := gin.New()
+	handler.NewAdminHandler("s3cret", func(context.Context) ([]handler.ConfigSetting, error) {
+		return settings, nil
+	}).Register(r)
+
+	tests := []struct {
+		name   string
+		header string
+		want   int
+	}{
+		{name: "missing token", want: http.StatusUnauthorized},
+		{name: "wrong token", header: "Bearer nope", want: http.StatusUnauthorized},
+		{name: "valid token", header: "Bearer s3cret", want: http.StatusOK},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Arrange
+			w := httptest.NewRecorder()
+			req := httptest.NewRequest(http.MethodGet, "/admin/config", nil)
+			if tt.header != "" {
+				req.Header.Set("Authorization", tt.header)
+			}
+
+			// Act
+			r.ServeHTTP(w, req)
+
+			// Assert
+			require.Equal(t, tt.want, w.Code, w.Body.String())
+			if tt.want != http.StatusOK {
+				return
+			}
+			var body struct {
+				Data []handler.ConfigSetting `json:"data"`
+			}
+			require.NoError(t, json.Unmarshal(w.Body.Bytes(), &body))
+			assert.Equal(t, settings, body.Data)
+		})
+	}
+}
@@ -0,0 +1,148 @@
+package handler
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/blackhorseya/go-ddd/internal/adapter/http/response"
+	"github.com/blackhorseya/go-ddd/internal/application/exporter"
+)
+
+// ExportRequest is the body of an export request.
+type ExportRequest struct {
+	Format string          `json:"format" example:"csv"`                  // csv or parquet
+	Params json.RawMessage `json:"params,omitempty" swaggertype:"object"` // exporter-specific filters
+}
+
+// ExportJob is an export job with its download link once completed.
+type ExportJob struct {
+	exporter.Job
+	DownloadURL string `json:"download_url,omitempty"`
+}
+
+// ExportHandler handles async export endpoints.
+type ExportHandler struct {
+	service   *exporter.Service
+	exporters map[string]exporter.Requester
+}
+
+// NewExportHandler creates a new ExportHandler serving the given exporters by name.
+func NewExportHandler(service *exporter.Service, exporters ...exporter.Requester) *ExportHandler {
+	h := &ExportHandler{service: service, exporters: make(map[string]exporter.Requester, len(exporters))}
+	for _, e := range exporters {
+		h.exporters[e.Name()] = e
+	}
+	return h
+}
+
+// Register registers export routes.
+func (h *ExportHandler) Register(r gin.IRouter) {
+	g := r.Group("/api/v1/exports")
+	g.POST("/:exporter", h.Request)
+	g.GET("/jobs/:id", h.Status)
+	g.GET("/jobs/:id/download", h.Download)
+}
+
+// Request creates an export job.
+//
+//	@Summary		Request an export
+//	@Description	建立匯出工作，由 worker 於背景產生 CSV 或 Parquet 檔案；完成後可透過預簽章 URL 下載
+//	@Tags			exports
+//	@Accept			json
+//	@Produce		json
+//	@Param			exporter	path		string			true	"Exporter name"
+//	@Param			request		body		ExportRequest	true	"Export request"
+//	@Success		202			{object}	response.Response{data=ExportJob}
+//	@Failure		400			{object}	response.Response
+//	@Failure		404			{object}	response.Response
+//	@Router			/api/v1/exports/{exporter} [post]
+func (h *ExportHandler) Request(c *gin.Context) {
+	e, ok := h.exporters[c.Param("exporter")]
+	if !ok {
+		response.NotFound(c, "exporter not found")
+		return
+	}
+
+	var req ExportRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.BadRequest(c, "invalid request body")
+		return
+	}
+	format, err := exporter.ParseFormat(req.Format)
+	if err != nil {
+		response.BadRequest(c, "format must be csv or parquet")
+		return
+	}
+
+	job, err := e.Request(c.Request.Context(), format, req.Params)
+	if errors.Is(err, exporter.ErrUnsupportedFormat) {
+		response.BadRequest(c, err.Error())
+		return
+	}
+	if err != nil {
+		response.FromError(c, err)
+		return
+	}
+
+	response.Accepted(c, ExportJob{Job: job})
+}
+
+// Status returns the state of an export job and, once completed, its download URL.
+//
+//	@Summary		Get export job status
+//	@Description	查詢匯出工作狀態；完成時附上有時效的下載 URL
+//	@Tags			exports
+//	@Produce		json
+//	@Param			id	path		string	true	"Job ID"
+//	@Success		200	{object}	response.Response{data=ExportJob}
+//	@Failure		404	{object}	response.Response
+//	@Router			/api/v1/exports/jobs/{id} [get]
+func (h *ExportHandler) Status(c *gin.Context) {
+	ctx := c.Request.Context()
+	job, err := h.service.Get(ctx, c.Param("id"))
+	if errors.Is(err, exporter.ErrJobNotFound) {
+		response.NotFound(c, "export job not found")
+		return
+	}
+	if err != nil {
+		response.FromError(c, err)
+		return
+	}
+
+	out := ExportJob{Job: job}
+	if job.Status == exporter.StatusCompleted {
+		out.DownloadURL, err = h.service.DownloadURL(ctx, job.ID)
+		if err != nil {
+			response.FromError(c, err)
+			return
+		}
+	}
+	response.OK(c, out)
+}
+
+// Download redirects to the presigned URL of a completed export.
+//
+//	@Summary		Download an export
+//	@Description	導向匯出檔案的預簽章下載 URL
+//	@Tags			exports
+//	@Param			id	path	string	true	"Job ID"
+//	@Success		302
+//	@Failure		404	{object}	response.Response
+//	@Failure		409	{object}	response.Response
+//	@Router			/api/v1/exports/jobs/{id}/download [get]
+func (h *ExportHandler) Download(c *gin.Context) {
+	url, err := h.service.DownloadURL(c.Request.Context(), c.Param("id"))
+	switch {
+	case errors.Is(err, exporter.ErrJobNotFound):
+		response.NotFound(c, "export job not found")
+	case errors.Is(err, exporter.ErrJobNotReady):
+		response.Conflict(c, "export is not completed")
+	case err != nil:
+		response.FromError(c, err)
+	default:
+		c.Redirect(http.StatusFound, url)
+	}
+}
@@ -0,0 +1,101 @@
+package handler_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/blackhorseya/go-ddd/internal/adapter/http/handler"
+	"github.com/blackhorseya/go-ddd/internal/application/exporter"
+	"github.com/blackhorseya/go-ddd/internal/application/task"
+	"github.com/blackhorseya/go-ddd/pkg/storagex"
+)
+
+// inlineQueue runs tasks as soon as they are enqueued.
+type inlineQueue struct {
+	handlers map[string]task.HandlerFunc
+}
+
+func (q *inlineQueue) HandleFunc(taskType string, h task.HandlerFunc) { q.handlers[taskType] = h }
+
+func (q *inlineQueue) Enqueue(ctx context.Context, taskType string, payload []byte, _ task.Options) error {
+	return q.handlers[taskType](ctx, payload)
+}
+
+func TestExportHandler(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	jobs := exporter.NewMemoryJobStore()
+	files := storagex.NewLocal(t.TempDir(), "http://files.test/files", []byte("secret"))
+	q := &inlineQueue{handlers: map[string]task.HandlerFunc{}}
+
+	e := exporter.New(exporter.Definition[item]{
+		Name: "items",
+		Query: func(_ context.Context, _ json.RawMessage, emit func(item) error) error {
+			return emit(item{Name: "a"})
+		},
+		Header: []string{"name"},
+		Record: func(it item) []string { return []string{it.Name} },
+	}, jobs, files, q)
+	e.Handle(q)
+
+	r := gin.New()
+	handler.NewExportHandler(exporter.NewService(jobs, files, 0), e).Register(r)
+
+	serve := func(method, target, body string) *httptest.ResponseRecorder {
+		w := httptest.NewRecorder()
+		req := httptest.NewRequest(method, target, strings.NewReader(body))
+		req.Header.Set("Content-Type", "application/json")
+		r.ServeHTTP(w, req)
+		return w
+	}
+
+	w := serve(http.MethodPost, "/api/v1/exports/items", `{"format":"csv"}`)
+	require.Equal(t, http.StatusAccepted, w.Code, w.Body.String())
+	var started struct {
+		Data handler.ExportJob `json:"data"`
+	}
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &started))
+
+	w = serve(http.MethodGet, "/api/v1/exports/jobs/"+started.Data.ID, "")
+	require.Equal(t, http.StatusOK, w.Code)
+	var status struct {
+		Data handler.ExportJob `json:"data"`
+	}
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &status))
+	assert.Equal(t, exporter.StatusCompleted, status.Data.Status)
+	assert.Equal(t, 1, status.Data.Rows)
+	assert.True(t, strings.HasPrefix(status.Data.DownloadURL, "http://files.test/files/exports/items/"))
+
+	w = serve(http.MethodGet, "/api/v1/exports/jobs/"+started.Data.ID+"/download", "")
+	assert.Equal(t, http.StatusFound, w.Code)
+	assert.True(t, strings.HasPrefix(w.Header().Get("Location"), "http://files.test/files/exports/items/"))
+
+	require.NoError(t, jobs.Create(context.Background(), exporter.Job{ID: "pending", Status: exporter.StatusPending}))
+
+	tests := []struct {
+		name       string
+		method     string
+		target     string
+		body       string
+		wantStatus int
+	}{
+		{"unknown exporter", http.MethodPost, "/api/v1/exports/nope", `{"format":"csv"}`, http.StatusNotFound},
+		{"unknown format", http.MethodPost, "/api/v1/exports/items", `{"format":"xlsx"}`, http.StatusBadRequest},
+		{"parquet", http.MethodPost, "/api/v1/exports/items", `{"format":"parquet"}`, http.StatusAccepted},
+		{"not ready", http.MethodGet, "/api/v1/exports/jobs/pending/download", "", http.StatusConflict},
+		{"unknown job", http.MethodGet, "/api/v1/exports/jobs/nope", "", http.StatusNotFound},
+		{"unknown download", http.MethodGet, "/api/v1/exports/jobs/nope/download", "", http.StatusNotFound},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.wantStatus, serve(tt.method, tt.target, tt.body).Code)
+		})
+	}
+}
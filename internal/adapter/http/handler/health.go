@@ -1,28 +1,45 @@
 package handler
 
 import (
+	"net/http"
+	"sort"
+
 	"github.com/gin-gonic/gin"
 
 	"github.com/blackhorseya/go-ddd/internal/adapter/http/response"
+	"github.com/blackhorseya/go-ddd/pkg/healthx"
 )
 
 // HealthStatus represents the health check response.
 type HealthStatus struct {
-	Status string `json:"status"`
+	Status string                         `json:"status"`
+	Checks map[string]healthx.CheckResult `json:"checks,omitempty"`
 }
 
 // HealthHandler handles health check endpoints.
-type HealthHandler struct{}
+type HealthHandler struct {
+	registry *healthx.Registry
+	startup  *healthx.Gate
+}
 
 // NewHealthHandler creates a new HealthHandler.
-func NewHealthHandler() *HealthHandler {
-	return &HealthHandler{}
+// A nil registry means readiness has no dependency checks, and a nil gate
+// means the service is considered started immediately.
+func NewHealthHandler(registry *healthx.Registry, startup *healthx.Gate) *HealthHandler {
+	if registry == nil {
+		registry = healthx.NewRegistry()
+	}
+	if startup == nil {
+		startup = healthx.NewGate()
+	}
+	return &HealthHandler{registry: registry, startup: startup}
 }
 
 // Register registers health check routes.
 func (h *HealthHandler) Register(r *gin.Engine) {
 	r.GET("/healthz", h.Liveness)
 	r.GET("/readyz", h.Readiness)
+	r.GET("/startupz", h.Startup)
 }
 
 // Liveness handles liveness probe.
@@ -37,15 +54,72 @@ func (h *HealthHandler) Liveness(c *gin.Context) {
 	response.OK(c, HealthStatus{Status: "ok"})
 }
 
+// Startup handles startup probe.
+//
+//	@Summary		Startup probe
+//	@Description	檢查所有啟動元件（資料庫遷移、快取預熱、消費者訂閱等）是否已完成
+//	@Tags			health
+//	@Produce		json
+//	@Success		200	{object}	response.Response{data=HealthStatus}
+//	@Failure		503	{object}	response.Response
+//	@Router			/startupz [get]
+func (h *HealthHandler) Startup(c *gin.Context) {
+	if !h.startupCompleted(c) {
+		return
+	}
+
+	response.OK(c, HealthStatus{Status: "ok"})
+}
+
 // Readiness handles readiness probe.
 //
 //	@Summary		Readiness probe
-//	@Description	檢查服務是否準備好接收流量
+//	@Description	檢查服務啟動完成且其關鍵依賴（資料庫、快取等）準備好接收流量；非關鍵依賴失敗時回傳 degraded
 //	@Tags			health
 //	@Produce		json
 //	@Success		200	{object}	response.Response{data=HealthStatus}
+//	@Failure		503	{object}	response.Response
 //	@Router			/readyz [get]
 func (h *HealthHandler) Readiness(c *gin.Context) {
-	// TODO: Add dependency checks (database, cache, etc.)
-	response.OK(c, HealthStatus{Status: "ok"})
+	if !h.startupCompleted(c) {
+		return
+	}
+
+	report := h.registry.Check(c.Request.Context())
+	if !report.IsUp() {
+		details := make([]response.FieldError, 0, len(report.Checks))
+		for name, result := range report.Checks {
+			if result.Status != healthx.StatusUp && result.Severity == healthx.SeverityCritical {
+				details = append(details, response.FieldError{Field: name, Message: result.Error})
+			}
+		}
+		sort.Slice(details, func(i, j int) bool { return details[i].Field < details[j].Field })
+		response.ErrWithDetails(c, http.StatusServiceUnavailable, response.CodeServiceUnavailable,
+			"dependency check failed", details)
+		return
+	}
+
+	// Degraded dependencies are reported but keep the service in rotation
+	status := "ok"
+	if report.Status == healthx.StatusDegraded {
+		status = string(healthx.StatusDegraded)
+	}
+	response.OK(c, HealthStatus{Status: status, Checks: report.Checks})
+}
+
+// startupCompleted writes a 503 response listing pending components and
+// returns false if startup has not completed.
+func (h *HealthHandler) startupCompleted(c *gin.Context) bool {
+	pending := h.startup.Pending()
+	if len(pending) == 0 {
+		return true
+	}
+
+	details := make([]response.FieldError, 0, len(pending))
+	for _, name := range pending {
+		details = append(details, response.FieldError{Field: name, Message: "not ready"})
+	}
+	response.ErrWithDetails(c, http.StatusServiceUnavailable, response.CodeServiceUnavailable,
+		"startup in progress", details)
+	return false
 }
@@ -0,0 +1,174 @@
+package handler
+
+import (
+	"errors"
+	"io"
+	"net/http"
+	"path/filepath"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/blackhorseya/go-ddd/internal/adapter/http/response"
+	"github.com/blackhorseya/go-ddd/internal/application/importer"
+)
+
+// ImportHandler handles bulk import endpoints.
+type ImportHandler struct {
+	jobs      importer.JobStore
+	importers map[string]importer.Starter
+}
+
+// NewImportHandler creates a new ImportHandler serving the given importers by name.
+func NewImportHandler(jobs importer.JobStore, importers ...importer.Starter) *ImportHandler {
+	h := &ImportHandler{jobs: jobs, importers: make(map[string]importer.Starter, len(importers))}
+	for _, im := range importers {
+		h.importers[im.Name()] = im
+	}
+	return h
+}
+
+// Register registers import routes.
+func (h *ImportHandler) Register(r gin.IRouter) {
+	g := r.Group("/api/v1/imports")
+	g.POST("/:importer", h.Start)
+	g.GET("/jobs/:id", h.Status)
+	g.GET("/jobs/:id/errors", h.Report)
+}
+
+// Start handles an import upload.
+//
+//	@Summary		Start a bulk import
+//	@Description	上傳 CSV 或 JSONL 檔案並於背景匯入；可用 multipart 欄位 file 或直接以 request body 上傳。格式由 format 參數、副檔名或 Content-Type 判斷
+//	@Tags			imports
+//	@Accept			multipart/form-data,text/csv,application/x-ndjson
+//	@Produce		json
+//	@Param			importer	path		string	true	"Importer name"
+//	@Param			format		query		string	false	"File format (csv, jsonl)"
+//	@Param			file		formData	file	false	"Import file"
+//	@Success		202			{object}	response.Response{data=importer.Job}
+//	@Failure		400			{object}	response.Response
+//	@Failure		404			{object}	response.Response
+//	@Router			/api/v1/imports/{importer} [post]
+func (h *ImportHandler) Start(c *gin.Context) {
+	im, ok := h.importers[c.Param("importer")]
+	if !ok {
+		response.NotFound(c, "importer not found")
+		return
+	}
+
+	body, filename, err := uploadedFile(c)
+	if err != nil {
+		response.BadRequest(c, "missing import file")
+		return
+	}
+	defer body.Close()
+
+	format, err := importer.ParseFormat(detectFormat(c, filename))
+	if err != nil {
+		response.BadRequest(c, "format must be csv or jsonl")
+		return
+	}
+
+	job, err := im.Start(c.Request.Context(), format, body)
+	if errors.Is(err, importer.ErrUnsupportedFormat) {
+		response.BadRequest(c, err.Error())
+		return
+	}
+	if err != nil {
+		response.FromError(c, err)
+		return
+	}
+
+	response.Accepted(c, job)
+}
+
+// Status returns the progress of an import job.
+//
+//	@Summary		Get import job status
+//	@Description	查詢匯入工作進度（已處理、成功、失敗筆數）
+//	@Tags			imports
+//	@Produce		json
+//	@Param			id	path		string	true	"Job ID"
+//	@Success		200	{object}	response.Response{data=importer.Job}
+//	@Failure		404	{object}	response.Response
+//	@Router			/api/v1/imports/jobs/{id} [get]
+func (h *ImportHandler) Status(c *gin.Context) {
+	job, err := h.jobs.Get(c.Request.Context(), c.Param("id"))
+	if errors.Is(err, importer.ErrJobNotFound) {
+		response.NotFound(c, "import job not found")
+		return
+	}
+	if err != nil {
+		response.FromError(c, err)
+		return
+	}
+
+	response.OK(c, job)
+}
+
+// Report downloads the rejected rows of an import job as CSV.
+//
+//	@Summary		Download import error report
+//	@Description	下載匯入失敗列的錯誤報告（CSV：line, field, message）
+//	@Tags			imports
+//	@Produce		text/csv
+//	@Param			id	path		string	true	"Job ID"
+//	@Success		200	{file}		file
+//	@Failure		404	{object}	response.Response
+//	@Router			/api/v1/imports/jobs/{id}/errors [get]
+func (h *ImportHandler) Report(c *gin.Context) {
+	id := c.Param("id")
+	errs, err := h.jobs.Errors(c.Request.Context(), id)
+	if errors.Is(err, importer.ErrJobNotFound) {
+		response.NotFound(c, "import job not found")
+		return
+	}
+	if err != nil {
+		response.FromError(c, err)
+		return
+	}
+
+	c.Header("Content-Type", "text/csv; charset=utf-8")
+	c.Header("Content-Disposition", `attachment; filename="import-`+id+`-errors.csv"`)
+	c.Status(http.StatusOK)
+	_ = importer.WriteReport(c.Writer, errs)
+}
+
+// uploadedFile returns the multipart "file" field, or the raw request body
+// for non-multipart uploads.
+func uploadedFile(c *gin.Context) (io.ReadCloser, string, error) {
+	if !strings.HasPrefix(c.ContentType(), "multipart/") {
+		if c.Request.Body == nil || c.Request.ContentLength == 0 {
+			return nil, "", http.ErrMissingFile
+		}
+		return c.Request.Body, "", nil
+	}
+
+	fh, err := c.FormFile("file")
+	if err != nil {
+		return nil, "", err
+	}
+	f, err := fh.Open()
+	if err != nil {
+		return nil, "", err
+	}
+	return f, fh.Filename, nil
+}
+
+// detectFormat picks the format from the query, the file extension or the content type.
+func detectFormat(c *gin.Context, filename string) string {
+	if f := c.Query("format"); f != "" {
+		return f
+	}
+	if ext := strings.TrimPrefix(filepath.Ext(filename), "."); ext != "" {
+		return ext
+	}
+	switch c.ContentType() {
+	case "text/csv":
+		return "csv"
+	case "application/x-ndjson", "application/jsonl":
+		return "jsonl"
+	}
+	return ""
+}
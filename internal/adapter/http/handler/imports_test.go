@@ -0,0 +1,126 @@
+package handler_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"mime/multipart"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/blackhorseya/go-ddd/internal/adapter/http/handler"
+	"github.com/blackhorseya/go-ddd/internal/application/importer"
+)
+
+type noopUoW struct{}
+
+func (noopUoW) Do(ctx context.Context, fn func(ctx context.Context) error) error { return fn(ctx) }
+
+type item struct {
+	Name string `json:"name"`
+}
+
+func newImportRouter(jobs importer.JobStore) *gin.Engine {
+	gin.SetMode(gin.TestMode)
+	im := importer.New(importer.Definition[item]{
+		Name: "items",
+		FromCSV: func(fields map[string]string) (item, error) {
+			return item{Name: fields["name"]}, nil
+		},
+		Validate: func(it item) error {
+			if it.Name == "" {
+				return assert.AnError
+			}
+			return nil
+		},
+		Persist: func(context.Context, []item) error { return nil },
+	}, noopUoW{}, jobs)
+
+	r := gin.New()
+	handler.NewImportHandler(jobs, im).Register(r)
+	return r
+}
+
+func TestImportHandler(t *testing.T) {
+	jobs := importer.NewMemoryJobStore()
+	r := newImportRouter(jobs)
+
+	// Upload as multipart; the format comes from the file extension.
+	var body bytes.Buffer
+	mw := multipart.NewWriter(&body)
+	fw, err := mw.CreateFormFile("file", "items.csv")
+	require.NoError(t, err)
+	_, _ = fw.Write([]byte("name\na\n\nb\n,\n"))
+	require.NoError(t, mw.Close())
+
+	w := httptest.NewRecorder()
+	req := httptest.NewRequest(http.MethodPost, "/api/v1/imports/items", &body)
+	req.Header.Set("Content-Type", mw.FormDataContentType())
+	r.ServeHTTP(w, req)
+	require.Equal(t, http.StatusAccepted, w.Code, w.Body.String())
+
+	var started struct {
+		Data importer.Job `json:"data"`
+	}
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &started))
+	id := started.Data.ID
+
+	var status struct {
+		Data importer.Job `json:"data"`
+	}
+	require.Eventually(t, func() bool {
+		w := httptest.NewRecorder()
+		r.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/api/v1/imports/jobs/"+id, nil))
+		return w.Code == http.StatusOK &&
+			json.Unmarshal(w.Body.Bytes(), &status) == nil &&
+			status.Data.Status.IsDone()
+	}, time.Second, 10*time.Millisecond)
+	assert.Equal(t, importer.StatusCompleted, status.Data.Status)
+	assert.Equal(t, 2, status.Data.Imported)
+	assert.Equal(t, 1, status.Data.Failed)
+
+	w = httptest.NewRecorder()
+	r.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/api/v1/imports/jobs/"+id+"/errors", nil))
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, "text/csv; charset=utf-8", w.Header().Get("Content-Type"))
+	assert.True(t, strings.HasPrefix(w.Body.String(), "line,field,message\n5,,"), w.Body.String())
+}
+
+func TestImportHandler_Errors(t *testing.T) {
+	r := newImportRouter(importer.NewMemoryJobStore())
+
+	tests := []struct {
+		name        string
+		method      string
+		target      string
+		contentType string
+		body        string
+		wantStatus  int
+	}{
+		{"unknown importer", http.MethodPost, "/api/v1/imports/nope", "text/csv", "name\na\n", http.StatusNotFound},
+		{"unknown format", http.MethodPost, "/api/v1/imports/items?format=xlsx", "", "name\na\n", http.StatusBadRequest},
+		{"missing file", http.MethodPost, "/api/v1/imports/items", "text/csv", "", http.StatusBadRequest},
+		{"unknown job", http.MethodGet, "/api/v1/imports/jobs/nope", "", "", http.StatusNotFound},
+		{"unknown report", http.MethodGet, "/api/v1/imports/jobs/nope/errors", "", "", http.StatusNotFound},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			req := httptest.NewRequest(tt.method, tt.target, strings.NewReader(tt.body))
+			if tt.contentType != "" {
+				req.Header.Set("Content-Type", tt.contentType)
+			}
+			r.ServeHTTP(w, req)
+
+			assert.Equal(t, tt.wantStatus, w.Code)
+		})
+	}
+}
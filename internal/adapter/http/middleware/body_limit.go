@@ -0,0 +1,30 @@
+package middleware
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/blackhorseya/go-ddd/internal/adapter/http/response"
+)
+
+// BodyLimit returns a middleware that caps request bodies at limit bytes.
+// Requests whose Content-Length already exceeds it are rejected with 413
+// PAYLOAD_TOO_LARGE; otherwise reads past the limit fail with
+// *http.MaxBytesError. A limit of 0 or less disables the check.
+func BodyLimit(limit int64) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if limit <= 0 {
+			c.Next()
+			return
+		}
+		if c.Request.ContentLength > limit {
+			response.PayloadTooLarge(c, fmt.Sprintf("request body exceeds %d bytes", limit))
+			c.Abort()
+			return
+		}
+		c.Request.Body = http.MaxBytesReader(c.Writer, c.Request.Body, limit)
+		c.Next()
+	}
+}
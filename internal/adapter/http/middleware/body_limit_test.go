@@ -0,0 +1,70 @@
+package middleware_test
+
+import (
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/blackhorseya/go-ddd/internal/adapter/http/middleware"
+	"github.com/blackhorseya/go-ddd/internal/adapter/http/response"
+)
+
+func newBodyLimitRouter(limit int64) *gin.Engine {
+	r := gin.New()
+	r.Use(middleware.BodyLimit(limit))
+	r.POST("/upload", func(c *gin.Context) {
+		body, err := io.ReadAll(c.Request.Body)
+		if maxErr := (*http.MaxBytesError)(nil); errors.As(err, &maxErr) {
+			response.PayloadTooLarge(c, err.Error())
+			return
+		}
+		response.OK(c, len(body))
+	})
+	return r
+}
+
+func TestBodyLimit_ContentLengthTooLarge(t *testing.T) {
+	r := newBodyLimitRouter(4)
+
+	w := httptest.NewRecorder()
+	req := httptest.NewRequest(http.MethodPost, "/upload", strings.NewReader("too large"))
+	r.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusRequestEntityTooLarge, w.Code)
+
+	var resp response.Response
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &resp))
+	require.NotNil(t, resp.Error)
+	assert.Equal(t, response.CodePayloadTooLarge, resp.Error.Code)
+}
+
+func TestBodyLimit_StreamedBodyTooLarge(t *testing.T) {
+	r := newBodyLimitRouter(4)
+
+	w := httptest.NewRecorder()
+	req := httptest.NewRequest(http.MethodPost, "/upload", strings.NewReader("too large"))
+	req.ContentLength = -1
+	r.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusRequestEntityTooLarge, w.Code)
+}
+
+func TestBodyLimit_WithinLimit(t *testing.T) {
+	for _, limit := range []int64{0, 16} {
+		r := newBodyLimitRouter(limit)
+
+		w := httptest.NewRecorder()
+		req := httptest.NewRequest(http.MethodPost, "/upload", strings.NewReader("small"))
+		r.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusOK, w.Code, "limit %d", limit)
+	}
+}
@@ -0,0 +1,42 @@
+package middleware
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/blackhorseya/go-ddd/internal/adapter/http/response"
+	"github.com/blackhorseya/go-ddd/pkg/breakerx"
+)
+
+// CircuitBreaker returns a middleware that protects routes calling a downstream
+// service with the given breaker. While the breaker is open, requests are
+// rejected with 503 CIRCUIT_OPEN. Responses with status >= 500 or handler
+// errors count as failures.
+//
+// Usage:
+//
+//	payments := breakerx.New(breakerx.Settings{Name: "payment-api"})
+//	r.POST("/orders/:id/pay", middleware.CircuitBreaker(payments), h.Pay)
+func CircuitBreaker(b *breakerx.Breaker) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		done, err := b.Allow()
+		if err != nil {
+			response.CircuitOpen(c, b.Name()+" is temporarily unavailable")
+			c.Abort()
+			return
+		}
+
+		// A panicking handler counts as a failure; Recovery handles the response.
+		defer func() {
+			if rec := recover(); rec != nil {
+				done(false)
+				panic(rec)
+			}
+		}()
+
+		c.Next()
+
+		done(len(c.Errors) == 0 && c.Writer.Status() < http.StatusInternalServerError)
+	}
+}
@@ -0,0 +1,42 @@
+package middleware_test
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/blackhorseya/go-ddd/internal/adapter/http/middleware"
+	"github.com/blackhorseya/go-ddd/internal/adapter/http/response"
+	"github.com/blackhorseya/go-ddd/pkg/breakerx"
+)
+
+func TestCircuitBreaker(t *testing.T) {
+	b := breakerx.New(breakerx.Settings{Name: "payment-api", FailureThreshold: 1})
+
+	r := gin.New()
+	r.GET("/pay", middleware.CircuitBreaker(b), func(c *gin.Context) {
+		response.InternalError(c, "downstream failed")
+	})
+
+	// First failure opens the breaker.
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/pay", nil))
+	assert.Equal(t, http.StatusInternalServerError, w.Code)
+	assert.Equal(t, breakerx.StateOpen, b.State())
+
+	// Subsequent requests are rejected.
+	w = httptest.NewRecorder()
+	r.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/pay", nil))
+	assert.Equal(t, http.StatusServiceUnavailable, w.Code)
+
+	var resp response.Response
+	err := json.Unmarshal(w.Body.Bytes(), &resp)
+	require.NoError(t, err)
+	require.NotNil(t, resp.Error)
+	assert.Equal(t, response.CodeCircuitOpen, resp.Error.Code)
+}
@@ -0,0 +1,120 @@
+package middleware
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/blackhorseya/go-ddd/pkg/cachex"
+	"github.com/blackhorseya/go-ddd/pkg/contextx"
+)
+
+// HeaderXCache reports whether a response was served from the cache (HIT or MISS).
+const HeaderXCache = "X-Cache"
+
+// cacheNamespace prefixes HTTP response cache keys.
+const cacheNamespace = cachex.Namespace("http")
+
+// ResponseCacheConfig configures the ResponseCache middleware.
+type ResponseCacheConfig struct {
+	// TTL is how long a response stays cached. Zero means no expiry.
+	TTL time.Duration
+
+	// VaryHeaders are request headers that are part of the cache key, e.g.
+	// "Authorization" for per-user responses. Their values are hashed.
+	VaryHeaders []string
+
+	// Tags returns invalidation tags for a request, e.g. "order:42", so
+	// commands invalidating query results also drop cached responses.
+	Tags func(c *gin.Context) []string
+}
+
+// cachedResponse is the stored form of a response.
+type cachedResponse struct {
+	ContentType string `json:"content_type"`
+	Body        []byte `json:"body"`
+}
+
+// ResponseCache returns a middleware that caches successful (200) GET
+// responses in c, keyed by the request URI and VaryHeaders. Requests with
+// "Cache-Control: no-cache" bypass the cache. Cache failures are logged
+// and never affect the response.
+//
+// Usage:
+//
+//	r.GET("/orders/:id", middleware.ResponseCache(cache, middleware.ResponseCacheConfig{TTL: time.Minute}), h.Get)
+func ResponseCache(c cachex.Cache, cfg ResponseCacheConfig) gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		if ctx.Request.Method != http.MethodGet || ctx.GetHeader("Cache-Control") == "no-cache" {
+			ctx.Next()
+			return
+		}
+
+		logger := contextx.From(ctx.Request.Context())
+		key := responseCacheKey(ctx, cfg.VaryHeaders)
+
+		if b, err := c.Get(ctx.Request.Context(), key); err == nil {
+			var res cachedResponse
+			if err := json.Unmarshal(b, &res); err == nil {
+				ctx.Header(HeaderXCache, "HIT")
+				ctx.Data(http.StatusOK, res.ContentType, res.Body)
+				ctx.Abort()
+				return
+			}
+			logger.Warn("response cache entry corrupt", "key", key)
+		}
+
+		w := &bodyRecorder{ResponseWriter: ctx.Writer}
+		ctx.Writer = w
+		ctx.Header(HeaderXCache, "MISS")
+		ctx.Next()
+
+		if w.Status() != http.StatusOK || len(ctx.Errors) > 0 {
+			return
+		}
+		b, err := json.Marshal(cachedResponse{ContentType: w.Header().Get("Content-Type"), Body: w.body.Bytes()})
+		if err == nil {
+			var tags []string
+			if cfg.Tags != nil {
+				tags = cfg.Tags(ctx)
+			}
+			err = c.Set(ctx.Request.Context(), key, b, cfg.TTL, tags...)
+		}
+		if err != nil {
+			logger.Warn("response cache set failed", "key", key, "error", err)
+		}
+	}
+}
+
+// responseCacheKey builds the key from the request URI and the hashed vary headers.
+func responseCacheKey(c *gin.Context, vary []string) string {
+	if len(vary) == 0 {
+		return cacheNamespace.Key(c.Request.URL.RequestURI())
+	}
+	h := sha256.New()
+	for _, name := range vary {
+		h.Write([]byte(name + "=" + c.GetHeader(name) + "\n"))
+	}
+	return cacheNamespace.Key(c.Request.URL.RequestURI(), hex.EncodeToString(h.Sum(nil)))
+}
+
+// bodyRecorder copies the response body while writing it through.
+type bodyRecorder struct {
+	gin.ResponseWriter
+	body bytes.Buffer
+}
+
+func (w *bodyRecorder) Write(b []byte) (int, error) {
+	w.body.Write(b)
+	return w.ResponseWriter.Write(b)
+}
+
+func (w *bodyRecorder) WriteString(s string) (int, error) {
+	w.body.WriteString(s)
+	return w.ResponseWriter.WriteString(s)
+}
@@ -0,0 +1,69 @@
+package middleware_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/blackhorseya/go-ddd/internal/adapter/http/middleware"
+	"github.com/blackhorseya/go-ddd/pkg/cachex"
+)
+
+func TestResponseCache(t *testing.T) {
+	// Arrange
+	cache := cachex.NewMemory()
+	calls := 0
+	r := gin.New()
+	r.GET("/orders/:id", middleware.ResponseCache(cache, middleware.ResponseCacheConfig{
+		TTL:         time.Minute,
+		VaryHeaders: []string{"Authorization"},
+		Tags:        func(c *gin.Context) []string { return []string{"order:" + c.Param("id")} },
+	}), func(c *gin.Context) {
+		calls++
+		if c.Param("id") == "missing" {
+			c.JSON(http.StatusNotFound, gin.H{"error": "not found"})
+			return
+		}
+		c.JSON(http.StatusOK, gin.H{"id": c.Param("id"), "auth": c.GetHeader("Authorization")})
+	})
+
+	get := func(path, auth string, headers ...string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodGet, path, nil)
+		req.Header.Set("Authorization", auth)
+		for i := 0; i+1 < len(headers); i += 2 {
+			req.Header.Set(headers[i], headers[i+1])
+		}
+		w := httptest.NewRecorder()
+		r.ServeHTTP(w, req)
+		return w
+	}
+
+	// Act & Assert: second request is served from the cache
+	first := get("/orders/1", "alice")
+	second := get("/orders/1", "alice")
+	assert.Equal(t, "MISS", first.Header().Get(middleware.HeaderXCache))
+	assert.Equal(t, "HIT", second.Header().Get(middleware.HeaderXCache))
+	assert.Equal(t, first.Body.String(), second.Body.String())
+	assert.Equal(t, "application/json; charset=utf-8", second.Header().Get("Content-Type"))
+	assert.Equal(t, 1, calls)
+
+	// Act & Assert: vary headers and no-cache bypass the entry
+	get("/orders/1", "bob")
+	get("/orders/1", "alice", "Cache-Control", "no-cache")
+	assert.Equal(t, 3, calls)
+
+	// Act & Assert: errors are not cached
+	get("/orders/missing", "alice")
+	get("/orders/missing", "alice")
+	assert.Equal(t, 5, calls)
+
+	// Act & Assert: tag invalidation drops the entry
+	assert.NoError(t, cache.InvalidateTags(context.Background(), "order:1"))
+	assert.Equal(t, "MISS", get("/orders/1", "alice").Header().Get(middleware.HeaderXCache))
+	assert.Equal(t, 6, calls)
+}
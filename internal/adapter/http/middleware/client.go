@@ -0,0 +1,21 @@
+package middleware
+
+import (
+	"github.com/gin-gonic/gin"
+
+	"github.com/blackhorseya/go-ddd/pkg/contextx"
+)
+
+// ClientInfo returns a middleware that copies the client IP and user agent
+// into the request context, so layers below HTTP can read them through
+// contextx without depending on gin. The IP honours gin's trusted proxies.
+func ClientInfo() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		ctx := contextx.WithClientIP(c.Request.Context(), c.ClientIP())
+		if ua := c.Request.UserAgent(); ua != "" {
+			ctx = contextx.WithUserAgent(ctx, ua)
+		}
+		c.Request = c.Request.WithContext(ctx)
+		c.Next()
+	}
+}
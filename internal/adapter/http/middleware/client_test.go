@@ -0,0 +1,33 @@
+package middleware_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/blackhorseya/go-ddd/internal/adapter/http/middleware"
+	"github.com/blackhorseya/go-ddd/pkg/contextx"
+)
+
+func TestClientInfo(t *testing.T) {
+	r := gin.New()
+	r.Use(middleware.ClientInfo())
+
+	var ip, ua string
+	r.GET("/whoami", func(c *gin.Context) {
+		ctx := contextx.From(c.Request.Context())
+		ip, ua = ctx.ClientIP(), ctx.UserAgent()
+	})
+
+	w := httptest.NewRecorder()
+	req := httptest.NewRequest(http.MethodGet, "/whoami", nil)
+	req.RemoteAddr = "203.0.113.7:51234"
+	req.Header.Set("User-Agent", "curl/8.5.0")
+	r.ServeHTTP(w, req)
+
+	assert.Equal(t, "203.0.113.7", ip)
+	assert.Equal(t, "curl/8.5.0", ua)
+}
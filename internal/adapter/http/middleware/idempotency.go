@@ -0,0 +1,22 @@
+package middleware
+
+import (
+	"github.com/gin-gonic/gin"
+
+	"github.com/blackhorseya/go-ddd/pkg/contextx"
+)
+
+// HeaderIdempotencyKey is the header clients use to make retries safe.
+const HeaderIdempotencyKey = "Idempotency-Key"
+
+// IdempotencyKey returns a middleware that copies the Idempotency-Key header
+// into the request context (contextx.WithIdempotencyKey), where the bus
+// Idempotent middleware reads it and the outbox records it.
+func IdempotencyKey() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if key := c.GetHeader(HeaderIdempotencyKey); key != "" {
+			c.Request = c.Request.WithContext(contextx.WithIdempotencyKey(c.Request.Context(), key))
+		}
+		c.Next()
+	}
+}
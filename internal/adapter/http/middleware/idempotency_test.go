@@ -0,0 +1,44 @@
+package middleware_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/blackhorseya/go-ddd/internal/adapter/http/middleware"
+	"github.com/blackhorseya/go-ddd/pkg/contextx"
+)
+
+func TestIdempotencyKey(t *testing.T) {
+	tests := []struct {
+		name   string
+		header string
+		want   string
+	}{
+		{name: "header present", header: "req-123", want: "req-123"},
+		{name: "header absent", want: ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got string
+			r := gin.New()
+			r.Use(middleware.IdempotencyKey())
+			r.POST("/orders", func(c *gin.Context) {
+				got = contextx.GetIdempotencyKey(c.Request.Context())
+				c.Status(http.StatusCreated)
+			})
+
+			req := httptest.NewRequest(http.MethodPost, "/orders", nil)
+			if tt.header != "" {
+				req.Header.Set(middleware.HeaderIdempotencyKey, tt.header)
+			}
+			r.ServeHTTP(httptest.NewRecorder(), req)
+
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
@@ -29,7 +29,7 @@ func Logging() gin.HandlerFunc {
 			"method", method,
 			"path", path,
 			"query", query,
-			"ip", clientIP,
+			"client_ip", clientIP,
 			"latency", latency.String(),
 			"user_agent", c.Request.UserAgent(),
 		)
@@ -0,0 +1,190 @@
+package middleware
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"math/rand/v2"
+	"net/http"
+	"net/url"
+	"slices"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/propagation"
+
+	"github.com/blackhorseya/go-ddd/pkg/contextx"
+)
+
+const (
+	// HeaderXShadowRequest marks a request as a mirrored copy.
+	HeaderXShadowRequest = "X-Shadow-Request"
+
+	// defaultMirrorTimeout bounds how long a shadow request may run.
+	defaultMirrorTimeout = 5 * time.Second
+
+	// defaultMirrorMaxInFlight bounds concurrent shadow requests.
+	defaultMirrorMaxInFlight = 64
+)
+
+// credentialHeaders are removed from shadow requests unless listed in
+// MirrorConfig.ForwardHeaders.
+var credentialHeaders = []string{"Authorization", "Proxy-Authorization", "Cookie"}
+
+// MirrorConfig contains traffic mirroring configuration.
+type MirrorConfig struct {
+	// Upstream is the base URL of the shadow service, e.g. "http://orders-v2:8080".
+	Upstream string
+
+	// Percentage is the share of requests to mirror (0-100).
+	Percentage float64
+
+	// Timeout bounds each shadow request. Default: 5s
+	Timeout time.Duration
+
+	// MaxInFlight bounds concurrent shadow requests; requests arriving
+	// while it is reached are not mirrored. Default: 64
+	MaxInFlight int
+
+	// ForwardHeaders lists credential headers (Authorization,
+	// Proxy-Authorization, Cookie) to copy to the shadow upstream, which
+	// receives none of them by default.
+	ForwardHeaders []string
+}
+
+// Mirror returns a middleware that asynchronously replays a percentage of
+// requests to a shadow upstream. The primary response is never affected:
+// shadow responses are discarded and failures are only logged.
+// Trace context is propagated and the copy is marked with X-Shadow-Request.
+// At most MaxInFlight copies run at once and credential headers are
+// stripped unless allowed by ForwardHeaders.
+func Mirror(cfg MirrorConfig) gin.HandlerFunc {
+	upstream, err := url.Parse(cfg.Upstream)
+	if err != nil || cfg.Upstream == "" || cfg.Percentage <= 0 {
+		return func(c *gin.Context) { c.Next() }
+	}
+
+	timeout := cfg.Timeout
+	if timeout <= 0 {
+		timeout = defaultMirrorTimeout
+	}
+	client := &http.Client{Timeout: timeout}
+
+	maxInFlight := cfg.MaxInFlight
+	if maxInFlight <= 0 {
+		maxInFlight = defaultMirrorMaxInFlight
+	}
+	inFlight := make(chan struct{}, maxInFlight)
+
+	var strip []string
+	for _, h := range credentialHeaders {
+		if !slices.ContainsFunc(cfg.ForwardHeaders, func(f string) bool { return http.CanonicalHeaderKey(f) == h }) {
+			strip = append(strip, h)
+		}
+	}
+
+	return func(c *gin.Context) {
+		if rand.Float64()*100 >= cfg.Percentage {
+			c.Next()
+			return
+		}
+
+		select {
+		case inFlight <- struct{}{}:
+		default:
+			contextx.From(c.Request.Context()).Debug("shadow request dropped", "max_in_flight", maxInFlight)
+			c.Next()
+			return
+		}
+
+		var body []byte
+		if c.Request.Body != nil {
+			var err error
+			body, err = io.ReadAll(c.Request.Body)
+			if err != nil {
+				// Hand the handler what was read plus the rest, and skip mirroring.
+				c.Request.Body = readCloser{io.MultiReader(bytes.NewReader(body), c.Request.Body), c.Request.Body}
+				<-inFlight
+				c.Next()
+				return
+			}
+			c.Request.Body = io.NopCloser(bytes.NewReader(body))
+		}
+
+		// Detach from the request lifecycle so the shadow call survives the response.
+		ctx := context.WithoutCancel(c.Request.Context())
+		shadow, err := newShadowRequest(ctx, c.Request, upstream, body, strip)
+		if err != nil {
+			<-inFlight
+		} else {
+			go func() {
+				defer func() { <-inFlight }()
+				sendShadow(client, shadow)
+			}()
+		}
+
+		c.Next()
+	}
+}
+
+// readCloser reads from Reader and closes the original request body.
+type readCloser struct {
+	io.Reader
+	io.Closer
+}
+
+// newShadowRequest clones the incoming request without the strip headers
+// and targets the shadow upstream.
+func newShadowRequest(ctx context.Context, r *http.Request, upstream *url.URL, body []byte, strip []string) (*http.Request, error) {
+	target := *upstream
+	target.Path = singleJoiningSlash(upstream.Path, r.URL.Path)
+	target.RawQuery = r.URL.RawQuery
+
+	req, err := http.NewRequestWithContext(ctx, r.Method, target.String(), bytes.NewReader(body))
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header = r.Header.Clone()
+	for _, h := range strip {
+		req.Header.Del(h)
+	}
+	req.Header.Set(HeaderXShadowRequest, "true")
+	otel.GetTextMapPropagator().Inject(ctx, propagation.HeaderCarrier(req.Header))
+
+	return req, nil
+}
+
+// sendShadow performs the shadow request and discards the response.
+func sendShadow(client *http.Client, req *http.Request) {
+	resp, err := client.Do(req)
+	if err != nil {
+		contextx.From(req.Context()).Warn("shadow request failed",
+			"url", req.URL.String(),
+			"error", err,
+		)
+		return
+	}
+	defer resp.Body.Close()
+
+	_, _ = io.Copy(io.Discard, resp.Body)
+	contextx.From(req.Context()).Debug("shadow request completed",
+		"url", req.URL.String(),
+		"status", resp.StatusCode,
+	)
+}
+
+// singleJoiningSlash joins two URL paths with exactly one slash.
+func singleJoiningSlash(a, b string) string {
+	switch {
+	case a == "":
+		return b
+	case a[len(a)-1] == '/' && len(b) > 0 && b[0] == '/':
+		return a + b[1:]
+	case a[len(a)-1] != '/' && (len(b) == 0 || b[0] != '/'):
+		return a + "/" + b
+	default:
+		return a + b
+	}
+}
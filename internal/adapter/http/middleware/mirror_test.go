@@ -0,0 +1,179 @@
+package middleware_test
+
+import (
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"testing/iotest"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/blackhorseya/go-ddd/internal/adapter/http/middleware"
+)
+
+type shadowRequest struct {
+	path   string
+	query  string
+	body   string
+	header http.Header
+}
+
+func TestMirror(t *testing.T) {
+	received := make(chan shadowRequest, 1)
+	shadow := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		received <- shadowRequest{r.URL.Path, r.URL.RawQuery, string(body), r.Header.Clone()}
+		w.WriteHeader(http.StatusTeapot)
+	}))
+	defer shadow.Close()
+
+	r := gin.New()
+	r.Use(middleware.Mirror(middleware.MirrorConfig{Upstream: shadow.URL + "/v2", Percentage: 100}))
+	r.POST("/orders", func(c *gin.Context) {
+		body, _ := io.ReadAll(c.Request.Body)
+		c.String(http.StatusCreated, string(body))
+	})
+
+	w := httptest.NewRecorder()
+	req := httptest.NewRequest(http.MethodPost, "/orders?dry=1", strings.NewReader(`{"id":"1"}`))
+	r.ServeHTTP(w, req)
+
+	// Primary response is unaffected and still sees the body.
+	assert.Equal(t, http.StatusCreated, w.Code)
+	assert.JSONEq(t, `{"id":"1"}`, w.Body.String())
+
+	select {
+	case got := <-received:
+		assert.Equal(t, "/v2/orders", got.path)
+		assert.Equal(t, "dry=1", got.query)
+		assert.JSONEq(t, `{"id":"1"}`, got.body)
+		assert.Equal(t, "true", got.header.Get(middleware.HeaderXShadowRequest))
+	case <-time.After(time.Second):
+		require.Fail(t, "shadow request not received")
+	}
+}
+
+func TestMirror_Disabled(t *testing.T) {
+	r := gin.New()
+	r.Use(middleware.Mirror(middleware.MirrorConfig{}))
+	r.GET("/ok", func(c *gin.Context) {
+		c.Status(http.StatusOK)
+	})
+
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/ok", nil))
+
+	assert.Equal(t, http.StatusOK, w.Code)
+}
+
+func TestMirror_CredentialHeaders(t *testing.T) {
+	tests := []struct {
+		name    string
+		forward []string
+		want    map[string]string
+	}{
+		{
+			name: "stripped by default",
+			want: map[string]string{"Authorization": "", "Cookie": "", "X-Tenant": "acme"},
+		},
+		{
+			name:    "allow-listed header kept",
+			forward: []string{"authorization"},
+			want:    map[string]string{"Authorization": "Bearer secret", "Cookie": "", "X-Tenant": "acme"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			received := make(chan http.Header, 1)
+			shadow := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				received <- r.Header.Clone()
+			}))
+			defer shadow.Close()
+
+			r := gin.New()
+			r.Use(middleware.Mirror(middleware.MirrorConfig{
+				Upstream:       shadow.URL,
+				Percentage:     100,
+				ForwardHeaders: tt.forward,
+			}))
+			r.GET("/orders", func(c *gin.Context) { c.Status(http.StatusOK) })
+
+			req := httptest.NewRequest(http.MethodGet, "/orders", nil)
+			req.Header.Set("Authorization", "Bearer secret")
+			req.Header.Set("Cookie", "session=abc")
+			req.Header.Set("X-Tenant", "acme")
+			r.ServeHTTP(httptest.NewRecorder(), req)
+
+			select {
+			case got := <-received:
+				for k, v := range tt.want {
+					assert.Equal(t, v, got.Get(k), k)
+				}
+			case <-time.After(time.Second):
+				require.Fail(t, "shadow request not received")
+			}
+		})
+	}
+}
+
+func TestMirror_MaxInFlight(t *testing.T) {
+	release := make(chan struct{})
+	var received atomic.Int32
+	shadow := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		received.Add(1)
+		<-release
+	}))
+	defer shadow.Close()
+	defer close(release)
+
+	r := gin.New()
+	r.Use(middleware.Mirror(middleware.MirrorConfig{Upstream: shadow.URL, Percentage: 100, MaxInFlight: 1}))
+	r.GET("/orders", func(c *gin.Context) { c.Status(http.StatusOK) })
+
+	for range 3 {
+		w := httptest.NewRecorder()
+		r.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/orders", nil))
+		assert.Equal(t, http.StatusOK, w.Code)
+	}
+
+	require.Eventually(t, func() bool { return received.Load() == 1 }, time.Second, 10*time.Millisecond)
+	time.Sleep(50 * time.Millisecond)
+	assert.Equal(t, int32(1), received.Load(), "requests over MaxInFlight should be dropped")
+}
+
+func TestMirror_BodyReadError(t *testing.T) {
+	var received atomic.Int32
+	shadow := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		received.Add(1)
+	}))
+	defer shadow.Close()
+
+	errBoom := errors.New("boom")
+	var gotBody string
+	var gotErr error
+	r := gin.New()
+	r.Use(middleware.Mirror(middleware.MirrorConfig{Upstream: shadow.URL, Percentage: 100}))
+	r.POST("/orders", func(c *gin.Context) {
+		body, err := io.ReadAll(c.Request.Body)
+		gotBody, gotErr = string(body), err
+		c.Status(http.StatusBadRequest)
+	})
+
+	w := httptest.NewRecorder()
+	body := io.MultiReader(strings.NewReader(`{"id"`), iotest.ErrReader(errBoom))
+	r.ServeHTTP(w, httptest.NewRequest(http.MethodPost, "/orders", body))
+
+	// The handler still sees the bytes read so far and the read error.
+	assert.Equal(t, `{"id"`, gotBody)
+	require.ErrorIs(t, gotErr, errBoom)
+	time.Sleep(50 * time.Millisecond)
+	assert.Zero(t, received.Load(), "a partially read request should not be mirrored")
+}
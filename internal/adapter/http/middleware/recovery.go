@@ -0,0 +1,59 @@
+package middleware
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"runtime/debug"
+
+	"github.com/gin-gonic/gin"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+
+	"github.com/blackhorseya/go-ddd/internal/adapter/http/response"
+	"github.com/blackhorseya/go-ddd/pkg/contextx"
+)
+
+// Recovery returns a middleware that recovers from panics.
+// It logs the panic with its stack trace, records the error on the active span,
+// and responds with the standard INTERNAL_ERROR envelope.
+// http.ErrAbortHandler is re-panicked so net/http aborts the connection.
+// Register it after Tracing and Logging, so the recovered panic marks the
+// request span and Logging reports the 500, and before the remaining
+// middleware so panics there are recovered too.
+func Recovery() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		defer func() {
+			rec := recover()
+			if rec == nil {
+				return
+			}
+
+			err, ok := rec.(error)
+			if !ok {
+				err = fmt.Errorf("%v", rec)
+			}
+			if errors.Is(err, http.ErrAbortHandler) {
+				panic(rec)
+			}
+
+			reqCtx := c.Request.Context()
+			span := trace.SpanFromContext(reqCtx)
+			span.RecordError(err, trace.WithStackTrace(true))
+			span.SetStatus(codes.Error, "panic recovered")
+
+			contextx.From(reqCtx).Error("panic recovered",
+				"trace_id", contextx.GetTraceID(reqCtx),
+				"error", err,
+				"method", c.Request.Method,
+				"path", c.Request.URL.Path,
+				"stack", string(debug.Stack()),
+			)
+
+			response.InternalError(c, "internal server error")
+			c.Abort()
+		}()
+
+		c.Next()
+	}
+}
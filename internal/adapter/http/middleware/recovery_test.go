@@ -0,0 +1,83 @@
+package middleware_test
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/blackhorseya/go-ddd/internal/adapter/http/middleware"
+	"github.com/blackhorseya/go-ddd/internal/adapter/http/response"
+)
+
+func init() {
+	gin.SetMode(gin.TestMode)
+}
+
+func TestRecovery(t *testing.T) {
+	r := gin.New()
+	r.Use(middleware.Recovery())
+	r.GET("/panic", func(c *gin.Context) {
+		panic("boom")
+	})
+
+	w := httptest.NewRecorder()
+	req := httptest.NewRequest(http.MethodGet, "/panic", nil)
+	r.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusInternalServerError, w.Code)
+
+	var resp response.Response
+	err := json.Unmarshal(w.Body.Bytes(), &resp)
+	require.NoError(t, err)
+
+	assert.False(t, resp.Success)
+	require.NotNil(t, resp.Error)
+	assert.Equal(t, response.CodeInternalError, resp.Error.Code)
+}
+
+func TestRecovery_NoPanic(t *testing.T) {
+	r := gin.New()
+	r.Use(middleware.Recovery())
+	r.GET("/ok", func(c *gin.Context) {
+		response.OK(c, nil)
+	})
+
+	w := httptest.NewRecorder()
+	req := httptest.NewRequest(http.MethodGet, "/ok", nil)
+	r.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+}
+
+func TestRecovery_ErrAbortHandler(t *testing.T) {
+	r := gin.New()
+	r.Use(middleware.Recovery())
+	r.GET("/abort", func(c *gin.Context) {
+		panic(http.ErrAbortHandler)
+	})
+
+	w := httptest.NewRecorder()
+	req := httptest.NewRequest(http.MethodGet, "/abort", nil)
+
+	assert.PanicsWithValue(t, http.ErrAbortHandler, func() { r.ServeHTTP(w, req) })
+}
+
+func TestRecovery_WrapsLaterMiddleware(t *testing.T) {
+	r := gin.New()
+	r.Use(middleware.Recovery())
+	r.Use(func(c *gin.Context) { panic("middleware boom") })
+	r.GET("/ok", func(c *gin.Context) {
+		response.OK(c, nil)
+	})
+
+	w := httptest.NewRecorder()
+	req := httptest.NewRequest(http.MethodGet, "/ok", nil)
+	r.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusInternalServerError, w.Code)
+}
@@ -0,0 +1,31 @@
+package middleware
+
+import (
+	"github.com/gin-gonic/gin"
+
+	"github.com/blackhorseya/go-ddd/pkg/contextx"
+	"github.com/blackhorseya/go-ddd/pkg/idx"
+)
+
+// RequestID returns a middleware that reads the request and correlation
+// IDs from the canonical headers (contextx.ExtractHTTP), generating a
+// request ID when the caller sent none, and echoes it in X-Request-ID.
+//
+// X-User-ID and X-Tenant-ID are removed first: clients must not assert
+// their own identity, which the authentication middleware sets instead.
+func RequestID() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		c.Request.Header.Del(contextx.HeaderUserID)
+		c.Request.Header.Del(contextx.HeaderTenantID)
+
+		ctx := contextx.ExtractHTTP(c.Request.Context(), c.Request.Header)
+		requestID := contextx.GetRequestID(ctx)
+		if requestID == "" {
+			requestID = idx.ULID()
+			ctx = contextx.WithRequestID(ctx, requestID)
+		}
+		c.Request = c.Request.WithContext(ctx)
+		c.Header(contextx.HeaderRequestID, requestID)
+		c.Next()
+	}
+}
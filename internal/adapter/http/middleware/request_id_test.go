@@ -0,0 +1,62 @@
+package middleware_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/blackhorseya/go-ddd/internal/adapter/http/middleware"
+	"github.com/blackhorseya/go-ddd/pkg/contextx"
+)
+
+func TestRequestID(t *testing.T) {
+	tests := []struct {
+		name        string
+		headers     map[string]string
+		wantRequest string
+		wantCorr    string
+	}{
+		{
+			name:        "propagated",
+			headers:     map[string]string{"X-Request-ID": "req-1", "X-Correlation-ID": "corr-1"},
+			wantRequest: "req-1",
+			wantCorr:    "corr-1",
+		},
+		{name: "generated"},
+		{
+			name:    "identity headers are ignored",
+			headers: map[string]string{"X-User-ID": "admin", "X-Tenant-ID": "other"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got *contextx.Contextx
+			r := gin.New()
+			r.Use(middleware.RequestID())
+			r.GET("/orders", func(c *gin.Context) {
+				got = contextx.From(c.Request.Context())
+			})
+
+			w := httptest.NewRecorder()
+			req := httptest.NewRequest(http.MethodGet, "/orders", nil)
+			for k, v := range tt.headers {
+				req.Header.Set(k, v)
+			}
+			r.ServeHTTP(w, req)
+
+			if tt.wantRequest != "" {
+				assert.Equal(t, tt.wantRequest, got.RequestID())
+			} else {
+				assert.NotEmpty(t, got.RequestID())
+			}
+			assert.Equal(t, got.RequestID(), w.Header().Get(contextx.HeaderRequestID))
+			assert.Equal(t, tt.wantCorr, got.CorrelationID())
+			assert.Empty(t, got.UserID())
+			assert.Empty(t, got.TenantID())
+		})
+	}
+}
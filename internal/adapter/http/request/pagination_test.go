@@ -0,0 +1,157 @@
+package request_test
+
+import (
+	"net/url"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/blackhorseya/go-ddd/internal/adapter/http/request"
+	"github.com/blackhorseya/go-ddd/internal/domain"
+)
+
+func TestNewPageRequestFromQuery(t *testing.T) {
+	tests := []struct {
+		name         string
+		query        string
+		wantPage     int
+		wantPageSize int
+		wantSort     []domain.SortOption
+		wantErr      error
+	}{
+		{
+			name:         "defaults",
+			query:        "",
+			wantPage:     domain.DefaultPage,
+			wantPageSize: domain.DefaultPageSize,
+		},
+		{
+			name:         "page, size and sort",
+			query:        "page=3&page_size=50&sort=-created_at,id",
+			wantPage:     3,
+			wantPageSize: 50,
+			wantSort: []domain.SortOption{
+				domain.NewSortOption("created_at", domain.SortDesc),
+				domain.NewSortOption("id", domain.SortAsc),
+			},
+		},
+		{
+			name:    "non-numeric page",
+			query:   "page=abc",
+			wantErr: domain.ErrInvalidPage,
+		},
+		{
+			name:    "page size too large",
+			query:   "page_size=5000",
+			wantErr: domain.ErrInvalidPageSize,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			values, err := url.ParseQuery(tt.query)
+			require.NoError(t, err)
+
+			req, err := request.NewPageRequestFromQuery(values)
+
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.wantPage, req.Page())
+			assert.Equal(t, tt.wantPageSize, req.PageSize())
+			assert.Equal(t, tt.wantSort, req.Sort())
+		})
+	}
+}
+
+func TestNewCursorRequestFromQuery(t *testing.T) {
+	cursor := domain.EncodeCursor("2024-01-01T00:00:00Z", "abc")
+
+	req, err := request.NewCursorRequestFromQuery(url.Values{
+		request.QueryCursor:   {cursor},
+		request.QueryPageSize: {"10"},
+		request.QuerySort:     {"-created_at"},
+	}, domain.CursorCodec{})
+
+	require.NoError(t, err)
+	assert.Equal(t, cursor, req.Cursor())
+	assert.Equal(t, 10, req.PageSize())
+	assert.Equal(t, []domain.SortOption{domain.NewSortOption("created_at", domain.SortDesc)}, req.Sort())
+}
+
+func TestNewCursorRequestFromQuery_InvalidCursor(t *testing.T) {
+	_, err := request.NewCursorRequestFromQuery(url.Values{request.QueryCursor: {"%%%"}}, domain.CursorCodec{})
+
+	assert.ErrorIs(t, err, domain.ErrInvalidCursor)
+}
+
+func TestParseSort(t *testing.T) {
+	got := request.ParseSort([]string{"name, -", "-updated_at"})
+
+	assert.Equal(t, []domain.SortOption{
+		domain.NewSortOption("name", domain.SortAsc),
+		domain.NewSortOption("updated_at", domain.SortDesc),
+	}, got)
+}
+
+func TestNewCursorRequestFromQuery_Signed(t *testing.T) {
+	codec := domain.NewCursorCodec(request.CursorVersion, 0, []byte("secret"))
+	signed, err := codec.Encode("abc")
+	require.NoError(t, err)
+
+	req, err := request.NewCursorRequestFromQuery(url.Values{request.QueryCursor: {signed}}, codec)
+	require.NoError(t, err)
+	assert.Equal(t, domain.EncodeCursor("abc"), req.Cursor(), "cursor is handed on in plain form")
+
+	next, err := req.NextCursor("def")
+	require.NoError(t, err)
+	values, err := codec.Decode(next)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"def"}, values, "next cursor is encoded with the codec")
+
+	_, err = request.NewCursorRequestFromQuery(url.Values{request.QueryCursor: {domain.EncodeCursor("abc")}}, codec)
+	assert.ErrorIs(t, err, domain.ErrInvalidCursor)
+}
+
+func TestNewCursorRequestFromPageOrCursor(t *testing.T) {
+	cursor := domain.EncodeCursor("abc")
+
+	tests := []struct {
+		name       string
+		values     url.Values
+		wantCursor string
+		wantOffset int
+	}{
+		{
+			name:       "cursor",
+			values:     url.Values{request.QueryCursor: {cursor}, request.QueryPage: {"2"}},
+			wantCursor: cursor,
+		},
+		{
+			name:       "page converted to offset cursor",
+			values:     url.Values{request.QueryPage: {"3"}, request.QueryPageSize: {"10"}},
+			wantCursor: domain.EncodeOffsetCursor(20),
+			wantOffset: 20,
+		},
+		{
+			name: "neither",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req, err := request.NewCursorRequestFromPageOrCursor(tt.values, domain.CursorCodec{})
+
+			require.NoError(t, err)
+			assert.Equal(t, tt.wantCursor, req.Cursor())
+			if domain.IsOffsetCursor(req.Cursor()) {
+				offset, err := domain.DecodeOffsetCursor(req.Cursor())
+				require.NoError(t, err)
+				assert.Equal(t, tt.wantOffset, offset)
+			}
+		})
+	}
+}
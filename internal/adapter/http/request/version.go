@@ -0,0 +1,35 @@
+package request
+
+import (
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/blackhorseya/go-ddd/internal/domain"
+)
+
+// HeaderIfMatch carries the aggregate version the client last read.
+const HeaderIfMatch = "If-Match"
+
+// IfMatchVersion returns the aggregate version from the If-Match header,
+// accepting both "3" and W/"3". ok is false when the header is absent.
+// A malformed header returns domain.ErrVersionMismatch, which maps to 412.
+//
+// Pair it with response.SetETag on reads and AggregateRoot.CheckVersion on writes.
+func IfMatchVersion(r *http.Request) (version int, ok bool, err error) {
+	raw := strings.TrimSpace(r.Header.Get(HeaderIfMatch))
+	if raw == "" {
+		return 0, false, nil
+	}
+
+	raw = strings.TrimPrefix(raw, "W/")
+	unquoted, err := strconv.Unquote(raw)
+	if err != nil {
+		return 0, true, domain.ErrVersionMismatch
+	}
+	version, err = strconv.Atoi(unquoted)
+	if err != nil {
+		return 0, true, domain.ErrVersionMismatch
+	}
+	return version, true, nil
+}
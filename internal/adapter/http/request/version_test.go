@@ -0,0 +1,43 @@
+package request_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/blackhorseya/go-ddd/internal/adapter/http/request"
+	"github.com/blackhorseya/go-ddd/internal/domain"
+)
+
+func TestIfMatchVersion(t *testing.T) {
+	tests := []struct {
+		name    string
+		header  string
+		want    int
+		wantOK  bool
+		wantErr error
+	}{
+		{name: "absent"},
+		{name: "strong", header: `"3"`, want: 3, wantOK: true},
+		{name: "weak", header: `W/"7"`, want: 7, wantOK: true},
+		{name: "unquoted", header: `3`, wantOK: true, wantErr: domain.ErrVersionMismatch},
+		{name: "not a version", header: `"abc"`, wantOK: true, wantErr: domain.ErrVersionMismatch},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := httptest.NewRequest(http.MethodPut, "/orders/1", nil)
+			if tt.header != "" {
+				r.Header.Set(request.HeaderIfMatch, tt.header)
+			}
+
+			got, ok, err := request.IfMatchVersion(r)
+
+			assert.ErrorIs(t, err, tt.wantErr)
+			assert.Equal(t, tt.wantOK, ok)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
@@ -10,14 +10,18 @@ import (
 // These codes can be used as i18n keys by frontend applications.
 const (
 	// General errors
-	CodeInternalError    = "INTERNAL_ERROR"
-	CodeBadRequest       = "BAD_REQUEST"
-	CodeUnauthorized     = "UNAUTHORIZED"
-	CodeForbidden        = "FORBIDDEN"
-	CodeNotFound         = "NOT_FOUND"
-	CodeConflict         = "CONFLICT"
-	CodeValidationFailed = "VALIDATION_FAILED"
-	CodeTooManyRequests  = "TOO_MANY_REQUESTS"
+	CodeInternalError      = "INTERNAL_ERROR"
+	CodeBadRequest         = "BAD_REQUEST"
+	CodeUnauthorized       = "UNAUTHORIZED"
+	CodeForbidden          = "FORBIDDEN"
+	CodeNotFound           = "NOT_FOUND"
+	CodeConflict           = "CONFLICT"
+	CodePreconditionFailed = "PRECONDITION_FAILED"
+	CodePayloadTooLarge    = "PAYLOAD_TOO_LARGE"
+	CodeValidationFailed   = "VALIDATION_FAILED"
+	CodeTooManyRequests    = "TOO_MANY_REQUESTS"
+	CodeServiceUnavailable = "SERVICE_UNAVAILABLE"
+	CodeCircuitOpen        = "CIRCUIT_OPEN"
 
 	// Resource-specific patterns (examples)
 	// Use format: {RESOURCE}_{ACTION}_{REASON}
@@ -54,11 +58,31 @@ func Conflict(c *gin.Context, message string) {
 	Err(c, http.StatusConflict, CodeConflict, message)
 }
 
+// PreconditionFailed sends a 412 Precondition Failed response.
+func PreconditionFailed(c *gin.Context, message string) {
+	Err(c, http.StatusPreconditionFailed, CodePreconditionFailed, message)
+}
+
+// PayloadTooLarge sends a 413 Request Entity Too Large response.
+func PayloadTooLarge(c *gin.Context, message string) {
+	Err(c, http.StatusRequestEntityTooLarge, CodePayloadTooLarge, message)
+}
+
 // TooManyRequests sends a 429 Too Many Requests response.
 func TooManyRequests(c *gin.Context, message string) {
 	Err(c, http.StatusTooManyRequests, CodeTooManyRequests, message)
 }
 
+// ServiceUnavailable sends a 503 Service Unavailable response.
+func ServiceUnavailable(c *gin.Context, message string) {
+	Err(c, http.StatusServiceUnavailable, CodeServiceUnavailable, message)
+}
+
+// CircuitOpen sends a 503 response indicating a downstream circuit breaker is open.
+func CircuitOpen(c *gin.Context, message string) {
+	Err(c, http.StatusServiceUnavailable, CodeCircuitOpen, message)
+}
+
 // InternalError sends a 500 Internal Server Error response.
 func InternalError(c *gin.Context, message string) {
 	Err(c, http.StatusInternalServerError, CodeInternalError, message)
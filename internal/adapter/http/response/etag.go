@@ -0,0 +1,13 @@
+package response
+
+import (
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+)
+
+// SetETag sets the ETag header to the aggregate version, e.g. "3".
+// Clients send it back in If-Match so lost updates are rejected with 412.
+func SetETag(c *gin.Context, version int) {
+	c.Header("ETag", strconv.Quote(strconv.Itoa(version)))
+}
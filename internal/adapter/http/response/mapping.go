@@ -0,0 +1,107 @@
+package response
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/blackhorseya/go-ddd/internal/application/result"
+	"github.com/blackhorseya/go-ddd/internal/domain"
+	"github.com/blackhorseya/go-ddd/internal/domain/valueobject"
+	"github.com/blackhorseya/go-ddd/pkg/errorx"
+)
+
+// errorMapping maps a known error to its HTTP status and error code.
+type errorMapping struct {
+	target error
+	status int
+	code   string
+}
+
+// errorMappings is the domain error mapping table shared by all adapters.
+// Errors are matched with errors.Is in order; unknown errors map to INTERNAL_ERROR.
+var errorMappings = []errorMapping{
+	{domain.ErrNotFound, http.StatusNotFound, CodeNotFound},
+	{domain.ErrInvalidPage, http.StatusBadRequest, CodeBadRequest},
+	{domain.ErrInvalidPageSize, http.StatusBadRequest, CodeBadRequest},
+	{domain.ErrInvalidCursor, http.StatusBadRequest, CodeBadRequest},
+	{domain.ErrInvalidSortField, http.StatusBadRequest, CodeBadRequest},
+	{domain.ErrValidation, http.StatusBadRequest, CodeValidationFailed},
+	{domain.ErrInvalidTransition, http.StatusConflict, CodeConflict},
+	{domain.ErrConcurrentModification, http.StatusConflict, CodeConflict},
+	{domain.ErrVersionMismatch, http.StatusPreconditionFailed, CodePreconditionFailed},
+	{valueobject.ErrInvalidEmail, http.StatusBadRequest, CodeValidationFailed},
+	{valueobject.ErrInvalidPhone, http.StatusBadRequest, CodeValidationFailed},
+	{valueobject.ErrInvalidURL, http.StatusBadRequest, CodeValidationFailed},
+	{valueobject.ErrInvalidPercentage, http.StatusBadRequest, CodeValidationFailed},
+	{valueobject.ErrInvalidCurrency, http.StatusBadRequest, CodeValidationFailed},
+	{valueobject.ErrCurrencyMismatch, http.StatusBadRequest, CodeBadRequest},
+	{valueobject.ErrInvalidEnum, http.StatusBadRequest, CodeValidationFailed},
+}
+
+// Classify returns the HTTP status and error code for err.
+// Typed errors (errorx.Error) map by kind and carry their own code;
+// other errors are looked up in the mapping table.
+func Classify(err error) (status int, code string) {
+	if e, ok := errorx.As(err); ok {
+		return e.Kind().HTTPStatus(), e.Code()
+	}
+	for _, m := range errorMappings {
+		if errors.Is(err, m.target) {
+			return m.status, m.code
+		}
+	}
+	return http.StatusInternalServerError, CodeInternalError
+}
+
+// SafeMessage returns a message for err that is safe to expose to clients.
+// Internal errors are replaced with a generic message, and typed errors
+// return their message without the wrapped cause.
+func SafeMessage(err error) string {
+	if status, _ := Classify(err); status >= http.StatusInternalServerError {
+		return "internal server error"
+	}
+	if e, ok := errorx.As(err); ok {
+		return e.Message()
+	}
+	if errors.Is(err, domain.ErrValidation) {
+		return domain.ErrValidation.Error()
+	}
+	return err.Error()
+}
+
+// Details returns the field errors carried by err, if it wraps a
+// *domain.ValidationError.
+func Details(err error) []FieldError {
+	var ve *domain.ValidationError
+	if !errors.As(err, &ve) {
+		return nil
+	}
+
+	details := make([]FieldError, len(ve.Violations()))
+	for i, v := range ve.Violations() {
+		details[i] = FieldError{Field: v.Field(), Rule: v.Rule(), Message: v.Message()}
+	}
+	return details
+}
+
+// FromError sends an error response derived from err using the mapping table.
+func FromError(c *gin.Context, err error) {
+	status, code := Classify(err)
+	if details := Details(err); len(details) > 0 {
+		ErrWithDetails(c, status, code, SafeMessage(err), details)
+		return
+	}
+	Err(c, status, code, SafeMessage(err))
+}
+
+// FromResult sends the value of a successful result with 200 OK, or the
+// error response for a failed one.
+func FromResult[T any](c *gin.Context, r result.Result[T]) {
+	if err := r.Err(); err != nil {
+		FromError(c, err)
+		return
+	}
+	OK(c, r.Value())
+}
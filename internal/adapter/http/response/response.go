@@ -9,6 +9,7 @@ import (
 	"github.com/gin-gonic/gin"
 
 	"github.com/blackhorseya/go-ddd/pkg/contextx"
+	"github.com/blackhorseya/go-ddd/pkg/mapx"
 )
 
 // Response represents a unified API response structure.
@@ -24,14 +25,25 @@ type Meta struct {
 	TraceID    string      `json:"trace_id,omitempty"`
 	Timestamp  time.Time   `json:"timestamp"`
 	Pagination *Pagination `json:"pagination,omitempty"`
+	Cursor     *Cursor     `json:"cursor,omitempty"`
 }
 
 // Pagination contains pagination information for list responses.
+// TotalUnknown is set when the total was not counted; Total and TotalPages are then 0.
 type Pagination struct {
-	Page       int `json:"page"`
-	PageSize   int `json:"page_size"`
-	Total      int `json:"total"`
-	TotalPages int `json:"total_pages"`
+	Page         int  `json:"page"`
+	PageSize     int  `json:"page_size"`
+	Total        int  `json:"total"`
+	TotalPages   int  `json:"total_pages"`
+	HasNext      bool `json:"has_next"`
+	TotalUnknown bool `json:"total_unknown,omitempty"`
+}
+
+// Cursor contains cursor pagination information for list responses.
+type Cursor struct {
+	NextCursor string `json:"next_cursor,omitempty"`
+	PrevCursor string `json:"prev_cursor,omitempty"`
+	HasMore    bool   `json:"has_more"`
 }
 
 // Error represents an error response.
@@ -44,6 +56,7 @@ type Error struct {
 // FieldError represents a validation error for a specific field.
 type FieldError struct {
 	Field   string `json:"field"`
+	Rule    string `json:"rule,omitempty"`
 	Message string `json:"message"`
 }
 
@@ -74,6 +87,15 @@ func Created(c *gin.Context, data any) {
 	})
 }
 
+// Accepted sends a 202 Accepted response with data, for work that completes asynchronously.
+func Accepted(c *gin.Context, data any) {
+	c.JSON(http.StatusAccepted, Response{
+		Success: true,
+		Data:    data,
+		Meta:    newMeta(c),
+	})
+}
+
 // NoContent sends a 204 No Content response.
 func NoContent(c *gin.Context) {
 	c.Status(http.StatusNoContent)
@@ -92,6 +114,25 @@ func List(c *gin.Context, data any, page, pageSize, total int) {
 		PageSize:   pageSize,
 		Total:      total,
 		TotalPages: totalPages,
+		HasNext:    page < totalPages,
+	}
+
+	c.JSON(http.StatusOK, Response{
+		Success: true,
+		Data:    data,
+		Meta:    meta,
+	})
+}
+
+// ListWithoutTotal sends a paginated response whose total was not counted.
+// hasNext is typically domain.PageResult.HasNext.
+func ListWithoutTotal(c *gin.Context, data any, page, pageSize int, hasNext bool) {
+	meta := newMeta(c)
+	meta.Pagination = &Pagination{
+		Page:         page,
+		PageSize:     pageSize,
+		HasNext:      hasNext,
+		TotalUnknown: true,
 	}
 
 	c.JSON(http.StatusOK, Response{
@@ -101,6 +142,36 @@ func List(c *gin.Context, data any, page, pageSize, total int) {
 	})
 }
 
+// CursorList sends a successful response with cursor-paginated data.
+func CursorList(c *gin.Context, data any, nextCursor, prevCursor string, hasMore bool) {
+	meta := newMeta(c)
+	meta.Cursor = &Cursor{
+		NextCursor: nextCursor,
+		PrevCursor: prevCursor,
+		HasMore:    hasMore,
+	}
+
+	c.JSON(http.StatusOK, Response{
+		Success: true,
+		Data:    data,
+		Meta:    meta,
+	})
+}
+
+// PageList sends a converted offset page, omitting the total when it was not counted.
+func PageList[T any](c *gin.Context, p mapx.Page[T]) {
+	if !p.HasTotal {
+		ListWithoutTotal(c, p.Items, p.Page, p.PageSize, p.HasNext)
+		return
+	}
+	List(c, p.Items, p.Page, p.PageSize, int(p.TotalItems))
+}
+
+// CursorPage sends a converted cursor page.
+func CursorPage[T any](c *gin.Context, p mapx.Cursor[T]) {
+	CursorList(c, p.Items, p.NextCursor, p.PrevCursor, p.HasMore)
+}
+
 // Err sends an error response with the given HTTP status code.
 func Err(c *gin.Context, status int, code, message string) {
 	c.JSON(status, Response{
@@ -2,8 +2,11 @@ package response_test
 
 import (
 	"encoding/json"
+	"errors"
+	"fmt"
 	"net/http"
 	"net/http/httptest"
+	"strconv"
 	"testing"
 
 	"github.com/gin-gonic/gin"
@@ -11,7 +14,11 @@ import (
 	"github.com/stretchr/testify/require"
 
 	"github.com/blackhorseya/go-ddd/internal/adapter/http/response"
+	"github.com/blackhorseya/go-ddd/internal/application/result"
+	"github.com/blackhorseya/go-ddd/internal/domain"
 	"github.com/blackhorseya/go-ddd/pkg/contextx"
+	"github.com/blackhorseya/go-ddd/pkg/errorx"
+	"github.com/blackhorseya/go-ddd/pkg/mapx"
 )
 
 func init() {
@@ -98,6 +105,8 @@ func TestList(t *testing.T) {
 	assert.Equal(t, 10, resp.Meta.Pagination.PageSize)
 	assert.Equal(t, 25, resp.Meta.Pagination.Total)
 	assert.Equal(t, 3, resp.Meta.Pagination.TotalPages)
+	assert.True(t, resp.Meta.Pagination.HasNext)
+	assert.False(t, resp.Meta.Pagination.TotalUnknown)
 }
 
 func TestList_ZeroPageSize(t *testing.T) {
@@ -113,6 +122,88 @@ func TestList_ZeroPageSize(t *testing.T) {
 	assert.Equal(t, 0, resp.Meta.Pagination.TotalPages)
 }
 
+func TestListWithoutTotal(t *testing.T) {
+	c, w := setupTestContext()
+
+	response.ListWithoutTotal(c, []string{"a"}, 2, 10, true)
+
+	var resp response.Response
+	err := json.Unmarshal(w.Body.Bytes(), &resp)
+	require.NoError(t, err)
+
+	require.NotNil(t, resp.Meta.Pagination)
+	assert.Equal(t, 2, resp.Meta.Pagination.Page)
+	assert.Equal(t, 10, resp.Meta.Pagination.PageSize)
+	assert.True(t, resp.Meta.Pagination.HasNext)
+	assert.True(t, resp.Meta.Pagination.TotalUnknown)
+	assert.Equal(t, 0, resp.Meta.Pagination.Total)
+}
+
+func TestCursorList(t *testing.T) {
+	c, w := setupTestContext()
+
+	response.CursorList(c, []string{"a"}, "next", "", true)
+
+	var resp response.Response
+	err := json.Unmarshal(w.Body.Bytes(), &resp)
+	require.NoError(t, err)
+
+	assert.Nil(t, resp.Meta.Pagination)
+	require.NotNil(t, resp.Meta.Cursor)
+	assert.Equal(t, "next", resp.Meta.Cursor.NextCursor)
+	assert.Empty(t, resp.Meta.Cursor.PrevCursor)
+	assert.True(t, resp.Meta.Cursor.HasMore)
+}
+
+func TestPageList(t *testing.T) {
+	tests := []struct {
+		name        string
+		page        domain.PageResult[int]
+		wantTotal   int
+		wantUnknown bool
+	}{
+		{name: "counted", page: domain.NewPageResult([]int{1, 2}, 1, 2, 5), wantTotal: 5},
+		{name: "uncounted", page: domain.NewPageResultWithoutTotal([]int{1, 2, 3}, 1, 2), wantUnknown: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c, w := setupTestContext()
+
+			response.PageList(c, mapx.MapPage(tt.page, strconv.Itoa))
+
+			var resp struct {
+				Data []string      `json:"data"`
+				Meta response.Meta `json:"meta"`
+			}
+			require.NoError(t, json.Unmarshal(w.Body.Bytes(), &resp))
+			assert.Equal(t, []string{"1", "2"}, resp.Data)
+			require.NotNil(t, resp.Meta.Pagination)
+			assert.Equal(t, tt.wantTotal, resp.Meta.Pagination.Total)
+			assert.Equal(t, tt.wantUnknown, resp.Meta.Pagination.TotalUnknown)
+			assert.True(t, resp.Meta.Pagination.HasNext)
+		})
+	}
+}
+
+func TestCursorPage(t *testing.T) {
+	c, w := setupTestContext()
+	page := domain.NewCursorResult([]int{7}, "next", "prev", true)
+
+	response.CursorPage(c, mapx.MapCursor(page, strconv.Itoa))
+
+	var resp struct {
+		Data []string      `json:"data"`
+		Meta response.Meta `json:"meta"`
+	}
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &resp))
+	assert.Equal(t, []string{"7"}, resp.Data)
+	require.NotNil(t, resp.Meta.Cursor)
+	assert.Equal(t, "next", resp.Meta.Cursor.NextCursor)
+	assert.Equal(t, "prev", resp.Meta.Cursor.PrevCursor)
+	assert.True(t, resp.Meta.Cursor.HasMore)
+}
+
 func TestErr(t *testing.T) {
 	c, w := setupTestContext()
 
@@ -190,12 +281,30 @@ func TestConvenienceErrors(t *testing.T) {
 			wantStatus: http.StatusConflict,
 			wantCode:   response.CodeConflict,
 		},
+		{
+			name:       "PayloadTooLarge",
+			callFunc:   func(c *gin.Context) { response.PayloadTooLarge(c, "too large") },
+			wantStatus: http.StatusRequestEntityTooLarge,
+			wantCode:   response.CodePayloadTooLarge,
+		},
 		{
 			name:       "TooManyRequests",
 			callFunc:   func(c *gin.Context) { response.TooManyRequests(c, "rate limited") },
 			wantStatus: http.StatusTooManyRequests,
 			wantCode:   response.CodeTooManyRequests,
 		},
+		{
+			name:       "ServiceUnavailable",
+			callFunc:   func(c *gin.Context) { response.ServiceUnavailable(c, "not ready") },
+			wantStatus: http.StatusServiceUnavailable,
+			wantCode:   response.CodeServiceUnavailable,
+		},
+		{
+			name:       "CircuitOpen",
+			callFunc:   func(c *gin.Context) { response.CircuitOpen(c, "payment-api unavailable") },
+			wantStatus: http.StatusServiceUnavailable,
+			wantCode:   response.CodeCircuitOpen,
+		},
 		{
 			name:       "InternalError",
 			callFunc:   func(c *gin.Context) { response.InternalError(c, "internal error") },
@@ -241,3 +350,153 @@ func TestValidationFailed(t *testing.T) {
 	assert.Equal(t, response.CodeValidationFailed, resp.Error.Code)
 	assert.Len(t, resp.Error.Details, 1)
 }
+
+func TestFromError(t *testing.T) {
+	tests := []struct {
+		name        string
+		err         error
+		wantStatus  int
+		wantCode    string
+		wantMessage string
+	}{
+		{
+			name:        "known domain error",
+			err:         domain.ErrInvalidPage,
+			wantStatus:  http.StatusBadRequest,
+			wantCode:    response.CodeBadRequest,
+			wantMessage: domain.ErrInvalidPage.Error(),
+		},
+		{
+			name:        "wrapped domain error",
+			err:         fmt.Errorf("list orders: %w", domain.ErrInvalidCursor),
+			wantStatus:  http.StatusBadRequest,
+			wantCode:    response.CodeBadRequest,
+			wantMessage: "list orders: " + domain.ErrInvalidCursor.Error(),
+		},
+		{
+			name:        "invalid sort field",
+			err:         domain.ValidateSortFields([]domain.SortOption{domain.NewSortOption("secret", domain.SortAsc)}, "id"),
+			wantStatus:  http.StatusBadRequest,
+			wantCode:    response.CodeBadRequest,
+			wantMessage: domain.ErrInvalidSortField.Error() + `: "secret"`,
+		},
+		{
+			name:        "concurrent modification",
+			err:         fmt.Errorf("save order: %w", domain.ErrConcurrentModification),
+			wantStatus:  http.StatusConflict,
+			wantCode:    response.CodeConflict,
+			wantMessage: "save order: " + domain.ErrConcurrentModification.Error(),
+		},
+		{
+			name:        "aggregate not found",
+			err:         fmt.Errorf("get order: %w", domain.ErrNotFound),
+			wantStatus:  http.StatusNotFound,
+			wantCode:    response.CodeNotFound,
+			wantMessage: "get order: " + domain.ErrNotFound.Error(),
+		},
+		{
+			name:        "version mismatch",
+			err:         domain.ErrVersionMismatch,
+			wantStatus:  http.StatusPreconditionFailed,
+			wantCode:    response.CodePreconditionFailed,
+			wantMessage: domain.ErrVersionMismatch.Error(),
+		},
+		{
+			name:        "typed error hides its cause",
+			err:         fmt.Errorf("get order: %w", errorx.NotFound("ORDER_NOT_FOUND", "order not found").WithCause(errors.New("sql: no rows"))),
+			wantStatus:  http.StatusNotFound,
+			wantCode:    "ORDER_NOT_FOUND",
+			wantMessage: "order not found",
+		},
+		{
+			name:        "typed internal error is hidden",
+			err:         errorx.Internal("", "database is down"),
+			wantStatus:  http.StatusInternalServerError,
+			wantCode:    response.CodeInternalError,
+			wantMessage: "internal server error",
+		},
+		{
+			name:        "unknown error is hidden",
+			err:         errors.New("connection reset by peer"),
+			wantStatus:  http.StatusInternalServerError,
+			wantCode:    response.CodeInternalError,
+			wantMessage: "internal server error",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c, w := setupTestContext()
+
+			response.FromError(c, tt.err)
+
+			assert.Equal(t, tt.wantStatus, w.Code)
+
+			var resp response.Response
+			err := json.Unmarshal(w.Body.Bytes(), &resp)
+			require.NoError(t, err)
+
+			require.NotNil(t, resp.Error)
+			assert.Equal(t, tt.wantCode, resp.Error.Code)
+			assert.Equal(t, tt.wantMessage, resp.Error.Message)
+		})
+	}
+}
+
+func TestSetETag(t *testing.T) {
+	c, w := setupTestContext()
+
+	response.SetETag(c, 3)
+
+	assert.Equal(t, `"3"`, w.Header().Get("ETag"))
+}
+
+func TestFromError_ValidationDetails(t *testing.T) {
+	c, w := setupTestContext()
+	var n domain.Notification
+	n.Add("quantity", "positive", "quantity must be positive")
+	n.Add("email", "format", "email is invalid")
+
+	response.FromError(c, fmt.Errorf("create order: %w", n.Err()))
+
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+
+	var resp response.Response
+	err := json.Unmarshal(w.Body.Bytes(), &resp)
+	require.NoError(t, err)
+
+	require.NotNil(t, resp.Error)
+	assert.Equal(t, response.CodeValidationFailed, resp.Error.Code)
+	assert.Equal(t, "validation failed", resp.Error.Message)
+	assert.Equal(t, []response.FieldError{
+		{Field: "quantity", Rule: "positive", Message: "quantity must be positive"},
+		{Field: "email", Rule: "format", Message: "email is invalid"},
+	}, resp.Error.Details)
+}
+
+func TestFromResult(t *testing.T) {
+	c, w := setupTestContext()
+	response.FromResult(c, result.Ok(map[string]string{"id": "o1"}))
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.JSONEq(t, `{"id":"o1"}`, string(mustData(t, w)))
+
+	c, w = setupTestContext()
+	response.FromResult(c, result.Fail[string](errorx.NotFound("ORDER_NOT_FOUND", "order not found")))
+
+	assert.Equal(t, http.StatusNotFound, w.Code)
+	var resp response.Response
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &resp))
+	require.NotNil(t, resp.Error)
+	assert.Equal(t, "ORDER_NOT_FOUND", resp.Error.Code)
+}
+
+// mustData returns the raw data field of a response body.
+func mustData(t *testing.T, w *httptest.ResponseRecorder) json.RawMessage {
+	t.Helper()
+	var body struct {
+		Data json.RawMessage `json:"data"`
+	}
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &body))
+	return body.Data
+}
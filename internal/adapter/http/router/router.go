@@ -7,6 +7,7 @@ import (
 	ginSwagger "github.com/swaggo/gin-swagger"
 
 	"github.com/blackhorseya/go-ddd/internal/adapter/http/middleware"
+	"github.com/blackhorseya/go-ddd/pkg/contextx"
 
 	_ "github.com/blackhorseya/go-ddd/api/openapi" // swagger docs
 )
@@ -16,12 +17,16 @@ type Options struct {
 	Mode        string // gin.DebugMode, gin.ReleaseMode, gin.TestMode
 	ServiceName string // Service name for tracing
 	CORS        cors.Config
+	MaxBodySize int64                   // Request body limit in bytes; 0 disables it
+	Mirror      middleware.MirrorConfig // Traffic mirroring; disabled when Upstream is empty
 }
 
 // DefaultOptions returns default router options.
 func DefaultOptions(serviceName string) Options {
 	corsConfig := cors.DefaultConfig()
 	corsConfig.AllowAllOrigins = true
+	corsConfig.AddAllowHeaders(middleware.HeaderIdempotencyKey, contextx.HeaderRequestID, contextx.HeaderCorrelationID)
+	corsConfig.AddExposeHeaders(contextx.HeaderRequestID)
 
 	return Options{
 		Mode:        gin.ReleaseMode,
@@ -37,11 +42,16 @@ func New(opts Options) *gin.Engine {
 	r := gin.New()
 
 	// Global middleware
-	r.Use(gin.Recovery())
 	r.Use(cors.New(opts.CORS))
 	r.Use(middleware.Tracing(opts.ServiceName))
 	r.Use(middleware.TraceID())
+	r.Use(middleware.RequestID())
+	r.Use(middleware.ClientInfo())
 	r.Use(middleware.Logging())
+	r.Use(middleware.Recovery())
+	r.Use(middleware.BodyLimit(opts.MaxBodySize))
+	r.Use(middleware.IdempotencyKey())
+	r.Use(middleware.Mirror(opts.Mirror))
 
 	// Swagger documentation
 	r.GET("/api/docs/*any", ginSwagger.WrapHandler(swaggerFiles.Handler))
@@ -0,0 +1,59 @@
+package router_test
+
+import (
+	"bytes"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/codes"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+
+	"github.com/blackhorseya/go-ddd/internal/adapter/http/router"
+)
+
+func TestNew_PanicIsTracedAndLogged(t *testing.T) {
+	// Arrange
+	recorder := tracetest.NewSpanRecorder()
+	provider := sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder))
+	prevProvider := otel.GetTracerProvider()
+	otel.SetTracerProvider(provider)
+	t.Cleanup(func() { otel.SetTracerProvider(prevProvider) })
+
+	var logs bytes.Buffer
+	prevLogger := slog.Default()
+	slog.SetDefault(slog.New(slog.NewJSONHandler(&logs, nil)))
+	t.Cleanup(func() { slog.SetDefault(prevLogger) })
+
+	opts := router.DefaultOptions("test")
+	opts.Mode = gin.TestMode
+	r := router.New(opts)
+	r.GET("/panic", func(*gin.Context) { panic("boom") })
+
+	// Act
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/panic", nil))
+
+	// Assert
+	assert.Equal(t, http.StatusInternalServerError, w.Code)
+	traceID := w.Header().Get("X-Trace-ID")
+	assert.NotEmpty(t, traceID)
+
+	spans := recorder.Ended()
+	require.Len(t, spans, 1)
+	assert.Equal(t, codes.Error, spans[0].Status().Code)
+	assert.Equal(t, traceID, spans[0].SpanContext().TraceID().String())
+	require.NotEmpty(t, spans[0].Events())
+	assert.Equal(t, "exception", spans[0].Events()[0].Name)
+
+	assert.Contains(t, logs.String(), `"msg":"panic recovered"`)
+	assert.Contains(t, logs.String(), `"msg":"server error"`)
+	assert.Contains(t, logs.String(), `"status":500`)
+	assert.Contains(t, logs.String(), `"trace_id":"`+traceID+`"`)
+}
@@ -9,24 +9,46 @@ import (
 
 	"github.com/gin-gonic/gin"
 
+	"github.com/blackhorseya/go-ddd/internal/adapter/graphql"
 	"github.com/blackhorseya/go-ddd/internal/adapter/http/handler"
+	"github.com/blackhorseya/go-ddd/internal/adapter/http/request"
 	"github.com/blackhorseya/go-ddd/internal/adapter/http/router"
+	"github.com/blackhorseya/go-ddd/internal/adapter/jsonrpc"
+	"github.com/blackhorseya/go-ddd/internal/domain"
 	"github.com/blackhorseya/go-ddd/pkg/contextx"
+	"github.com/blackhorseya/go-ddd/pkg/healthx"
 )
 
 // Server wraps the HTTP server with graceful shutdown support.
 type Server struct {
-	server *http.Server
-	router *gin.Engine
+	server  *http.Server
+	router  *gin.Engine
+	cursors domain.CursorCodec
 }
 
 // NewServer creates a new HTTP server.
-func NewServer(cfg ServerConfig, serviceName string) *Server {
+// The health registry provides the dependency checks reported by /readyz,
+// and the startup gate holds /startupz and /readyz until all components are ready.
+func NewServer(cfg ServerConfig, serviceName string, health *healthx.Registry, startup *healthx.Gate) *Server {
+	if health == nil {
+		health = healthx.NewRegistry()
+	}
+
 	opts := router.DefaultOptions(serviceName)
+	if cfg.Mode != "" {
+		opts.Mode = cfg.Mode
+	}
+	opts.MaxBodySize = cfg.MaxBodySize
+	opts.Mirror = cfg.Mirror
 	r := router.New(opts)
 
 	// Register handlers
-	handler.NewHealthHandler().Register(r)
+	handler.NewHealthHandler(health, startup).Register(r)
+	graphql.NewHandler(graphql.NewResolver(health), cfg.GraphQL).Register(r)
+
+	rpc := jsonrpc.NewServer()
+	rpc.Register(jsonrpc.MethodHealthCheck, jsonrpc.HealthCheck(health))
+	rpc.RegisterRoutes(r)
 
 	addr := fmt.Sprintf("%s:%d", cfg.Host, cfg.Port)
 	srv := &http.Server{
@@ -37,7 +59,30 @@ func NewServer(cfg ServerConfig, serviceName string) *Server {
 	}
 
 	return &Server{
-		server: srv,
+		server:  srv,
+		router:  r,
+		cursors: domain.NewCursorCodec(request.CursorVersion, cfg.CursorTTL, cfg.CursorKeys...),
+	}
+}
+
+// NewHealthServer creates an HTTP server exposing only the health probes
+// (/healthz, /readyz, /startupz), for processes such as the worker that
+// serve no business routes.
+func NewHealthServer(cfg ServerConfig, serviceName string, health *healthx.Registry, startup *healthx.Gate) *Server {
+	opts := router.DefaultOptions(serviceName)
+	if cfg.Mode != "" {
+		opts.Mode = cfg.Mode
+	}
+	r := router.New(opts)
+	handler.NewHealthHandler(health, startup).Register(r)
+
+	return &Server{
+		server: &http.Server{
+			Addr:         fmt.Sprintf("%s:%d", cfg.Host, cfg.Port),
+			Handler:      r,
+			ReadTimeout:  cfg.ReadTimeout,
+			WriteTimeout: cfg.WriteTimeout,
+		},
 		router: r,
 	}
 }
@@ -47,6 +92,12 @@ func (s *Server) Router() *gin.Engine {
 	return s.router
 }
 
+// Cursors returns the codec list endpoints open and issue cursors with,
+// passed to request.NewCursorRequestFromQuery.
+func (s *Server) Cursors() domain.CursorCodec {
+	return s.cursors
+}
+
 // Run starts the server and blocks until the context is cancelled.
 // It handles graceful shutdown when the context is done.
 func (s *Server) Run(ctx context.Context) error {
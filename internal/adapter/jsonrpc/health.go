@@ -0,0 +1,18 @@
+package jsonrpc
+
+import (
+	"context"
+	"encoding/json"
+
+	"github.com/blackhorseya/go-ddd/pkg/healthx"
+)
+
+// MethodHealthCheck reports the readiness of the service and its dependencies.
+const MethodHealthCheck = "health.check"
+
+// HealthCheck returns a method handler that runs the registered health checks.
+func HealthCheck(registry *healthx.Registry) MethodFunc {
+	return func(ctx context.Context, _ json.RawMessage) (any, error) {
+		return registry.Check(ctx), nil
+	}
+}
@@ -0,0 +1,132 @@
+// Package jsonrpc provides a JSON-RPC 2.0 adapter served alongside the REST routes.
+// It supports single and batch requests as well as notifications, propagates
+// the request context (trace, request ID, logger) into method handlers, and
+// maps errors through the shared domain error mapping table.
+package jsonrpc
+
+import (
+	"encoding/json"
+	"net/http"
+)
+
+// Version is the supported JSON-RPC protocol version.
+const Version = "2.0"
+
+// Standard JSON-RPC 2.0 error codes.
+const (
+	CodeParseError     = -32700
+	CodeInvalidRequest = -32600
+	CodeMethodNotFound = -32601
+	CodeInvalidParams  = -32602
+	CodeInternalError  = -32603
+)
+
+// Application error codes in the -32000 to -32099 range the specification
+// reserves for server errors. error.data.code carries the REST error code.
+const (
+	CodeServerError        = -32000 // other client errors
+	CodeUnauthorized       = -32001
+	CodeForbidden          = -32003
+	CodeNotFound           = -32004
+	CodeConflict           = -32009
+	CodePreconditionFailed = -32012
+	CodePayloadTooLarge    = -32013
+	CodeTooManyRequests    = -32029
+)
+
+// Request is a JSON-RPC 2.0 request object.
+type Request struct {
+	JSONRPC string          `json:"jsonrpc"`
+	Method  string          `json:"method"`
+	Params  json.RawMessage `json:"params,omitempty"`
+	ID      json.RawMessage `json:"id,omitempty"`
+}
+
+// IsNotification returns true if the request has no ID and expects no response.
+func (r Request) IsNotification() bool {
+	return len(r.ID) == 0
+}
+
+// Response is a JSON-RPC 2.0 response object.
+type Response struct {
+	JSONRPC string          `json:"jsonrpc"`
+	Result  any             `json:"result,omitempty"`
+	Error   *Error          `json:"error,omitempty"`
+	ID      json.RawMessage `json:"id"`
+}
+
+// MarshalJSON always includes result on success (even when null) and omits it on error,
+// as required by the specification.
+func (r Response) MarshalJSON() ([]byte, error) {
+	if r.Error != nil {
+		return json.Marshal(struct {
+			JSONRPC string          `json:"jsonrpc"`
+			Error   *Error          `json:"error"`
+			ID      json.RawMessage `json:"id"`
+		}{r.JSONRPC, r.Error, r.ID})
+	}
+
+	return json.Marshal(struct {
+		JSONRPC string          `json:"jsonrpc"`
+		Result  any             `json:"result"`
+		ID      json.RawMessage `json:"id"`
+	}{r.JSONRPC, r.Result, r.ID})
+}
+
+// Error is a JSON-RPC 2.0 error object.
+type Error struct {
+	Code    int        `json:"code"`
+	Message string     `json:"message"`
+	Data    *ErrorData `json:"data,omitempty"`
+}
+
+// ErrorData carries the application error code, matching the REST error codes.
+type ErrorData struct {
+	Code string `json:"code"`
+}
+
+// Error implements the error interface.
+func (e *Error) Error() string {
+	return e.Message
+}
+
+// NewError creates a JSON-RPC error with the given code and message.
+func NewError(code int, message string) *Error {
+	return &Error{Code: code, Message: message}
+}
+
+// ErrInvalidParams returns an invalid params error. Method handlers should
+// return it when params cannot be decoded.
+func ErrInvalidParams(message string) *Error {
+	return NewError(CodeInvalidParams, message)
+}
+
+// nullID is used for responses to requests whose ID could not be determined.
+var nullID = json.RawMessage("null")
+
+// statusToCode maps an HTTP status from the error mapping table to a JSON-RPC error code.
+// Only bad requests and validation failures are reported as invalid params.
+func statusToCode(status int) int {
+	switch {
+	case status >= http.StatusInternalServerError:
+		return CodeInternalError
+	case status == http.StatusBadRequest, status == http.StatusUnprocessableEntity:
+		return CodeInvalidParams
+	case status == http.StatusUnauthorized:
+		return CodeUnauthorized
+	case status == http.StatusForbidden:
+		return CodeForbidden
+	case status == http.StatusNotFound:
+		return CodeNotFound
+	case status == http.StatusConflict:
+		return CodeConflict
+	case status == http.StatusPreconditionFailed:
+		return CodePreconditionFailed
+	case status == http.StatusRequestEntityTooLarge:
+		return CodePayloadTooLarge
+	case status == http.StatusTooManyRequests:
+		return CodeTooManyRequests
+	default:
+		return CodeServerError
+	}
+}
@@ -0,0 +1,264 @@
+package jsonrpc
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"runtime/debug"
+	"sort"
+	"sync"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/blackhorseya/go-ddd/internal/adapter/http/response"
+	"github.com/blackhorseya/go-ddd/pkg/contextx"
+)
+
+// Endpoint is the path the JSON-RPC API is served under.
+const Endpoint = "/rpc"
+
+// MethodFunc handles a single JSON-RPC method call.
+// The returned result is marshaled as the response result.
+type MethodFunc func(ctx context.Context, params json.RawMessage) (any, error)
+
+// Batch defaults.
+const (
+	DefaultMaxBatchSize     = 100
+	DefaultBatchConcurrency = 8
+)
+
+// Server dispatches JSON-RPC requests to registered methods.
+// It is safe for concurrent use.
+type Server struct {
+	mu      sync.RWMutex
+	methods map[string]MethodFunc
+
+	maxBatchSize     int
+	batchConcurrency int
+}
+
+// Option configures a Server.
+type Option func(*Server)
+
+// WithMaxBatchSize sets the largest batch accepted; larger batches are
+// rejected with an invalid request error. Default: 100
+func WithMaxBatchSize(n int) Option {
+	return func(s *Server) {
+		if n > 0 {
+			s.maxBatchSize = n
+		}
+	}
+}
+
+// WithBatchConcurrency sets how many calls of one batch run at once.
+// Default: 8
+func WithBatchConcurrency(n int) Option {
+	return func(s *Server) {
+		if n > 0 {
+			s.batchConcurrency = n
+		}
+	}
+}
+
+// NewServer creates an empty Server.
+func NewServer(opts ...Option) *Server {
+	s := &Server{
+		methods:          make(map[string]MethodFunc),
+		maxBatchSize:     DefaultMaxBatchSize,
+		batchConcurrency: DefaultBatchConcurrency,
+	}
+	for _, opt := range opts {
+		opt(s)
+	}
+	return s
+}
+
+// Register registers a method handler. A later registration for the same
+// method replaces the earlier one.
+func (s *Server) Register(method string, fn MethodFunc) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.methods[method] = fn
+}
+
+// Methods returns the registered method names in sorted order.
+func (s *Server) Methods() []string {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	names := make([]string, 0, len(s.methods))
+	for name := range s.methods {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// RegisterRoutes registers the JSON-RPC endpoint on the Gin engine.
+// The global tracing, logging, and recovery middleware apply as well.
+func (s *Server) RegisterRoutes(r *gin.Engine) {
+	r.POST(Endpoint, s.Handle)
+}
+
+// Handle serves a single or batch JSON-RPC request.
+func (s *Server) Handle(c *gin.Context) {
+	body, err := io.ReadAll(c.Request.Body)
+	if err != nil {
+		c.JSON(http.StatusOK, errorResponse(nullID, NewError(CodeParseError, "failed to read request body")))
+		return
+	}
+
+	body = bytes.TrimSpace(body)
+	if len(body) > 0 && body[0] == '[' {
+		s.handleBatch(c, body)
+		return
+	}
+
+	var req Request
+	if err := json.Unmarshal(body, &req); err != nil {
+		c.JSON(http.StatusOK, errorResponse(nullID, NewError(CodeParseError, "parse error")))
+		return
+	}
+
+	resp := s.call(c.Request.Context(), req)
+	if resp == nil {
+		c.Status(http.StatusNoContent)
+		return
+	}
+	c.JSON(http.StatusOK, resp)
+}
+
+// handleBatch executes a batch request on a bounded pool of workers and
+// preserves request order.
+func (s *Server) handleBatch(c *gin.Context, body []byte) {
+	var raws []json.RawMessage
+	if err := json.Unmarshal(body, &raws); err != nil {
+		c.JSON(http.StatusOK, errorResponse(nullID, NewError(CodeParseError, "parse error")))
+		return
+	}
+	if len(raws) == 0 {
+		c.JSON(http.StatusOK, errorResponse(nullID, NewError(CodeInvalidRequest, "empty batch")))
+		return
+	}
+	if len(raws) > s.maxBatchSize {
+		c.JSON(http.StatusOK, errorResponse(nullID, NewError(CodeInvalidRequest,
+			fmt.Sprintf("batch too large: %d requests, max %d", len(raws), s.maxBatchSize))))
+		return
+	}
+
+	responses := make([]*Response, len(raws))
+	next := make(chan int)
+	var wg sync.WaitGroup
+	for range min(s.batchConcurrency, len(raws)) {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+
+			for i := range next {
+				var req Request
+				if err := json.Unmarshal(raws[i], &req); err != nil {
+					responses[i] = errorResponse(nullID, NewError(CodeInvalidRequest, "invalid request"))
+					continue
+				}
+				responses[i] = s.call(c.Request.Context(), req)
+			}
+		}()
+	}
+	for i := range raws {
+		next <- i
+	}
+	close(next)
+	wg.Wait()
+
+	results := make([]*Response, 0, len(responses))
+	for _, resp := range responses {
+		if resp != nil {
+			results = append(results, resp)
+		}
+	}
+	if len(results) == 0 {
+		c.Status(http.StatusNoContent)
+		return
+	}
+	c.JSON(http.StatusOK, results)
+}
+
+// call executes a single request. It returns nil for notifications.
+func (s *Server) call(ctx context.Context, req Request) *Response {
+	id := req.ID
+	if len(id) == 0 {
+		id = nullID
+	}
+
+	if req.JSONRPC != Version || req.Method == "" {
+		if req.IsNotification() {
+			return nil
+		}
+		return errorResponse(id, NewError(CodeInvalidRequest, "invalid request"))
+	}
+
+	s.mu.RLock()
+	fn, ok := s.methods[req.Method]
+	s.mu.RUnlock()
+	if !ok {
+		if req.IsNotification() {
+			return nil
+		}
+		return errorResponse(id, NewError(CodeMethodNotFound, fmt.Sprintf("method not found: %s", req.Method)))
+	}
+
+	ctx = contextx.WithOperation(ctx, req.Method)
+	result, err := invoke(ctx, fn, req.Params)
+	if req.IsNotification() {
+		return nil
+	}
+	if err != nil {
+		return errorResponse(id, toError(ctx, req.Method, err))
+	}
+	return &Response{JSONRPC: Version, Result: result, ID: id}
+}
+
+// invoke runs fn and converts a panic into an error.
+func invoke(ctx context.Context, fn MethodFunc, params json.RawMessage) (result any, err error) {
+	defer func() {
+		if rec := recover(); rec != nil {
+			contextx.From(ctx).Error("jsonrpc panic recovered",
+				"trace_id", contextx.GetTraceID(ctx),
+				"error", fmt.Sprintf("%v", rec),
+				"stack", string(debug.Stack()),
+			)
+			err = fmt.Errorf("panic: %v", rec)
+		}
+	}()
+
+	return fn(ctx, params)
+}
+
+// toError maps a method error to a JSON-RPC error using the shared domain error mapping table.
+func toError(ctx context.Context, method string, err error) *Error {
+	var rpcErr *Error
+	if errors.As(err, &rpcErr) {
+		return rpcErr
+	}
+
+	status, code := response.Classify(err)
+	if code == response.CodeInternalError {
+		contextx.From(ctx).Error("jsonrpc method error", "method", method, "error", err)
+	}
+
+	return &Error{
+		Code:    statusToCode(status),
+		Message: response.SafeMessage(err),
+		Data:    &ErrorData{Code: code},
+	}
+}
+
+// errorResponse builds an error response.
+func errorResponse(id json.RawMessage, err *Error) *Response {
+	return &Response{JSONRPC: Version, Error: err, ID: id}
+}
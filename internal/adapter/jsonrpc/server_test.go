@@ -0,0 +1,271 @@
+package jsonrpc_test
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/blackhorseya/go-ddd/internal/adapter/http/response"
+	"github.com/blackhorseya/go-ddd/internal/adapter/jsonrpc"
+	"github.com/blackhorseya/go-ddd/internal/domain"
+	"github.com/blackhorseya/go-ddd/pkg/contextx"
+	"github.com/blackhorseya/go-ddd/pkg/errorx"
+)
+
+func init() {
+	gin.SetMode(gin.TestMode)
+}
+
+func newTestRouter() *gin.Engine {
+	s := jsonrpc.NewServer()
+	s.Register("math.add", func(_ context.Context, params json.RawMessage) (any, error) {
+		var args []int
+		if err := json.Unmarshal(params, &args); err != nil {
+			return nil, jsonrpc.ErrInvalidParams("params must be an array of integers")
+		}
+		sum := 0
+		for _, v := range args {
+			sum += v
+		}
+		return sum, nil
+	})
+	s.Register("orders.list", func(context.Context, json.RawMessage) (any, error) {
+		return nil, domain.ErrInvalidPage
+	})
+	s.Register("orders.get", func(context.Context, json.RawMessage) (any, error) {
+		return nil, errorx.NotFound("ORDER_NOT_FOUND", "order not found")
+	})
+	s.Register("orders.cancel", func(context.Context, json.RawMessage) (any, error) {
+		return nil, domain.ErrInvalidTransition
+	})
+	s.Register("orders.delete", func(context.Context, json.RawMessage) (any, error) {
+		return nil, errorx.Forbidden("", "not allowed")
+	})
+	s.Register("op.name", func(ctx context.Context, _ json.RawMessage) (any, error) {
+		return contextx.GetOperation(ctx), nil
+	})
+	s.Register("boom", func(context.Context, json.RawMessage) (any, error) {
+		panic("boom")
+	})
+
+	r := gin.New()
+	s.RegisterRoutes(r)
+	return r
+}
+
+func do(t *testing.T, body string) *httptest.ResponseRecorder {
+	t.Helper()
+
+	w := httptest.NewRecorder()
+	req := httptest.NewRequest(http.MethodPost, jsonrpc.Endpoint, strings.NewReader(body))
+	newTestRouter().ServeHTTP(w, req)
+	return w
+}
+
+func TestServer_Single(t *testing.T) {
+	tests := []struct {
+		name       string
+		body       string
+		wantResult any
+		wantCode   int
+		wantApp    string
+	}{
+		{
+			name:       "success",
+			body:       `{"jsonrpc":"2.0","method":"math.add","params":[1,2,3],"id":1}`,
+			wantResult: float64(6),
+		},
+		{
+			name:       "operation propagated",
+			body:       `{"jsonrpc":"2.0","method":"op.name","id":1}`,
+			wantResult: "op.name",
+		},
+		{
+			name:     "method not found",
+			body:     `{"jsonrpc":"2.0","method":"missing","id":1}`,
+			wantCode: jsonrpc.CodeMethodNotFound,
+		},
+		{
+			name:     "invalid params",
+			body:     `{"jsonrpc":"2.0","method":"math.add","params":{"a":1},"id":1}`,
+			wantCode: jsonrpc.CodeInvalidParams,
+		},
+		{
+			name:     "domain error mapped",
+			body:     `{"jsonrpc":"2.0","method":"orders.list","id":1}`,
+			wantCode: jsonrpc.CodeInvalidParams,
+			wantApp:  response.CodeBadRequest,
+		},
+		{
+			name:     "not found mapped",
+			body:     `{"jsonrpc":"2.0","method":"orders.get","id":1}`,
+			wantCode: jsonrpc.CodeNotFound,
+			wantApp:  "ORDER_NOT_FOUND",
+		},
+		{
+			name:     "conflict mapped",
+			body:     `{"jsonrpc":"2.0","method":"orders.cancel","id":1}`,
+			wantCode: jsonrpc.CodeConflict,
+			wantApp:  response.CodeConflict,
+		},
+		{
+			name:     "forbidden mapped",
+			body:     `{"jsonrpc":"2.0","method":"orders.delete","id":1}`,
+			wantCode: jsonrpc.CodeForbidden,
+			wantApp:  "FORBIDDEN",
+		},
+		{
+			name:     "panic is internal error",
+			body:     `{"jsonrpc":"2.0","method":"boom","id":1}`,
+			wantCode: jsonrpc.CodeInternalError,
+			wantApp:  response.CodeInternalError,
+		},
+		{
+			name:     "invalid version",
+			body:     `{"jsonrpc":"1.0","method":"math.add","id":1}`,
+			wantCode: jsonrpc.CodeInvalidRequest,
+		},
+		{
+			name:     "parse error",
+			body:     `{"jsonrpc":`,
+			wantCode: jsonrpc.CodeParseError,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := do(t, tt.body)
+
+			require.Equal(t, http.StatusOK, w.Code)
+
+			var resp jsonrpc.Response
+			require.NoError(t, json.Unmarshal(w.Body.Bytes(), &resp))
+			assert.Equal(t, jsonrpc.Version, resp.JSONRPC)
+
+			if tt.wantCode == 0 {
+				require.Nil(t, resp.Error)
+				assert.Equal(t, tt.wantResult, resp.Result)
+				return
+			}
+
+			require.NotNil(t, resp.Error)
+			assert.Equal(t, tt.wantCode, resp.Error.Code)
+			if tt.wantApp != "" {
+				require.NotNil(t, resp.Error.Data)
+				assert.Equal(t, tt.wantApp, resp.Error.Data.Code)
+			}
+		})
+	}
+}
+
+func TestResponse_MarshalJSON(t *testing.T) {
+	success, err := json.Marshal(jsonrpc.Response{JSONRPC: jsonrpc.Version, ID: json.RawMessage("1")})
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"jsonrpc":"2.0","result":null,"id":1}`, string(success))
+
+	failure, err := json.Marshal(jsonrpc.Response{
+		JSONRPC: jsonrpc.Version,
+		Error:   jsonrpc.NewError(jsonrpc.CodeInternalError, "internal"),
+		ID:      json.RawMessage("1"),
+	})
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"jsonrpc":"2.0","error":{"code":-32603,"message":"internal"},"id":1}`, string(failure))
+}
+
+func TestServer_Notification(t *testing.T) {
+	w := do(t, `{"jsonrpc":"2.0","method":"math.add","params":[1]}`)
+
+	assert.Equal(t, http.StatusNoContent, w.Code)
+	assert.Empty(t, w.Body.String())
+}
+
+func TestServer_Batch(t *testing.T) {
+	w := do(t, `[
+		{"jsonrpc":"2.0","method":"math.add","params":[1,2],"id":"a"},
+		{"jsonrpc":"2.0","method":"math.add","params":[5]},
+		{"jsonrpc":"2.0","method":"missing","id":"b"},
+		42
+	]`)
+
+	require.Equal(t, http.StatusOK, w.Code)
+
+	var resp []jsonrpc.Response
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &resp))
+	require.Len(t, resp, 3)
+
+	assert.JSONEq(t, `"a"`, string(resp[0].ID))
+	assert.Equal(t, float64(3), resp[0].Result)
+	assert.JSONEq(t, `"b"`, string(resp[1].ID))
+	assert.Equal(t, jsonrpc.CodeMethodNotFound, resp[1].Error.Code)
+	assert.Equal(t, jsonrpc.CodeInvalidRequest, resp[2].Error.Code)
+}
+
+func TestServer_EmptyBatch(t *testing.T) {
+	w := do(t, `[]`)
+
+	var resp jsonrpc.Response
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &resp))
+	require.NotNil(t, resp.Error)
+	assert.Equal(t, jsonrpc.CodeInvalidRequest, resp.Error.Code)
+}
+
+func TestServer_BatchTooLarge(t *testing.T) {
+	s := jsonrpc.NewServer(jsonrpc.WithMaxBatchSize(2))
+	s.Register("ping", func(context.Context, json.RawMessage) (any, error) { return "pong", nil })
+	r := gin.New()
+	s.RegisterRoutes(r)
+
+	w := httptest.NewRecorder()
+	body := `[{"jsonrpc":"2.0","method":"ping","id":1},{"jsonrpc":"2.0","method":"ping","id":2},{"jsonrpc":"2.0","method":"ping","id":3}]`
+	r.ServeHTTP(w, httptest.NewRequest(http.MethodPost, jsonrpc.Endpoint, strings.NewReader(body)))
+
+	var resp jsonrpc.Response
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &resp))
+	require.NotNil(t, resp.Error)
+	assert.Equal(t, jsonrpc.CodeInvalidRequest, resp.Error.Code)
+}
+
+func TestServer_BatchConcurrency(t *testing.T) {
+	var running, peak atomic.Int32
+	s := jsonrpc.NewServer(jsonrpc.WithBatchConcurrency(2))
+	s.Register("slow", func(context.Context, json.RawMessage) (any, error) {
+		n := running.Add(1)
+		defer running.Add(-1)
+		for {
+			p := peak.Load()
+			if n <= p || peak.CompareAndSwap(p, n) {
+				break
+			}
+		}
+		time.Sleep(10 * time.Millisecond)
+		return nil, nil
+	})
+	r := gin.New()
+	s.RegisterRoutes(r)
+
+	calls := make([]string, 10)
+	for i := range calls {
+		calls[i] = fmt.Sprintf(`{"jsonrpc":"2.0","method":"slow","id":%d}`, i)
+	}
+	w := httptest.NewRecorder()
+	body := "[" + strings.Join(calls, ",") + "]"
+	r.ServeHTTP(w, httptest.NewRequest(http.MethodPost, jsonrpc.Endpoint, strings.NewReader(body)))
+
+	var resp []jsonrpc.Response
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &resp))
+	require.Len(t, resp, 10)
+	for i, rsp := range resp {
+		assert.JSONEq(t, fmt.Sprint(i), string(rsp.ID), "order preserved")
+	}
+	assert.LessOrEqual(t, peak.Load(), int32(2))
+}
@@ -0,0 +1,49 @@
+// Package authz defines the application port for authorization decisions.
+// Use cases and bus middleware ask an Authorizer whether the current
+// subject may perform an action on a resource; policy storage and
+// evaluation live in the infrastructure layer.
+package authz
+
+import (
+	"context"
+
+	"github.com/blackhorseya/go-ddd/pkg/contextx"
+	"github.com/blackhorseya/go-ddd/pkg/errorx"
+)
+
+var (
+	// ErrUnauthenticated is returned when the context carries no subject.
+	ErrUnauthenticated = errorx.Unauthorized("UNAUTHENTICATED", "authentication required")
+
+	// ErrForbidden is returned when the subject is not allowed to act.
+	ErrForbidden = errorx.Forbidden("FORBIDDEN", "permission denied")
+)
+
+// Authorizer decides whether subject may perform action on resource.
+type Authorizer interface {
+	Can(ctx context.Context, subject, action, resource string) (bool, error)
+}
+
+// Subject returns the authenticated subject of ctx (the contextx user ID).
+func Subject(ctx context.Context) string {
+	return contextx.GetUserID(ctx)
+}
+
+// Require returns nil if the subject of ctx may perform action on
+// resource, ErrUnauthenticated if ctx has no subject and ErrForbidden if
+// the policy denies it.
+func Require(ctx context.Context, a Authorizer, action, resource string) error {
+	subject := Subject(ctx)
+	if subject == "" {
+		return ErrUnauthenticated
+	}
+
+	ok, err := a.Can(ctx, subject, action, resource)
+	if err != nil {
+		return errorx.Wrap(err, errorx.KindInternal, "", "authorization failed")
+	}
+	if !ok {
+		return ErrForbidden
+	}
+	return nil
+}
@@ -0,0 +1,45 @@
+package bus
+
+import (
+	"context"
+
+	"github.com/blackhorseya/go-ddd/internal/application/authz"
+)
+
+// Protected is implemented by commands and queries that name the
+// permission they require, e.g. ("update", "orders/42").
+type Protected interface {
+	Permission() (action, resource string)
+}
+
+// Public is implemented by commands and queries that need no authorization.
+type Public interface {
+	Public()
+}
+
+// Authorization returns a middleware that checks every message against a
+// before it reaches the handler. Messages implementing Protected are
+// checked with their own permission; all other messages, except Public
+// ones, are checked with their MessageName as the action on resource "*",
+// so an unlisted message is denied by default.
+//
+// Failures are authz.ErrUnauthenticated (401) or authz.ErrForbidden (403).
+func Authorization(a authz.Authorizer) Middleware {
+	return func(next HandlerFunc) HandlerFunc {
+		return func(ctx context.Context, msg any) (any, error) {
+			if _, ok := msg.(Public); ok {
+				return next(ctx, msg)
+			}
+
+			action, resource := MessageName(msg), "*"
+			if p, ok := msg.(Protected); ok {
+				action, resource = p.Permission()
+			}
+
+			if err := authz.Require(ctx, a, action, resource); err != nil {
+				return nil, err
+			}
+			return next(ctx, msg)
+		}
+	}
+}
@@ -0,0 +1,81 @@
+package bus_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/blackhorseya/go-ddd/internal/application/authz"
+	"github.com/blackhorseya/go-ddd/internal/application/bus"
+	"github.com/blackhorseya/go-ddd/pkg/contextx"
+	"github.com/blackhorseya/go-ddd/pkg/errorx"
+)
+
+// allowList grants "subject action resource" triples.
+type allowList map[string]bool
+
+func (a allowList) Can(_ context.Context, subject, action, resource string) (bool, error) {
+	return a[subject+" "+action+" "+resource], nil
+}
+
+type cancelOrder struct {
+	ID string
+}
+
+func (c cancelOrder) Permission() (string, string) { return "cancel", "orders/" + c.ID }
+
+type healthCheck struct{}
+
+func (healthCheck) Public() {}
+
+func TestAuthorization(t *testing.T) {
+	policy := allowList{
+		"alice cancel orders/1":        true,
+		"alice bus_test.createOrder *": true,
+	}
+
+	tests := []struct {
+		name     string
+		user     string
+		msg      any
+		wantKind errorx.Kind
+		wantErr  bool
+	}{
+		{name: "protected message allowed", user: "alice", msg: cancelOrder{ID: "1"}},
+		{name: "protected message denied", user: "alice", msg: cancelOrder{ID: "2"}, wantErr: true, wantKind: errorx.KindForbidden},
+		{name: "message name allowed", user: "alice", msg: createOrder{}},
+		{name: "unlisted message denied", user: "bob", msg: createOrder{}, wantErr: true, wantKind: errorx.KindForbidden},
+		{name: "anonymous", msg: createOrder{}, wantErr: true, wantKind: errorx.KindUnauthorized},
+		{name: "public message", msg: healthCheck{}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Arrange
+			b := bus.NewCommandBus(bus.Authorization(policy))
+			bus.RegisterCommand(b, func(context.Context, cancelOrder) error { return nil })
+			bus.RegisterCommand(b, func(context.Context, createOrder) error { return nil })
+			bus.RegisterCommand(b, func(context.Context, healthCheck) error { return nil })
+
+			ctx := context.Background()
+			if tt.user != "" {
+				ctx = contextx.WithUserID(ctx, tt.user)
+			}
+
+			// Act
+			err := b.Dispatch(ctx, tt.msg)
+
+			// Assert
+			if !tt.wantErr {
+				require.NoError(t, err)
+				return
+			}
+			assert.Equal(t, tt.wantKind, errorx.KindOf(err))
+			if tt.wantKind == errorx.KindForbidden {
+				assert.ErrorIs(t, err, authz.ErrForbidden)
+			}
+		})
+	}
+}
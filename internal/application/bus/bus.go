@@ -0,0 +1,80 @@
+// Package bus dispatches commands and queries to their handlers.
+// Handlers are registered per message type; cross-cutting concerns such as
+// caching, validation and transactions are added as Middleware, in the
+// same order they are passed to Use.
+package bus
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"reflect"
+	"sync"
+)
+
+var (
+	// ErrHandlerNotFound is returned when no handler is registered for a message type.
+	ErrHandlerNotFound = errors.New("no handler registered")
+
+	// ErrResultType is returned by Ask when the query result has an unexpected type.
+	ErrResultType = errors.New("unexpected query result type")
+)
+
+// HandlerFunc handles a command or query. Command handlers return a nil result.
+type HandlerFunc func(ctx context.Context, msg any) (any, error)
+
+// Middleware decorates a HandlerFunc.
+type Middleware func(next HandlerFunc) HandlerFunc
+
+// registry maps message types to handlers and holds the middleware chain.
+type registry struct {
+	kind string
+
+	mu          sync.RWMutex
+	handlers    map[reflect.Type]HandlerFunc
+	middlewares []Middleware
+}
+
+func newRegistry(kind string, mws []Middleware) registry {
+	return registry{
+		kind:        kind,
+		handlers:    make(map[reflect.Type]HandlerFunc),
+		middlewares: mws,
+	}
+}
+
+// use appends middlewares to the chain.
+func (r *registry) use(mws ...Middleware) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.middlewares = append(r.middlewares, mws...)
+}
+
+// register adds the handler for t. Registering a type twice is a wiring
+// bug, so it panics like http.ServeMux does.
+func (r *registry) register(t reflect.Type, h HandlerFunc) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, ok := r.handlers[t]; ok {
+		panic(fmt.Sprintf("bus: %s handler for %s already registered", r.kind, t))
+	}
+	r.handlers[t] = h
+}
+
+// dispatch runs the handler for msg through the middleware chain.
+func (r *registry) dispatch(ctx context.Context, msg any) (any, error) {
+	r.mu.RLock()
+	h, ok := r.handlers[reflect.TypeOf(msg)]
+	mws := r.middlewares
+	r.mu.RUnlock()
+
+	if !ok {
+		return nil, fmt.Errorf("%w: %s %T", ErrHandlerNotFound, r.kind, msg)
+	}
+	for i := len(mws) - 1; i >= 0; i-- {
+		h = mws[i](h)
+	}
+	return h(ctx, msg)
+}
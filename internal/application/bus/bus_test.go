@@ -0,0 +1,131 @@
+package bus_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/blackhorseya/go-ddd/internal/application/bus"
+)
+
+type createOrder struct {
+	CustomerID string
+}
+
+type getOrder struct {
+	ID string
+}
+
+type orderView struct {
+	ID     string `json:"id"`
+	Status string `json:"status"`
+}
+
+func TestCommandBus_Dispatch(t *testing.T) {
+	// Arrange
+	var calls []string
+	trace := func(name string) bus.Middleware {
+		return func(next bus.HandlerFunc) bus.HandlerFunc {
+			return func(ctx context.Context, msg any) (any, error) {
+				calls = append(calls, name)
+				return next(ctx, msg)
+			}
+		}
+	}
+
+	b := bus.NewCommandBus(trace("outer"))
+	b.Use(trace("inner"))
+	bus.RegisterCommand(b, func(_ context.Context, cmd createOrder) error {
+		calls = append(calls, "handler:"+cmd.CustomerID)
+		return nil
+	})
+
+	// Act
+	err := b.Dispatch(context.Background(), createOrder{CustomerID: "c-1"})
+
+	// Assert
+	require.NoError(t, err)
+	assert.Equal(t, []string{"outer", "inner", "handler:c-1"}, calls)
+}
+
+func TestCommandBus_Dispatch_NotFound(t *testing.T) {
+	// Arrange
+	b := bus.NewCommandBus()
+
+	// Act
+	err := b.Dispatch(context.Background(), createOrder{})
+
+	// Assert
+	assert.ErrorIs(t, err, bus.ErrHandlerNotFound)
+}
+
+func TestCommandBus_RegisterTwice(t *testing.T) {
+	b := bus.NewCommandBus()
+	h := func(context.Context, createOrder) error { return nil }
+	bus.RegisterCommand(b, h)
+
+	assert.Panics(t, func() { bus.RegisterCommand(b, h) })
+}
+
+func TestAsk(t *testing.T) {
+	wantErr := errors.New("db down")
+
+	tests := []struct {
+		name    string
+		id      string
+		want    orderView
+		wantErr error
+	}{
+		{
+			name: "typed result",
+			id:   "o-1",
+			want: orderView{ID: "o-1", Status: "confirmed"},
+		},
+		{
+			name:    "handler error",
+			id:      "fail",
+			wantErr: wantErr,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Arrange
+			b := bus.NewQueryBus()
+			bus.RegisterQuery(b, func(_ context.Context, q getOrder) (orderView, error) {
+				if q.ID == "fail" {
+					return orderView{}, wantErr
+				}
+				return orderView{ID: q.ID, Status: "confirmed"}, nil
+			})
+
+			// Act
+			got, err := bus.Ask[orderView](context.Background(), b, getOrder{ID: tt.id})
+
+			// Assert
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestAsk_ResultType(t *testing.T) {
+	// Arrange
+	b := bus.NewQueryBus()
+	bus.RegisterQuery(b, func(context.Context, getOrder) (orderView, error) {
+		return orderView{}, nil
+	})
+
+	// Act
+	_, err := bus.Ask[*orderView](context.Background(), b, getOrder{})
+
+	// Assert
+	assert.ErrorIs(t, err, bus.ErrResultType)
+}
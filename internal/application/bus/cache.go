@@ -0,0 +1,131 @@
+package bus
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"sync"
+	"time"
+
+	"github.com/blackhorseya/go-ddd/pkg/cachex"
+	"github.com/blackhorseya/go-ddd/pkg/contextx"
+)
+
+// CacheOptions configures the Cached decorator.
+type CacheOptions[Q any] struct {
+	// TTL is how long a result stays cached. Zero means no expiry.
+	TTL time.Duration
+
+	// Key builds the cache key. Default: CacheKey.
+	Key func(q Q) (string, error)
+
+	// Tags returns invalidation tags for a query, e.g. "order:42".
+	Tags func(q Q) []string
+}
+
+// Invalidator is implemented by commands that make cached query results
+// stale. See InvalidateTags.
+type Invalidator interface {
+	InvalidatesTags() []string
+}
+
+// CacheKey builds a key from the query type and its JSON encoding, e.g.
+// "query:orders.GetOrder:<sha256>". Queries with equal fields share a key.
+func CacheKey(q any) (string, error) {
+	b, err := json.Marshal(q)
+	if err != nil {
+		return "", fmt.Errorf("build cache key for %T: %w", q, err)
+	}
+	sum := sha256.Sum256(b)
+	return "query:" + reflect.TypeOf(q).String() + ":" + hex.EncodeToString(sum[:]), nil
+}
+
+// Cached decorates h so results are stored in c as JSON and served from c
+// until they expire or their tags are invalidated. Concurrent misses for
+// the same key run h once (see cachex.Loader), detached from the callers'
+// cancellation. Cache failures are logged and fall through to h, so the
+// cache never breaks a read. Errors returned by h are not cached.
+func Cached[Q, R any](c cachex.Cache, h QueryHandler[Q, R], opts CacheOptions[Q]) QueryHandler[Q, R] {
+	keyFn := opts.Key
+	if keyFn == nil {
+		keyFn = func(q Q) (string, error) { return CacheKey(q) }
+	}
+	loader := cachex.NewLoader(c)
+
+	return func(ctx context.Context, q Q) (R, error) {
+		logger := contextx.From(ctx)
+
+		key, err := keyFn(q)
+		if err != nil {
+			logger.Warn("query cache key failed", "error", err)
+			return h(ctx, q)
+		}
+		var tags []string
+		if opts.Tags != nil {
+			tags = opts.Tags(q)
+		}
+
+		// fresh is set when this caller ran h, so encoding failures never hide
+		// its result. The load may outlive a cancelled caller, hence the lock.
+		var (
+			mu    sync.Mutex
+			r     R
+			fresh bool
+		)
+		b, err := loader.GetOrLoad(ctx, key, opts.TTL, func(ctx context.Context) ([]byte, error) {
+			res, err := h(ctx, q)
+			if err != nil {
+				return nil, err
+			}
+			mu.Lock()
+			r, fresh = res, true
+			mu.Unlock()
+			return json.Marshal(res)
+		}, tags...)
+
+		mu.Lock()
+		res, ran := r, fresh
+		mu.Unlock()
+		if ran {
+			if err != nil {
+				logger.Warn("query cache set failed", "key", key, "error", err)
+			}
+			return res, nil
+		}
+		if err != nil {
+			var zero R
+			return zero, err
+		}
+
+		var cached R
+		if err := json.Unmarshal(b, &cached); err != nil {
+			logger.Warn("query cache entry corrupt", "key", key)
+			return h(ctx, q)
+		}
+		return cached, nil
+	}
+}
+
+// InvalidateTags returns a command middleware that, after a command
+// implementing Invalidator succeeds, invalidates its tags in c.
+// Invalidation failures are logged; the command has already succeeded.
+func InvalidateTags(c cachex.Cache) Middleware {
+	return func(next HandlerFunc) HandlerFunc {
+		return func(ctx context.Context, msg any) (any, error) {
+			res, err := next(ctx, msg)
+			if err != nil {
+				return res, err
+			}
+
+			if inv, ok := msg.(Invalidator); ok {
+				if err := c.InvalidateTags(ctx, inv.InvalidatesTags()...); err != nil {
+					contextx.From(ctx).Warn("query cache invalidation failed", "command", fmt.Sprintf("%T", msg), "error", err)
+				}
+			}
+			return res, nil
+		}
+	}
+}
@@ -0,0 +1,80 @@
+package bus_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/blackhorseya/go-ddd/internal/application/bus"
+	"github.com/blackhorseya/go-ddd/pkg/cachex"
+)
+
+type confirmOrder struct {
+	ID string
+}
+
+func (c confirmOrder) InvalidatesTags() []string {
+	return []string{"order:" + c.ID}
+}
+
+func TestCached(t *testing.T) {
+	// Arrange
+	ctx := context.Background()
+	cache := cachex.NewMemory()
+	status := "pending"
+	hits := 0
+
+	queries := bus.NewQueryBus()
+	bus.RegisterQuery(queries, bus.Cached(cache,
+		func(_ context.Context, q getOrder) (orderView, error) {
+			hits++
+			return orderView{ID: q.ID, Status: status}, nil
+		},
+		bus.CacheOptions[getOrder]{
+			TTL:  time.Minute,
+			Tags: func(q getOrder) []string { return []string{"order:" + q.ID} },
+		},
+	))
+
+	commands := bus.NewCommandBus(bus.InvalidateTags(cache))
+	bus.RegisterCommand(commands, func(context.Context, confirmOrder) error {
+		status = "confirmed"
+		return nil
+	})
+
+	// Act & Assert: second read is served from the cache
+	first, err := bus.Ask[orderView](ctx, queries, getOrder{ID: "o-1"})
+	require.NoError(t, err)
+	second, err := bus.Ask[orderView](ctx, queries, getOrder{ID: "o-1"})
+	require.NoError(t, err)
+	assert.Equal(t, first, second)
+	assert.Equal(t, 1, hits)
+
+	// Act & Assert: a different query misses
+	_, err = bus.Ask[orderView](ctx, queries, getOrder{ID: "o-2"})
+	require.NoError(t, err)
+	assert.Equal(t, 2, hits)
+
+	// Act & Assert: the command invalidates the tag
+	require.NoError(t, commands.Dispatch(ctx, confirmOrder{ID: "o-1"}))
+	third, err := bus.Ask[orderView](ctx, queries, getOrder{ID: "o-1"})
+	require.NoError(t, err)
+	assert.Equal(t, "confirmed", third.Status)
+	assert.Equal(t, 3, hits)
+}
+
+func TestCacheKey(t *testing.T) {
+	a, err := bus.CacheKey(getOrder{ID: "o-1"})
+	require.NoError(t, err)
+	b, err := bus.CacheKey(getOrder{ID: "o-1"})
+	require.NoError(t, err)
+	c, err := bus.CacheKey(getOrder{ID: "o-2"})
+	require.NoError(t, err)
+
+	assert.Equal(t, a, b)
+	assert.NotEqual(t, a, c)
+	assert.Contains(t, a, "query:bus_test.getOrder:")
+}
@@ -0,0 +1,39 @@
+package bus
+
+import (
+	"context"
+	"reflect"
+)
+
+// CommandHandler handles a command of type C.
+type CommandHandler[C any] func(ctx context.Context, cmd C) error
+
+// CommandBus routes commands to exactly one handler each.
+// It is safe for concurrent use.
+type CommandBus struct {
+	registry
+}
+
+// NewCommandBus creates a CommandBus with the given middlewares.
+func NewCommandBus(mws ...Middleware) *CommandBus {
+	return &CommandBus{registry: newRegistry("command", mws)}
+}
+
+// Use appends middlewares to the chain.
+func (b *CommandBus) Use(mws ...Middleware) {
+	b.use(mws...)
+}
+
+// RegisterCommand registers the handler for commands of type C.
+// It panics if a handler for C is already registered.
+func RegisterCommand[C any](b *CommandBus, h CommandHandler[C]) {
+	b.register(reflect.TypeFor[C](), func(ctx context.Context, msg any) (any, error) {
+		return nil, h(ctx, msg.(C))
+	})
+}
+
+// Dispatch sends cmd to its handler.
+func (b *CommandBus) Dispatch(ctx context.Context, cmd any) error {
+	_, err := b.dispatch(ctx, cmd)
+	return err
+}
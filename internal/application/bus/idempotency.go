@@ -0,0 +1,264 @@
+package bus
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/blackhorseya/go-ddd/pkg/contextx"
+	"github.com/blackhorseya/go-ddd/pkg/errorx"
+)
+
+var (
+	// ErrCommandInProgress is returned when a command with the same idempotency
+	// key is still being handled.
+	ErrCommandInProgress = errorx.Conflict("COMMAND_IN_PROGRESS", "a request with this idempotency key is in progress")
+
+	// ErrIdempotencyKeyReused is returned when an idempotency key is sent again
+	// with a different command payload.
+	ErrIdempotencyKeyReused = errorx.Conflict("IDEMPOTENCY_KEY_REUSED", "the idempotency key was already used for a different request")
+)
+
+// DefaultIdempotencyTTL is how long MemoryIdempotencyStore remembers a
+// completed command.
+const DefaultIdempotencyTTL = 24 * time.Hour
+
+// IdempotencyStatus is the state of an idempotency key.
+type IdempotencyStatus int
+
+const (
+	// IdempotencyNew means the key was unused and is now reserved.
+	IdempotencyNew IdempotencyStatus = iota
+	// IdempotencyPending means another execution holds the key.
+	IdempotencyPending
+	// IdempotencyCompleted means a previous execution succeeded.
+	IdempotencyCompleted
+)
+
+// IdempotencyRecord is the state of an idempotency key as seen by Reserve.
+type IdempotencyRecord struct {
+	Status IdempotencyStatus
+
+	// PayloadHash identifies the command that reserved the key.
+	PayloadHash string
+
+	// Result is the JSON-encoded result of a completed execution.
+	Result []byte
+}
+
+// IdempotencyStore records command executions by key.
+type IdempotencyStore interface {
+	// Reserve claims key for the command identified by payloadHash and
+	// returns the record as it was before the call. Only IdempotencyNew
+	// grants the caller the right to execute.
+	// Implementations should treat a pending key whose lock has expired
+	// as new, so a crashed execution does not block retries forever.
+	Reserve(ctx context.Context, key, payloadHash string) (IdempotencyRecord, error)
+
+	// Complete marks a reserved key as successfully executed and stores
+	// the JSON-encoded result for replay.
+	Complete(ctx context.Context, key string, result []byte) error
+
+	// Release frees a reserved key after a failed execution.
+	Release(ctx context.Context, key string) error
+}
+
+// Keyed is implemented by commands that carry their own idempotency key,
+// such as commands built from a broker message ID.
+type Keyed interface {
+	IdempotencyKey() string
+}
+
+// Idempotent returns a command middleware that executes each command at
+// most once per idempotency key. The key comes from the command (Keyed)
+// or from ctx (contextx.GetIdempotencyKey), and is scoped by the tenant,
+// the user and the command type, so callers cannot collide with each
+// other's keys. Commands without a key pass through.
+//
+// Duplicates of a completed command replay its stored result without
+// calling the handler; a non-nil result replays as json.RawMessage.
+// Duplicates of a command still running fail with ErrCommandInProgress,
+// and a key sent again with a different payload fails with
+// ErrIdempotencyKeyReused. A failed execution releases the key so the
+// client may retry.
+//
+// Register it before Transactional so the key is recorded outside the
+// command's transaction.
+func Idempotent(store IdempotencyStore) Middleware {
+	return func(next HandlerFunc) HandlerFunc {
+		return func(ctx context.Context, msg any) (any, error) {
+			key := contextx.GetIdempotencyKey(ctx)
+			if k, ok := msg.(Keyed); ok {
+				key = k.IdempotencyKey()
+			}
+			if key == "" {
+				return next(ctx, msg)
+			}
+			key = idempotencyScope(ctx, msg) + ":" + key
+
+			hash, err := payloadHash(msg)
+			if err != nil {
+				return nil, err
+			}
+
+			rec, err := store.Reserve(ctx, key, hash)
+			if err != nil {
+				return nil, err
+			}
+			// Keys recorded before payload hashing have no hash to compare.
+			if rec.Status != IdempotencyNew && rec.PayloadHash != "" && rec.PayloadHash != hash {
+				return nil, ErrIdempotencyKeyReused
+			}
+			switch rec.Status {
+			case IdempotencyCompleted:
+				contextx.From(ctx).Debug("duplicate command replayed", "idempotency_key", key)
+				return replay(rec.Result), nil
+			case IdempotencyPending:
+				return nil, ErrCommandInProgress
+			}
+
+			res, err := next(ctx, msg)
+			if err != nil {
+				if releaseErr := store.Release(ctx, key); releaseErr != nil {
+					contextx.From(ctx).Warn("release idempotency key failed", "idempotency_key", key, "error", releaseErr)
+				}
+				return nil, err
+			}
+
+			result, err := json.Marshal(res)
+			if err != nil {
+				contextx.From(ctx).Warn("encode command result failed", "idempotency_key", key, "error", err)
+				result = nil
+			}
+			if err := store.Complete(ctx, key, result); err != nil {
+				contextx.From(ctx).Warn("complete idempotency key failed", "idempotency_key", key, "error", err)
+			}
+			return res, nil
+		}
+	}
+}
+
+// idempotencyScope prefixes keys with the tenant, the user and the
+// command type.
+func idempotencyScope(ctx context.Context, msg any) string {
+	return contextx.GetTenantID(ctx) + "/" + contextx.GetUserID(ctx) + "/" + MessageName(msg)
+}
+
+// payloadHash returns the SHA-256 of the command's JSON encoding.
+func payloadHash(msg any) (string, error) {
+	b, err := json.Marshal(msg)
+	if err != nil {
+		return "", fmt.Errorf("hash %s payload: %w", MessageName(msg), err)
+	}
+	sum := sha256.Sum256(b)
+	return hex.EncodeToString(sum[:]), nil
+}
+
+// replay returns a stored result, nil for results that encoded as null.
+func replay(result []byte) any {
+	if len(result) == 0 || string(result) == "null" {
+		return nil
+	}
+	return json.RawMessage(result)
+}
+
+// memoryRecord is an idempotency key held by MemoryIdempotencyStore.
+type memoryRecord struct {
+	IdempotencyRecord
+	lockedAt time.Time
+}
+
+// MemoryIdempotencyStore is an in-process IdempotencyStore for single
+// instances and tests. It is safe for concurrent use.
+type MemoryIdempotencyStore struct {
+	lockTTL time.Duration
+	ttl     time.Duration
+	now     func() time.Time
+
+	mu        sync.Mutex
+	records   map[string]memoryRecord
+	nextSweep time.Time
+}
+
+var _ IdempotencyStore = (*MemoryIdempotencyStore)(nil)
+
+// NewMemoryIdempotencyStore creates a store whose pending reservations
+// expire after lockTTL and completed keys after ttl. A lockTTL <= 0 means
+// reservations never expire; a ttl <= 0 uses DefaultIdempotencyTTL.
+func NewMemoryIdempotencyStore(lockTTL, ttl time.Duration) *MemoryIdempotencyStore {
+	if ttl <= 0 {
+		ttl = DefaultIdempotencyTTL
+	}
+	return &MemoryIdempotencyStore{
+		lockTTL: lockTTL,
+		ttl:     ttl,
+		now:     time.Now,
+		records: make(map[string]memoryRecord),
+	}
+}
+
+// Reserve claims key.
+func (s *MemoryIdempotencyStore) Reserve(_ context.Context, key, payloadHash string) (IdempotencyRecord, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	now := s.now()
+	s.sweep(now)
+	if r, ok := s.records[key]; ok && !s.expired(r, now) {
+		return r.IdempotencyRecord, nil
+	}
+
+	s.records[key] = memoryRecord{
+		IdempotencyRecord: IdempotencyRecord{Status: IdempotencyPending, PayloadHash: payloadHash},
+		lockedAt:          now,
+	}
+	return IdempotencyRecord{Status: IdempotencyNew}, nil
+}
+
+// Complete marks key as executed.
+func (s *MemoryIdempotencyStore) Complete(_ context.Context, key string, result []byte) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	r := s.records[key]
+	r.Status = IdempotencyCompleted
+	r.Result = result
+	r.lockedAt = s.now()
+	s.records[key] = r
+	return nil
+}
+
+// Release frees key.
+func (s *MemoryIdempotencyStore) Release(_ context.Context, key string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	delete(s.records, key)
+	return nil
+}
+
+// expired reports whether r no longer blocks its key: completed keys
+// expire after ttl, pending ones after lockTTL.
+func (s *MemoryIdempotencyStore) expired(r memoryRecord, now time.Time) bool {
+	if r.Status == IdempotencyCompleted {
+		return now.Sub(r.lockedAt) >= s.ttl
+	}
+	return s.lockTTL > 0 && now.Sub(r.lockedAt) >= s.lockTTL
+}
+
+// sweep drops expired keys, at most once per ttl, to bound memory.
+func (s *MemoryIdempotencyStore) sweep(now time.Time) {
+	if now.Before(s.nextSweep) {
+		return
+	}
+	s.nextSweep = now.Add(s.ttl)
+	for key, r := range s.records {
+		if s.expired(r, now) {
+			delete(s.records, key)
+		}
+	}
+}
@@ -0,0 +1,176 @@
+package bus_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/blackhorseya/go-ddd/internal/application/bus"
+	"github.com/blackhorseya/go-ddd/pkg/contextx"
+	"github.com/blackhorseya/go-ddd/pkg/errorx"
+)
+
+type chargeCard struct {
+	MessageID string
+}
+
+func (c chargeCard) IdempotencyKey() string { return c.MessageID }
+
+func TestIdempotent(t *testing.T) {
+	// Arrange
+	ctx := context.Background()
+	charges := 0
+	fail := true
+
+	b := bus.NewCommandBus(bus.Idempotent(bus.NewMemoryIdempotencyStore(time.Minute, time.Hour)))
+	bus.RegisterCommand(b, func(context.Context, chargeCard) error {
+		if fail {
+			fail = false
+			return errors.New("gateway timeout")
+		}
+		charges++
+		return nil
+	})
+	bus.RegisterCommand(b, func(context.Context, createOrder) error {
+		charges++
+		return nil
+	})
+
+	// Act & Assert: a failed execution releases the key
+	require.Error(t, b.Dispatch(ctx, chargeCard{MessageID: "m-1"}))
+	require.NoError(t, b.Dispatch(ctx, chargeCard{MessageID: "m-1"}))
+	assert.Equal(t, 1, charges)
+
+	// Act & Assert: redelivery is skipped
+	require.NoError(t, b.Dispatch(ctx, chargeCard{MessageID: "m-1"}))
+	assert.Equal(t, 1, charges)
+
+	// Act & Assert: keys from ctx are scoped by command type
+	keyed := contextx.WithIdempotencyKey(ctx, "m-1")
+	require.NoError(t, b.Dispatch(keyed, createOrder{}))
+	require.NoError(t, b.Dispatch(keyed, createOrder{}))
+	assert.Equal(t, 2, charges)
+
+	// Act & Assert: commands without a key always run
+	require.NoError(t, b.Dispatch(ctx, createOrder{}))
+	assert.Equal(t, 3, charges)
+}
+
+func TestIdempotent_InProgress(t *testing.T) {
+	// Arrange
+	ctx := contextx.WithIdempotencyKey(context.Background(), "k-1")
+	b := bus.NewCommandBus(bus.Idempotent(bus.NewMemoryIdempotencyStore(time.Minute, time.Hour)))
+
+	var (
+		nested error
+		calls  int
+	)
+	bus.RegisterCommand(b, func(ctx context.Context, cmd createOrder) error {
+		calls++
+		if calls == 1 {
+			nested = b.Dispatch(ctx, cmd)
+		}
+		return nil
+	})
+
+	// Act: the handler dispatches a duplicate while the first still runs
+	err := b.Dispatch(ctx, createOrder{})
+
+	// Assert
+	require.NoError(t, err)
+	assert.ErrorIs(t, nested, bus.ErrCommandInProgress)
+	assert.Equal(t, 1, calls)
+}
+
+func TestIdempotent_Scope(t *testing.T) {
+	// Arrange
+	charges := 0
+	b := bus.NewCommandBus(bus.Idempotent(bus.NewMemoryIdempotencyStore(time.Minute, time.Hour)))
+	bus.RegisterCommand(b, func(context.Context, createOrder) error {
+		charges++
+		return nil
+	})
+	keyed := contextx.WithIdempotencyKey(context.Background(), "k-1")
+
+	// Act: the same key from different tenants and users
+	require.NoError(t, b.Dispatch(contextx.WithTenantID(keyed, "acme"), createOrder{}))
+	require.NoError(t, b.Dispatch(contextx.WithTenantID(keyed, "globex"), createOrder{}))
+	require.NoError(t, b.Dispatch(contextx.WithUserID(contextx.WithTenantID(keyed, "acme"), "u-1"), createOrder{}))
+	require.NoError(t, b.Dispatch(contextx.WithTenantID(keyed, "acme"), createOrder{}))
+
+	// Assert
+	assert.Equal(t, 3, charges)
+}
+
+func TestIdempotent_PayloadMismatch(t *testing.T) {
+	// Arrange
+	ctx := contextx.WithIdempotencyKey(context.Background(), "k-1")
+	b := bus.NewCommandBus(bus.Idempotent(bus.NewMemoryIdempotencyStore(time.Minute, time.Hour)))
+	bus.RegisterCommand(b, func(context.Context, createOrder) error { return nil })
+	require.NoError(t, b.Dispatch(ctx, createOrder{CustomerID: "c-1"}))
+
+	// Act
+	err := b.Dispatch(ctx, createOrder{CustomerID: "c-2"})
+
+	// Assert
+	require.ErrorIs(t, err, bus.ErrIdempotencyKeyReused)
+	assert.Equal(t, errorx.KindConflict, errorx.KindOf(err))
+}
+
+func TestIdempotent_ReplaysResult(t *testing.T) {
+	// Arrange
+	ctx := contextx.WithIdempotencyKey(context.Background(), "k-1")
+	calls := 0
+	h := bus.Idempotent(bus.NewMemoryIdempotencyStore(time.Minute, time.Hour))(func(context.Context, any) (any, error) {
+		calls++
+		return map[string]string{"order_id": "o-1"}, nil
+	})
+	_, err := h(ctx, createOrder{})
+	require.NoError(t, err)
+
+	// Act
+	res, err := h(ctx, createOrder{})
+
+	// Assert
+	require.NoError(t, err)
+	assert.Equal(t, 1, calls)
+	assert.JSONEq(t, `{"order_id":"o-1"}`, string(res.(json.RawMessage)))
+}
+
+func TestMemoryIdempotencyStore_TTL(t *testing.T) {
+	// Arrange
+	ctx := context.Background()
+	s := bus.NewMemoryIdempotencyStore(time.Minute, time.Nanosecond)
+	_, err := s.Reserve(ctx, "k", "h")
+	require.NoError(t, err)
+	require.NoError(t, s.Complete(ctx, "k", nil))
+	time.Sleep(time.Millisecond)
+
+	// Act
+	rec, err := s.Reserve(ctx, "k", "h")
+
+	// Assert
+	require.NoError(t, err)
+	assert.Equal(t, bus.IdempotencyNew, rec.Status)
+}
+
+func TestMemoryIdempotencyStore_ExpiredLock(t *testing.T) {
+	// Arrange
+	ctx := context.Background()
+	s := bus.NewMemoryIdempotencyStore(time.Nanosecond, time.Hour)
+	_, err := s.Reserve(ctx, "k", "h")
+	require.NoError(t, err)
+	time.Sleep(time.Millisecond)
+
+	// Act
+	rec, err := s.Reserve(ctx, "k", "h")
+
+	// Assert
+	require.NoError(t, err)
+	assert.Equal(t, bus.IdempotencyNew, rec.Status)
+}
@@ -0,0 +1,81 @@
+package bus
+
+import (
+	"context"
+	"reflect"
+	"time"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+
+	"github.com/blackhorseya/go-ddd/pkg/contextx"
+)
+
+// instrumentationName is the OpenTelemetry tracer name.
+const instrumentationName = "github.com/blackhorseya/go-ddd/internal/application/bus"
+
+// MessageName returns the name used for spans, logs and the contextx
+// operation, e.g. "orders.ConfirmOrder". Pointers are dereferenced.
+func MessageName(msg any) string {
+	t := reflect.TypeOf(msg)
+	if t == nil {
+		return "<nil>"
+	}
+	for t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+	return t.String()
+}
+
+// Tracing returns a middleware that runs each message in a span named after
+// its type and records handler errors on the span. It also sets the message
+// name as the contextx operation.
+func Tracing() Middleware {
+	tracer := otel.Tracer(instrumentationName)
+
+	return func(next HandlerFunc) HandlerFunc {
+		return func(ctx context.Context, msg any) (any, error) {
+			name := MessageName(msg)
+			ctx = contextx.WithOperation(ctx, name)
+
+			ctx, span := tracer.Start(ctx, name,
+				trace.WithSpanKind(trace.SpanKindInternal),
+				trace.WithAttributes(attribute.String("bus.message", name)),
+			)
+			defer span.End()
+
+			res, err := next(ctx, msg)
+			if err != nil {
+				span.RecordError(err)
+				span.SetStatus(codes.Error, err.Error())
+			}
+			return res, err
+		}
+	}
+}
+
+// Logging returns a middleware that logs when each message starts and
+// finishes, with its duration and outcome.
+func Logging() Middleware {
+	return func(next HandlerFunc) HandlerFunc {
+		return func(ctx context.Context, msg any) (any, error) {
+			name := MessageName(msg)
+			logger := contextx.From(ctx).WithFields("message", name)
+
+			logger.Debug("message started")
+			start := time.Now()
+
+			res, err := next(ctx, msg)
+
+			logger = logger.WithFields("duration", time.Since(start).String())
+			if err != nil {
+				logger.Error("message failed", "outcome", "error", "error", err)
+				return res, err
+			}
+			logger.Info("message handled", "outcome", "ok")
+			return res, nil
+		}
+	}
+}
@@ -0,0 +1,58 @@
+package bus_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/codes"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+
+	"github.com/blackhorseya/go-ddd/internal/application/bus"
+	"github.com/blackhorseya/go-ddd/pkg/contextx"
+)
+
+func TestMessageName(t *testing.T) {
+	assert.Equal(t, "bus_test.getOrder", bus.MessageName(getOrder{}))
+	assert.Equal(t, "bus_test.getOrder", bus.MessageName(&getOrder{}))
+	assert.Equal(t, "<nil>", bus.MessageName(nil))
+}
+
+func TestTracing(t *testing.T) {
+	// Arrange
+	recorder := tracetest.NewSpanRecorder()
+	provider := sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder))
+	prev := otel.GetTracerProvider()
+	otel.SetTracerProvider(provider)
+	t.Cleanup(func() { otel.SetTracerProvider(prev) })
+
+	var operation string
+	b := bus.NewCommandBus(bus.Tracing(), bus.Logging())
+	bus.RegisterCommand(b, func(ctx context.Context, cmd createOrder) error {
+		operation = contextx.GetOperation(ctx)
+		if cmd.CustomerID == "" {
+			return errors.New("customer required")
+		}
+		return nil
+	})
+
+	// Act
+	okErr := b.Dispatch(context.Background(), createOrder{CustomerID: "c-1"})
+	failErr := b.Dispatch(context.Background(), createOrder{})
+
+	// Assert
+	require.NoError(t, okErr)
+	require.Error(t, failErr)
+	assert.Equal(t, "bus_test.createOrder", operation)
+
+	spans := recorder.Ended()
+	require.Len(t, spans, 2)
+	assert.Equal(t, "bus_test.createOrder", spans[0].Name())
+	assert.Equal(t, codes.Unset, spans[0].Status().Code)
+	assert.Equal(t, codes.Error, spans[1].Status().Code)
+	assert.Len(t, spans[1].Events(), 1)
+}
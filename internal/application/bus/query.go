@@ -0,0 +1,59 @@
+package bus
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+)
+
+// QueryHandler handles a query of type Q and returns a result of type R.
+type QueryHandler[Q, R any] func(ctx context.Context, q Q) (R, error)
+
+// QueryBus routes queries to exactly one handler each.
+// It is safe for concurrent use.
+type QueryBus struct {
+	registry
+}
+
+// NewQueryBus creates a QueryBus with the given middlewares.
+func NewQueryBus(mws ...Middleware) *QueryBus {
+	return &QueryBus{registry: newRegistry("query", mws)}
+}
+
+// Use appends middlewares to the chain.
+func (b *QueryBus) Use(mws ...Middleware) {
+	b.use(mws...)
+}
+
+// RegisterQuery registers the handler for queries of type Q.
+// It panics if a handler for Q is already registered.
+func RegisterQuery[Q, R any](b *QueryBus, h QueryHandler[Q, R]) {
+	b.register(reflect.TypeFor[Q](), func(ctx context.Context, msg any) (any, error) {
+		return h(ctx, msg.(Q))
+	})
+}
+
+// Ask sends q to its handler and returns the untyped result.
+func (b *QueryBus) Ask(ctx context.Context, q any) (any, error) {
+	return b.dispatch(ctx, q)
+}
+
+// Ask sends q to its handler and returns the result as R:
+//
+//	view, err := bus.Ask[*OrderView](ctx, queries, GetOrder{ID: id})
+func Ask[R any](ctx context.Context, b *QueryBus, q any) (R, error) {
+	var zero R
+
+	res, err := b.Ask(ctx, q)
+	if err != nil {
+		return zero, err
+	}
+	if res == nil {
+		return zero, nil
+	}
+	r, ok := res.(R)
+	if !ok {
+		return zero, fmt.Errorf("%w: got %T, want %T", ErrResultType, res, zero)
+	}
+	return r, nil
+}
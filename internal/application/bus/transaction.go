@@ -0,0 +1,94 @@
+package bus
+
+import (
+	"context"
+	"sync"
+
+	"github.com/blackhorseya/go-ddd/internal/domain"
+)
+
+// Aggregate is implemented by aggregates embedding domain.AggregateRoot.
+type Aggregate interface {
+	Events() []domain.DomainEvent
+	ClearEvents()
+}
+
+// EventSink stores domain events in the transaction bound to ctx,
+// e.g. *outbox.Store.
+type EventSink interface {
+	AddEvents(ctx context.Context, events ...domain.DomainEvent) error
+}
+
+// tracker collects the aggregates touched by one command.
+type tracker struct {
+	mu         sync.Mutex
+	aggregates []Aggregate
+}
+
+type trackerKey struct{}
+
+// Track registers aggregates whose recorded events should be written to
+// the outbox when the current command commits. Handlers call it after
+// changing an aggregate:
+//
+//	order.Confirm()
+//	if err := repo.Save(ctx, order); err != nil {
+//		return err
+//	}
+//	bus.Track(ctx, order)
+//
+// Outside the Transactional middleware Track is a no-op and events stay
+// recorded on the aggregate.
+func Track(ctx context.Context, aggregates ...Aggregate) {
+	t, ok := ctx.Value(trackerKey{}).(*tracker)
+	if !ok {
+		return
+	}
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	t.aggregates = append(t.aggregates, aggregates...)
+}
+
+// Transactional returns a command middleware that runs each command in its
+// own unit of work. The transaction commits when the handler succeeds and
+// rolls back when it returns an error or panics. Events recorded by tracked
+// aggregates are written to sink inside the same transaction, so the outbox
+// relay only publishes them after commit; they are cleared from the
+// aggregates once the commit succeeds.
+//
+// Register it after Validation so invalid commands never open a transaction.
+func Transactional(uow domain.UnitOfWork, sink EventSink) Middleware {
+	return func(next HandlerFunc) HandlerFunc {
+		return func(ctx context.Context, msg any) (any, error) {
+			t := &tracker{}
+
+			var res any
+			err := uow.Do(ctx, func(ctx context.Context) error {
+				var err error
+				res, err = next(context.WithValue(ctx, trackerKey{}, t), msg)
+				if err != nil {
+					return err
+				}
+
+				var events []domain.DomainEvent
+				for _, a := range t.aggregates {
+					events = append(events, a.Events()...)
+				}
+				if len(events) == 0 {
+					return nil
+				}
+				return sink.AddEvents(ctx, events...)
+			})
+			if err != nil {
+				return nil, err
+			}
+
+			for _, a := range t.aggregates {
+				a.ClearEvents()
+			}
+			return res, nil
+		}
+	}
+}
@@ -0,0 +1,132 @@
+package bus_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/blackhorseya/go-ddd/internal/application/bus"
+	"github.com/blackhorseya/go-ddd/internal/domain"
+)
+
+// fakeUoW records whether the unit of work committed or rolled back.
+type fakeUoW struct {
+	committed  bool
+	rolledBack bool
+}
+
+type txKey struct{}
+
+func (u *fakeUoW) Do(ctx context.Context, fn func(ctx context.Context) error) (err error) {
+	defer func() {
+		if p := recover(); p != nil {
+			u.rolledBack = true
+			panic(p)
+		}
+	}()
+
+	if err := fn(context.WithValue(ctx, txKey{}, true)); err != nil {
+		u.rolledBack = true
+		return err
+	}
+	u.committed = true
+	return nil
+}
+
+// fakeSink records events and whether they were added inside the transaction.
+type fakeSink struct {
+	events []string
+	inTx   bool
+}
+
+func (s *fakeSink) AddEvents(ctx context.Context, events ...domain.DomainEvent) error {
+	s.inTx, _ = ctx.Value(txKey{}).(bool)
+	for _, e := range events {
+		s.events = append(s.events, e.Name())
+	}
+	return nil
+}
+
+type aggregate struct {
+	domain.AggregateRoot[string]
+}
+
+type shipOrder struct {
+	Fail bool
+}
+
+func TestTransactional(t *testing.T) {
+	tests := []struct {
+		name           string
+		cmd            shipOrder
+		wantErr        bool
+		wantCommitted  bool
+		wantEvents     []string
+		wantEventsLeft int
+	}{
+		{
+			name:          "commits and writes events",
+			cmd:           shipOrder{},
+			wantCommitted: true,
+			wantEvents:    []string{"order.shipped"},
+		},
+		{
+			name:           "rolls back on error",
+			cmd:            shipOrder{Fail: true},
+			wantErr:        true,
+			wantEventsLeft: 1,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Arrange
+			root, err := domain.NewAggregateRoot("order-1")
+			require.NoError(t, err)
+			o := &aggregate{AggregateRoot: root}
+
+			uow := &fakeUoW{}
+			sink := &fakeSink{}
+			b := bus.NewCommandBus(bus.Transactional(uow, sink))
+			bus.RegisterCommand(b, func(ctx context.Context, cmd shipOrder) error {
+				o.Record(domain.NewBaseEvent("order.shipped", "order-1"))
+				bus.Track(ctx, o)
+				if cmd.Fail {
+					return errors.New("stock unavailable")
+				}
+				return nil
+			})
+
+			// Act
+			err = b.Dispatch(context.Background(), tt.cmd)
+
+			// Assert
+			assert.Equal(t, tt.wantErr, err != nil)
+			assert.Equal(t, tt.wantCommitted, uow.committed)
+			assert.Equal(t, !tt.wantCommitted, uow.rolledBack)
+			assert.Equal(t, tt.wantEvents, sink.events)
+			assert.Len(t, o.Events(), tt.wantEventsLeft)
+			if tt.wantEvents != nil {
+				assert.True(t, sink.inTx)
+			}
+		})
+	}
+}
+
+func TestTransactional_Panic(t *testing.T) {
+	// Arrange
+	uow := &fakeUoW{}
+	sink := &fakeSink{}
+	b := bus.NewCommandBus(bus.Transactional(uow, sink))
+	bus.RegisterCommand(b, func(context.Context, shipOrder) error {
+		panic("boom")
+	})
+
+	// Act & Assert
+	assert.Panics(t, func() { _ = b.Dispatch(context.Background(), shipOrder{}) })
+	assert.True(t, uow.rolledBack)
+	assert.Empty(t, sink.events)
+}
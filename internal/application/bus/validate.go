@@ -0,0 +1,132 @@
+package bus
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"strings"
+	"sync"
+
+	"github.com/go-playground/validator/v10"
+
+	"github.com/blackhorseya/go-ddd/internal/domain"
+	"github.com/blackhorseya/go-ddd/pkg/errorx"
+)
+
+// codeValidationFailed matches the HTTP layer's VALIDATION_FAILED code.
+const codeValidationFailed = "VALIDATION_FAILED"
+
+// Validatable is implemented by commands and queries that check their own
+// invariants beyond struct tags.
+type Validatable interface {
+	Validate() error
+}
+
+var (
+	validateOnce sync.Once
+	validate     *validator.Validate
+)
+
+// structValidator returns the shared validator, reporting fields by their
+// JSON names so violations match the request body.
+func structValidator() *validator.Validate {
+	validateOnce.Do(func() {
+		validate = validator.New(validator.WithRequiredStructEnabled())
+		validate.RegisterTagNameFunc(func(f reflect.StructField) string {
+			name, _, _ := strings.Cut(f.Tag.Get("json"), ",")
+			if name == "-" {
+				return ""
+			}
+			if name == "" {
+				return f.Name
+			}
+			return name
+		})
+	})
+	return validate
+}
+
+// Validation returns a middleware that validates messages before they
+// reach the handler: first `validate` struct tags, then Validate() for
+// messages implementing Validatable. Failures are returned as an
+// errorx.KindInvalid error wrapping a *domain.ValidationError, so HTTP
+// responds 400 with field details and gRPC with InvalidArgument.
+func Validation() Middleware {
+	return func(next HandlerFunc) HandlerFunc {
+		return func(ctx context.Context, msg any) (any, error) {
+			if err := validateMessage(msg); err != nil {
+				return nil, err
+			}
+			return next(ctx, msg)
+		}
+	}
+}
+
+// validateMessage runs tag and method validation on msg.
+func validateMessage(msg any) error {
+	var n domain.Notification
+
+	if isStruct(msg) {
+		err := structValidator().Struct(msg)
+		var fieldErrs validator.ValidationErrors
+		if errors.As(err, &fieldErrs) {
+			for _, fe := range fieldErrs {
+				n.Add(fieldPath(fe), fe.Tag(), violationMessage(fe))
+			}
+		} else if err != nil {
+			return errorx.Internal("", "validate message").WithCause(err)
+		}
+	}
+
+	if v, ok := msg.(Validatable); ok && !n.HasErrors() {
+		err := v.Validate()
+		var ve *domain.ValidationError
+		switch {
+		case errors.As(err, &ve):
+			for _, violation := range ve.Violations() {
+				n.Add(violation.Field(), violation.Rule(), violation.Message())
+			}
+		case err != nil:
+			return errorx.Invalid(codeValidationFailed, err.Error()).WithCause(err)
+		}
+	}
+
+	if err := n.Err(); err != nil {
+		return errorx.Invalid(codeValidationFailed, domain.ErrValidation.Error()).WithCause(err)
+	}
+	return nil
+}
+
+// isStruct reports whether msg is a struct or a non-nil pointer to one.
+func isStruct(msg any) bool {
+	v := reflect.ValueOf(msg)
+	if v.Kind() == reflect.Pointer {
+		if v.IsNil() {
+			return false
+		}
+		v = v.Elem()
+	}
+	return v.Kind() == reflect.Struct
+}
+
+// fieldPath returns the field path without the root type name,
+// e.g. "items[0].sku" for "CreateOrder.items[0].sku".
+func fieldPath(fe validator.FieldError) string {
+	_, path, ok := strings.Cut(fe.Namespace(), ".")
+	if !ok {
+		return fe.Field()
+	}
+	return path
+}
+
+// violationMessage describes a failed validation tag.
+func violationMessage(fe validator.FieldError) string {
+	switch {
+	case fe.Tag() == "required":
+		return fe.Field() + " is required"
+	case fe.Param() != "":
+		return fe.Field() + " must satisfy " + fe.Tag() + "=" + fe.Param()
+	default:
+		return fe.Field() + " must satisfy " + fe.Tag()
+	}
+}
@@ -0,0 +1,116 @@
+package bus_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/blackhorseya/go-ddd/internal/application/bus"
+	"github.com/blackhorseya/go-ddd/internal/domain"
+	"github.com/blackhorseya/go-ddd/pkg/errorx"
+)
+
+type placeOrder struct {
+	CustomerID string `json:"customer_id" validate:"required"`
+	Quantity   int    `json:"quantity" validate:"min=1"`
+	Coupon     string `json:"coupon"`
+}
+
+func (c placeOrder) Validate() error {
+	var n domain.Notification
+	n.Check(c.Coupon != "EXPIRED", "coupon", "active", "coupon has expired")
+	return n.Err()
+}
+
+func TestValidation(t *testing.T) {
+	tests := []struct {
+		name       string
+		cmd        placeOrder
+		wantFields []string
+		wantRules  []string
+	}{
+		{
+			name: "valid command",
+			cmd:  placeOrder{CustomerID: "c-1", Quantity: 1},
+		},
+		{
+			name:       "tag violations",
+			cmd:        placeOrder{},
+			wantFields: []string{"customer_id", "quantity"},
+			wantRules:  []string{"required", "min"},
+		},
+		{
+			name:       "validate method violation",
+			cmd:        placeOrder{CustomerID: "c-1", Quantity: 1, Coupon: "EXPIRED"},
+			wantFields: []string{"coupon"},
+			wantRules:  []string{"active"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Arrange
+			handled := false
+			b := bus.NewCommandBus(bus.Validation())
+			bus.RegisterCommand(b, func(context.Context, placeOrder) error {
+				handled = true
+				return nil
+			})
+
+			// Act
+			err := b.Dispatch(context.Background(), tt.cmd)
+
+			// Assert
+			if tt.wantFields == nil {
+				require.NoError(t, err)
+				assert.True(t, handled)
+				return
+			}
+			require.Error(t, err)
+			assert.False(t, handled)
+			assert.ErrorIs(t, err, domain.ErrValidation)
+			assert.Equal(t, errorx.KindInvalid, errorx.KindOf(err))
+
+			var ve *domain.ValidationError
+			require.True(t, errors.As(err, &ve))
+			var fields, rules []string
+			for _, v := range ve.Violations() {
+				fields = append(fields, v.Field())
+				rules = append(rules, v.Rule())
+			}
+			assert.Equal(t, tt.wantFields, fields)
+			assert.Equal(t, tt.wantRules, rules)
+		})
+	}
+}
+
+type lookupOrder struct {
+	ID string
+}
+
+func (q lookupOrder) Validate() error {
+	if q.ID == "" {
+		return errors.New("id is required")
+	}
+	return nil
+}
+
+func TestValidation_PlainError(t *testing.T) {
+	// Arrange
+	b := bus.NewQueryBus(bus.Validation())
+	bus.RegisterQuery(b, func(context.Context, lookupOrder) (string, error) {
+		return "ok", nil
+	})
+
+	// Act
+	_, err := b.Ask(context.Background(), lookupOrder{})
+
+	// Assert
+	e, ok := errorx.As(err)
+	require.True(t, ok)
+	assert.Equal(t, errorx.KindInvalid, e.Kind())
+	assert.Equal(t, "id is required", e.Message())
+}
@@ -0,0 +1,73 @@
+// Package event provides the application-level dispatcher for domain events.
+// Use cases dispatch the events recorded by an aggregate only after the
+// aggregate has been persisted successfully.
+package event
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/blackhorseya/go-ddd/internal/domain"
+	"github.com/blackhorseya/go-ddd/pkg/eventx"
+)
+
+// Handler handles a dispatched domain event.
+type Handler func(ctx context.Context, event domain.DomainEvent) error
+
+// Source is implemented by aggregates that record domain events
+// (any type embedding domain.AggregateRoot).
+type Source interface {
+	PullEvents() []domain.DomainEvent
+}
+
+// Dispatcher routes domain events to subscribed handlers by event name
+// over an in-process eventx.Bus. It is safe for concurrent use.
+type Dispatcher struct {
+	bus *eventx.Bus
+}
+
+// NewDispatcher creates an empty Dispatcher that runs handlers synchronously.
+func NewDispatcher() *Dispatcher {
+	return NewDispatcherOn(eventx.New(eventx.Options{}))
+}
+
+// NewDispatcherOn creates a Dispatcher on bus, e.g. an asynchronous bus
+// with a worker pool. The caller closes the bus.
+func NewDispatcherOn(bus *eventx.Bus) *Dispatcher {
+	return &Dispatcher{bus: bus}
+}
+
+// Subscribe registers a handler for events with the given name.
+func (d *Dispatcher) Subscribe(name string, h Handler) {
+	eventx.Subscribe(d.bus, func(ctx context.Context, e domain.DomainEvent) error {
+		if e.Name() != name {
+			return nil
+		}
+		if err := h(ctx, e); err != nil {
+			return fmt.Errorf("handle %s: %w", name, err)
+		}
+		return nil
+	})
+}
+
+// Dispatch delivers events to their handlers in order. On a synchronous
+// bus all handlers are invoked and their errors joined; a panicking
+// handler is reported as eventx.ErrPanic.
+func (d *Dispatcher) Dispatch(ctx context.Context, events ...domain.DomainEvent) error {
+	anys := make([]any, len(events))
+	for i, e := range events {
+		anys[i] = e
+	}
+	return d.bus.Publish(ctx, anys...)
+}
+
+// DispatchFrom pulls the events recorded by src and dispatches them.
+// Call it after the aggregate has been persisted:
+//
+//	if err := repo.Save(ctx, order); err != nil {
+//		return err
+//	}
+//	return dispatcher.DispatchFrom(ctx, order)
+func (d *Dispatcher) DispatchFrom(ctx context.Context, src Source) error {
+	return d.Dispatch(ctx, src.PullEvents()...)
+}
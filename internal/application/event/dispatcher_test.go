@@ -0,0 +1,75 @@
+package event_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/blackhorseya/go-ddd/internal/application/event"
+	"github.com/blackhorseya/go-ddd/internal/domain"
+	"github.com/blackhorseya/go-ddd/pkg/eventx"
+)
+
+type order struct {
+	domain.AggregateRoot[string]
+}
+
+func TestDispatcher_DispatchFrom(t *testing.T) {
+	// Arrange
+	root, err := domain.NewAggregateRoot("order-1")
+	require.NoError(t, err)
+	o := &order{AggregateRoot: root}
+	o.Record(domain.NewBaseEvent("order.confirmed", "order-1"))
+	o.Record(domain.NewBaseEvent("order.unhandled", "order-1"))
+
+	var received []string
+	d := event.NewDispatcher()
+	d.Subscribe("order.confirmed", func(_ context.Context, e domain.DomainEvent) error {
+		received = append(received, e.AggregateID())
+		return nil
+	})
+
+	// Act
+	err = d.DispatchFrom(context.Background(), o)
+
+	// Assert
+	require.NoError(t, err)
+	assert.Equal(t, []string{"order-1"}, received)
+	assert.Empty(t, o.Events())
+}
+
+func TestDispatcher_JoinsHandlerErrors(t *testing.T) {
+	errFirst := errors.New("first")
+	called := false
+
+	d := event.NewDispatcher()
+	d.Subscribe("order.confirmed", func(context.Context, domain.DomainEvent) error { return errFirst })
+	d.Subscribe("order.confirmed", func(context.Context, domain.DomainEvent) error {
+		called = true
+		return nil
+	})
+
+	err := d.Dispatch(context.Background(), domain.NewBaseEvent("order.confirmed", "order-1"))
+
+	require.ErrorIs(t, err, errFirst)
+	assert.True(t, called, "later handlers must still run")
+}
+
+func TestDispatcher_IsolatesPanics(t *testing.T) {
+	called := false
+
+	d := event.NewDispatcher()
+	d.Subscribe("order.confirmed", func(context.Context, domain.DomainEvent) error { panic("boom") })
+	d.Subscribe("order.confirmed", func(context.Context, domain.DomainEvent) error {
+		called = true
+		return nil
+	})
+
+	err := d.Dispatch(context.Background(), domain.NewBaseEvent("order.confirmed", "order-1"))
+
+	require.ErrorIs(t, err, eventx.ErrPanic)
+	assert.True(t, called, "later handlers must still run")
+}
@@ -0,0 +1,239 @@
+// Package exporter runs exports of datasets too large for a synchronous
+// response. A request creates a pending job and enqueues a task; a worker
+// streams the query results as CSV or Parquet into object storage, and the
+// client polls the job and downloads the file through a presigned URL.
+//
+//	orders := exporter.New(exporter.Definition[OrderRow]{
+//		Name:   "orders",
+//		Query:  repo.StreamOrders,
+//		Header: []string{"id", "status", "total"},
+//		Record: OrderRow.Strings,
+//	}, jobs, files, queue)
+//
+//	// API
+//	job, err := orders.Request(ctx, exporter.FormatCSV, params)
+//
+//	// worker
+//	orders.Handle(taskServer)
+package exporter
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"time"
+
+	"github.com/blackhorseya/go-ddd/internal/application/storage"
+	"github.com/blackhorseya/go-ddd/internal/application/task"
+	"github.com/blackhorseya/go-ddd/pkg/contextx"
+	"github.com/blackhorseya/go-ddd/pkg/idx"
+)
+
+// Definition describes the rows of an export and where they come from.
+type Definition[T any] struct {
+	// Name identifies the exporter, e.g. "orders". It also names the task
+	// type ("export:orders") and the storage prefix ("exports/orders/").
+	Name string
+
+	// Query streams the rows to export, calling emit once per row.
+	// params are the filters supplied with the request.
+	Query func(ctx context.Context, params json.RawMessage, emit func(row T) error) error
+
+	// Header and Record encode rows as CSV. Required for CSV exports.
+	Header []string
+	Record func(row T) []string
+
+	// Task sets the queue options of the export task, e.g. Queue or Timeout.
+	Task task.Options
+}
+
+// Requester creates export jobs. It is implemented by *Exporter and lets
+// adapters hold exporters of different row types.
+type Requester interface {
+	Name() string
+	Request(ctx context.Context, format Format, params json.RawMessage) (Job, error)
+}
+
+// TaskHandler runs export tasks. It is implemented by *Exporter and lets
+// workers hold exporters of different row types.
+type TaskHandler interface {
+	Name() string
+	Handle(r task.Registrar)
+}
+
+// payload is the task payload of an export.
+type payload struct {
+	JobID  string          `json:"job_id"`
+	Params json.RawMessage `json:"params,omitempty"`
+}
+
+// Exporter exports rows of type T according to a Definition.
+type Exporter[T any] struct {
+	def   Definition[T]
+	jobs  JobStore
+	store storage.Store
+	queue task.Enqueuer
+	task  task.Task[payload]
+	now   func() time.Time
+}
+
+var (
+	_ Requester   = (*Exporter[struct{}])(nil)
+	_ TaskHandler = (*Exporter[struct{}])(nil)
+)
+
+// New creates an Exporter. queue may be nil on workers that only handle exports.
+func New[T any](def Definition[T], jobs JobStore, store storage.Store, queue task.Enqueuer) *Exporter[T] {
+	return &Exporter[T]{
+		def:   def,
+		jobs:  jobs,
+		store: store,
+		queue: queue,
+		task:  task.New[payload]("export:"+def.Name, def.Task),
+		now:   time.Now,
+	}
+}
+
+// Name returns the exporter name.
+func (e *Exporter[T]) Name() string {
+	return e.def.Name
+}
+
+// Request creates a pending job and enqueues the export.
+func (e *Exporter[T]) Request(ctx context.Context, format Format, params json.RawMessage) (Job, error) {
+	if err := e.supports(format); err != nil {
+		return Job{}, err
+	}
+
+	id := idx.ULID()
+	job := Job{
+		ID:        id,
+		Exporter:  e.def.Name,
+		Format:    format,
+		Status:    StatusPending,
+		Key:       fmt.Sprintf("exports/%s/%s.%s", e.def.Name, id, format.Extension()),
+		CreatedAt: e.now(),
+	}
+	if err := e.jobs.Create(ctx, job); err != nil {
+		return Job{}, fmt.Errorf("create export job: %w", err)
+	}
+
+	opts := e.def.Task
+	opts.ID = id
+	if err := e.task.EnqueueWith(ctx, e.queue, payload{JobID: id, Params: params}, opts); err != nil {
+		_ = e.finish(ctx, &job, err)
+		return Job{}, fmt.Errorf("enqueue export job: %w", err)
+	}
+	return job, nil
+}
+
+// Handle registers the export task handler on a worker.
+func (e *Exporter[T]) Handle(r task.Registrar) {
+	task.Handle(r, e.task, e.run)
+}
+
+// run executes an export job. Jobs that are already done are skipped so a
+// redelivered task is harmless. Failures are recorded on the job and not
+// retried; the client requests a new export instead.
+func (e *Exporter[T]) run(ctx context.Context, p payload) error {
+	job, err := e.jobs.Get(ctx, p.JobID)
+	if errors.Is(err, ErrJobNotFound) {
+		return fmt.Errorf("export job %s: %w", p.JobID, task.ErrSkipRetry)
+	}
+	if err != nil {
+		return err
+	}
+	if job.Status.IsDone() {
+		return nil
+	}
+
+	job.Status = StatusRunning
+	if err := e.jobs.Update(ctx, job); err != nil {
+		return fmt.Errorf("update export job: %w", err)
+	}
+
+	rows, err := e.export(ctx, job, p.Params)
+	job.Rows = rows
+	if ferr := e.finish(ctx, &job, err); ferr != nil {
+		return ferr
+	}
+
+	logger := contextx.From(ctx)
+	if err != nil {
+		logger.Error("export failed", "exporter", e.def.Name, "job_id", job.ID, "error", err)
+		return fmt.Errorf("export %s: %w: %w", job.ID, err, task.ErrSkipRetry)
+	}
+	logger.Info("export completed", "exporter", e.def.Name, "job_id", job.ID, "rows", rows)
+	return nil
+}
+
+// export streams the query results through an encoder into storage.
+func (e *Exporter[T]) export(ctx context.Context, job Job, params json.RawMessage) (int, error) {
+	pr, pw := io.Pipe()
+
+	var rows int
+	done := make(chan error, 1)
+	go func() {
+		err := e.write(ctx, job.Format, params, pw, &rows)
+		_ = pw.CloseWithError(err)
+		done <- err
+	}()
+
+	err := e.store.Put(ctx, job.Key, pr, job.Format.ContentType())
+	// Unblock the writer if storage stopped reading early.
+	_ = pr.CloseWithError(err)
+	if werr := <-done; werr != nil {
+		return rows, werr
+	}
+	if err != nil {
+		return rows, fmt.Errorf("store export file: %w", err)
+	}
+	return rows, nil
+}
+
+// write encodes every row returned by the query to w.
+func (e *Exporter[T]) write(ctx context.Context, format Format, params json.RawMessage, w io.Writer, rows *int) error {
+	enc, err := newRowWriter(e.def, format, w)
+	if err != nil {
+		return err
+	}
+	err = e.def.Query(ctx, params, func(row T) error {
+		if err := enc.Write(row); err != nil {
+			return err
+		}
+		*rows++
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+	return enc.Close()
+}
+
+// finish marks job completed, or failed with err, and saves it.
+func (e *Exporter[T]) finish(ctx context.Context, job *Job, err error) error {
+	finished := e.now()
+	job.FinishedAt = &finished
+	job.Status = StatusCompleted
+	if err != nil {
+		job.Status = StatusFailed
+		job.Error = err.Error()
+	}
+	if uerr := e.jobs.Update(context.WithoutCancel(ctx), *job); uerr != nil {
+		return fmt.Errorf("update export job: %w", uerr)
+	}
+	return nil
+}
+
+// supports reports whether the exporter can write format.
+func (e *Exporter[T]) supports(format Format) error {
+	switch {
+	case format == FormatCSV && e.def.Record != nil:
+		return nil
+	case format == FormatParquet && isStruct[T]():
+		return nil
+	}
+	return fmt.Errorf("%w: %s does not produce %q", ErrUnsupportedFormat, e.def.Name, format)
+}
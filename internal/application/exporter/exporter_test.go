@@ -0,0 +1,204 @@
+package exporter_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"strconv"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/parquet-go/parquet-go"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/blackhorseya/go-ddd/internal/application/exporter"
+	"github.com/blackhorseya/go-ddd/internal/application/storage"
+	"github.com/blackhorseya/go-ddd/internal/application/task"
+)
+
+type orderRow struct {
+	ID    string `parquet:"id"`
+	Total int64  `parquet:"total"`
+}
+
+// queue delivers enqueued tasks to registered handlers on demand.
+type queue struct {
+	handlers map[string]task.HandlerFunc
+	pending  []func(ctx context.Context) error
+	fail     error
+}
+
+func newQueue() *queue { return &queue{handlers: make(map[string]task.HandlerFunc)} }
+
+func (q *queue) HandleFunc(taskType string, h task.HandlerFunc) { q.handlers[taskType] = h }
+
+func (q *queue) Enqueue(_ context.Context, taskType string, payload []byte, _ task.Options) error {
+	if q.fail != nil {
+		return q.fail
+	}
+	q.pending = append(q.pending, func(ctx context.Context) error {
+		return q.handlers[taskType](ctx, payload)
+	})
+	return nil
+}
+
+// drain runs the pending tasks, returning the last error.
+func (q *queue) drain(ctx context.Context) error {
+	var err error
+	for _, run := range q.pending {
+		if e := run(ctx); e != nil {
+			err = e
+		}
+	}
+	q.pending = nil
+	return err
+}
+
+// objects is an in-memory storage.Store.
+type objects struct {
+	mu    sync.Mutex
+	files map[string][]byte
+}
+
+func (o *objects) Put(_ context.Context, key string, r io.Reader, _ string) error {
+	b, err := io.ReadAll(r)
+	if err != nil {
+		return err
+	}
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	o.files[key] = b
+	return nil
+}
+
+func (o *objects) PresignGet(_ context.Context, key string, ttl time.Duration) (string, error) {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	if _, ok := o.files[key]; !ok {
+		return "", storage.ErrNotFound
+	}
+	return "https://files.test/" + key + "?ttl=" + ttl.String(), nil
+}
+
+type filter struct {
+	Min int64 `json:"min"`
+}
+
+func newExporter(jobs exporter.JobStore, files storage.Store, q *queue, queryErr error) *exporter.Exporter[orderRow] {
+	rows := []orderRow{{"o1", 100}, {"o2", 250}, {"o3", 50}}
+	e := exporter.New(exporter.Definition[orderRow]{
+		Name: "orders",
+		Query: func(_ context.Context, params json.RawMessage, emit func(orderRow) error) error {
+			var f filter
+			if len(params) > 0 {
+				if err := json.Unmarshal(params, &f); err != nil {
+					return err
+				}
+			}
+			for _, r := range rows {
+				if r.Total < f.Min {
+					continue
+				}
+				if err := emit(r); err != nil {
+					return err
+				}
+			}
+			return queryErr
+		},
+		Header: []string{"id", "total"},
+		Record: func(r orderRow) []string { return []string{r.ID, strconv.FormatInt(r.Total, 10)} },
+	}, jobs, files, q)
+	e.Handle(q)
+	return e
+}
+
+func TestExporter_CSV(t *testing.T) {
+	ctx := context.Background()
+	jobs, files, q := exporter.NewMemoryJobStore(), &objects{files: map[string][]byte{}}, newQueue()
+	e := newExporter(jobs, files, q, nil)
+	svc := exporter.NewService(jobs, files, time.Minute)
+
+	job, err := e.Request(ctx, exporter.FormatCSV, json.RawMessage(`{"min":100}`))
+	require.NoError(t, err)
+	assert.Equal(t, exporter.StatusPending, job.Status)
+
+	_, err = svc.DownloadURL(ctx, job.ID)
+	assert.ErrorIs(t, err, exporter.ErrJobNotReady)
+
+	require.NoError(t, q.drain(ctx))
+
+	got, err := svc.Get(ctx, job.ID)
+	require.NoError(t, err)
+	assert.Equal(t, exporter.StatusCompleted, got.Status)
+	assert.Equal(t, 2, got.Rows)
+	assert.NotNil(t, got.FinishedAt)
+	assert.Equal(t, "id,total\no1,100\no2,250\n", string(files.files[got.Key]))
+
+	url, err := svc.DownloadURL(ctx, job.ID)
+	require.NoError(t, err)
+	assert.Equal(t, "https://files.test/exports/orders/"+job.ID+".csv?ttl=1m0s", url)
+}
+
+func TestExporter_Parquet(t *testing.T) {
+	ctx := context.Background()
+	jobs, files, q := exporter.NewMemoryJobStore(), &objects{files: map[string][]byte{}}, newQueue()
+	e := newExporter(jobs, files, q, nil)
+
+	job, err := e.Request(ctx, exporter.FormatParquet, nil)
+	require.NoError(t, err)
+	require.NoError(t, q.drain(ctx))
+
+	b := files.files[job.Key]
+	rows, err := parquet.Read[orderRow](bytes.NewReader(b), int64(len(b)))
+	require.NoError(t, err)
+	assert.Equal(t, []orderRow{{"o1", 100}, {"o2", 250}, {"o3", 50}}, rows)
+}
+
+func TestExporter_QueryFails(t *testing.T) {
+	ctx := context.Background()
+	jobs, files, q := exporter.NewMemoryJobStore(), &objects{files: map[string][]byte{}}, newQueue()
+	e := newExporter(jobs, files, q, errors.New("connection reset"))
+
+	job, err := e.Request(ctx, exporter.FormatCSV, nil)
+	require.NoError(t, err)
+
+	err = q.drain(ctx)
+	assert.ErrorIs(t, err, task.ErrSkipRetry)
+
+	got, err := jobs.Get(ctx, job.ID)
+	require.NoError(t, err)
+	assert.Equal(t, exporter.StatusFailed, got.Status)
+	assert.Equal(t, "connection reset", got.Error)
+	assert.Empty(t, files.files)
+
+	// A redelivered task leaves the finished job alone.
+	require.NoError(t, q.handlers["export:orders"](ctx, []byte(`{"job_id":"`+job.ID+`"}`)))
+}
+
+func TestExporter_Request_Errors(t *testing.T) {
+	ctx := context.Background()
+	jobs, files, q := exporter.NewMemoryJobStore(), &objects{files: map[string][]byte{}}, newQueue()
+
+	untyped := exporter.New(exporter.Definition[string]{Name: "names"}, jobs, files, q)
+	_, err := untyped.Request(ctx, exporter.FormatCSV, nil)
+	assert.ErrorIs(t, err, exporter.ErrUnsupportedFormat)
+	_, err = untyped.Request(ctx, exporter.FormatParquet, nil)
+	assert.ErrorIs(t, err, exporter.ErrUnsupportedFormat)
+
+	q.fail = errors.New("redis down")
+	_, err = newExporter(jobs, files, q, nil).Request(ctx, exporter.FormatCSV, nil)
+	assert.ErrorContains(t, err, "redis down")
+}
+
+func TestParseFormat(t *testing.T) {
+	got, err := exporter.ParseFormat("Parquet")
+	require.NoError(t, err)
+	assert.Equal(t, exporter.FormatParquet, got)
+
+	_, err = exporter.ParseFormat("xlsx")
+	assert.ErrorIs(t, err, exporter.ErrUnsupportedFormat)
+}
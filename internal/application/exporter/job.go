@@ -0,0 +1,56 @@
+package exporter
+
+import (
+	"context"
+	"errors"
+	"time"
+)
+
+var (
+	// ErrJobNotFound is returned by stores when a job does not exist.
+	ErrJobNotFound = errors.New("export job not found")
+
+	// ErrJobNotReady is returned when downloading a job that has not completed.
+	ErrJobNotReady = errors.New("export job is not completed")
+)
+
+// Status is the lifecycle state of an export job.
+type Status string
+
+const (
+	StatusPending   Status = "pending"
+	StatusRunning   Status = "running"
+	StatusCompleted Status = "completed"
+	StatusFailed    Status = "failed"
+)
+
+// IsDone reports whether a job in this status will make no further progress.
+func (s Status) IsDone() bool {
+	return s == StatusCompleted || s == StatusFailed
+}
+
+// Job tracks one export. Key locates the file in storage once completed.
+type Job struct {
+	ID         string     `json:"id"`
+	Exporter   string     `json:"exporter"`
+	Format     Format     `json:"format"`
+	Status     Status     `json:"status"`
+	Rows       int        `json:"rows"`
+	Key        string     `json:"-"`
+	Error      string     `json:"error,omitempty"`
+	CreatedAt  time.Time  `json:"created_at"`
+	FinishedAt *time.Time `json:"finished_at,omitempty"`
+}
+
+// JobStore persists export jobs. It is shared by the API, which creates
+// and reads jobs, and the worker, which runs them.
+type JobStore interface {
+	// Create stores a new job.
+	Create(ctx context.Context, job Job) error
+
+	// Update replaces an existing job.
+	Update(ctx context.Context, job Job) error
+
+	// Get returns the job with the given ID.
+	Get(ctx context.Context, id string) (Job, error)
+}
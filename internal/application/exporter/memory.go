@@ -0,0 +1,53 @@
+package exporter
+
+import (
+	"context"
+	"sync"
+)
+
+// MemoryJobStore is an in-process JobStore for tests and single instances.
+// It is safe for concurrent use.
+type MemoryJobStore struct {
+	mu   sync.Mutex
+	jobs map[string]Job
+}
+
+var _ JobStore = (*MemoryJobStore)(nil)
+
+// NewMemoryJobStore creates an empty MemoryJobStore.
+func NewMemoryJobStore() *MemoryJobStore {
+	return &MemoryJobStore{jobs: make(map[string]Job)}
+}
+
+// Create stores a new job.
+func (s *MemoryJobStore) Create(_ context.Context, job Job) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.jobs[job.ID] = job
+	return nil
+}
+
+// Update replaces an existing job.
+func (s *MemoryJobStore) Update(_ context.Context, job Job) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if _, ok := s.jobs[job.ID]; !ok {
+		return ErrJobNotFound
+	}
+	s.jobs[job.ID] = job
+	return nil
+}
+
+// Get returns the job with the given ID.
+func (s *MemoryJobStore) Get(_ context.Context, id string) (Job, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	job, ok := s.jobs[id]
+	if !ok {
+		return Job{}, ErrJobNotFound
+	}
+	return job, nil
+}
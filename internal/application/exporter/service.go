@@ -0,0 +1,44 @@
+package exporter
+
+import (
+	"context"
+	"time"
+
+	"github.com/blackhorseya/go-ddd/internal/application/storage"
+)
+
+// DefaultURLTTL is how long a download URL stays valid.
+const DefaultURLTTL = 15 * time.Minute
+
+// Service answers job status and download queries for all exporters.
+type Service struct {
+	jobs  JobStore
+	store storage.Store
+	ttl   time.Duration
+}
+
+// NewService creates a Service issuing download URLs valid for ttl (default 15m).
+func NewService(jobs JobStore, store storage.Store, ttl time.Duration) *Service {
+	if ttl <= 0 {
+		ttl = DefaultURLTTL
+	}
+	return &Service{jobs: jobs, store: store, ttl: ttl}
+}
+
+// Get returns the job with the given ID.
+func (s *Service) Get(ctx context.Context, id string) (Job, error) {
+	return s.jobs.Get(ctx, id)
+}
+
+// DownloadURL returns a presigned URL for a completed job's file, or
+// ErrJobNotReady while the job is pending, running or failed.
+func (s *Service) DownloadURL(ctx context.Context, id string) (string, error) {
+	job, err := s.jobs.Get(ctx, id)
+	if err != nil {
+		return "", err
+	}
+	if job.Status != StatusCompleted {
+		return "", ErrJobNotReady
+	}
+	return s.store.PresignGet(ctx, job.Key, s.ttl)
+}
@@ -0,0 +1,98 @@
+package exporter
+
+import (
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"reflect"
+	"strings"
+
+	"github.com/parquet-go/parquet-go"
+)
+
+// ErrUnsupportedFormat is returned for formats an exporter cannot write.
+var ErrUnsupportedFormat = errors.New("unsupported export format")
+
+// Format is the encoding of an export file.
+type Format string
+
+const (
+	// FormatCSV is comma-separated values with a header row.
+	FormatCSV Format = "csv"
+
+	// FormatParquet is Apache Parquet; the schema comes from the row struct's parquet tags.
+	FormatParquet Format = "parquet"
+)
+
+// ParseFormat parses a format name such as "csv" or "parquet".
+func ParseFormat(s string) (Format, error) {
+	switch f := Format(strings.ToLower(strings.TrimSpace(s))); f {
+	case FormatCSV, FormatParquet:
+		return f, nil
+	default:
+		return "", fmt.Errorf("%w: %q", ErrUnsupportedFormat, s)
+	}
+}
+
+// Extension returns the file extension for the format.
+func (f Format) Extension() string { return string(f) }
+
+// ContentType returns the MIME type of the format.
+func (f Format) ContentType() string {
+	if f == FormatParquet {
+		return "application/vnd.apache.parquet"
+	}
+	return "text/csv"
+}
+
+// rowWriter encodes rows to an underlying writer. Close flushes buffered
+// data but does not close the underlying writer.
+type rowWriter[T any] interface {
+	Write(row T) error
+	Close() error
+}
+
+// newRowWriter returns a writer for format.
+func newRowWriter[T any](def Definition[T], format Format, w io.Writer) (rowWriter[T], error) {
+	switch format {
+	case FormatCSV:
+		cw := csv.NewWriter(w)
+		if err := cw.Write(def.Header); err != nil {
+			return nil, err
+		}
+		return &csvWriter[T]{w: cw, record: def.Record}, nil
+	case FormatParquet:
+		return &parquetWriter[T]{w: parquet.NewGenericWriter[T](w)}, nil
+	default:
+		return nil, fmt.Errorf("%w: %q", ErrUnsupportedFormat, format)
+	}
+}
+
+// isStruct reports whether T is a struct, as required for a parquet schema.
+func isStruct[T any]() bool {
+	return reflect.TypeFor[T]().Kind() == reflect.Struct
+}
+
+type csvWriter[T any] struct {
+	w      *csv.Writer
+	record func(T) []string
+}
+
+func (c *csvWriter[T]) Write(row T) error { return c.w.Write(c.record(row)) }
+
+func (c *csvWriter[T]) Close() error {
+	c.w.Flush()
+	return c.w.Error()
+}
+
+type parquetWriter[T any] struct {
+	w *parquet.GenericWriter[T]
+}
+
+func (p *parquetWriter[T]) Write(row T) error {
+	_, err := p.w.Write([]T{row})
+	return err
+}
+
+func (p *parquetWriter[T]) Close() error { return p.w.Close() }
@@ -0,0 +1,355 @@
+// Package importer runs bulk imports of CSV and JSONL files.
+// Rows are parsed as a stream, validated one by one and persisted in
+// batches, each batch in its own transaction. Rejected rows are collected
+// into an error report and progress is tracked on a Job so clients can
+// poll the status while the import runs in the background.
+//
+//	products := importer.New(importer.Definition[Product]{
+//		Name:     "products",
+//		FromCSV:  productFromCSV,
+//		Validate: Product.Validate,
+//		Persist:  repo.SaveAll,
+//	}, uow, jobs)
+//
+//	job, err := products.Start(ctx, importer.FormatCSV, file)
+package importer
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"time"
+
+	"github.com/blackhorseya/go-ddd/internal/domain"
+	"github.com/blackhorseya/go-ddd/pkg/contextx"
+	"github.com/blackhorseya/go-ddd/pkg/idx"
+)
+
+// Defaults for Definition.
+const (
+	DefaultBatchSize = 500
+	DefaultMaxErrors = 1000
+)
+
+// Definition describes how rows become items of type T and how they are stored.
+type Definition[T any] struct {
+	// Name identifies the importer, e.g. "products".
+	Name string
+
+	// FromCSV builds an item from a CSV row keyed by header name.
+	// Required for CSV imports.
+	FromCSV func(fields map[string]string) (T, error)
+
+	// FromJSON builds an item from a JSONL line.
+	// Default: json.Unmarshal into T.
+	FromJSON func(raw []byte) (T, error)
+
+	// Validate checks an item before it is persisted. A *domain.ValidationError
+	// adds one report entry per violation. Optional.
+	Validate func(item T) error
+
+	// Persist stores a batch of valid items. It runs inside a transaction;
+	// if it fails, every row of the batch is reported as failed.
+	Persist func(ctx context.Context, items []T) error
+
+	// BatchSize is the number of items persisted per transaction.
+	// Default: 500
+	BatchSize int
+
+	// MaxErrors caps the number of entries kept in the error report.
+	// Failed rows are still counted beyond the cap. Default: 1000
+	MaxErrors int
+}
+
+// Starter starts imports in the background. It is implemented by *Importer
+// and lets adapters hold importers of different item types.
+type Starter interface {
+	Name() string
+	Start(ctx context.Context, format Format, r io.Reader) (Job, error)
+}
+
+// Importer imports files according to a Definition.
+type Importer[T any] struct {
+	def  Definition[T]
+	uow  domain.UnitOfWork
+	jobs JobStore
+	now  func() time.Time
+}
+
+var _ Starter = (*Importer[struct{}])(nil)
+
+// New creates an Importer, applying defaults for zero values.
+func New[T any](def Definition[T], uow domain.UnitOfWork, jobs JobStore) *Importer[T] {
+	if def.BatchSize <= 0 {
+		def.BatchSize = DefaultBatchSize
+	}
+	if def.MaxErrors <= 0 {
+		def.MaxErrors = DefaultMaxErrors
+	}
+	if def.FromJSON == nil {
+		def.FromJSON = func(raw []byte) (T, error) {
+			var item T
+			err := json.Unmarshal(raw, &item)
+			return item, err
+		}
+	}
+	return &Importer[T]{def: def, uow: uow, jobs: jobs, now: time.Now}
+}
+
+// Name returns the importer name.
+func (im *Importer[T]) Name() string {
+	return im.def.Name
+}
+
+// Start creates a pending job and imports r in the background.
+// r is copied to a temporary file first, so it may be closed once Start
+// returns (e.g. an HTTP request body). The import outlives ctx cancellation.
+func (im *Importer[T]) Start(ctx context.Context, format Format, r io.Reader) (Job, error) {
+	if err := im.supports(format); err != nil {
+		return Job{}, err
+	}
+
+	f, err := spool(r)
+	if err != nil {
+		return Job{}, err
+	}
+
+	job, err := im.create(ctx, format)
+	if err != nil {
+		_ = f.Close()
+		_ = os.Remove(f.Name())
+		return Job{}, err
+	}
+
+	go func() {
+		defer os.Remove(f.Name())
+		defer f.Close()
+
+		_, _ = im.process(context.WithoutCancel(ctx), job, format, f)
+	}()
+
+	return job, nil
+}
+
+// Run imports r synchronously and returns the finished job.
+// The returned error is non-nil only if the job itself failed;
+// rejected rows are reported on the job instead.
+func (im *Importer[T]) Run(ctx context.Context, format Format, r io.Reader) (Job, error) {
+	if err := im.supports(format); err != nil {
+		return Job{}, err
+	}
+
+	job, err := im.create(ctx, format)
+	if err != nil {
+		return Job{}, err
+	}
+	return im.process(ctx, job, format, r)
+}
+
+// supports reports whether the importer can read format.
+func (im *Importer[T]) supports(format Format) error {
+	switch format {
+	case FormatJSONL:
+		return nil
+	case FormatCSV:
+		if im.def.FromCSV != nil {
+			return nil
+		}
+	}
+	return fmt.Errorf("%w: %s does not accept %q", ErrUnsupportedFormat, im.def.Name, format)
+}
+
+// create stores a new pending job.
+func (im *Importer[T]) create(ctx context.Context, format Format) (Job, error) {
+	job := Job{
+		ID:        idx.ULID(),
+		Importer:  im.def.Name,
+		Format:    format,
+		Status:    StatusPending,
+		CreatedAt: im.now(),
+	}
+	if err := im.jobs.Create(ctx, job); err != nil {
+		return Job{}, fmt.Errorf("create import job: %w", err)
+	}
+	return job, nil
+}
+
+// process reads every row, persists valid items in batches and records progress.
+func (im *Importer[T]) process(ctx context.Context, job Job, format Format, r io.Reader) (Job, error) {
+	logger := contextx.From(ctx)
+	run := &run[T]{im: im, job: job}
+
+	run.job.Status = StatusRunning
+	err := im.jobs.Update(ctx, run.job)
+	if err == nil {
+		err = run.read(ctx, format, r)
+	}
+	if err == nil {
+		err = run.flush(ctx)
+	}
+
+	finished := im.now()
+	run.job.FinishedAt = &finished
+	run.job.Status = StatusCompleted
+	if err != nil {
+		run.job.Status = StatusFailed
+		run.job.Error = err.Error()
+	}
+
+	// Record the outcome even if the caller's context has been cancelled.
+	if uerr := im.jobs.Update(context.WithoutCancel(ctx), run.job, run.errs...); uerr != nil && err == nil {
+		err = fmt.Errorf("update import job: %w", uerr)
+	}
+
+	if err != nil {
+		logger.Error("import failed", "importer", im.def.Name, "job_id", job.ID, "error", err)
+		return run.job, err
+	}
+	logger.Info("import completed",
+		"importer", im.def.Name,
+		"job_id", job.ID,
+		"processed", run.job.Processed,
+		"imported", run.job.Imported,
+		"failed", run.job.Failed,
+	)
+	return run.job, nil
+}
+
+// run holds the state of one import while it is processed.
+type run[T any] struct {
+	im       *Importer[T]
+	job      Job
+	batch    []T
+	lines    []int
+	errs     []RowError // not yet saved to the job store
+	reported int        // report entries saved or pending
+}
+
+// read streams rows, flushing whenever a batch or the pending errors fill up.
+func (r *run[T]) read(ctx context.Context, format Format, in io.Reader) error {
+	rows, err := newRowReader(format, in)
+	if err != nil {
+		return err
+	}
+
+	size := r.im.def.BatchSize
+	for {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+
+		row, err := rows.Next()
+		if errors.Is(err, io.EOF) {
+			return nil
+		}
+		var re *rowError
+		switch {
+		case errors.As(err, &re):
+			r.job.Processed++
+			r.reject(RowError{Line: re.line, Message: re.Error()})
+		case err != nil:
+			return fmt.Errorf("read %s: %w", format, err)
+		default:
+			r.job.Processed++
+			r.accept(format, row)
+		}
+
+		if len(r.batch) >= size || len(r.errs) >= size {
+			if err := r.flush(ctx); err != nil {
+				return err
+			}
+		}
+	}
+}
+
+// accept decodes and validates a row, adding it to the batch or the report.
+func (r *run[T]) accept(format Format, row Row) {
+	def := r.im.def
+
+	var item T
+	var err error
+	if format == FormatCSV {
+		item, err = def.FromCSV(row.Fields)
+	} else {
+		item, err = def.FromJSON(row.Raw)
+	}
+	if err == nil && def.Validate != nil {
+		err = def.Validate(item)
+	}
+	if err != nil {
+		r.reject(rowErrors(row.Line, err)...)
+		return
+	}
+
+	r.batch = append(r.batch, item)
+	r.lines = append(r.lines, row.Line)
+}
+
+// reject counts a failed row and adds its errors to the report, up to MaxErrors.
+func (r *run[T]) reject(errs ...RowError) {
+	r.job.Failed++
+	for _, e := range errs {
+		if r.reported >= r.im.def.MaxErrors {
+			return
+		}
+		r.errs = append(r.errs, e)
+		r.reported++
+	}
+}
+
+// flush persists the current batch in a transaction and saves progress.
+func (r *run[T]) flush(ctx context.Context) error {
+	if len(r.batch) > 0 {
+		batch := r.batch
+		err := r.im.uow.Do(ctx, func(ctx context.Context) error {
+			return r.im.def.Persist(ctx, batch)
+		})
+		if err != nil {
+			for _, line := range r.lines {
+				r.reject(RowError{Line: line, Message: err.Error()})
+			}
+		} else {
+			r.job.Imported += len(batch)
+		}
+		r.batch, r.lines = nil, nil
+	}
+
+	if err := r.im.jobs.Update(ctx, r.job, r.errs...); err != nil {
+		return fmt.Errorf("update import job: %w", err)
+	}
+	r.errs = nil
+	return nil
+}
+
+// spool copies r to a temporary file positioned at its start.
+func spool(r io.Reader) (*os.File, error) {
+	f, err := os.CreateTemp("", "import-*")
+	if err != nil {
+		return nil, fmt.Errorf("spool import file: %w", err)
+	}
+	if _, err = io.Copy(f, r); err == nil {
+		_, err = f.Seek(0, io.SeekStart)
+	}
+	if err != nil {
+		_ = f.Close()
+		_ = os.Remove(f.Name())
+		return nil, fmt.Errorf("spool import file: %w", err)
+	}
+	return f, nil
+}
+
+// rowErrors converts a decode or validation error into report entries.
+func rowErrors(line int, err error) []RowError {
+	var verr *domain.ValidationError
+	if errors.As(err, &verr) {
+		out := make([]RowError, len(verr.Violations()))
+		for i, v := range verr.Violations() {
+			out[i] = RowError{Line: line, Field: v.Field(), Message: v.Message()}
+		}
+		return out
+	}
+	return []RowError{{Line: line, Message: err.Error()}}
+}
@@ -0,0 +1,233 @@
+package importer_test
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/blackhorseya/go-ddd/internal/application/importer"
+	"github.com/blackhorseya/go-ddd/internal/domain"
+)
+
+type product struct {
+	SKU   string `json:"sku"`
+	Price int    `json:"price"`
+}
+
+func productFromCSV(fields map[string]string) (product, error) {
+	price, err := strconv.Atoi(fields["price"])
+	if err != nil {
+		return product{}, errors.New("price must be a number")
+	}
+	return product{SKU: fields["sku"], Price: price}, nil
+}
+
+func validateProduct(p product) error {
+	var n domain.Notification
+	n.Check(p.SKU != "", "sku", "required", "sku is required")
+	n.Check(p.Price > 0, "price", "positive", "price must be positive")
+	return n.Err()
+}
+
+// fakeUoW counts transactions.
+type fakeUoW struct {
+	commits int
+}
+
+func (u *fakeUoW) Do(ctx context.Context, fn func(ctx context.Context) error) error {
+	if err := fn(ctx); err != nil {
+		return err
+	}
+	u.commits++
+	return nil
+}
+
+// repo stores persisted products and can fail batches containing a SKU.
+type repo struct {
+	saved  []product
+	failOn string
+}
+
+func (r *repo) SaveAll(_ context.Context, items []product) error {
+	for _, p := range items {
+		if p.SKU == r.failOn {
+			return errors.New("duplicate sku " + p.SKU)
+		}
+	}
+	r.saved = append(r.saved, items...)
+	return nil
+}
+
+func newImporter(r *repo, uow *fakeUoW, jobs importer.JobStore) *importer.Importer[product] {
+	return importer.New(importer.Definition[product]{
+		Name:      "products",
+		FromCSV:   productFromCSV,
+		Validate:  validateProduct,
+		Persist:   r.SaveAll,
+		BatchSize: 2,
+	}, uow, jobs)
+}
+
+func TestImporter_RunCSV(t *testing.T) {
+	r, uow, jobs := &repo{}, &fakeUoW{}, importer.NewMemoryJobStore()
+	im := newImporter(r, uow, jobs)
+
+	file := "sku,price\n" +
+		"a,10\n" +
+		"b,abc\n" +
+		",0\n" +
+		"c,30\n" +
+		"d,40,extra\n" +
+		"e,50\n"
+
+	job, err := im.Run(context.Background(), importer.FormatCSV, strings.NewReader(file))
+	require.NoError(t, err)
+
+	assert.Equal(t, importer.StatusCompleted, job.Status)
+	assert.Equal(t, 6, job.Processed)
+	assert.Equal(t, 3, job.Imported)
+	assert.Equal(t, 3, job.Failed)
+	assert.NotNil(t, job.FinishedAt)
+	assert.Equal(t, []product{{"a", 10}, {"c", 30}, {"e", 50}}, r.saved)
+	assert.Equal(t, 2, uow.commits)
+
+	errs, err := jobs.Errors(context.Background(), job.ID)
+	require.NoError(t, err)
+	assert.Equal(t, []importer.RowError{
+		{Line: 3, Message: "price must be a number"},
+		{Line: 4, Field: "sku", Message: "sku is required"},
+		{Line: 4, Field: "price", Message: "price must be positive"},
+		{Line: 6, Message: "expected 2 fields, got 3"},
+	}, errs)
+
+	stored, err := jobs.Get(context.Background(), job.ID)
+	require.NoError(t, err)
+	assert.Equal(t, job, stored)
+}
+
+func TestImporter_RunJSONL(t *testing.T) {
+	r := &repo{failOn: "c"}
+	jobs := importer.NewMemoryJobStore()
+	im := newImporter(r, &fakeUoW{}, jobs)
+
+	file := `{"sku":"a","price":1}` + "\n\n" +
+		`{"sku":"b","price":2}` + "\n" +
+		`{"sku":"c","price":3}` + "\n" +
+		`not json`
+
+	job, err := im.Run(context.Background(), importer.FormatJSONL, strings.NewReader(file))
+	require.NoError(t, err)
+
+	assert.Equal(t, 4, job.Processed)
+	assert.Equal(t, 2, job.Imported)
+	assert.Equal(t, 2, job.Failed)
+	assert.Equal(t, []product{{"a", 1}, {"b", 2}}, r.saved)
+
+	errs, err := jobs.Errors(context.Background(), job.ID)
+	require.NoError(t, err)
+	require.Len(t, errs, 2)
+	assert.Equal(t, 4, errs[0].Line)
+	assert.Equal(t, "duplicate sku c", errs[0].Message)
+	assert.Equal(t, 5, errs[1].Line)
+}
+
+func TestImporter_Run_MissingHeader(t *testing.T) {
+	jobs := importer.NewMemoryJobStore()
+	im := newImporter(&repo{}, &fakeUoW{}, jobs)
+
+	job, err := im.Run(context.Background(), importer.FormatCSV, strings.NewReader(""))
+	require.Error(t, err)
+
+	assert.Equal(t, importer.StatusFailed, job.Status)
+	assert.Contains(t, job.Error, "missing header")
+}
+
+func TestImporter_UnsupportedFormat(t *testing.T) {
+	im := importer.New(importer.Definition[product]{Name: "products"}, &fakeUoW{}, importer.NewMemoryJobStore())
+
+	_, err := im.Run(context.Background(), importer.FormatCSV, strings.NewReader("sku\n"))
+	assert.ErrorIs(t, err, importer.ErrUnsupportedFormat)
+}
+
+func TestImporter_Start(t *testing.T) {
+	r, jobs := &repo{}, importer.NewMemoryJobStore()
+	im := newImporter(r, &fakeUoW{}, jobs)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	job, err := im.Start(ctx, importer.FormatCSV, strings.NewReader("sku,price\na,1\nb,2\nc,3\n"))
+	cancel() // the import outlives the request
+	require.NoError(t, err)
+	assert.Equal(t, importer.StatusPending, job.Status)
+
+	require.Eventually(t, func() bool {
+		got, err := jobs.Get(context.Background(), job.ID)
+		return err == nil && got.Status.IsDone()
+	}, time.Second, 10*time.Millisecond)
+
+	got, err := jobs.Get(context.Background(), job.ID)
+	require.NoError(t, err)
+	assert.Equal(t, importer.StatusCompleted, got.Status)
+	assert.Equal(t, 3, got.Imported)
+}
+
+func TestImporter_MaxErrors(t *testing.T) {
+	jobs := importer.NewMemoryJobStore()
+	im := importer.New(importer.Definition[product]{
+		Name:      "products",
+		FromCSV:   productFromCSV,
+		Persist:   (&repo{}).SaveAll,
+		MaxErrors: 2,
+	}, &fakeUoW{}, jobs)
+
+	job, err := im.Run(context.Background(), importer.FormatCSV, strings.NewReader("sku,price\na,x\nb,x\nc,x\n"))
+	require.NoError(t, err)
+	assert.Equal(t, 3, job.Failed)
+
+	errs, err := jobs.Errors(context.Background(), job.ID)
+	require.NoError(t, err)
+	assert.Len(t, errs, 2)
+}
+
+func TestWriteReport(t *testing.T) {
+	var buf bytes.Buffer
+
+	err := importer.WriteReport(&buf, []importer.RowError{
+		{Line: 3, Field: "name", Message: "name is required"},
+		{Line: 7, Message: "bad, row"},
+	})
+	require.NoError(t, err)
+
+	assert.Equal(t, "line,field,message\n3,name,name is required\n7,,\"bad, row\"\n", buf.String())
+}
+
+func TestParseFormat(t *testing.T) {
+	tests := []struct {
+		in      string
+		want    importer.Format
+		wantErr bool
+	}{
+		{in: "csv", want: importer.FormatCSV},
+		{in: "JSONL", want: importer.FormatJSONL},
+		{in: "ndjson", want: importer.FormatJSONL},
+		{in: "xlsx", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.in, func(t *testing.T) {
+			got, err := importer.ParseFormat(tt.in)
+			if tt.wantErr {
+				assert.ErrorIs(t, err, importer.ErrUnsupportedFormat)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
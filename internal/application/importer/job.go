@@ -0,0 +1,63 @@
+package importer
+
+import (
+	"context"
+	"errors"
+	"time"
+)
+
+// ErrJobNotFound is returned by stores when a job does not exist.
+var ErrJobNotFound = errors.New("import job not found")
+
+// Status is the lifecycle state of an import job.
+type Status string
+
+const (
+	StatusPending   Status = "pending"
+	StatusRunning   Status = "running"
+	StatusCompleted Status = "completed"
+	StatusFailed    Status = "failed"
+)
+
+// IsDone reports whether a job in this status will make no further progress.
+func (s Status) IsDone() bool {
+	return s == StatusCompleted || s == StatusFailed
+}
+
+// Job tracks the progress of one import.
+// Processed counts every parsed row; each row ends up either Imported or Failed.
+type Job struct {
+	ID         string     `json:"id"`
+	Importer   string     `json:"importer"`
+	Format     Format     `json:"format"`
+	Status     Status     `json:"status"`
+	Processed  int        `json:"processed"`
+	Imported   int        `json:"imported"`
+	Failed     int        `json:"failed"`
+	Error      string     `json:"error,omitempty"` // set when the whole job failed
+	CreatedAt  time.Time  `json:"created_at"`
+	FinishedAt *time.Time `json:"finished_at,omitempty"`
+}
+
+// RowError describes why a row was rejected.
+// Field is empty when the error concerns the whole row.
+type RowError struct {
+	Line    int    `json:"line"`
+	Field   string `json:"field,omitempty"`
+	Message string `json:"message"`
+}
+
+// JobStore persists import jobs and their row errors.
+type JobStore interface {
+	// Create stores a new job.
+	Create(ctx context.Context, job Job) error
+
+	// Update replaces the job's progress and appends row errors to its report.
+	Update(ctx context.Context, job Job, errs ...RowError) error
+
+	// Get returns the job with the given ID.
+	Get(ctx context.Context, id string) (Job, error)
+
+	// Errors returns the job's row errors ordered by line.
+	Errors(ctx context.Context, id string) ([]RowError, error)
+}
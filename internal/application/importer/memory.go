@@ -0,0 +1,72 @@
+package importer
+
+import (
+	"context"
+	"sort"
+	"sync"
+)
+
+// MemoryJobStore is an in-process JobStore for tests and single instances.
+// It is safe for concurrent use.
+type MemoryJobStore struct {
+	mu     sync.Mutex
+	jobs   map[string]Job
+	errors map[string][]RowError
+}
+
+var _ JobStore = (*MemoryJobStore)(nil)
+
+// NewMemoryJobStore creates an empty MemoryJobStore.
+func NewMemoryJobStore() *MemoryJobStore {
+	return &MemoryJobStore{
+		jobs:   make(map[string]Job),
+		errors: make(map[string][]RowError),
+	}
+}
+
+// Create stores a new job.
+func (s *MemoryJobStore) Create(_ context.Context, job Job) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.jobs[job.ID] = job
+	return nil
+}
+
+// Update replaces the job's progress and appends row errors.
+func (s *MemoryJobStore) Update(_ context.Context, job Job, errs ...RowError) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if _, ok := s.jobs[job.ID]; !ok {
+		return ErrJobNotFound
+	}
+	s.jobs[job.ID] = job
+	s.errors[job.ID] = append(s.errors[job.ID], errs...)
+	return nil
+}
+
+// Get returns the job with the given ID.
+func (s *MemoryJobStore) Get(_ context.Context, id string) (Job, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	job, ok := s.jobs[id]
+	if !ok {
+		return Job{}, ErrJobNotFound
+	}
+	return job, nil
+}
+
+// Errors returns the job's row errors ordered by line.
+func (s *MemoryJobStore) Errors(_ context.Context, id string) ([]RowError, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if _, ok := s.jobs[id]; !ok {
+		return nil, ErrJobNotFound
+	}
+	errs := append([]RowError(nil), s.errors[id]...)
+	sort.SliceStable(errs, func(i, j int) bool { return errs[i].Line < errs[j].Line })
+	return errs, nil
+}
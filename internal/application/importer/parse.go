@@ -0,0 +1,145 @@
+package importer
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+)
+
+// ErrUnsupportedFormat is returned for formats an importer cannot read.
+var ErrUnsupportedFormat = errors.New("unsupported import format")
+
+// Format is the encoding of an import file.
+type Format string
+
+const (
+	// FormatCSV is comma-separated values with a header row naming the fields.
+	FormatCSV Format = "csv"
+
+	// FormatJSONL is one JSON object per line.
+	FormatJSONL Format = "jsonl"
+)
+
+// ParseFormat parses a format name such as "csv" or "jsonl" ("ndjson" is accepted as an alias).
+func ParseFormat(s string) (Format, error) {
+	switch strings.ToLower(strings.TrimSpace(s)) {
+	case "csv":
+		return FormatCSV, nil
+	case "jsonl", "ndjson":
+		return FormatJSONL, nil
+	default:
+		return "", fmt.Errorf("%w: %q", ErrUnsupportedFormat, s)
+	}
+}
+
+// Row is one record read from an import file.
+type Row struct {
+	// Line is the 1-based line number of the record in the file.
+	Line int
+
+	// Fields holds CSV values keyed by header name. Nil for JSONL.
+	Fields map[string]string
+
+	// Raw holds the JSON object of a JSONL line. Nil for CSV.
+	Raw []byte
+}
+
+// rowReader streams rows from a file. Next returns io.EOF after the last row.
+// A *rowError means only the current row is broken and reading may continue.
+type rowReader interface {
+	Next() (Row, error)
+}
+
+// rowError is a recoverable parse error for a single row.
+type rowError struct {
+	line int
+	err  error
+}
+
+func (e *rowError) Error() string { return e.err.Error() }
+func (e *rowError) Unwrap() error { return e.err }
+
+// newRowReader returns a streaming reader for the given format.
+func newRowReader(format Format, r io.Reader) (rowReader, error) {
+	switch format {
+	case FormatCSV:
+		return newCSVReader(r)
+	case FormatJSONL:
+		return &jsonlReader{r: bufio.NewReader(r)}, nil
+	default:
+		return nil, fmt.Errorf("%w: %q", ErrUnsupportedFormat, format)
+	}
+}
+
+// csvReader reads CSV rows keyed by the header row.
+type csvReader struct {
+	r      *csv.Reader
+	header []string
+}
+
+func newCSVReader(r io.Reader) (*csvReader, error) {
+	cr := csv.NewReader(r)
+	cr.TrimLeadingSpace = true
+
+	header, err := cr.Read()
+	if errors.Is(err, io.EOF) {
+		return nil, errors.New("csv: missing header row")
+	}
+	if err != nil {
+		return nil, fmt.Errorf("csv: read header: %w", err)
+	}
+	for i, h := range header {
+		header[i] = strings.TrimSpace(strings.TrimPrefix(h, "\ufeff"))
+	}
+
+	return &csvReader{r: cr, header: header}, nil
+}
+
+func (c *csvReader) Next() (Row, error) {
+	record, err := c.r.Read()
+	if err != nil {
+		var pe *csv.ParseError
+		if errors.As(err, &pe) && errors.Is(err, csv.ErrFieldCount) {
+			return Row{}, &rowError{line: pe.StartLine, err: fmt.Errorf("expected %d fields, got %d", len(c.header), len(record))}
+		}
+		return Row{}, err
+	}
+
+	line, _ := c.r.FieldPos(0)
+	fields := make(map[string]string, len(c.header))
+	for i, h := range c.header {
+		fields[h] = record[i]
+	}
+	return Row{Line: line, Fields: fields}, nil
+}
+
+// jsonlReader reads one JSON object per line, skipping blank lines.
+type jsonlReader struct {
+	r    *bufio.Reader
+	line int
+}
+
+func (j *jsonlReader) Next() (Row, error) {
+	for {
+		b, err := j.r.ReadBytes('\n')
+		if len(b) == 0 && err != nil {
+			return Row{}, err
+		}
+		if err != nil && !errors.Is(err, io.EOF) {
+			return Row{}, err
+		}
+
+		j.line++
+		b = bytes.TrimSpace(b)
+		if len(b) > 0 {
+			return Row{Line: j.line, Raw: b}, nil
+		}
+		if errors.Is(err, io.EOF) {
+			return Row{}, io.EOF
+		}
+	}
+}
@@ -0,0 +1,23 @@
+package importer
+
+import (
+	"encoding/csv"
+	"io"
+	"strconv"
+)
+
+// WriteReport writes row errors as a CSV error report with the columns
+// line, field and message, suitable for download next to the original file.
+func WriteReport(w io.Writer, errs []RowError) error {
+	cw := csv.NewWriter(w)
+	if err := cw.Write([]string{"line", "field", "message"}); err != nil {
+		return err
+	}
+	for _, e := range errs {
+		if err := cw.Write([]string{strconv.Itoa(e.Line), e.Field, e.Message}); err != nil {
+			return err
+		}
+	}
+	cw.Flush()
+	return cw.Error()
+}
@@ -0,0 +1,40 @@
+// Package mail defines the application port for sending email.
+// Use cases describe a message, usually by template name and data, and a
+// Mailer renders and delivers it; backends live in the infrastructure layer.
+//
+//	err := mailer.Send(ctx, mail.Message{
+//		To:       []string{customer.Email},
+//		Subject:  "Your order has shipped",
+//		Template: "order_shipped",
+//		Data:     shipment,
+//	})
+package mail
+
+import (
+	"context"
+	"errors"
+)
+
+// ErrRejected is returned when the provider permanently refuses a message,
+// e.g. an invalid recipient or an unverified sender. Retrying cannot
+// succeed, so task handlers should wrap it with task.ErrSkipRetry.
+var ErrRejected = errors.New("mail rejected")
+
+// Message is an email to send. Set Template to render the body from the
+// named template with Data, or set Text and/or HTML directly.
+type Message struct {
+	To      []string
+	ReplyTo string
+	Subject string
+
+	Template string
+	Data     any
+
+	Text string
+	HTML string
+}
+
+// Mailer sends email.
+type Mailer interface {
+	Send(ctx context.Context, msg Message) error
+}
@@ -0,0 +1,128 @@
+package message
+
+import (
+	"context"
+	"maps"
+	"strconv"
+	"time"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/propagation"
+
+	"github.com/blackhorseya/go-ddd/pkg/contextx"
+	"github.com/blackhorseya/go-ddd/pkg/idx"
+)
+
+// Standard header keys. Adapters carry envelopes as payload plus these
+// headers; the OTel trace context uses the W3C keys (traceparent).
+const (
+	HeaderMessageID     = "message_id"
+	HeaderEventName     = "event_name"
+	HeaderEventVersion  = "event_version"
+	HeaderOccurredAt    = "occurred_at"
+	HeaderRequestID     = contextx.FieldRequestID
+	HeaderCorrelationID = contextx.FieldCorrelationID
+	HeaderTenantID      = contextx.FieldTenantID
+	HeaderUserID        = contextx.FieldUserID
+
+	// HeaderIdempotencyKey records the idempotency key of the request
+	// that produced the message. Extract does not restore it: one request
+	// may emit several messages, so consumers deduplicate by message ID.
+	HeaderIdempotencyKey = "idempotency_key"
+)
+
+// NewEnvelope creates an envelope for the event name at schema version,
+// with a fresh ULID and the current time.
+func NewEnvelope(name string, version int, key string, payload []byte) Envelope {
+	return Envelope{
+		ID:         idx.ULID(),
+		Topic:      name,
+		Name:       name,
+		Version:    version,
+		Key:        key,
+		Payload:    payload,
+		OccurredAt: time.Now().UTC(),
+	}
+}
+
+// Inject returns env with the contextx values and trace context of ctx
+// added to its headers. Headers already set are kept, so values captured
+// when the event was recorded survive a relay publishing it later.
+func Inject(ctx context.Context, env Envelope) Envelope {
+	injected := make(map[string]string)
+	contextx.InjectMap(ctx, injected)
+	if key := contextx.GetIdempotencyKey(ctx); key != "" {
+		injected[HeaderIdempotencyKey] = key
+	}
+	otel.GetTextMapPropagator().Inject(ctx, propagation.MapCarrier(injected))
+
+	headers := maps.Clone(env.Headers)
+	if headers == nil {
+		headers = make(map[string]string, len(injected))
+	}
+	for k, v := range injected {
+		if _, ok := headers[k]; !ok {
+			headers[k] = v
+		}
+	}
+	env.Headers = headers
+	return env
+}
+
+// Extract restores the values written by Inject into ctx. The envelope ID
+// becomes the correlation ID when none was propagated, so everything a
+// message causes can be traced back to it.
+func Extract(ctx context.Context, env Envelope) context.Context {
+	headers := env.Headers
+	ctx = otel.GetTextMapPropagator().Extract(ctx, propagation.MapCarrier(headers))
+	ctx = contextx.ExtractMap(ctx, headers)
+	if headers[HeaderCorrelationID] == "" && env.ID != "" {
+		ctx = contextx.WithCorrelationID(ctx, env.ID)
+	}
+	return ctx
+}
+
+// Metadata returns env's headers plus its ID, name, version and occurrence
+// time, for transports that carry only a payload and string headers.
+func (e Envelope) Metadata() map[string]string {
+	md := make(map[string]string, len(e.Headers)+4)
+	maps.Copy(md, e.Headers)
+	for key, value := range map[string]string{
+		HeaderMessageID: e.ID,
+		HeaderEventName: e.Name,
+	} {
+		if value != "" {
+			md[key] = value
+		}
+	}
+	if e.Version > 0 {
+		md[HeaderEventVersion] = strconv.Itoa(e.Version)
+	}
+	if !e.OccurredAt.IsZero() {
+		md[HeaderOccurredAt] = e.OccurredAt.UTC().Format(time.RFC3339Nano)
+	}
+	return md
+}
+
+// FromMetadata rebuilds an envelope from a payload and the headers
+// produced by Metadata. The name defaults to the topic.
+func FromMetadata(topic, key string, payload []byte, md map[string]string) Envelope {
+	env := Envelope{
+		ID:      md[HeaderMessageID],
+		Topic:   topic,
+		Name:    md[HeaderEventName],
+		Key:     key,
+		Payload: payload,
+		Headers: md,
+	}
+	if env.Name == "" {
+		env.Name = topic
+	}
+	if v, err := strconv.Atoi(md[HeaderEventVersion]); err == nil {
+		env.Version = v
+	}
+	if t, err := time.Parse(time.RFC3339Nano, md[HeaderOccurredAt]); err == nil {
+		env.OccurredAt = t
+	}
+	return env
+}
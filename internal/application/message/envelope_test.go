@@ -0,0 +1,85 @@
+package message_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/trace"
+
+	"github.com/blackhorseya/go-ddd/internal/application/message"
+	"github.com/blackhorseya/go-ddd/pkg/contextx"
+)
+
+func TestInjectExtract(t *testing.T) {
+	// Arrange
+	otel.SetTextMapPropagator(propagation.TraceContext{})
+	sc := trace.NewSpanContext(trace.SpanContextConfig{
+		TraceID:    trace.TraceID{1},
+		SpanID:     trace.SpanID{2},
+		TraceFlags: trace.FlagsSampled,
+	})
+	ctx := trace.ContextWithSpanContext(context.Background(), sc)
+	ctx = contextx.WithRequestID(ctx, "req-1")
+	ctx = contextx.WithCorrelationID(ctx, "corr-1")
+	ctx = contextx.WithTenantID(ctx, "tenant-1")
+	ctx = contextx.WithUserID(ctx, "user-1")
+	ctx = contextx.WithIdempotencyKey(ctx, "idem-1")
+	env := message.NewEnvelope("order.placed", 2, "o-1", []byte(`{}`))
+	env.Headers = map[string]string{message.HeaderRequestID: "recorded"}
+
+	// Act
+	injected := message.Inject(ctx, env)
+	got := message.Extract(context.Background(), injected)
+
+	// Assert
+	assert.Equal(t, "recorded", contextx.GetRequestID(got), "existing headers are kept")
+	assert.Equal(t, "corr-1", contextx.GetCorrelationID(got))
+	assert.Equal(t, "tenant-1", contextx.GetTenantID(got))
+	assert.Equal(t, "user-1", contextx.GetUserID(got))
+	assert.Equal(t, sc.TraceID(), trace.SpanContextFromContext(got).TraceID())
+	assert.Equal(t, "idem-1", injected.Headers[message.HeaderIdempotencyKey])
+	assert.Empty(t, contextx.GetIdempotencyKey(got), "consumers deduplicate by message ID")
+	assert.Equal(t, map[string]string{message.HeaderRequestID: "recorded"}, env.Headers, "input is not mutated")
+}
+
+func TestExtract_CorrelatesByMessageID(t *testing.T) {
+	env := message.NewEnvelope("order.placed", 1, "o-1", nil)
+
+	ctx := message.Extract(context.Background(), env)
+
+	assert.Equal(t, env.ID, contextx.GetCorrelationID(ctx))
+}
+
+func TestEnvelope_Metadata(t *testing.T) {
+	// Arrange
+	env := message.Envelope{
+		ID:         "m-1",
+		Topic:      "orders",
+		Name:       "order.placed",
+		Version:    2,
+		Key:        "o-1",
+		Payload:    []byte(`{}`),
+		Headers:    map[string]string{"schema": "avro"},
+		OccurredAt: time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC),
+	}
+
+	// Act
+	got := message.FromMetadata(env.Topic, env.Key, env.Payload, env.Metadata())
+
+	// Assert
+	require.Equal(t, "avro", got.Headers["schema"])
+	got.Headers = env.Headers
+	assert.Equal(t, env, got)
+}
+
+func TestFromMetadata_DefaultsNameToTopic(t *testing.T) {
+	got := message.FromMetadata("order.placed", "", nil, map[string]string{})
+
+	assert.Equal(t, "order.placed", got.Name)
+	assert.Zero(t, got.Version)
+}
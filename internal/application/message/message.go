@@ -0,0 +1,106 @@
+// Package message defines the application port for the message bus.
+// Integration events leave the service through a Publisher (normally fed by
+// the outbox relay) and arrive through handlers registered on a Subscriber;
+// broker adapters live in the infrastructure layer.
+//
+//	var OrderPlaced = message.NewTopic[OrderPlacedV1]("order.placed")
+//
+//	// producer
+//	err := OrderPlaced.Publish(ctx, bus, order.ID, OrderPlacedV1{...})
+//
+//	// consumer
+//	message.Handle(consumer, OrderPlaced, func(ctx context.Context, env message.Envelope, e OrderPlacedV1) error { ... })
+package message
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+)
+
+// ErrSkipRetry makes a failing handler skip the remaining retries and move
+// the message straight to the dead-letter topic. Wrap it for permanent
+// failures such as malformed payloads.
+var ErrSkipRetry = errors.New("skip retry")
+
+// Envelope is a message with its routing metadata.
+type Envelope struct {
+	// ID uniquely identifies the message; consumers deduplicate on it
+	// because delivery is at-least-once.
+	ID string
+
+	// Topic names the stream, e.g. "order.placed".
+	Topic string
+
+	// Name is the event name; it usually equals Topic unless several
+	// event types share a stream.
+	Name string
+
+	// Version is the payload schema version; consumers branch on it while
+	// producers migrate. Zero means unversioned.
+	Version int
+
+	// Key selects the partition; messages with the same key are delivered
+	// in order, so use the aggregate ID.
+	Key string
+
+	// Payload is the encoded message body.
+	Payload []byte
+
+	// Headers carry metadata such as trace context.
+	Headers map[string]string
+
+	// OccurredAt is when the underlying event happened.
+	OccurredAt time.Time
+}
+
+// Publisher publishes envelopes to the message bus.
+type Publisher interface {
+	Publish(ctx context.Context, envs ...Envelope) error
+}
+
+// HandlerFunc processes a delivered envelope. Adapters call it with a
+// context restored by Extract. Returning an error retries the message and
+// eventually dead-letters it.
+type HandlerFunc func(ctx context.Context, env Envelope) error
+
+// Subscriber registers handlers by topic.
+type Subscriber interface {
+	Subscribe(topic string, h HandlerFunc)
+}
+
+// Topic is a typed topic definition with payload type P.
+type Topic[P any] struct {
+	Name    string
+	Version int
+}
+
+// NewTopic defines a topic whose payloads are JSON-encoded P values at
+// schema version 1.
+func NewTopic[P any](name string) Topic[P] {
+	return Topic[P]{Name: name, Version: 1}
+}
+
+// Publish encodes payload and publishes it under key, carrying the
+// contextx values and trace context of ctx.
+func (t Topic[P]) Publish(ctx context.Context, p Publisher, key string, payload P) error {
+	b, err := json.Marshal(payload)
+	if err != nil {
+		return fmt.Errorf("encode message %s: %w", t.Name, err)
+	}
+	return p.Publish(ctx, Inject(ctx, NewEnvelope(t.Name, t.Version, key, b)))
+}
+
+// Handle registers h for t. Payloads that cannot be decoded are
+// dead-lettered without retries.
+func Handle[P any](s Subscriber, t Topic[P], h func(ctx context.Context, env Envelope, payload P) error) {
+	s.Subscribe(t.Name, func(ctx context.Context, env Envelope) error {
+		var p P
+		if err := json.Unmarshal(env.Payload, &p); err != nil {
+			return fmt.Errorf("decode message %s: %w: %w", t.Name, err, ErrSkipRetry)
+		}
+		return h(ctx, env, p)
+	})
+}
@@ -0,0 +1,76 @@
+package message_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/blackhorseya/go-ddd/internal/application/message"
+)
+
+type orderPlaced struct {
+	OrderID string `json:"order_id"`
+}
+
+// memoryBus delivers published envelopes to subscribed handlers synchronously.
+type memoryBus struct {
+	handlers map[string]message.HandlerFunc
+	sent     []message.Envelope
+}
+
+func (b *memoryBus) Subscribe(topic string, h message.HandlerFunc) {
+	if b.handlers == nil {
+		b.handlers = make(map[string]message.HandlerFunc)
+	}
+	b.handlers[topic] = h
+}
+
+func (b *memoryBus) Publish(ctx context.Context, envs ...message.Envelope) error {
+	for _, env := range envs {
+		b.sent = append(b.sent, env)
+		if err := b.handlers[env.Topic](ctx, env); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func TestTopic_Publish(t *testing.T) {
+	// Arrange
+	placed := message.NewTopic[orderPlaced]("order.placed")
+	bus := &memoryBus{}
+
+	var got orderPlaced
+	message.Handle(bus, placed, func(_ context.Context, _ message.Envelope, e orderPlaced) error {
+		got = e
+		return nil
+	})
+
+	// Act
+	err := placed.Publish(context.Background(), bus, "o-1", orderPlaced{OrderID: "o-1"})
+
+	// Assert
+	require.NoError(t, err)
+	assert.Equal(t, orderPlaced{OrderID: "o-1"}, got)
+	require.Len(t, bus.sent, 1)
+	assert.Equal(t, "o-1", bus.sent[0].Key)
+	assert.Equal(t, "order.placed", bus.sent[0].Name)
+	assert.Equal(t, 1, bus.sent[0].Version)
+	assert.NotEmpty(t, bus.sent[0].ID)
+	assert.False(t, bus.sent[0].OccurredAt.IsZero())
+}
+
+func TestHandle_MalformedPayload(t *testing.T) {
+	// Arrange
+	placed := message.NewTopic[orderPlaced]("order.placed")
+	bus := &memoryBus{}
+	message.Handle(bus, placed, func(context.Context, message.Envelope, orderPlaced) error { return nil })
+
+	// Act
+	err := bus.Publish(context.Background(), message.Envelope{Topic: "order.placed", Payload: []byte("{")})
+
+	// Assert
+	assert.ErrorIs(t, err, message.ErrSkipRetry)
+}
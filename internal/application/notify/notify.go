@@ -0,0 +1,57 @@
+// Package notify defines the application port for user notifications over
+// SMS, mobile push and chat. Use cases name a template and the channels to
+// use; a Notifier renders one message per channel and delivers them.
+//
+//	err := notifier.Notify(ctx, notify.Notification{
+//		Template: "order_shipped",
+//		Data:     shipment,
+//		Title:    "Your order has shipped",
+//		To:       notify.Recipient{Phone: customer.Phone, DeviceTokens: customer.Devices},
+//		Channels: []notify.Channel{notify.SMS, notify.Push},
+//	})
+package notify
+
+import (
+	"context"
+	"errors"
+)
+
+// ErrRejected is returned when a provider permanently refuses a message,
+// e.g. an invalid phone number or an unregistered device token.
+var ErrRejected = errors.New("notification rejected")
+
+// Channel is a delivery channel.
+type Channel string
+
+// Supported channels.
+const (
+	SMS  Channel = "sms"
+	Push Channel = "push"
+	Chat Channel = "chat"
+)
+
+// Recipient holds the per-channel addresses of the notified party. Chat
+// messages go to the configured team channel and need no address.
+type Recipient struct {
+	// Phone is an E.164 number, e.g. "+14155550100".
+	Phone string
+
+	// DeviceTokens are push registration tokens.
+	DeviceTokens []string
+}
+
+// Notification is a message to deliver on one or more channels. The body
+// for each channel is rendered from Template with Data; Title is used by
+// channels that show one, such as push.
+type Notification struct {
+	Template string
+	Data     any
+	Title    string
+	To       Recipient
+	Channels []Channel
+}
+
+// Notifier delivers notifications.
+type Notifier interface {
+	Notify(ctx context.Context, n Notification) error
+}
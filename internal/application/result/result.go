@@ -0,0 +1,82 @@
+// Package result provides Result, the outcome of a use case: either a
+// value or an error. Use cases return typed errors (errorx.Error or the
+// domain sentinel errors), and transports map a Result to a response
+// mechanically, e.g. with response.FromResult or errorx.GRPCStatus.
+//
+//	func (uc *GetOrder) Execute(ctx context.Context, id string) result.Result[OrderDTO] {
+//		order, err := uc.repo.Get(ctx, id)
+//		if err != nil {
+//			return result.Fail[OrderDTO](ErrOrderNotFound.WithCause(err))
+//		}
+//		return result.Ok(toDTO(order))
+//	}
+package result
+
+import "github.com/blackhorseya/go-ddd/pkg/errorx"
+
+// errNil replaces a nil error passed to Fail, so a failure is never mistaken for success.
+var errNil = errorx.Internal("", "result failed without an error")
+
+// Result holds either a value or an error. The zero value is a successful
+// Result holding the zero value of T.
+type Result[T any] struct {
+	value T
+	err   error
+}
+
+// Ok returns a successful Result holding v.
+func Ok[T any](v T) Result[T] {
+	return Result[T]{value: v}
+}
+
+// Fail returns a failed Result holding err.
+func Fail[T any](err error) Result[T] {
+	if err == nil {
+		err = errNil
+	}
+	return Result[T]{err: err}
+}
+
+// Of converts a (value, error) pair, as returned by most Go functions, into a Result.
+func Of[T any](v T, err error) Result[T] {
+	if err != nil {
+		return Result[T]{err: err}
+	}
+	return Result[T]{value: v}
+}
+
+// IsOk reports whether r holds a value.
+func (r Result[T]) IsOk() bool { return r.err == nil }
+
+// Value returns the value, or the zero value of T if r failed.
+func (r Result[T]) Value() T { return r.value }
+
+// Err returns the error, or nil if r succeeded.
+func (r Result[T]) Err() error { return r.err }
+
+// Unwrap returns the value and error as a pair.
+func (r Result[T]) Unwrap() (T, error) { return r.value, r.err }
+
+// ValueOr returns the value, or def if r failed.
+func (r Result[T]) ValueOr(def T) T {
+	if r.err != nil {
+		return def
+	}
+	return r.value
+}
+
+// Map converts the value of a successful Result with fn; failures pass through.
+func Map[T, U any](r Result[T], fn func(T) U) Result[U] {
+	if r.err != nil {
+		return Result[U]{err: r.err}
+	}
+	return Result[U]{value: fn(r.value)}
+}
+
+// Then chains a step that may fail; failures pass through without calling fn.
+func Then[T, U any](r Result[T], fn func(T) Result[U]) Result[U] {
+	if r.err != nil {
+		return Result[U]{err: r.err}
+	}
+	return fn(r.value)
+}
@@ -0,0 +1,70 @@
+package result_test
+
+import (
+	"errors"
+	"strconv"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/blackhorseya/go-ddd/internal/application/result"
+	"github.com/blackhorseya/go-ddd/pkg/errorx"
+)
+
+var errNotFound = errorx.NotFound("ORDER_NOT_FOUND", "order not found")
+
+func TestResult(t *testing.T) {
+	ok := result.Ok(42)
+	assert.True(t, ok.IsOk())
+	assert.Equal(t, 42, ok.Value())
+	assert.NoError(t, ok.Err())
+	assert.Equal(t, 42, ok.ValueOr(7))
+
+	failed := result.Fail[int](errNotFound)
+	assert.False(t, failed.IsOk())
+	assert.Equal(t, 0, failed.Value())
+	assert.ErrorIs(t, failed.Err(), errNotFound)
+	assert.Equal(t, 7, failed.ValueOr(7))
+
+	v, err := failed.Unwrap()
+	assert.Equal(t, 0, v)
+	assert.ErrorIs(t, err, errNotFound)
+
+	var zero result.Result[string]
+	assert.True(t, zero.IsOk())
+}
+
+func TestFail_NilError(t *testing.T) {
+	r := result.Fail[int](nil)
+
+	assert.False(t, r.IsOk())
+	assert.Equal(t, errorx.KindInternal, errorx.KindOf(r.Err()))
+}
+
+func TestOf(t *testing.T) {
+	assert.Equal(t, 12, result.Of(strconv.Atoi("12")).Value())
+
+	r := result.Of(strconv.Atoi("x"))
+	assert.False(t, r.IsOk())
+	var numErr *strconv.NumError
+	assert.True(t, errors.As(r.Err(), &numErr))
+}
+
+func TestMapThen(t *testing.T) {
+	double := func(n int) int { return n * 2 }
+	parse := func(s string) result.Result[int] { return result.Of(strconv.Atoi(s)) }
+
+	assert.Equal(t, 8, result.Map(result.Ok(4), double).Value())
+	assert.ErrorIs(t, result.Map(result.Fail[int](errNotFound), double).Err(), errNotFound)
+
+	assert.Equal(t, 5, result.Then(result.Ok("5"), parse).Value())
+	assert.False(t, result.Then(result.Ok("x"), parse).IsOk())
+
+	called := false
+	r := result.Then(result.Fail[string](errNotFound), func(s string) result.Result[int] {
+		called = true
+		return parse(s)
+	})
+	assert.False(t, called)
+	assert.ErrorIs(t, r.Err(), errNotFound)
+}
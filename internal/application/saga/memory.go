@@ -0,0 +1,83 @@
+package saga
+
+import (
+	"context"
+	"sort"
+	"sync"
+)
+
+// MemoryStore is an in-process Store for tests and single instances.
+// It is safe for concurrent use.
+type MemoryStore struct {
+	mu        sync.Mutex
+	instances map[string]Instance
+}
+
+var _ Store = (*MemoryStore)(nil)
+
+// NewMemoryStore creates an empty MemoryStore.
+func NewMemoryStore() *MemoryStore {
+	return &MemoryStore{instances: make(map[string]Instance)}
+}
+
+// Create stores a new instance.
+func (s *MemoryStore) Create(_ context.Context, inst Instance) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if _, ok := s.instances[inst.ID]; ok {
+		return ErrInstanceExists
+	}
+	s.instances[inst.ID] = cloneInstance(inst)
+	return nil
+}
+
+// Save replaces an existing instance if its version matches.
+func (s *MemoryStore) Save(_ context.Context, inst Instance) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	stored, ok := s.instances[inst.ID]
+	if !ok {
+		return ErrInstanceNotFound
+	}
+	if stored.Version != inst.Version {
+		return ErrStaleInstance
+	}
+	inst.Version++
+	s.instances[inst.ID] = cloneInstance(inst)
+	return nil
+}
+
+// Load returns the instance with the given ID.
+func (s *MemoryStore) Load(_ context.Context, id string) (Instance, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	inst, ok := s.instances[id]
+	if !ok {
+		return Instance{}, ErrInstanceNotFound
+	}
+	return cloneInstance(inst), nil
+}
+
+// ListActive returns running and compensating instances ordered by ID.
+func (s *MemoryStore) ListActive(_ context.Context) ([]Instance, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	var active []Instance
+	for _, inst := range s.instances {
+		if inst.Status.IsActive() {
+			active = append(active, cloneInstance(inst))
+		}
+	}
+	sort.Slice(active, func(i, j int) bool { return active[i].ID < active[j].ID })
+	return active, nil
+}
+
+// cloneInstance copies inst so callers cannot mutate stored data.
+func cloneInstance(inst Instance) Instance {
+	inst.Data = append([]byte(nil), inst.Data...)
+	return inst
+}
@@ -0,0 +1,73 @@
+package saga
+
+import (
+	"context"
+	"time"
+
+	"github.com/blackhorseya/go-ddd/pkg/contextx"
+	"github.com/blackhorseya/go-ddd/pkg/runx"
+)
+
+// Default resumer settings.
+const (
+	DefaultResumeInterval = 30 * time.Second
+	DefaultResumeIdle     = 5 * time.Minute
+)
+
+// ResumerConfig configures the Resumer.
+type ResumerConfig struct {
+	// Interval is the delay between scans for stalled instances.
+	Interval time.Duration
+
+	// Idle is how long an active instance must go without progress before
+	// it is taken over. Keep it above the longest step, or a slow step is
+	// run again elsewhere before its own save is rejected.
+	Idle time.Duration
+}
+
+// Resumer is a worker component that periodically resumes stalled saga
+// instances, such as those of a crashed worker.
+type Resumer struct {
+	orchestrator *Orchestrator
+	cfg          ResumerConfig
+	started      runx.Signal
+}
+
+// NewResumer creates a Resumer, applying defaults for zero config values.
+func NewResumer(o *Orchestrator, cfg ResumerConfig) *Resumer {
+	if cfg.Interval <= 0 {
+		cfg.Interval = DefaultResumeInterval
+	}
+	if cfg.Idle <= 0 {
+		cfg.Idle = DefaultResumeIdle
+	}
+	return &Resumer{orchestrator: o, cfg: cfg}
+}
+
+// Started returns a channel closed after the first scan.
+func (r *Resumer) Started() <-chan struct{} {
+	return r.started.Done()
+}
+
+// Run resumes stalled instances until ctx is cancelled.
+func (r *Resumer) Run(ctx context.Context) error {
+	logger := contextx.From(ctx)
+	logger.Info("saga resumer started", "interval", r.cfg.Interval, "idle", r.cfg.Idle)
+
+	ticker := time.NewTicker(r.cfg.Interval)
+	defer ticker.Stop()
+
+	for {
+		if err := r.orchestrator.Resume(ctx, r.cfg.Idle); err != nil && ctx.Err() == nil {
+			logger.Error("resume sagas failed", "error", err)
+		}
+		r.started.Fire()
+
+		select {
+		case <-ctx.Done():
+			logger.Info("saga resumer stopped")
+			return nil
+		case <-ticker.C:
+		}
+	}
+}
@@ -0,0 +1,307 @@
+// Package saga orchestrates workflows that span several aggregates, such
+// as order → payment → shipment. A saga runs its steps in order and, when
+// a step fails, runs the compensations of the completed steps in reverse.
+// Progress is persisted after every step so an interrupted saga resumes
+// where it stopped.
+//
+// Instances are versioned: every save must match the version that was
+// loaded, so when two orchestrators drive the same instance only one of
+// them can record progress and the other stops with ErrStaleInstance.
+package saga
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/blackhorseya/go-ddd/internal/application/event"
+	"github.com/blackhorseya/go-ddd/internal/domain"
+	"github.com/blackhorseya/go-ddd/pkg/contextx"
+)
+
+var (
+	// ErrUnknownSaga is returned when no definition is registered under a name.
+	ErrUnknownSaga = errors.New("saga is not registered")
+
+	// ErrInstanceNotFound is returned by stores when an instance does not exist.
+	ErrInstanceNotFound = errors.New("saga instance not found")
+
+	// ErrInstanceExists is returned by stores when an instance ID is already used.
+	ErrInstanceExists = errors.New("saga instance already exists")
+
+	// ErrStaleInstance is returned by stores when an instance was saved by
+	// someone else since it was loaded.
+	ErrStaleInstance = errors.New("saga instance was modified concurrently")
+)
+
+// Status is the lifecycle state of a saga instance.
+type Status string
+
+const (
+	StatusRunning      Status = "running"
+	StatusCompensating Status = "compensating"
+	StatusCompleted    Status = "completed"
+	StatusCompensated  Status = "compensated"
+)
+
+// IsActive reports whether an instance in this status still has work to do.
+func (s Status) IsActive() bool {
+	return s == StatusRunning || s == StatusCompensating
+}
+
+// Step is one unit of work in a saga. Do and Compensate may run more than
+// once after a crash, so they must be idempotent. Both may modify data,
+// e.g. to remember a payment ID needed for the refund.
+type Step[D any] struct {
+	Name       string
+	Do         func(ctx context.Context, data *D) error
+	Compensate func(ctx context.Context, data *D) error // optional
+}
+
+// Definition describes a saga and its steps.
+type Definition[D any] struct {
+	Name  string
+	Steps []Step[D]
+}
+
+// Instance is the persisted state of one saga run.
+type Instance struct {
+	ID     string
+	Saga   string
+	Status Status
+
+	// Step is the number of completed steps. While compensating it counts
+	// down as compensations complete.
+	Step int
+
+	// Data is the JSON encoding of the saga data.
+	Data []byte
+
+	// Error is the step failure that triggered compensation.
+	Error string
+
+	// Version counts the saves of the instance, see Store.Save.
+	Version int
+
+	UpdatedAt time.Time
+}
+
+// Store persists saga instances.
+type Store interface {
+	Create(ctx context.Context, inst Instance) error
+
+	// Save updates inst if its stored version still equals inst.Version
+	// and increments the stored version. It returns ErrStaleInstance when
+	// the instance was saved by someone else in the meantime.
+	Save(ctx context.Context, inst Instance) error
+
+	Load(ctx context.Context, id string) (Instance, error)
+
+	// ListActive returns instances that are running or compensating.
+	ListActive(ctx context.Context) ([]Instance, error)
+}
+
+// runner executes an instance of one registered definition.
+type runner func(ctx context.Context, inst *Instance) error
+
+// Orchestrator runs registered sagas. It is safe for concurrent use, and
+// several orchestrators may share a store: versioned saves ensure only one
+// of them advances a given instance.
+type Orchestrator struct {
+	store Store
+
+	mu      sync.RWMutex
+	runners map[string]runner
+}
+
+// NewOrchestrator creates an Orchestrator backed by store.
+func NewOrchestrator(store Store) *Orchestrator {
+	return &Orchestrator{store: store, runners: make(map[string]runner)}
+}
+
+// Register adds a saga definition. It panics if the name is already used.
+func Register[D any](o *Orchestrator, def Definition[D]) {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+
+	if _, ok := o.runners[def.Name]; ok {
+		panic(fmt.Sprintf("saga: %s already registered", def.Name))
+	}
+	o.runners[def.Name] = func(ctx context.Context, inst *Instance) error {
+		return run(ctx, o.store, def, inst)
+	}
+}
+
+// Start creates an instance of the named saga with the given data and runs
+// it to completion or compensation. It returns the final instance; a
+// compensated saga is not an error, callers inspect Status instead.
+func (o *Orchestrator) Start(ctx context.Context, name, id string, data any) (Instance, error) {
+	r, err := o.runner(name)
+	if err != nil {
+		return Instance{}, err
+	}
+
+	b, err := json.Marshal(data)
+	if err != nil {
+		return Instance{}, fmt.Errorf("encode saga %s data: %w", name, err)
+	}
+
+	inst := Instance{ID: id, Saga: name, Status: StatusRunning, Data: b, UpdatedAt: time.Now()}
+	if err := o.store.Create(ctx, inst); err != nil {
+		return Instance{}, err
+	}
+
+	err = r(ctx, &inst)
+	return inst, err
+}
+
+// Resume continues the active instances not updated for at least idle,
+// e.g. those left behind by a crashed worker; an idle of 0 resumes all of
+// them. Each instance is claimed with a versioned save before it runs, so
+// an instance another orchestrator claimed or advanced first is skipped.
+// Instances are resumed independently; errors are joined.
+func (o *Orchestrator) Resume(ctx context.Context, idle time.Duration) error {
+	insts, err := o.store.ListActive(ctx)
+	if err != nil {
+		return err
+	}
+
+	logger := contextx.From(ctx)
+	cutoff := time.Now().Add(-idle)
+
+	var errs []error
+	for _, inst := range insts {
+		if idle > 0 && inst.UpdatedAt.After(cutoff) {
+			continue
+		}
+		r, err := o.runner(inst.Saga)
+		if err == nil {
+			err = o.claim(ctx, &inst)
+		}
+		if err == nil {
+			logger.Info("resuming saga", "saga", inst.Saga, "id", inst.ID, "status", inst.Status, "step", inst.Step)
+			err = r(ctx, &inst)
+		}
+		if errors.Is(err, ErrStaleInstance) {
+			logger.Info("saga resumed elsewhere", "saga", inst.Saga, "id", inst.ID)
+			continue
+		}
+		if err != nil {
+			errs = append(errs, fmt.Errorf("resume saga %s: %w", inst.ID, err))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// claim takes over inst by saving it unchanged, which fails with
+// ErrStaleInstance if another orchestrator saved it first.
+func (o *Orchestrator) claim(ctx context.Context, inst *Instance) error {
+	inst.UpdatedAt = time.Now()
+	if err := o.store.Save(ctx, *inst); err != nil {
+		return err
+	}
+	inst.Version++
+	return nil
+}
+
+// StartOn returns an event handler that starts the named saga for each
+// event, so sagas can be driven by domain events:
+//
+//	dispatcher.Subscribe("order.placed", saga.StartOn(o, "checkout",
+//		func(e domain.DomainEvent) (string, Checkout, error) {
+//			return "checkout-" + e.AggregateID(), Checkout{OrderID: e.AggregateID()}, nil
+//		}))
+//
+// The instance ID should be derived from the event so redelivery does not
+// start the saga twice; a duplicate ID is ignored.
+func StartOn[D any](o *Orchestrator, name string, build func(e domain.DomainEvent) (string, D, error)) event.Handler {
+	return func(ctx context.Context, e domain.DomainEvent) error {
+		id, data, err := build(e)
+		if err != nil {
+			return err
+		}
+		_, err = o.Start(ctx, name, id, data)
+		if errors.Is(err, ErrInstanceExists) {
+			return nil
+		}
+		return err
+	}
+}
+
+// runner returns the runner registered under name.
+func (o *Orchestrator) runner(name string) (runner, error) {
+	o.mu.RLock()
+	defer o.mu.RUnlock()
+
+	r, ok := o.runners[name]
+	if !ok {
+		return nil, fmt.Errorf("%w: %s", ErrUnknownSaga, name)
+	}
+	return r, nil
+}
+
+// run drives inst forward, then backward if a step fails, saving the
+// instance after every transition.
+func run[D any](ctx context.Context, store Store, def Definition[D], inst *Instance) error {
+	var data D
+	if err := json.Unmarshal(inst.Data, &data); err != nil {
+		return fmt.Errorf("decode saga %s data: %w", inst.ID, err)
+	}
+
+	save := func(status Status, step int) error {
+		b, err := json.Marshal(data)
+		if err != nil {
+			return fmt.Errorf("encode saga %s data: %w", inst.ID, err)
+		}
+		inst.Status, inst.Step, inst.Data, inst.UpdatedAt = status, step, b, time.Now()
+		if err := store.Save(ctx, *inst); err != nil {
+			return err
+		}
+		inst.Version++
+		return nil
+	}
+
+	logger := contextx.From(ctx).WithFields("saga", def.Name, "id", inst.ID)
+
+	for inst.Status == StatusRunning && inst.Step < len(def.Steps) {
+		step := def.Steps[inst.Step]
+		if err := step.Do(ctx, &data); err != nil {
+			logger.Warn("saga step failed, compensating", "step", step.Name, "error", err)
+			inst.Error = fmt.Sprintf("%s: %v", step.Name, err)
+			if err := save(StatusCompensating, inst.Step); err != nil {
+				return err
+			}
+			break
+		}
+		if err := save(StatusRunning, inst.Step+1); err != nil {
+			return err
+		}
+	}
+
+	if inst.Status == StatusRunning {
+		logger.Info("saga completed")
+		return save(StatusCompleted, inst.Step)
+	}
+
+	for inst.Status == StatusCompensating && inst.Step > 0 {
+		step := def.Steps[inst.Step-1]
+		if step.Compensate != nil {
+			if err := step.Compensate(ctx, &data); err != nil {
+				// Stay in compensating so Resume retries this compensation.
+				return fmt.Errorf("compensate %s step %s: %w", inst.ID, step.Name, err)
+			}
+		}
+		if err := save(StatusCompensating, inst.Step-1); err != nil {
+			return err
+		}
+	}
+
+	if inst.Status == StatusCompensating {
+		logger.Info("saga compensated")
+		return save(StatusCompensated, 0)
+	}
+	return nil
+}
@@ -0,0 +1,306 @@
+package saga_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/blackhorseya/go-ddd/internal/application/saga"
+	"github.com/blackhorseya/go-ddd/internal/domain"
+)
+
+type checkout struct {
+	OrderID   string `json:"order_id"`
+	PaymentID string `json:"payment_id"`
+}
+
+// journal records the actions performed by the checkout saga.
+type journal struct {
+	calls        []string
+	failStep     string
+	failRefundOn int
+	refunds      int
+}
+
+func (j *journal) step(name string, do func(*checkout)) func(context.Context, *checkout) error {
+	return func(_ context.Context, d *checkout) error {
+		if j.failStep == name {
+			return errors.New(name + " rejected")
+		}
+		j.calls = append(j.calls, name)
+		if do != nil {
+			do(d)
+		}
+		return nil
+	}
+}
+
+func (j *journal) definition() saga.Definition[checkout] {
+	return saga.Definition[checkout]{
+		Name: "checkout",
+		Steps: []saga.Step[checkout]{
+			{
+				Name:       "reserve",
+				Do:         j.step("reserve", nil),
+				Compensate: j.step("release", nil),
+			},
+			{
+				Name: "charge",
+				Do:   j.step("charge", func(d *checkout) { d.PaymentID = "pay-" + d.OrderID }),
+				Compensate: func(_ context.Context, d *checkout) error {
+					j.refunds++
+					if j.refunds == j.failRefundOn {
+						return errors.New("gateway down")
+					}
+					j.calls = append(j.calls, "refund:"+d.PaymentID)
+					return nil
+				},
+			},
+			{
+				Name: "ship",
+				Do:   j.step("ship", nil),
+			},
+		},
+	}
+}
+
+func TestOrchestrator_Start(t *testing.T) {
+	tests := []struct {
+		name       string
+		failStep   string
+		wantStatus saga.Status
+		wantCalls  []string
+	}{
+		{
+			name:       "all steps succeed",
+			wantStatus: saga.StatusCompleted,
+			wantCalls:  []string{"reserve", "charge", "ship"},
+		},
+		{
+			name:       "failure compensates completed steps in reverse",
+			failStep:   "ship",
+			wantStatus: saga.StatusCompensated,
+			wantCalls:  []string{"reserve", "charge", "refund:pay-o-1", "release"},
+		},
+		{
+			name:       "first step failure has nothing to compensate",
+			failStep:   "reserve",
+			wantStatus: saga.StatusCompensated,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Arrange
+			j := &journal{failStep: tt.failStep}
+			store := saga.NewMemoryStore()
+			o := saga.NewOrchestrator(store)
+			saga.Register(o, j.definition())
+
+			// Act
+			inst, err := o.Start(context.Background(), "checkout", "c-1", checkout{OrderID: "o-1"})
+
+			// Assert
+			require.NoError(t, err)
+			assert.Equal(t, tt.wantStatus, inst.Status)
+			assert.Equal(t, tt.wantCalls, j.calls)
+
+			stored, err := store.Load(context.Background(), "c-1")
+			require.NoError(t, err)
+			assert.Equal(t, tt.wantStatus, stored.Status)
+		})
+	}
+}
+
+func TestOrchestrator_Start_UnknownSaga(t *testing.T) {
+	o := saga.NewOrchestrator(saga.NewMemoryStore())
+
+	_, err := o.Start(context.Background(), "missing", "id", nil)
+
+	assert.ErrorIs(t, err, saga.ErrUnknownSaga)
+}
+
+func TestOrchestrator_Resume(t *testing.T) {
+	// Arrange: a crash left the saga after its first step
+	ctx := context.Background()
+	store := saga.NewMemoryStore()
+	data, _ := json.Marshal(checkout{OrderID: "o-1"})
+	require.NoError(t, store.Create(ctx, saga.Instance{
+		ID: "c-1", Saga: "checkout", Status: saga.StatusRunning, Step: 1, Data: data,
+	}))
+
+	j := &journal{}
+	o := saga.NewOrchestrator(store)
+	saga.Register(o, j.definition())
+
+	// Act
+	err := o.Resume(ctx, 0)
+
+	// Assert
+	require.NoError(t, err)
+	assert.Equal(t, []string{"charge", "ship"}, j.calls)
+	inst, err := store.Load(ctx, "c-1")
+	require.NoError(t, err)
+	assert.Equal(t, saga.StatusCompleted, inst.Status)
+
+	var got checkout
+	require.NoError(t, json.Unmarshal(inst.Data, &got))
+	assert.Equal(t, "pay-o-1", got.PaymentID)
+}
+
+func TestOrchestrator_Resume_FailedCompensation(t *testing.T) {
+	// Arrange
+	ctx := context.Background()
+	j := &journal{failStep: "ship", failRefundOn: 1}
+	store := saga.NewMemoryStore()
+	o := saga.NewOrchestrator(store)
+	saga.Register(o, j.definition())
+
+	// Act: the first refund fails and leaves the saga compensating
+	inst, err := o.Start(ctx, "checkout", "c-1", checkout{OrderID: "o-1"})
+	require.Error(t, err)
+	assert.Equal(t, saga.StatusCompensating, inst.Status)
+
+	err = o.Resume(ctx, 0)
+
+	// Assert
+	require.NoError(t, err)
+	inst, err = store.Load(ctx, "c-1")
+	require.NoError(t, err)
+	assert.Equal(t, saga.StatusCompensated, inst.Status)
+	assert.Equal(t, "ship: ship rejected", inst.Error)
+	assert.Equal(t, []string{"reserve", "charge", "refund:pay-o-1", "release"}, j.calls)
+}
+
+func TestOrchestrator_Resume_SkipsActiveInstances(t *testing.T) {
+	// Arrange: the instance made progress a moment ago
+	ctx := context.Background()
+	store := saga.NewMemoryStore()
+	data, _ := json.Marshal(checkout{OrderID: "o-1"})
+	require.NoError(t, store.Create(ctx, saga.Instance{
+		ID: "c-1", Saga: "checkout", Status: saga.StatusRunning, Step: 1, Data: data, UpdatedAt: time.Now(),
+	}))
+
+	j := &journal{}
+	o := saga.NewOrchestrator(store)
+	saga.Register(o, j.definition())
+
+	// Act
+	err := o.Resume(ctx, time.Minute)
+
+	// Assert
+	require.NoError(t, err)
+	assert.Empty(t, j.calls)
+}
+
+func TestOrchestrator_Resume_Concurrent(t *testing.T) {
+	// Arrange: two orchestrators share the store of a stalled saga
+	ctx := context.Background()
+	store := saga.NewMemoryStore()
+	data, _ := json.Marshal(checkout{OrderID: "o-1"})
+	require.NoError(t, store.Create(ctx, saga.Instance{
+		ID: "c-1", Saga: "checkout", Status: saga.StatusRunning, Step: 1, Data: data,
+	}))
+
+	j := &journal{}
+	first, second := saga.NewOrchestrator(store), saga.NewOrchestrator(store)
+	saga.Register(second, j.definition())
+
+	// The second orchestrator takes the saga over while the first is
+	// still charging
+	def := j.definition()
+	charge := def.Steps[1].Do
+	var takeover error
+	def.Steps[1].Do = func(ctx context.Context, d *checkout) error {
+		if err := charge(ctx, d); err != nil {
+			return err
+		}
+		takeover = second.Resume(ctx, 0)
+		return nil
+	}
+	saga.Register(first, def)
+
+	// Act
+	err := first.Resume(ctx, 0)
+
+	// Assert: the first orchestrator's progress is rejected and only the
+	// second one ships
+	require.NoError(t, err)
+	require.NoError(t, takeover)
+	assert.Equal(t, []string{"charge", "charge", "ship"}, j.calls)
+	inst, err := store.Load(ctx, "c-1")
+	require.NoError(t, err)
+	assert.Equal(t, saga.StatusCompleted, inst.Status)
+}
+
+func TestMemoryStore_Save_Stale(t *testing.T) {
+	// Arrange
+	ctx := context.Background()
+	store := saga.NewMemoryStore()
+	inst := saga.Instance{ID: "c-1", Saga: "checkout", Status: saga.StatusRunning}
+	require.NoError(t, store.Create(ctx, inst))
+	require.NoError(t, store.Save(ctx, inst))
+
+	// Act: a second save from the same loaded version
+	err := store.Save(ctx, inst)
+
+	// Assert
+	require.ErrorIs(t, err, saga.ErrStaleInstance)
+	got, err := store.Load(ctx, "c-1")
+	require.NoError(t, err)
+	assert.Equal(t, 1, got.Version)
+}
+
+func TestResumer_Run(t *testing.T) {
+	// Arrange: a crash left the saga after its first step
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	store := saga.NewMemoryStore()
+	data, _ := json.Marshal(checkout{OrderID: "o-1"})
+	require.NoError(t, store.Create(ctx, saga.Instance{
+		ID: "c-1", Saga: "checkout", Status: saga.StatusRunning, Step: 1, Data: data,
+	}))
+
+	j := &journal{}
+	o := saga.NewOrchestrator(store)
+	saga.Register(o, j.definition())
+	r := saga.NewResumer(o, saga.ResumerConfig{Interval: time.Hour, Idle: time.Minute})
+
+	// Act
+	done := make(chan error, 1)
+	go func() { done <- r.Run(ctx) }()
+	<-r.Started()
+	cancel()
+
+	// Assert
+	require.NoError(t, <-done)
+	inst, err := store.Load(context.Background(), "c-1")
+	require.NoError(t, err)
+	assert.Equal(t, saga.StatusCompleted, inst.Status)
+}
+
+func TestStartOn(t *testing.T) {
+	// Arrange
+	j := &journal{}
+	o := saga.NewOrchestrator(saga.NewMemoryStore())
+	saga.Register(o, j.definition())
+
+	h := saga.StartOn(o, "checkout", func(e domain.DomainEvent) (string, checkout, error) {
+		return "checkout-" + e.AggregateID(), checkout{OrderID: e.AggregateID()}, nil
+	})
+	e := domain.NewBaseEvent("order.placed", "o-1")
+
+	// Act: redelivery of the same event
+	first := h(context.Background(), e)
+	second := h(context.Background(), e)
+
+	// Assert
+	require.NoError(t, first)
+	require.NoError(t, second)
+	assert.Equal(t, []string{"reserve", "charge", "ship"}, j.calls)
+}
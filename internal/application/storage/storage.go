@@ -0,0 +1,25 @@
+// Package storage defines the application port for object storage.
+// Use cases write large artifacts such as export files through a Store
+// and hand clients a time-limited presigned URL instead of streaming the
+// bytes through the API. Every pkg/storagex backend satisfies Store.
+package storage
+
+import (
+	"context"
+	"io"
+	"time"
+
+	"github.com/blackhorseya/go-ddd/pkg/storagex"
+)
+
+// ErrNotFound is returned when an object does not exist.
+var ErrNotFound = storagex.ErrNotFound
+
+// Store stores objects by key, e.g. "exports/orders/01J....csv".
+type Store interface {
+	// Put writes the object read from r, replacing any existing object.
+	Put(ctx context.Context, key string, r io.Reader, contentType string) error
+
+	// PresignGet returns a URL that downloads the object until ttl elapses.
+	PresignGet(ctx context.Context, key string, ttl time.Duration) (string, error)
+}
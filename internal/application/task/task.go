@@ -0,0 +1,95 @@
+// Package task defines the application port for asynchronous tasks.
+// Use cases enqueue typed tasks through an Enqueuer without knowing the
+// queue backend; workers register typed handlers on a Registrar.
+//
+//	var SendReceipt = task.New[Receipt]("email:receipt", task.Options{MaxRetry: 5})
+//
+//	// use case
+//	err := SendReceipt.Enqueue(ctx, queue, Receipt{OrderID: id})
+//
+//	// worker
+//	task.Handle(server, SendReceipt, func(ctx context.Context, r Receipt) error { ... })
+package task
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+)
+
+// ErrSkipRetry makes a failing handler skip the remaining retries and move
+// the task straight to the dead-letter queue. Wrap it for permanent
+// failures such as malformed payloads:
+//
+//	return fmt.Errorf("unknown order %s: %w", id, task.ErrSkipRetry)
+var ErrSkipRetry = errors.New("skip retry")
+
+// Options controls how a task is enqueued. Zero values use backend defaults.
+type Options struct {
+	// Queue is the queue name. Default: "default"
+	Queue string
+
+	// MaxRetry is the number of retries before the task is dead-lettered.
+	MaxRetry int
+
+	// Timeout bounds a single attempt.
+	Timeout time.Duration
+
+	// ProcessIn delays the first attempt.
+	ProcessIn time.Duration
+
+	// ID deduplicates tasks: a task with an ID already queued is rejected.
+	ID string
+}
+
+// Enqueuer submits tasks to a queue.
+type Enqueuer interface {
+	Enqueue(ctx context.Context, taskType string, payload []byte, opts Options) error
+}
+
+// HandlerFunc processes a raw task payload.
+type HandlerFunc func(ctx context.Context, payload []byte) error
+
+// Registrar registers task handlers by type.
+type Registrar interface {
+	HandleFunc(taskType string, h HandlerFunc)
+}
+
+// Task is a typed task definition with payload type P.
+type Task[P any] struct {
+	Type    string
+	Options Options
+}
+
+// New defines a task type with default options.
+func New[P any](taskType string, opts Options) Task[P] {
+	return Task[P]{Type: taskType, Options: opts}
+}
+
+// Enqueue submits payload using the task's default options.
+func (t Task[P]) Enqueue(ctx context.Context, q Enqueuer, payload P) error {
+	return t.EnqueueWith(ctx, q, payload, t.Options)
+}
+
+// EnqueueWith submits payload with explicit options, e.g. a per-call ID.
+func (t Task[P]) EnqueueWith(ctx context.Context, q Enqueuer, payload P, opts Options) error {
+	b, err := json.Marshal(payload)
+	if err != nil {
+		return fmt.Errorf("encode task %s: %w", t.Type, err)
+	}
+	return q.Enqueue(ctx, t.Type, b, opts)
+}
+
+// Handle registers h for t. Payloads that cannot be decoded are
+// dead-lettered without retries.
+func Handle[P any](r Registrar, t Task[P], h func(ctx context.Context, payload P) error) {
+	r.HandleFunc(t.Type, func(ctx context.Context, b []byte) error {
+		var p P
+		if err := json.Unmarshal(b, &p); err != nil {
+			return fmt.Errorf("decode task %s: %w: %w", t.Type, err, ErrSkipRetry)
+		}
+		return h(ctx, p)
+	})
+}
@@ -0,0 +1,66 @@
+package task_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/blackhorseya/go-ddd/internal/application/task"
+)
+
+type receipt struct {
+	OrderID string `json:"order_id"`
+}
+
+// memoryQueue delivers enqueued tasks to registered handlers synchronously.
+type memoryQueue struct {
+	handlers map[string]task.HandlerFunc
+	opts     []task.Options
+}
+
+func (q *memoryQueue) HandleFunc(taskType string, h task.HandlerFunc) {
+	if q.handlers == nil {
+		q.handlers = make(map[string]task.HandlerFunc)
+	}
+	q.handlers[taskType] = h
+}
+
+func (q *memoryQueue) Enqueue(ctx context.Context, taskType string, payload []byte, opts task.Options) error {
+	q.opts = append(q.opts, opts)
+	return q.handlers[taskType](ctx, payload)
+}
+
+func TestTask_Enqueue(t *testing.T) {
+	// Arrange
+	sendReceipt := task.New[receipt]("email:receipt", task.Options{Queue: "mail", MaxRetry: 5})
+	q := &memoryQueue{}
+
+	var got receipt
+	task.Handle(q, sendReceipt, func(_ context.Context, r receipt) error {
+		got = r
+		return nil
+	})
+
+	// Act
+	err := sendReceipt.Enqueue(context.Background(), q, receipt{OrderID: "o-1"})
+
+	// Assert
+	require.NoError(t, err)
+	assert.Equal(t, receipt{OrderID: "o-1"}, got)
+	assert.Equal(t, []task.Options{{Queue: "mail", MaxRetry: 5}}, q.opts)
+}
+
+func TestHandle_MalformedPayload(t *testing.T) {
+	// Arrange
+	sendReceipt := task.New[receipt]("email:receipt", task.Options{})
+	q := &memoryQueue{}
+	task.Handle(q, sendReceipt, func(context.Context, receipt) error { return nil })
+
+	// Act
+	err := q.Enqueue(context.Background(), "email:receipt", []byte("{"), task.Options{})
+
+	// Assert
+	assert.ErrorIs(t, err, task.ErrSkipRetry)
+}
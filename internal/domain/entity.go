@@ -0,0 +1,202 @@
+package domain
+
+import (
+	"errors"
+	"time"
+)
+
+// Entity errors
+var (
+	ErrEmptyEntityID = errors.New("entity id must not be empty")
+
+	// ErrConcurrentModification is returned by repositories when the stored
+	// version no longer matches the aggregate, i.e. another writer won.
+	ErrConcurrentModification = errors.New("aggregate was modified concurrently")
+
+	// ErrVersionMismatch is returned when a caller-supplied expected version
+	// (e.g. an If-Match header) does not match the aggregate.
+	ErrVersionMismatch = errors.New("aggregate version does not match expected version")
+)
+
+// ============================================================================
+// Entity (具有唯一標識的領域物件)
+// ============================================================================
+
+// Entity is the base type for domain objects with identity.
+// Embed it in concrete entities to get identity equality and timestamps.
+type Entity[ID comparable] struct {
+	id        ID
+	createdAt time.Time
+	updatedAt time.Time
+	deletedAt time.Time
+	createdBy string
+	updatedBy string
+}
+
+// NewEntity creates a new entity with the given ID and current timestamps
+func NewEntity[ID comparable](id ID) (Entity[ID], error) {
+	var zero ID
+	if id == zero {
+		return Entity[ID]{}, ErrEmptyEntityID
+	}
+	now := time.Now().UTC()
+	return Entity[ID]{
+		id:        id,
+		createdAt: now,
+		updatedAt: now,
+	}, nil
+}
+
+// RestoreEntity reconstitutes an entity from persisted state without validation
+func RestoreEntity[ID comparable](id ID, createdAt, updatedAt time.Time) Entity[ID] {
+	return Entity[ID]{
+		id:        id,
+		createdAt: createdAt,
+		updatedAt: updatedAt,
+	}
+}
+
+// Getters
+func (e Entity[ID]) ID() ID               { return e.id }
+func (e Entity[ID]) CreatedAt() time.Time { return e.createdAt }
+func (e Entity[ID]) UpdatedAt() time.Time { return e.updatedAt }
+func (e Entity[ID]) DeletedAt() time.Time { return e.deletedAt }
+func (e Entity[ID]) IsDeleted() bool      { return !e.deletedAt.IsZero() }
+func (e Entity[ID]) CreatedBy() string    { return e.createdBy }
+func (e Entity[ID]) UpdatedBy() string    { return e.updatedBy }
+
+// Equals reports whether two entities have the same identity.
+// Entities without an ID are never equal.
+func (e Entity[ID]) Equals(other Entity[ID]) bool {
+	var zero ID
+	return e.id != zero && e.id == other.id
+}
+
+// Touch updates the modification timestamp. Call it from behavior methods
+// that change entity state.
+func (e *Entity[ID]) Touch() {
+	e.updatedAt = time.Now().UTC()
+}
+
+// ============================================================================
+// Audit (稽核欄位)
+// ============================================================================
+
+// AuditCreate records actor as the creator of a new entity.
+// Repositories call it before inserting; see sqldb.BeforeInsert.
+func (e *Entity[ID]) AuditCreate(actor string) {
+	e.createdBy = actor
+	e.updatedBy = actor
+	e.updatedAt = time.Now().UTC()
+}
+
+// AuditUpdate records actor as the author of the latest change and refreshes UpdatedAt.
+// Repositories call it before updating; see sqldb.BeforeUpdate.
+func (e *Entity[ID]) AuditUpdate(actor string) {
+	e.updatedBy = actor
+	e.updatedAt = time.Now().UTC()
+}
+
+// WithAudit returns a copy with the persisted audit authors.
+// Repositories use it with RestoreEntity when loading rows.
+func (e Entity[ID]) WithAudit(createdBy, updatedBy string) Entity[ID] {
+	e.createdBy = createdBy
+	e.updatedBy = updatedBy
+	return e
+}
+
+// ============================================================================
+// Soft Delete (軟刪除)
+// ============================================================================
+
+// DeletedFilter selects which rows repository queries return with respect to soft deletion.
+type DeletedFilter int
+
+const (
+	// ExcludeDeleted returns only live rows. It is the zero value and the default.
+	ExcludeDeleted DeletedFilter = iota
+	// IncludeDeleted returns live and soft-deleted rows.
+	IncludeDeleted
+	// OnlyDeleted returns only soft-deleted rows, e.g. for a trash view.
+	OnlyDeleted
+)
+
+// SoftDelete marks the entity as deleted. Deleting an already deleted
+// entity keeps the original deletion time.
+func (e *Entity[ID]) SoftDelete() {
+	if e.IsDeleted() {
+		return
+	}
+	now := time.Now().UTC()
+	e.deletedAt = now
+	e.updatedAt = now
+}
+
+// Restore clears the soft-delete mark.
+func (e *Entity[ID]) Restore() {
+	if !e.IsDeleted() {
+		return
+	}
+	e.deletedAt = time.Time{}
+	e.updatedAt = time.Now().UTC()
+}
+
+// WithDeletedAt returns a copy with the deletion time set.
+// Repositories use it with RestoreEntity when loading soft-deleted rows.
+func (e Entity[ID]) WithDeletedAt(deletedAt time.Time) Entity[ID] {
+	e.deletedAt = deletedAt
+	return e
+}
+
+// ============================================================================
+// Aggregate Root (聚合根，一致性邊界)
+// ============================================================================
+
+// AggregateRoot is the base type for aggregate roots.
+// It extends Entity with a version used for optimistic concurrency control
+// and records the domain events raised by its behavior methods.
+type AggregateRoot[ID comparable] struct {
+	Entity[ID]
+	EventRecorder
+	version int
+}
+
+// NewAggregateRoot creates a new aggregate root with the given ID at version 0
+func NewAggregateRoot[ID comparable](id ID) (AggregateRoot[ID], error) {
+	entity, err := NewEntity(id)
+	if err != nil {
+		return AggregateRoot[ID]{}, err
+	}
+	return AggregateRoot[ID]{Entity: entity}, nil
+}
+
+// RestoreAggregateRoot reconstitutes an aggregate root from persisted state without validation
+func RestoreAggregateRoot[ID comparable](id ID, createdAt, updatedAt time.Time, version int) AggregateRoot[ID] {
+	return AggregateRoot[ID]{
+		Entity:  RestoreEntity(id, createdAt, updatedAt),
+		version: version,
+	}
+}
+
+// Version returns the aggregate version. It is incremented on each persisted change.
+func (a AggregateRoot[ID]) Version() int { return a.version }
+
+// IncrementVersion bumps the version.
+// Repositories call it after successfully persisting the aggregate.
+//
+// Convention: repositories update with the version they loaded and report a
+// lost update when no row matches:
+//
+//	UPDATE orders SET ..., version = version + 1 WHERE id = $1 AND version = $2
+func (a *AggregateRoot[ID]) IncrementVersion() {
+	a.version++
+}
+
+// CheckVersion returns ErrVersionMismatch unless expected equals the current version.
+// Use it to honor a version supplied by the client before applying changes.
+func (a AggregateRoot[ID]) CheckVersion(expected int) error {
+	if expected != a.version {
+		return ErrVersionMismatch
+	}
+	return nil
+}
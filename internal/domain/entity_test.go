@@ -0,0 +1,201 @@
+package domain
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+// ============================================================================
+// Entity Tests
+// ============================================================================
+
+func TestNewEntity(t *testing.T) {
+	tests := []struct {
+		name    string
+		id      string
+		wantErr error
+	}{
+		{"valid id", "order-1", nil},
+		{"empty id", "", ErrEmptyEntityID},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Act
+			e, err := NewEntity(tt.id)
+
+			// Assert
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("NewEntity() error = %v, want %v", err, tt.wantErr)
+			}
+			if err != nil {
+				return
+			}
+			if e.ID() != tt.id {
+				t.Errorf("ID() = %v, want %v", e.ID(), tt.id)
+			}
+			if e.CreatedAt().IsZero() || !e.CreatedAt().Equal(e.UpdatedAt()) {
+				t.Errorf("timestamps not initialized: created=%v updated=%v", e.CreatedAt(), e.UpdatedAt())
+			}
+		})
+	}
+}
+
+func TestRestoreEntity(t *testing.T) {
+	created := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	updated := created.Add(time.Hour)
+
+	e := RestoreEntity(42, created, updated)
+
+	if e.ID() != 42 {
+		t.Errorf("ID() = %v, want 42", e.ID())
+	}
+	if !e.CreatedAt().Equal(created) {
+		t.Errorf("CreatedAt() = %v, want %v", e.CreatedAt(), created)
+	}
+	if !e.UpdatedAt().Equal(updated) {
+		t.Errorf("UpdatedAt() = %v, want %v", e.UpdatedAt(), updated)
+	}
+}
+
+func TestEntity_Equals(t *testing.T) {
+	ts := time.Now()
+
+	tests := []struct {
+		name string
+		a, b Entity[string]
+		want bool
+	}{
+		{"same id", RestoreEntity("a", ts, ts), RestoreEntity("a", ts.Add(time.Hour), ts), true},
+		{"different id", RestoreEntity("a", ts, ts), RestoreEntity("b", ts, ts), false},
+		{"zero ids", Entity[string]{}, Entity[string]{}, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.a.Equals(tt.b); got != tt.want {
+				t.Errorf("Equals() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestEntity_Touch(t *testing.T) {
+	past := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	e := RestoreEntity("a", past, past)
+
+	e.Touch()
+
+	if !e.UpdatedAt().After(past) {
+		t.Errorf("UpdatedAt() = %v, want after %v", e.UpdatedAt(), past)
+	}
+	if !e.CreatedAt().Equal(past) {
+		t.Errorf("CreatedAt() changed to %v", e.CreatedAt())
+	}
+}
+
+func TestEntity_Audit(t *testing.T) {
+	// Arrange
+	past := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	e := RestoreEntity("a", past, past)
+
+	// Act & Assert
+	e.AuditCreate("alice")
+	if e.CreatedBy() != "alice" || e.UpdatedBy() != "alice" {
+		t.Errorf("after AuditCreate: CreatedBy() = %q, UpdatedBy() = %q", e.CreatedBy(), e.UpdatedBy())
+	}
+
+	e.AuditUpdate("bob")
+	if e.CreatedBy() != "alice" || e.UpdatedBy() != "bob" {
+		t.Errorf("after AuditUpdate: CreatedBy() = %q, UpdatedBy() = %q", e.CreatedBy(), e.UpdatedBy())
+	}
+	if !e.UpdatedAt().After(past) || !e.CreatedAt().Equal(past) {
+		t.Errorf("CreatedAt() = %v, UpdatedAt() = %v", e.CreatedAt(), e.UpdatedAt())
+	}
+
+	restored := RestoreEntity("a", past, past).WithAudit("alice", "bob")
+	if restored.CreatedBy() != "alice" || restored.UpdatedBy() != "bob" {
+		t.Errorf("WithAudit() = %q, %q", restored.CreatedBy(), restored.UpdatedBy())
+	}
+}
+
+func TestEntity_SoftDelete(t *testing.T) {
+	// Arrange
+	past := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	e := RestoreEntity("a", past, past)
+
+	// Act
+	e.SoftDelete()
+	deletedAt := e.DeletedAt()
+	e.SoftDelete()
+
+	// Assert
+	if !e.IsDeleted() || deletedAt.IsZero() {
+		t.Fatalf("IsDeleted() = %v, DeletedAt() = %v", e.IsDeleted(), deletedAt)
+	}
+	if !e.DeletedAt().Equal(deletedAt) {
+		t.Errorf("second SoftDelete() changed DeletedAt() to %v", e.DeletedAt())
+	}
+	if !e.UpdatedAt().Equal(deletedAt) {
+		t.Errorf("UpdatedAt() = %v, want %v", e.UpdatedAt(), deletedAt)
+	}
+}
+
+func TestEntity_Restore(t *testing.T) {
+	past := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	e := RestoreEntity("a", past, past).WithDeletedAt(past)
+
+	e.Restore()
+
+	if e.IsDeleted() {
+		t.Error("IsDeleted() = true after Restore()")
+	}
+	if !e.UpdatedAt().After(past) {
+		t.Errorf("UpdatedAt() = %v, want after %v", e.UpdatedAt(), past)
+	}
+}
+
+// ============================================================================
+// AggregateRoot Tests
+// ============================================================================
+
+func TestNewAggregateRoot(t *testing.T) {
+	a, err := NewAggregateRoot("order-1")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if a.ID() != "order-1" {
+		t.Errorf("ID() = %v, want order-1", a.ID())
+	}
+	if a.Version() != 0 {
+		t.Errorf("Version() = %v, want 0", a.Version())
+	}
+
+	if _, err := NewAggregateRoot(""); !errors.Is(err, ErrEmptyEntityID) {
+		t.Errorf("NewAggregateRoot(\"\") error = %v, want %v", err, ErrEmptyEntityID)
+	}
+}
+
+func TestAggregateRoot_IncrementVersion(t *testing.T) {
+	ts := time.Now()
+	a := RestoreAggregateRoot("order-1", ts, ts, 3)
+
+	a.IncrementVersion()
+
+	if a.Version() != 4 {
+		t.Errorf("Version() = %v, want 4", a.Version())
+	}
+}
+
+func TestAggregateRoot_CheckVersion(t *testing.T) {
+	ts := time.Now()
+	a := RestoreAggregateRoot("order-1", ts, ts, 3)
+
+	if err := a.CheckVersion(3); err != nil {
+		t.Errorf("CheckVersion(3) error = %v, want nil", err)
+	}
+	if err := a.CheckVersion(2); !errors.Is(err, ErrVersionMismatch) {
+		t.Errorf("CheckVersion(2) error = %v, want %v", err, ErrVersionMismatch)
+	}
+}
@@ -0,0 +1,73 @@
+package domain
+
+import (
+	"time"
+)
+
+// ============================================================================
+// Domain Event (領域事件)
+// ============================================================================
+
+// DomainEvent is something that happened in the domain that other parts of the
+// system may react to. Event names should be past tense, e.g. "order.confirmed".
+type DomainEvent interface {
+	Name() string
+	OccurredAt() time.Time
+	AggregateID() string
+}
+
+// BaseEvent provides the common DomainEvent fields.
+// Embed it in concrete events and add the event-specific payload.
+type BaseEvent struct {
+	name        string
+	aggregateID string
+	occurredAt  time.Time
+}
+
+// NewBaseEvent creates a BaseEvent occurring now
+func NewBaseEvent(name, aggregateID string) BaseEvent {
+	return BaseEvent{
+		name:        name,
+		aggregateID: aggregateID,
+		occurredAt:  time.Now().UTC(),
+	}
+}
+
+// Getters
+func (e BaseEvent) Name() string          { return e.name }
+func (e BaseEvent) AggregateID() string   { return e.aggregateID }
+func (e BaseEvent) OccurredAt() time.Time { return e.occurredAt }
+
+// ============================================================================
+// Event Recorder (聚合內收集事件)
+// ============================================================================
+
+// EventRecorder collects domain events raised by an aggregate until they are
+// pulled for dispatching after the aggregate has been persisted.
+type EventRecorder struct {
+	events []DomainEvent
+}
+
+// Record appends an event raised by the aggregate.
+func (r *EventRecorder) Record(event DomainEvent) {
+	r.events = append(r.events, event)
+}
+
+// Events returns the recorded events without clearing them.
+func (r *EventRecorder) Events() []DomainEvent {
+	events := make([]DomainEvent, len(r.events))
+	copy(events, r.events)
+	return events
+}
+
+// PullEvents returns the recorded events and clears the recorder.
+func (r *EventRecorder) PullEvents() []DomainEvent {
+	events := r.events
+	r.events = nil
+	return events
+}
+
+// ClearEvents discards the recorded events.
+func (r *EventRecorder) ClearEvents() {
+	r.events = nil
+}
@@ -0,0 +1,59 @@
+package domain
+
+import (
+	"testing"
+)
+
+type orderConfirmed struct {
+	BaseEvent
+}
+
+func TestNewBaseEvent(t *testing.T) {
+	e := NewBaseEvent("order.confirmed", "order-1")
+
+	if e.Name() != "order.confirmed" {
+		t.Errorf("Name() = %v, want order.confirmed", e.Name())
+	}
+	if e.AggregateID() != "order-1" {
+		t.Errorf("AggregateID() = %v, want order-1", e.AggregateID())
+	}
+	if e.OccurredAt().IsZero() {
+		t.Error("OccurredAt() is zero")
+	}
+}
+
+func TestEventRecorder(t *testing.T) {
+	// Arrange
+	a, err := NewAggregateRoot("order-1")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	// Act
+	a.Record(orderConfirmed{NewBaseEvent("order.confirmed", "order-1")})
+	a.Record(orderConfirmed{NewBaseEvent("order.shipped", "order-1")})
+
+	// Assert
+	if got := len(a.Events()); got != 2 {
+		t.Fatalf("len(Events()) = %d, want 2", got)
+	}
+
+	pulled := a.PullEvents()
+	if len(pulled) != 2 || pulled[0].Name() != "order.confirmed" || pulled[1].Name() != "order.shipped" {
+		t.Errorf("PullEvents() = %v", pulled)
+	}
+	if got := len(a.Events()); got != 0 {
+		t.Errorf("len(Events()) after pull = %d, want 0", got)
+	}
+}
+
+func TestEventRecorder_ClearEvents(t *testing.T) {
+	var r EventRecorder
+	r.Record(NewBaseEvent("order.confirmed", "order-1"))
+
+	r.ClearEvents()
+
+	if got := len(r.PullEvents()); got != 0 {
+		t.Errorf("len(PullEvents()) = %d, want 0", got)
+	}
+}
@@ -0,0 +1,129 @@
+package domain
+
+import (
+	"errors"
+	"fmt"
+)
+
+// ============================================================================
+// State Machine (聚合狀態轉換)
+// ============================================================================
+
+// ErrInvalidTransition is returned when a transition is not allowed.
+var ErrInvalidTransition = errors.New("invalid state transition")
+
+// TransitionEvent builds the domain event raised by a transition.
+// It may return nil to raise nothing.
+type TransitionEvent[S comparable, T any] func(subject T, from, to S) DomainEvent
+
+// transition is an allowed edge of the state machine.
+type transition[S comparable, T any] struct {
+	guards []func(subject T) error
+	event  TransitionEvent[S, T]
+}
+
+// StateMachine defines the allowed status transitions of an aggregate, with
+// optional guards and events. Define it once per aggregate type and fire
+// transitions from behavior methods:
+//
+//	var orderLifecycle = domain.NewStateMachine[Status, *Order]().
+//		Permit(StatusPending, StatusPaid, StatusCancelled).
+//		Permit(StatusPaid, StatusShipped).
+//		Guard(StatusPending, StatusPaid, func(o *Order) error { ... }).
+//		OnTransition(StatusPaid, StatusShipped, func(o *Order, from, to Status) domain.DomainEvent {
+//			return domain.NewBaseEvent("order.shipped", o.ID())
+//		})
+//
+//	func (o *Order) Ship() error {
+//		if err := orderLifecycle.Fire(o, o.status, StatusShipped); err != nil {
+//			return err
+//		}
+//		o.status = StatusShipped
+//		return nil
+//	}
+//
+// A StateMachine must be fully defined before it is used concurrently.
+type StateMachine[S comparable, T any] struct {
+	transitions map[S]map[S]*transition[S, T]
+}
+
+// NewStateMachine creates an empty state machine.
+func NewStateMachine[S comparable, T any]() *StateMachine[S, T] {
+	return &StateMachine[S, T]{transitions: make(map[S]map[S]*transition[S, T])}
+}
+
+// Permit allows transitions from one state to each of the given states.
+func (m *StateMachine[S, T]) Permit(from S, to ...S) *StateMachine[S, T] {
+	for _, t := range to {
+		m.edge(from, t)
+	}
+	return m
+}
+
+// Guard adds a guard to the from→to transition, permitting it if needed.
+// Guards run in order and the first error aborts the transition.
+func (m *StateMachine[S, T]) Guard(from, to S, guard func(subject T) error) *StateMachine[S, T] {
+	e := m.edge(from, to)
+	e.guards = append(e.guards, guard)
+	return m
+}
+
+// OnTransition sets the event raised by the from→to transition, permitting it if needed.
+func (m *StateMachine[S, T]) OnTransition(from, to S, event TransitionEvent[S, T]) *StateMachine[S, T] {
+	m.edge(from, to).event = event
+	return m
+}
+
+// CanTransition reports whether from→to is permitted, ignoring guards.
+func (m *StateMachine[S, T]) CanTransition(from, to S) bool {
+	_, ok := m.transitions[from][to]
+	return ok
+}
+
+// Next returns the states reachable from the given state, in no particular order.
+func (m *StateMachine[S, T]) Next(from S) []S {
+	states := make([]S, 0, len(m.transitions[from]))
+	for to := range m.transitions[from] {
+		states = append(states, to)
+	}
+	return states
+}
+
+// Fire validates the from→to transition for subject and runs its guards.
+// On success, the transition event (if any) is recorded on subject when it
+// has a Record(DomainEvent) method, as aggregates embedding AggregateRoot do.
+// The caller updates the state afterwards.
+func (m *StateMachine[S, T]) Fire(subject T, from, to S) error {
+	e, ok := m.transitions[from][to]
+	if !ok {
+		return fmt.Errorf("%w: %v -> %v", ErrInvalidTransition, from, to)
+	}
+
+	for _, guard := range e.guards {
+		if err := guard(subject); err != nil {
+			return err
+		}
+	}
+
+	if e.event != nil {
+		if event := e.event(subject, from, to); event != nil {
+			if r, ok := any(subject).(interface{ Record(DomainEvent) }); ok {
+				r.Record(event)
+			}
+		}
+	}
+	return nil
+}
+
+// edge returns the from→to transition, creating it if needed.
+func (m *StateMachine[S, T]) edge(from, to S) *transition[S, T] {
+	if m.transitions[from] == nil {
+		m.transitions[from] = make(map[S]*transition[S, T])
+	}
+	e, ok := m.transitions[from][to]
+	if !ok {
+		e = &transition[S, T]{}
+		m.transitions[from][to] = e
+	}
+	return e
+}
@@ -0,0 +1,91 @@
+package domain
+
+import (
+	"errors"
+	"slices"
+	"testing"
+)
+
+type orderStatus string
+
+const (
+	statusPending   orderStatus = "pending"
+	statusPaid      orderStatus = "paid"
+	statusShipped   orderStatus = "shipped"
+	statusCancelled orderStatus = "cancelled"
+)
+
+type fsmOrder struct {
+	AggregateRoot[string]
+	status orderStatus
+	paid   bool
+}
+
+var errNotPaid = errors.New("payment not received")
+
+func newOrderLifecycle() *StateMachine[orderStatus, *fsmOrder] {
+	return NewStateMachine[orderStatus, *fsmOrder]().
+		Permit(statusPending, statusPaid, statusCancelled).
+		Guard(statusPending, statusPaid, func(o *fsmOrder) error {
+			if !o.paid {
+				return errNotPaid
+			}
+			return nil
+		}).
+		OnTransition(statusPaid, statusShipped, func(o *fsmOrder, _, _ orderStatus) DomainEvent {
+			return NewBaseEvent("order.shipped", o.ID())
+		})
+}
+
+func TestStateMachine_Fire(t *testing.T) {
+	tests := []struct {
+		name       string
+		from, to   orderStatus
+		paid       bool
+		wantErr    error
+		wantEvents []string
+	}{
+		{"permitted", statusPending, statusCancelled, false, nil, nil},
+		{"guard passes", statusPending, statusPaid, true, nil, nil},
+		{"guard fails", statusPending, statusPaid, false, errNotPaid, nil},
+		{"not permitted", statusShipped, statusPending, false, ErrInvalidTransition, nil},
+		{"raises event", statusPaid, statusShipped, false, nil, []string{"order.shipped"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Arrange
+			root, _ := NewAggregateRoot("order-1")
+			o := &fsmOrder{AggregateRoot: root, status: tt.from, paid: tt.paid}
+
+			// Act
+			err := newOrderLifecycle().Fire(o, tt.from, tt.to)
+
+			// Assert
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("Fire() error = %v, want %v", err, tt.wantErr)
+			}
+			var names []string
+			for _, e := range o.Events() {
+				names = append(names, e.Name())
+			}
+			if !slices.Equal(names, tt.wantEvents) {
+				t.Errorf("events = %v, want %v", names, tt.wantEvents)
+			}
+		})
+	}
+}
+
+func TestStateMachine_CanTransition(t *testing.T) {
+	m := newOrderLifecycle()
+
+	if !m.CanTransition(statusPending, statusPaid) || m.CanTransition(statusCancelled, statusPaid) {
+		t.Error("CanTransition() returned an unexpected result")
+	}
+
+	next := m.Next(statusPending)
+	slices.Sort(next)
+	if !slices.Equal(next, []orderStatus{statusCancelled, statusPaid}) {
+		t.Errorf("Next(pending) = %v", next)
+	}
+}
@@ -0,0 +1,12 @@
+package domain
+
+// ============================================================================
+// ID Generation (識別碼產生)
+// ============================================================================
+
+// IDGenerator produces unique identifiers for new entities.
+// Implementations should return lexicographically sortable IDs (e.g. ULID or
+// UUIDv7) so cursor pagination over IDs follows creation order.
+type IDGenerator interface {
+	NewID() string
+}
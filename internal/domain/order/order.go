@@ -0,0 +1,99 @@
+// Package order is the Order aggregate. It is the reference aggregate of the
+// template: persistence adapters and use cases use it as their worked example.
+package order
+
+import (
+	"errors"
+
+	"github.com/blackhorseya/go-ddd/internal/domain"
+	"github.com/blackhorseya/go-ddd/internal/domain/valueobject"
+)
+
+// Order errors
+var (
+	ErrEmptyCustomerID = errors.New("order customer id must not be empty")
+	ErrInvalidTotal    = errors.New("order total must be positive")
+	ErrCannotConfirm   = errors.New("only pending orders can be confirmed")
+	ErrCannotCancel    = errors.New("confirmed or cancelled orders cannot be cancelled")
+)
+
+// ============================================================================
+// Status (訂單狀態)
+// ============================================================================
+
+// Status is the order lifecycle state.
+type Status string
+
+const (
+	StatusPending   Status = "pending"
+	StatusConfirmed Status = "confirmed"
+	StatusCancelled Status = "cancelled"
+)
+
+// ============================================================================
+// Order (聚合根)
+// ============================================================================
+
+// Order is the aggregate root for a customer order.
+type Order struct {
+	domain.AggregateRoot[string]
+	customerID string
+	status     Status
+	total      valueobject.Money
+}
+
+// NewOrder creates a pending order.
+func NewOrder(id, customerID string, total valueobject.Money) (*Order, error) {
+	root, err := domain.NewAggregateRoot(id)
+	if err != nil {
+		return nil, err
+	}
+	if customerID == "" {
+		return nil, ErrEmptyCustomerID
+	}
+	if !total.IsPositive() {
+		return nil, ErrInvalidTotal
+	}
+	return &Order{
+		AggregateRoot: root,
+		customerID:    customerID,
+		status:        StatusPending,
+		total:         total,
+	}, nil
+}
+
+// Restore reconstitutes an order from persisted state without validation.
+// Repositories build root with domain.RestoreAggregateRoot.
+func Restore(root domain.AggregateRoot[string], customerID string, status Status, total valueobject.Money) *Order {
+	return &Order{
+		AggregateRoot: root,
+		customerID:    customerID,
+		status:        status,
+		total:         total,
+	}
+}
+
+// Getters
+func (o *Order) CustomerID() string       { return o.customerID }
+func (o *Order) Status() Status           { return o.status }
+func (o *Order) Total() valueobject.Money { return o.total }
+
+// Confirm moves a pending order to confirmed.
+func (o *Order) Confirm() error {
+	if o.status != StatusPending {
+		return ErrCannotConfirm
+	}
+	o.status = StatusConfirmed
+	o.Touch()
+	return nil
+}
+
+// Cancel moves a pending order to cancelled.
+func (o *Order) Cancel() error {
+	if o.status != StatusPending {
+		return ErrCannotCancel
+	}
+	o.status = StatusCancelled
+	o.Touch()
+	return nil
+}
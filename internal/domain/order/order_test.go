@@ -0,0 +1,94 @@
+package order
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/blackhorseya/go-ddd/internal/domain"
+	"github.com/blackhorseya/go-ddd/internal/domain/valueobject"
+)
+
+func mustMoney(t *testing.T, amount int64) valueobject.Money {
+	t.Helper()
+	m, err := valueobject.NewMoney(amount, "USD")
+	if err != nil {
+		t.Fatalf("NewMoney() error = %v", err)
+	}
+	return m
+}
+
+func TestNewOrder(t *testing.T) {
+	tests := []struct {
+		name       string
+		id         string
+		customerID string
+		amount     int64
+		wantErr    error
+	}{
+		{name: "valid", id: "o1", customerID: "c1", amount: 100},
+		{name: "empty id", id: "", customerID: "c1", amount: 100, wantErr: domain.ErrEmptyEntityID},
+		{name: "empty customer", id: "o1", customerID: "", amount: 100, wantErr: ErrEmptyCustomerID},
+		{name: "zero total", id: "o1", customerID: "c1", amount: 0, wantErr: ErrInvalidTotal},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Act
+			o, err := NewOrder(tt.id, tt.customerID, mustMoney(t, tt.amount))
+
+			// Assert
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("NewOrder() error = %v, want %v", err, tt.wantErr)
+			}
+			if err == nil && o.Status() != StatusPending {
+				t.Errorf("Status() = %v, want %v", o.Status(), StatusPending)
+			}
+		})
+	}
+}
+
+func TestOrder_Transitions(t *testing.T) {
+	tests := []struct {
+		name    string
+		from    Status
+		act     func(*Order) error
+		want    Status
+		wantErr error
+	}{
+		{name: "confirm pending", from: StatusPending, act: (*Order).Confirm, want: StatusConfirmed},
+		{name: "confirm cancelled", from: StatusCancelled, act: (*Order).Confirm, want: StatusCancelled, wantErr: ErrCannotConfirm},
+		{name: "cancel pending", from: StatusPending, act: (*Order).Cancel, want: StatusCancelled},
+		{name: "cancel confirmed", from: StatusConfirmed, act: (*Order).Cancel, want: StatusConfirmed, wantErr: ErrCannotCancel},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Arrange
+			o := Restore(domain.AggregateRoot[string]{}, "c1", tt.from, mustMoney(t, 100))
+
+			// Act
+			err := tt.act(o)
+
+			// Assert
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("error = %v, want %v", err, tt.wantErr)
+			}
+			if o.Status() != tt.want {
+				t.Errorf("Status() = %v, want %v", o.Status(), tt.want)
+			}
+		})
+	}
+}
+
+func TestSpecs(t *testing.T) {
+	// Arrange
+	o := Restore(domain.AggregateRoot[string]{}, "c1", StatusPending, mustMoney(t, 100))
+
+	// Act & Assert
+	if !(StatusSpec{Status: StatusPending}).IsSatisfiedBy(o) {
+		t.Error("StatusSpec{pending} should match a pending order")
+	}
+	if (CustomerSpec{CustomerID: "c2"}).IsSatisfiedBy(o) {
+		t.Error("CustomerSpec{c2} should not match an order of c1")
+	}
+}
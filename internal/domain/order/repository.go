@@ -0,0 +1,39 @@
+package order
+
+import (
+	"context"
+
+	"github.com/blackhorseya/go-ddd/internal/domain"
+)
+
+// ============================================================================
+// Repository (持久化 Port)
+// ============================================================================
+
+// Repository persists orders.
+type Repository interface {
+	domain.Repository[*Order, string]
+
+	// CountByCustomer returns the number of live orders of a customer.
+	CountByCustomer(ctx context.Context, customerID string) (int64, error)
+}
+
+// ============================================================================
+// Specifications (查詢規格)
+// ============================================================================
+
+// StatusSpec selects orders in a status.
+type StatusSpec struct {
+	Status Status
+}
+
+// IsSatisfiedBy reports whether the order has the status.
+func (s StatusSpec) IsSatisfiedBy(o *Order) bool { return o.status == s.Status }
+
+// CustomerSpec selects the orders of a customer.
+type CustomerSpec struct {
+	CustomerID string
+}
+
+// IsSatisfiedBy reports whether the order belongs to the customer.
+func (s CustomerSpec) IsSatisfiedBy(o *Order) bool { return o.customerID == s.CustomerID }
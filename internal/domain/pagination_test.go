@@ -1,7 +1,11 @@
 package domain
 
 import (
+	"errors"
+	"strconv"
+	"strings"
 	"testing"
+	"time"
 )
 
 // ============================================================================
@@ -213,6 +217,45 @@ func TestPageRequest_WithSort(t *testing.T) {
 	}
 }
 
+func TestPageRequest_WithAllowedSortFields(t *testing.T) {
+	tests := []struct {
+		name    string
+		sort    []SortOption
+		wantErr error
+	}{
+		{
+			name: "allowed fields",
+			sort: []SortOption{NewSortOption("created_at", SortDesc), NewSortOption("id", SortAsc)},
+		},
+		{
+			name: "no sort options",
+		},
+		{
+			name:    "unknown field",
+			sort:    []SortOption{NewSortOption("id", SortAsc), NewSortOption("id; DROP TABLE orders", SortAsc)},
+			wantErr: ErrInvalidSortField,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Arrange
+			req := NewPageRequestWithDefaults().WithSort(tt.sort...)
+
+			// Act
+			got, err := req.WithAllowedSortFields("created_at", "id")
+
+			// Assert
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("WithAllowedSortFields() error = %v, want %v", err, tt.wantErr)
+			}
+			if err == nil && len(got.Sort()) != len(tt.sort) {
+				t.Errorf("Sort() length = %v, want %v", len(got.Sort()), len(tt.sort))
+			}
+		})
+	}
+}
+
 // ============================================================================
 // PageResult Tests
 // ============================================================================
@@ -348,6 +391,97 @@ func TestEmptyPageResult(t *testing.T) {
 	}
 }
 
+func TestNewPageResultWithoutTotal(t *testing.T) {
+	tests := []struct {
+		name      string
+		items     []int
+		page      int
+		wantItems int
+		wantNext  bool
+		wantPrev  bool
+	}{
+		{"extra row means next page", []int{1, 2, 3, 4}, 1, 3, true, false},
+		{"exact page size is last page", []int{1, 2, 3}, 2, 3, false, true},
+		{"partial page", []int{1}, 3, 1, false, true},
+		{"empty", nil, 1, 0, false, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Act
+			r := NewPageResultWithoutTotal(tt.items, tt.page, 3)
+
+			// Assert
+			if len(r.Items()) != tt.wantItems {
+				t.Errorf("len(Items()) = %v, want %v", len(r.Items()), tt.wantItems)
+			}
+			if r.HasNext() != tt.wantNext {
+				t.Errorf("HasNext() = %v, want %v", r.HasNext(), tt.wantNext)
+			}
+			if r.HasPrev() != tt.wantPrev {
+				t.Errorf("HasPrev() = %v, want %v", r.HasPrev(), tt.wantPrev)
+			}
+			if r.HasTotal() {
+				t.Error("HasTotal() = true, want false")
+			}
+		})
+	}
+}
+
+func TestPageRequest_FetchLimit(t *testing.T) {
+	req, _ := NewPageRequest(1, 20)
+
+	if got := req.FetchLimit(); got != 21 {
+		t.Errorf("FetchLimit() = %v, want 21", got)
+	}
+}
+
+func TestMapPageResult(t *testing.T) {
+	// Arrange
+	r := NewPageResult([]int{1, 2, 3}, 2, 3, 10)
+
+	// Act
+	got := MapPageResult(r, func(n int) string { return strings.Repeat("x", n) })
+
+	// Assert
+	if len(got.Items()) != 3 || got.Items()[2] != "xxx" {
+		t.Errorf("Items() = %v, want [x xx xxx]", got.Items())
+	}
+	if got.Page() != 2 || got.PageSize() != 3 || got.TotalItems() != 10 || got.TotalPages() != 4 {
+		t.Errorf("metadata = (%d, %d, %d, %d), want (2, 3, 10, 4)",
+			got.Page(), got.PageSize(), got.TotalItems(), got.TotalPages())
+	}
+
+	if empty := MapPageResult(EmptyPageResult[int](), strconv.Itoa); !empty.IsEmpty() {
+		t.Errorf("mapped empty result IsEmpty() = false")
+	}
+}
+
+func TestTryMapPageResult(t *testing.T) {
+	// Arrange
+	r := NewPageResult([]string{"1", "2"}, 1, 2, 5)
+
+	// Act
+	got, err := TryMapPageResult(r, strconv.Atoi)
+
+	// Assert
+	if err != nil {
+		t.Fatalf("TryMapPageResult() error = %v", err)
+	}
+	if len(got.Items()) != 2 || got.Items()[1] != 2 || got.TotalItems() != 5 || got.TotalPages() != 3 {
+		t.Errorf("TryMapPageResult() = %v (total %d/%d), want [1 2] (5/3)",
+			got.Items(), got.TotalItems(), got.TotalPages())
+	}
+
+	// Act
+	_, err = TryMapPageResult(NewPageResult([]string{"x"}, 1, 1, 1), strconv.Atoi)
+
+	// Assert
+	if err == nil {
+		t.Error("TryMapPageResult() error = nil, want parse error")
+	}
+}
+
 // ============================================================================
 // CursorRequest Tests
 // ============================================================================
@@ -454,6 +588,17 @@ func TestCursorRequest_WithSort(t *testing.T) {
 // CursorResult Tests
 // ============================================================================
 
+func TestCursorRequest_WithAllowedSortFields(t *testing.T) {
+	req := NewCursorRequestWithDefaults().WithSort(NewSortOption("name", SortAsc))
+
+	if _, err := req.WithAllowedSortFields("created_at"); !errors.Is(err, ErrInvalidSortField) {
+		t.Errorf("WithAllowedSortFields() error = %v, want %v", err, ErrInvalidSortField)
+	}
+	if _, err := req.WithAllowedSortFields("name"); err != nil {
+		t.Errorf("WithAllowedSortFields() unexpected error: %v", err)
+	}
+}
+
 func TestNewCursorResult(t *testing.T) {
 	// Arrange
 	items := []string{"a", "b", "c"}
@@ -492,6 +637,32 @@ func TestCursorResult_IsEmpty(t *testing.T) {
 	})
 }
 
+func TestMapCursorResult(t *testing.T) {
+	r := NewCursorResult([]int{1, 2}, "next", "prev", true)
+
+	got := MapCursorResult(r, strconv.Itoa)
+
+	if len(got.Items()) != 2 || got.Items()[0] != "1" || got.Items()[1] != "2" {
+		t.Errorf("Items() = %v, want [1 2]", got.Items())
+	}
+	if got.NextCursor() != "next" || got.PrevCursor() != "prev" || !got.HasMore() {
+		t.Errorf("metadata = (%q, %q, %v), want (next, prev, true)", got.NextCursor(), got.PrevCursor(), got.HasMore())
+	}
+}
+
+func TestTryMapCursorResult(t *testing.T) {
+	r := NewCursorResult([]string{"1", "x"}, "next", "", true)
+
+	if _, err := TryMapCursorResult(r, strconv.Atoi); err == nil {
+		t.Error("TryMapCursorResult() error = nil, want parse error")
+	}
+
+	got, err := TryMapCursorResult(NewCursorResult([]string{"7"}, "next", "", true), strconv.Atoi)
+	if err != nil || len(got.Items()) != 1 || got.Items()[0] != 7 || got.NextCursor() != "next" || !got.HasMore() {
+		t.Errorf("TryMapCursorResult() = %v, %v, want [7] with cursor metadata", got.Items(), err)
+	}
+}
+
 func TestEmptyCursorResult(t *testing.T) {
 	result := EmptyCursorResult[int]()
 
@@ -665,3 +836,336 @@ func TestCursorRoundTrip(t *testing.T) {
 		})
 	}
 }
+
+// ============================================================================
+// Signed Cursor Tests
+// ============================================================================
+
+func TestSignedCursorRoundTrip(t *testing.T) {
+	// Arrange
+	key := []byte("secret")
+
+	// Act
+	encoded, err := EncodeSignedCursor(key, "2024-01-15T10:30:00Z", "uuid-456")
+	if err != nil {
+		t.Fatalf("EncodeSignedCursor() error = %v", err)
+	}
+	decoded, err := DecodeSignedCursor(encoded, key)
+
+	// Assert
+	if err != nil {
+		t.Fatalf("DecodeSignedCursor() error = %v", err)
+	}
+	if len(decoded) != 2 || decoded[0] != "2024-01-15T10:30:00Z" || decoded[1] != "uuid-456" {
+		t.Errorf("DecodeSignedCursor() = %v", decoded)
+	}
+}
+
+func TestDecodeSignedCursor(t *testing.T) {
+	oldKey, newKey := []byte("old"), []byte("new")
+	signedOld, _ := EncodeSignedCursor(oldKey, "id-1")
+	signedNew, _ := EncodeSignedCursor(newKey, "id-1")
+	forged, _ := EncodeSignedCursor(newKey, "id-2")
+	payload, _, _ := strings.Cut(signedNew, ".")
+	_, forgedSig, _ := strings.Cut(forged, ".")
+
+	tests := []struct {
+		name    string
+		cursor  string
+		keys    [][]byte
+		want    []string
+		wantErr error
+	}{
+		{"empty cursor", "", [][]byte{newKey}, nil, nil},
+		{"current key", signedNew, [][]byte{newKey, oldKey}, []string{"id-1"}, nil},
+		{"rotated key", signedOld, [][]byte{newKey, oldKey}, []string{"id-1"}, nil},
+		{"retired key", signedOld, [][]byte{newKey}, nil, ErrInvalidCursor},
+		{"tampered payload", EncodeCursor("id-2") + "." + strings.SplitN(signedNew, ".", 2)[1], [][]byte{newKey}, nil, ErrInvalidCursor},
+		{"swapped signature", payload + "." + forgedSig, [][]byte{newKey}, nil, ErrInvalidCursor},
+		{"unsigned cursor", EncodeCursor("id-1"), [][]byte{newKey}, nil, ErrInvalidCursor},
+		{"no keys", signedNew, nil, nil, ErrEmptyCursorKey},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := DecodeSignedCursor(tt.cursor, tt.keys...)
+
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("DecodeSignedCursor() error = %v, want %v", err, tt.wantErr)
+			}
+			if len(got) != len(tt.want) || (len(got) > 0 && got[0] != tt.want[0]) {
+				t.Errorf("DecodeSignedCursor() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestEncodeSignedCursor_EmptyKey(t *testing.T) {
+	if _, err := EncodeSignedCursor(nil, "id-1"); !errors.Is(err, ErrEmptyCursorKey) {
+		t.Errorf("EncodeSignedCursor() error = %v, want %v", err, ErrEmptyCursorKey)
+	}
+}
+
+// ============================================================================
+// Keyset Cursor Tests
+// ============================================================================
+
+func TestKeysetCursorRoundTrip(t *testing.T) {
+	// Arrange
+	ts := time.Date(2024, 1, 15, 18, 30, 0, 123456789, time.FixedZone("UTC+8", 8*3600))
+	k, err := NewKeysetCursor(ts, 42, "a:b")
+	if err != nil {
+		t.Fatalf("NewKeysetCursor() error = %v", err)
+	}
+
+	// Act
+	decoded, err := DecodeKeysetCursor(k.Encode())
+
+	// Assert
+	if err != nil {
+		t.Fatalf("DecodeKeysetCursor() error = %v", err)
+	}
+	values := decoded.Values()
+	if len(values) != 3 {
+		t.Fatalf("len(Values()) = %d, want 3", len(values))
+	}
+	if got, ok := values[0].(time.Time); !ok || !got.Equal(ts) || got.Location() != time.UTC {
+		t.Errorf("Values()[0] = %v, want %v in UTC", values[0], ts)
+	}
+	if values[1] != int64(42) {
+		t.Errorf("Values()[1] = %#v, want int64(42)", values[1])
+	}
+	if values[2] != "a:b" {
+		t.Errorf("Values()[2] = %v, want a:b", values[2])
+	}
+}
+
+func TestNewKeysetCursor_Invalid(t *testing.T) {
+	if _, err := NewKeysetCursor(); !errors.Is(err, ErrInvalidCursor) {
+		t.Errorf("NewKeysetCursor() error = %v, want %v", err, ErrInvalidCursor)
+	}
+	if _, err := NewKeysetCursor(3.14); !errors.Is(err, ErrInvalidCursor) {
+		t.Errorf("NewKeysetCursor(float) error = %v, want %v", err, ErrInvalidCursor)
+	}
+}
+
+func TestParseKeysetCursor_Invalid(t *testing.T) {
+	tests := []struct {
+		name   string
+		values []string
+	}{
+		{"empty", nil},
+		{"untagged", []string{"abc"}},
+		{"bad int", []string{"i:abc"}},
+		{"bad time", []string{"t:yesterday"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := ParseKeysetCursor(tt.values); !errors.Is(err, ErrInvalidCursor) {
+				t.Errorf("ParseKeysetCursor() error = %v, want %v", err, ErrInvalidCursor)
+			}
+		})
+	}
+}
+
+// ============================================================================
+// Cursor Codec Tests
+// ============================================================================
+
+func TestCursorCodec(t *testing.T) {
+	issued := time.Date(2024, 1, 15, 10, 0, 0, 0, time.UTC)
+	key := []byte("secret")
+
+	encode := func(c CursorCodec) string {
+		c.now = func() time.Time { return issued }
+		cursor, err := c.Encode("2024-01-15T09:00:00Z", "id-1")
+		if err != nil {
+			t.Fatalf("Encode() error = %v", err)
+		}
+		return cursor
+	}
+
+	tests := []struct {
+		name    string
+		encoder CursorCodec
+		decoder CursorCodec
+		elapsed time.Duration
+		wantErr error
+	}{
+		{"same version", NewCursorCodec(1, 0), NewCursorCodec(1, 0), 24 * time.Hour, nil},
+		{"signed", NewCursorCodec(1, 0, key), NewCursorCodec(1, 0, key), 0, nil},
+		{"within ttl", NewCursorCodec(1, time.Hour), NewCursorCodec(1, time.Hour), 30 * time.Minute, nil},
+		{"expired", NewCursorCodec(1, time.Hour), NewCursorCodec(1, time.Hour), 2 * time.Hour, ErrInvalidCursor},
+		{"old version", NewCursorCodec(1, 0), NewCursorCodec(2, 0), 0, ErrInvalidCursor},
+		{"unsigned when keys required", NewCursorCodec(1, 0), NewCursorCodec(1, 0, key), 0, ErrInvalidCursor},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Arrange
+			cursor := encode(tt.encoder)
+			decoder := tt.decoder
+			decoder.now = func() time.Time { return issued.Add(tt.elapsed) }
+
+			// Act
+			values, err := decoder.Decode(cursor)
+
+			// Assert
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("Decode() error = %v, want %v", err, tt.wantErr)
+			}
+			if err == nil && (len(values) != 2 || values[0] != "2024-01-15T09:00:00Z" || values[1] != "id-1") {
+				t.Errorf("Decode() = %v", values)
+			}
+		})
+	}
+}
+
+func TestCursorCodec_UnversionedCursor(t *testing.T) {
+	c := NewCursorCodec(1, 0)
+
+	if _, err := c.Decode(EncodeCursor("id-1")); !errors.Is(err, ErrInvalidCursor) {
+		t.Errorf("Decode() error = %v, want %v", err, ErrInvalidCursor)
+	}
+}
+
+func TestCursorCodec_Zero(t *testing.T) {
+	var c CursorCodec
+
+	cursor, err := c.Encode("id-1")
+	if err != nil || cursor != EncodeCursor("id-1") {
+		t.Errorf("Encode() = %q, %v, want plain cursor", cursor, err)
+	}
+	if values, err := c.Decode(cursor); err != nil || len(values) != 1 || values[0] != "id-1" {
+		t.Errorf("Decode() = %v, %v", values, err)
+	}
+}
+
+func TestCursorCodec_Open(t *testing.T) {
+	// Arrange
+	c := NewCursorCodec(1, time.Hour, []byte("secret"))
+	cursor, _ := c.Encode("2024-01-15T09:00:00Z", "id-1")
+
+	// Act
+	plain, err := c.Open(cursor)
+
+	// Assert
+	if err != nil || plain != EncodeCursor("2024-01-15T09:00:00Z", "id-1") {
+		t.Errorf("Open() = %q, %v, want plain cursor", plain, err)
+	}
+	if plain, err := c.Open(""); err != nil || plain != "" {
+		t.Errorf("Open(\"\") = %q, %v, want empty", plain, err)
+	}
+	if _, err := c.Open(EncodeCursor("id-1")); !errors.Is(err, ErrInvalidCursor) {
+		t.Errorf("Open(unsigned) error = %v, want %v", err, ErrInvalidCursor)
+	}
+}
+
+func TestCursorRequest_NextCursor(t *testing.T) {
+	// Arrange
+	codec := NewCursorCodec(1, 0, []byte("secret"))
+	req := NewCursorRequestWithDefaults().
+		WithCursorCodec(codec).
+		WithSort(NewSortOption("id", SortAsc))
+
+	// Act
+	next, err := req.NextCursor("id-1")
+
+	// Assert
+	if err != nil {
+		t.Fatalf("NextCursor() error = %v", err)
+	}
+	if values, err := codec.Decode(next); err != nil || len(values) != 1 || values[0] != "id-1" {
+		t.Errorf("Decode(NextCursor()) = %v, %v, want [id-1]", values, err)
+	}
+	if plain, _ := NewCursorRequestWithDefaults().NextCursor("id-1"); plain != EncodeCursor("id-1") {
+		t.Errorf("NextCursor() without codec = %q, want plain cursor", plain)
+	}
+}
+
+// ============================================================================
+// Offset ↔ Cursor Bridge Tests
+// ============================================================================
+
+func TestOffsetCursorRoundTrip(t *testing.T) {
+	for _, offset := range []int{0, 20, 1000} {
+		got, err := DecodeOffsetCursor(EncodeOffsetCursor(offset))
+		if err != nil || got != offset {
+			t.Errorf("DecodeOffsetCursor(EncodeOffsetCursor(%d)) = %d, %v", offset, got, err)
+		}
+	}
+
+	if got, err := DecodeOffsetCursor(""); err != nil || got != 0 {
+		t.Errorf("DecodeOffsetCursor(\"\") = %d, %v, want 0, nil", got, err)
+	}
+	if _, err := DecodeOffsetCursor(EncodeCursor("id-1")); !errors.Is(err, ErrInvalidCursor) {
+		t.Errorf("DecodeOffsetCursor(key cursor) error = %v, want %v", err, ErrInvalidCursor)
+	}
+	if IsOffsetCursor(EncodeCursor("id-1")) || !IsOffsetCursor(EncodeOffsetCursor(0)) {
+		t.Error("IsOffsetCursor() misclassified cursor")
+	}
+}
+
+func TestCursorRequestFromPage(t *testing.T) {
+	// Arrange
+	p, _ := NewPageRequest(3, 25)
+	p = p.WithSort(NewSortOption("id", SortDesc))
+
+	// Act
+	c := CursorRequestFromPage(p)
+
+	// Assert
+	offset, err := DecodeOffsetCursor(c.Cursor())
+	if err != nil || offset != 50 {
+		t.Errorf("offset = %d, %v, want 50", offset, err)
+	}
+	if c.PageSize() != 25 || len(c.Sort()) != 1 {
+		t.Errorf("PageSize() = %d, len(Sort()) = %d, want 25, 1", c.PageSize(), len(c.Sort()))
+	}
+}
+
+func TestNewOffsetCursorResult(t *testing.T) {
+	tests := []struct {
+		name       string
+		items      []int
+		offset     int
+		wantItems  int
+		wantMore   bool
+		wantNext   int
+		wantPrev   int
+		wantNoPrev bool
+	}{
+		{"first page with more", []int{1, 2, 3}, 0, 2, true, 2, 0, true},
+		{"middle page", []int{1, 2, 3}, 4, 2, true, 6, 2, false},
+		{"last page", []int{1}, 2, 1, false, 0, 0, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req, _ := NewCursorRequest(EncodeOffsetCursor(tt.offset), 2)
+			r, err := NewOffsetCursorResult(tt.items, req)
+			if err != nil {
+				t.Fatalf("NewOffsetCursorResult() error = %v", err)
+			}
+
+			if len(r.Items()) != tt.wantItems || r.HasMore() != tt.wantMore {
+				t.Errorf("len(Items()) = %d, HasMore() = %v, want %d, %v", len(r.Items()), r.HasMore(), tt.wantItems, tt.wantMore)
+			}
+			if tt.wantMore {
+				if next, _ := DecodeOffsetCursor(r.NextCursor()); next != tt.wantNext {
+					t.Errorf("next offset = %d, want %d", next, tt.wantNext)
+				}
+			} else if r.NextCursor() != "" {
+				t.Errorf("NextCursor() = %q, want empty", r.NextCursor())
+			}
+			if tt.wantNoPrev {
+				if r.PrevCursor() != "" {
+					t.Errorf("PrevCursor() = %q, want empty", r.PrevCursor())
+				}
+			} else if prev, _ := DecodeOffsetCursor(r.PrevCursor()); prev != tt.wantPrev || r.PrevCursor() == "" {
+				t.Errorf("prev offset = %d, want %d", prev, tt.wantPrev)
+			}
+		})
+	}
+}
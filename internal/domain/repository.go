@@ -0,0 +1,45 @@
+package domain
+
+import (
+	"context"
+	"errors"
+)
+
+// ErrNotFound is returned by repositories when no aggregate matches the identifier.
+var ErrNotFound = errors.New("aggregate not found")
+
+// ============================================================================
+// Repository (聚合持久化 Port)
+// ============================================================================
+
+// Repository is the generic persistence port for an aggregate type T
+// identified by ID. Aggregate packages declare their own interface by
+// embedding it and adding query methods specific to the aggregate:
+//
+//	type Repository interface {
+//		domain.Repository[*Order, string]
+//		FindByCustomer(ctx context.Context, customerID string) ([]*Order, error)
+//	}
+//
+// Implementations live in the infrastructure layer and join the
+// UnitOfWork transaction carried by ctx.
+type Repository[T any, ID comparable] interface {
+	// Get returns the aggregate with the given ID, or ErrNotFound.
+	// Soft-deleted aggregates are not returned.
+	Get(ctx context.Context, id ID) (T, error)
+
+	// Save inserts a new aggregate or updates an existing one with optimistic
+	// locking, returning ErrConcurrentModification when the stored version changed.
+	Save(ctx context.Context, aggregate T) error
+
+	// Delete soft-deletes the aggregate, or returns ErrNotFound.
+	Delete(ctx context.Context, id ID) error
+
+	// FindPage returns the aggregates satisfying spec as an offset page.
+	// A nil spec matches every aggregate.
+	FindPage(ctx context.Context, spec Specification[T], page PageRequest) (PageResult[T], error)
+
+	// FindCursor returns the aggregates satisfying spec as a keyset page.
+	// A nil spec matches every aggregate.
+	FindCursor(ctx context.Context, spec Specification[T], req CursorRequest) (CursorResult[T], error)
+}
@@ -0,0 +1,82 @@
+package domain
+
+// ============================================================================
+// Specification (查詢規格)
+// ============================================================================
+
+// Specification is a business rule that a candidate either satisfies or not.
+// Specifications compose with And, Or and Not so query logic stays in the
+// domain; the infrastructure layer translates them into queries.
+type Specification[T any] interface {
+	IsSatisfiedBy(candidate T) bool
+}
+
+// SpecFunc adapts a predicate function to a Specification.
+type SpecFunc[T any] func(candidate T) bool
+
+// IsSatisfiedBy calls f(candidate).
+func (f SpecFunc[T]) IsSatisfiedBy(candidate T) bool { return f(candidate) }
+
+// AndSpecification is satisfied when all of its specifications are.
+type AndSpecification[T any] struct {
+	specs []Specification[T]
+}
+
+// And composes specifications with logical AND.
+func And[T any](specs ...Specification[T]) AndSpecification[T] {
+	return AndSpecification[T]{specs: specs}
+}
+
+// IsSatisfiedBy reports whether every specification is satisfied.
+func (s AndSpecification[T]) IsSatisfiedBy(candidate T) bool {
+	for _, spec := range s.specs {
+		if !spec.IsSatisfiedBy(candidate) {
+			return false
+		}
+	}
+	return true
+}
+
+// Specs returns the composed specifications.
+func (s AndSpecification[T]) Specs() []Specification[T] { return s.specs }
+
+// OrSpecification is satisfied when any of its specifications is.
+type OrSpecification[T any] struct {
+	specs []Specification[T]
+}
+
+// Or composes specifications with logical OR.
+func Or[T any](specs ...Specification[T]) OrSpecification[T] {
+	return OrSpecification[T]{specs: specs}
+}
+
+// IsSatisfiedBy reports whether any specification is satisfied.
+func (s OrSpecification[T]) IsSatisfiedBy(candidate T) bool {
+	for _, spec := range s.specs {
+		if spec.IsSatisfiedBy(candidate) {
+			return true
+		}
+	}
+	return false
+}
+
+// Specs returns the composed specifications.
+func (s OrSpecification[T]) Specs() []Specification[T] { return s.specs }
+
+// NotSpecification negates a specification.
+type NotSpecification[T any] struct {
+	spec Specification[T]
+}
+
+// Not negates a specification.
+func Not[T any](spec Specification[T]) NotSpecification[T] {
+	return NotSpecification[T]{spec: spec}
+}
+
+// IsSatisfiedBy reports whether the wrapped specification is not satisfied.
+func (s NotSpecification[T]) IsSatisfiedBy(candidate T) bool {
+	return !s.spec.IsSatisfiedBy(candidate)
+}
+
+// Spec returns the negated specification.
+func (s NotSpecification[T]) Spec() Specification[T] { return s.spec }
@@ -0,0 +1,34 @@
+package domain
+
+import (
+	"testing"
+)
+
+func TestSpecification_Composition(t *testing.T) {
+	positive := SpecFunc[int](func(n int) bool { return n > 0 })
+	even := SpecFunc[int](func(n int) bool { return n%2 == 0 })
+
+	tests := []struct {
+		name string
+		spec Specification[int]
+		n    int
+		want bool
+	}{
+		{"and satisfied", And[int](positive, even), 4, true},
+		{"and unsatisfied", And[int](positive, even), 3, false},
+		{"empty and", And[int](), 3, true},
+		{"or satisfied", Or[int](positive, even), -2, true},
+		{"or unsatisfied", Or[int](positive, even), -3, false},
+		{"empty or", Or[int](), 3, false},
+		{"not", Not[int](even), 3, true},
+		{"nested", And[int](positive, Not[int](even)), 5, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.spec.IsSatisfiedBy(tt.n); got != tt.want {
+				t.Errorf("IsSatisfiedBy(%d) = %v, want %v", tt.n, got, tt.want)
+			}
+		})
+	}
+}
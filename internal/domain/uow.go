@@ -0,0 +1,16 @@
+package domain
+
+import "context"
+
+// ============================================================================
+// Unit of Work (交易邊界)
+// ============================================================================
+
+// UnitOfWork runs a function atomically. Repositories used with the context
+// passed to fn participate in the same transaction, which is committed when
+// fn returns nil and rolled back otherwise.
+//
+// Implementations live in the infrastructure layer.
+type UnitOfWork interface {
+	Do(ctx context.Context, fn func(ctx context.Context) error) error
+}
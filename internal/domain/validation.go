@@ -0,0 +1,110 @@
+package domain
+
+import (
+	"errors"
+	"strings"
+)
+
+// ============================================================================
+// Notification (收集多個業務規則違反)
+// ============================================================================
+
+// ErrValidation matches any *ValidationError with errors.Is.
+var ErrValidation = errors.New("validation failed")
+
+// Violation describes a single broken business rule.
+type Violation struct {
+	field   string
+	rule    string
+	message string
+}
+
+// Getters
+func (v Violation) Field() string   { return v.field }
+func (v Violation) Rule() string    { return v.rule }
+func (v Violation) Message() string { return v.message }
+
+// Notification collects rule violations so an aggregate can report all of
+// them in one pass instead of failing on the first:
+//
+//	var n domain.Notification
+//	n.Check(name != "", "name", "required", "name is required")
+//	n.Check(qty > 0, "quantity", "positive", "quantity must be positive")
+//	return n.Err()
+type Notification struct {
+	violations []Violation
+}
+
+// Add records a violation.
+func (n *Notification) Add(field, rule, message string) {
+	n.violations = append(n.violations, Violation{field: field, rule: rule, message: message})
+}
+
+// Check records a violation unless ok is true.
+func (n *Notification) Check(ok bool, field, rule, message string) {
+	if !ok {
+		n.Add(field, rule, message)
+	}
+}
+
+// HasErrors reports whether any violation was recorded.
+func (n *Notification) HasErrors() bool { return len(n.violations) > 0 }
+
+// Violations returns a copy of the recorded violations.
+func (n *Notification) Violations() []Violation {
+	return append([]Violation(nil), n.violations...)
+}
+
+// Err returns a *ValidationError with all violations, or nil if there are none.
+func (n *Notification) Err() error {
+	if !n.HasErrors() {
+		return nil
+	}
+	return &ValidationError{violations: n.Violations()}
+}
+
+// ValidationError reports one or more rule violations.
+type ValidationError struct {
+	violations []Violation
+}
+
+// Violations returns the violations.
+func (e *ValidationError) Violations() []Violation { return e.violations }
+
+// Error lists the violations, e.g. "validation failed: name: name is required".
+func (e *ValidationError) Error() string {
+	parts := make([]string, len(e.violations))
+	for i, v := range e.violations {
+		parts[i] = v.field + ": " + v.message
+	}
+	return ErrValidation.Error() + ": " + strings.Join(parts, "; ")
+}
+
+// Is reports whether target is ErrValidation.
+func (e *ValidationError) Is(target error) bool { return target == ErrValidation }
+
+// ============================================================================
+// RuleSet (可重用的驗證規則集合)
+// ============================================================================
+
+// Rule checks one business rule on v and records violations in n.
+type Rule[T any] func(v T, n *Notification)
+
+// RuleSet is an ordered set of rules validated in one pass.
+type RuleSet[T any] struct {
+	rules []Rule[T]
+}
+
+// NewRuleSet creates a rule set.
+func NewRuleSet[T any](rules ...Rule[T]) RuleSet[T] {
+	return RuleSet[T]{rules: rules}
+}
+
+// Validate runs every rule and returns a *ValidationError if any failed.
+func (s RuleSet[T]) Validate(v T) error {
+	var n Notification
+	for _, rule := range s.rules {
+		rule(v, &n)
+	}
+	return n.Err()
+}
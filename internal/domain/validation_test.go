@@ -0,0 +1,84 @@
+package domain
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+)
+
+func TestNotification(t *testing.T) {
+	// Arrange
+	var n Notification
+
+	// Act
+	n.Check(true, "name", "required", "name is required")
+	n.Check(false, "quantity", "positive", "quantity must be positive")
+	n.Add("email", "format", "email is invalid")
+	err := n.Err()
+
+	// Assert
+	if !n.HasErrors() {
+		t.Fatal("HasErrors() = false, want true")
+	}
+
+	var ve *ValidationError
+	if !errors.As(fmt.Errorf("create order: %w", err), &ve) {
+		t.Fatalf("errors.As(*ValidationError) failed for %v", err)
+	}
+	if !errors.Is(err, ErrValidation) {
+		t.Error("errors.Is(err, ErrValidation) = false")
+	}
+
+	got := ve.Violations()
+	if len(got) != 2 || got[0].Field() != "quantity" || got[0].Rule() != "positive" || got[1].Field() != "email" {
+		t.Errorf("Violations() = %v", got)
+	}
+	if want := "validation failed: quantity: quantity must be positive; email: email is invalid"; err.Error() != want {
+		t.Errorf("Error() = %q, want %q", err.Error(), want)
+	}
+}
+
+func TestNotification_NoErrors(t *testing.T) {
+	var n Notification
+
+	if err := n.Err(); err != nil {
+		t.Errorf("Err() = %v, want nil", err)
+	}
+}
+
+func TestRuleSet_Validate(t *testing.T) {
+	type order struct {
+		customer string
+		items    int
+	}
+
+	rules := NewRuleSet(
+		func(o order, n *Notification) {
+			n.Check(o.customer != "", "customer", "required", "customer is required")
+		},
+		func(o order, n *Notification) { n.Check(o.items > 0, "items", "min", "order needs at least one item") },
+	)
+
+	tests := []struct {
+		name  string
+		order order
+		want  int
+	}{
+		{"valid", order{"alice", 1}, 0},
+		{"all rules broken", order{}, 2},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := rules.Validate(tt.order)
+
+			var ve *ValidationError
+			switch {
+			case tt.want == 0 && err != nil:
+				t.Errorf("Validate() error = %v, want nil", err)
+			case tt.want > 0 && (!errors.As(err, &ve) || len(ve.Violations()) != tt.want):
+				t.Errorf("Validate() error = %v, want %d violations", err, tt.want)
+			}
+		})
+	}
+}
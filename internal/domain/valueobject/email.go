@@ -0,0 +1,65 @@
+package valueobject
+
+import (
+	"encoding/json"
+	"net/mail"
+	"strings"
+)
+
+// maxEmailLength is the maximum length of an email address (RFC 5321).
+const maxEmailLength = 254
+
+// Email is a validated email address (Value Object).
+// The domain part is normalized to lower case.
+type Email struct {
+	value string
+}
+
+// NewEmail creates a validated email address.
+func NewEmail(s string) (Email, error) {
+	s = strings.TrimSpace(s)
+	if s == "" || len(s) > maxEmailLength {
+		return Email{}, ErrInvalidEmail
+	}
+
+	addr, err := mail.ParseAddress(s)
+	if err != nil || addr.Name != "" || addr.Address != s {
+		return Email{}, ErrInvalidEmail
+	}
+
+	local, domain, ok := strings.Cut(addr.Address, "@")
+	if !ok || !strings.Contains(domain, ".") {
+		return Email{}, ErrInvalidEmail
+	}
+	return Email{value: local + "@" + strings.ToLower(domain)}, nil
+}
+
+// Getters
+func (e Email) String() string { return e.value }
+func (e Email) IsZero() bool   { return e.value == "" }
+
+// Domain returns the part after "@".
+func (e Email) Domain() string {
+	_, domain, _ := strings.Cut(e.value, "@")
+	return domain
+}
+
+// Equals reports whether two addresses are equal.
+func (e Email) Equals(other Email) bool { return e.value == other.value }
+
+// MarshalJSON encodes the address as a JSON string.
+func (e Email) MarshalJSON() ([]byte, error) { return json.Marshal(e.value) }
+
+// UnmarshalJSON decodes and validates a JSON string.
+func (e *Email) UnmarshalJSON(data []byte) error {
+	var s string
+	if err := json.Unmarshal(data, &s); err != nil {
+		return err
+	}
+	v, err := NewEmail(s)
+	if err != nil {
+		return err
+	}
+	*e = v
+	return nil
+}
@@ -0,0 +1,61 @@
+package valueobject
+
+import (
+	"encoding/json"
+	"errors"
+	"testing"
+)
+
+func TestNewEmail(t *testing.T) {
+	tests := []struct {
+		name    string
+		input   string
+		want    string
+		wantErr error
+	}{
+		{"valid", "alice@example.com", "alice@example.com", nil},
+		{"domain lowercased", "Alice@Example.COM", "Alice@example.com", nil},
+		{"surrounding spaces", "  bob@example.org ", "bob@example.org", nil},
+		{"empty", "", "", ErrInvalidEmail},
+		{"missing at", "alice.example.com", "", ErrInvalidEmail},
+		{"display name", "Alice <alice@example.com>", "", ErrInvalidEmail},
+		{"no tld", "alice@localhost", "", ErrInvalidEmail},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := NewEmail(tt.input)
+
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("NewEmail() error = %v, want %v", err, tt.wantErr)
+			}
+			if got.String() != tt.want {
+				t.Errorf("String() = %q, want %q", got.String(), tt.want)
+			}
+		})
+	}
+}
+
+func TestEmail_JSON(t *testing.T) {
+	// Arrange
+	e, _ := NewEmail("alice@example.com")
+
+	// Act
+	data, err := json.Marshal(e)
+	if err != nil {
+		t.Fatalf("Marshal() error = %v", err)
+	}
+	var decoded Email
+	err = json.Unmarshal(data, &decoded)
+
+	// Assert
+	if err != nil {
+		t.Fatalf("Unmarshal() error = %v", err)
+	}
+	if string(data) != `"alice@example.com"` || !decoded.Equals(e) {
+		t.Errorf("round-trip = %s, %v", data, decoded)
+	}
+	if err := json.Unmarshal([]byte(`"not-an-email"`), &decoded); !errors.Is(err, ErrInvalidEmail) {
+		t.Errorf("Unmarshal(invalid) error = %v, want %v", err, ErrInvalidEmail)
+	}
+}
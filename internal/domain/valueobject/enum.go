@@ -0,0 +1,84 @@
+package valueobject
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+)
+
+// EnumSet is the set of allowed values of a string enum type. Declare one per
+// type and delegate to it instead of hand-rolling switch statements:
+//
+//	type Status string
+//
+//	const (
+//		StatusPending Status = "pending"
+//		StatusPaid    Status = "paid"
+//	)
+//
+//	var statuses = valueobject.NewEnumSet("status", StatusPending, StatusPaid)
+//
+//	func ParseStatus(s string) (Status, error)      { return statuses.Parse(s) }
+//	func (s *Status) UnmarshalText(b []byte) error { return statuses.UnmarshalText(s, b) }
+//	func (s *Status) Scan(src any) error           { return statuses.Scan(s, src) }
+//
+// String-kinded types already marshal to JSON, text and driver values as
+// plain strings; UnmarshalText and Scan add validation on the way in.
+type EnumSet[T ~string] struct {
+	name   string
+	values []T
+}
+
+// NewEnumSet creates an enum set. name is used in error messages.
+func NewEnumSet[T ~string](name string, values ...T) EnumSet[T] {
+	return EnumSet[T]{name: name, values: values}
+}
+
+// Getters
+func (s EnumSet[T]) Name() string { return s.name }
+
+// Values returns the allowed values in declaration order.
+func (s EnumSet[T]) Values() []T { return slices.Clone(s.values) }
+
+// IsValid reports whether v is allowed.
+func (s EnumSet[T]) IsValid(v T) bool { return slices.Contains(s.values, v) }
+
+// Parse returns v as T, or an ErrInvalidEnum error listing the valid values.
+func (s EnumSet[T]) Parse(v string) (T, error) {
+	if !s.IsValid(T(v)) {
+		return "", fmt.Errorf("%w: %s %q (valid: %s)", ErrInvalidEnum, s.name, v, s)
+	}
+	return T(v), nil
+}
+
+// UnmarshalText validates text and stores it in dst.
+// JSON decoding uses it for enum types implementing encoding.TextUnmarshaler.
+func (s EnumSet[T]) UnmarshalText(dst *T, text []byte) error {
+	v, err := s.Parse(string(text))
+	if err != nil {
+		return err
+	}
+	*dst = v
+	return nil
+}
+
+// Scan validates a database value and stores it in dst.
+func (s EnumSet[T]) Scan(dst *T, src any) error {
+	switch v := src.(type) {
+	case string:
+		return s.UnmarshalText(dst, []byte(v))
+	case []byte:
+		return s.UnmarshalText(dst, v)
+	default:
+		return fmt.Errorf("scan %s: unsupported type %T", s.name, src)
+	}
+}
+
+// String lists the valid values, e.g. "pending, paid".
+func (s EnumSet[T]) String() string {
+	parts := make([]string, len(s.values))
+	for i, v := range s.values {
+		parts[i] = string(v)
+	}
+	return strings.Join(parts, ", ")
+}
@@ -0,0 +1,81 @@
+package valueobject
+
+import (
+	"encoding/json"
+	"errors"
+	"testing"
+)
+
+type testStatus string
+
+const (
+	testStatusPending testStatus = "pending"
+	testStatusPaid    testStatus = "paid"
+)
+
+var testStatuses = NewEnumSet("status", testStatusPending, testStatusPaid)
+
+func (s *testStatus) UnmarshalText(b []byte) error { return testStatuses.UnmarshalText(s, b) }
+func (s *testStatus) Scan(src any) error           { return testStatuses.Scan(s, src) }
+
+func TestEnumSet_Parse(t *testing.T) {
+	tests := []struct {
+		name    string
+		input   string
+		want    testStatus
+		wantErr error
+	}{
+		{"valid", "paid", testStatusPaid, nil},
+		{"unknown", "shipped", "", ErrInvalidEnum},
+		{"case sensitive", "PAID", "", ErrInvalidEnum},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := testStatuses.Parse(tt.input)
+
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("Parse() error = %v, want %v", err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("Parse() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestEnumSet_ErrorListsValues(t *testing.T) {
+	_, err := testStatuses.Parse("shipped")
+
+	if want := `invalid enum value: status "shipped" (valid: pending, paid)`; err.Error() != want {
+		t.Errorf("Error() = %q, want %q", err.Error(), want)
+	}
+}
+
+func TestEnumSet_Marshaling(t *testing.T) {
+	// Arrange
+	var payload struct {
+		Status testStatus `json:"status"`
+	}
+
+	// Act & Assert
+	if err := json.Unmarshal([]byte(`{"status":"paid"}`), &payload); err != nil || payload.Status != testStatusPaid {
+		t.Errorf("Unmarshal() = %q, %v", payload.Status, err)
+	}
+	if err := json.Unmarshal([]byte(`{"status":"bogus"}`), &payload); !errors.Is(err, ErrInvalidEnum) {
+		t.Errorf("Unmarshal(invalid) error = %v, want %v", err, ErrInvalidEnum)
+	}
+
+	data, _ := json.Marshal(payload)
+	if string(data) != `{"status":"paid"}` {
+		t.Errorf("Marshal() = %s", data)
+	}
+
+	var scanned testStatus
+	if err := scanned.Scan([]byte("pending")); err != nil || scanned != testStatusPending {
+		t.Errorf("Scan() = %q, %v", scanned, err)
+	}
+	if err := scanned.Scan(42); err == nil {
+		t.Error("Scan(int) error = nil")
+	}
+}
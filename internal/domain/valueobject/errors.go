@@ -0,0 +1,19 @@
+// Package valueobject provides common validated, immutable value objects.
+// Constructors return an error for invalid input, and every value object
+// marshals to and from its canonical string (or number) form in JSON.
+package valueobject
+
+import "errors"
+
+// Value object errors
+var (
+	ErrInvalidEmail      = errors.New("invalid email address")
+	ErrInvalidPhone      = errors.New("invalid phone number")
+	ErrInvalidURL        = errors.New("invalid URL")
+	ErrInvalidPercentage = errors.New("percentage must be between 0 and 100")
+	ErrInvalidCurrency   = errors.New("currency must be an ISO 4217 code")
+	ErrCurrencyMismatch  = errors.New("currency mismatch")
+	ErrInvalidAllocation = errors.New("allocation ratios must be non-negative with a positive sum")
+	ErrMoneyOverflow     = errors.New("money amount overflow")
+	ErrInvalidEnum       = errors.New("invalid enum value")
+)
@@ -0,0 +1,244 @@
+package valueobject
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+)
+
+// ============================================================================
+// Currency
+// ============================================================================
+
+// Currency is an ISO 4217 currency code, e.g. "USD" (Value Object).
+type Currency struct {
+	code string
+}
+
+// zeroDecimalCurrencies have no minor unit; all others are assumed to have two.
+var zeroDecimalCurrencies = map[string]bool{
+	"BIF": true, "CLP": true, "DJF": true, "GNF": true, "ISK": true,
+	"JPY": true, "KMF": true, "KRW": true, "PYG": true, "RWF": true,
+	"UGX": true, "VND": true, "VUV": true, "XAF": true, "XOF": true, "XPF": true,
+}
+
+// NewCurrency creates a currency from a three-letter code (case-insensitive).
+func NewCurrency(code string) (Currency, error) {
+	code = strings.ToUpper(strings.TrimSpace(code))
+	if len(code) != 3 {
+		return Currency{}, ErrInvalidCurrency
+	}
+	for _, r := range code {
+		if r < 'A' || r > 'Z' {
+			return Currency{}, ErrInvalidCurrency
+		}
+	}
+	return Currency{code: code}, nil
+}
+
+// Getters
+func (c Currency) Code() string   { return c.code }
+func (c Currency) String() string { return c.code }
+func (c Currency) IsZero() bool   { return c.code == "" }
+
+// Exponent returns the number of minor unit digits, e.g. 2 for USD and 0 for JPY.
+func (c Currency) Exponent() int {
+	if zeroDecimalCurrencies[c.code] {
+		return 0
+	}
+	return 2
+}
+
+// ============================================================================
+// Money
+// ============================================================================
+
+// Money is an amount in minor units (e.g. cents) of a currency (Value Object).
+// Arithmetic is exact and only allowed between equal currencies.
+type Money struct {
+	amount   int64
+	currency Currency
+}
+
+// NewMoney creates money from an amount in minor units, e.g. NewMoney(1050, "USD") is $10.50.
+func NewMoney(amount int64, currency string) (Money, error) {
+	c, err := NewCurrency(currency)
+	if err != nil {
+		return Money{}, err
+	}
+	return Money{amount: amount, currency: c}, nil
+}
+
+// Zero returns zero money in the given currency.
+func Zero(currency Currency) Money {
+	return Money{currency: currency}
+}
+
+// Getters
+func (m Money) Amount() int64      { return m.amount }
+func (m Money) Currency() Currency { return m.currency }
+func (m Money) IsZero() bool       { return m.amount == 0 }
+func (m Money) IsNegative() bool   { return m.amount < 0 }
+func (m Money) IsPositive() bool   { return m.amount > 0 }
+
+// Equals reports whether both amount and currency are equal.
+func (m Money) Equals(other Money) bool { return m == other }
+
+// Add returns m + other. Returns ErrCurrencyMismatch or ErrMoneyOverflow.
+func (m Money) Add(other Money) (Money, error) {
+	if err := m.sameCurrency(other); err != nil {
+		return Money{}, err
+	}
+	sum := m.amount + other.amount
+	if (other.amount > 0 && sum < m.amount) || (other.amount < 0 && sum > m.amount) {
+		return Money{}, ErrMoneyOverflow
+	}
+	return Money{amount: sum, currency: m.currency}, nil
+}
+
+// Sub returns m - other. Returns ErrCurrencyMismatch or ErrMoneyOverflow.
+func (m Money) Sub(other Money) (Money, error) {
+	if other.amount == math.MinInt64 {
+		return Money{}, ErrMoneyOverflow
+	}
+	return m.Add(Money{amount: -other.amount, currency: other.currency})
+}
+
+// Multiply returns m * factor, e.g. unit price times quantity.
+func (m Money) Multiply(factor int64) (Money, error) {
+	if m.amount == 0 || factor == 0 {
+		return Money{currency: m.currency}, nil
+	}
+	product := m.amount * factor
+	if product/factor != m.amount || (m.amount == -1 && factor == math.MinInt64) || (factor == -1 && m.amount == math.MinInt64) {
+		return Money{}, ErrMoneyOverflow
+	}
+	return Money{amount: product, currency: m.currency}, nil
+}
+
+// Allocate splits m by ratios without losing minor units: the remainder is
+// distributed one unit at a time to the first parts.
+// Example: $1.00 allocated 1:1:1 is [$0.34, $0.33, $0.33].
+func (m Money) Allocate(ratios ...int) ([]Money, error) {
+	var total int64
+	for _, r := range ratios {
+		if r < 0 {
+			return nil, ErrInvalidAllocation
+		}
+		total += int64(r)
+	}
+	if total == 0 {
+		return nil, ErrInvalidAllocation
+	}
+
+	parts := make([]Money, len(ratios))
+	remainder := m.amount
+	for i, r := range ratios {
+		share, err := mulDiv(m.amount, int64(r), total)
+		if err != nil {
+			return nil, err
+		}
+		parts[i] = Money{amount: share, currency: m.currency}
+		remainder -= share
+	}
+
+	step := int64(1)
+	if remainder < 0 {
+		step = -1
+	}
+	for i := 0; remainder != 0; i = (i + 1) % len(parts) {
+		if ratios[i] == 0 {
+			continue
+		}
+		parts[i].amount += step
+		remainder -= step
+	}
+	return parts, nil
+}
+
+// String formats the amount with the currency exponent, e.g. "10.50 USD".
+func (m Money) String() string {
+	exp := m.currency.Exponent()
+	if exp == 0 {
+		return strconv.FormatInt(m.amount, 10) + " " + m.currency.code
+	}
+
+	sign := ""
+	abs := uint64(m.amount)
+	if m.amount < 0 {
+		sign = "-"
+		abs = uint64(-(m.amount + 1)) + 1
+	}
+	unit := uint64(math.Pow10(exp))
+	return fmt.Sprintf("%s%d.%0*d %s", sign, abs/unit, exp, abs%unit, m.currency.code)
+}
+
+// sameCurrency returns ErrCurrencyMismatch unless both currencies are equal.
+func (m Money) sameCurrency(other Money) error {
+	if m.currency != other.currency {
+		return fmt.Errorf("%w: %s and %s", ErrCurrencyMismatch, m.currency, other.currency)
+	}
+	return nil
+}
+
+// mulDiv returns a*b/c truncated toward zero, detecting overflow of a*b.
+func mulDiv(a, b, c int64) (int64, error) {
+	if b == 0 {
+		return 0, nil
+	}
+	product := a * b
+	if product/b != a {
+		return 0, ErrMoneyOverflow
+	}
+	return product / c, nil
+}
+
+// ============================================================================
+// Marshaling
+// ============================================================================
+
+// moneyJSON is the wire format of Money.
+type moneyJSON struct {
+	Amount   int64  `json:"amount"`
+	Currency string `json:"currency"`
+}
+
+// MarshalJSON encodes money as {"amount":1050,"currency":"USD"} with the amount in minor units.
+func (m Money) MarshalJSON() ([]byte, error) {
+	return json.Marshal(moneyJSON{Amount: m.amount, Currency: m.currency.code})
+}
+
+// UnmarshalJSON decodes and validates money.
+func (m *Money) UnmarshalJSON(data []byte) error {
+	var raw moneyJSON
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return err
+	}
+	v, err := NewMoney(raw.Amount, raw.Currency)
+	if err != nil {
+		return err
+	}
+	*m = v
+	return nil
+}
+
+// Value implements driver.Valuer, storing money as JSON (e.g. a jsonb column).
+// Use Amount and Currency to store it in separate columns instead.
+func (m Money) Value() (driver.Value, error) {
+	return m.MarshalJSON()
+}
+
+// Scan implements sql.Scanner for values written by Value.
+func (m *Money) Scan(src any) error {
+	switch v := src.(type) {
+	case []byte:
+		return m.UnmarshalJSON(v)
+	case string:
+		return m.UnmarshalJSON([]byte(v))
+	default:
+		return fmt.Errorf("scan money: unsupported type %T", src)
+	}
+}
@@ -0,0 +1,179 @@
+package valueobject
+
+import (
+	"encoding/json"
+	"errors"
+	"math"
+	"testing"
+)
+
+func mustMoney(t *testing.T, amount int64, currency string) Money {
+	t.Helper()
+	m, err := NewMoney(amount, currency)
+	if err != nil {
+		t.Fatalf("NewMoney() error = %v", err)
+	}
+	return m
+}
+
+func TestNewMoney(t *testing.T) {
+	tests := []struct {
+		name     string
+		currency string
+		want     string
+		wantErr  error
+	}{
+		{"upper case", "USD", "USD", nil},
+		{"lower case normalized", "twd", "TWD", nil},
+		{"too long", "USDT", "", ErrInvalidCurrency},
+		{"digits", "U5D", "", ErrInvalidCurrency},
+		{"empty", "", "", ErrInvalidCurrency},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m, err := NewMoney(100, tt.currency)
+
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("NewMoney() error = %v, want %v", err, tt.wantErr)
+			}
+			if m.Currency().Code() != tt.want {
+				t.Errorf("Currency() = %v, want %v", m.Currency(), tt.want)
+			}
+		})
+	}
+}
+
+func TestMoney_AddSub(t *testing.T) {
+	// Arrange
+	a := mustMoney(t, 1050, "USD")
+	b := mustMoney(t, 250, "USD")
+
+	// Act
+	sum, err := a.Add(b)
+	if err != nil {
+		t.Fatalf("Add() error = %v", err)
+	}
+	diff, err := a.Sub(b)
+	if err != nil {
+		t.Fatalf("Sub() error = %v", err)
+	}
+
+	// Assert
+	if sum.Amount() != 1300 || diff.Amount() != 800 {
+		t.Errorf("Add() = %v, Sub() = %v, want 1300, 800", sum.Amount(), diff.Amount())
+	}
+}
+
+func TestMoney_Errors(t *testing.T) {
+	usd := mustMoney(t, 100, "USD")
+	eur := mustMoney(t, 100, "EUR")
+	huge := mustMoney(t, math.MaxInt64, "USD")
+
+	tests := []struct {
+		name    string
+		op      func() (Money, error)
+		wantErr error
+	}{
+		{"add mismatch", func() (Money, error) { return usd.Add(eur) }, ErrCurrencyMismatch},
+		{"sub mismatch", func() (Money, error) { return usd.Sub(eur) }, ErrCurrencyMismatch},
+		{"add overflow", func() (Money, error) { return huge.Add(usd) }, ErrMoneyOverflow},
+		{"multiply overflow", func() (Money, error) { return huge.Multiply(2) }, ErrMoneyOverflow},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := tt.op(); !errors.Is(err, tt.wantErr) {
+				t.Errorf("error = %v, want %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestMoney_Multiply(t *testing.T) {
+	m := mustMoney(t, 299, "USD")
+
+	got, err := m.Multiply(3)
+
+	if err != nil || got.Amount() != 897 {
+		t.Errorf("Multiply(3) = %v, %v, want 897", got.Amount(), err)
+	}
+}
+
+func TestMoney_Allocate(t *testing.T) {
+	tests := []struct {
+		name    string
+		amount  int64
+		ratios  []int
+		want    []int64
+		wantErr error
+	}{
+		{"even split with remainder", 100, []int{1, 1, 1}, []int64{34, 33, 33}, nil},
+		{"weighted", 1000, []int{70, 30}, []int64{700, 300}, nil},
+		{"zero ratio gets nothing", 5, []int{0, 1, 1}, []int64{0, 3, 2}, nil},
+		{"negative amount", -100, []int{1, 1, 1}, []int64{-34, -33, -33}, nil},
+		{"no ratios", 100, nil, nil, ErrInvalidAllocation},
+		{"negative ratio", 100, []int{1, -1}, nil, ErrInvalidAllocation},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			parts, err := mustMoney(t, tt.amount, "USD").Allocate(tt.ratios...)
+
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("Allocate() error = %v, want %v", err, tt.wantErr)
+			}
+			if len(parts) != len(tt.want) {
+				t.Fatalf("len(Allocate()) = %d, want %d", len(parts), len(tt.want))
+			}
+			for i, p := range parts {
+				if p.Amount() != tt.want[i] {
+					t.Errorf("part[%d] = %d, want %d", i, p.Amount(), tt.want[i])
+				}
+			}
+		})
+	}
+}
+
+func TestMoney_String(t *testing.T) {
+	tests := []struct {
+		amount   int64
+		currency string
+		want     string
+	}{
+		{1050, "USD", "10.50 USD"},
+		{-5, "USD", "-0.05 USD"},
+		{1500, "JPY", "1500 JPY"},
+	}
+
+	for _, tt := range tests {
+		if got := mustMoney(t, tt.amount, tt.currency).String(); got != tt.want {
+			t.Errorf("String() = %q, want %q", got, tt.want)
+		}
+	}
+}
+
+func TestMoney_Marshaling(t *testing.T) {
+	m := mustMoney(t, 1050, "USD")
+
+	data, err := json.Marshal(m)
+	if err != nil {
+		t.Fatalf("Marshal() error = %v", err)
+	}
+	if string(data) != `{"amount":1050,"currency":"USD"}` {
+		t.Errorf("Marshal() = %s", data)
+	}
+
+	value, err := m.Value()
+	if err != nil {
+		t.Fatalf("Value() error = %v", err)
+	}
+	var scanned Money
+	if err := scanned.Scan(value); err != nil || !scanned.Equals(m) {
+		t.Errorf("Scan(Value()) = %v, %v, want %v", scanned, err, m)
+	}
+
+	if err := json.Unmarshal([]byte(`{"amount":1,"currency":"dollars"}`), &scanned); !errors.Is(err, ErrInvalidCurrency) {
+		t.Errorf("Unmarshal(invalid) error = %v, want %v", err, ErrInvalidCurrency)
+	}
+}
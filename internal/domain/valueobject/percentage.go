@@ -0,0 +1,52 @@
+package valueobject
+
+import (
+	"encoding/json"
+	"math"
+	"strconv"
+)
+
+// Percentage is a value between 0 and 100 inclusive (Value Object).
+type Percentage struct {
+	value float64
+}
+
+// NewPercentage creates a validated percentage.
+func NewPercentage(v float64) (Percentage, error) {
+	if math.IsNaN(v) || v < 0 || v > 100 {
+		return Percentage{}, ErrInvalidPercentage
+	}
+	return Percentage{value: v}, nil
+}
+
+// Getters
+func (p Percentage) Value() float64    { return p.value }
+func (p Percentage) Fraction() float64 { return p.value / 100 }
+
+// Of returns the percentage of amount, e.g. 15% of 200 is 30.
+func (p Percentage) Of(amount float64) float64 { return amount * p.Fraction() }
+
+// String formats the percentage, e.g. "12.5%".
+func (p Percentage) String() string {
+	return strconv.FormatFloat(p.value, 'f', -1, 64) + "%"
+}
+
+// Equals reports whether two percentages are equal.
+func (p Percentage) Equals(other Percentage) bool { return p.value == other.value }
+
+// MarshalJSON encodes the percentage as a JSON number.
+func (p Percentage) MarshalJSON() ([]byte, error) { return json.Marshal(p.value) }
+
+// UnmarshalJSON decodes and validates a JSON number.
+func (p *Percentage) UnmarshalJSON(data []byte) error {
+	var v float64
+	if err := json.Unmarshal(data, &v); err != nil {
+		return err
+	}
+	pct, err := NewPercentage(v)
+	if err != nil {
+		return err
+	}
+	*p = pct
+	return nil
+}
@@ -0,0 +1,49 @@
+package valueobject
+
+import (
+	"encoding/json"
+	"errors"
+	"math"
+	"testing"
+)
+
+func TestNewPercentage(t *testing.T) {
+	tests := []struct {
+		name    string
+		input   float64
+		wantErr error
+	}{
+		{"zero", 0, nil},
+		{"fraction", 12.5, nil},
+		{"hundred", 100, nil},
+		{"negative", -1, ErrInvalidPercentage},
+		{"above hundred", 100.1, ErrInvalidPercentage},
+		{"nan", math.NaN(), ErrInvalidPercentage},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := NewPercentage(tt.input)
+
+			if !errors.Is(err, tt.wantErr) {
+				t.Errorf("NewPercentage() error = %v, want %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestPercentage(t *testing.T) {
+	p, _ := NewPercentage(15)
+
+	if got := p.Of(200); got != 30 {
+		t.Errorf("Of(200) = %v, want 30", got)
+	}
+	if got := p.String(); got != "15%" {
+		t.Errorf("String() = %q, want 15%%", got)
+	}
+
+	var decoded Percentage
+	if err := json.Unmarshal([]byte(`150`), &decoded); !errors.Is(err, ErrInvalidPercentage) {
+		t.Errorf("Unmarshal(150) error = %v, want %v", err, ErrInvalidPercentage)
+	}
+}
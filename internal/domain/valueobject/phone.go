@@ -0,0 +1,64 @@
+package valueobject
+
+import (
+	"encoding/json"
+	"strings"
+)
+
+// E.164 allows at most 15 digits; 8 is a practical minimum.
+const (
+	minPhoneDigits = 8
+	maxPhoneDigits = 15
+)
+
+// PhoneNumber is a phone number in E.164 format, e.g. "+886912345678" (Value Object).
+type PhoneNumber struct {
+	value string
+}
+
+// NewPhoneNumber creates a validated phone number. Spaces, dashes, dots and
+// parentheses are stripped; the result must start with "+" and a country code.
+func NewPhoneNumber(s string) (PhoneNumber, error) {
+	normalized := strings.Map(func(r rune) rune {
+		switch r {
+		case ' ', '-', '.', '(', ')':
+			return -1
+		}
+		return r
+	}, strings.TrimSpace(s))
+
+	digits, ok := strings.CutPrefix(normalized, "+")
+	if !ok || len(digits) < minPhoneDigits || len(digits) > maxPhoneDigits || digits[0] == '0' {
+		return PhoneNumber{}, ErrInvalidPhone
+	}
+	for _, r := range digits {
+		if r < '0' || r > '9' {
+			return PhoneNumber{}, ErrInvalidPhone
+		}
+	}
+	return PhoneNumber{value: normalized}, nil
+}
+
+// Getters
+func (p PhoneNumber) String() string { return p.value }
+func (p PhoneNumber) IsZero() bool   { return p.value == "" }
+
+// Equals reports whether two phone numbers are equal.
+func (p PhoneNumber) Equals(other PhoneNumber) bool { return p.value == other.value }
+
+// MarshalJSON encodes the number as a JSON string.
+func (p PhoneNumber) MarshalJSON() ([]byte, error) { return json.Marshal(p.value) }
+
+// UnmarshalJSON decodes and validates a JSON string.
+func (p *PhoneNumber) UnmarshalJSON(data []byte) error {
+	var s string
+	if err := json.Unmarshal(data, &s); err != nil {
+		return err
+	}
+	v, err := NewPhoneNumber(s)
+	if err != nil {
+		return err
+	}
+	*p = v
+	return nil
+}
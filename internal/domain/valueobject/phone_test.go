@@ -0,0 +1,50 @@
+package valueobject
+
+import (
+	"encoding/json"
+	"errors"
+	"testing"
+)
+
+func TestNewPhoneNumber(t *testing.T) {
+	tests := []struct {
+		name    string
+		input   string
+		want    string
+		wantErr error
+	}{
+		{"e164", "+886912345678", "+886912345678", nil},
+		{"formatted", "+1 (415) 555-0100", "+14155550100", nil},
+		{"missing plus", "0912345678", "", ErrInvalidPhone},
+		{"too short", "+1234", "", ErrInvalidPhone},
+		{"too long", "+1234567890123456", "", ErrInvalidPhone},
+		{"letters", "+1415555CALL", "", ErrInvalidPhone},
+		{"leading zero country code", "+0912345678", "", ErrInvalidPhone},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := NewPhoneNumber(tt.input)
+
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("NewPhoneNumber() error = %v, want %v", err, tt.wantErr)
+			}
+			if got.String() != tt.want {
+				t.Errorf("String() = %q, want %q", got.String(), tt.want)
+			}
+		})
+	}
+}
+
+func TestPhoneNumber_JSON(t *testing.T) {
+	var p PhoneNumber
+
+	if err := json.Unmarshal([]byte(`"+1 415 555 0100"`), &p); err != nil {
+		t.Fatalf("Unmarshal() error = %v", err)
+	}
+
+	data, _ := json.Marshal(p)
+	if string(data) != `"+14155550100"` {
+		t.Errorf("Marshal() = %s, want \"+14155550100\"", data)
+	}
+}
@@ -0,0 +1,52 @@
+package valueobject
+
+import (
+	"encoding/json"
+	"net/url"
+	"strings"
+)
+
+// URL is an absolute http or https URL (Value Object).
+type URL struct {
+	value string
+}
+
+// NewURL creates a validated absolute http(s) URL.
+func NewURL(s string) (URL, error) {
+	s = strings.TrimSpace(s)
+	u, err := url.Parse(s)
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return URL{}, ErrInvalidURL
+	}
+	return URL{value: u.String()}, nil
+}
+
+// Getters
+func (u URL) String() string { return u.value }
+func (u URL) IsZero() bool   { return u.value == "" }
+
+// Parsed returns a copy of the URL as *url.URL.
+func (u URL) Parsed() *url.URL {
+	parsed, _ := url.Parse(u.value)
+	return parsed
+}
+
+// Equals reports whether two URLs are equal.
+func (u URL) Equals(other URL) bool { return u.value == other.value }
+
+// MarshalJSON encodes the URL as a JSON string.
+func (u URL) MarshalJSON() ([]byte, error) { return json.Marshal(u.value) }
+
+// UnmarshalJSON decodes and validates a JSON string.
+func (u *URL) UnmarshalJSON(data []byte) error {
+	var s string
+	if err := json.Unmarshal(data, &s); err != nil {
+		return err
+	}
+	v, err := NewURL(s)
+	if err != nil {
+		return err
+	}
+	*u = v
+	return nil
+}
@@ -0,0 +1,49 @@
+package valueobject
+
+import (
+	"encoding/json"
+	"errors"
+	"testing"
+)
+
+func TestNewURL(t *testing.T) {
+	tests := []struct {
+		name    string
+		input   string
+		wantErr error
+	}{
+		{"https", "https://example.com/path?q=1", nil},
+		{"http with port", "http://localhost:8080", nil},
+		{"relative", "/path", ErrInvalidURL},
+		{"unsupported scheme", "ftp://example.com", ErrInvalidURL},
+		{"missing host", "https://", ErrInvalidURL},
+		{"malformed", "http://[::1", ErrInvalidURL},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := NewURL(tt.input)
+
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("NewURL() error = %v, want %v", err, tt.wantErr)
+			}
+			if err == nil && got.String() != tt.input {
+				t.Errorf("String() = %q, want %q", got.String(), tt.input)
+			}
+		})
+	}
+}
+
+func TestURL_JSON(t *testing.T) {
+	u, _ := NewURL("https://example.com")
+
+	data, _ := json.Marshal(u)
+	var decoded URL
+	if err := json.Unmarshal(data, &decoded); err != nil {
+		t.Fatalf("Unmarshal() error = %v", err)
+	}
+
+	if !decoded.Equals(u) || decoded.Parsed().Host != "example.com" {
+		t.Errorf("round-trip = %v", decoded)
+	}
+}
@@ -0,0 +1,108 @@
+package config
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"text/tabwriter"
+)
+
+// ErrUsage is returned for malformed config subcommand arguments.
+var ErrUsage = errors.New("usage: config show [-json] | config schema | config encrypt [-kms key-id] <value|->")
+
+// Command runs the `config` CLI subcommand:
+//
+//   - `config show` prints the effective configuration at path as a table
+//     of key, value and source, or as JSON with -json; sensitive values
+//     are masked as in Describe.
+//   - `config schema` prints the JSON Schema of the config file.
+//   - `config encrypt` prints an encrypted config value for the plaintext
+//     argument, or stdin for "-", using the age recipients in
+//     CONFIG_AGE_RECIPIENTS (default: those of CONFIG_AGE_KEY) or the
+//     AWS KMS key given with -kms.
+func Command(ctx context.Context, path string, args []string, w io.Writer, opts ...Option) error {
+	if len(args) == 0 {
+		return ErrUsage
+	}
+	switch args[0] {
+	case "show":
+		if len(args) > 2 || (len(args) == 2 && args[1] != "-json") {
+			return ErrUsage
+		}
+		return show(ctx, path, len(args) == 2, w, opts)
+	case "schema":
+		if len(args) != 1 {
+			return ErrUsage
+		}
+		b, err := Schema()
+		if err != nil {
+			return err
+		}
+		_, err = w.Write(b)
+		return err
+	case "encrypt":
+		return encrypt(ctx, args[1:], w)
+	default:
+		return ErrUsage
+	}
+}
+
+func show(ctx context.Context, path string, asJSON bool, w io.Writer, opts []Option) error {
+	settings, err := Describe(ctx, path, DefaultSecrets(), opts...)
+	if err != nil {
+		return err
+	}
+
+	if asJSON {
+		enc := json.NewEncoder(w)
+		enc.SetIndent("", "  ")
+		return enc.Encode(settings)
+	}
+
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(tw, "KEY\tVALUE\tSOURCE")
+	for _, s := range settings {
+		fmt.Fprintf(tw, "%s\t%s\t%s\n", s.Key, s.Value, s.Source)
+	}
+	return tw.Flush()
+}
+
+func encrypt(ctx context.Context, args []string, w io.Writer) error {
+	var kmsKeyID string
+	if len(args) == 3 && args[0] == "-kms" {
+		kmsKeyID, args = args[1], args[2:]
+	}
+	if len(args) != 1 {
+		return ErrUsage
+	}
+	plaintext := args[0]
+	if plaintext == "-" {
+		b, err := io.ReadAll(os.Stdin)
+		if err != nil {
+			return err
+		}
+		plaintext = strings.TrimRight(string(b), "\r\n")
+	}
+
+	p := NewEncryptedProvider(os.Getenv("CONFIG_AGE_KEY"))
+	var value string
+	var err error
+	if kmsKeyID != "" {
+		value, err = p.EncryptKMS(ctx, plaintext, kmsKeyID)
+	} else {
+		recipients := p.Recipients()
+		if r := os.Getenv("CONFIG_AGE_RECIPIENTS"); r != "" {
+			recipients = strings.Split(r, ",")
+		}
+		value, err = EncryptAge(plaintext, recipients...)
+	}
+	if err != nil {
+		return err
+	}
+	_, err = fmt.Fprintln(w, value)
+	return err
+}
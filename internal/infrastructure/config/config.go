@@ -1,25 +1,72 @@
 package config
 
 import (
+	"net"
+	"net/url"
+	"strconv"
 	"time"
 )
 
 // Config holds all configuration for the service.
 type Config struct {
-	App      App       `mapstructure:"app"`
-	Server   Server    `mapstructure:"server"`
-	Database Database  `mapstructure:"database"`
-	Redis    Redis     `mapstructure:"redis"`
-	Log      LogConfig `mapstructure:"log"`
+	App        App        `mapstructure:"app"`
+	Server     Server     `mapstructure:"server"`
+	Database   Database   `mapstructure:"database"`
+	DynamoDB   DynamoDB   `mapstructure:"dynamodb"`
+	Redis      Redis      `mapstructure:"redis"`
+	Kafka      Kafka      `mapstructure:"kafka"`
+	Remote     Remote     `mapstructure:"remote"`
+	Log        LogConfig  `mapstructure:"log"`
+	Otel       Otel       `mapstructure:"otel"`
+	Mail       Mail       `mapstructure:"mail"`
+	Notify     Notify     `mapstructure:"notify"`
+	Outbox     Outbox     `mapstructure:"outbox"`
+	Pagination Pagination `mapstructure:"pagination"`
+	Scheduler  Scheduler  `mapstructure:"scheduler"`
+	Storage    Storage    `mapstructure:"storage"`
+	TaskQueue  TaskQueue  `mapstructure:"task_queue"`
+	Worker     Worker     `mapstructure:"worker"`
+
+	// sections holds the registered sections by key, each as a *T
+	sections map[string]any
 }
 
 // LogConfig contains logging configuration.
 // This is defined in infrastructure layer to avoid dependency on pkg/logx.
 type LogConfig struct {
-	Level     string `mapstructure:"level"`
-	Format    string `mapstructure:"format"`
-	Output    string `mapstructure:"output"`
-	AddSource bool   `mapstructure:"add_source"`
+	Level     string  `mapstructure:"level"`
+	Format    string  `mapstructure:"format"`
+	Output    string  `mapstructure:"output"` // stdout, stderr, file
+	AddSource bool    `mapstructure:"add_source"`
+	File      LogFile `mapstructure:"file"`
+}
+
+// LogFile configures the rotating log file used when log.output is
+// "file", for deployments without a log shipper. It mirrors
+// logx.FileConfig.
+type LogFile struct {
+	Path       string        `mapstructure:"path"`
+	MaxSize    ByteSize      `mapstructure:"max_size"`    // rotate past this size
+	MaxAge     time.Duration `mapstructure:"max_age"`     // 0 keeps rotated files regardless of age
+	MaxBackups int           `mapstructure:"max_backups"` // 0 keeps every rotated file
+	Compress   bool          `mapstructure:"compress"`    // gzip rotated files
+}
+
+// Otel contains OpenTelemetry tracing configuration. It mirrors
+// otelx.Config; the service name, version and environment come from App
+// and the build instead.
+type Otel struct {
+	Enabled    bool     `mapstructure:"enabled"`
+	Exporter   string   `mapstructure:"exporter"`    // otlp, stdout, noop
+	SampleRate float64  `mapstructure:"sample_rate"` // 0.0 to 1.0
+	OTLP       OtelOTLP `mapstructure:"otlp"`
+}
+
+// OtelOTLP contains OTLP exporter configuration.
+type OtelOTLP struct {
+	Endpoint string `mapstructure:"endpoint"` // collector address, e.g. localhost:4318
+	Insecure bool   `mapstructure:"insecure"` // disable TLS
+	Protocol string `mapstructure:"protocol"` // http, grpc
 }
 
 // App contains application-level configuration.
@@ -40,6 +87,27 @@ type HTTP struct {
 	Port         int           `mapstructure:"port"`
 	ReadTimeout  time.Duration `mapstructure:"read_timeout"`
 	WriteTimeout time.Duration `mapstructure:"write_timeout"`
+	MaxBodySize  ByteSize      `mapstructure:"max_body_size"` // e.g. "10MiB"; 0 disables the limit
+	Mode         string        `mapstructure:"mode"`          // gin mode: debug, release, test
+	Mirror       Mirror        `mapstructure:"mirror"`
+	AdminToken   string        `mapstructure:"admin_token"` // Bearer token for /admin endpoints; empty disables them
+	GraphQL      GraphQL       `mapstructure:"graphql"`
+}
+
+// GraphQL contains GraphQL endpoint configuration.
+// Both tools are off by default and preset on by the development profile.
+type GraphQL struct {
+	Playground    bool `mapstructure:"playground"`    // serve /graphql/playground
+	Introspection bool `mapstructure:"introspection"` // answer schema introspection queries
+}
+
+// Mirror contains traffic mirroring (shadowing) configuration.
+type Mirror struct {
+	Upstream       string        `mapstructure:"upstream"`   // shadow service base URL; empty disables mirroring
+	Percentage     float64       `mapstructure:"percentage"` // 0-100
+	Timeout        time.Duration `mapstructure:"timeout"`
+	MaxInFlight    int           `mapstructure:"max_in_flight"`   // concurrent shadow requests; excess requests are not mirrored
+	ForwardHeaders []string      `mapstructure:"forward_headers"` // credential headers to copy, e.g. Authorization; none by default
 }
 
 // GRPC contains gRPC server configuration.
@@ -60,14 +128,249 @@ type Database struct {
 	MaxOpenConns    int           `mapstructure:"max_open_conns"`
 	MaxIdleConns    int           `mapstructure:"max_idle_conns"`
 	ConnMaxLifetime time.Duration `mapstructure:"conn_max_lifetime"`
+	ConnMaxIdleTime time.Duration `mapstructure:"conn_max_idle_time"`
+
+	// SlowQueryThreshold logs statements taking at least this long; 0 disables it.
+	SlowQueryThreshold time.Duration `mapstructure:"slow_query_threshold"`
+
+	// AutoMigrate applies pending migrations on start; ignored in production.
+	AutoMigrate bool `mapstructure:"auto_migrate"`
+}
+
+// Pagination contains pagination configuration.
+type Pagination struct {
+	// CursorKeys are HMAC keys for signed cursors. The first key signs;
+	// all keys verify, so rotate by prepending a new key.
+	CursorKeys []string `mapstructure:"cursor_keys"`
+
+	// CursorTTL rejects cursors older than this; 0 disables expiry.
+	CursorTTL time.Duration `mapstructure:"cursor_ttl"`
+}
+
+// DSN returns the PostgreSQL connection URL.
+func (d Database) DSN() string {
+	u := url.URL{
+		Scheme:   "postgres",
+		User:     url.UserPassword(d.User, d.Password),
+		Host:     net.JoinHostPort(d.Host, strconv.Itoa(d.Port)),
+		Path:     "/" + d.Name,
+		RawQuery: url.Values{"sslmode": {d.SSLMode}}.Encode(),
+	}
+	return u.String()
+}
+
+// DynamoDB contains Amazon DynamoDB configuration. Credentials come from
+// the default AWS chain.
+type DynamoDB struct {
+	Region   string `mapstructure:"region"`
+	Endpoint string `mapstructure:"endpoint"` // DynamoDB Local / LocalStack URL, empty for AWS
+	Table    string `mapstructure:"table"`    // single table holding all aggregates
+}
+
+// Outbox contains transactional outbox relay configuration.
+type Outbox struct {
+	PollInterval time.Duration `mapstructure:"poll_interval"`
+	BatchSize    int           `mapstructure:"batch_size"`
+	MaxAttempts  int           `mapstructure:"max_attempts"` // failed publishes before a message is parked
+}
+
+// Scheduler contains cron job configuration.
+type Scheduler struct {
+	// Jobs maps job names to cron expressions, e.g. "*/5 * * * *" or "@every 1h".
+	// Registered jobs without an entry are disabled.
+	Jobs map[string]string `mapstructure:"jobs"`
+
+	// LockTTL is how long a fired tick stays claimed across instances.
+	LockTTL time.Duration `mapstructure:"lock_ttl"`
+}
+
+// Mail contains outgoing email configuration.
+type Mail struct {
+	// Driver selects the backend: "log" (default, logs instead of sending),
+	// "smtp" or "ses".
+	Driver string `mapstructure:"driver"`
+
+	// From is the sender address, e.g. "Shop <no-reply@example.com>".
+	From string `mapstructure:"from"`
+
+	// MaxAttempts bounds delivery attempts on transient failures.
+	MaxAttempts int `mapstructure:"max_attempts"`
+
+	// RetryBackoff is the delay before the first retry, doubled after each.
+	RetryBackoff time.Duration `mapstructure:"retry_backoff"`
+
+	SMTP SMTPMail `mapstructure:"smtp"`
+	SES  SESMail  `mapstructure:"ses"`
+}
+
+// SMTPMail contains SMTP relay configuration.
+type SMTPMail struct {
+	Host     string `mapstructure:"host"`
+	Port     int    `mapstructure:"port"`
+	Username string `mapstructure:"username"`
+	Password string `mapstructure:"password"`
+}
+
+// SESMail contains Amazon SES configuration. Credentials come from the
+// default AWS chain.
+type SESMail struct {
+	Region string `mapstructure:"region"`
+}
+
+// Notify contains notification channel credentials. A channel left
+// unconfigured logs its messages instead of sending them.
+type Notify struct {
+	Twilio TwilioNotify `mapstructure:"twilio"`
+	Slack  SlackNotify  `mapstructure:"slack"`
+	FCM    FCMNotify    `mapstructure:"fcm"`
+}
+
+// TwilioNotify configures SMS through Twilio.
+type TwilioNotify struct {
+	AccountSID string `mapstructure:"account_sid"`
+	AuthToken  string `mapstructure:"auth_token"`
+
+	// From is the sending number or messaging service SID.
+	From string `mapstructure:"from"`
+}
+
+// SlackNotify configures chat messages through a Slack incoming webhook.
+type SlackNotify struct {
+	WebhookURL string `mapstructure:"webhook_url"`
+}
+
+// FCMNotify configures push through Firebase Cloud Messaging.
+type FCMNotify struct {
+	ProjectID string `mapstructure:"project_id"`
+
+	// CredentialsFile is a service account JSON key; empty uses
+	// Application Default Credentials.
+	CredentialsFile string `mapstructure:"credentials_file"`
+}
+
+// Storage contains object storage configuration for uploads and generated
+// files such as exports.
+type Storage struct {
+	// Driver selects the backend: "local" (default) or "s3".
+	Driver string `mapstructure:"driver"`
+
+	// Dir is the root directory of the local object store.
+	Dir string `mapstructure:"dir"`
+
+	// BaseURL is the public URL where the store serves presigned downloads.
+	BaseURL string `mapstructure:"base_url"`
+
+	// SigningKey authenticates presigned download URLs.
+	SigningKey string `mapstructure:"signing_key"`
+
+	// URLTTL is how long a presigned download URL stays valid.
+	URLTTL time.Duration `mapstructure:"url_ttl"`
+
+	// S3 configures the "s3" driver.
+	S3 S3Storage `mapstructure:"s3"`
+}
+
+// S3Storage contains S3-compatible object storage configuration.
+type S3Storage struct {
+	Bucket string `mapstructure:"bucket"`
+	Region string `mapstructure:"region"`
+
+	// Endpoint overrides the AWS endpoint, e.g. "http://localhost:9000" for MinIO.
+	Endpoint string `mapstructure:"endpoint"`
+
+	// UsePathStyle puts the bucket in the URL path, as MinIO requires.
+	UsePathStyle bool `mapstructure:"use_path_style"`
+}
+
+// TaskQueue contains asynchronous task queue (asynq) configuration.
+type TaskQueue struct {
+	Concurrency     int            `mapstructure:"concurrency"`
+	Queues          map[string]int `mapstructure:"queues"` // queue name -> priority
+	RetryBaseDelay  time.Duration  `mapstructure:"retry_base_delay"`
+	RetryMaxDelay   time.Duration  `mapstructure:"retry_max_delay"`
+	ShutdownTimeout time.Duration  `mapstructure:"shutdown_timeout"`
+}
+
+// Worker contains background worker configuration.
+type Worker struct {
+	Host       string           `mapstructure:"host"` // health endpoint host
+	Port       int              `mapstructure:"port"` // health endpoint port
+	Components WorkerComponents `mapstructure:"components"`
+
+	// LeaderElection runs singleton components (outbox relay, scheduler) on
+	// one replica at a time, elected through a Redis lock.
+	LeaderElection bool `mapstructure:"leader_election"`
+}
+
+// WorkerComponents toggles the components a worker runs, so each kind of
+// background processing can be scaled as a separate deployment.
+type WorkerComponents struct {
+	Outbox      bool `mapstructure:"outbox"`      // outbox relay
+	Projections bool `mapstructure:"projections"` // read-model projections
+	Sagas       bool `mapstructure:"sagas"`       // resumes stalled saga instances
+	Scheduler   bool `mapstructure:"scheduler"`   // cron jobs
+	Tasks       bool `mapstructure:"tasks"`       // async task consumer (requires Redis)
+	Consumer    bool `mapstructure:"consumer"`    // message bus consumer (requires Kafka)
 }
 
 // Redis contains Redis configuration.
 type Redis struct {
+	// Mode selects the topology: standalone, sentinel or cluster.
+	Mode     string `mapstructure:"mode"`
 	Host     string `mapstructure:"host"`
 	Port     int    `mapstructure:"port"`
+	Username string `mapstructure:"username"`
 	Password string `mapstructure:"password"`
-	DB       int    `mapstructure:"db"`
+	DB       int    `mapstructure:"db"` // ignored in cluster mode
+
+	// Addrs lists sentinel or cluster seed nodes (host:port); host and
+	// port are used when empty.
+	Addrs []string `mapstructure:"addrs"`
+
+	// MasterName and SentinelPassword configure sentinel mode.
+	MasterName       string `mapstructure:"master_name"`
+	SentinelPassword string `mapstructure:"sentinel_password"`
+
+	PoolSize     int           `mapstructure:"pool_size"`      // 0 keeps the client default
+	MinIdleConns int           `mapstructure:"min_idle_conns"` // 0 keeps the client default
+	DialTimeout  time.Duration `mapstructure:"dial_timeout"`
+	ReadTimeout  time.Duration `mapstructure:"read_timeout"`
+	WriteTimeout time.Duration `mapstructure:"write_timeout"`
+
+	TLS RedisTLS `mapstructure:"tls"`
+}
+
+// RedisTLS contains Redis TLS configuration.
+type RedisTLS struct {
+	Enabled    bool   `mapstructure:"enabled"`
+	ServerName string `mapstructure:"server_name"` // defaults to the dialled host
+	CAFile     string `mapstructure:"ca_file"`     // PEM bundle; system roots when empty
+
+	// InsecureSkipVerify disables certificate verification (development only).
+	InsecureSkipVerify bool `mapstructure:"insecure_skip_verify"`
+}
+
+// Kafka contains message bus configuration.
+type Kafka struct {
+	Brokers      []string      `mapstructure:"brokers"`      // empty disables the bus
+	GroupID      string        `mapstructure:"group_id"`     // consumer group
+	TopicPrefix  string        `mapstructure:"topic_prefix"` // prepended to event names
+	DLQSuffix    string        `mapstructure:"dlq_suffix"`   // dead-letter topic suffix
+	MaxRetries   int           `mapstructure:"max_retries"`  // retries before dead-lettering
+	RetryBackoff time.Duration `mapstructure:"retry_backoff"`
+	BatchTimeout time.Duration `mapstructure:"batch_timeout"` // producer batching delay
+}
+
+// Remote contains the central configuration store merged over the config
+// files. It is read from the files and environment only, never from the
+// remote document itself.
+type Remote struct {
+	Provider        string        `mapstructure:"provider"`         // consul, etcd; empty disables remote config
+	Endpoint        string        `mapstructure:"endpoint"`         // e.g. http://consul:8500 or http://etcd:2379
+	Key             string        `mapstructure:"key"`              // key holding the document, e.g. config/go-ddd/production
+	Format          string        `mapstructure:"format"`           // yaml, json
+	Token           string        `mapstructure:"token"`            // Consul ACL token; may be a secret reference
+	RefreshInterval time.Duration `mapstructure:"refresh_interval"` // polling interval for reloads
 }
 
 // IsDevelopment returns true if running in development environment.
@@ -0,0 +1,79 @@
+package config
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"slices"
+	"strings"
+)
+
+// Masked replaces sensitive values in Describe output.
+const Masked = "******"
+
+// Setting is one effective configuration value and where it came from.
+type Setting struct {
+	Key    string `json:"key"`
+	Value  string `json:"value"`
+	Source string `json:"source"` // "default", "profile:<env>", "file:<path>", "remote:<provider>:<key>", "env:<VAR>", "dotenv:<VAR>" or "flag:--<key>"
+}
+
+// Describe returns every setting of the configuration at path, merged as
+// Load does and sorted by key, with the source that set it. Secret
+// references are shown as written rather than resolved, and other
+// sensitive values (passwords, tokens, keys) are replaced by Masked.
+// Unlike Load it does not validate, so it can inspect a broken setup.
+func Describe(ctx context.Context, path string, secrets *Secrets, opts ...Option) ([]Setting, error) {
+	o := newOptions(opts)
+	v, layers, err := read(ctx, path, secrets, o)
+	if err != nil {
+		return nil, err
+	}
+	cfg, err := unmarshal(v)
+	if err != nil {
+		return nil, err
+	}
+
+	values := leaves(cfg)
+	settings := make([]Setting, 0, len(values))
+	for key, val := range values {
+		s := Setting{Key: key, Value: fmt.Sprint(val.Interface()), Source: "default"}
+		for _, l := range layers {
+			if l.values.IsSet(key) {
+				s.Source = l.source
+			}
+		}
+		if env := envKey(key); os.Getenv(env) != "" {
+			s.Source = "env:" + env
+			if fromDotEnv(env) {
+				s.Source = "dotenv:" + env
+			}
+		}
+		if _, ok := o.flags[key]; ok {
+			s.Source = "flag:--" + key
+		}
+		if sensitive(key) && s.Value != "" && !secrets.IsRef(s.Value) {
+			s.Value = Masked
+		}
+		settings = append(settings, s)
+	}
+	slices.SortFunc(settings, func(a, b Setting) int { return strings.Compare(a.Key, b.Key) })
+	return settings, nil
+}
+
+// envKey is the environment variable overriding key, e.g.
+// APP_DATABASE_PASSWORD for database.password.
+func envKey(key string) string {
+	return "APP_" + strings.ToUpper(strings.ReplaceAll(key, ".", "_"))
+}
+
+// sensitive reports whether the value of key is a credential.
+func sensitive(key string) bool {
+	name := key[strings.LastIndex(key, ".")+1:]
+	for _, s := range []string{"password", "secret", "token"} {
+		if strings.Contains(name, s) {
+			return true
+		}
+	}
+	return strings.HasSuffix(name, "_key") || strings.HasSuffix(name, "_keys") || name == "webhook_url"
+}
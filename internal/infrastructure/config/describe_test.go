@@ -0,0 +1,59 @@
+package config
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestDescribe(t *testing.T) {
+	// Arrange
+	dir := t.TempDir()
+	path := filepath.Join(dir, "config.yaml")
+	files := map[string]string{
+		"config.yaml":       "database:\n  host: db\n  password: plaintext\nredis:\n  password: env:TEST_REDIS_PASSWORD\n",
+		"config.local.yaml": "database:\n  host: localhost\n",
+	}
+	for name, data := range files {
+		if err := os.WriteFile(filepath.Join(dir, name), []byte(data), 0o600); err != nil {
+			t.Fatal(err)
+		}
+	}
+	t.Setenv("APP_LOG_LEVEL", "debug")
+
+	// Act
+	settings, err := Describe(context.Background(), path, DefaultSecrets())
+
+	// Assert
+	if err != nil {
+		t.Fatalf("Describe() error = %v", err)
+	}
+	got := make(map[string]Setting, len(settings))
+	for _, s := range settings {
+		got[s.Key] = s
+	}
+	tests := []struct {
+		key    string
+		value  string
+		source string
+	}{
+		{key: "server.http.port", value: "8080", source: "default"},
+		{key: "database.host", value: "localhost", source: "file:" + filepath.Join(dir, "config.local.yaml")},
+		{key: "database.password", value: Masked, source: "file:" + path},
+		{key: "redis.password", value: "env:TEST_REDIS_PASSWORD", source: "file:" + path},
+		{key: "log.level", value: "debug", source: "env:APP_LOG_LEVEL"},
+		{key: "storage.signing_key", value: "", source: "default"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.key, func(t *testing.T) {
+			s, ok := got[tt.key]
+			if !ok {
+				t.Fatalf("Describe() missing %s", tt.key)
+			}
+			if s.Value != tt.value || s.Source != tt.source {
+				t.Errorf("Describe() %s = %q from %q, want %q from %q", tt.key, s.Value, s.Source, tt.value, tt.source)
+			}
+		})
+	}
+}
@@ -0,0 +1,133 @@
+package config
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"regexp"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+// DotEnvFile is the dotenv file read from the working directory before
+// environment variables are applied, the way docker-compose reads it.
+const DotEnvFile = ".env"
+
+// envName matches a dotenv variable name.
+var envName = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_]*$`)
+
+var (
+	dotenvMu sync.Mutex
+	// dotenvSet records the variables set from the file and their values,
+	// so a reload picks up edits without overriding the real environment.
+	dotenvSet = make(map[string]string)
+)
+
+// loadDotEnv sets the variables in path that are not already in the
+// environment. A missing file is not an error. Lines are KEY=VALUE with
+// an optional "export " prefix; "#" starts a comment outside quotes,
+// single-quoted values are literal and double-quoted values may span
+// lines and use \n, \t, \" and \\ escapes.
+func loadDotEnv(path string) error {
+	vars, err := parseDotEnv(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	dotenvMu.Lock()
+	defer dotenvMu.Unlock()
+	for _, kv := range vars {
+		if cur, ok := os.LookupEnv(kv[0]); ok {
+			if set, fromFile := dotenvSet[kv[0]]; !fromFile || set != cur {
+				continue
+			}
+		}
+		if err := os.Setenv(kv[0], kv[1]); err != nil {
+			return fmt.Errorf("%s: set %s: %w", path, kv[0], err)
+		}
+		dotenvSet[kv[0]] = kv[1]
+	}
+	return nil
+}
+
+// fromDotEnv reports whether the variable name currently holds the value
+// set from the dotenv file.
+func fromDotEnv(name string) bool {
+	dotenvMu.Lock()
+	defer dotenvMu.Unlock()
+	set, ok := dotenvSet[name]
+	return ok && os.Getenv(name) == set
+}
+
+// parseDotEnv returns the key/value pairs in path in file order.
+func parseDotEnv(path string) ([][2]string, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	var vars [][2]string
+	lines := strings.Split(strings.ReplaceAll(string(data), "\r\n", "\n"), "\n")
+	for i := 0; i < len(lines); i++ {
+		lineNo := i + 1
+		line := strings.TrimSpace(lines[i])
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		line = strings.TrimPrefix(line, "export ")
+		key, value, ok := strings.Cut(line, "=")
+		key = strings.TrimSpace(key)
+		if !ok || !envName.MatchString(key) {
+			return nil, fmt.Errorf("%s:%d: want KEY=VALUE", path, lineNo)
+		}
+		value = strings.TrimSpace(value)
+
+		switch {
+		case strings.HasPrefix(value, "'"):
+			end := strings.Index(value[1:], "'")
+			if end < 0 {
+				return nil, fmt.Errorf("%s:%d: unterminated quote", path, lineNo)
+			}
+			value = value[1 : end+1]
+		case strings.HasPrefix(value, `"`):
+			// Join following lines until the closing quote
+			for closingQuote(value) < 0 && i+1 < len(lines) {
+				i++
+				value += "\n" + lines[i]
+			}
+			end := closingQuote(value)
+			if end < 0 {
+				return nil, fmt.Errorf("%s:%d: unterminated quote", path, lineNo)
+			}
+			unquoted, err := strconv.Unquote(strings.ReplaceAll(value[:end+1], "\n", `\n`))
+			if err != nil {
+				return nil, fmt.Errorf("%s:%d: %w", path, lineNo, err)
+			}
+			value = unquoted
+		default:
+			if idx := strings.Index(value, " #"); idx >= 0 {
+				value = strings.TrimSpace(value[:idx])
+			}
+		}
+		vars = append(vars, [2]string{key, value})
+	}
+	return vars, nil
+}
+
+// closingQuote returns the index of the unescaped double quote ending the
+// string that starts at s[0], or -1.
+func closingQuote(s string) int {
+	for i := 1; i < len(s); i++ {
+		switch s[i] {
+		case '\\':
+			i++
+		case '"':
+			return i
+		}
+	}
+	return -1
+}
@@ -0,0 +1,119 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"testing"
+)
+
+func TestParseDotEnv(t *testing.T) {
+	tests := []struct {
+		name    string
+		data    string
+		want    [][2]string
+		wantErr string
+	}{
+		{
+			name: "plain, export and comments",
+			data: "# local setup\nAPP_APP_ENV=development\n\nexport APP_DATABASE_HOST = db # compose service\nEMPTY=\n",
+			want: [][2]string{{"APP_APP_ENV", "development"}, {"APP_DATABASE_HOST", "db"}, {"EMPTY", ""}},
+		},
+		{
+			name: "quotes",
+			data: "SINGLE='literal $HOME # not a comment'\nDOUBLE=\"tab\\there \\\"quoted\\\"\"\nHASH=a#b\n",
+			want: [][2]string{{"SINGLE", "literal $HOME # not a comment"}, {"DOUBLE", "tab\there \"quoted\""}, {"HASH", "a#b"}},
+		},
+		{
+			name: "multi-line double quotes",
+			data: "KEY=\"-----BEGIN-----\nabc\n-----END-----\"\nNEXT=1\r\n",
+			want: [][2]string{{"KEY", "-----BEGIN-----\nabc\n-----END-----"}, {"NEXT", "1"}},
+		},
+		{name: "missing equals", data: "JUSTAKEY\n", wantErr: ".env:1: want KEY=VALUE"},
+		{name: "invalid name", data: "A=1\n1BAD=2\n", wantErr: ".env:2: want KEY=VALUE"},
+		{name: "unterminated", data: "A='open\n", wantErr: ".env:1: unterminated quote"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Arrange
+			path := filepath.Join(t.TempDir(), ".env")
+			if err := os.WriteFile(path, []byte(tt.data), 0o600); err != nil {
+				t.Fatal(err)
+			}
+
+			// Act
+			got, err := parseDotEnv(path)
+
+			// Assert
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("parseDotEnv() error = %v, want %q", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("parseDotEnv() error = %v", err)
+			}
+			if !slices.Equal(got, tt.want) {
+				t.Errorf("parseDotEnv() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestLoad_DotEnv(t *testing.T) {
+	// Arrange
+	dir := t.TempDir()
+	t.Chdir(dir)
+	// Restore both variables after the test; only the first is set beforehand
+	t.Setenv("APP_SERVER_HTTP_PORT", "9191")
+	t.Setenv("APP_DATABASE_HOST", "")
+	os.Unsetenv("APP_DATABASE_HOST")
+	t.Cleanup(func() { clear(dotenvSet) })
+	data := "APP_SERVER_HTTP_PORT=7070\nAPP_DATABASE_HOST=compose-db\n"
+	if err := os.WriteFile(filepath.Join(dir, DotEnvFile), []byte(data), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	// Act
+	cfg, err := Load("")
+
+	// Assert
+	if err != nil {
+		t.Fatalf("Load() error = %v", err)
+	}
+	if cfg.Database.Host != "compose-db" {
+		t.Errorf("Database.Host = %q, want compose-db from .env", cfg.Database.Host)
+	}
+	if cfg.Server.HTTP.Port != 9191 {
+		t.Errorf("Server.HTTP.Port = %d, want 9191 from the environment", cfg.Server.HTTP.Port)
+	}
+
+	settings, err := Describe(t.Context(), "", NewSecrets(0, nil))
+	if err != nil {
+		t.Fatalf("Describe() error = %v", err)
+	}
+	for _, s := range settings {
+		if s.Key == "database.host" && s.Source != "dotenv:APP_DATABASE_HOST" {
+			t.Errorf("database.host source = %q, want dotenv:APP_DATABASE_HOST", s.Source)
+		}
+		if s.Key == "server.http.port" && s.Source != "env:APP_SERVER_HTTP_PORT" {
+			t.Errorf("server.http.port source = %q, want env:APP_SERVER_HTTP_PORT", s.Source)
+		}
+	}
+
+	// Edits to .env apply on the next load
+	data = "APP_DATABASE_HOST=other-db\n"
+	if err := os.WriteFile(filepath.Join(dir, DotEnvFile), []byte(data), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	cfg, err = Load("")
+	if err != nil {
+		t.Fatalf("Load() error = %v", err)
+	}
+	if cfg.Database.Host != "other-db" {
+		t.Errorf("Database.Host after edit = %q, want other-db", cfg.Database.Host)
+	}
+}
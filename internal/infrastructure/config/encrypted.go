@@ -0,0 +1,162 @@
+package config
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+	"sync"
+
+	"filippo.io/age"
+	awsconfig "github.com/aws/aws-sdk-go-v2/config"
+	"github.com/aws/aws-sdk-go-v2/service/kms"
+)
+
+// EncryptedProvider decrypts values committed to config files in
+// encrypted form, so low-sensitivity secrets can live in the repository:
+//
+//	enc:age:<base64>  age ciphertext, decrypted with the identities in CONFIG_AGE_KEY
+//	enc:kms:<base64>  AWS KMS ciphertext blob, decrypted with the default AWS credentials
+//
+// Create values with `service config encrypt`.
+type EncryptedProvider struct {
+	identities []age.Identity
+	parseErr   error
+
+	once   sync.Once
+	client *kms.Client
+	err    error
+}
+
+// NewEncryptedProvider creates a provider for the age identities in keys,
+// one AGE-SECRET-KEY-1... per line; keys may be empty when only KMS is
+// used.
+func NewEncryptedProvider(keys string) *EncryptedProvider {
+	p := &EncryptedProvider{}
+	if strings.TrimSpace(keys) != "" {
+		p.identities, p.parseErr = age.ParseIdentities(strings.NewReader(keys))
+	}
+	return p
+}
+
+// Secret implements SecretProvider; path is "age:<base64>" or
+// "kms:<base64>".
+func (p *EncryptedProvider) Secret(ctx context.Context, path string) (string, error) {
+	method, payload, ok := strings.Cut(path, ":")
+	if !ok {
+		return "", errors.New("enc: value must be enc:age:<base64> or enc:kms:<base64>")
+	}
+	ciphertext, err := base64.StdEncoding.DecodeString(payload)
+	if err != nil {
+		return "", fmt.Errorf("enc: decode base64: %w", err)
+	}
+
+	switch method {
+	case "age":
+		return p.decryptAge(ciphertext)
+	case "kms":
+		return p.decryptKMS(ctx, ciphertext)
+	default:
+		return "", fmt.Errorf("enc: unknown method %q", method)
+	}
+}
+
+func (p *EncryptedProvider) decryptAge(ciphertext []byte) (string, error) {
+	if p.parseErr != nil {
+		return "", fmt.Errorf("enc: parse CONFIG_AGE_KEY: %w", p.parseErr)
+	}
+	if len(p.identities) == 0 {
+		return "", errors.New("enc: CONFIG_AGE_KEY not set")
+	}
+	r, err := age.Decrypt(bytes.NewReader(ciphertext), p.identities...)
+	if err != nil {
+		return "", fmt.Errorf("enc: age: %w", err)
+	}
+	plaintext, err := io.ReadAll(r)
+	if err != nil {
+		return "", fmt.Errorf("enc: age: %w", err)
+	}
+	return string(plaintext), nil
+}
+
+func (p *EncryptedProvider) decryptKMS(ctx context.Context, ciphertext []byte) (string, error) {
+	if err := p.initKMS(ctx); err != nil {
+		return "", err
+	}
+	out, err := p.client.Decrypt(ctx, &kms.DecryptInput{CiphertextBlob: ciphertext})
+	if err != nil {
+		return "", fmt.Errorf("enc: kms: %w", err)
+	}
+	return string(out.Plaintext), nil
+}
+
+// initKMS creates the client on first use so configurations without KMS
+// values never load AWS credentials.
+func (p *EncryptedProvider) initKMS(ctx context.Context) error {
+	p.once.Do(func() {
+		cfg, err := awsconfig.LoadDefaultConfig(ctx)
+		if err != nil {
+			p.err = fmt.Errorf("load aws config: %w", err)
+			return
+		}
+		p.client = kms.NewFromConfig(cfg)
+	})
+	return p.err
+}
+
+// EncryptAge encrypts plaintext to the given age recipients (age1...) and
+// returns it as an "enc:age:" config value.
+func EncryptAge(plaintext string, recipients ...string) (string, error) {
+	if len(recipients) == 0 {
+		return "", errors.New("enc: no age recipients")
+	}
+	rs := make([]age.Recipient, 0, len(recipients))
+	for _, r := range recipients {
+		recipient, err := age.ParseX25519Recipient(strings.TrimSpace(r))
+		if err != nil {
+			return "", fmt.Errorf("enc: %w", err)
+		}
+		rs = append(rs, recipient)
+	}
+
+	var buf bytes.Buffer
+	w, err := age.Encrypt(&buf, rs...)
+	if err != nil {
+		return "", fmt.Errorf("enc: age: %w", err)
+	}
+	if _, err := io.WriteString(w, plaintext); err != nil {
+		return "", fmt.Errorf("enc: age: %w", err)
+	}
+	if err := w.Close(); err != nil {
+		return "", fmt.Errorf("enc: age: %w", err)
+	}
+	return "enc:age:" + base64.StdEncoding.EncodeToString(buf.Bytes()), nil
+}
+
+// EncryptKMS encrypts plaintext with the AWS KMS key keyID (ID, ARN or
+// alias) and returns it as an "enc:kms:" config value.
+func (p *EncryptedProvider) EncryptKMS(ctx context.Context, plaintext, keyID string) (string, error) {
+	if err := p.initKMS(ctx); err != nil {
+		return "", err
+	}
+	out, err := p.client.Encrypt(ctx, &kms.EncryptInput{KeyId: &keyID, Plaintext: []byte(plaintext)})
+	if err != nil {
+		return "", fmt.Errorf("enc: kms: %w", err)
+	}
+	return "enc:kms:" + base64.StdEncoding.EncodeToString(out.CiphertextBlob), nil
+}
+
+// Recipients returns the age recipients of the provider's identities, so
+// values can be encrypted for the key that will decrypt them.
+func (p *EncryptedProvider) Recipients() []string {
+	var out []string
+	for _, id := range p.identities {
+		if x, ok := id.(*age.X25519Identity); ok {
+			out = append(out, x.Recipient().String())
+		}
+	}
+	return out
+}
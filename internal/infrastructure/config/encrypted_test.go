@@ -0,0 +1,99 @@
+package config
+
+import (
+	"bytes"
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"filippo.io/age"
+)
+
+func TestEncryptedProvider_Age(t *testing.T) {
+	// Arrange
+	identity, err := age.GenerateX25519Identity()
+	if err != nil {
+		t.Fatal(err)
+	}
+	other, err := age.GenerateX25519Identity()
+	if err != nil {
+		t.Fatal(err)
+	}
+	value, err := EncryptAge("s3cret", identity.Recipient().String())
+	if err != nil {
+		t.Fatalf("EncryptAge() error = %v", err)
+	}
+
+	tests := []struct {
+		name    string
+		keys    string
+		ref     string
+		want    string
+		wantErr string
+	}{
+		{name: "decrypts", keys: identity.String(), ref: value, want: "s3cret"},
+		{name: "wrong key", keys: other.String(), ref: value, wantErr: "did not match"},
+		{name: "no key", ref: value, wantErr: "CONFIG_AGE_KEY not set"},
+		{name: "bad base64", keys: identity.String(), ref: "enc:age:%%%", wantErr: "decode base64"},
+		{name: "unknown method", keys: identity.String(), ref: "enc:gpg:AAAA", wantErr: "unknown method"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := NewSecrets(0, map[string]SecretProvider{"enc": NewEncryptedProvider(tt.keys)})
+
+			// Act
+			got, err := s.Resolve(context.Background(), tt.ref)
+
+			// Assert
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("Resolve() error = %v, want %q", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil || got != tt.want {
+				t.Errorf("Resolve() = %q, %v, want %q", got, err, tt.want)
+			}
+		})
+	}
+}
+
+func TestLoad_EncryptedValues(t *testing.T) {
+	// Arrange
+	identity, err := age.GenerateX25519Identity()
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Setenv("CONFIG_AGE_KEY", identity.String())
+
+	var out bytes.Buffer
+	if err := Command(context.Background(), "", []string{"encrypt", "db-password"}, &out); err != nil {
+		t.Fatalf("Command(encrypt) error = %v", err)
+	}
+	value := strings.TrimSpace(out.String())
+	if !strings.HasPrefix(value, "enc:age:") {
+		t.Fatalf("encrypt printed %q, want an enc:age: value", value)
+	}
+
+	path := filepath.Join(t.TempDir(), "config.yaml")
+	if err := os.WriteFile(path, []byte("database:\n  password: "+value+"\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	// Act
+	cfg, err := LoadWithSecrets(context.Background(), path, NewSecrets(time.Minute, map[string]SecretProvider{
+		"enc": NewEncryptedProvider(os.Getenv("CONFIG_AGE_KEY")),
+	}))
+
+	// Assert
+	if err != nil {
+		t.Fatalf("Load() error = %v", err)
+	}
+	if cfg.Database.Password != "db-password" {
+		t.Errorf("Database.Password = %q, want decrypted value", cfg.Database.Password)
+	}
+}
@@ -0,0 +1,100 @@
+package config
+
+import (
+	"flag"
+	"maps"
+	"slices"
+
+	"github.com/spf13/viper"
+)
+
+// Option customizes Load.
+type Option func(*options)
+
+type options struct {
+	flags  map[string]string // overrides set on the command line, by key
+	strict bool              // reject unknown keys, see Strict
+}
+
+func newOptions(opts []Option) options {
+	var o options
+	for _, opt := range opts {
+		opt(&o)
+	}
+	return o
+}
+
+// BindFlags defines a flag on fs for every configuration key, including
+// registered sections, named after the key: -server.http.port=9000 or
+// --log.level=debug. Call it before fs.Parse and pass WithFlags(fs) to
+// Load to apply the flags that were set.
+func BindFlags(fs *flag.FlagSet) {
+	for _, key := range keys() {
+		fs.Var(new(flagValue), key, "override config key "+key)
+	}
+}
+
+// WithFlags applies the flags defined by BindFlags that were set on fs.
+// They take precedence over environment variables, the remote store and
+// files.
+func WithFlags(fs *flag.FlagSet) Option {
+	return func(o *options) {
+		fs.Visit(func(f *flag.Flag) {
+			if v, ok := f.Value.(*flagValue); ok {
+				if o.flags == nil {
+					o.flags = make(map[string]string)
+				}
+				o.flags[f.Name] = v.String()
+			}
+		})
+	}
+}
+
+// bindFlags binds the overrides in o to v.
+func (o options) bindFlags(v *viper.Viper) error {
+	for _, key := range slices.Sorted(maps.Keys(o.flags)) {
+		if err := v.BindFlagValue(key, boundFlag{name: key, value: o.flags[key]}); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// keys returns every configuration key, sorted.
+func keys() []string {
+	v := viper.New()
+	setDefaults(v)
+	for _, s := range registeredSections() {
+		s.setDefaults(v)
+	}
+	all := v.AllKeys()
+	for key := range leaves(&Config{}) {
+		all = append(all, key)
+	}
+	slices.Sort(all)
+	return slices.Compact(all)
+}
+
+// flagValue holds a value verbatim; the decoder converts it like a value
+// from the environment, so "30s" sets a duration and "a,b" a list.
+type flagValue struct {
+	value string
+}
+
+func (f *flagValue) String() string { return f.value }
+
+func (f *flagValue) Set(s string) error {
+	f.value = s
+	return nil
+}
+
+// boundFlag adapts a set flag to viper.FlagValue.
+type boundFlag struct {
+	name  string
+	value string
+}
+
+func (f boundFlag) HasChanged() bool    { return true }
+func (f boundFlag) Name() string        { return f.name }
+func (f boundFlag) ValueString() string { return f.value }
+func (f boundFlag) ValueType() string   { return "string" }
@@ -0,0 +1,89 @@
+package config
+
+import (
+	"context"
+	"flag"
+	"io"
+	"os"
+	"path/filepath"
+	"slices"
+	"testing"
+	"time"
+)
+
+func TestWithFlags(t *testing.T) {
+	// Arrange
+	t.Setenv("APP_LOG_LEVEL", "error")
+	path := filepath.Join(t.TempDir(), "config.yaml")
+	if err := os.WriteFile(path, []byte("server:\n  http:\n    port: 8081\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	fs := flag.NewFlagSet("test", flag.ContinueOnError)
+	BindFlags(fs)
+	args := []string{
+		"--server.http.port=9000",
+		"-log.level=debug",
+		"-server.http.read_timeout", "45s",
+		"--kafka.brokers=a:9092,b:9092",
+		"--database.auto_migrate=true",
+		"--test_payments.retries=7",
+	}
+	if err := fs.Parse(args); err != nil {
+		t.Fatal(err)
+	}
+
+	// Act
+	cfg, err := Load(path, WithFlags(fs))
+
+	// Assert
+	if err != nil {
+		t.Fatalf("Load() error = %v", err)
+	}
+	if cfg.Server.HTTP.Port != 9000 {
+		t.Errorf("Server.HTTP.Port = %d, want 9000 over the file", cfg.Server.HTTP.Port)
+	}
+	if cfg.Log.Level != "debug" {
+		t.Errorf("Log.Level = %q, want debug over the environment", cfg.Log.Level)
+	}
+	if cfg.Server.HTTP.ReadTimeout != 45*time.Second {
+		t.Errorf("Server.HTTP.ReadTimeout = %s, want 45s", cfg.Server.HTTP.ReadTimeout)
+	}
+	if want := []string{"a:9092", "b:9092"}; !slices.Equal(cfg.Kafka.Brokers, want) {
+		t.Errorf("Kafka.Brokers = %v, want %v", cfg.Kafka.Brokers, want)
+	}
+	if !cfg.Database.AutoMigrate {
+		t.Error("Database.AutoMigrate = false, want true")
+	}
+	if got := testPaymentsSection.Get(cfg).Retries; got != 7 {
+		t.Errorf("section Retries = %d, want 7", got)
+	}
+
+	t.Run("reload keeps flags", func(t *testing.T) {
+		// Arrange
+		r := NewReloader(path, cfg, DefaultSecrets(), WithFlags(fs))
+		if err := os.WriteFile(path, []byte("server:\n  http:\n    port: 8082\nlog:\n  format: text\n"), 0o600); err != nil {
+			t.Fatal(err)
+		}
+
+		// Act
+		err := r.Reload(context.Background())
+
+		// Assert
+		if err != nil {
+			t.Fatalf("Reload() error = %v", err)
+		}
+		if got := r.Current(); got.Log.Level != "debug" || got.Log.Format != "text" {
+			t.Errorf("Current() log = %q/%q, want debug/text", got.Log.Level, got.Log.Format)
+		}
+	})
+}
+
+func TestBindFlags_UnknownKey(t *testing.T) {
+	fs := flag.NewFlagSet("test", flag.ContinueOnError)
+	fs.SetOutput(io.Discard)
+	BindFlags(fs)
+
+	if err := fs.Parse([]string{"--server.http.prot=9000"}); err == nil {
+		t.Error("Parse() error = nil, want unknown flag error")
+	}
+}
@@ -1,44 +1,235 @@
 package config
 
 import (
+	"context"
+	"errors"
 	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
 	"strings"
 	"time"
 
 	"github.com/spf13/viper"
 )
 
-// Load reads configuration from file and environment variables.
-func Load(path string) (*Config, error) {
+// Load reads configuration from file, the remote store, environment
+// variables and flags (see WithFlags) and resolves secret references with
+// DefaultSecrets. Files are layered as described by Layers, the remote
+// document configured under remote is merged over them, and environment
+// variables and then flags override all. Values
+// may reference the environment as ${VAR} or ${VAR:-default}; write $${
+// for a literal "${".
+func Load(path string, opts ...Option) (*Config, error) {
+	return LoadWithSecrets(context.Background(), path, DefaultSecrets(), opts...)
+}
+
+// LoadWithSecrets is Load with a custom secret resolver. String values
+// that are references, e.g. database.password set to
+// "vault:secret/db#password", are replaced by the secret they point to;
+// keep secrets to rotate them later with Secrets.Watch. The result is
+// checked with Config.Validate before it is returned.
+func LoadWithSecrets(ctx context.Context, path string, secrets *Secrets, opts ...Option) (*Config, error) {
+	v, _, err := read(ctx, path, secrets, newOptions(opts))
+	if err != nil {
+		return nil, err
+	}
+
+	cfg, err := unmarshal(v)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := secrets.resolveAll(ctx, cfg); err != nil {
+		return nil, fmt.Errorf("resolve secrets: %w", err)
+	}
+
+	if err := cfg.Validate(); err != nil {
+		return nil, err
+	}
+
+	return cfg, nil
+}
+
+// unmarshal decodes Config and every registered section from v.
+func unmarshal(v *viper.Viper) (*Config, error) {
+	var cfg Config
+	if err := v.Unmarshal(&cfg, decodeHooks); err != nil {
+		return nil, fmt.Errorf("unmarshal config: %w", err)
+	}
+	cfg.sections = make(map[string]any)
+	for key, s := range registeredSections() {
+		val, err := s.decode(v)
+		if err != nil {
+			return nil, fmt.Errorf("unmarshal config: %w", err)
+		}
+		cfg.sections[key] = val
+	}
+	return &cfg, nil
+}
+
+// layer is one set of values merged over the defaults: a config file or
+// the remote document.
+type layer struct {
+	source string // "file:<path>" or "remote:<provider>:<key>"
+	values *viper.Viper
+}
+
+// read merges defaults, config file layers, the remote document,
+// environment variables and flags into a viper instance ready to unmarshal. It also
+// returns the app.env profile and the merged layers in order.
+func read(ctx context.Context, path string, secrets *Secrets, o options) (*viper.Viper, []layer, error) {
 	v := viper.New()
 
 	// Set defaults
 	setDefaults(v)
+	for _, s := range registeredSections() {
+		s.setDefaults(v)
+	}
 
-	// Read from config file if path provided
-	if path != "" {
-		v.SetConfigFile(path)
-		if err := v.ReadInConfig(); err != nil {
-			return nil, fmt.Errorf("read config file: %w", err)
-		}
+	// Command-line flags override everything else
+	if err := o.bindFlags(v); err != nil {
+		return nil, nil, fmt.Errorf("bind flags: %w", err)
 	}
 
-	// Read from environment variables
+	// Read from environment variables, including those in .env
+	if err := loadDotEnv(DotEnvFile); err != nil {
+		return nil, nil, fmt.Errorf("read %s: %w", DotEnvFile, err)
+	}
 	v.SetEnvPrefix("APP")
 	v.SetEnvKeyReplacer(strings.NewReplacer(".", "_"))
 	v.AutomaticEnv()
 
-	var cfg Config
-	if err := v.Unmarshal(&cfg); err != nil {
-		return nil, fmt.Errorf("unmarshal config: %w", err)
+	var layers []layer
+	merge := func(l layer) error {
+		expandEnv(l.values)
+		if err := v.MergeConfigMap(l.values.AllSettings()); err != nil {
+			return err
+		}
+		layers = append(layers, l)
+		return nil
 	}
 
-	return &cfg, nil
+	// Read from config file if path provided, then merge the environment
+	// and local overrides on top of it
+	if path != "" {
+		f, err := readFile(path)
+		if err != nil {
+			return nil, nil, fmt.Errorf("read config file: %w", err)
+		}
+		if err := merge(layer{source: "file:" + path, values: f}); err != nil {
+			return nil, nil, fmt.Errorf("read config file: %w", err)
+		}
+		for _, name := range Layers(path, v.GetString("app.env"))[1:] {
+			f, err := readFile(name)
+			if errors.Is(err, os.ErrNotExist) {
+				continue
+			}
+			if err == nil {
+				err = merge(layer{source: "file:" + name, values: f})
+			}
+			if err != nil {
+				return nil, nil, fmt.Errorf("merge config file %s: %w", name, err)
+			}
+		}
+	}
+
+	// Merge the central document over the files when remote.provider is set
+	remote, err := readRemote(ctx, v, secrets)
+	if err != nil {
+		return nil, nil, err
+	}
+	if remote != nil {
+		if err := merge(*remote); err != nil {
+			return nil, nil, fmt.Errorf("merge remote config: %w", err)
+		}
+	}
+
+	if o.strict {
+		if err := checkUnknown(layers); err != nil {
+			return nil, nil, err
+		}
+	}
+
+	// Preset defaults for the resolved app.env; every layer above still wins
+	if profile := applyProfile(v); profile != nil {
+		layers = append([]layer{*profile}, layers...)
+	}
+
+	return v, layers, nil
+}
+
+func readFile(path string) (*viper.Viper, error) {
+	f := viper.New()
+	f.SetConfigFile(path)
+	if err := f.ReadInConfig(); err != nil {
+		return nil, err
+	}
+	return f, nil
+}
+
+// Layers returns the files merged for the config file at path, in order:
+// the file itself, its environment override and a local override, e.g.
+// config.yaml, config.production.yaml and config.local.yaml for env
+// "production". Overrides are optional and only need the keys they change.
+func Layers(path, env string) []string {
+	ext := filepath.Ext(path)
+	stem := strings.TrimSuffix(path, ext)
+	layers := []string{path}
+	if env != "" {
+		layers = append(layers, stem+"."+env+ext)
+	}
+	return append(layers, stem+".local"+ext)
+}
+
+// envRef matches ${VAR} and ${VAR:-default}, with an optional leading $
+// escaping the reference.
+var envRef = regexp.MustCompile(`\$?\$\{([A-Za-z_][A-Za-z0-9_]*)(?::-([^}]*))?\}`)
+
+// expandEnv interpolates environment references in every string value,
+// including string list items. It runs on each layer before it is merged,
+// so non-string fields such as ports can be set from the environment too.
+func expandEnv(v *viper.Viper) {
+	for _, key := range v.AllKeys() {
+		switch val := v.Get(key).(type) {
+		case string:
+			if strings.Contains(val, "${") {
+				v.Set(key, expand(val))
+			}
+		case []any:
+			items := make([]any, len(val))
+			changed := false
+			for i, item := range val {
+				if s, ok := item.(string); ok && strings.Contains(s, "${") {
+					item, changed = expand(s), true
+				}
+				items[i] = item
+			}
+			if changed {
+				v.Set(key, items)
+			}
+		}
+	}
+}
+
+// expand replaces the references in s. Like the shell, an unset or empty
+// variable falls back to the default, or to "" without one.
+func expand(s string) string {
+	return envRef.ReplaceAllStringFunc(s, func(ref string) string {
+		if strings.HasPrefix(ref, "$$") {
+			return ref[1:]
+		}
+		m := envRef.FindStringSubmatch(ref)
+		if val := os.Getenv(m[1]); val != "" {
+			return val
+		}
+		return m[2]
+	})
 }
 
 // MustLoad loads configuration and panics on error.
-func MustLoad(path string) *Config {
-	cfg, err := Load(path)
+func MustLoad(path string, opts ...Option) *Config {
+	cfg, err := Load(path, opts...)
 	if err != nil {
 		panic(fmt.Sprintf("failed to load config: %v", err))
 	}
@@ -55,6 +246,16 @@ func setDefaults(v *viper.Viper) {
 	v.SetDefault("server.http.port", 8080)
 	v.SetDefault("server.http.read_timeout", 30*time.Second)
 	v.SetDefault("server.http.write_timeout", 30*time.Second)
+	v.SetDefault("server.http.max_body_size", "10MiB")
+	v.SetDefault("server.http.mode", "release")
+	v.SetDefault("server.http.mirror.upstream", "")
+	v.SetDefault("server.http.mirror.percentage", 0)
+	v.SetDefault("server.http.mirror.timeout", 5*time.Second)
+	v.SetDefault("server.http.mirror.max_in_flight", 64)
+	v.SetDefault("server.http.mirror.forward_headers", []string{})
+	v.SetDefault("server.http.admin_token", "")
+	v.SetDefault("server.http.graphql.playground", false)
+	v.SetDefault("server.http.graphql.introspection", false)
 
 	// gRPC server defaults
 	v.SetDefault("server.grpc.host", "0.0.0.0")
@@ -71,14 +272,119 @@ func setDefaults(v *viper.Viper) {
 	v.SetDefault("database.max_open_conns", 25)
 	v.SetDefault("database.max_idle_conns", 5)
 	v.SetDefault("database.conn_max_lifetime", 5*time.Minute)
+	v.SetDefault("database.conn_max_idle_time", time.Minute)
+	v.SetDefault("database.slow_query_threshold", 200*time.Millisecond)
+	v.SetDefault("database.auto_migrate", false)
+
+	// DynamoDB defaults
+	v.SetDefault("dynamodb.region", "us-east-1")
+	v.SetDefault("dynamodb.endpoint", "")
+	v.SetDefault("dynamodb.table", "go-ddd")
 
 	// Redis defaults
+	v.SetDefault("redis.mode", "standalone")
 	v.SetDefault("redis.host", "localhost")
 	v.SetDefault("redis.port", 6379)
 	v.SetDefault("redis.password", "")
 	v.SetDefault("redis.db", 0)
+	v.SetDefault("redis.dial_timeout", 5*time.Second)
+	v.SetDefault("redis.read_timeout", 3*time.Second)
+	v.SetDefault("redis.write_timeout", 3*time.Second)
+	v.SetDefault("redis.tls.enabled", false)
+
+	// Kafka defaults
+	v.SetDefault("kafka.brokers", []string{})
+	v.SetDefault("kafka.group_id", "go-ddd-worker")
+	v.SetDefault("kafka.topic_prefix", "")
+	v.SetDefault("kafka.dlq_suffix", ".dlq")
+	v.SetDefault("kafka.max_retries", 3)
+	v.SetDefault("kafka.retry_backoff", time.Second)
+	v.SetDefault("kafka.batch_timeout", 10*time.Millisecond)
+
+	// Outbox defaults
+	v.SetDefault("outbox.poll_interval", time.Second)
+	v.SetDefault("outbox.batch_size", 100)
+	v.SetDefault("outbox.max_attempts", 10)
+
+	// Pagination defaults
+	v.SetDefault("pagination.cursor_keys", []string{})
+	v.SetDefault("pagination.cursor_ttl", 0)
+
+	// Scheduler defaults
+	v.SetDefault("scheduler.jobs", map[string]string{})
+	v.SetDefault("scheduler.lock_ttl", 5*time.Minute)
+
+	// Storage defaults
+	v.SetDefault("storage.driver", "local")
+	v.SetDefault("storage.dir", "./data/objects")
+	v.SetDefault("storage.base_url", "http://localhost:8080/files")
+	v.SetDefault("storage.signing_key", "")
+	v.SetDefault("storage.url_ttl", 15*time.Minute)
+	v.SetDefault("storage.s3.bucket", "")
+	v.SetDefault("storage.s3.region", "us-east-1")
+	v.SetDefault("storage.s3.endpoint", "")
+	v.SetDefault("storage.s3.use_path_style", false)
+
+	// Mail defaults
+	v.SetDefault("mail.driver", "log")
+	v.SetDefault("mail.from", "no-reply@example.com")
+	v.SetDefault("mail.max_attempts", 3)
+	v.SetDefault("mail.retry_backoff", time.Second)
+	v.SetDefault("mail.smtp.host", "localhost")
+	v.SetDefault("mail.smtp.port", 587)
+	v.SetDefault("mail.smtp.username", "")
+	v.SetDefault("mail.smtp.password", "")
+	v.SetDefault("mail.ses.region", "us-east-1")
+
+	// Notify defaults
+	v.SetDefault("notify.twilio.account_sid", "")
+	v.SetDefault("notify.twilio.auth_token", "")
+	v.SetDefault("notify.twilio.from", "")
+	v.SetDefault("notify.slack.webhook_url", "")
+	v.SetDefault("notify.fcm.project_id", "")
+	v.SetDefault("notify.fcm.credentials_file", "")
+
+	// Task queue defaults
+	v.SetDefault("task_queue.concurrency", 10)
+	v.SetDefault("task_queue.queues", map[string]int{"default": 1})
+	v.SetDefault("task_queue.retry_base_delay", time.Second)
+	v.SetDefault("task_queue.retry_max_delay", 10*time.Minute)
+	v.SetDefault("task_queue.shutdown_timeout", 10*time.Second)
+
+	// Worker defaults
+	v.SetDefault("worker.host", "0.0.0.0")
+	v.SetDefault("worker.port", 8081)
+	v.SetDefault("worker.components.outbox", true)
+	v.SetDefault("worker.components.projections", true)
+	v.SetDefault("worker.components.sagas", false)
+	v.SetDefault("worker.components.scheduler", true)
+	v.SetDefault("worker.components.tasks", false)
+	v.SetDefault("worker.components.consumer", false)
+	v.SetDefault("worker.leader_election", false)
+
+	// OpenTelemetry defaults
+	v.SetDefault("otel.enabled", true)
+	v.SetDefault("otel.exporter", "noop")
+	v.SetDefault("otel.sample_rate", 1.0)
+	v.SetDefault("otel.otlp.endpoint", "localhost:4318")
+	v.SetDefault("otel.otlp.insecure", true)
+	v.SetDefault("otel.otlp.protocol", "http")
+
+	// Remote config defaults
+	v.SetDefault("remote.provider", "")
+	v.SetDefault("remote.endpoint", "")
+	v.SetDefault("remote.key", "")
+	v.SetDefault("remote.format", "yaml")
+	v.SetDefault("remote.token", "")
+	v.SetDefault("remote.refresh_interval", 30*time.Second)
 
 	// Log defaults
 	v.SetDefault("log.level", "info")
 	v.SetDefault("log.format", "json")
+	v.SetDefault("log.output", "stdout")
+	v.SetDefault("log.file.path", "")
+	v.SetDefault("log.file.max_size", "100MiB")
+	v.SetDefault("log.file.max_age", 0)
+	v.SetDefault("log.file.max_backups", 0)
+	v.SetDefault("log.file.compress", false)
 }
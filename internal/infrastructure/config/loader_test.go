@@ -0,0 +1,137 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"slices"
+	"testing"
+)
+
+func TestLayers(t *testing.T) {
+	tests := []struct {
+		path string
+		env  string
+		want []string
+	}{
+		{
+			path: "configs/config.yaml",
+			env:  "production",
+			want: []string{"configs/config.yaml", "configs/config.production.yaml", "configs/config.local.yaml"},
+		},
+		{
+			path: "app.json",
+			want: []string{"app.json", "app.local.json"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.path, func(t *testing.T) {
+			if got := Layers(tt.path, tt.env); !slices.Equal(got, tt.want) {
+				t.Errorf("Layers() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestLoad_Layers(t *testing.T) {
+	// Arrange
+	dir := t.TempDir()
+	files := map[string]string{
+		"config.yaml":            "app:\n  env: staging\nlog:\n  level: info\n  format: json\ndatabase:\n  host: db\n",
+		"config.staging.yaml":    "log:\n  level: warn\ndatabase:\n  host: staging-db\n",
+		"config.local.yaml":      "database:\n  host: localhost\n",
+		"config.production.yaml": "log:\n  level: error\nstorage:\n  signing_key: secret\n",
+	}
+	for name, data := range files {
+		if err := os.WriteFile(filepath.Join(dir, name), []byte(data), 0o600); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	// Act
+	cfg, err := Load(filepath.Join(dir, "config.yaml"))
+
+	// Assert
+	if err != nil {
+		t.Fatalf("Load() error = %v", err)
+	}
+	if cfg.Log.Level != "warn" {
+		t.Errorf("Log.Level = %q, want warn from the environment layer", cfg.Log.Level)
+	}
+	if cfg.Log.Format != "json" {
+		t.Errorf("Log.Format = %q, want json from the base file", cfg.Log.Format)
+	}
+	if cfg.Database.Host != "localhost" {
+		t.Errorf("Database.Host = %q, want localhost from the local layer", cfg.Database.Host)
+	}
+
+	t.Run("environment variable selects the layer", func(t *testing.T) {
+		// Arrange
+		t.Setenv("APP_APP_ENV", "production")
+
+		// Act
+		cfg, err := Load(filepath.Join(dir, "config.yaml"))
+
+		// Assert
+		if err != nil {
+			t.Fatalf("Load() error = %v", err)
+		}
+		if cfg.Log.Level != "error" {
+			t.Errorf("Log.Level = %q, want error from the production layer", cfg.Log.Level)
+		}
+	})
+}
+
+func TestExpand(t *testing.T) {
+	t.Setenv("TEST_DB_HOST", "db.internal")
+	t.Setenv("TEST_EMPTY", "")
+
+	tests := []struct {
+		in   string
+		want string
+	}{
+		{in: "${TEST_DB_HOST}", want: "db.internal"},
+		{in: "postgres://${TEST_DB_HOST}:5432", want: "postgres://db.internal:5432"},
+		{in: "${TEST_UNSET:-localhost}", want: "localhost"},
+		{in: "${TEST_EMPTY:-fallback}", want: "fallback"},
+		{in: "${TEST_DB_HOST:-localhost}", want: "db.internal"},
+		{in: "${TEST_UNSET}", want: ""},
+		{in: "$${TEST_DB_HOST}", want: "${TEST_DB_HOST}"},
+		{in: "env:TEST_DB_HOST", want: "env:TEST_DB_HOST"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.in, func(t *testing.T) {
+			if got := expand(tt.in); got != tt.want {
+				t.Errorf("expand(%q) = %q, want %q", tt.in, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestLoad_ExpandEnv(t *testing.T) {
+	// Arrange
+	t.Setenv("TEST_DB_HOST", "db.internal")
+	t.Setenv("TEST_DB_PORT", "6543")
+	t.Setenv("TEST_BROKER", "kafka-1:9092")
+	path := filepath.Join(t.TempDir(), "config.yaml")
+	data := "database:\n  host: ${TEST_DB_HOST}\n  port: ${TEST_DB_PORT:-5432}\n  name: ${TEST_DB_NAME:-orders}\n" +
+		"kafka:\n  brokers:\n    - ${TEST_BROKER}\n    - kafka-2:9092\n"
+	if err := os.WriteFile(path, []byte(data), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	// Act
+	cfg, err := Load(path)
+
+	// Assert
+	if err != nil {
+		t.Fatalf("Load() error = %v", err)
+	}
+	if cfg.Database.Host != "db.internal" || cfg.Database.Port != 6543 || cfg.Database.Name != "orders" {
+		t.Errorf("Database = %s:%d/%s, want db.internal:6543/orders", cfg.Database.Host, cfg.Database.Port, cfg.Database.Name)
+	}
+	if want := []string{"kafka-1:9092", "kafka-2:9092"}; !slices.Equal(cfg.Kafka.Brokers, want) {
+		t.Errorf("Kafka.Brokers = %v, want %v", cfg.Kafka.Brokers, want)
+	}
+}
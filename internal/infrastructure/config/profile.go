@@ -0,0 +1,46 @@
+package config
+
+import (
+	"strings"
+
+	"github.com/spf13/viper"
+)
+
+// profiles are the defaults preset for each app.env, applied over the
+// built-in defaults and under every file, remote, environment and flag
+// value. Environments without a profile (test, staging) keep the
+// built-in defaults.
+var profiles = map[string]map[string]any{
+	"development": {
+		"log.level":        "debug",
+		"log.format":       "text",
+		"server.http.mode": "debug",
+		"otel.exporter":    "stdout",
+
+		"server.http.graphql.playground":    true,
+		"server.http.graphql.introspection": true,
+	},
+	"production": {
+		"log.level":        "info",
+		"log.format":       "json",
+		"server.http.mode": "release",
+		"otel.exporter":    "otlp",
+	},
+}
+
+// applyProfile sets the profile defaults for the app.env resolved in v
+// and returns them as a layer for Describe, or nil when the environment
+// has no profile.
+func applyProfile(v *viper.Viper) *layer {
+	env := strings.ToLower(v.GetString("app.env"))
+	preset, ok := profiles[env]
+	if !ok {
+		return nil
+	}
+	values := viper.New()
+	for key, val := range preset {
+		v.SetDefault(key, val)
+		values.Set(key, val)
+	}
+	return &layer{source: "profile:" + env, values: values}
+}
@@ -0,0 +1,104 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestLoad_Profile(t *testing.T) {
+	tests := []struct {
+		name       string
+		data       string
+		wantLevel  string
+		wantFormat string
+		wantMode   string
+		wantExp    string
+		wantGQL    bool
+	}{
+		{
+			name:       "development",
+			data:       "app:\n  env: development\n",
+			wantLevel:  "debug",
+			wantFormat: "text",
+			wantMode:   "debug",
+			wantExp:    "stdout",
+			wantGQL:    true,
+		},
+		{
+			name:       "production",
+			data:       "app:\n  env: production\nstorage:\n  signing_key: secret\n",
+			wantLevel:  "info",
+			wantFormat: "json",
+			wantMode:   "release",
+			wantExp:    "otlp",
+		},
+		{
+			name:       "staging keeps built-in defaults",
+			data:       "app:\n  env: staging\n",
+			wantLevel:  "info",
+			wantFormat: "json",
+			wantMode:   "release",
+			wantExp:    "noop",
+		},
+		{
+			name:       "file overrides the profile",
+			data:       "app:\n  env: production\nstorage:\n  signing_key: secret\nlog:\n  level: warn\notel:\n  exporter: noop\n",
+			wantLevel:  "warn",
+			wantFormat: "json",
+			wantMode:   "release",
+			wantExp:    "noop",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Arrange
+			path := filepath.Join(t.TempDir(), "config.yaml")
+			if err := os.WriteFile(path, []byte(tt.data), 0o600); err != nil {
+				t.Fatal(err)
+			}
+
+			// Act
+			cfg, err := Load(path)
+
+			// Assert
+			if err != nil {
+				t.Fatalf("Load() error = %v", err)
+			}
+			if cfg.Log.Level != tt.wantLevel || cfg.Log.Format != tt.wantFormat {
+				t.Errorf("Log = %s/%s, want %s/%s", cfg.Log.Level, cfg.Log.Format, tt.wantLevel, tt.wantFormat)
+			}
+			if cfg.Server.HTTP.Mode != tt.wantMode {
+				t.Errorf("Server.HTTP.Mode = %q, want %q", cfg.Server.HTTP.Mode, tt.wantMode)
+			}
+			if cfg.Otel.Exporter != tt.wantExp {
+				t.Errorf("Otel.Exporter = %q, want %q", cfg.Otel.Exporter, tt.wantExp)
+			}
+			if gql := cfg.Server.HTTP.GraphQL; gql.Playground != tt.wantGQL || gql.Introspection != tt.wantGQL {
+				t.Errorf("Server.HTTP.GraphQL = %+v, want both %v", gql, tt.wantGQL)
+			}
+		})
+	}
+}
+
+func TestLoad_ProfileFromEnv(t *testing.T) {
+	// Arrange
+	t.Setenv("APP_APP_ENV", "production")
+	t.Setenv("APP_STORAGE_SIGNING_KEY", "secret")
+	t.Setenv("APP_LOG_FORMAT", "text")
+
+	// Act
+	cfg, err := Load("")
+
+	// Assert
+	if err != nil {
+		t.Fatalf("Load() error = %v", err)
+	}
+	if cfg.Log.Level != "info" || cfg.Server.HTTP.Mode != "release" {
+		t.Errorf("Log.Level = %q, Mode = %q, want production profile", cfg.Log.Level, cfg.Server.HTTP.Mode)
+	}
+	if cfg.Log.Format != "text" {
+		t.Errorf("Log.Format = %q, want text from the environment", cfg.Log.Format)
+	}
+}
@@ -0,0 +1,301 @@
+package config
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"path/filepath"
+	"reflect"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/fsnotify/fsnotify"
+
+	"github.com/blackhorseya/go-ddd/pkg/contextx"
+)
+
+// immutableKeys are settings bound at startup, such as listener addresses,
+// the tracer provider, the environment selecting the override layer and
+// the remote store itself; changing them requires a restart, so a reload
+// keeps the running values.
+var immutableKeys = []string{
+	"app.name",
+	"app.env",
+	"server.http.host",
+	"server.http.port",
+	"server.grpc.host",
+	"server.grpc.port",
+	"worker.port",
+	"otel",
+	"remote",
+}
+
+// Change describes an applied reload. Keys lists the dotted keys whose
+// values differ between Old and New, e.g. "log.level", sorted.
+type Change struct {
+	Old  *Config
+	New  *Config
+	Keys []string
+}
+
+// Has reports whether key or any key below it changed, e.g. Has("log")
+// is true when "log.level" changed.
+func (c Change) Has(key string) bool {
+	return slices.ContainsFunc(c.Keys, func(k string) bool { return under(k, key) })
+}
+
+type subscriber struct {
+	key string
+	fn  func(Change)
+}
+
+// Reloader re-reads the configuration file and remote document when they
+// change and notifies
+// subscribers of what changed. A file that fails to load or validate is
+// rejected and the current configuration stays in effect; changes to
+// immutable settings are ignored with a warning.
+type Reloader struct {
+	path    string
+	secrets *Secrets
+	opts    []Option
+
+	reloadMu sync.Mutex // serializes reloads and notifications
+	mu       sync.RWMutex
+	current  *Config
+	subs     []subscriber
+}
+
+// NewReloader creates a Reloader for the file at path, starting from cfg
+// as returned by Load. Secret references are resolved with secrets, and
+// opts should match the ones given to Load so flags keep applying.
+func NewReloader(path string, cfg *Config, secrets *Secrets, opts ...Option) *Reloader {
+	return &Reloader{path: path, secrets: secrets, opts: opts, current: cfg}
+}
+
+// Current returns the configuration in effect. It must not be modified.
+func (r *Reloader) Current() *Config {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	return r.current
+}
+
+// Subscribe calls fn after every reload that changes key or a key below
+// it; an empty key matches every change. Callbacks run sequentially on
+// the reloading goroutine, so they should return quickly.
+func (r *Reloader) Subscribe(key string, fn func(Change)) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.subs = append(r.subs, subscriber{key: key, fn: fn})
+}
+
+// Reload loads and validates the file and, if anything changed, makes it
+// current and notifies subscribers. An invalid file is returned as an
+// error and leaves the current configuration untouched.
+func (r *Reloader) Reload(ctx context.Context) error {
+	r.reloadMu.Lock()
+	defer r.reloadMu.Unlock()
+
+	next, err := LoadWithSecrets(ctx, r.path, r.secrets, r.opts...)
+	if err != nil {
+		return err
+	}
+
+	prev := r.Current()
+	before, after := leaves(prev), leaves(next)
+	var keys []string
+	for key, v := range after {
+		old, ok := before[key]
+		if ok && reflect.DeepEqual(v.Interface(), old.Interface()) {
+			continue
+		}
+		if ok && slices.ContainsFunc(immutableKeys, func(k string) bool { return under(key, k) }) {
+			contextx.From(ctx).Warn("config change requires a restart, ignored",
+				"key", key)
+			v.Set(old)
+			continue
+		}
+		keys = append(keys, key)
+	}
+	if len(keys) == 0 {
+		return nil
+	}
+	slices.Sort(keys)
+
+	r.mu.Lock()
+	r.current = next
+	subs := slices.Clone(r.subs)
+	r.mu.Unlock()
+
+	contextx.From(ctx).Info("config reloaded", "keys", keys)
+	change := Change{Old: prev, New: next, Keys: keys}
+	for _, s := range subs {
+		if s.key == "" || change.Has(s.key) {
+			s.fn(change)
+		}
+	}
+	return nil
+}
+
+// Run watches the file and its override layers, including ones not yet
+// created, and polls the remote store every remote.refresh_interval,
+// reloading on every change until ctx is done. The file watcher is closed
+// before Run returns.
+func (r *Reloader) Run(ctx context.Context) error {
+	remote := r.Current().Remote
+	if r.path == "" && remote.Provider == "" {
+		return errors.New("config reload needs a config file or remote store")
+	}
+	logger := contextx.From(ctx)
+	reload := func(source string) {
+		if ctx.Err() != nil {
+			return
+		}
+		if err := r.Reload(ctx); err != nil {
+			logger.Warn("config reload rejected, keeping current configuration",
+				"source", source, "error", err)
+		}
+	}
+
+	var (
+		w      *layerWatcher
+		events <-chan fsnotify.Event
+		errs   <-chan error
+	)
+	if r.path != "" {
+		layers := Layers(r.path, r.Current().App.Env)
+		var err error
+		if w, err = watchLayers(layers); err != nil {
+			return err
+		}
+		defer w.Close()
+		events, errs = w.Events, w.Errors
+		logger.Info("config watch started", "files", layers)
+	}
+
+	var tick <-chan time.Time
+	if remote.Provider != "" && remote.RefreshInterval > 0 {
+		logger.Info("remote config polling started",
+			"provider", remote.Provider, "key", remote.Key, "interval", remote.RefreshInterval)
+		ticker := time.NewTicker(remote.RefreshInterval)
+		defer ticker.Stop()
+		tick = ticker.C
+	}
+
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case ev, ok := <-events:
+			if !ok {
+				events = nil
+				continue
+			}
+			if layer, changed := w.changed(ev); changed {
+				reload(layer)
+			}
+		case err, ok := <-errs:
+			if !ok {
+				errs = nil
+				continue
+			}
+			logger.Warn("config watch error", "error", err)
+		case <-tick:
+			reload(remote.Provider + ":" + remote.Key)
+		}
+	}
+}
+
+// layerWatcher watches the directories holding the configuration layers,
+// so files created or replaced after startup are noticed, and remembers
+// where each layer resolves to so a swapped symlink, as Kubernetes does
+// for mounted ConfigMaps, counts as a change.
+type layerWatcher struct {
+	*fsnotify.Watcher
+	layers map[string]string // cleaned layer path to resolved path
+}
+
+func watchLayers(layers []string) (*layerWatcher, error) {
+	fw, err := fsnotify.NewWatcher()
+	if err != nil {
+		return nil, fmt.Errorf("config watch: %w", err)
+	}
+	w := &layerWatcher{Watcher: fw, layers: make(map[string]string, len(layers))}
+	for _, layer := range layers {
+		layer = filepath.Clean(layer)
+		w.layers[layer] = resolve(layer)
+		dir := filepath.Dir(layer)
+		if slices.Contains(fw.WatchList(), dir) {
+			continue
+		}
+		if err := fw.Add(dir); err != nil {
+			_ = fw.Close()
+			return nil, fmt.Errorf("config watch %s: %w", dir, err)
+		}
+	}
+	return w, nil
+}
+
+// changed reports the layer ev changed, if any: a write to or creation of
+// the layer itself, or any event after which the layer resolves to a
+// different file.
+func (w *layerWatcher) changed(ev fsnotify.Event) (string, bool) {
+	name := filepath.Clean(ev.Name)
+	if real, ok := w.layers[name]; ok && ev.Has(fsnotify.Write|fsnotify.Create) {
+		if cur := resolve(name); cur != real {
+			w.layers[name] = cur
+		}
+		return name, true
+	}
+	for layer, real := range w.layers {
+		if cur := resolve(layer); cur != real {
+			w.layers[layer] = cur
+			return layer, true
+		}
+	}
+	return "", false
+}
+
+// resolve returns the file path points to after following symlinks, or
+// "" when it does not exist.
+func resolve(path string) string {
+	real, err := filepath.EvalSymlinks(path)
+	if err != nil {
+		return ""
+	}
+	return real
+}
+
+// leaves maps every dotted key of cfg, including registered sections, to
+// its settable value; structs are flattened, while slices and maps are
+// compared as a whole.
+func leaves(cfg *Config) map[string]reflect.Value {
+	out := make(map[string]reflect.Value)
+	flatten(reflect.ValueOf(cfg).Elem(), "", out)
+	for key, val := range cfg.sections {
+		flatten(reflect.ValueOf(val).Elem(), key, out)
+	}
+	return out
+}
+
+func flatten(v reflect.Value, key string, out map[string]reflect.Value) {
+	if v.Kind() != reflect.Struct {
+		out[key] = v
+		return
+	}
+	t := v.Type()
+	for i := range t.NumField() {
+		f := t.Field(i)
+		if !f.IsExported() {
+			continue
+		}
+		name, _, _ := strings.Cut(f.Tag.Get("mapstructure"), ",")
+		flatten(v.Field(i), join(key, name), out)
+	}
+}
+
+// under reports whether key is prefix or a key below it.
+func under(key, prefix string) bool {
+	return key == prefix || strings.HasPrefix(key, prefix+".")
+}
@@ -0,0 +1,154 @@
+package config
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"slices"
+	"testing"
+	"time"
+
+	"go.uber.org/goleak"
+)
+
+func TestReloader_Reload(t *testing.T) {
+	// Arrange
+	path := filepath.Join(t.TempDir(), "config.yaml")
+	write := func(data string) {
+		t.Helper()
+		if err := os.WriteFile(path, []byte(data), 0o600); err != nil {
+			t.Fatal(err)
+		}
+	}
+	write("log:\n  level: info\nserver:\n  http:\n    port: 8080\n")
+	cfg, err := Load(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	r := NewReloader(path, cfg, DefaultSecrets())
+
+	var logChanges, allChanges []Change
+	r.Subscribe("log", func(c Change) { logChanges = append(logChanges, c) })
+	r.Subscribe("redis", func(Change) { t.Error("redis subscriber notified") })
+	r.Subscribe("", func(c Change) { allChanges = append(allChanges, c) })
+
+	// Act
+	write("log:\n  level: debug\n  format: json\nserver:\n  http:\n    port: 9999\n")
+	err = r.Reload(context.Background())
+
+	// Assert
+	if err != nil {
+		t.Fatalf("Reload() error = %v", err)
+	}
+	if len(logChanges) != 1 || len(allChanges) != 1 {
+		t.Fatalf("notifications = %d log, %d all, want 1 each", len(logChanges), len(allChanges))
+	}
+	if want := []string{"log.format", "log.level"}; !slices.Equal(logChanges[0].Keys, want) {
+		t.Errorf("Keys = %v, want %v", logChanges[0].Keys, want)
+	}
+	if logChanges[0].Old != cfg || logChanges[0].New != r.Current() {
+		t.Error("Change should carry the previous and current configuration")
+	}
+	if got := r.Current().Log.Level; got != "debug" {
+		t.Errorf("Current().Log.Level = %q, want debug", got)
+	}
+	if got := r.Current().Server.HTTP.Port; got != 8080 {
+		t.Errorf("Current().Server.HTTP.Port = %d, want immutable 8080", got)
+	}
+
+	t.Run("unchanged file does not notify", func(t *testing.T) {
+		// Act
+		err := r.Reload(context.Background())
+
+		// Assert
+		if err != nil || len(allChanges) != 1 {
+			t.Errorf("Reload() error = %v, notifications = %d, want nil, 1", err, len(allChanges))
+		}
+	})
+
+	t.Run("invalid file is rejected", func(t *testing.T) {
+		// Arrange
+		write("log:\n  level: verbose\n")
+
+		// Act
+		err := r.Reload(context.Background())
+
+		// Assert
+		if !errors.Is(err, ErrInvalid) {
+			t.Fatalf("Reload() error = %v, want ErrInvalid", err)
+		}
+		if got := r.Current().Log.Level; got != "debug" || len(allChanges) != 1 {
+			t.Errorf("Current().Log.Level = %q after rejected reload, want debug", got)
+		}
+	})
+}
+
+func TestChange_Has(t *testing.T) {
+	c := Change{Keys: []string{"log.level", "server.http.mirror.percentage"}}
+
+	tests := []struct {
+		key  string
+		want bool
+	}{
+		{key: "log", want: true},
+		{key: "log.level", want: true},
+		{key: "server.http.mirror", want: true},
+		{key: "server.http.mirror.timeout", want: false},
+		{key: "lo", want: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.key, func(t *testing.T) {
+			if got := c.Has(tt.key); got != tt.want {
+				t.Errorf("Has(%q) = %v, want %v", tt.key, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestReloader_Run(t *testing.T) {
+	defer goleak.VerifyNone(t, goleak.IgnoreCurrent())
+
+	// Arrange
+	path := filepath.Join(t.TempDir(), "config.yaml")
+	if err := os.WriteFile(path, []byte("log:\n  level: info\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	cfg, err := Load(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	r := NewReloader(path, cfg, DefaultSecrets())
+	changed := make(chan Change, 1)
+	r.Subscribe("log", func(c Change) { changed <- c })
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan error, 1)
+	go func() { done <- r.Run(ctx) }()
+
+	// Act
+	deadline := time.After(5 * time.Second)
+	var got Change
+	for got.New == nil {
+		// Rewrite until the watcher, which starts asynchronously, sees it.
+		if err := os.WriteFile(path, []byte("log:\n  level: debug\n"), 0o600); err != nil {
+			t.Fatal(err)
+		}
+		select {
+		case got = <-changed:
+		case <-time.After(50 * time.Millisecond):
+		case <-deadline:
+			t.Fatal("Run() did not reload the changed file")
+		}
+	}
+	cancel()
+
+	// Assert
+	if err := <-done; err != nil {
+		t.Errorf("Run() error = %v", err)
+	}
+	if got.New.Log.Level != "debug" {
+		t.Errorf("Change.New.Log.Level = %q, want debug", got.New.Log.Level)
+	}
+}
@@ -0,0 +1,178 @@
+package config
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/spf13/viper"
+)
+
+// ErrRemoteNotFound is returned when the remote key does not exist.
+var ErrRemoteNotFound = errors.New("remote config not found")
+
+// RemoteProvider fetches a configuration document stored under key in a
+// central store.
+type RemoteProvider interface {
+	Fetch(ctx context.Context, key string) ([]byte, error)
+}
+
+// NewRemoteProvider returns the provider configured by r, or nil when
+// remote configuration is disabled.
+func NewRemoteProvider(r Remote) (RemoteProvider, error) {
+	switch strings.ToLower(r.Provider) {
+	case "":
+		return nil, nil
+	case "consul":
+		return NewConsulProvider(r.Endpoint, r.Token), nil
+	case "etcd":
+		return NewEtcdProvider(r.Endpoint), nil
+	default:
+		return nil, fmt.Errorf("unsupported remote config provider: %s", r.Provider)
+	}
+}
+
+// readRemote fetches the document configured under remote in v, or
+// returns nil when remote configuration is disabled.
+func readRemote(ctx context.Context, v *viper.Viper, secrets *Secrets) (*layer, error) {
+	// Read keys individually: UnmarshalKey skips defaults of nested keys
+	r := Remote{
+		Provider: v.GetString("remote.provider"),
+		Endpoint: v.GetString("remote.endpoint"),
+		Key:      v.GetString("remote.key"),
+		Format:   v.GetString("remote.format"),
+		Token:    v.GetString("remote.token"),
+	}
+	if secrets.IsRef(r.Token) {
+		token, err := secrets.Resolve(ctx, r.Token)
+		if err != nil {
+			return nil, fmt.Errorf("remote.token: %w", err)
+		}
+		r.Token = token
+	}
+	provider, err := NewRemoteProvider(r)
+	if err != nil || provider == nil {
+		return nil, err
+	}
+
+	doc, err := provider.Fetch(ctx, r.Key)
+	if err != nil {
+		return nil, fmt.Errorf("fetch remote config %s: %w", r.Key, err)
+	}
+	values := viper.New()
+	values.SetConfigType(r.Format)
+	if err := values.ReadConfig(bytes.NewReader(doc)); err != nil {
+		return nil, fmt.Errorf("parse remote config %s: %w", r.Key, err)
+	}
+	return &layer{source: "remote:" + strings.ToLower(r.Provider) + ":" + r.Key, values: values}, nil
+}
+
+// ConsulProvider reads documents from the Consul KV store with
+// GET /v1/kv/<key>?raw.
+type ConsulProvider struct {
+	addr   string
+	token  string
+	client *http.Client
+}
+
+// NewConsulProvider creates a provider for the Consul agent at addr. token
+// is the ACL token and may be empty.
+func NewConsulProvider(addr, token string) *ConsulProvider {
+	return &ConsulProvider{
+		addr:   strings.TrimRight(addr, "/"),
+		token:  token,
+		client: &http.Client{Timeout: 10 * time.Second},
+	}
+}
+
+// Fetch implements RemoteProvider.
+func (p *ConsulProvider) Fetch(ctx context.Context, key string) ([]byte, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet,
+		p.addr+"/v1/kv/"+escapePath(strings.TrimPrefix(key, "/"))+"?raw", nil)
+	if err != nil {
+		return nil, err
+	}
+	if p.token != "" {
+		req.Header.Set("X-Consul-Token", p.token)
+	}
+
+	resp, err := p.client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("consul: %w", err)
+	}
+	defer resp.Body.Close()
+	switch {
+	case resp.StatusCode == http.StatusNotFound:
+		return nil, fmt.Errorf("%w: consul %s", ErrRemoteNotFound, key)
+	case resp.StatusCode != http.StatusOK:
+		return nil, fmt.Errorf("consul: status %d", resp.StatusCode)
+	}
+	return io.ReadAll(resp.Body)
+}
+
+// escapePath escapes each segment of a slash-separated key.
+func escapePath(key string) string {
+	segments := strings.Split(key, "/")
+	for i, s := range segments {
+		segments[i] = url.PathEscape(s)
+	}
+	return strings.Join(segments, "/")
+}
+
+// EtcdProvider reads documents from etcd v3 through its JSON gateway with
+// POST /v3/kv/range.
+type EtcdProvider struct {
+	addr   string
+	client *http.Client
+}
+
+// NewEtcdProvider creates a provider for the etcd endpoint at addr.
+func NewEtcdProvider(addr string) *EtcdProvider {
+	return &EtcdProvider{
+		addr:   strings.TrimRight(addr, "/"),
+		client: &http.Client{Timeout: 10 * time.Second},
+	}
+}
+
+// Fetch implements RemoteProvider.
+func (p *EtcdProvider) Fetch(ctx context.Context, key string) ([]byte, error) {
+	body, err := json.Marshal(map[string]string{"key": base64.StdEncoding.EncodeToString([]byte(key))})
+	if err != nil {
+		return nil, err
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, p.addr+"/v3/kv/range", bytes.NewReader(body))
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := p.client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("etcd: %w", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("etcd: status %d", resp.StatusCode)
+	}
+
+	var out struct {
+		KVs []struct {
+			Value []byte `json:"value"` // base64 in JSON
+		} `json:"kvs"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&out); err != nil {
+		return nil, fmt.Errorf("etcd: decode response: %w", err)
+	}
+	if len(out.KVs) == 0 {
+		return nil, fmt.Errorf("%w: etcd %s", ErrRemoteNotFound, key)
+	}
+	return out.KVs[0].Value, nil
+}
@@ -0,0 +1,129 @@
+package config
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"sync/atomic"
+	"testing"
+)
+
+func TestRemoteProviders_Fetch(t *testing.T) {
+	const doc = "log:\n  level: debug\n"
+	consul := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/v1/kv/config/app" || r.Header.Get("X-Consul-Token") != "acl" {
+			http.NotFound(w, r)
+			return
+		}
+		_, _ = w.Write([]byte(doc))
+	}))
+	defer consul.Close()
+	etcd := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var req struct {
+			Key []byte `json:"key"`
+		}
+		_ = json.NewDecoder(r.Body).Decode(&req)
+		if r.URL.Path != "/v3/kv/range" || string(req.Key) != "config/app" {
+			_, _ = w.Write([]byte(`{"header":{}}`))
+			return
+		}
+		_, _ = w.Write([]byte(`{"kvs":[{"value":"` + base64.StdEncoding.EncodeToString([]byte(doc)) + `"}]}`))
+	}))
+	defer etcd.Close()
+
+	tests := []struct {
+		name     string
+		provider RemoteProvider
+		key      string
+		wantErr  error
+	}{
+		{name: "consul", provider: NewConsulProvider(consul.URL, "acl"), key: "config/app"},
+		{name: "consul missing", provider: NewConsulProvider(consul.URL, "acl"), key: "config/other", wantErr: ErrRemoteNotFound},
+		{name: "etcd", provider: NewEtcdProvider(etcd.URL), key: "config/app"},
+		{name: "etcd missing", provider: NewEtcdProvider(etcd.URL), key: "config/other", wantErr: ErrRemoteNotFound},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Act
+			got, err := tt.provider.Fetch(context.Background(), tt.key)
+
+			// Assert
+			if !errors.Is(err, tt.wantErr) || (tt.wantErr == nil && err != nil) {
+				t.Fatalf("Fetch() error = %v, want %v", err, tt.wantErr)
+			}
+			if tt.wantErr == nil && string(got) != doc {
+				t.Errorf("Fetch() = %q, want %q", got, doc)
+			}
+		})
+	}
+}
+
+func TestLoad_Remote(t *testing.T) {
+	// Arrange
+	var doc atomic.Value
+	doc.Store("log:\n  level: warn\ndatabase:\n  host: central-db\n")
+	consul := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		_, _ = w.Write([]byte(doc.Load().(string)))
+	}))
+	defer consul.Close()
+
+	path := filepath.Join(t.TempDir(), "config.yaml")
+	data := "log:\n  level: info\n  format: text\nremote:\n  provider: consul\n  endpoint: " + consul.URL + "\n  key: config/app\n"
+	if err := os.WriteFile(path, []byte(data), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	// Act
+	cfg, err := Load(path)
+
+	// Assert
+	if err != nil {
+		t.Fatalf("Load() error = %v", err)
+	}
+	if cfg.Log.Level != "warn" || cfg.Database.Host != "central-db" {
+		t.Errorf("Load() = level %q, host %q, want remote warn, central-db", cfg.Log.Level, cfg.Database.Host)
+	}
+	if cfg.Log.Format != "text" {
+		t.Errorf("Log.Format = %q, want text from the file", cfg.Log.Format)
+	}
+
+	t.Run("reload picks up remote changes", func(t *testing.T) {
+		// Arrange
+		r := NewReloader(path, cfg, DefaultSecrets())
+		var keys []string
+		r.Subscribe("log", func(c Change) { keys = c.Keys })
+		doc.Store("log:\n  level: error\ndatabase:\n  host: central-db\n")
+
+		// Act
+		err := r.Reload(context.Background())
+
+		// Assert
+		if err != nil {
+			t.Fatalf("Reload() error = %v", err)
+		}
+		if len(keys) != 1 || keys[0] != "log.level" || r.Current().Log.Level != "error" {
+			t.Errorf("Reload() keys = %v, level = %q, want [log.level], error", keys, r.Current().Log.Level)
+		}
+	})
+
+	t.Run("describe attributes remote values", func(t *testing.T) {
+		// Act
+		settings, err := Describe(context.Background(), path, DefaultSecrets())
+
+		// Assert
+		if err != nil {
+			t.Fatalf("Describe() error = %v", err)
+		}
+		for _, s := range settings {
+			if s.Key == "database.host" && s.Source != "remote:consul:config/app" {
+				t.Errorf("database.host source = %q, want remote:consul:config/app", s.Source)
+			}
+		}
+	})
+}
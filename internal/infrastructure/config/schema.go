@@ -0,0 +1,117 @@
+package config
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"strings"
+	"time"
+
+	"github.com/spf13/viper"
+)
+
+// durationPattern matches Go duration strings such as "1h30m" or "250ms".
+const durationPattern = `^-?([0-9]+(\.[0-9]+)?(ns|us|µs|ms|s|m|h))+$`
+
+// Schema returns a JSON Schema (draft 2020-12) describing the config file,
+// including registered sections, with types, enum values and defaults.
+// Unknown keys are rejected so typos surface in editors and CI.
+func Schema() ([]byte, error) {
+	return schema(registeredSections())
+}
+
+func schema(sections map[string]section) ([]byte, error) {
+	v := viper.New()
+	setDefaults(v)
+	for _, s := range sections {
+		s.setDefaults(v)
+	}
+
+	root := typeSchema(reflect.TypeFor[Config](), "", v)
+	props := root["properties"].(map[string]any)
+	for key, s := range sections {
+		props[key] = typeSchema(s.typ(), key, v)
+	}
+	root["$schema"] = "https://json-schema.org/draft/2020-12/schema"
+	root["title"] = "go-ddd configuration"
+	root["$defs"] = map[string]any{
+		"duration": map[string]any{
+			"description": "Go duration such as 1h30m, or nanoseconds",
+			"anyOf": []any{
+				map[string]any{"type": "string", "pattern": durationPattern},
+				map[string]any{"type": "integer"},
+				map[string]any{"$ref": "#/$defs/interpolated"},
+			},
+		},
+		"size": map[string]any{
+			"description": "Size such as 10MB or 1GiB, or bytes",
+			"anyOf": []any{
+				map[string]any{"type": "string", "pattern": sizePattern},
+				map[string]any{"type": "integer", "minimum": 0},
+				map[string]any{"$ref": "#/$defs/interpolated"},
+			},
+		},
+		"interpolated": map[string]any{
+			"description": "Environment reference expanded at load time, e.g. ${PORT:-8080}",
+			"type":        "string",
+			"pattern":     `\$\{[A-Za-z_][A-Za-z0-9_]*(:-[^}]*)?\}`,
+		},
+	}
+
+	b, err := json.MarshalIndent(root, "", "  ")
+	if err != nil {
+		return nil, fmt.Errorf("marshal schema: %w", err)
+	}
+	return append(b, '\n'), nil
+}
+
+// typeSchema describes t, the type of key; defaults are taken from v.
+func typeSchema(t reflect.Type, key string, v *viper.Viper) map[string]any {
+	interpolated := map[string]any{"$ref": "#/$defs/interpolated"}
+	var s map[string]any
+	switch {
+	case t == reflect.TypeFor[time.Duration]():
+		s = map[string]any{"$ref": "#/$defs/duration"}
+	case t == reflect.TypeFor[ByteSize]():
+		s = map[string]any{"$ref": "#/$defs/size"}
+	case t.Kind() == reflect.Struct:
+		props := make(map[string]any)
+		for i := range t.NumField() {
+			f := t.Field(i)
+			if !f.IsExported() {
+				continue
+			}
+			name, _, _ := strings.Cut(f.Tag.Get("mapstructure"), ",")
+			props[name] = typeSchema(f.Type, join(key, name), v)
+		}
+		return map[string]any{"type": "object", "properties": props, "additionalProperties": false}
+	case t.Kind() == reflect.String:
+		s = map[string]any{"type": "string"}
+		if values, ok := enums[key]; ok {
+			s["enum"] = values
+		}
+	case t.Kind() == reflect.Bool:
+		s = map[string]any{"anyOf": []any{map[string]any{"type": "boolean"}, interpolated}}
+	case t.Kind() >= reflect.Int && t.Kind() <= reflect.Uint64:
+		s = map[string]any{"anyOf": []any{map[string]any{"type": "integer"}, interpolated}}
+	case t.Kind() == reflect.Float32 || t.Kind() == reflect.Float64:
+		s = map[string]any{"anyOf": []any{map[string]any{"type": "number"}, interpolated}}
+	case t.Kind() == reflect.Slice:
+		s = map[string]any{"type": "array", "items": typeSchema(t.Elem(), "", v)}
+	case t.Kind() == reflect.Map:
+		s = map[string]any{"type": "object", "additionalProperties": typeSchema(t.Elem(), "", v)}
+	default:
+		s = map[string]any{}
+	}
+
+	if key != "" {
+		if def := v.Get(key); def != nil {
+			if d, ok := def.(time.Duration); ok {
+				s["default"] = d.String()
+			} else {
+				s["default"] = def
+			}
+		}
+	}
+	return s
+}
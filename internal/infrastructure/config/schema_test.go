@@ -0,0 +1,74 @@
+package config
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"os"
+	"testing"
+)
+
+func TestSchema_Generated(t *testing.T) {
+	// Arrange
+	want, err := os.ReadFile("../../../configs/config.schema.json")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Act
+	got, err := schema(nil)
+
+	// Assert
+	if err != nil {
+		t.Fatalf("schema() error = %v", err)
+	}
+	if !bytes.Equal(got, want) {
+		t.Error("configs/config.schema.json is stale; run `task generate:config-schema`")
+	}
+}
+
+func TestSchema(t *testing.T) {
+	// Act
+	b, err := Schema()
+
+	// Assert
+	if err != nil {
+		t.Fatalf("Schema() error = %v", err)
+	}
+	var s struct {
+		Properties map[string]struct {
+			Properties           map[string]map[string]any `json:"properties"`
+			AdditionalProperties bool                      `json:"additionalProperties"`
+		} `json:"properties"`
+	}
+	if err := json.Unmarshal(b, &s); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		section string
+		key     string
+		field   string
+		want    string
+	}{
+		{section: "app", key: "env", field: "enum", want: "[development test staging production]"},
+		{section: "log", key: "level", field: "default", want: "info"},
+		{section: "outbox", key: "poll_interval", field: "default", want: "1s"},
+		{section: "outbox", key: "poll_interval", field: "$ref", want: "#/$defs/duration"},
+		{section: "test_payments", key: "retries", field: "default", want: "3"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.section+"."+tt.key, func(t *testing.T) {
+			section, ok := s.Properties[tt.section]
+			if !ok {
+				t.Fatalf("schema has no %s section", tt.section)
+			}
+			if section.AdditionalProperties {
+				t.Error("additionalProperties = true, want unknown keys rejected")
+			}
+			if got := fmt.Sprint(section.Properties[tt.key][tt.field]); got != tt.want {
+				t.Errorf("%s = %s, want %s", tt.field, got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,356 @@
+package config
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"os"
+	"reflect"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	awsconfig "github.com/aws/aws-sdk-go-v2/config"
+	"github.com/aws/aws-sdk-go-v2/service/secretsmanager"
+)
+
+// ErrSecretNotFound is returned when a secret reference cannot be resolved.
+var ErrSecretNotFound = errors.New("secret not found")
+
+// SecretProvider fetches the raw secret stored at path, e.g. the Vault path
+// "secret/db". Structured secrets are returned as a JSON object so a
+// reference can select one field.
+type SecretProvider interface {
+	Secret(ctx context.Context, path string) (string, error)
+}
+
+// SecretProviderFunc adapts a function to SecretProvider.
+type SecretProviderFunc func(ctx context.Context, path string) (string, error)
+
+// Secret calls f.
+func (f SecretProviderFunc) Secret(ctx context.Context, path string) (string, error) {
+	return f(ctx, path)
+}
+
+// Secrets resolves secret references of the form "<scheme>:<path>[#field]",
+// e.g. "vault:secret/db#password" or "env:DB_PASSWORD", through a chain of
+// providers keyed by scheme. Values are cached for a TTL so rotated
+// secrets are picked up by later resolutions and by Watch.
+type Secrets struct {
+	providers map[string]SecretProvider
+	ttl       time.Duration
+	now       func() time.Time
+
+	mu    sync.Mutex
+	cache map[string]cachedSecret
+}
+
+type cachedSecret struct {
+	value   string
+	expires time.Time
+}
+
+// NewSecrets creates a resolver for providers keyed by scheme. ttl bounds
+// how long a resolved value is reused; zero disables caching.
+func NewSecrets(ttl time.Duration, providers map[string]SecretProvider) *Secrets {
+	return &Secrets{
+		providers: providers,
+		ttl:       ttl,
+		now:       time.Now,
+		cache:     make(map[string]cachedSecret),
+	}
+}
+
+// DefaultSecrets resolves the "env", "file", "vault", "awssm" and "enc"
+// schemes with a five-minute cache. Vault is addressed by VAULT_ADDR and
+// VAULT_TOKEN; AWS Secrets Manager and KMS use the default AWS credential
+// chain; age-encrypted values are decrypted with CONFIG_AGE_KEY. These
+// variables may also come from the .env file.
+func DefaultSecrets() *Secrets {
+	_ = loadDotEnv(DotEnvFile) // errors are reported by Load
+	return NewSecrets(5*time.Minute, map[string]SecretProvider{
+		"env":   SecretProviderFunc(envSecret),
+		"file":  SecretProviderFunc(fileSecret),
+		"vault": NewVaultProvider(os.Getenv("VAULT_ADDR"), os.Getenv("VAULT_TOKEN")),
+		"awssm": &AWSSecretsProvider{},
+		"enc":   NewEncryptedProvider(os.Getenv("CONFIG_AGE_KEY")),
+	})
+}
+
+// IsRef reports whether v is a reference to a registered scheme. Other
+// values, such as "localhost:6379", are plain configuration.
+func (s *Secrets) IsRef(v string) bool {
+	scheme, _, ok := strings.Cut(v, ":")
+	if !ok {
+		return false
+	}
+	_, ok = s.providers[scheme]
+	return ok
+}
+
+// Resolve returns the secret a reference points to.
+func (s *Secrets) Resolve(ctx context.Context, ref string) (string, error) {
+	s.mu.Lock()
+	if c, ok := s.cache[ref]; ok && s.now().Before(c.expires) {
+		s.mu.Unlock()
+		return c.value, nil
+	}
+	s.mu.Unlock()
+
+	value, err := s.fetch(ctx, ref)
+	if err != nil {
+		return "", err
+	}
+	if s.ttl > 0 {
+		s.mu.Lock()
+		s.cache[ref] = cachedSecret{value: value, expires: s.now().Add(s.ttl)}
+		s.mu.Unlock()
+	}
+	return value, nil
+}
+
+func (s *Secrets) fetch(ctx context.Context, ref string) (string, error) {
+	scheme, rest, _ := strings.Cut(ref, ":")
+	provider, ok := s.providers[scheme]
+	if !ok {
+		return "", fmt.Errorf("unknown secret scheme %q", scheme)
+	}
+	path, field, hasField := strings.Cut(rest, "#")
+
+	raw, err := provider.Secret(ctx, path)
+	if err != nil {
+		return "", fmt.Errorf("resolve secret %s: %w", redact(ref), err)
+	}
+	if !hasField {
+		return raw, nil
+	}
+
+	var fields map[string]any
+	if err := json.Unmarshal([]byte(raw), &fields); err != nil {
+		return "", fmt.Errorf("resolve secret %s: not a JSON object", redact(ref))
+	}
+	v, ok := fields[field]
+	if !ok {
+		return "", fmt.Errorf("%w: %s", ErrSecretNotFound, redact(ref))
+	}
+	if str, ok := v.(string); ok {
+		return str, nil
+	}
+	b, _ := json.Marshal(v)
+	return string(b), nil
+}
+
+// Watch re-resolves ref every interval, bypassing the cache, and calls fn
+// with the new value whenever it changes, until ctx is done. Use it to
+// rotate credentials, e.g. to reset a connection pool's password.
+func (s *Secrets) Watch(ctx context.Context, ref string, interval time.Duration, fn func(value string)) {
+	last, _ := s.Resolve(ctx, ref)
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+		value, err := s.fetch(ctx, ref)
+		if err != nil || value == last {
+			continue
+		}
+		s.mu.Lock()
+		s.cache[ref] = cachedSecret{value: value, expires: s.now().Add(s.ttl)}
+		s.mu.Unlock()
+		last = value
+		fn(value)
+	}
+}
+
+// resolveAll replaces every string field of cfg and its registered
+// sections holding a reference with the secret it points to.
+func (s *Secrets) resolveAll(ctx context.Context, cfg *Config) error {
+	if err := s.walk(ctx, reflect.ValueOf(cfg).Elem(), ""); err != nil {
+		return err
+	}
+	for key, val := range cfg.sections {
+		if err := s.walk(ctx, reflect.ValueOf(val).Elem(), key); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (s *Secrets) walk(ctx context.Context, v reflect.Value, key string) error {
+	switch v.Kind() {
+	case reflect.Struct:
+		t := v.Type()
+		for i := range t.NumField() {
+			f := t.Field(i)
+			if !f.IsExported() {
+				continue
+			}
+			name, _, _ := strings.Cut(f.Tag.Get("mapstructure"), ",")
+			if err := s.walk(ctx, v.Field(i), join(key, name)); err != nil {
+				return err
+			}
+		}
+	case reflect.String:
+		if !s.IsRef(v.String()) {
+			return nil
+		}
+		secret, err := s.Resolve(ctx, v.String())
+		if err != nil {
+			return fmt.Errorf("%s: %w", key, err)
+		}
+		v.SetString(secret)
+	case reflect.Slice:
+		for i := range v.Len() {
+			if err := s.walk(ctx, v.Index(i), fmt.Sprintf("%s[%d]", key, i)); err != nil {
+				return err
+			}
+		}
+	case reflect.Map:
+		if v.Type().Elem().Kind() != reflect.String {
+			return nil
+		}
+		for _, k := range v.MapKeys() {
+			if !s.IsRef(v.MapIndex(k).String()) {
+				continue
+			}
+			secret, err := s.Resolve(ctx, v.MapIndex(k).String())
+			if err != nil {
+				return fmt.Errorf("%s: %w", join(key, fmt.Sprint(k)), err)
+			}
+			v.SetMapIndex(k, reflect.ValueOf(secret).Convert(v.Type().Elem()))
+		}
+	}
+	return nil
+}
+
+func join(prefix, name string) string {
+	if prefix == "" {
+		return name
+	}
+	return prefix + "." + name
+}
+
+// redact drops the field from a reference for error messages; the path
+// identifies the secret without hinting at its content.
+func redact(ref string) string {
+	before, _, _ := strings.Cut(ref, "#")
+	return before
+}
+
+// envSecret reads an environment variable, e.g. "env:DB_PASSWORD".
+func envSecret(_ context.Context, name string) (string, error) {
+	v, ok := os.LookupEnv(name)
+	if !ok {
+		return "", fmt.Errorf("%w: environment variable %s", ErrSecretNotFound, name)
+	}
+	return v, nil
+}
+
+// fileSecret reads a mounted secret file such as a Docker or Kubernetes
+// secret, e.g. "file:/run/secrets/db_password", without its trailing newline.
+func fileSecret(_ context.Context, path string) (string, error) {
+	b, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return "", fmt.Errorf("%w: file %s", ErrSecretNotFound, path)
+	}
+	if err != nil {
+		return "", err
+	}
+	return strings.TrimRight(string(b), "\r\n"), nil
+}
+
+// VaultProvider reads HashiCorp Vault KV version 2 secrets. The first path
+// segment is the mount: "secret/db" reads GET /v1/secret/data/db and
+// returns its data as a JSON object.
+type VaultProvider struct {
+	addr   string
+	token  string
+	client *http.Client
+}
+
+// NewVaultProvider creates a provider for the Vault server at addr.
+func NewVaultProvider(addr, token string) *VaultProvider {
+	return &VaultProvider{
+		addr:   strings.TrimRight(addr, "/"),
+		token:  token,
+		client: &http.Client{Timeout: 10 * time.Second},
+	}
+}
+
+// Secret implements SecretProvider.
+func (p *VaultProvider) Secret(ctx context.Context, path string) (string, error) {
+	if p.addr == "" {
+		return "", errors.New("vault: VAULT_ADDR not set")
+	}
+	mount, rest, ok := strings.Cut(path, "/")
+	if !ok {
+		return "", fmt.Errorf("vault: path %q has no mount", path)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, p.addr+"/v1/"+mount+"/data/"+rest, nil)
+	if err != nil {
+		return "", err
+	}
+	req.Header.Set("X-Vault-Token", p.token)
+
+	resp, err := p.client.Do(req)
+	if err != nil {
+		return "", fmt.Errorf("vault: %w", err)
+	}
+	defer resp.Body.Close()
+	switch {
+	case resp.StatusCode == http.StatusNotFound:
+		return "", fmt.Errorf("%w: vault %s", ErrSecretNotFound, path)
+	case resp.StatusCode != http.StatusOK:
+		return "", fmt.Errorf("vault: status %d", resp.StatusCode)
+	}
+
+	var body struct {
+		Data struct {
+			Data json.RawMessage `json:"data"`
+		} `json:"data"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&body); err != nil {
+		return "", fmt.Errorf("vault: decode response: %w", err)
+	}
+	return string(body.Data.Data), nil
+}
+
+// AWSSecretsProvider reads AWS Secrets Manager secrets by name or ARN,
+// e.g. "awssm:prod/db#password". The client is created on first use so
+// configurations without such references never load AWS credentials.
+type AWSSecretsProvider struct {
+	once   sync.Once
+	client *secretsmanager.Client
+	err    error
+}
+
+// Secret implements SecretProvider.
+func (p *AWSSecretsProvider) Secret(ctx context.Context, name string) (string, error) {
+	p.once.Do(func() {
+		cfg, err := awsconfig.LoadDefaultConfig(ctx)
+		if err != nil {
+			p.err = fmt.Errorf("load aws config: %w", err)
+			return
+		}
+		p.client = secretsmanager.NewFromConfig(cfg)
+	})
+	if p.err != nil {
+		return "", p.err
+	}
+
+	out, err := p.client.GetSecretValue(ctx, &secretsmanager.GetSecretValueInput{SecretId: aws.String(name)})
+	if err != nil {
+		return "", fmt.Errorf("secrets manager: %w", err)
+	}
+	if out.SecretString == nil {
+		return "", fmt.Errorf("secrets manager: %s has no string value", name)
+	}
+	return *out.SecretString, nil
+}
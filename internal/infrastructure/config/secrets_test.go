@@ -0,0 +1,142 @@
+package config
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestSecrets_Resolve(t *testing.T) {
+	t.Setenv("TEST_DB_PASSWORD", "from-env")
+	dir := t.TempDir()
+	file := filepath.Join(dir, "db_password")
+	if err := os.WriteFile(file, []byte("from-file\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	vault := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("X-Vault-Token") != "root" || r.URL.Path != "/v1/secret/data/db" {
+			http.NotFound(w, r)
+			return
+		}
+		_, _ = w.Write([]byte(`{"data":{"data":{"password":"from-vault","port":5432}}}`))
+	}))
+	defer vault.Close()
+
+	s := NewSecrets(time.Minute, map[string]SecretProvider{
+		"env":   SecretProviderFunc(envSecret),
+		"file":  SecretProviderFunc(fileSecret),
+		"vault": NewVaultProvider(vault.URL, "root"),
+	})
+
+	tests := []struct {
+		ref     string
+		want    string
+		wantErr error
+	}{
+		{ref: "env:TEST_DB_PASSWORD", want: "from-env"},
+		{ref: "file:" + file, want: "from-file"},
+		{ref: "vault:secret/db#password", want: "from-vault"},
+		{ref: "vault:secret/db#port", want: "5432"},
+		{ref: "vault:secret/db#missing", wantErr: ErrSecretNotFound},
+		{ref: "vault:secret/other#password", wantErr: ErrSecretNotFound},
+		{ref: "env:TEST_UNSET_SECRET", wantErr: ErrSecretNotFound},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.ref, func(t *testing.T) {
+			// Act
+			got, err := s.Resolve(context.Background(), tt.ref)
+
+			// Assert
+			if !errors.Is(err, tt.wantErr) || (tt.wantErr == nil && err != nil) {
+				t.Fatalf("Resolve() error = %v, want %v", err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("Resolve() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestSecrets_CacheAndWatch(t *testing.T) {
+	// Arrange
+	var mu sync.Mutex
+	value, calls := "v1", 0
+	set := func(v string) {
+		mu.Lock()
+		defer mu.Unlock()
+		value = v
+	}
+	now := time.Unix(1_700_000_000, 0)
+	s := NewSecrets(time.Minute, map[string]SecretProvider{
+		"test": SecretProviderFunc(func(context.Context, string) (string, error) {
+			mu.Lock()
+			defer mu.Unlock()
+			calls++
+			return value, nil
+		}),
+	})
+	s.now = func() time.Time { return now }
+	ctx := context.Background()
+
+	// Act
+	_, _ = s.Resolve(ctx, "test:key")
+	set("v2")
+	cached, _ := s.Resolve(ctx, "test:key")
+	now = now.Add(2 * time.Minute)
+	rotated, _ := s.Resolve(ctx, "test:key")
+
+	// Assert
+	if cached != "v1" || rotated != "v2" || calls != 2 {
+		t.Errorf("Resolve() = %q then %q after %d fetches, want v1 then v2 after 2", cached, rotated, calls)
+	}
+
+	watchCtx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	changed := make(chan string, 1)
+	go s.Watch(watchCtx, "test:key", time.Millisecond, func(v string) { changed <- v })
+	time.Sleep(5 * time.Millisecond)
+	set("v3")
+	select {
+	case got := <-changed:
+		if got != "v3" {
+			t.Errorf("Watch() = %q, want v3", got)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("Watch() did not report the rotated value")
+	}
+}
+
+func TestLoadWithSecrets(t *testing.T) {
+	// Arrange
+	path := filepath.Join(t.TempDir(), "config.yaml")
+	yaml := "database:\n  password: test:db#password\nkafka:\n  brokers: [localhost:9092]\n"
+	if err := os.WriteFile(path, []byte(yaml), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	s := NewSecrets(0, map[string]SecretProvider{
+		"test": SecretProviderFunc(func(context.Context, string) (string, error) {
+			return `{"password":"s3cret"}`, nil
+		}),
+	})
+
+	// Act
+	cfg, err := LoadWithSecrets(context.Background(), path, s)
+
+	// Assert
+	if err != nil {
+		t.Fatalf("LoadWithSecrets() error = %v", err)
+	}
+	if cfg.Database.Password != "s3cret" {
+		t.Errorf("database.password = %q, want resolved secret", cfg.Database.Password)
+	}
+	if len(cfg.Kafka.Brokers) != 1 || cfg.Kafka.Brokers[0] != "localhost:9092" {
+		t.Errorf("kafka.brokers = %v, want plain value kept", cfg.Kafka.Brokers)
+	}
+}
@@ -0,0 +1,125 @@
+package config
+
+import (
+	"fmt"
+	"maps"
+	"reflect"
+	"strings"
+	"sync"
+
+	"github.com/spf13/viper"
+)
+
+// section is the type-erased view of a Section used by the loader.
+type section interface {
+	typ() reflect.Type
+	setDefaults(v *viper.Viper)
+	decode(v *viper.Viper) (any, error)
+	check(value any) error
+}
+
+var (
+	sectionsMu sync.RWMutex
+	sections   = make(map[string]section)
+)
+
+// Section is a configuration block owned by a module instead of Config,
+// decoded from a top-level key into T. Modules declare it in a
+// package-level variable so it is registered before Load:
+//
+//	var paymentsConfig = config.Register("payments", PaymentsConfig{Timeout: 5 * time.Second}, nil)
+//
+//	cfg := paymentsConfig.Get(appConfig)
+//
+// Sections take part in everything Config does: defaults and environment
+// overrides (APP_PAYMENTS_TIMEOUT), layered files, secret references,
+// validation, hot reload and `config show`.
+type Section[T any] struct {
+	key      string
+	defaults T
+	validate func(T) error
+}
+
+// Register adds a section under key with its defaults and an optional
+// validator, whose error is reported by Config.Validate. key must be a
+// single top-level key not used by Config; Register panics otherwise or
+// if key is already registered.
+func Register[T any](key string, defaults T, validate func(T) error) *Section[T] {
+	if key == "" || strings.Contains(key, ".") {
+		panic(fmt.Sprintf("config: invalid section key %q", key))
+	}
+	if isConfigKey(key) {
+		panic(fmt.Sprintf("config: section key %q is used by Config", key))
+	}
+
+	sectionsMu.Lock()
+	defer sectionsMu.Unlock()
+	if _, ok := sections[key]; ok {
+		panic(fmt.Sprintf("config: section %q registered twice", key))
+	}
+	s := &Section[T]{key: key, defaults: defaults, validate: validate}
+	sections[key] = s
+	return s
+}
+
+// Get returns the section's value in cfg, or its defaults when cfg was not
+// returned by Load.
+func (s *Section[T]) Get(cfg *Config) T {
+	if p, ok := cfg.sections[s.key].(*T); ok {
+		return *p
+	}
+	return s.defaults
+}
+
+func (s *Section[T]) typ() reflect.Type {
+	return reflect.TypeFor[T]()
+}
+
+func (s *Section[T]) setDefaults(v *viper.Viper) {
+	defaults := s.defaults
+	out := make(map[string]reflect.Value)
+	flatten(reflect.ValueOf(&defaults).Elem(), s.key, out)
+	for key, val := range out {
+		v.SetDefault(key, val.Interface())
+	}
+}
+
+// decode unmarshals the section through a one-field wrapper struct so it
+// gets the same decoding (durations, sizes, weak typing) as Config.
+func (s *Section[T]) decode(v *viper.Viper) (any, error) {
+	wrapper := reflect.New(reflect.StructOf([]reflect.StructField{{
+		Name: "Section",
+		Type: reflect.TypeFor[T](),
+		Tag:  reflect.StructTag(`mapstructure:"` + s.key + `"`),
+	}}))
+	if err := v.Unmarshal(wrapper.Interface(), decodeHooks); err != nil {
+		return nil, fmt.Errorf("unmarshal %s: %w", s.key, err)
+	}
+	return wrapper.Elem().Field(0).Addr().Interface(), nil
+}
+
+func (s *Section[T]) check(value any) error {
+	if s.validate == nil {
+		return nil
+	}
+	return s.validate(*value.(*T))
+}
+
+// isConfigKey reports whether key is a top-level key of Config.
+func isConfigKey(key string) bool {
+	t := reflect.TypeFor[Config]()
+	for i := range t.NumField() {
+		name, _, _ := strings.Cut(t.Field(i).Tag.Get("mapstructure"), ",")
+		if name == key {
+			return true
+		}
+	}
+	return false
+}
+
+// registeredSections returns a snapshot of the registry.
+func registeredSections() map[string]section {
+	sectionsMu.RLock()
+	defer sectionsMu.RUnlock()
+	return maps.Clone(sections)
+}
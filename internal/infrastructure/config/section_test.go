@@ -0,0 +1,145 @@
+package config
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+type testPayments struct {
+	Provider string        `mapstructure:"provider"`
+	APIKey   string        `mapstructure:"api_key"`
+	Timeout  time.Duration `mapstructure:"timeout"`
+	Retries  int           `mapstructure:"retries"`
+}
+
+var testPaymentsSection = Register("test_payments", testPayments{Provider: "stripe", Timeout: 5 * time.Second, Retries: 3},
+	func(p testPayments) error {
+		if p.Retries < 0 {
+			return errors.New("retries must not be negative")
+		}
+		return nil
+	})
+
+func TestSection_Load(t *testing.T) {
+	// Arrange
+	t.Setenv("APP_TEST_PAYMENTS_RETRIES", "5")
+	t.Setenv("TEST_PAYMENTS_KEY", "sk_live")
+	path := filepath.Join(t.TempDir(), "config.yaml")
+	data := "test_payments:\n  timeout: 10s\n  api_key: env:TEST_PAYMENTS_KEY\n"
+	if err := os.WriteFile(path, []byte(data), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	// Act
+	cfg, err := Load(path)
+
+	// Assert
+	if err != nil {
+		t.Fatalf("Load() error = %v", err)
+	}
+	want := testPayments{Provider: "stripe", APIKey: "sk_live", Timeout: 10 * time.Second, Retries: 5}
+	if got := testPaymentsSection.Get(cfg); got != want {
+		t.Errorf("Get() = %+v, want %+v", got, want)
+	}
+
+	t.Run("validation", func(t *testing.T) {
+		// Arrange
+		t.Setenv("APP_TEST_PAYMENTS_RETRIES", "-1")
+
+		// Act
+		_, err := Load(path)
+
+		// Assert
+		if !errors.Is(err, ErrInvalid) || !strings.Contains(err.Error(), "test_payments: retries must not be negative") {
+			t.Errorf("Load() error = %v, want test_payments validation error", err)
+		}
+	})
+
+	t.Run("reload", func(t *testing.T) {
+		// Arrange
+		r := NewReloader(path, cfg, DefaultSecrets())
+		var keys []string
+		r.Subscribe("test_payments", func(c Change) { keys = c.Keys })
+		data := "test_payments:\n  timeout: 20s\n  api_key: env:TEST_PAYMENTS_KEY\n"
+		if err := os.WriteFile(path, []byte(data), 0o600); err != nil {
+			t.Fatal(err)
+		}
+
+		// Act
+		err := r.Reload(context.Background())
+
+		// Assert
+		if err != nil {
+			t.Fatalf("Reload() error = %v", err)
+		}
+		if len(keys) != 1 || keys[0] != "test_payments.timeout" {
+			t.Errorf("Change.Keys = %v, want [test_payments.timeout]", keys)
+		}
+		if got := testPaymentsSection.Get(r.Current()).Timeout; got != 20*time.Second {
+			t.Errorf("Get().Timeout = %s, want 20s", got)
+		}
+	})
+
+	t.Run("describe", func(t *testing.T) {
+		// Act
+		settings, err := Describe(context.Background(), path, DefaultSecrets())
+
+		// Assert
+		if err != nil {
+			t.Fatalf("Describe() error = %v", err)
+		}
+		found := 0
+		for _, s := range settings {
+			switch s.Key {
+			case "test_payments.api_key":
+				found++
+				if s.Value != "env:TEST_PAYMENTS_KEY" {
+					t.Errorf("api_key = %q, want the reference", s.Value)
+				}
+			case "test_payments.provider":
+				found++
+				if s.Source != "default" {
+					t.Errorf("provider source = %q, want default", s.Source)
+				}
+			}
+		}
+		if found != 2 {
+			t.Errorf("Describe() listed %d of 2 section settings", found)
+		}
+	})
+}
+
+func TestSection_Get_Defaults(t *testing.T) {
+	got := testPaymentsSection.Get(&Config{})
+
+	if got.Provider != "stripe" || got.Retries != 3 {
+		t.Errorf("Get() = %+v, want defaults", got)
+	}
+}
+
+func TestRegister_Panics(t *testing.T) {
+	tests := []struct {
+		name string
+		key  string
+	}{
+		{name: "config key", key: "database"},
+		{name: "nested key", key: "a.b"},
+		{name: "duplicate", key: "test_payments"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			defer func() {
+				if recover() == nil {
+					t.Errorf("Register(%q) did not panic", tt.key)
+				}
+			}()
+			Register(tt.key, struct{}{}, nil)
+		})
+	}
+}
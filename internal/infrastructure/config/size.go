@@ -0,0 +1,122 @@
+package config
+
+import (
+	"fmt"
+	"math"
+	"reflect"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/go-viper/mapstructure/v2"
+)
+
+// ByteSize is a size in bytes that config files may write as a plain
+// integer or a human-readable string such as "512KB", "10MB" or "1GiB".
+// Decimal units (KB, MB, GB, TB) are powers of 1000 and binary units
+// (KiB, MiB, GiB, TiB) powers of 1024; the "B" suffix is optional and
+// units are case-insensitive.
+type ByteSize int64
+
+// Common sizes.
+const (
+	Byte ByteSize = 1
+	KiB           = 1024 * Byte
+	MiB           = 1024 * KiB
+	GiB           = 1024 * MiB
+	TiB           = 1024 * GiB
+	KB            = 1000 * Byte
+	MB            = 1000 * KB
+	GB            = 1000 * MB
+	TB            = 1000 * GB
+)
+
+// sizeUnits lists the accepted suffixes, lower-cased, longest first so
+// "kib" is tried before "b".
+var sizeUnits = []struct {
+	suffix string
+	size   ByteSize
+}{
+	{"kib", KiB}, {"mib", MiB}, {"gib", GiB}, {"tib", TiB},
+	{"ki", KiB}, {"mi", MiB}, {"gi", GiB}, {"ti", TiB},
+	{"kb", KB}, {"mb", MB}, {"gb", GB}, {"tb", TB},
+	{"k", KB}, {"m", MB}, {"g", GB}, {"t", TB},
+	{"b", Byte},
+}
+
+// sizePattern matches the strings accepted by ParseByteSize.
+const sizePattern = `^\s*[0-9]+(\.[0-9]+)?\s*([KkMmGgTt]([Ii][Bb]?|[Bb])?|[Bb])?\s*$`
+
+// sizeNumber matches the number in front of the unit.
+var sizeNumber = regexp.MustCompile(`^[0-9]+(\.[0-9]+)?$`)
+
+// ParseByteSize parses a size such as "10MB", "1.5GiB" or "4096".
+func ParseByteSize(s string) (ByteSize, error) {
+	str := strings.ToLower(strings.TrimSpace(s))
+	unit := Byte
+	for _, u := range sizeUnits {
+		if num, ok := strings.CutSuffix(str, u.suffix); ok {
+			str, unit = strings.TrimSpace(num), u.size
+			break
+		}
+	}
+	if !sizeNumber.MatchString(str) {
+		return 0, fmt.Errorf("invalid size %q (want e.g. 10MB or 1GiB)", s)
+	}
+	n, err := strconv.ParseFloat(str, 64)
+	if err != nil {
+		return 0, fmt.Errorf("invalid size %q: %w", s, err)
+	}
+	size := n * float64(unit)
+	if size >= math.MaxInt64 {
+		return 0, fmt.Errorf("size %q overflows int64", s)
+	}
+	return ByteSize(size), nil
+}
+
+// String formats the size in the largest binary unit that divides it
+// exactly, e.g. "10MiB", falling back to bytes.
+func (b ByteSize) String() string {
+	for _, u := range []struct {
+		suffix string
+		size   ByteSize
+	}{{"TiB", TiB}, {"GiB", GiB}, {"MiB", MiB}, {"KiB", KiB}} {
+		if b != 0 && b%u.size == 0 {
+			return strconv.FormatInt(int64(b/u.size), 10) + u.suffix
+		}
+	}
+	return strconv.FormatInt(int64(b), 10) + "B"
+}
+
+// stringToByteSizeHook decodes size strings into ByteSize fields.
+func stringToByteSizeHook(from, to reflect.Type, data any) (any, error) {
+	if from.Kind() != reflect.String || to != reflect.TypeFor[ByteSize]() {
+		return data, nil
+	}
+	return ParseByteSize(data.(string))
+}
+
+// stringToDurationHook decodes duration strings such as "1h30m" with an
+// error that says what was expected.
+func stringToDurationHook(from, to reflect.Type, data any) (any, error) {
+	if from.Kind() != reflect.String || to != reflect.TypeFor[time.Duration]() {
+		return data, nil
+	}
+	d, err := time.ParseDuration(strings.TrimSpace(data.(string)))
+	if err != nil {
+		return nil, fmt.Errorf("invalid duration %q (want e.g. 30s or 1h30m)", data)
+	}
+	return d, nil
+}
+
+// decodeHooks runs the size and duration hooks ahead of viper's defaults.
+// mapstructure prefixes their errors with the offending key, e.g.
+// "'server.http.max_body_size' invalid size "10 parsecs"".
+func decodeHooks(c *mapstructure.DecoderConfig) {
+	c.DecodeHook = mapstructure.ComposeDecodeHookFunc(
+		stringToByteSizeHook,
+		stringToDurationHook,
+		c.DecodeHook,
+	)
+}
@@ -0,0 +1,133 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestParseByteSize(t *testing.T) {
+	tests := []struct {
+		in      string
+		want    ByteSize
+		wantErr bool
+	}{
+		{in: "4096", want: 4096},
+		{in: "512B", want: 512},
+		{in: "10MB", want: 10 * MB},
+		{in: "10mb", want: 10 * MB},
+		{in: "1GiB", want: GiB},
+		{in: "1.5 GiB", want: GiB + 512*MiB},
+		{in: "64Ki", want: 64 * KiB},
+		{in: "2k", want: 2 * KB},
+		{in: "", wantErr: true},
+		{in: "MB", wantErr: true},
+		{in: "-1MB", wantErr: true},
+		{in: "1e3", wantErr: true},
+		{in: "10 parsecs", wantErr: true},
+		{in: "99999999TB", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.in, func(t *testing.T) {
+			// Act
+			got, err := ParseByteSize(tt.in)
+
+			// Assert
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ParseByteSize(%q) error = %v, wantErr %v", tt.in, err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("ParseByteSize(%q) = %d, want %d", tt.in, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestByteSize_String(t *testing.T) {
+	tests := []struct {
+		in   ByteSize
+		want string
+	}{
+		{in: 0, want: "0B"},
+		{in: 1000, want: "1000B"},
+		{in: 10 * MiB, want: "10MiB"},
+		{in: 1536 * KiB, want: "1536KiB"},
+		{in: 2 * TiB, want: "2TiB"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.want, func(t *testing.T) {
+			if got := tt.in.String(); got != tt.want {
+				t.Errorf("String() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestLoad_HumanReadable(t *testing.T) {
+	// Arrange
+	path := filepath.Join(t.TempDir(), "config.yaml")
+	data := "server:\n  http:\n    max_body_size: 25MB\n    read_timeout: 1h30m\n"
+	if err := os.WriteFile(path, []byte(data), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	// Act
+	cfg, err := Load(path)
+
+	// Assert
+	if err != nil {
+		t.Fatalf("Load() error = %v", err)
+	}
+	if got := cfg.Server.HTTP.MaxBodySize; got != 25*MB {
+		t.Errorf("MaxBodySize = %d, want %d", got, 25*MB)
+	}
+	if got := cfg.Server.HTTP.ReadTimeout; got != 90*time.Minute {
+		t.Errorf("ReadTimeout = %s, want 1h30m", got)
+	}
+}
+
+func TestLoad_HumanReadableErrors(t *testing.T) {
+	tests := []struct {
+		name string
+		data string
+		want []string
+	}{
+		{
+			name: "size",
+			data: "server:\n  http:\n    max_body_size: 10 parsecs\n",
+			want: []string{"server.http.max_body_size", `invalid size "10 parsecs"`},
+		},
+		{
+			name: "duration",
+			data: "redis:\n  dial_timeout: 5 seconds\n",
+			want: []string{"redis.dial_timeout", `invalid duration "5 seconds"`},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Arrange
+			path := filepath.Join(t.TempDir(), "config.yaml")
+			if err := os.WriteFile(path, []byte(tt.data), 0o600); err != nil {
+				t.Fatal(err)
+			}
+
+			// Act
+			_, err := Load(path)
+
+			// Assert
+			if err == nil {
+				t.Fatal("Load() error = nil, want decode error")
+			}
+			for _, want := range tt.want {
+				if !strings.Contains(err.Error(), want) {
+					t.Errorf("Load() error = %q, want it to contain %q", err, want)
+				}
+			}
+		})
+	}
+}
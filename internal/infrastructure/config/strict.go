@@ -0,0 +1,90 @@
+package config
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+)
+
+// ErrUnknownKey is returned in strict mode for config keys that map to no
+// setting.
+var ErrUnknownKey = errors.New("unknown configuration key")
+
+// Strict makes Load fail when a config file or the remote document holds
+// keys that map to no setting, such as a misspelled "pagination.pagesize",
+// instead of ignoring them.
+func Strict() Option {
+	return func(o *options) {
+		o.strict = true
+	}
+}
+
+// checkUnknown returns an error wrapping ErrUnknownKey for every key in
+// the file and remote layers that is not a setting, with the closest
+// known key as a hint.
+func checkUnknown(layers []layer) error {
+	known := keys()
+	var errs []error
+	for _, l := range layers {
+		if !strings.HasPrefix(l.source, "file:") && !strings.HasPrefix(l.source, "remote:") {
+			continue
+		}
+		for _, key := range slices.Sorted(slices.Values(l.values.AllKeys())) {
+			if isKnown(key, known) {
+				continue
+			}
+			msg := fmt.Sprintf("%s: %s", l.source, key)
+			if hint := closest(key, known); hint != "" {
+				msg += fmt.Sprintf(" (did you mean %s?)", hint)
+			}
+			errs = append(errs, fmt.Errorf("%w: %s", ErrUnknownKey, msg))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// isKnown reports whether key is a setting, an entry of a map setting
+// such as scheduler.jobs.<name>, or an empty block holding settings.
+func isKnown(key string, known []string) bool {
+	for _, k := range known {
+		if key == k || strings.HasPrefix(key, k+".") || strings.HasPrefix(k, key+".") {
+			return true
+		}
+	}
+	return false
+}
+
+// closest returns the known key within two edits of key, ignoring
+// underscores, or "" when none is that close.
+func closest(key string, known []string) string {
+	best, bestDist := "", 3
+	norm := strings.ReplaceAll(key, "_", "")
+	for _, k := range known {
+		if d := distance(norm, strings.ReplaceAll(k, "_", "")); d < bestDist {
+			best, bestDist = k, d
+		}
+	}
+	return best
+}
+
+// distance returns the Levenshtein distance between a and b.
+func distance(a, b string) int {
+	prev := make([]int, len(b)+1)
+	cur := make([]int, len(b)+1)
+	for j := range prev {
+		prev[j] = j
+	}
+	for i := 1; i <= len(a); i++ {
+		cur[0] = i
+		for j := 1; j <= len(b); j++ {
+			cost := 1
+			if a[i-1] == b[j-1] {
+				cost = 0
+			}
+			cur[j] = min(prev[j]+1, cur[j-1]+1, prev[j-1]+cost)
+		}
+		prev, cur = cur, prev
+	}
+	return prev[len(b)]
+}
@@ -0,0 +1,68 @@
+package config
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestLoad_Strict(t *testing.T) {
+	tests := []struct {
+		name string
+		data string
+		want []string
+	}{
+		{
+			name: "known keys and map entries",
+			data: "log:\n  level: warn\nscheduler:\n  jobs:\n    cleanup: \"@daily\"\nserver:\n  http:\n    mirror: {}\n",
+		},
+		{
+			name: "typo with hint",
+			data: "log:\n  levl: warn\nserver:\n  http:\n    readtimeout: 5s\n",
+			want: []string{
+				"log.levl (did you mean log.level?)",
+				"server.http.readtimeout (did you mean server.http.read_timeout?)",
+			},
+		},
+		{
+			name: "unrelated key",
+			data: "feature_flags:\n  beta: true\n",
+			want: []string{"file:", "feature_flags.beta"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Arrange
+			path := filepath.Join(t.TempDir(), "config.yaml")
+			if err := os.WriteFile(path, []byte(tt.data), 0o600); err != nil {
+				t.Fatal(err)
+			}
+
+			// Act
+			_, err := Load(path, Strict())
+			_, lenient := Load(path)
+
+			// Assert
+			if lenient != nil {
+				t.Fatalf("Load() without Strict error = %v", lenient)
+			}
+			if len(tt.want) == 0 {
+				if err != nil {
+					t.Fatalf("Load() error = %v", err)
+				}
+				return
+			}
+			if !errors.Is(err, ErrUnknownKey) {
+				t.Fatalf("Load() error = %v, want ErrUnknownKey", err)
+			}
+			for _, want := range tt.want {
+				if !strings.Contains(err.Error(), want) {
+					t.Errorf("Load() error = %q, want it to contain %q", err, want)
+				}
+			}
+		})
+	}
+}
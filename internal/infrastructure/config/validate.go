@@ -0,0 +1,300 @@
+package config
+
+import (
+	"errors"
+	"fmt"
+	"maps"
+	"net/url"
+	"slices"
+	"strings"
+	"time"
+)
+
+// ErrInvalid is wrapped by every error returned by Validate.
+var ErrInvalid = errors.New("invalid configuration")
+
+// Accepted enum values.
+var (
+	envs          = []string{"development", "test", "staging", "production"}
+	dbDrivers     = []string{"postgres", "mysql"}
+	redisModes    = []string{"standalone", "sentinel", "cluster"}
+	logLevels     = []string{"debug", "info", "warn", "warning", "error"}
+	logFormats    = []string{"json", "text"}
+	ginModes      = []string{"debug", "release", "test"}
+	logOutputs    = []string{"", "stdout", "stderr", "file"}
+	mailDrivers   = []string{"log", "smtp", "ses"}
+	remotes       = []string{"", "consul", "etcd"}
+	remoteFormats = []string{"yaml", "json"}
+	otelExporters = []string{"", "noop", "stdout", "otlp"}
+	otlpProtocols = []string{"", "http", "grpc"}
+	storageDriver = []string{"local", "s3"}
+)
+
+// enums maps enum keys to their accepted values for Schema.
+var enums = map[string][]string{
+	"app.env":            envs,
+	"server.http.mode":   ginModes,
+	"database.driver":    dbDrivers,
+	"redis.mode":         redisModes,
+	"log.level":          logLevels,
+	"log.format":         logFormats,
+	"log.output":         logOutputs,
+	"mail.driver":        mailDrivers,
+	"storage.driver":     storageDriver,
+	"remote.provider":    remotes,
+	"remote.format":      remoteFormats,
+	"otel.exporter":      otelExporters,
+	"otel.otlp.protocol": otlpProtocols,
+}
+
+// validator collects problems so Validate reports them all at once.
+type validator struct {
+	errs []error
+}
+
+func (v *validator) addf(field, format string, args ...any) {
+	v.errs = append(v.errs, fmt.Errorf("%w: %s: %s", ErrInvalid, field, fmt.Sprintf(format, args...)))
+}
+
+func (v *validator) required(field, value string) {
+	if strings.TrimSpace(value) == "" {
+		v.addf(field, "is required")
+	}
+}
+
+func (v *validator) oneOf(field, value string, allowed []string) {
+	if !slices.Contains(allowed, strings.ToLower(value)) {
+		v.addf(field, "must be one of %s, got %q", strings.Join(allowed, ", "), value)
+	}
+}
+
+func (v *validator) port(field string, value int) {
+	if value < 1 || value > 65535 {
+		v.addf(field, "must be between 1 and 65535, got %d", value)
+	}
+}
+
+func (v *validator) positive(field string, value time.Duration) {
+	if value <= 0 {
+		v.addf(field, "must be positive, got %s", value)
+	}
+}
+
+func (v *validator) nonNegative(field string, value time.Duration) {
+	if value < 0 {
+		v.addf(field, "must not be negative, got %s", value)
+	}
+}
+
+func (v *validator) atLeast(field string, value, low int) {
+	if value < low {
+		v.addf(field, "must be at least %d, got %d", low, value)
+	}
+}
+
+func (v *validator) url(field, value string) {
+	u, err := url.Parse(value)
+	if err != nil || u.Scheme == "" || u.Host == "" {
+		v.addf(field, "must be an absolute URL, got %q", value)
+	}
+}
+
+// RequirePostgres returns an error unless database.driver selects
+// PostgreSQL. The service and worker keep export jobs, the outbox,
+// projection checkpoints, sagas and idempotency keys in PostgreSQL-only
+// tables, so both call it at startup; MySQL serves the GORM repositories
+// and the seed command only.
+func (c *Config) RequirePostgres() error {
+	if d := strings.ToLower(c.Database.Driver); d != "" && d != "postgres" {
+		return fmt.Errorf("%w: database.driver: the service and worker require postgres, got %q", ErrInvalid, c.Database.Driver)
+	}
+	return nil
+}
+
+// Validate checks ranges, enum values and the settings required by enabled
+// features, returning every problem joined into one error. Each wraps
+// ErrInvalid and names the offending key, e.g.
+// "invalid configuration: server.http.port: must be between 1 and 65535, got 0".
+func (c *Config) Validate() error {
+	v := &validator{}
+
+	v.required("app.name", c.App.Name)
+	v.oneOf("app.env", c.App.Env, envs)
+
+	v.port("server.http.port", c.Server.HTTP.Port)
+	v.positive("server.http.read_timeout", c.Server.HTTP.ReadTimeout)
+	v.positive("server.http.write_timeout", c.Server.HTTP.WriteTimeout)
+	v.oneOf("server.http.mode", c.Server.HTTP.Mode, ginModes)
+	if c.Server.HTTP.MaxBodySize < 0 {
+		v.addf("server.http.max_body_size", "must not be negative, got %d", c.Server.HTTP.MaxBodySize)
+	}
+	if m := c.Server.HTTP.Mirror; m.Upstream != "" {
+		v.url("server.http.mirror.upstream", m.Upstream)
+		v.positive("server.http.mirror.timeout", m.Timeout)
+		v.atLeast("server.http.mirror.max_in_flight", m.MaxInFlight, 0)
+		if m.Percentage < 0 || m.Percentage > 100 {
+			v.addf("server.http.mirror.percentage", "must be between 0 and 100, got %g", m.Percentage)
+		}
+	}
+	v.port("server.grpc.port", c.Server.GRPC.Port)
+
+	c.Database.validate(v)
+	c.Redis.validate(v)
+	c.Log.validate(v)
+	c.Mail.validate(v)
+	c.Storage.validate(v, c.IsProduction())
+
+	c.Remote.validate(v)
+	c.Otel.validate(v)
+
+	if len(c.Kafka.Brokers) > 0 {
+		v.required("kafka.group_id", c.Kafka.GroupID)
+		v.atLeast("kafka.max_retries", c.Kafka.MaxRetries, 0)
+		v.nonNegative("kafka.retry_backoff", c.Kafka.RetryBackoff)
+	}
+	v.nonNegative("pagination.cursor_ttl", c.Pagination.CursorTTL)
+
+	w := c.Worker
+	v.port("worker.port", w.Port)
+	if w.Components.Outbox {
+		v.positive("outbox.poll_interval", c.Outbox.PollInterval)
+		v.atLeast("outbox.batch_size", c.Outbox.BatchSize, 1)
+		v.atLeast("outbox.max_attempts", c.Outbox.MaxAttempts, 1)
+	}
+	if w.Components.Scheduler || w.LeaderElection {
+		v.positive("scheduler.lock_ttl", c.Scheduler.LockTTL)
+	}
+	if w.Components.Tasks {
+		v.atLeast("task_queue.concurrency", c.TaskQueue.Concurrency, 1)
+		if len(c.TaskQueue.Queues) == 0 {
+			v.addf("task_queue.queues", "is required when worker.components.tasks is enabled")
+		}
+	}
+	if w.Components.Consumer && len(c.Kafka.Brokers) == 0 {
+		v.addf("kafka.brokers", "is required when worker.components.consumer is enabled")
+	}
+
+	sections := registeredSections()
+	for _, key := range slices.Sorted(maps.Keys(c.sections)) {
+		if s, ok := sections[key]; ok {
+			if err := s.check(c.sections[key]); err != nil {
+				v.errs = append(v.errs, fmt.Errorf("%w: %s: %w", ErrInvalid, key, err))
+			}
+		}
+	}
+
+	return errors.Join(v.errs...)
+}
+
+func (d Database) validate(v *validator) {
+	v.oneOf("database.driver", d.Driver, dbDrivers)
+	v.required("database.host", d.Host)
+	v.port("database.port", d.Port)
+	v.required("database.name", d.Name)
+	v.atLeast("database.max_open_conns", d.MaxOpenConns, 0)
+	v.atLeast("database.max_idle_conns", d.MaxIdleConns, 0)
+	if d.MaxOpenConns > 0 && d.MaxIdleConns > d.MaxOpenConns {
+		v.addf("database.max_idle_conns", "must not exceed max_open_conns (%d), got %d", d.MaxOpenConns, d.MaxIdleConns)
+	}
+	v.nonNegative("database.conn_max_lifetime", d.ConnMaxLifetime)
+	v.nonNegative("database.conn_max_idle_time", d.ConnMaxIdleTime)
+	v.nonNegative("database.slow_query_threshold", d.SlowQueryThreshold)
+}
+
+func (r Redis) validate(v *validator) {
+	v.oneOf("redis.mode", r.Mode, redisModes)
+	switch strings.ToLower(r.Mode) {
+	case "sentinel":
+		v.required("redis.master_name", r.MasterName)
+		if len(r.Addrs) == 0 {
+			v.addf("redis.addrs", "is required in sentinel mode")
+		}
+	case "cluster":
+		if len(r.Addrs) == 0 && r.Host == "" {
+			v.addf("redis.addrs", "is required in cluster mode")
+		}
+	default:
+		if len(r.Addrs) == 0 {
+			v.required("redis.host", r.Host)
+			v.port("redis.port", r.Port)
+		}
+	}
+	v.atLeast("redis.db", r.DB, 0)
+	v.atLeast("redis.pool_size", r.PoolSize, 0)
+	v.nonNegative("redis.dial_timeout", r.DialTimeout)
+	v.nonNegative("redis.read_timeout", r.ReadTimeout)
+	v.nonNegative("redis.write_timeout", r.WriteTimeout)
+}
+
+func (l LogConfig) validate(v *validator) {
+	v.oneOf("log.level", l.Level, logLevels)
+	v.oneOf("log.format", l.Format, logFormats)
+	v.oneOf("log.output", l.Output, logOutputs)
+	if strings.EqualFold(l.Output, "file") {
+		v.required("log.file.path", l.File.Path)
+		if l.File.MaxSize < 0 {
+			v.addf("log.file.max_size", "must not be negative, got %d", l.File.MaxSize)
+		}
+		v.nonNegative("log.file.max_age", l.File.MaxAge)
+		v.atLeast("log.file.max_backups", l.File.MaxBackups, 0)
+	}
+}
+
+func (m Mail) validate(v *validator) {
+	v.oneOf("mail.driver", m.Driver, mailDrivers)
+	v.required("mail.from", m.From)
+	v.atLeast("mail.max_attempts", m.MaxAttempts, 1)
+	v.nonNegative("mail.retry_backoff", m.RetryBackoff)
+	switch strings.ToLower(m.Driver) {
+	case "smtp":
+		v.required("mail.smtp.host", m.SMTP.Host)
+		v.port("mail.smtp.port", m.SMTP.Port)
+	case "ses":
+		v.required("mail.ses.region", m.SES.Region)
+	}
+}
+
+func (s Storage) validate(v *validator, production bool) {
+	v.oneOf("storage.driver", s.Driver, storageDriver)
+	v.positive("storage.url_ttl", s.URLTTL)
+	switch strings.ToLower(s.Driver) {
+	case "local":
+		v.required("storage.dir", s.Dir)
+		v.url("storage.base_url", s.BaseURL)
+		// An empty key lets anyone forge download URLs
+		if production {
+			v.required("storage.signing_key", s.SigningKey)
+		}
+	case "s3":
+		v.required("storage.s3.bucket", s.S3.Bucket)
+		v.required("storage.s3.region", s.S3.Region)
+		if s.S3.Endpoint != "" {
+			v.url("storage.s3.endpoint", s.S3.Endpoint)
+		}
+	}
+}
+
+func (r Remote) validate(v *validator) {
+	v.oneOf("remote.provider", r.Provider, remotes)
+	if r.Provider == "" {
+		return
+	}
+	v.url("remote.endpoint", r.Endpoint)
+	v.required("remote.key", r.Key)
+	v.oneOf("remote.format", r.Format, remoteFormats)
+	v.nonNegative("remote.refresh_interval", r.RefreshInterval)
+}
+
+func (o Otel) validate(v *validator) {
+	if !o.Enabled {
+		return
+	}
+	v.oneOf("otel.exporter", o.Exporter, otelExporters)
+	if o.SampleRate < 0 || o.SampleRate > 1 {
+		v.addf("otel.sample_rate", "must be between 0 and 1, got %g", o.SampleRate)
+	}
+	if o.Exporter == "otlp" {
+		v.required("otel.otlp.endpoint", o.OTLP.Endpoint)
+		v.oneOf("otel.otlp.protocol", o.OTLP.Protocol, otlpProtocols)
+	}
+}
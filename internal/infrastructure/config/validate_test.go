@@ -0,0 +1,172 @@
+package config
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func validConfig(t *testing.T) *Config {
+	t.Helper()
+	cfg, err := Load("")
+	if err != nil {
+		t.Fatalf("Load() defaults error = %v", err)
+	}
+	return cfg
+}
+
+func TestConfig_Validate(t *testing.T) {
+	tests := []struct {
+		name   string
+		modify func(*Config)
+		want   []string
+	}{
+		{name: "defaults", modify: func(*Config) {}},
+		{
+			name:   "port out of range",
+			modify: func(c *Config) { c.Server.HTTP.Port = 0 },
+			want:   []string{"server.http.port: must be between 1 and 65535, got 0"},
+		},
+		{
+			name:   "negative timeout",
+			modify: func(c *Config) { c.Redis.DialTimeout = -1 },
+			want:   []string{"redis.dial_timeout: must not be negative"},
+		},
+		{
+			name: "unknown enums",
+			modify: func(c *Config) {
+				c.App.Env = "prod"
+				c.Database.Driver = "sqlite"
+				c.Log.Level = "trace"
+			},
+			want: []string{"app.env", "database.driver", "log.level"},
+		},
+		{
+			name:   "consumer without brokers",
+			modify: func(c *Config) { c.Worker.Components.Consumer = true; c.Kafka.Brokers = nil },
+			want:   []string{"kafka.brokers: is required when worker.components.consumer is enabled"},
+		},
+		{
+			name:   "s3 without bucket",
+			modify: func(c *Config) { c.Storage.Driver = "s3"; c.Storage.S3.Bucket = "" },
+			want:   []string{"storage.s3.bucket: is required"},
+		},
+		{
+			name:   "smtp without host",
+			modify: func(c *Config) { c.Mail.Driver = "smtp"; c.Mail.SMTP.Host = "" },
+			want:   []string{"mail.smtp.host: is required"},
+		},
+		{
+			name:   "sentinel without master",
+			modify: func(c *Config) { c.Redis.Mode = "sentinel"; c.Redis.Addrs = []string{"localhost:26379"} },
+			want:   []string{"redis.master_name: is required"},
+		},
+		{
+			name: "otel exporter",
+			modify: func(c *Config) {
+				c.Otel.Exporter = "otlp"
+				c.Otel.OTLP.Protocol = "thrift"
+				c.Otel.SampleRate = 2
+			},
+			want: []string{"otel.otlp.protocol", "otel.sample_rate: must be between 0 and 1, got 2"},
+		},
+		{
+			name: "mirror percentage",
+			modify: func(c *Config) {
+				c.Server.HTTP.Mirror.Upstream = "http://shadow:8080"
+				c.Server.HTTP.Mirror.Percentage = 150
+			},
+			want: []string{"server.http.mirror.percentage: must be between 0 and 100"},
+		},
+		{
+			name: "log file",
+			modify: func(c *Config) {
+				c.Log.Output = "file"
+				c.Log.File.MaxBackups = -1
+			},
+			want: []string{"log.file.path: is required", "log.file.max_backups"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Arrange
+			cfg := validConfig(t)
+			tt.modify(cfg)
+
+			// Act
+			err := cfg.Validate()
+
+			// Assert
+			if len(tt.want) == 0 {
+				if err != nil {
+					t.Fatalf("Validate() error = %v, want nil", err)
+				}
+				return
+			}
+			if !errors.Is(err, ErrInvalid) {
+				t.Fatalf("Validate() error = %v, want ErrInvalid", err)
+			}
+			for _, want := range tt.want {
+				if !strings.Contains(err.Error(), want) {
+					t.Errorf("Validate() error = %q, want it to contain %q", err, want)
+				}
+			}
+		})
+	}
+}
+
+func TestConfig_RequirePostgres(t *testing.T) {
+	tests := []struct {
+		name    string
+		driver  string
+		wantErr bool
+	}{
+		{name: "postgres", driver: "postgres"},
+		{name: "default", driver: ""},
+		{name: "mysql", driver: "mysql", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Arrange
+			cfg := validConfig(t)
+			cfg.Database.Driver = tt.driver
+
+			// Act
+			err := cfg.RequirePostgres()
+
+			// Assert
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("RequirePostgres() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErr && !errors.Is(err, ErrInvalid) {
+				t.Errorf("RequirePostgres() error = %v, want ErrInvalid", err)
+			}
+		})
+	}
+}
+
+func TestLoad_Invalid(t *testing.T) {
+	// Arrange
+	path := filepath.Join(t.TempDir(), "config.yaml")
+	data := "server:\n  http:\n    port: 70000\ndatabase:\n  driver: oracle\n"
+	if err := os.WriteFile(path, []byte(data), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	// Act
+	_, err := Load(path)
+
+	// Assert
+	if !errors.Is(err, ErrInvalid) {
+		t.Fatalf("Load() error = %v, want ErrInvalid", err)
+	}
+	for _, want := range []string{"server.http.port", "database.driver"} {
+		if !strings.Contains(err.Error(), want) {
+			t.Errorf("Load() error = %q, want it to contain %q", err, want)
+		}
+	}
+}
@@ -0,0 +1,79 @@
+// Package exportstore provides the PostgreSQL exporter.JobStore.
+package exportstore
+
+import (
+	"context"
+	"database/sql"
+	_ "embed"
+	"errors"
+	"fmt"
+
+	"github.com/blackhorseya/go-ddd/internal/application/exporter"
+)
+
+// Schema is the DDL for the export job table.
+//
+//go:embed schema.sql
+var Schema string
+
+// Store is the PostgreSQL exporter.JobStore, shared by the API and the worker.
+type Store struct {
+	db *sql.DB
+}
+
+var _ exporter.JobStore = (*Store)(nil)
+
+// NewStore creates a new Store.
+func NewStore(db *sql.DB) *Store {
+	return &Store{db: db}
+}
+
+// Create inserts a new job.
+func (s *Store) Create(ctx context.Context, job exporter.Job) error {
+	const query = `INSERT INTO export_jobs (id, exporter, format, status, rows, key, error, created_at, finished_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)`
+
+	_, err := s.db.ExecContext(ctx, query,
+		job.ID, job.Exporter, job.Format, job.Status, job.Rows, job.Key, job.Error, job.CreatedAt, job.FinishedAt)
+	if err != nil {
+		return fmt.Errorf("insert export job %s: %w", job.ID, err)
+	}
+	return nil
+}
+
+// Update saves the job's status, row count and outcome.
+func (s *Store) Update(ctx context.Context, job exporter.Job) error {
+	const query = `UPDATE export_jobs
+		SET status = $2, rows = $3, error = $4, finished_at = $5
+		WHERE id = $1`
+
+	res, err := s.db.ExecContext(ctx, query, job.ID, job.Status, job.Rows, job.Error, job.FinishedAt)
+	if err != nil {
+		return fmt.Errorf("update export job %s: %w", job.ID, err)
+	}
+	n, err := res.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if n == 0 {
+		return exporter.ErrJobNotFound
+	}
+	return nil
+}
+
+// Get returns the job with the given ID.
+func (s *Store) Get(ctx context.Context, id string) (exporter.Job, error) {
+	const query = `SELECT id, exporter, format, status, rows, key, error, created_at, finished_at
+		FROM export_jobs WHERE id = $1`
+
+	var job exporter.Job
+	err := s.db.QueryRowContext(ctx, query, id).Scan(
+		&job.ID, &job.Exporter, &job.Format, &job.Status, &job.Rows, &job.Key, &job.Error, &job.CreatedAt, &job.FinishedAt)
+	if errors.Is(err, sql.ErrNoRows) {
+		return exporter.Job{}, exporter.ErrJobNotFound
+	}
+	if err != nil {
+		return exporter.Job{}, fmt.Errorf("load export job %s: %w", id, err)
+	}
+	return job, nil
+}
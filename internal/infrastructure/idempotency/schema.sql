@@ -0,0 +1,16 @@
+-- Idempotency keys (PostgreSQL).
+-- A row is pending while a command runs and completed once it succeeds.
+-- payload_hash detects a key reused for a different command, and result
+-- holds the JSON-encoded result replayed to duplicates.
+CREATE TABLE IF NOT EXISTS idempotency_keys (
+    key          TEXT        PRIMARY KEY,
+    payload_hash TEXT        NOT NULL DEFAULT '',
+    result       BYTEA,
+    completed    BOOLEAN     NOT NULL DEFAULT FALSE,
+    locked_at    TIMESTAMPTZ NOT NULL DEFAULT now(),
+    completed_at TIMESTAMPTZ
+);
+
+CREATE INDEX IF NOT EXISTS idx_idempotency_keys_completed_at
+    ON idempotency_keys (completed_at)
+    WHERE completed;
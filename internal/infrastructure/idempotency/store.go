@@ -0,0 +1,108 @@
+// Package idempotency provides the PostgreSQL store backing the bus
+// idempotency middleware, so duplicate commands are detected across
+// instances, HTTP retries and message redelivery.
+package idempotency
+
+import (
+	"context"
+	"database/sql"
+	_ "embed"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/blackhorseya/go-ddd/internal/application/bus"
+)
+
+// Schema is the DDL for the idempotency table.
+//
+//go:embed schema.sql
+var Schema string
+
+// DefaultLockTTL is how long a pending key blocks duplicates before it is
+// considered abandoned.
+const DefaultLockTTL = 5 * time.Minute
+
+// Store is the PostgreSQL bus.IdempotencyStore. It deliberately uses the
+// database handle rather than sqldb.Conn, so reservations are visible to
+// other instances immediately and survive a command's rollback.
+type Store struct {
+	db      *sql.DB
+	lockTTL time.Duration
+}
+
+var _ bus.IdempotencyStore = (*Store)(nil)
+
+// NewStore creates a Store. A lockTTL <= 0 uses DefaultLockTTL.
+func NewStore(db *sql.DB, lockTTL time.Duration) *Store {
+	if lockTTL <= 0 {
+		lockTTL = DefaultLockTTL
+	}
+	return &Store{db: db, lockTTL: lockTTL}
+}
+
+// Reserve claims key, taking over a pending key whose lock has expired.
+func (s *Store) Reserve(ctx context.Context, key, payloadHash string) (bus.IdempotencyRecord, error) {
+	const reserve = `INSERT INTO idempotency_keys (key, payload_hash) VALUES ($1, $2)
+		ON CONFLICT (key) DO UPDATE SET locked_at = now(), payload_hash = EXCLUDED.payload_hash
+		WHERE NOT idempotency_keys.completed
+			AND idempotency_keys.locked_at < now() - make_interval(secs => $3)
+		RETURNING key`
+
+	var reserved string
+	err := s.db.QueryRowContext(ctx, reserve, key, payloadHash, s.lockTTL.Seconds()).Scan(&reserved)
+	if err == nil {
+		return bus.IdempotencyRecord{Status: bus.IdempotencyNew}, nil
+	}
+	if !errors.Is(err, sql.ErrNoRows) {
+		return bus.IdempotencyRecord{}, fmt.Errorf("reserve idempotency key: %w", err)
+	}
+
+	const load = `SELECT completed, payload_hash, result FROM idempotency_keys WHERE key = $1`
+
+	var (
+		rec       bus.IdempotencyRecord
+		completed bool
+	)
+	err = s.db.QueryRowContext(ctx, load, key).Scan(&completed, &rec.PayloadHash, &rec.Result)
+	if err != nil {
+		return bus.IdempotencyRecord{}, fmt.Errorf("load idempotency key: %w", err)
+	}
+	rec.Status = bus.IdempotencyPending
+	if completed {
+		rec.Status = bus.IdempotencyCompleted
+	}
+	return rec, nil
+}
+
+// Complete marks key as executed and stores its result.
+func (s *Store) Complete(ctx context.Context, key string, result []byte) error {
+	const query = `UPDATE idempotency_keys SET completed = TRUE, completed_at = now(), result = $2 WHERE key = $1`
+
+	if _, err := s.db.ExecContext(ctx, query, key, result); err != nil {
+		return fmt.Errorf("complete idempotency key: %w", err)
+	}
+	return nil
+}
+
+// Release deletes a pending key.
+func (s *Store) Release(ctx context.Context, key string) error {
+	const query = `DELETE FROM idempotency_keys WHERE key = $1 AND NOT completed`
+
+	if _, err := s.db.ExecContext(ctx, query, key); err != nil {
+		return fmt.Errorf("release idempotency key: %w", err)
+	}
+	return nil
+}
+
+// Purge deletes keys completed before the cutoff and returns how many
+// were removed. Run it periodically to bound the table size.
+func (s *Store) Purge(ctx context.Context, before time.Time) (int64, error) {
+	const query = `DELETE FROM idempotency_keys WHERE completed AND completed_at < $1`
+
+	res, err := s.db.ExecContext(ctx, query, before)
+	if err != nil {
+		return 0, fmt.Errorf("purge idempotency keys: %w", err)
+	}
+	return res.RowsAffected()
+}
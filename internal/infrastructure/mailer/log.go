@@ -0,0 +1,22 @@
+package mailer
+
+import (
+	"context"
+
+	"github.com/blackhorseya/go-ddd/pkg/contextx"
+)
+
+// LogSender logs messages instead of sending them. Use it in development
+// so flows that send email can be exercised without a mail server.
+type LogSender struct{}
+
+// Send logs the message, including its text body.
+func (LogSender) Send(ctx context.Context, e Email) error {
+	contextx.From(ctx).Info("mail not sent (log driver)",
+		"from", e.From,
+		"to", e.To,
+		"subject", e.Subject,
+		"text", e.Text,
+	)
+	return nil
+}
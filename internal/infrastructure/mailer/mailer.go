@@ -0,0 +1,156 @@
+// Package mailer provides mail.Mailer with SMTP, Amazon SES and logging
+// backends. A Mailer renders the message from embedded templates and
+// retries transient delivery failures with exponential backoff.
+package mailer
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/blackhorseya/go-ddd/internal/application/mail"
+	"github.com/blackhorseya/go-ddd/internal/infrastructure/config"
+)
+
+// Email is a rendered message ready for delivery.
+type Email struct {
+	From    string
+	To      []string
+	ReplyTo string
+	Subject string
+	Text    string
+	HTML    string
+}
+
+// Sender delivers rendered email. Permanent failures wrap mail.ErrRejected;
+// any other error is treated as transient and retried.
+type Sender interface {
+	Send(ctx context.Context, e Email) error
+}
+
+// Options configures a Mailer.
+type Options struct {
+	// From is the sender address, e.g. "Shop <no-reply@example.com>".
+	From string
+
+	// Templates renders messages that name a template.
+	Templates *Templates
+
+	// MaxAttempts bounds delivery attempts. Default: 3
+	MaxAttempts int
+
+	// Backoff is the delay before the first retry, doubled after each
+	// attempt. Default: 1s
+	Backoff time.Duration
+}
+
+// Mailer renders messages and delivers them through a Sender.
+type Mailer struct {
+	sender Sender
+	opts   Options
+}
+
+var _ mail.Mailer = (*Mailer)(nil)
+
+// NewMailer creates a Mailer delivering through sender.
+func NewMailer(sender Sender, opts Options) *Mailer {
+	if opts.MaxAttempts <= 0 {
+		opts.MaxAttempts = 3
+	}
+	if opts.Backoff <= 0 {
+		opts.Backoff = time.Second
+	}
+	return &Mailer{sender: sender, opts: opts}
+}
+
+// New creates a Mailer for the backend selected by cfg.Driver, rendering
+// the templates embedded in this package.
+func New(ctx context.Context, cfg config.Mail) (*Mailer, error) {
+	templates, err := DefaultTemplates()
+	if err != nil {
+		return nil, err
+	}
+
+	var sender Sender
+	switch cfg.Driver {
+	case "", "log":
+		sender = LogSender{}
+	case "smtp":
+		sender = NewSMTP(SMTPConfig{
+			Host:     cfg.SMTP.Host,
+			Port:     cfg.SMTP.Port,
+			Username: cfg.SMTP.Username,
+			Password: cfg.SMTP.Password,
+		})
+	case "ses":
+		if sender, err = NewSES(ctx, cfg.SES.Region); err != nil {
+			return nil, err
+		}
+	default:
+		return nil, fmt.Errorf("unknown mail driver %q", cfg.Driver)
+	}
+
+	return NewMailer(sender, Options{
+		From:        cfg.From,
+		Templates:   templates,
+		MaxAttempts: cfg.MaxAttempts,
+		Backoff:     cfg.RetryBackoff,
+	}), nil
+}
+
+// Send renders msg and delivers it, retrying transient failures.
+func (m *Mailer) Send(ctx context.Context, msg mail.Message) error {
+	e, err := m.render(msg)
+	if err != nil {
+		return err
+	}
+
+	delay := m.opts.Backoff
+	for attempt := 1; ; attempt++ {
+		err = m.sender.Send(ctx, e)
+		if err == nil || errors.Is(err, mail.ErrRejected) || attempt == m.opts.MaxAttempts {
+			break
+		}
+
+		t := time.NewTimer(delay)
+		select {
+		case <-ctx.Done():
+			t.Stop()
+			return fmt.Errorf("send mail %q: %w", e.Subject, errors.Join(err, ctx.Err()))
+		case <-t.C:
+		}
+		delay *= 2
+	}
+	if err != nil {
+		return fmt.Errorf("send mail %q: %w", e.Subject, err)
+	}
+	return nil
+}
+
+// render resolves the message body from its template, if any.
+func (m *Mailer) render(msg mail.Message) (Email, error) {
+	if len(msg.To) == 0 {
+		return Email{}, fmt.Errorf("%w: no recipients", mail.ErrRejected)
+	}
+	e := Email{
+		From:    m.opts.From,
+		To:      msg.To,
+		ReplyTo: msg.ReplyTo,
+		Subject: msg.Subject,
+		Text:    msg.Text,
+		HTML:    msg.HTML,
+	}
+	if msg.Template == "" {
+		return e, nil
+	}
+	if m.opts.Templates == nil {
+		return Email{}, fmt.Errorf("%w: %s", ErrUnknownTemplate, msg.Template)
+	}
+	text, html, err := m.opts.Templates.Render(msg.Template, msg.Data)
+	if err != nil {
+		return Email{}, err
+	}
+	e.Text, e.HTML = text, html
+	return e, nil
+}
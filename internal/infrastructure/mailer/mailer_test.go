@@ -0,0 +1,139 @@
+package mailer
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"testing/fstest"
+	"time"
+
+	"github.com/blackhorseya/go-ddd/internal/application/mail"
+)
+
+// recordingSender fails with errs in order, then succeeds.
+type recordingSender struct {
+	errs []error
+	sent []Email
+}
+
+func (s *recordingSender) Send(_ context.Context, e Email) error {
+	s.sent = append(s.sent, e)
+	if len(s.errs) == 0 {
+		return nil
+	}
+	err := s.errs[0]
+	s.errs = s.errs[1:]
+	return err
+}
+
+func TestMailer_Retry(t *testing.T) {
+	transient := errors.New("connection reset")
+	tests := []struct {
+		name         string
+		errs         []error
+		wantAttempts int
+		wantErr      error
+	}{
+		{name: "first attempt succeeds", wantAttempts: 1},
+		{name: "transient failure is retried", errs: []error{transient, transient}, wantAttempts: 3},
+		{name: "attempts are bounded", errs: []error{transient, transient, transient}, wantAttempts: 3, wantErr: transient},
+		{name: "rejection is not retried", errs: []error{mail.ErrRejected}, wantAttempts: 1, wantErr: mail.ErrRejected},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Arrange
+			sender := &recordingSender{errs: tt.errs}
+			m := NewMailer(sender, Options{From: "shop@example.com", Backoff: time.Millisecond})
+
+			// Act
+			err := m.Send(context.Background(), mail.Message{To: []string{"a@example.com"}, Subject: "Hi", Text: "hello"})
+
+			// Assert
+			if !errors.Is(err, tt.wantErr) || (tt.wantErr == nil && err != nil) {
+				t.Errorf("Send() error = %v, want %v", err, tt.wantErr)
+			}
+			if len(sender.sent) != tt.wantAttempts {
+				t.Errorf("attempts = %d, want %d", len(sender.sent), tt.wantAttempts)
+			}
+		})
+	}
+}
+
+func TestMailer_RetryStopsOnCancel(t *testing.T) {
+	// Arrange
+	sender := &recordingSender{errs: []error{errors.New("timeout")}}
+	m := NewMailer(sender, Options{Backoff: time.Hour})
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	// Act
+	err := m.Send(ctx, mail.Message{To: []string{"a@example.com"}, Text: "hello"})
+
+	// Assert
+	if !errors.Is(err, context.Canceled) || len(sender.sent) != 1 {
+		t.Errorf("Send() = %v after %d attempts, want %v after 1", err, len(sender.sent), context.Canceled)
+	}
+}
+
+func TestMailer_Templates(t *testing.T) {
+	// Arrange
+	templates, err := ParseTemplates(fstest.MapFS{
+		"receipt.html.tmpl": {Data: []byte(`<p>Total: {{.Total}}</p>`)},
+		"receipt.txt.tmpl":  {Data: []byte("Total: {{.Total}}\n\n")},
+		"notice.txt.tmpl":   {Data: []byte(`Notice for {{.}}`)},
+	})
+	if err != nil {
+		t.Fatalf("ParseTemplates() error = %v", err)
+	}
+	sender := &recordingSender{}
+	m := NewMailer(sender, Options{From: "shop@example.com", Templates: templates})
+	ctx := context.Background()
+
+	// Act
+	err = m.Send(ctx, mail.Message{
+		To:       []string{"a@example.com"},
+		Subject:  "Receipt",
+		Template: "receipt",
+		Data:     map[string]string{"Total": "<$10>"},
+	})
+
+	// Assert
+	if err != nil {
+		t.Fatalf("Send() error = %v", err)
+	}
+	got := sender.sent[0]
+	if got.Text != "Total: <$10>\n" || got.HTML != "<p>Total: &lt;$10&gt;</p>" || got.From != "shop@example.com" {
+		t.Errorf("rendered = %+v", got)
+	}
+
+	if err := m.Send(ctx, mail.Message{To: []string{"a@example.com"}, Template: "notice", Data: "you"}); err != nil {
+		t.Fatalf("Send(text only) error = %v", err)
+	}
+	if got := sender.sent[1]; got.Text != "Notice for you\n" || got.HTML != "" {
+		t.Errorf("text only = %+v", got)
+	}
+
+	if err := m.Send(ctx, mail.Message{To: []string{"a@example.com"}, Template: "missing"}); !errors.Is(err, ErrUnknownTemplate) {
+		t.Errorf("Send(missing) error = %v, want %v", err, ErrUnknownTemplate)
+	}
+	if err := m.Send(ctx, mail.Message{Text: "hello"}); !errors.Is(err, mail.ErrRejected) {
+		t.Errorf("Send(no recipients) error = %v, want %v", err, mail.ErrRejected)
+	}
+}
+
+func TestDefaultTemplates(t *testing.T) {
+	templates, err := DefaultTemplates()
+	if err != nil {
+		t.Fatalf("DefaultTemplates() error = %v", err)
+	}
+
+	text, html, err := templates.Render("welcome", map[string]string{"Name": "Ada"})
+	if err != nil {
+		t.Fatalf("Render(welcome) error = %v", err)
+	}
+	if !strings.Contains(text, "Hi Ada,") || !strings.Contains(html, "<p>Hi Ada,</p>") {
+		t.Errorf("Render(welcome) = %q, %q", text, html)
+	}
+}
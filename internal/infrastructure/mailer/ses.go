@@ -0,0 +1,83 @@
+package mailer
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	awsconfig "github.com/aws/aws-sdk-go-v2/config"
+	"github.com/aws/aws-sdk-go-v2/service/sesv2"
+	"github.com/aws/aws-sdk-go-v2/service/sesv2/types"
+
+	"github.com/blackhorseya/go-ddd/internal/application/mail"
+)
+
+// SESAPI is the subset of *sesv2.Client used by SES.
+type SESAPI interface {
+	SendEmail(ctx context.Context, in *sesv2.SendEmailInput, optFns ...func(*sesv2.Options)) (*sesv2.SendEmailOutput, error)
+}
+
+// SES sends email through the Amazon SES v2 API.
+type SES struct {
+	api SESAPI
+}
+
+var _ Sender = (*SES)(nil)
+
+// NewSES creates an SES sender using the default AWS credential chain.
+func NewSES(ctx context.Context, region string) (*SES, error) {
+	cfg, err := awsconfig.LoadDefaultConfig(ctx, awsconfig.WithRegion(region))
+	if err != nil {
+		return nil, fmt.Errorf("load aws config: %w", err)
+	}
+	return &SES{api: sesv2.NewFromConfig(cfg)}, nil
+}
+
+// Send delivers e as a simple SES message. Rejections and account or
+// sender verification problems wrap mail.ErrRejected; throttling and
+// service errors are transient.
+func (s *SES) Send(ctx context.Context, e Email) error {
+	body := &types.Body{}
+	if e.Text != "" {
+		body.Text = utf8(e.Text)
+	}
+	if e.HTML != "" {
+		body.Html = utf8(e.HTML)
+	}
+	in := &sesv2.SendEmailInput{
+		FromEmailAddress: aws.String(e.From),
+		Destination:      &types.Destination{ToAddresses: e.To},
+		Content: &types.EmailContent{
+			Simple: &types.Message{Subject: utf8(e.Subject), Body: body},
+		},
+	}
+	if e.ReplyTo != "" {
+		in.ReplyToAddresses = []string{e.ReplyTo}
+	}
+
+	if _, err := s.api.SendEmail(ctx, in); err != nil {
+		if permanentSESError(err) {
+			return fmt.Errorf("%w: %v", mail.ErrRejected, err)
+		}
+		return err
+	}
+	return nil
+}
+
+func utf8(s string) *types.Content {
+	return &types.Content{Data: aws.String(s), Charset: aws.String("UTF-8")}
+}
+
+func permanentSESError(err error) bool {
+	var (
+		rejected    *types.MessageRejected
+		unverified  *types.MailFromDomainNotVerifiedException
+		badRequest  *types.BadRequestException
+		suspended   *types.AccountSuspendedException
+		pausedSends *types.SendingPausedException
+	)
+	return errors.As(err, &rejected) || errors.As(err, &unverified) ||
+		errors.As(err, &badRequest) || errors.As(err, &suspended) ||
+		errors.As(err, &pausedSends)
+}
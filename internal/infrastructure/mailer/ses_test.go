@@ -0,0 +1,64 @@
+package mailer
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/sesv2"
+	"github.com/aws/aws-sdk-go-v2/service/sesv2/types"
+
+	"github.com/blackhorseya/go-ddd/internal/application/mail"
+)
+
+type fakeSES struct {
+	err error
+	in  *sesv2.SendEmailInput
+}
+
+func (f *fakeSES) SendEmail(_ context.Context, in *sesv2.SendEmailInput, _ ...func(*sesv2.Options)) (*sesv2.SendEmailOutput, error) {
+	f.in = in
+	return &sesv2.SendEmailOutput{}, f.err
+}
+
+func TestSES_Send(t *testing.T) {
+	tests := []struct {
+		name         string
+		err          error
+		wantErr      bool
+		wantRejected bool
+	}{
+		{name: "sent"},
+		{name: "rejected", err: &types.MessageRejected{}, wantErr: true, wantRejected: true},
+		{name: "unverified sender", err: &types.MailFromDomainNotVerifiedException{}, wantErr: true, wantRejected: true},
+		{name: "throttled", err: &types.TooManyRequestsException{}, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Arrange
+			api := &fakeSES{err: tt.err}
+			s := &SES{api: api}
+
+			// Act
+			err := s.Send(context.Background(), Email{
+				From:    "shop@example.com",
+				To:      []string{"ada@example.com"},
+				ReplyTo: "support@example.com",
+				Subject: "Hi",
+				Text:    "hello",
+			})
+
+			// Assert
+			if (err != nil) != tt.wantErr || errors.Is(err, mail.ErrRejected) != tt.wantRejected {
+				t.Fatalf("Send() error = %v, wantErr %v, wantRejected %v", err, tt.wantErr, tt.wantRejected)
+			}
+			msg := api.in.Content.Simple
+			if aws.ToString(msg.Subject.Data) != "Hi" || aws.ToString(msg.Body.Text.Data) != "hello" || msg.Body.Html != nil ||
+				api.in.ReplyToAddresses[0] != "support@example.com" {
+				t.Errorf("SendEmail input = %+v", api.in)
+			}
+		})
+	}
+}
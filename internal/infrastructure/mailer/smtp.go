@@ -0,0 +1,191 @@
+package mailer
+
+import (
+	"bytes"
+	"context"
+	"crypto/tls"
+	"errors"
+	"fmt"
+	"io"
+	"mime"
+	"mime/multipart"
+	"mime/quotedprintable"
+	"net"
+	netmail "net/mail"
+	"net/smtp"
+	"net/textproto"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/blackhorseya/go-ddd/internal/application/mail"
+	"github.com/blackhorseya/go-ddd/pkg/idx"
+)
+
+// SMTPConfig configures an SMTP relay.
+type SMTPConfig struct {
+	Host string
+	Port int
+
+	// Username and Password enable PLAIN auth, which net/smtp only sends
+	// over TLS or to localhost.
+	Username string
+	Password string
+}
+
+// SMTP sends email through an SMTP relay, upgrading to TLS with STARTTLS
+// when the server offers it.
+type SMTP struct {
+	cfg SMTPConfig
+	now func() time.Time
+}
+
+var _ Sender = (*SMTP)(nil)
+
+// NewSMTP creates an SMTP sender.
+func NewSMTP(cfg SMTPConfig) *SMTP {
+	return &SMTP{cfg: cfg, now: time.Now}
+}
+
+// Send delivers e in one SMTP session. 5xx replies are permanent and wrap
+// mail.ErrRejected; connection errors and 4xx replies are transient.
+func (s *SMTP) Send(ctx context.Context, e Email) error {
+	from, err := netmail.ParseAddress(e.From)
+	if err != nil {
+		return fmt.Errorf("%w: sender %q: %v", mail.ErrRejected, e.From, err)
+	}
+	to := make([]string, len(e.To))
+	for i, addr := range e.To {
+		a, err := netmail.ParseAddress(addr)
+		if err != nil {
+			return fmt.Errorf("%w: recipient %q: %v", mail.ErrRejected, addr, err)
+		}
+		to[i] = a.Address
+	}
+	body, err := s.build(e)
+	if err != nil {
+		return err
+	}
+	return classify(s.deliver(ctx, from.Address, to, body))
+}
+
+func (s *SMTP) deliver(ctx context.Context, from string, to []string, body []byte) error {
+	addr := net.JoinHostPort(s.cfg.Host, strconv.Itoa(s.cfg.Port))
+	var d net.Dialer
+	conn, err := d.DialContext(ctx, "tcp", addr)
+	if err != nil {
+		return err
+	}
+	if deadline, ok := ctx.Deadline(); ok {
+		_ = conn.SetDeadline(deadline)
+	}
+
+	c, err := smtp.NewClient(conn, s.cfg.Host)
+	if err != nil {
+		_ = conn.Close()
+		return err
+	}
+	defer c.Close()
+
+	if ok, _ := c.Extension("STARTTLS"); ok {
+		if err := c.StartTLS(&tls.Config{ServerName: s.cfg.Host}); err != nil {
+			return err
+		}
+	}
+	if s.cfg.Username != "" {
+		if err := c.Auth(smtp.PlainAuth("", s.cfg.Username, s.cfg.Password, s.cfg.Host)); err != nil {
+			return err
+		}
+	}
+	if err := c.Mail(from); err != nil {
+		return err
+	}
+	for _, rcpt := range to {
+		if err := c.Rcpt(rcpt); err != nil {
+			return err
+		}
+	}
+	w, err := c.Data()
+	if err != nil {
+		return err
+	}
+	if _, err := w.Write(body); err != nil {
+		return err
+	}
+	if err := w.Close(); err != nil {
+		return err
+	}
+	return c.Quit()
+}
+
+// build encodes e as a MIME message: multipart/alternative when it has
+// both bodies, otherwise a single quoted-printable part.
+func (s *SMTP) build(e Email) ([]byte, error) {
+	var buf bytes.Buffer
+	header := func(k, v string) { fmt.Fprintf(&buf, "%s: %s\r\n", k, v) }
+
+	header("From", e.From)
+	header("To", strings.Join(e.To, ", "))
+	if e.ReplyTo != "" {
+		header("Reply-To", e.ReplyTo)
+	}
+	header("Subject", mime.QEncoding.Encode("utf-8", e.Subject))
+	header("Date", s.now().Format(time.RFC1123Z))
+	header("Message-ID", "<"+idx.ULID()+"@"+s.cfg.Host+">")
+	header("MIME-Version", "1.0")
+
+	if e.Text == "" || e.HTML == "" {
+		contentType, content := "text/plain", e.Text
+		if e.HTML != "" {
+			contentType, content = "text/html", e.HTML
+		}
+		header("Content-Type", contentType+"; charset=utf-8")
+		header("Content-Transfer-Encoding", "quoted-printable")
+		buf.WriteString("\r\n")
+		if err := writeQP(&buf, content); err != nil {
+			return nil, err
+		}
+		return buf.Bytes(), nil
+	}
+
+	mw := multipart.NewWriter(&buf)
+	header("Content-Type", "multipart/alternative; boundary="+mw.Boundary())
+	buf.WriteString("\r\n")
+	for _, part := range []struct{ contentType, content string }{
+		{"text/plain", e.Text},
+		{"text/html", e.HTML},
+	} {
+		w, err := mw.CreatePart(textproto.MIMEHeader{
+			"Content-Type":              {part.contentType + "; charset=utf-8"},
+			"Content-Transfer-Encoding": {"quoted-printable"},
+		})
+		if err != nil {
+			return nil, err
+		}
+		if err := writeQP(w, part.content); err != nil {
+			return nil, err
+		}
+	}
+	if err := mw.Close(); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// writeQP writes s quoted-printable encoded.
+func writeQP(w io.Writer, s string) error {
+	qp := quotedprintable.NewWriter(w)
+	if _, err := qp.Write([]byte(s)); err != nil {
+		return err
+	}
+	return qp.Close()
+}
+
+// classify wraps permanent SMTP replies (5xx) with mail.ErrRejected.
+func classify(err error) error {
+	var reply *textproto.Error
+	if errors.As(err, &reply) && reply.Code >= 500 {
+		return fmt.Errorf("%w: %v", mail.ErrRejected, err)
+	}
+	return err
+}
@@ -0,0 +1,151 @@
+package mailer
+
+import (
+	"context"
+	"errors"
+	"net"
+	"net/textproto"
+	"strings"
+	"testing"
+
+	"github.com/blackhorseya/go-ddd/internal/application/mail"
+)
+
+// fakeSMTP accepts one session per connection and records the DATA of
+// each message. Recipients at reject.test get a 550, at busy.test a 451.
+type fakeSMTP struct {
+	ln   net.Listener
+	data chan string
+}
+
+func newFakeSMTP(t *testing.T) *fakeSMTP {
+	t.Helper()
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("listen: %v", err)
+	}
+	s := &fakeSMTP{ln: ln, data: make(chan string, 10)}
+	t.Cleanup(func() { _ = ln.Close() })
+	go func() {
+		for {
+			conn, err := ln.Accept()
+			if err != nil {
+				return
+			}
+			go s.serve(conn)
+		}
+	}()
+	return s
+}
+
+func (s *fakeSMTP) serve(conn net.Conn) {
+	defer conn.Close()
+	tp := textproto.NewConn(conn)
+	_ = tp.PrintfLine("220 fake ESMTP")
+	for {
+		line, err := tp.ReadLine()
+		if err != nil {
+			return
+		}
+		cmd := strings.ToUpper(strings.Fields(line + " ")[0])
+		switch {
+		case cmd == "EHLO" || cmd == "HELO" || cmd == "MAIL":
+			_ = tp.PrintfLine("250 OK")
+		case cmd == "RCPT" && strings.Contains(line, "@reject.test"):
+			_ = tp.PrintfLine("550 no such user")
+		case cmd == "RCPT" && strings.Contains(line, "@busy.test"):
+			_ = tp.PrintfLine("451 try again later")
+		case cmd == "RCPT":
+			_ = tp.PrintfLine("250 OK")
+		case cmd == "DATA":
+			_ = tp.PrintfLine("354 go ahead")
+			b, _ := tp.ReadDotBytes()
+			s.data <- string(b)
+			_ = tp.PrintfLine("250 queued")
+		case cmd == "QUIT":
+			_ = tp.PrintfLine("221 bye")
+			return
+		default:
+			_ = tp.PrintfLine("502 not implemented")
+		}
+	}
+}
+
+func (s *fakeSMTP) sender() *SMTP {
+	addr := s.ln.Addr().(*net.TCPAddr)
+	return NewSMTP(SMTPConfig{Host: addr.IP.String(), Port: addr.Port})
+}
+
+func TestSMTP_Send(t *testing.T) {
+	// Arrange
+	server := newFakeSMTP(t)
+	e := Email{
+		From:    "Shop <shop@example.com>",
+		To:      []string{"ada@example.com"},
+		Subject: "Grüße",
+		Text:    "hello",
+		HTML:    "<p>hello</p>",
+	}
+
+	// Act
+	err := server.sender().Send(context.Background(), e)
+
+	// Assert
+	if err != nil {
+		t.Fatalf("Send() error = %v", err)
+	}
+	data := <-server.data
+	for _, want := range []string{
+		"From: Shop <shop@example.com>",
+		"To: ada@example.com",
+		"Subject: =?utf-8?q?Gr=C3=BC=C3=9Fe?=",
+		"Content-Type: multipart/alternative; boundary=",
+		"Content-Type: text/plain; charset=utf-8",
+		"<p>hello</p>",
+	} {
+		if !strings.Contains(data, want) {
+			t.Errorf("message missing %q:\n%s", want, data)
+		}
+	}
+}
+
+func TestSMTP_Errors(t *testing.T) {
+	server := newFakeSMTP(t)
+
+	tests := []struct {
+		name         string
+		from, to     string
+		wantRejected bool
+	}{
+		{name: "permanent reply", from: "shop@example.com", to: "x@reject.test", wantRejected: true},
+		{name: "transient reply", from: "shop@example.com", to: "x@busy.test"},
+		{name: "invalid sender", from: "not an address", to: "ada@example.com", wantRejected: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Act
+			err := server.sender().Send(context.Background(), Email{From: tt.from, To: []string{tt.to}, Text: "x"})
+
+			// Assert
+			if err == nil {
+				t.Fatal("Send() error = nil")
+			}
+			if got := errors.Is(err, mail.ErrRejected); got != tt.wantRejected {
+				t.Errorf("Send() error = %v, rejected = %v, want %v", err, got, tt.wantRejected)
+			}
+		})
+	}
+
+	t.Run("connection refused is transient", func(t *testing.T) {
+		ln, _ := net.Listen("tcp", "127.0.0.1:0")
+		port := ln.Addr().(*net.TCPAddr).Port
+		_ = ln.Close()
+
+		err := NewSMTP(SMTPConfig{Host: "127.0.0.1", Port: port}).Send(context.Background(),
+			Email{From: "shop@example.com", To: []string{"ada@example.com"}, Text: "x"})
+		if err == nil || errors.Is(err, mail.ErrRejected) {
+			t.Errorf("Send() error = %v, want transient error", err)
+		}
+	})
+}
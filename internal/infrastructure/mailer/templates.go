@@ -0,0 +1,89 @@
+package mailer
+
+import (
+	"bytes"
+	"embed"
+	"errors"
+	"fmt"
+	htmltemplate "html/template"
+	"io/fs"
+	"strings"
+	texttemplate "text/template"
+)
+
+// ErrUnknownTemplate is returned when neither an HTML nor a text template
+// exists for a name.
+var ErrUnknownTemplate = errors.New("unknown mail template")
+
+const (
+	htmlSuffix = ".html.tmpl"
+	textSuffix = ".txt.tmpl"
+)
+
+//go:embed templates
+var embedded embed.FS
+
+// Templates renders message bodies from "<name>.html.tmpl" and
+// "<name>.txt.tmpl" files. HTML templates are auto-escaped; a name may
+// have either or both.
+type Templates struct {
+	html *htmltemplate.Template
+	text *texttemplate.Template
+}
+
+// DefaultTemplates parses the templates embedded in the binary.
+func DefaultTemplates() (*Templates, error) {
+	sub, err := fs.Sub(embedded, "templates")
+	if err != nil {
+		return nil, err
+	}
+	return ParseTemplates(sub)
+}
+
+// ParseTemplates parses the templates at the root of fsys.
+func ParseTemplates(fsys fs.FS) (*Templates, error) {
+	t := &Templates{
+		html: htmltemplate.New("mail"),
+		text: texttemplate.New("mail"),
+	}
+	if names, err := fs.Glob(fsys, "*"+htmlSuffix); err != nil {
+		return nil, err
+	} else if len(names) > 0 {
+		if t.html, err = t.html.ParseFS(fsys, names...); err != nil {
+			return nil, fmt.Errorf("parse html templates: %w", err)
+		}
+	}
+	if names, err := fs.Glob(fsys, "*"+textSuffix); err != nil {
+		return nil, err
+	} else if len(names) > 0 {
+		if t.text, err = t.text.ParseFS(fsys, names...); err != nil {
+			return nil, fmt.Errorf("parse text templates: %w", err)
+		}
+	}
+	return t, nil
+}
+
+// Render executes the templates for name with data.
+func (t *Templates) Render(name string, data any) (text, html string, err error) {
+	ht := t.html.Lookup(name + htmlSuffix)
+	tt := t.text.Lookup(name + textSuffix)
+	if ht == nil && tt == nil {
+		return "", "", fmt.Errorf("%w: %s", ErrUnknownTemplate, name)
+	}
+
+	var buf bytes.Buffer
+	if tt != nil {
+		if err := tt.Execute(&buf, data); err != nil {
+			return "", "", fmt.Errorf("render %s%s: %w", name, textSuffix, err)
+		}
+		text = strings.TrimSpace(buf.String()) + "\n"
+		buf.Reset()
+	}
+	if ht != nil {
+		if err := ht.Execute(&buf, data); err != nil {
+			return "", "", fmt.Errorf("render %s%s: %w", name, htmlSuffix, err)
+		}
+		html = buf.String()
+	}
+	return text, html, nil
+}
@@ -0,0 +1,210 @@
+package kafka
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"maps"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	kafkago "github.com/segmentio/kafka-go"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+
+	"github.com/blackhorseya/go-ddd/internal/application/message"
+	"github.com/blackhorseya/go-ddd/pkg/contextx"
+	"github.com/blackhorseya/go-ddd/pkg/runx"
+)
+
+// reader is the subset of *kafkago.Reader used here.
+type reader interface {
+	FetchMessage(ctx context.Context) (kafkago.Message, error)
+	CommitMessages(ctx context.Context, msgs ...kafkago.Message) error
+	Close() error
+}
+
+// Consumer delivers messages from subscribed topics to their handlers.
+// Messages are processed one at a time in partition order; scale out by
+// running more replicas in the same consumer group.
+type Consumer struct {
+	cfg       Config
+	handlers  map[string]message.HandlerFunc
+	dlq       writer
+	newReader func(topics []string) reader
+	sleep     func(ctx context.Context, d time.Duration) error
+	started   runx.Signal
+}
+
+var _ message.Subscriber = (*Consumer)(nil)
+
+// NewConsumer creates a Consumer. Register handlers with Subscribe before
+// calling Run.
+func NewConsumer(cfg Config) *Consumer {
+	cfg = cfg.withDefaults()
+	return &Consumer{
+		cfg:      cfg,
+		handlers: make(map[string]message.HandlerFunc),
+		dlq:      newWriter(cfg),
+		newReader: func(topics []string) reader {
+			return kafkago.NewReader(kafkago.ReaderConfig{
+				Brokers:     cfg.Brokers,
+				GroupID:     cfg.GroupID,
+				GroupTopics: topics,
+				StartOffset: kafkago.FirstOffset,
+			})
+		},
+		sleep: sleep,
+	}
+}
+
+// Subscribe registers h for topic. The handler context carries the
+// producer's contextx values and a span continuing its trace.
+func (c *Consumer) Subscribe(topic string, h message.HandlerFunc) {
+	c.handlers[c.cfg.TopicPrefix+topic] = h
+}
+
+// Started returns a channel closed once Run has subscribed to the
+// registered topics.
+func (c *Consumer) Started() <-chan struct{} {
+	return c.started.Done()
+}
+
+// Run consumes until ctx is cancelled. It returns an error only when a
+// failed message can be neither dead-lettered nor committed, leaving its
+// offset uncommitted so the group redelivers it after a restart.
+func (c *Consumer) Run(ctx context.Context) error {
+	logger := contextx.From(ctx)
+	if len(c.handlers) == 0 {
+		logger.Info("kafka consumer has no subscriptions")
+		c.started.Fire()
+		<-ctx.Done()
+		return nil
+	}
+
+	topics := slices.Sorted(maps.Keys(c.handlers))
+	r := c.newReader(topics)
+	defer func() {
+		_ = r.Close()
+		_ = c.dlq.Close()
+	}()
+	c.started.Fire()
+	logger.Info("kafka consumer started", "group", c.cfg.GroupID, "topics", topics)
+
+	for {
+		msg, err := r.FetchMessage(ctx)
+		if err != nil {
+			if ctx.Err() != nil {
+				logger.Info("kafka consumer stopped")
+				return nil
+			}
+			return fmt.Errorf("kafka fetch: %w", err)
+		}
+
+		if err := c.process(ctx, msg); err != nil {
+			return err
+		}
+		if ctx.Err() != nil {
+			return nil
+		}
+		if err := r.CommitMessages(ctx, msg); err != nil {
+			return fmt.Errorf("kafka commit %s/%d@%d: %w", msg.Topic, msg.Partition, msg.Offset, err)
+		}
+	}
+}
+
+// process handles msg with retries and dead-letters it on final failure.
+func (c *Consumer) process(ctx context.Context, msg kafkago.Message) error {
+	h, ok := c.handlers[msg.Topic]
+	if !ok {
+		return nil
+	}
+
+	attempts, err := c.handle(ctx, h, msg)
+	if err == nil || ctx.Err() != nil {
+		return nil
+	}
+
+	contextx.From(ctx).Error("message dead-lettered",
+		"topic", msg.Topic,
+		"partition", msg.Partition,
+		"offset", msg.Offset,
+		"attempts", attempts,
+		"error", err,
+	)
+	dead := kafkago.Message{
+		Topic: msg.Topic + c.cfg.DLQSuffix,
+		Key:   msg.Key,
+		Value: msg.Value,
+		Time:  msg.Time,
+		Headers: append(slices.Clip(msg.Headers),
+			kafkago.Header{Key: headerDLQError, Value: []byte(err.Error())},
+			kafkago.Header{Key: headerDLQAttempts, Value: []byte(strconv.Itoa(attempts))},
+		),
+	}
+	if err := c.dlq.WriteMessages(ctx, dead); err != nil {
+		return fmt.Errorf("kafka dead-letter %s: %w", msg.Topic, err)
+	}
+	return nil
+}
+
+// handle runs h until it succeeds, fails with message.ErrSkipRetry or
+// exhausts MaxRetries, returning the number of attempts made.
+func (c *Consumer) handle(ctx context.Context, h message.HandlerFunc, msg kafkago.Message) (int, error) {
+	topic := strings.TrimPrefix(msg.Topic, c.cfg.TopicPrefix)
+	hctx, env := decode(ctx, topic, msg)
+	hctx = contextx.WithOperation(hctx, "message."+topic)
+
+	delay := c.cfg.RetryBackoff
+	for attempt := 1; ; attempt++ {
+		err := c.attempt(hctx, h, env, attempt)
+		if err == nil || errors.Is(err, message.ErrSkipRetry) || attempt > c.cfg.MaxRetries {
+			return attempt, err
+		}
+
+		contextx.From(hctx).Warn("message failed, will retry",
+			"topic", topic, "attempt", attempt, "max_retries", c.cfg.MaxRetries, "error", err)
+		if err := c.sleep(ctx, delay); err != nil {
+			return attempt, err
+		}
+		delay *= 2
+	}
+}
+
+// attempt runs h once inside a consumer span.
+func (c *Consumer) attempt(ctx context.Context, h message.HandlerFunc, env message.Envelope, attempt int) error {
+	ctx, span := otel.Tracer(instrumentationName).Start(ctx, "message "+env.Topic,
+		trace.WithSpanKind(trace.SpanKindConsumer),
+		trace.WithAttributes(
+			attribute.String("messaging.system", "kafka"),
+			attribute.String("messaging.destination.name", env.Topic),
+			attribute.String("messaging.message.id", env.ID),
+			attribute.Int("messaging.attempt", attempt),
+		),
+	)
+	defer span.End()
+
+	err := h(ctx, env)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return err
+}
+
+// sleep waits for d or until ctx is cancelled.
+func sleep(ctx context.Context, d time.Duration) error {
+	t := time.NewTimer(d)
+	defer t.Stop()
+
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-t.C:
+		return nil
+	}
+}
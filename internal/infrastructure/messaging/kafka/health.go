@@ -0,0 +1,46 @@
+package kafka
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	kafkago "github.com/segmentio/kafka-go"
+
+	"github.com/blackhorseya/go-ddd/pkg/healthx"
+)
+
+// Checker returns a readiness checker that fetches cluster metadata from
+// the first reachable broker, so it fails only when no broker answers.
+func Checker(brokers []string) healthx.Checker {
+	return healthx.NewChecker("kafka", func(ctx context.Context) error {
+		if len(brokers) == 0 {
+			return errors.New("no brokers configured")
+		}
+		var errs []error
+		for _, addr := range brokers {
+			err := ping(ctx, addr)
+			if err == nil {
+				return nil
+			}
+			errs = append(errs, err)
+		}
+		return errors.Join(errs...)
+	})
+}
+
+// ping dials addr and requests the broker list.
+func ping(ctx context.Context, addr string) error {
+	conn, err := kafkago.DialContext(ctx, "tcp", addr)
+	if err != nil {
+		return fmt.Errorf("dial %s: %w", addr, err)
+	}
+	defer conn.Close()
+	if deadline, ok := ctx.Deadline(); ok {
+		_ = conn.SetDeadline(deadline)
+	}
+	if _, err := conn.Brokers(); err != nil {
+		return fmt.Errorf("metadata from %s: %w", addr, err)
+	}
+	return nil
+}
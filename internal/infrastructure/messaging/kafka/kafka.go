@@ -0,0 +1,124 @@
+// Package kafka implements the message port on Apache Kafka.
+// The Producer partitions by envelope key so each aggregate's messages stay
+// ordered; the Consumer reads as a consumer group, commits an offset only
+// after its handler succeeds, and moves messages that exhaust their retries,
+// or fail with message.ErrSkipRetry, to "<topic><DLQSuffix>".
+package kafka
+
+import (
+	"context"
+	"time"
+
+	kafkago "github.com/segmentio/kafka-go"
+
+	"github.com/blackhorseya/go-ddd/internal/application/message"
+)
+
+// instrumentationName is the OpenTelemetry tracer name.
+const instrumentationName = "github.com/blackhorseya/go-ddd/internal/infrastructure/messaging/kafka"
+
+// Header keys added to dead-lettered messages.
+const (
+	headerDLQError    = "dlq_error"
+	headerDLQAttempts = "dlq_attempts"
+)
+
+// Default settings.
+const (
+	DefaultDLQSuffix    = ".dlq"
+	DefaultMaxRetries   = 3
+	DefaultRetryBackoff = time.Second
+	DefaultBatchTimeout = 10 * time.Millisecond
+)
+
+// Config configures the Producer and Consumer.
+type Config struct {
+	// Brokers are the bootstrap broker addresses, e.g. "localhost:9092".
+	Brokers []string
+
+	// GroupID is the consumer group; replicas sharing it split partitions.
+	GroupID string
+
+	// TopicPrefix is prepended to every topic name, e.g. "go-ddd.".
+	TopicPrefix string
+
+	// DLQSuffix is appended to a topic name to form its dead-letter topic.
+	// Default: ".dlq"
+	DLQSuffix string
+
+	// MaxRetries is the number of in-process retries before a message is
+	// dead-lettered. Default: 3
+	MaxRetries int
+
+	// RetryBackoff is the first retry delay, doubled per retry.
+	// Default: 1s
+	RetryBackoff time.Duration
+
+	// BatchTimeout bounds how long the producer waits to fill a batch.
+	// Default: 10ms
+	BatchTimeout time.Duration
+}
+
+// withDefaults returns cfg with zero values replaced by defaults.
+func (cfg Config) withDefaults() Config {
+	if cfg.DLQSuffix == "" {
+		cfg.DLQSuffix = DefaultDLQSuffix
+	}
+	if cfg.MaxRetries < 0 {
+		cfg.MaxRetries = 0
+	} else if cfg.MaxRetries == 0 {
+		cfg.MaxRetries = DefaultMaxRetries
+	}
+	if cfg.RetryBackoff <= 0 {
+		cfg.RetryBackoff = DefaultRetryBackoff
+	}
+	if cfg.BatchTimeout <= 0 {
+		cfg.BatchTimeout = DefaultBatchTimeout
+	}
+	return cfg
+}
+
+// writer is the subset of *kafkago.Writer used here.
+type writer interface {
+	WriteMessages(ctx context.Context, msgs ...kafkago.Message) error
+	Close() error
+}
+
+// newWriter creates a writer that routes each message to its own topic
+// and hashes keys onto partitions.
+func newWriter(cfg Config) *kafkago.Writer {
+	return &kafkago.Writer{
+		Addr:         kafkago.TCP(cfg.Brokers...),
+		Balancer:     &kafkago.Hash{},
+		RequiredAcks: kafkago.RequireAll,
+		BatchTimeout: cfg.BatchTimeout,
+	}
+}
+
+// encode converts env to a Kafka message on topic, adding the contextx
+// values and trace context of ctx to its headers.
+func encode(ctx context.Context, topic string, env message.Envelope) kafkago.Message {
+	msg := kafkago.Message{Topic: topic, Value: env.Payload, Time: env.OccurredAt}
+	if env.Key != "" {
+		msg.Key = []byte(env.Key)
+	}
+	for k, v := range message.Inject(ctx, env).Metadata() {
+		msg.Headers = append(msg.Headers, kafkago.Header{Key: k, Value: []byte(v)})
+	}
+	return msg
+}
+
+// decode converts msg back to an envelope on topic and restores the
+// values written by encode into ctx.
+func decode(ctx context.Context, topic string, msg kafkago.Message) (context.Context, message.Envelope) {
+	headers := make(map[string]string, len(msg.Headers))
+	for _, h := range msg.Headers {
+		headers[h.Key] = string(h.Value)
+	}
+
+	env := message.FromMetadata(topic, string(msg.Key), msg.Value, headers)
+	if env.OccurredAt.IsZero() {
+		env.OccurredAt = msg.Time
+	}
+	return message.Extract(ctx, env), env
+}
@@ -0,0 +1,221 @@
+package kafka
+
+import (
+	"context"
+	"errors"
+	"net"
+	"testing"
+	"time"
+
+	kafkago "github.com/segmentio/kafka-go"
+
+	"github.com/blackhorseya/go-ddd/internal/application/message"
+	"github.com/blackhorseya/go-ddd/pkg/contextx"
+)
+
+// fakeWriter records written messages.
+type fakeWriter struct {
+	msgs []kafkago.Message
+	err  error
+}
+
+func (w *fakeWriter) WriteMessages(_ context.Context, msgs ...kafkago.Message) error {
+	if w.err != nil {
+		return w.err
+	}
+	w.msgs = append(w.msgs, msgs...)
+	return nil
+}
+
+func (w *fakeWriter) Close() error { return nil }
+
+// fakeReader returns queued messages, then cancels the consumer.
+type fakeReader struct {
+	msgs      []kafkago.Message
+	committed []kafkago.Message
+	cancel    context.CancelFunc
+}
+
+func (r *fakeReader) FetchMessage(ctx context.Context) (kafkago.Message, error) {
+	if len(r.msgs) == 0 {
+		r.cancel()
+		<-ctx.Done()
+		return kafkago.Message{}, ctx.Err()
+	}
+	msg := r.msgs[0]
+	r.msgs = r.msgs[1:]
+	return msg, nil
+}
+
+func (r *fakeReader) CommitMessages(_ context.Context, msgs ...kafkago.Message) error {
+	r.committed = append(r.committed, msgs...)
+	return nil
+}
+
+func (r *fakeReader) Close() error { return nil }
+
+// newTestConsumer returns a consumer reading msgs from a fake reader.
+func newTestConsumer(cfg Config, msgs ...kafkago.Message) (*Consumer, *fakeReader, *fakeWriter) {
+	c := NewConsumer(cfg)
+	r := &fakeReader{msgs: msgs}
+	dlq := &fakeWriter{}
+	c.dlq = dlq
+	c.newReader = func([]string) reader { return r }
+	c.sleep = func(context.Context, time.Duration) error { return nil }
+	return c, r, dlq
+}
+
+func TestProducer_Publish(t *testing.T) {
+	// Arrange
+	w := &fakeWriter{}
+	p := &Producer{w: w, prefix: "app."}
+	ctx := contextx.WithRequestID(context.Background(), "req-1")
+	occurred := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	// Act
+	err := p.Publish(ctx, message.Envelope{
+		ID:         "m-1",
+		Topic:      "order.placed",
+		Key:        "o-1",
+		Payload:    []byte(`{}`),
+		Headers:    map[string]string{"schema": "v1"},
+		OccurredAt: occurred,
+	})
+
+	// Assert
+	if err != nil {
+		t.Fatalf("Publish() error = %v", err)
+	}
+	if len(w.msgs) != 1 {
+		t.Fatalf("wrote %d messages, want 1", len(w.msgs))
+	}
+	msg := w.msgs[0]
+	if msg.Topic != "app.order.placed" || string(msg.Key) != "o-1" {
+		t.Errorf("message = %s/%s, want app.order.placed/o-1", msg.Topic, msg.Key)
+	}
+
+	_, env := decode(context.Background(), "order.placed", msg)
+	if env.ID != "m-1" || !env.OccurredAt.Equal(occurred) {
+		t.Errorf("decoded id = %q occurred_at = %v", env.ID, env.OccurredAt)
+	}
+	if env.Headers["schema"] != "v1" || env.Headers[message.HeaderRequestID] != "req-1" {
+		t.Errorf("decoded headers = %v", env.Headers)
+	}
+}
+
+func TestConsumer_Run(t *testing.T) {
+	boom := errors.New("boom")
+
+	tests := []struct {
+		name         string
+		results      []error
+		wantAttempts int
+		wantDLQ      bool
+	}{
+		{name: "success", results: []error{nil}, wantAttempts: 1},
+		{name: "retried then succeeds", results: []error{boom, nil}, wantAttempts: 2},
+		{name: "retries exhausted", results: []error{boom, boom, boom}, wantAttempts: 3, wantDLQ: true},
+		{name: "skip retry", results: []error{message.ErrSkipRetry}, wantAttempts: 1, wantDLQ: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Arrange
+			ctx, cancel := context.WithCancel(context.Background())
+			defer cancel()
+
+			msg := kafkago.Message{Topic: "app.order.placed", Key: []byte("o-1"), Value: []byte(`{}`)}
+			c, r, dlq := newTestConsumer(Config{TopicPrefix: "app.", MaxRetries: 2}, msg)
+			r.cancel = cancel
+
+			var attempts int
+			var got message.Envelope
+			c.Subscribe("order.placed", func(_ context.Context, env message.Envelope) error {
+				got = env
+				attempts++
+				return tt.results[attempts-1]
+			})
+
+			// Act
+			err := c.Run(ctx)
+
+			// Assert
+			if err != nil {
+				t.Fatalf("Run() error = %v", err)
+			}
+			if attempts != tt.wantAttempts {
+				t.Errorf("attempts = %d, want %d", attempts, tt.wantAttempts)
+			}
+			if got.Topic != "order.placed" || got.Key != "o-1" {
+				t.Errorf("envelope = %s/%s, want order.placed/o-1", got.Topic, got.Key)
+			}
+			if len(r.committed) != 1 {
+				t.Errorf("committed %d messages, want 1", len(r.committed))
+			}
+			if gotDLQ := len(dlq.msgs) == 1; gotDLQ != tt.wantDLQ {
+				t.Fatalf("dead-lettered = %v, want %v", gotDLQ, tt.wantDLQ)
+			}
+			if tt.wantDLQ && dlq.msgs[0].Topic != "app.order.placed.dlq" {
+				t.Errorf("dlq topic = %s", dlq.msgs[0].Topic)
+			}
+		})
+	}
+}
+
+func TestConsumer_RunDeadLetterFailure(t *testing.T) {
+	// Arrange
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	msg := kafkago.Message{Topic: "order.placed", Value: []byte(`{}`)}
+	c, r, dlq := newTestConsumer(Config{}, msg)
+	r.cancel = cancel
+	dlq.err = errors.New("broker down")
+	c.Subscribe("order.placed", func(context.Context, message.Envelope) error {
+		return message.ErrSkipRetry
+	})
+
+	// Act
+	err := c.Run(ctx)
+
+	// Assert
+	if err == nil {
+		t.Fatal("Run() error = nil, want dead-letter failure")
+	}
+	if len(r.committed) != 0 {
+		t.Errorf("committed %d messages, want 0", len(r.committed))
+	}
+}
+
+func TestChecker(t *testing.T) {
+	// A listener closed before the check leaves a port nothing answers on
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("Listen() error = %v", err)
+	}
+	closed := ln.Addr().String()
+	_ = ln.Close()
+
+	tests := []struct {
+		name    string
+		brokers []string
+	}{
+		{name: "no brokers", brokers: nil},
+		{name: "unreachable brokers", brokers: []string{closed, closed}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Arrange
+			ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+			defer cancel()
+
+			// Act
+			err := Checker(tt.brokers).Check(ctx)
+
+			// Assert
+			if err == nil {
+				t.Error("Check() error = nil, want an error")
+			}
+		})
+	}
+}
@@ -0,0 +1,42 @@
+package kafka
+
+import (
+	"context"
+	"fmt"
+
+	kafkago "github.com/segmentio/kafka-go"
+
+	"github.com/blackhorseya/go-ddd/internal/application/message"
+)
+
+// Producer publishes envelopes to Kafka. It is safe for concurrent use.
+type Producer struct {
+	w      writer
+	prefix string
+}
+
+var _ message.Publisher = (*Producer)(nil)
+
+// NewProducer creates a Producer. Connections are opened lazily.
+func NewProducer(cfg Config) *Producer {
+	cfg = cfg.withDefaults()
+	return &Producer{w: newWriter(cfg), prefix: cfg.TopicPrefix}
+}
+
+// Publish writes envs synchronously and returns once every broker replica
+// has acknowledged them.
+func (p *Producer) Publish(ctx context.Context, envs ...message.Envelope) error {
+	msgs := make([]kafkago.Message, len(envs))
+	for i, env := range envs {
+		msgs[i] = encode(ctx, p.prefix+env.Topic, env)
+	}
+	if err := p.w.WriteMessages(ctx, msgs...); err != nil {
+		return fmt.Errorf("kafka publish: %w", err)
+	}
+	return nil
+}
+
+// Close flushes pending writes and closes the connections.
+func (p *Producer) Close() error {
+	return p.w.Close()
+}
@@ -0,0 +1,129 @@
+package notifier
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+
+	"github.com/blackhorseya/go-ddd/internal/application/notify"
+)
+
+// capture is an HTTP server replying with status and recording requests.
+type capture struct {
+	status int
+	reqs   []*http.Request
+	bodies []string
+}
+
+func newCapture(t *testing.T, status int) (*capture, *httptest.Server) {
+	t.Helper()
+	c := &capture{status: status}
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		b, _ := io.ReadAll(r.Body)
+		c.reqs = append(c.reqs, r)
+		c.bodies = append(c.bodies, string(b))
+		w.WriteHeader(c.status)
+	}))
+	t.Cleanup(srv.Close)
+	return c, srv
+}
+
+func TestTwilio_Send(t *testing.T) {
+	tests := []struct {
+		name         string
+		status       int
+		to           notify.Recipient
+		wantErr      bool
+		wantRejected bool
+	}{
+		{name: "sent", status: http.StatusCreated, to: notify.Recipient{Phone: "+14155550100"}},
+		{name: "invalid number", status: http.StatusBadRequest, to: notify.Recipient{Phone: "+1"}, wantErr: true, wantRejected: true},
+		{name: "rate limited", status: http.StatusTooManyRequests, to: notify.Recipient{Phone: "+14155550100"}, wantErr: true},
+		{name: "no phone", status: http.StatusCreated, wantErr: true, wantRejected: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Arrange
+			c, srv := newCapture(t, tt.status)
+			tw := NewTwilio("AC1", "token", "+14155550199")
+			tw.baseURL = srv.URL
+
+			// Act
+			err := tw.Send(context.Background(), tt.to, Message{Body: "hello"})
+
+			// Assert
+			if (err != nil) != tt.wantErr || errors.Is(err, notify.ErrRejected) != tt.wantRejected {
+				t.Fatalf("Send() error = %v, wantErr %v, wantRejected %v", err, tt.wantErr, tt.wantRejected)
+			}
+			if tt.to.Phone == "" {
+				return
+			}
+			r := c.reqs[0]
+			user, pass, _ := r.BasicAuth()
+			form, _ := url.ParseQuery(c.bodies[0])
+			if r.URL.Path != "/2010-04-01/Accounts/AC1/Messages.json" || user != "AC1" || pass != "token" ||
+				form.Get("To") != tt.to.Phone || form.Get("From") != "+14155550199" || form.Get("Body") != "hello" {
+				t.Errorf("request = %s %v", r.URL.Path, form)
+			}
+		})
+	}
+}
+
+func TestSlack_Send(t *testing.T) {
+	// Arrange
+	c, srv := newCapture(t, http.StatusOK)
+	s := NewSlack(srv.URL + "/services/T/B/secret")
+
+	// Act
+	err := s.Send(context.Background(), notify.Recipient{}, Message{Title: "Shipped", Body: "order o1"})
+
+	// Assert
+	if err != nil {
+		t.Fatalf("Send() error = %v", err)
+	}
+	var payload map[string]string
+	_ = json.Unmarshal([]byte(c.bodies[0]), &payload)
+	if payload["text"] != "*Shipped*\norder o1" {
+		t.Errorf("payload = %v", payload)
+	}
+
+	srv.Close()
+	if err := s.Send(context.Background(), notify.Recipient{}, Message{Body: "x"}); err == nil || strings.Contains(err.Error(), "secret") {
+		t.Errorf("Send(closed) error = %v, want error without webhook URL", err)
+	}
+}
+
+func TestFCM_Send(t *testing.T) {
+	// Arrange
+	c, srv := newCapture(t, http.StatusOK)
+	f := newFCM(srv.URL, "proj", srv.Client())
+
+	// Act
+	err := f.Send(context.Background(), notify.Recipient{DeviceTokens: []string{"t1", "t2"}}, Message{Title: "Hi", Body: "hello"})
+
+	// Assert
+	if err != nil {
+		t.Fatalf("Send() error = %v", err)
+	}
+	if len(c.reqs) != 2 || c.reqs[0].URL.Path != "/v1/projects/proj/messages:send" {
+		t.Fatalf("requests = %d, path %q", len(c.reqs), c.reqs[0].URL.Path)
+	}
+	var req fcmRequest
+	_ = json.Unmarshal([]byte(c.bodies[1]), &req)
+	if req.Message.Token != "t2" || req.Message.Notification != (fcmNotification{Title: "Hi", Body: "hello"}) {
+		t.Errorf("payload = %+v", req)
+	}
+
+	c.status = http.StatusNotFound
+	err = f.Send(context.Background(), notify.Recipient{DeviceTokens: []string{"stale"}}, Message{Body: "x"})
+	if !errors.Is(err, notify.ErrRejected) {
+		t.Errorf("Send(stale token) error = %v, want %v", err, notify.ErrRejected)
+	}
+}
@@ -0,0 +1,110 @@
+package notifier
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"os"
+
+	"golang.org/x/oauth2"
+	"golang.org/x/oauth2/google"
+
+	"github.com/blackhorseya/go-ddd/internal/application/notify"
+)
+
+const fcmScope = "https://www.googleapis.com/auth/firebase.messaging"
+
+// FCM sends push notifications through the Firebase Cloud Messaging HTTP
+// v1 API, one request per device token.
+type FCM struct {
+	endpoint string
+	client   *http.Client
+}
+
+var _ Sender = (*FCM)(nil)
+
+// NewFCM creates a push sender for the Firebase project. Credentials come
+// from the service account JSON at credentialsFile, or from Application
+// Default Credentials when it is empty.
+func NewFCM(ctx context.Context, projectID, credentialsFile string) (*FCM, error) {
+	var creds *google.Credentials
+	var err error
+	if credentialsFile != "" {
+		data, readErr := os.ReadFile(credentialsFile)
+		if readErr != nil {
+			return nil, fmt.Errorf("read fcm credentials: %w", readErr)
+		}
+		creds, err = google.CredentialsFromJSON(ctx, data, fcmScope)
+	} else {
+		creds, err = google.FindDefaultCredentials(ctx, fcmScope)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("load fcm credentials: %w", err)
+	}
+
+	client := oauth2.NewClient(ctx, creds.TokenSource)
+	client.Timeout = defaultClient.Timeout
+	return newFCM("https://fcm.googleapis.com", projectID, client), nil
+}
+
+func newFCM(baseURL, projectID string, client *http.Client) *FCM {
+	return &FCM{
+		endpoint: baseURL + "/v1/projects/" + projectID + "/messages:send",
+		client:   client,
+	}
+}
+
+type fcmRequest struct {
+	Message fcmMessage `json:"message"`
+}
+
+type fcmMessage struct {
+	Token        string          `json:"token"`
+	Notification fcmNotification `json:"notification"`
+}
+
+type fcmNotification struct {
+	Title string `json:"title,omitempty"`
+	Body  string `json:"body"`
+}
+
+// Send pushes msg to every device token. A stale token fails with
+// notify.ErrRejected without stopping delivery to the others.
+func (f *FCM) Send(ctx context.Context, to notify.Recipient, msg Message) error {
+	if len(to.DeviceTokens) == 0 {
+		return fmt.Errorf("%w: recipient has no device tokens", notify.ErrRejected)
+	}
+	var errs []error
+	for _, token := range to.DeviceTokens {
+		if err := f.push(ctx, token, msg); err != nil {
+			errs = append(errs, fmt.Errorf("fcm: %w", err))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+func (f *FCM) push(ctx context.Context, token string, msg Message) error {
+	payload, err := json.Marshal(fcmRequest{Message: fcmMessage{
+		Token:        token,
+		Notification: fcmNotification{Title: msg.Title, Body: msg.Body},
+	}})
+	if err != nil {
+		return err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, f.endpoint, bytes.NewReader(payload))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := f.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	return checkResponse(resp)
+}
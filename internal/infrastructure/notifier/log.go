@@ -0,0 +1,26 @@
+package notifier
+
+import (
+	"context"
+
+	"github.com/blackhorseya/go-ddd/internal/application/notify"
+	"github.com/blackhorseya/go-ddd/pkg/contextx"
+)
+
+// LogSender logs messages instead of sending them, for channels without
+// credentials in development.
+type LogSender struct {
+	Channel notify.Channel
+}
+
+// Send logs the message.
+func (s LogSender) Send(ctx context.Context, to notify.Recipient, msg Message) error {
+	contextx.From(ctx).Info("notification not sent (no credentials)",
+		"channel", s.Channel,
+		"phone", to.Phone,
+		"devices", len(to.DeviceTokens),
+		"title", msg.Title,
+		"body", msg.Body,
+	)
+	return nil
+}
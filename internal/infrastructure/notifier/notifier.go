@@ -0,0 +1,138 @@
+// Package notifier provides notify.Notifier with Twilio SMS, Slack webhook
+// and Firebase Cloud Messaging channels. A Dispatcher renders a message per
+// channel from embedded templates and fans it out concurrently; channels
+// without credentials log messages instead of sending them.
+package notifier
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/blackhorseya/go-ddd/internal/application/notify"
+	"github.com/blackhorseya/go-ddd/internal/infrastructure/config"
+)
+
+// Message is a notification rendered for one channel.
+type Message struct {
+	Title string
+	Body  string
+}
+
+// Sender delivers messages on one channel. Permanent failures wrap
+// notify.ErrRejected.
+type Sender interface {
+	Send(ctx context.Context, to notify.Recipient, msg Message) error
+}
+
+// Dispatcher renders notifications and fans them out to channel senders.
+type Dispatcher struct {
+	templates *Templates
+	senders   map[notify.Channel]Sender
+}
+
+var _ notify.Notifier = (*Dispatcher)(nil)
+
+// NewDispatcher creates a Dispatcher delivering through senders.
+func NewDispatcher(templates *Templates, senders map[notify.Channel]Sender) *Dispatcher {
+	return &Dispatcher{templates: templates, senders: senders}
+}
+
+// New creates a Dispatcher for the channels in cfg, rendering the templates
+// embedded in this package. A channel without credentials gets a
+// LogSender, so development setups exercise every channel.
+func New(ctx context.Context, cfg config.Notify) (*Dispatcher, error) {
+	templates, err := DefaultTemplates()
+	if err != nil {
+		return nil, err
+	}
+
+	senders := map[notify.Channel]Sender{
+		notify.SMS:  LogSender{Channel: notify.SMS},
+		notify.Push: LogSender{Channel: notify.Push},
+		notify.Chat: LogSender{Channel: notify.Chat},
+	}
+	if cfg.Twilio.AccountSID != "" {
+		senders[notify.SMS] = NewTwilio(cfg.Twilio.AccountSID, cfg.Twilio.AuthToken, cfg.Twilio.From)
+	}
+	if cfg.Slack.WebhookURL != "" {
+		senders[notify.Chat] = NewSlack(cfg.Slack.WebhookURL)
+	}
+	if cfg.FCM.ProjectID != "" {
+		fcm, err := NewFCM(ctx, cfg.FCM.ProjectID, cfg.FCM.CredentialsFile)
+		if err != nil {
+			return nil, err
+		}
+		senders[notify.Push] = fcm
+	}
+	return NewDispatcher(templates, senders), nil
+}
+
+// Notify delivers n on each of its channels concurrently; with no channels
+// it uses every channel the template has a variant for. Failures are
+// joined and prefixed with the channel, and never stop other channels.
+func (d *Dispatcher) Notify(ctx context.Context, n notify.Notification) error {
+	channels := n.Channels
+	if len(channels) == 0 {
+		for ch := range d.senders {
+			if d.templates.Has(n.Template, ch) {
+				channels = append(channels, ch)
+			}
+		}
+		if len(channels) == 0 {
+			return fmt.Errorf("%w: %s", ErrUnknownTemplate, n.Template)
+		}
+	}
+	slices.Sort(channels)
+	channels = slices.Compact(channels)
+
+	errs := make([]error, len(channels))
+	var wg sync.WaitGroup
+	for i, ch := range channels {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if err := d.send(ctx, ch, n); err != nil {
+				errs[i] = fmt.Errorf("%s: %w", ch, err)
+			}
+		}()
+	}
+	wg.Wait()
+	return errors.Join(errs...)
+}
+
+func (d *Dispatcher) send(ctx context.Context, ch notify.Channel, n notify.Notification) error {
+	sender, ok := d.senders[ch]
+	if !ok {
+		return fmt.Errorf("%w: channel not configured", notify.ErrRejected)
+	}
+	body, err := d.templates.Render(n.Template, ch, n.Data)
+	if err != nil {
+		return err
+	}
+	return sender.Send(ctx, n.To, Message{Title: n.Title, Body: body})
+}
+
+// defaultClient is shared by the HTTP-based senders.
+var defaultClient = &http.Client{Timeout: 10 * time.Second}
+
+// checkResponse turns a non-2xx response into an error. Client errors other
+// than timeouts and rate limits are permanent.
+func checkResponse(resp *http.Response) error {
+	if resp.StatusCode >= 200 && resp.StatusCode < 300 {
+		return nil
+	}
+	b, _ := io.ReadAll(io.LimitReader(resp.Body, 1024))
+	err := fmt.Errorf("status %d: %s", resp.StatusCode, strings.TrimSpace(string(b)))
+	if resp.StatusCode >= 400 && resp.StatusCode < 500 &&
+		resp.StatusCode != http.StatusRequestTimeout && resp.StatusCode != http.StatusTooManyRequests {
+		return fmt.Errorf("%w: %w", notify.ErrRejected, err)
+	}
+	return err
+}
@@ -0,0 +1,124 @@
+package notifier
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"testing/fstest"
+
+	"github.com/blackhorseya/go-ddd/internal/application/notify"
+)
+
+// recordingSender records messages and fails with err.
+type recordingSender struct {
+	mu   sync.Mutex
+	err  error
+	sent []Message
+}
+
+func (s *recordingSender) Send(_ context.Context, _ notify.Recipient, msg Message) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.sent = append(s.sent, msg)
+	return s.err
+}
+
+func newTestDispatcher(t *testing.T) (*Dispatcher, map[notify.Channel]*recordingSender) {
+	t.Helper()
+	templates, err := ParseTemplates(fstest.MapFS{
+		"shipped.sms.tmpl":  {Data: []byte("Order {{.}} shipped\n")},
+		"shipped.chat.tmpl": {Data: []byte("*{{.}}* shipped")},
+	})
+	if err != nil {
+		t.Fatalf("ParseTemplates() error = %v", err)
+	}
+	senders := map[notify.Channel]*recordingSender{
+		notify.SMS:  {},
+		notify.Push: {},
+		notify.Chat: {},
+	}
+	channels := map[notify.Channel]Sender{}
+	for ch, s := range senders {
+		channels[ch] = s
+	}
+	return NewDispatcher(templates, channels), senders
+}
+
+func TestDispatcher_Notify(t *testing.T) {
+	tests := []struct {
+		name     string
+		channels []notify.Channel
+		wantSMS  int
+		wantChat int
+		wantErr  error
+	}{
+		{name: "requested channel", channels: []notify.Channel{notify.SMS}, wantSMS: 1},
+		{name: "duplicate channels are sent once", channels: []notify.Channel{notify.SMS, notify.SMS}, wantSMS: 1},
+		{name: "no channels fans out to every template variant", wantSMS: 1, wantChat: 1},
+		{name: "missing variant fails its channel only", channels: []notify.Channel{notify.Push, notify.Chat}, wantChat: 1, wantErr: ErrUnknownTemplate},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Arrange
+			d, senders := newTestDispatcher(t)
+
+			// Act
+			err := d.Notify(context.Background(), notify.Notification{
+				Template: "shipped",
+				Data:     "o1",
+				Title:    "Shipped",
+				Channels: tt.channels,
+			})
+
+			// Assert
+			if !errors.Is(err, tt.wantErr) || (tt.wantErr == nil && err != nil) {
+				t.Errorf("Notify() error = %v, want %v", err, tt.wantErr)
+			}
+			if got := len(senders[notify.SMS].sent); got != tt.wantSMS {
+				t.Errorf("sms sent = %d, want %d", got, tt.wantSMS)
+			}
+			if got := len(senders[notify.Chat].sent); got != tt.wantChat {
+				t.Errorf("chat sent = %d, want %d", got, tt.wantChat)
+			}
+			if len(senders[notify.Push].sent) != 0 {
+				t.Errorf("push sent = %d, want 0", len(senders[notify.Push].sent))
+			}
+		})
+	}
+}
+
+func TestDispatcher_ChannelFailureIsIsolated(t *testing.T) {
+	// Arrange
+	d, senders := newTestDispatcher(t)
+	senders[notify.SMS].err = notify.ErrRejected
+
+	// Act
+	err := d.Notify(context.Background(), notify.Notification{Template: "shipped", Data: "o1"})
+
+	// Assert
+	if !errors.Is(err, notify.ErrRejected) {
+		t.Errorf("Notify() error = %v, want %v", err, notify.ErrRejected)
+	}
+	chat := senders[notify.Chat].sent
+	if len(chat) != 1 || chat[0].Body != "*o1* shipped" {
+		t.Errorf("chat sent = %+v, want rendered chat message", chat)
+	}
+	if got := senders[notify.SMS].sent[0].Body; got != "Order o1 shipped" {
+		t.Errorf("sms body = %q, want trimmed render", got)
+	}
+}
+
+func TestDefaultTemplates(t *testing.T) {
+	templates, err := DefaultTemplates()
+	if err != nil {
+		t.Fatalf("DefaultTemplates() error = %v", err)
+	}
+	data := map[string]string{"OrderID": "o1", "TrackingURL": "https://t.example/o1"}
+	for _, ch := range []notify.Channel{notify.SMS, notify.Push, notify.Chat} {
+		if body, err := templates.Render("order_shipped", ch, data); err != nil || body == "" {
+			t.Errorf("Render(order_shipped, %s) = %q, %v", ch, body, err)
+		}
+	}
+}
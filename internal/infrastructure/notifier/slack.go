@@ -0,0 +1,60 @@
+package notifier
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+
+	"github.com/blackhorseya/go-ddd/internal/application/notify"
+)
+
+// Slack posts chat messages to a Slack incoming webhook. The webhook is
+// bound to one channel, so the recipient is ignored.
+type Slack struct {
+	webhookURL string
+	client     *http.Client
+}
+
+var _ Sender = (*Slack)(nil)
+
+// NewSlack creates a chat sender for the incoming webhook URL.
+func NewSlack(webhookURL string) *Slack {
+	return &Slack{webhookURL: webhookURL, client: defaultClient}
+}
+
+// Send posts the body, with the title in bold above it when set.
+func (s *Slack) Send(ctx context.Context, _ notify.Recipient, msg Message) error {
+	text := msg.Body
+	if msg.Title != "" {
+		text = "*" + msg.Title + "*\n" + text
+	}
+	payload, err := json.Marshal(map[string]string{"text": text})
+	if err != nil {
+		return err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, s.webhookURL, bytes.NewReader(payload))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := s.client.Do(req)
+	if err != nil {
+		// The webhook URL is a credential; keep it out of the error.
+		var uerr *url.Error
+		if errors.As(err, &uerr) {
+			err = uerr.Err
+		}
+		return fmt.Errorf("slack: %w", err)
+	}
+	defer resp.Body.Close()
+	if err := checkResponse(resp); err != nil {
+		return fmt.Errorf("slack: %w", err)
+	}
+	return nil
+}
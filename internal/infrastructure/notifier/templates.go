@@ -0,0 +1,73 @@
+package notifier
+
+import (
+	"bytes"
+	"embed"
+	"errors"
+	"fmt"
+	"io/fs"
+	"strings"
+	"text/template"
+
+	"github.com/blackhorseya/go-ddd/internal/application/notify"
+)
+
+// ErrUnknownTemplate is returned when a template has no variant for a
+// channel.
+var ErrUnknownTemplate = errors.New("unknown notification template")
+
+//go:embed templates
+var embedded embed.FS
+
+// Templates renders per-channel bodies from "<name>.<channel>.tmpl" files,
+// e.g. "order_shipped.sms.tmpl", so each channel gets wording that fits
+// its length limits and formatting.
+type Templates struct {
+	set *template.Template
+}
+
+// DefaultTemplates parses the templates embedded in the binary.
+func DefaultTemplates() (*Templates, error) {
+	sub, err := fs.Sub(embedded, "templates")
+	if err != nil {
+		return nil, err
+	}
+	return ParseTemplates(sub)
+}
+
+// ParseTemplates parses the "*.tmpl" files at the root of fsys.
+func ParseTemplates(fsys fs.FS) (*Templates, error) {
+	set := template.New("notify")
+	names, err := fs.Glob(fsys, "*.tmpl")
+	if err != nil {
+		return nil, err
+	}
+	if len(names) > 0 {
+		if set, err = set.ParseFS(fsys, names...); err != nil {
+			return nil, fmt.Errorf("parse notification templates: %w", err)
+		}
+	}
+	return &Templates{set: set}, nil
+}
+
+// Has reports whether name has a variant for ch.
+func (t *Templates) Has(name string, ch notify.Channel) bool {
+	return t.set.Lookup(file(name, ch)) != nil
+}
+
+// Render executes the variant of name for ch with data.
+func (t *Templates) Render(name string, ch notify.Channel, data any) (string, error) {
+	tmpl := t.set.Lookup(file(name, ch))
+	if tmpl == nil {
+		return "", fmt.Errorf("%w: %s for %s", ErrUnknownTemplate, name, ch)
+	}
+	var buf bytes.Buffer
+	if err := tmpl.Execute(&buf, data); err != nil {
+		return "", fmt.Errorf("render %s: %w", file(name, ch), err)
+	}
+	return strings.TrimSpace(buf.String()), nil
+}
+
+func file(name string, ch notify.Channel) string {
+	return name + "." + string(ch) + ".tmpl"
+}
@@ -0,0 +1,60 @@
+package notifier
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/blackhorseya/go-ddd/internal/application/notify"
+)
+
+// Twilio sends SMS through the Twilio Messages API.
+type Twilio struct {
+	accountSID string
+	authToken  string
+	from       string
+	baseURL    string
+	client     *http.Client
+}
+
+var _ Sender = (*Twilio)(nil)
+
+// NewTwilio creates an SMS sender for the account, sending from the
+// E.164 number or messaging service SID from.
+func NewTwilio(accountSID, authToken, from string) *Twilio {
+	return &Twilio{
+		accountSID: accountSID,
+		authToken:  authToken,
+		from:       from,
+		baseURL:    "https://api.twilio.com",
+		client:     defaultClient,
+	}
+}
+
+// Send texts the body to the recipient's phone.
+func (t *Twilio) Send(ctx context.Context, to notify.Recipient, msg Message) error {
+	if to.Phone == "" {
+		return fmt.Errorf("%w: recipient has no phone", notify.ErrRejected)
+	}
+	form := url.Values{"To": {to.Phone}, "From": {t.from}, "Body": {msg.Body}}
+	endpoint := t.baseURL + "/2010-04-01/Accounts/" + url.PathEscape(t.accountSID) + "/Messages.json"
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, endpoint, strings.NewReader(form.Encode()))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	req.SetBasicAuth(t.accountSID, t.authToken)
+
+	resp, err := t.client.Do(req)
+	if err != nil {
+		return fmt.Errorf("twilio: %w", err)
+	}
+	defer resp.Body.Close()
+	if err := checkResponse(resp); err != nil {
+		return fmt.Errorf("twilio: %w", err)
+	}
+	return nil
+}
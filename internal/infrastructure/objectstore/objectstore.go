@@ -0,0 +1,35 @@
+// Package objectstore builds the configured storagex backend.
+package objectstore
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+
+	"github.com/blackhorseya/go-ddd/internal/infrastructure/config"
+	"github.com/blackhorseya/go-ddd/pkg/storagex"
+)
+
+// New returns the traced backend selected by cfg.Driver. For the local
+// driver it also returns the handler serving presigned downloads, to be
+// mounted at cfg.BaseURL; S3 serves its own URLs and the handler is nil.
+func New(ctx context.Context, cfg config.Storage) (storagex.Storage, http.Handler, error) {
+	switch cfg.Driver {
+	case "", "local":
+		l := storagex.NewLocal(cfg.Dir, cfg.BaseURL, []byte(cfg.SigningKey))
+		return storagex.WithTracing(l, "local"), l, nil
+	case "s3":
+		s, err := storagex.NewS3(ctx, storagex.S3Config{
+			Bucket:       cfg.S3.Bucket,
+			Region:       cfg.S3.Region,
+			Endpoint:     cfg.S3.Endpoint,
+			UsePathStyle: cfg.S3.UsePathStyle,
+		})
+		if err != nil {
+			return nil, nil, err
+		}
+		return storagex.WithTracing(s, "s3"), nil, nil
+	default:
+		return nil, nil, fmt.Errorf("unknown storage driver %q", cfg.Driver)
+	}
+}
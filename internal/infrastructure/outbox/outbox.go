@@ -0,0 +1,60 @@
+// Package outbox implements the transactional outbox pattern.
+// Domain events are written to an outbox table in the same database
+// transaction as the aggregate that raised them, and a background Relay
+// publishes them to the message bus with at-least-once semantics.
+package outbox
+
+import (
+	_ "embed"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/blackhorseya/go-ddd/internal/domain"
+)
+
+// Schema is the DDL for the outbox table.
+//
+//go:embed schema.sql
+var Schema string
+
+// Message is a domain event stored in the outbox.
+type Message struct {
+	ID          int64
+	TxID        int64 // transaction that wrote the message, see Position
+	AggregateID string
+	EventName   string
+	Payload     []byte
+	Headers     map[string]string // message headers, see message.Inject
+	OccurredAt  time.Time
+	Attempts    int
+}
+
+// Position is a place in the outbox read as an event log, ordered by the
+// writing transaction and then by ID. IDs alone are not commit-ordered: a
+// transaction can take a lower ID and commit after a higher one has been
+// read, so readers that checkpoint an ID would skip it.
+type Position struct {
+	TxID int64
+	ID   int64
+}
+
+// Position returns the log position of m.
+func (m Message) Position() Position {
+	return Position{TxID: m.TxID, ID: m.ID}
+}
+
+// NewMessage converts a domain event to an outbox message.
+// The payload is the JSON encoding of the event's exported fields.
+func NewMessage(event domain.DomainEvent) (Message, error) {
+	payload, err := json.Marshal(event)
+	if err != nil {
+		return Message{}, fmt.Errorf("encode event %s: %w", event.Name(), err)
+	}
+	return Message{
+		AggregateID: event.AggregateID(),
+		EventName:   event.Name(),
+		Payload:     payload,
+		OccurredAt:  event.OccurredAt(),
+	}, nil
+}
@@ -0,0 +1,49 @@
+package outbox
+
+import (
+	"context"
+	"strconv"
+
+	"github.com/blackhorseya/go-ddd/internal/application/message"
+	"github.com/blackhorseya/go-ddd/pkg/contextx"
+)
+
+// LogPublisher is a Publisher that only logs messages.
+// Use it in development until a message bus adapter is configured.
+type LogPublisher struct{}
+
+// Publish logs the message.
+func (LogPublisher) Publish(ctx context.Context, msg Message) error {
+	contextx.From(ctx).Info("outbox message published",
+		"id", msg.ID,
+		"event", msg.EventName,
+		"aggregate_id", msg.AggregateID,
+	)
+	return nil
+}
+
+// BusPublisher publishes outbox messages to a message bus. The event name
+// is the topic and the aggregate ID the key, so each aggregate's events are
+// delivered in order.
+type BusPublisher struct {
+	bus message.Publisher
+}
+
+// NewBusPublisher creates a BusPublisher on bus.
+func NewBusPublisher(bus message.Publisher) *BusPublisher {
+	return &BusPublisher{bus: bus}
+}
+
+// Publish converts msg to an envelope identified by its outbox ID, carrying
+// the headers captured when the event was recorded.
+func (p *BusPublisher) Publish(ctx context.Context, msg Message) error {
+	return p.bus.Publish(ctx, message.Envelope{
+		ID:         "outbox-" + strconv.FormatInt(msg.ID, 10),
+		Topic:      msg.EventName,
+		Name:       msg.EventName,
+		Key:        msg.AggregateID,
+		Payload:    msg.Payload,
+		Headers:    msg.Headers,
+		OccurredAt: msg.OccurredAt,
+	})
+}
@@ -0,0 +1,178 @@
+package outbox
+
+import (
+	"context"
+	"time"
+
+	"github.com/blackhorseya/go-ddd/pkg/contextx"
+	"github.com/blackhorseya/go-ddd/pkg/runx"
+)
+
+// Default relay settings.
+const (
+	DefaultPollInterval = time.Second
+	DefaultBatchSize    = 100
+	DefaultLockTTL      = 30 * time.Second
+	DefaultMaxAttempts  = 10
+)
+
+// lockKey is the distributed lock taken around each poll.
+const lockKey = "outbox:relay"
+
+// Publisher publishes outbox messages to the message bus.
+type Publisher interface {
+	Publish(ctx context.Context, msg Message) error
+}
+
+// Locker acquires distributed locks, e.g. *lockx.Locker.
+type Locker interface {
+	TryLock(ctx context.Context, key string, ttl time.Duration) (unlock func(), ok bool, err error)
+}
+
+// RelayConfig configures the Relay.
+type RelayConfig struct {
+	// PollInterval is the delay between polls when the outbox is drained.
+	PollInterval time.Duration
+
+	// BatchSize is the maximum number of messages fetched per poll.
+	BatchSize int
+
+	// Locker, when set, lets only one relay instance poll at a time so
+	// replicas do not publish the same messages concurrently.
+	Locker Locker
+
+	// LockTTL bounds how long a crashed relay holds the lock.
+	// Default: 30s
+	LockTTL time.Duration
+
+	// MaxAttempts is how many publishes of a message may fail before it is
+	// parked and skipped, so one poison message cannot stall the outbox.
+	// Default: 10
+	MaxAttempts int
+}
+
+// Relay polls the outbox and publishes pending messages.
+// Messages are marked published only after the publisher succeeds, so a
+// message may be delivered more than once (at-least-once); consumers must be
+// idempotent. Run a single relay per database, or set RelayConfig.Locker
+// when several worker replicas run the relay.
+type Relay struct {
+	store     MessageStore
+	publisher Publisher
+	cfg       RelayConfig
+	started   runx.Signal
+}
+
+// NewRelay creates a new Relay, applying defaults for zero config values.
+func NewRelay(store MessageStore, publisher Publisher, cfg RelayConfig) *Relay {
+	if cfg.PollInterval <= 0 {
+		cfg.PollInterval = DefaultPollInterval
+	}
+	if cfg.BatchSize <= 0 {
+		cfg.BatchSize = DefaultBatchSize
+	}
+	if cfg.LockTTL <= 0 {
+		cfg.LockTTL = DefaultLockTTL
+	}
+	if cfg.MaxAttempts <= 0 {
+		cfg.MaxAttempts = DefaultMaxAttempts
+	}
+	return &Relay{store: store, publisher: publisher, cfg: cfg}
+}
+
+// Started returns a channel closed after the first successful poll.
+func (r *Relay) Started() <-chan struct{} {
+	return r.started.Done()
+}
+
+// Run polls and publishes until ctx is cancelled.
+func (r *Relay) Run(ctx context.Context) error {
+	logger := contextx.From(ctx)
+	logger.Info("outbox relay started",
+		"poll_interval", r.cfg.PollInterval.String(),
+		"batch_size", r.cfg.BatchSize,
+	)
+
+	ticker := time.NewTicker(r.cfg.PollInterval)
+	defer ticker.Stop()
+
+	for {
+		published, err := r.RelayOnce(ctx)
+		if err != nil {
+			logger.Error("outbox relay failed", "error", err)
+		} else {
+			r.started.Fire()
+		}
+
+		// Keep draining while full batches are published.
+		if err == nil && published == r.cfg.BatchSize && ctx.Err() == nil {
+			continue
+		}
+
+		select {
+		case <-ctx.Done():
+			logger.Info("outbox relay stopped")
+			return nil
+		case <-ticker.C:
+		}
+	}
+}
+
+// RelayOnce publishes one batch of pending messages and returns how many were published.
+// It stops at the first publish failure to preserve ordering; the failed
+// message is retried on the next poll. A message failing for the
+// MaxAttempts-th time is parked instead and the batch moves on, so messages
+// behind it are published out of order. With a Locker, a poll while another
+// instance holds the lock publishes nothing.
+func (r *Relay) RelayOnce(ctx context.Context) (int, error) {
+	if r.cfg.Locker != nil {
+		unlock, ok, err := r.cfg.Locker.TryLock(ctx, lockKey, r.cfg.LockTTL)
+		if err != nil {
+			return 0, err
+		}
+		if !ok {
+			return 0, nil
+		}
+		defer unlock()
+	}
+
+	msgs, err := r.store.FetchPending(ctx, r.cfg.BatchSize)
+	if err != nil {
+		return 0, err
+	}
+
+	published := 0
+	for _, m := range msgs {
+		if err := r.publisher.Publish(ctx, m); err != nil {
+			if m.Attempts+1 >= r.cfg.MaxAttempts {
+				contextx.From(ctx).Error("outbox message parked",
+					"id", m.ID,
+					"event", m.EventName,
+					"attempts", m.Attempts+1,
+					"error", err,
+				)
+				if err := r.store.MarkParked(ctx, m.ID, err); err != nil {
+					return published, err
+				}
+				continue
+			}
+
+			contextx.From(ctx).Warn("publish outbox message failed",
+				"id", m.ID,
+				"event", m.EventName,
+				"attempts", m.Attempts+1,
+				"error", err,
+			)
+			if err := r.store.MarkFailed(ctx, m.ID, err); err != nil {
+				return published, err
+			}
+			return published, nil
+		}
+
+		if err := r.store.MarkPublished(ctx, m.ID); err != nil {
+			return published, err
+		}
+		published++
+	}
+	return published, nil
+}
@@ -0,0 +1,330 @@
+package outbox
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/blackhorseya/go-ddd/internal/application/message"
+	"github.com/blackhorseya/go-ddd/internal/domain"
+)
+
+// fakeStore is an in-memory MessageStore.
+type fakeStore struct {
+	pending   []Message
+	published []int64
+	failed    map[int64]int
+	parked    []int64
+}
+
+func (s *fakeStore) FetchPending(_ context.Context, limit int) ([]Message, error) {
+	var msgs []Message
+	for _, m := range s.pending {
+		if !s.isPublished(m.ID) && !slices.Contains(s.parked, m.ID) && len(msgs) < limit {
+			m.Attempts = s.failed[m.ID]
+			msgs = append(msgs, m)
+		}
+	}
+	return msgs, nil
+}
+
+func (s *fakeStore) MarkPublished(_ context.Context, id int64) error {
+	s.published = append(s.published, id)
+	return nil
+}
+
+func (s *fakeStore) MarkFailed(_ context.Context, id int64, _ error) error {
+	if s.failed == nil {
+		s.failed = make(map[int64]int)
+	}
+	s.failed[id]++
+	return nil
+}
+
+func (s *fakeStore) MarkParked(ctx context.Context, id int64, cause error) error {
+	s.parked = append(s.parked, id)
+	return s.MarkFailed(ctx, id, cause)
+}
+
+func (s *fakeStore) isPublished(id int64) bool {
+	for _, p := range s.published {
+		if p == id {
+			return true
+		}
+	}
+	return false
+}
+
+// fakePublisher fails for the configured message IDs.
+type fakePublisher struct {
+	failIDs map[int64]bool
+	sent    []int64
+}
+
+func (p *fakePublisher) Publish(_ context.Context, msg Message) error {
+	if p.failIDs[msg.ID] {
+		return errors.New("broker unavailable")
+	}
+	p.sent = append(p.sent, msg.ID)
+	return nil
+}
+
+func TestRelay_RelayOnce(t *testing.T) {
+	tests := []struct {
+		name          string
+		failIDs       map[int64]bool
+		wantPublished int
+		wantFailed    map[int64]int
+	}{
+		{
+			name:          "publishes all pending messages",
+			wantPublished: 3,
+		},
+		{
+			name:          "stops at first failure",
+			failIDs:       map[int64]bool{2: true},
+			wantPublished: 1,
+			wantFailed:    map[int64]int{2: 1},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Arrange
+			store := &fakeStore{pending: []Message{{ID: 1}, {ID: 2}, {ID: 3}}}
+			pub := &fakePublisher{failIDs: tt.failIDs}
+			relay := NewRelay(store, pub, RelayConfig{BatchSize: 10})
+
+			// Act
+			published, err := relay.RelayOnce(context.Background())
+
+			// Assert
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if published != tt.wantPublished {
+				t.Errorf("published = %d, want %d", published, tt.wantPublished)
+			}
+			if len(store.published) != tt.wantPublished {
+				t.Errorf("store published = %v", store.published)
+			}
+			for id, n := range tt.wantFailed {
+				if store.failed[id] != n {
+					t.Errorf("failed[%d] = %d, want %d", id, store.failed[id], n)
+				}
+			}
+		})
+	}
+}
+
+func TestRelay_RelayOnceParksPoisonMessage(t *testing.T) {
+	// Arrange
+	store := &fakeStore{pending: []Message{{ID: 1}, {ID: 2}, {ID: 3}}}
+	pub := &fakePublisher{failIDs: map[int64]bool{2: true}}
+	relay := NewRelay(store, pub, RelayConfig{BatchSize: 10, MaxAttempts: 3})
+
+	// Act
+	var total int
+	for range 3 {
+		published, err := relay.RelayOnce(context.Background())
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		total += published
+	}
+
+	// Assert
+	if !reflect.DeepEqual(store.parked, []int64{2}) {
+		t.Errorf("parked = %v, want [2]", store.parked)
+	}
+	if store.failed[2] != 3 {
+		t.Errorf("failed[2] = %d, want 3 attempts", store.failed[2])
+	}
+	if total != 2 || !reflect.DeepEqual(pub.sent, []int64{1, 3}) {
+		t.Errorf("published = %d, sent = %v, want 1 and 3 sent", total, pub.sent)
+	}
+
+	// Act
+	published, err := relay.RelayOnce(context.Background())
+
+	// Assert
+	if err != nil || published != 0 || store.failed[2] != 3 {
+		t.Errorf("RelayOnce() after parking = %d, %v, failed[2] = %d, want parked message skipped",
+			published, err, store.failed[2])
+	}
+}
+
+// fakeLocker grants the lock when free is true.
+type fakeLocker struct {
+	free     bool
+	unlocked int
+}
+
+func (l *fakeLocker) TryLock(context.Context, string, time.Duration) (func(), bool, error) {
+	if !l.free {
+		return nil, false, nil
+	}
+	return func() { l.unlocked++ }, true, nil
+}
+
+func TestRelay_RelayOnceWithLocker(t *testing.T) {
+	tests := []struct {
+		name          string
+		free          bool
+		wantPublished int
+		wantUnlocked  int
+	}{
+		{"lock acquired", true, 1, 1},
+		{"lock held elsewhere", false, 0, 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Arrange
+			store := &fakeStore{pending: []Message{{ID: 1}}}
+			locker := &fakeLocker{free: tt.free}
+			relay := NewRelay(store, &fakePublisher{}, RelayConfig{Locker: locker})
+
+			// Act
+			published, err := relay.RelayOnce(context.Background())
+
+			// Assert
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if published != tt.wantPublished {
+				t.Errorf("published = %d, want %d", published, tt.wantPublished)
+			}
+			if locker.unlocked != tt.wantUnlocked {
+				t.Errorf("unlocked = %d, want %d", locker.unlocked, tt.wantUnlocked)
+			}
+		})
+	}
+}
+
+func TestRelay_RunStopsOnCancel(t *testing.T) {
+	store := &fakeStore{pending: []Message{{ID: 1}}}
+	pub := &fakePublisher{}
+	relay := NewRelay(store, pub, RelayConfig{})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	if err := relay.Run(ctx); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(pub.sent) != 1 {
+		t.Errorf("sent = %v, want one message relayed before stopping", pub.sent)
+	}
+}
+
+// errLocker fails every lock attempt, like an unreachable Redis.
+type errLocker struct{}
+
+func (errLocker) TryLock(context.Context, string, time.Duration) (func(), bool, error) {
+	return nil, false, errors.New("redis unavailable")
+}
+
+func TestRelay_Started(t *testing.T) {
+	tests := []struct {
+		name   string
+		locker Locker
+		want   bool
+	}{
+		{name: "after a successful poll", want: true},
+		{name: "not while polls fail", locker: errLocker{}, want: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Arrange
+			relay := NewRelay(&fakeStore{}, &fakePublisher{}, RelayConfig{Locker: tt.locker})
+			ctx, cancel := context.WithCancel(context.Background())
+			cancel()
+
+			// Act
+			_ = relay.Run(ctx)
+
+			// Assert
+			select {
+			case <-relay.Started():
+				if !tt.want {
+					t.Error("Started() closed, want open")
+				}
+			default:
+				if tt.want {
+					t.Error("Started() open, want closed")
+				}
+			}
+		})
+	}
+}
+
+type orderPlaced struct {
+	domain.BaseEvent
+	Total int `json:"total"`
+}
+
+func TestNewMessage(t *testing.T) {
+	event := orderPlaced{BaseEvent: domain.NewBaseEvent("order.placed", "order-1"), Total: 42}
+
+	msg, err := NewMessage(event)
+
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if msg.EventName != "order.placed" || msg.AggregateID != "order-1" {
+		t.Errorf("unexpected message: %+v", msg)
+	}
+	if string(msg.Payload) != `{"total":42}` {
+		t.Errorf("Payload = %s", msg.Payload)
+	}
+	if !msg.OccurredAt.Equal(event.OccurredAt()) {
+		t.Errorf("OccurredAt = %v, want %v", msg.OccurredAt, event.OccurredAt())
+	}
+}
+
+// busFunc adapts a function to message.Publisher.
+type busFunc func(ctx context.Context, envs ...message.Envelope) error
+
+func (f busFunc) Publish(ctx context.Context, envs ...message.Envelope) error { return f(ctx, envs...) }
+
+func TestBusPublisher_Publish(t *testing.T) {
+	// Arrange
+	var got []message.Envelope
+	p := NewBusPublisher(busFunc(func(_ context.Context, envs ...message.Envelope) error {
+		got = append(got, envs...)
+		return nil
+	}))
+	occurred := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	// Act
+	err := p.Publish(context.Background(), Message{
+		ID:          42,
+		AggregateID: "o-1",
+		EventName:   "order.placed",
+		Payload:     []byte(`{}`),
+		Headers:     map[string]string{message.HeaderRequestID: "req-1"},
+		OccurredAt:  occurred,
+	})
+
+	// Assert
+	if err != nil {
+		t.Fatalf("Publish() error = %v", err)
+	}
+	want := message.Envelope{
+		ID:         "outbox-42",
+		Topic:      "order.placed",
+		Name:       "order.placed",
+		Key:        "o-1",
+		Payload:    []byte(`{}`),
+		Headers:    map[string]string{message.HeaderRequestID: "req-1"},
+		OccurredAt: occurred,
+	}
+	if !reflect.DeepEqual(got, []message.Envelope{want}) {
+		t.Errorf("Publish() sent %+v, want %+v", got, want)
+	}
+}
@@ -0,0 +1,52 @@
+package outbox
+
+import (
+	"context"
+
+	"github.com/blackhorseya/go-ddd/internal/domain"
+)
+
+// Aggregate is implemented by aggregates embedding domain.AggregateRoot.
+type Aggregate interface {
+	Events() []domain.DomainEvent
+	ClearEvents()
+}
+
+// Saver persists an aggregate. Implementations should use
+// sqldb.Conn(ctx, db) so they join the transaction bound to ctx.
+type Saver[T Aggregate] interface {
+	Save(ctx context.Context, aggregate T) error
+}
+
+// Repository decorates a Saver so that the aggregate and its recorded
+// domain events are written to the outbox in one transaction.
+type Repository[T Aggregate] struct {
+	uow   domain.UnitOfWork
+	inner Saver[T]
+	store *Store
+}
+
+// NewRepository creates an outbox-backed repository decorator.
+func NewRepository[T Aggregate](uow domain.UnitOfWork, inner Saver[T], store *Store) *Repository[T] {
+	return &Repository[T]{uow: uow, inner: inner, store: store}
+}
+
+// Save persists the aggregate and its events atomically.
+// When ctx already carries a transaction, Save joins it.
+// Recorded events are cleared once the unit of work succeeds.
+func (r *Repository[T]) Save(ctx context.Context, aggregate T) error {
+	events := aggregate.Events()
+
+	err := r.uow.Do(ctx, func(ctx context.Context) error {
+		if err := r.inner.Save(ctx, aggregate); err != nil {
+			return err
+		}
+		return r.store.AddEvents(ctx, events...)
+	})
+	if err != nil {
+		return err
+	}
+
+	aggregate.ClearEvents()
+	return nil
+}
@@ -0,0 +1,25 @@
+-- Transactional outbox table (PostgreSQL).
+-- Domain events are inserted in the same transaction as the aggregate and
+-- published asynchronously by the outbox relay.
+CREATE TABLE IF NOT EXISTS outbox_messages (
+    id           BIGSERIAL PRIMARY KEY,
+    tx_id        BIGINT      NOT NULL DEFAULT pg_current_xact_id()::text::bigint,
+    aggregate_id TEXT        NOT NULL,
+    event_name   TEXT        NOT NULL,
+    payload      JSONB       NOT NULL,
+    headers      JSONB       NOT NULL DEFAULT '{}',
+    occurred_at  TIMESTAMPTZ NOT NULL,
+    created_at   TIMESTAMPTZ NOT NULL DEFAULT now(),
+    published_at TIMESTAMPTZ,
+    attempts     INT         NOT NULL DEFAULT 0,
+    last_error   TEXT,
+    parked_at    TIMESTAMPTZ -- set when the relay gave up, see Store.MarkParked
+);
+
+CREATE INDEX IF NOT EXISTS idx_outbox_messages_pending
+    ON outbox_messages (id)
+    WHERE published_at IS NULL AND parked_at IS NULL;
+
+-- Projections read the outbox in commit order, see Store.FetchAfter.
+CREATE INDEX IF NOT EXISTS idx_outbox_messages_tx
+    ON outbox_messages (tx_id, id);
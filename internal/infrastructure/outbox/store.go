@@ -0,0 +1,156 @@
+package outbox
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+
+	"github.com/blackhorseya/go-ddd/internal/application/message"
+	"github.com/blackhorseya/go-ddd/internal/domain"
+	"github.com/blackhorseya/go-ddd/internal/infrastructure/persistence/sqldb"
+)
+
+// MessageStore reads and updates pending outbox messages for the Relay.
+type MessageStore interface {
+	FetchPending(ctx context.Context, limit int) ([]Message, error)
+	MarkPublished(ctx context.Context, id int64) error
+	MarkFailed(ctx context.Context, id int64, cause error) error
+	MarkParked(ctx context.Context, id int64, cause error) error
+}
+
+// Store is the PostgreSQL outbox repository.
+type Store struct {
+	db *sql.DB
+}
+
+// NewStore creates a new Store.
+func NewStore(db *sql.DB) *Store {
+	return &Store{db: db}
+}
+
+// Add inserts messages. It joins the transaction bound to ctx, which should
+// be the one that persists the aggregate, and records the contextx values
+// and trace context of ctx as message headers.
+func (s *Store) Add(ctx context.Context, msgs ...Message) error {
+	const query = `INSERT INTO outbox_messages (aggregate_id, event_name, payload, headers, occurred_at)
+		VALUES ($1, $2, $3, $4, $5)`
+
+	conn := sqldb.Conn(ctx, s.db)
+	for _, m := range msgs {
+		headers, err := json.Marshal(message.Inject(ctx, message.Envelope{Headers: m.Headers}).Headers)
+		if err != nil {
+			return fmt.Errorf("encode outbox headers %s: %w", m.EventName, err)
+		}
+		if _, err := conn.ExecContext(ctx, query, m.AggregateID, m.EventName, m.Payload, headers, m.OccurredAt); err != nil {
+			return fmt.Errorf("insert outbox message %s: %w", m.EventName, err)
+		}
+	}
+	return nil
+}
+
+// AddEvents converts domain events to messages and inserts them.
+// Like Add, it joins the transaction bound to ctx.
+func (s *Store) AddEvents(ctx context.Context, events ...domain.DomainEvent) error {
+	msgs := make([]Message, 0, len(events))
+	for _, e := range events {
+		m, err := NewMessage(e)
+		if err != nil {
+			return err
+		}
+		msgs = append(msgs, m)
+	}
+	return s.Add(ctx, msgs...)
+}
+
+// FetchPending returns up to limit unpublished, unparked messages in insertion order.
+func (s *Store) FetchPending(ctx context.Context, limit int) ([]Message, error) {
+	const query = `SELECT id, tx_id, aggregate_id, event_name, payload, headers, occurred_at, attempts
+		FROM outbox_messages
+		WHERE published_at IS NULL AND parked_at IS NULL
+		ORDER BY id
+		LIMIT $1`
+
+	rows, err := s.db.QueryContext(ctx, query, limit)
+	if err != nil {
+		return nil, fmt.Errorf("query pending outbox messages: %w", err)
+	}
+	return scanMessages(rows)
+}
+
+// FetchAfter returns up to limit messages positioned after after, published
+// or not, in Position order. Projections use it to read the outbox as an
+// event log.
+//
+// Only messages of transactions older than every transaction still in
+// flight are returned, so a message committing late always sorts after
+// the ones already read and a checkpointed Position never skips it.
+func (s *Store) FetchAfter(ctx context.Context, after Position, limit int) ([]Message, error) {
+	const query = `SELECT id, tx_id, aggregate_id, event_name, payload, headers, occurred_at, attempts
+		FROM outbox_messages
+		WHERE (tx_id, id) > ($1, $2)
+		  AND tx_id < pg_snapshot_xmin(pg_current_snapshot())::text::bigint
+		ORDER BY tx_id, id
+		LIMIT $3`
+
+	rows, err := s.db.QueryContext(ctx, query, after.TxID, after.ID, limit)
+	if err != nil {
+		return nil, fmt.Errorf("query outbox messages after %d/%d: %w", after.TxID, after.ID, err)
+	}
+	return scanMessages(rows)
+}
+
+// scanMessages reads and closes rows selected with the column list used by
+// FetchPending and FetchAfter.
+func scanMessages(rows *sql.Rows) ([]Message, error) {
+	defer rows.Close()
+
+	var msgs []Message
+	for rows.Next() {
+		var (
+			m       Message
+			headers []byte
+		)
+		if err := rows.Scan(&m.ID, &m.TxID, &m.AggregateID, &m.EventName, &m.Payload, &headers, &m.OccurredAt, &m.Attempts); err != nil {
+			return nil, fmt.Errorf("scan outbox message: %w", err)
+		}
+		if err := json.Unmarshal(headers, &m.Headers); err != nil {
+			return nil, fmt.Errorf("decode outbox message %d headers: %w", m.ID, err)
+		}
+		msgs = append(msgs, m)
+	}
+	return msgs, rows.Err()
+}
+
+// MarkPublished marks a message as published.
+func (s *Store) MarkPublished(ctx context.Context, id int64) error {
+	const query = `UPDATE outbox_messages SET published_at = now() WHERE id = $1`
+
+	if _, err := s.db.ExecContext(ctx, query, id); err != nil {
+		return fmt.Errorf("mark outbox message %d published: %w", id, err)
+	}
+	return nil
+}
+
+// MarkFailed records a failed publish attempt.
+func (s *Store) MarkFailed(ctx context.Context, id int64, cause error) error {
+	const query = `UPDATE outbox_messages SET attempts = attempts + 1, last_error = $2 WHERE id = $1`
+
+	if _, err := s.db.ExecContext(ctx, query, id, cause.Error()); err != nil {
+		return fmt.Errorf("mark outbox message %d failed: %w", id, err)
+	}
+	return nil
+}
+
+// MarkParked records a final failed publish attempt and parks the message so
+// FetchPending skips it. Clear parked_at to retry a parked message.
+func (s *Store) MarkParked(ctx context.Context, id int64, cause error) error {
+	const query = `UPDATE outbox_messages
+		SET attempts = attempts + 1, last_error = $2, parked_at = now()
+		WHERE id = $1`
+
+	if _, err := s.db.ExecContext(ctx, query, id, cause.Error()); err != nil {
+		return fmt.Errorf("mark outbox message %d parked: %w", id, err)
+	}
+	return nil
+}
@@ -0,0 +1,54 @@
+// Package dynamodb implements repositories on Amazon DynamoDB using a
+// single-table design: every aggregate lives in one table under a
+// "<TYPE>#<id>" partition key, and a generic collection index lists each
+// aggregate type in sort-key order for cursor pagination.
+//
+// Table layout (create with the AWS CLI, CloudFormation or Terraform):
+//
+//	pk (S, partition key), sk (S, sort key)
+//	GSI gsi1: gsi1pk (S, partition key), gsi1sk (S, sort key), projection ALL
+package dynamodb
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	awsconfig "github.com/aws/aws-sdk-go-v2/config"
+	awsdynamodb "github.com/aws/aws-sdk-go-v2/service/dynamodb"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb/types"
+
+	"github.com/blackhorseya/go-ddd/internal/infrastructure/config"
+)
+
+// API is the subset of *dynamodb.Client used by repositories.
+type API interface {
+	GetItem(ctx context.Context, in *awsdynamodb.GetItemInput, optFns ...func(*awsdynamodb.Options)) (*awsdynamodb.GetItemOutput, error)
+	PutItem(ctx context.Context, in *awsdynamodb.PutItemInput, optFns ...func(*awsdynamodb.Options)) (*awsdynamodb.PutItemOutput, error)
+	UpdateItem(ctx context.Context, in *awsdynamodb.UpdateItemInput, optFns ...func(*awsdynamodb.Options)) (*awsdynamodb.UpdateItemOutput, error)
+	Query(ctx context.Context, in *awsdynamodb.QueryInput, optFns ...func(*awsdynamodb.Options)) (*awsdynamodb.QueryOutput, error)
+}
+
+var _ API = (*awsdynamodb.Client)(nil)
+
+// NewClient creates a client from cfg. Credentials come from the default
+// AWS chain (environment, shared config, instance role); Endpoint points
+// the client at DynamoDB Local or LocalStack in development.
+func NewClient(ctx context.Context, cfg config.DynamoDB) (*awsdynamodb.Client, error) {
+	awsCfg, err := awsconfig.LoadDefaultConfig(ctx, awsconfig.WithRegion(cfg.Region))
+	if err != nil {
+		return nil, fmt.Errorf("load aws config: %w", err)
+	}
+	return awsdynamodb.NewFromConfig(awsCfg, func(o *awsdynamodb.Options) {
+		if cfg.Endpoint != "" {
+			o.BaseEndpoint = aws.String(cfg.Endpoint)
+		}
+	}), nil
+}
+
+// isConditionFailed reports whether err is a failed condition expression.
+func isConditionFailed(err error) bool {
+	var ccf *types.ConditionalCheckFailedException
+	return errors.As(err, &ccf)
+}
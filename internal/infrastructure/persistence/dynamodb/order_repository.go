@@ -0,0 +1,104 @@
+package dynamodb
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/feature/dynamodb/expression"
+
+	"github.com/blackhorseya/go-ddd/internal/domain"
+	"github.com/blackhorseya/go-ddd/internal/domain/order"
+	"github.com/blackhorseya/go-ddd/internal/domain/valueobject"
+	"github.com/blackhorseya/go-ddd/internal/infrastructure/persistence/sqldb"
+)
+
+// orderType prefixes order keys and names the order collection.
+const orderType = "ORDER"
+
+// orderItem is the DynamoDB item of an order.
+type orderItem struct {
+	ID          string     `dynamodbav:"id"`
+	CustomerID  string     `dynamodbav:"customer_id"`
+	Status      string     `dynamodbav:"status"`
+	TotalAmount int64      `dynamodbav:"total_amount"`
+	Currency    string     `dynamodbav:"currency"`
+	Version     int        `dynamodbav:"version"`
+	CreatedAt   time.Time  `dynamodbav:"created_at"`
+	UpdatedAt   time.Time  `dynamodbav:"updated_at"`
+	CreatedBy   string     `dynamodbav:"created_by"`
+	UpdatedBy   string     `dynamodbav:"updated_by"`
+	DeletedAt   *time.Time `dynamodbav:"deleted_at,omitempty"`
+}
+
+// OrderRepository is the order.Repository backed by DynamoDB.
+type OrderRepository struct {
+	*Repository[*order.Order, string, orderItem]
+}
+
+var _ order.Repository = (*OrderRepository)(nil)
+
+// NewOrderRepository creates an OrderRepository on table.
+func NewOrderRepository(api API, table string) *OrderRepository {
+	return &OrderRepository{NewRepository(api, Config[*order.Order, string, orderItem]{
+		Table:     Table{Name: table},
+		Type:      orderType,
+		ToItem:    toOrderItem,
+		ToDomain:  toOrder,
+		SortKeyOf: func(o *order.Order) string { return TimeKey(o.CreatedAt(), o.ID()) },
+		Filter:    orderFilter,
+	})}
+}
+
+// CountByCustomer returns the number of live orders of a customer.
+func (r *OrderRepository) CountByCustomer(ctx context.Context, customerID string) (int64, error) {
+	n, err := r.Count(ctx, order.CustomerSpec{CustomerID: customerID})
+	if err != nil {
+		return 0, fmt.Errorf("count orders of customer %s: %w", customerID, err)
+	}
+	return n, nil
+}
+
+// orderFilter translates order specifications into filter expressions.
+func orderFilter(spec domain.Specification[*order.Order]) (expression.ConditionBuilder, error) {
+	switch s := spec.(type) {
+	case order.StatusSpec:
+		return expression.Name("status").Equal(expression.Value(string(s.Status))), nil
+	case order.CustomerSpec:
+		return expression.Name("customer_id").Equal(expression.Value(s.CustomerID)), nil
+	default:
+		return expression.ConditionBuilder{}, fmt.Errorf("%w: %T", sqldb.ErrUnsupportedSpec, spec)
+	}
+}
+
+// toOrderItem maps an order to its item.
+func toOrderItem(o *order.Order, version int) orderItem {
+	return orderItem{
+		ID:          o.ID(),
+		CustomerID:  o.CustomerID(),
+		Status:      string(o.Status()),
+		TotalAmount: o.Total().Amount(),
+		Currency:    o.Total().Currency().Code(),
+		Version:     version,
+		CreatedAt:   o.CreatedAt(),
+		UpdatedAt:   o.UpdatedAt(),
+		CreatedBy:   o.CreatedBy(),
+		UpdatedBy:   o.UpdatedBy(),
+		DeletedAt:   OptionalTime(o.DeletedAt()),
+	}
+}
+
+// toOrder reconstitutes an order from its item.
+func toOrder(it orderItem) (*order.Order, error) {
+	total, err := valueobject.NewMoney(it.TotalAmount, it.Currency)
+	if err != nil {
+		return nil, fmt.Errorf("order %s: %w", it.ID, err)
+	}
+
+	root := domain.RestoreAggregateRoot(it.ID, it.CreatedAt, it.UpdatedAt, it.Version)
+	root.Entity = root.Entity.WithAudit(it.CreatedBy, it.UpdatedBy)
+	if it.DeletedAt != nil {
+		root.Entity = root.Entity.WithDeletedAt(*it.DeletedAt)
+	}
+	return order.Restore(root, it.CustomerID, order.Status(it.Status), total), nil
+}
@@ -0,0 +1,441 @@
+package dynamodb
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/feature/dynamodb/attributevalue"
+	"github.com/aws/aws-sdk-go-v2/feature/dynamodb/expression"
+	awsdynamodb "github.com/aws/aws-sdk-go-v2/service/dynamodb"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb/types"
+
+	"github.com/blackhorseya/go-ddd/internal/domain"
+	"github.com/blackhorseya/go-ddd/internal/infrastructure/persistence/sqldb"
+)
+
+// Attribute conventions shared by items used with Repository, matching the
+// SQL repositories' columns.
+const (
+	versionAttr   = "version"
+	updatedAtAttr = "updated_at"
+	updatedByAttr = "updated_by"
+	deletedAtAttr = "deleted_at"
+)
+
+// Aggregate is what Repository needs from an aggregate root. Pointers to
+// types embedding domain.AggregateRoot satisfy it.
+type Aggregate[ID comparable] interface {
+	ID() ID
+	Version() int
+	IncrementVersion()
+	sqldb.Auditable
+}
+
+// Config configures a Repository for aggregate T stored as item I. Items
+// are marshalled with attributevalue and must use the attribute names
+// version, updated_at, updated_by and deleted_at (omitempty) for those fields.
+type Config[T Aggregate[ID], ID comparable, I any] struct {
+	// Table is the single table.
+	Table Table
+
+	// Collection is the index listing every aggregate of this type.
+	Collection Index
+
+	// Type prefixes keys and is the collection partition, e.g. "ORDER".
+	Type string
+
+	// ToItem converts an aggregate to its item, storing version as the item version.
+	ToItem func(aggregate T, version int) I
+
+	// ToDomain reconstitutes an aggregate from its item.
+	ToDomain func(I) (T, error)
+
+	// SortKeyOf returns the collection sort key, normally
+	// TimeKey(created_at, id). Required.
+	SortKeyOf func(T) string
+
+	// SortField is the domain sort field backed by the collection sort key.
+	// Default: "created_at"
+	SortField string
+
+	// Filter translates specifications into filter expressions.
+	// Optional: without it only nil specifications are accepted.
+	Filter func(domain.Specification[T]) (expression.ConditionBuilder, error)
+}
+
+// Repository is a generic domain.Repository backed by DynamoDB. Embed it
+// in aggregate repositories and add aggregate-specific queries alongside.
+//
+// Writes use condition expressions for optimistic locking. Lists query the
+// collection index, so the only sort is its sort key; specifications and
+// soft deletion become filter expressions.
+type Repository[T Aggregate[ID], ID comparable, I any] struct {
+	api API
+	cfg Config[T, ID, I]
+	now func() time.Time
+}
+
+// NewRepository creates a Repository, applying defaults for zero config values.
+func NewRepository[T Aggregate[ID], ID comparable, I any](api API, cfg Config[T, ID, I]) *Repository[T, ID, I] {
+	cfg.Table = cfg.Table.withDefaults()
+	cfg.Collection = cfg.Collection.withDefaults()
+	if cfg.SortField == "" {
+		cfg.SortField = "created_at"
+	}
+	return &Repository[T, ID, I]{api: api, cfg: cfg, now: time.Now}
+}
+
+// API returns the client, for aggregate-specific queries in embedding repositories.
+func (r *Repository[T, ID, I]) API() API {
+	return r.api
+}
+
+// Get returns the live aggregate with the given ID, or domain.ErrNotFound.
+func (r *Repository[T, ID, I]) Get(ctx context.Context, id ID) (T, error) {
+	var zero T
+	out, err := r.api.GetItem(ctx, &awsdynamodb.GetItemInput{
+		TableName:      aws.String(r.cfg.Table.Name),
+		Key:            r.key(id),
+		ConsistentRead: aws.Bool(true),
+	})
+	if err != nil {
+		return zero, fmt.Errorf("get %v: %w", id, err)
+	}
+	if out.Item == nil || deleted(out.Item) {
+		return zero, domain.ErrNotFound
+	}
+	return r.toDomain(out.Item)
+}
+
+// Save inserts a new aggregate (version 0) or replaces a live one whose
+// stored version still matches, returning domain.ErrConcurrentModification
+// otherwise, including when a new aggregate's ID is taken. On success the
+// aggregate version is incremented.
+func (r *Repository[T, ID, I]) Save(ctx context.Context, aggregate T) error {
+	version := aggregate.Version()
+
+	var cond expression.ConditionBuilder
+	if version == 0 {
+		sqldb.BeforeInsert(ctx, aggregate)
+		cond = expression.AttributeNotExists(expression.Name(r.cfg.Table.PartitionKey))
+	} else {
+		sqldb.BeforeUpdate(ctx, aggregate)
+		cond = expression.Name(versionAttr).Equal(expression.Value(version)).
+			And(expression.AttributeNotExists(expression.Name(deletedAtAttr)))
+	}
+
+	item, err := attributevalue.MarshalMap(r.cfg.ToItem(aggregate, version+1))
+	if err != nil {
+		return fmt.Errorf("marshal %v: %w", aggregate.ID(), err)
+	}
+	for k, v := range r.key(aggregate.ID()) {
+		item[k] = v
+	}
+	item[r.cfg.Collection.PartitionKey] = &types.AttributeValueMemberS{Value: r.cfg.Type}
+	item[r.cfg.Collection.SortKey] = &types.AttributeValueMemberS{Value: r.cfg.SortKeyOf(aggregate)}
+
+	expr, err := expression.NewBuilder().WithCondition(cond).Build()
+	if err != nil {
+		return err
+	}
+	_, err = r.api.PutItem(ctx, &awsdynamodb.PutItemInput{
+		TableName:                 aws.String(r.cfg.Table.Name),
+		Item:                      item,
+		ConditionExpression:       expr.Condition(),
+		ExpressionAttributeNames:  expr.Names(),
+		ExpressionAttributeValues: expr.Values(),
+	})
+	if isConditionFailed(err) {
+		return domain.ErrConcurrentModification
+	}
+	if err != nil {
+		return fmt.Errorf("put %v: %w", aggregate.ID(), err)
+	}
+	aggregate.IncrementVersion()
+	return nil
+}
+
+// Delete soft-deletes the aggregate and bumps its version, or returns domain.ErrNotFound.
+func (r *Repository[T, ID, I]) Delete(ctx context.Context, id ID) error {
+	now := r.now().UTC()
+	update := expression.Set(expression.Name(deletedAtAttr), expression.Value(now)).
+		Set(expression.Name(updatedAtAttr), expression.Value(now)).
+		Set(expression.Name(updatedByAttr), expression.Value(sqldb.Actor(ctx))).
+		Add(expression.Name(versionAttr), expression.Value(1))
+	cond := expression.AttributeExists(expression.Name(r.cfg.Table.PartitionKey)).
+		And(expression.AttributeNotExists(expression.Name(deletedAtAttr)))
+	expr, err := expression.NewBuilder().WithUpdate(update).WithCondition(cond).Build()
+	if err != nil {
+		return err
+	}
+
+	_, err = r.api.UpdateItem(ctx, &awsdynamodb.UpdateItemInput{
+		TableName:                 aws.String(r.cfg.Table.Name),
+		Key:                       r.key(id),
+		UpdateExpression:          expr.Update(),
+		ConditionExpression:       expr.Condition(),
+		ExpressionAttributeNames:  expr.Names(),
+		ExpressionAttributeValues: expr.Values(),
+	})
+	if isConditionFailed(err) {
+		return domain.ErrNotFound
+	}
+	if err != nil {
+		return fmt.Errorf("delete %v: %w", id, err)
+	}
+	return nil
+}
+
+// FindPage returns the live aggregates satisfying spec as an offset page
+// with a total count. DynamoDB cannot skip or count without reading, so
+// this reads the whole filtered collection; prefer FindCursor for large
+// collections.
+func (r *Repository[T, ID, I]) FindPage(ctx context.Context, spec domain.Specification[T], page domain.PageRequest) (domain.PageResult[T], error) {
+	in, err := r.queryInput(spec, page.Sort())
+	if err != nil {
+		return domain.PageResult[T]{}, err
+	}
+
+	var (
+		total int64
+		items []T
+	)
+	err = r.each(ctx, in, func(raw map[string]types.AttributeValue) (bool, error) {
+		if total >= int64(page.Offset()) && len(items) < page.Limit() {
+			item, err := r.toDomain(raw)
+			if err != nil {
+				return false, err
+			}
+			items = append(items, item)
+		}
+		total++
+		return true, nil
+	})
+	if err != nil {
+		return domain.PageResult[T]{}, err
+	}
+	if items == nil {
+		items = []T{}
+	}
+	return domain.NewPageResult(items, page.Page(), page.PageSize(), total), nil
+}
+
+// FindCursor returns the live aggregates satisfying spec as a keyset page
+// over the collection sort key. The cursor is the item's key attributes,
+// i.e. a DynamoDB ExclusiveStartKey, in the domain keyset cursor format.
+func (r *Repository[T, ID, I]) FindCursor(ctx context.Context, spec domain.Specification[T], req domain.CursorRequest) (domain.CursorResult[T], error) {
+	in, err := r.queryInput(spec, req.Sort())
+	if err != nil {
+		return domain.CursorResult[T]{}, err
+	}
+	if req.HasCursor() {
+		start, err := r.decodeCursor(req.Cursor())
+		if err != nil {
+			return domain.CursorResult[T]{}, err
+		}
+		in.ExclusiveStartKey = start
+	}
+
+	// Filters apply after Limit, so keep reading until a full page plus one
+	// item (to learn whether more follow) has matched.
+	var raws []map[string]types.AttributeValue
+	err = r.each(ctx, in, func(raw map[string]types.AttributeValue) (bool, error) {
+		raws = append(raws, raw)
+		return len(raws) <= req.Limit(), nil
+	})
+	if err != nil {
+		return domain.CursorResult[T]{}, err
+	}
+
+	raws, hasMore := sqldb.TrimPage(raws, req.Limit())
+	items := make([]T, 0, len(raws))
+	for _, raw := range raws {
+		item, err := r.toDomain(raw)
+		if err != nil {
+			return domain.CursorResult[T]{}, err
+		}
+		items = append(items, item)
+	}
+
+	var next string
+	if hasMore {
+		next, err = r.encodeCursor(req, raws[len(raws)-1])
+		if err != nil {
+			return domain.CursorResult[T]{}, err
+		}
+	}
+	return domain.NewCursorResult(items, next, "", hasMore), nil
+}
+
+// Count returns the number of live aggregates satisfying spec.
+func (r *Repository[T, ID, I]) Count(ctx context.Context, spec domain.Specification[T]) (int64, error) {
+	in, err := r.queryInput(spec, nil)
+	if err != nil {
+		return 0, err
+	}
+	in.Select = types.SelectCount
+
+	var n int64
+	for {
+		out, err := r.api.Query(ctx, in)
+		if err != nil {
+			return 0, fmt.Errorf("count %s: %w", r.cfg.Type, err)
+		}
+		n += int64(out.Count)
+		if out.LastEvaluatedKey == nil {
+			return n, nil
+		}
+		in.ExclusiveStartKey = out.LastEvaluatedKey
+	}
+}
+
+// queryInput builds a query over the live items of the collection
+// satisfying spec, ordered by sort.
+func (r *Repository[T, ID, I]) queryInput(spec domain.Specification[T], sort []domain.SortOption) (*awsdynamodb.QueryInput, error) {
+	if err := domain.ValidateSortFields(sort, r.cfg.SortField); err != nil {
+		return nil, err
+	}
+	if len(sort) > 1 {
+		return nil, fmt.Errorf("%w: only %q is sortable", domain.ErrInvalidSortField, r.cfg.SortField)
+	}
+	ascending := len(sort) == 1 && sort[0].IsAscending()
+
+	filter := expression.AttributeNotExists(expression.Name(deletedAtAttr))
+	if spec != nil {
+		if r.cfg.Filter == nil {
+			return nil, fmt.Errorf("%w: %T", sqldb.ErrUnsupportedSpec, spec)
+		}
+		f, err := r.cfg.Filter(spec)
+		if err != nil {
+			return nil, err
+		}
+		filter = filter.And(f)
+	}
+
+	keyCond := expression.Key(r.cfg.Collection.PartitionKey).Equal(expression.Value(r.cfg.Type))
+	expr, err := expression.NewBuilder().WithKeyCondition(keyCond).WithFilter(filter).Build()
+	if err != nil {
+		return nil, err
+	}
+	return &awsdynamodb.QueryInput{
+		TableName:                 aws.String(r.cfg.Table.Name),
+		IndexName:                 aws.String(r.cfg.Collection.Name),
+		KeyConditionExpression:    expr.KeyCondition(),
+		FilterExpression:          expr.Filter(),
+		ExpressionAttributeNames:  expr.Names(),
+		ExpressionAttributeValues: expr.Values(),
+		ScanIndexForward:          aws.Bool(ascending),
+	}, nil
+}
+
+// each runs in page by page, calling fn for each item until fn returns
+// false or the results are exhausted.
+func (r *Repository[T, ID, I]) each(ctx context.Context, in *awsdynamodb.QueryInput, fn func(map[string]types.AttributeValue) (bool, error)) error {
+	for {
+		out, err := r.api.Query(ctx, in)
+		if err != nil {
+			return fmt.Errorf("query %s: %w", r.cfg.Type, err)
+		}
+		for _, raw := range out.Items {
+			more, err := fn(raw)
+			if err != nil || !more {
+				return err
+			}
+		}
+		if out.LastEvaluatedKey == nil {
+			return nil
+		}
+		in.ExclusiveStartKey = out.LastEvaluatedKey
+	}
+}
+
+// key returns the primary key of the aggregate with the given ID.
+func (r *Repository[T, ID, I]) key(id ID) map[string]types.AttributeValue {
+	k := Key(r.cfg.Type, fmt.Sprint(id))
+	return map[string]types.AttributeValue{
+		r.cfg.Table.PartitionKey: &types.AttributeValueMemberS{Value: k},
+		r.cfg.Table.SortKey:      &types.AttributeValueMemberS{Value: k},
+	}
+}
+
+// keyAttrs are the attributes of a collection query's LastEvaluatedKey.
+func (r *Repository[T, ID, I]) keyAttrs() []string {
+	return []string{
+		r.cfg.Table.PartitionKey,
+		r.cfg.Table.SortKey,
+		r.cfg.Collection.PartitionKey,
+		r.cfg.Collection.SortKey,
+	}
+}
+
+// encodeCursor encodes the key attributes of raw as the keyset cursor of
+// the page following req.
+func (r *Repository[T, ID, I]) encodeCursor(req domain.CursorRequest, raw map[string]types.AttributeValue) (string, error) {
+	attrs := r.keyAttrs()
+	values := make([]any, len(attrs))
+	for i, name := range attrs {
+		s, ok := raw[name].(*types.AttributeValueMemberS)
+		if !ok {
+			return "", fmt.Errorf("%w: key attribute %s missing", domain.ErrInvalidCursor, name)
+		}
+		values[i] = s.Value
+	}
+	cursor, err := domain.NewKeysetCursor(values...)
+	if err != nil {
+		return "", err
+	}
+	return req.NextCursor(cursor.Strings()...)
+}
+
+// decodeCursor restores the ExclusiveStartKey encoded by encodeCursor.
+func (r *Repository[T, ID, I]) decodeCursor(cursor string) (map[string]types.AttributeValue, error) {
+	k, err := domain.DecodeKeysetCursor(cursor)
+	if err != nil {
+		return nil, err
+	}
+	attrs := r.keyAttrs()
+	if k.Len() != len(attrs) {
+		return nil, domain.ErrInvalidCursor
+	}
+
+	start := make(map[string]types.AttributeValue, len(attrs))
+	for i, v := range k.Values() {
+		s, ok := v.(string)
+		if !ok {
+			return nil, domain.ErrInvalidCursor
+		}
+		start[attrs[i]] = &types.AttributeValueMemberS{Value: s}
+	}
+	return start, nil
+}
+
+// toDomain unmarshals raw and reconstitutes the aggregate.
+func (r *Repository[T, ID, I]) toDomain(raw map[string]types.AttributeValue) (T, error) {
+	var zero T
+	var item I
+	if err := attributevalue.UnmarshalMap(raw, &item); err != nil {
+		return zero, fmt.Errorf("unmarshal %s: %w", r.cfg.Type, err)
+	}
+	return r.cfg.ToDomain(item)
+}
+
+// deleted reports whether raw is soft-deleted.
+func deleted(raw map[string]types.AttributeValue) bool {
+	v, ok := raw[deletedAtAttr]
+	if !ok {
+		return false
+	}
+	_, null := v.(*types.AttributeValueMemberNULL)
+	return !null
+}
+
+// OptionalTime returns nil for the zero time, so an omitempty attribute
+// such as deleted_at is left out of the item.
+func OptionalTime(t time.Time) *time.Time {
+	if t.IsZero() {
+		return nil
+	}
+	return &t
+}
@@ -0,0 +1,305 @@
+package dynamodb
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	awsdynamodb "github.com/aws/aws-sdk-go-v2/service/dynamodb"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb/types"
+
+	"github.com/blackhorseya/go-ddd/internal/domain"
+	"github.com/blackhorseya/go-ddd/internal/domain/order"
+	"github.com/blackhorseya/go-ddd/internal/domain/valueobject"
+)
+
+// fakeAPI is an in-memory table. Condition and filter expressions are not
+// evaluated: writes fail with writeErr when set, and queries return the
+// collection in sort-key order, pageSize items per page, skipping items
+// with deleted_at.
+type fakeAPI struct {
+	items    map[string]map[string]types.AttributeValue
+	pageSize int
+	writeErr error
+	queries  []*awsdynamodb.QueryInput
+	puts     []*awsdynamodb.PutItemInput
+}
+
+func newFakeAPI() *fakeAPI {
+	return &fakeAPI{items: make(map[string]map[string]types.AttributeValue), pageSize: 2}
+}
+
+func str(v types.AttributeValue) string {
+	s, _ := v.(*types.AttributeValueMemberS)
+	if s == nil {
+		return ""
+	}
+	return s.Value
+}
+
+func (f *fakeAPI) GetItem(_ context.Context, in *awsdynamodb.GetItemInput, _ ...func(*awsdynamodb.Options)) (*awsdynamodb.GetItemOutput, error) {
+	return &awsdynamodb.GetItemOutput{Item: f.items[str(in.Key["pk"])]}, nil
+}
+
+func (f *fakeAPI) PutItem(_ context.Context, in *awsdynamodb.PutItemInput, _ ...func(*awsdynamodb.Options)) (*awsdynamodb.PutItemOutput, error) {
+	f.puts = append(f.puts, in)
+	if f.writeErr != nil {
+		return nil, f.writeErr
+	}
+	f.items[str(in.Item["pk"])] = in.Item
+	return &awsdynamodb.PutItemOutput{}, nil
+}
+
+func (f *fakeAPI) UpdateItem(_ context.Context, in *awsdynamodb.UpdateItemInput, _ ...func(*awsdynamodb.Options)) (*awsdynamodb.UpdateItemOutput, error) {
+	if f.writeErr != nil {
+		return nil, f.writeErr
+	}
+	f.items[str(in.Key["pk"])]["deleted_at"] = &types.AttributeValueMemberS{Value: "now"}
+	return &awsdynamodb.UpdateItemOutput{}, nil
+}
+
+func (f *fakeAPI) Query(_ context.Context, in *awsdynamodb.QueryInput, _ ...func(*awsdynamodb.Options)) (*awsdynamodb.QueryOutput, error) {
+	f.queries = append(f.queries, in)
+
+	var all []map[string]types.AttributeValue
+	for _, item := range f.items {
+		all = append(all, item)
+	}
+	sort.Slice(all, func(i, j int) bool { return str(all[i]["gsi1sk"]) < str(all[j]["gsi1sk"]) })
+	if !*in.ScanIndexForward {
+		slices.Reverse(all)
+	}
+	if in.ExclusiveStartKey != nil {
+		i := slices.IndexFunc(all, func(item map[string]types.AttributeValue) bool {
+			return str(item["pk"]) == str(in.ExclusiveStartKey["pk"])
+		})
+		all = all[i+1:]
+	}
+
+	out := &awsdynamodb.QueryOutput{}
+	if len(all) > f.pageSize {
+		all = all[:f.pageSize]
+		last := all[len(all)-1]
+		out.LastEvaluatedKey = map[string]types.AttributeValue{
+			"pk": last["pk"], "sk": last["sk"], "gsi1pk": last["gsi1pk"], "gsi1sk": last["gsi1sk"],
+		}
+	}
+	for _, item := range all {
+		if _, ok := item["deleted_at"]; !ok {
+			out.Items = append(out.Items, item)
+			out.Count++
+		}
+	}
+	return out, nil
+}
+
+// seedOrders saves n orders o0..o(n-1) created one minute apart.
+func seedOrders(t *testing.T, repo *OrderRepository, n int) {
+	t.Helper()
+	start := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	for i := range n {
+		total, _ := valueobject.NewMoney(int64(100*(i+1)), "USD")
+		root := domain.RestoreAggregateRoot("o"+strconv.Itoa(i), start.Add(time.Duration(i)*time.Minute), start, 0)
+		if err := repo.Save(context.Background(), order.Restore(root, "c1", order.StatusPending, total)); err != nil {
+			t.Fatalf("Save() error = %v", err)
+		}
+	}
+}
+
+func TestRepository_SaveAndGet(t *testing.T) {
+	// Arrange
+	ctx := context.Background()
+	api := newFakeAPI()
+	repo := NewOrderRepository(api, "app")
+	total, _ := valueobject.NewMoney(500, "USD")
+	o, _ := order.NewOrder("o1", "c1", total)
+
+	// Act
+	err := repo.Save(ctx, o)
+	got, getErr := repo.Get(ctx, "o1")
+
+	// Assert
+	if err != nil || getErr != nil {
+		t.Fatalf("Save() error = %v, Get() error = %v", err, getErr)
+	}
+	if o.Version() != 1 || got.Version() != 1 {
+		t.Errorf("versions = %d, %d, want 1", o.Version(), got.Version())
+	}
+	if got.CustomerID() != "c1" || got.Total().Amount() != 500 {
+		t.Errorf("Get() = %s %d, want c1 500", got.CustomerID(), got.Total().Amount())
+	}
+	item := api.puts[0].Item
+	if str(item["pk"]) != "ORDER#o1" || str(item["gsi1pk"]) != "ORDER" || !strings.HasSuffix(str(item["gsi1sk"]), "#o1") {
+		t.Errorf("item keys = %v %v %v", item["pk"], item["gsi1pk"], item["gsi1sk"])
+	}
+	if !strings.Contains(*api.puts[0].ConditionExpression, "attribute_not_exists") {
+		t.Errorf("insert condition = %s", *api.puts[0].ConditionExpression)
+	}
+}
+
+func TestRepository_SaveConflict(t *testing.T) {
+	tests := []struct {
+		name    string
+		version int
+	}{
+		{name: "insert existing id", version: 0},
+		{name: "update stale version", version: 3},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Arrange
+			api := newFakeAPI()
+			api.writeErr = &types.ConditionalCheckFailedException{}
+			repo := NewOrderRepository(api, "app")
+			total, _ := valueobject.NewMoney(500, "USD")
+			o := order.Restore(domain.RestoreAggregateRoot("o1", time.Now(), time.Now(), tt.version),
+				"c1", order.StatusPending, total)
+
+			// Act
+			err := repo.Save(context.Background(), o)
+
+			// Assert
+			if !errors.Is(err, domain.ErrConcurrentModification) {
+				t.Errorf("Save() error = %v, want ErrConcurrentModification", err)
+			}
+			if o.Version() != tt.version {
+				t.Errorf("Version() = %d, want unchanged %d", o.Version(), tt.version)
+			}
+		})
+	}
+}
+
+func TestRepository_Delete(t *testing.T) {
+	// Arrange
+	ctx := context.Background()
+	api := newFakeAPI()
+	repo := NewOrderRepository(api, "app")
+	seedOrders(t, repo, 1)
+
+	// Act
+	err := repo.Delete(ctx, "o0")
+	_, getErr := repo.Get(ctx, "o0")
+	api.writeErr = &types.ConditionalCheckFailedException{}
+	againErr := repo.Delete(ctx, "o0")
+
+	// Assert
+	if err != nil {
+		t.Fatalf("Delete() error = %v", err)
+	}
+	if !errors.Is(getErr, domain.ErrNotFound) {
+		t.Errorf("Get() error = %v, want ErrNotFound", getErr)
+	}
+	if !errors.Is(againErr, domain.ErrNotFound) {
+		t.Errorf("second Delete() error = %v, want ErrNotFound", againErr)
+	}
+}
+
+func TestRepository_FindCursor(t *testing.T) {
+	// Arrange
+	ctx := context.Background()
+	api := newFakeAPI()
+	repo := NewOrderRepository(api, "app")
+	seedOrders(t, repo, 5)
+	_ = repo.Delete(ctx, "o3")
+	req, _ := domain.NewCursorRequest("", 2)
+
+	// Act
+	var pages [][]string
+	for {
+		res, err := repo.FindCursor(ctx, nil, req)
+		if err != nil {
+			t.Fatalf("FindCursor() error = %v", err)
+		}
+		var ids []string
+		for _, o := range res.Items() {
+			ids = append(ids, o.ID())
+		}
+		pages = append(pages, ids)
+		if !res.HasMore() {
+			break
+		}
+		req, _ = domain.NewCursorRequest(res.NextCursor(), 2)
+	}
+
+	// Assert
+	want := [][]string{{"o4", "o2"}, {"o1", "o0"}}
+	if len(pages) != len(want) || !slices.Equal(pages[0], want[0]) || !slices.Equal(pages[1], want[1]) {
+		t.Errorf("pages = %v, want %v", pages, want)
+	}
+	if *api.queries[0].ScanIndexForward {
+		t.Error("default sort must be descending")
+	}
+}
+
+func TestRepository_FindCursorErrors(t *testing.T) {
+	repo := NewOrderRepository(newFakeAPI(), "app")
+
+	tests := []struct {
+		name    string
+		req     domain.CursorRequest
+		wantErr error
+	}{
+		{
+			name:    "unsortable field",
+			req:     domain.NewCursorRequestWithDefaults().WithSort(domain.NewSortOption("total", domain.SortAsc)),
+			wantErr: domain.ErrInvalidSortField,
+		},
+		{
+			name: "foreign cursor",
+			req: func() domain.CursorRequest {
+				r, _ := domain.NewCursorRequest(domain.EncodeCursor("x"), 2)
+				return r
+			}(),
+			wantErr: domain.ErrInvalidCursor,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := repo.FindCursor(context.Background(), nil, tt.req)
+
+			if !errors.Is(err, tt.wantErr) {
+				t.Errorf("FindCursor() error = %v, want %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestRepository_FindPageAndCount(t *testing.T) {
+	// Arrange
+	ctx := context.Background()
+	repo := NewOrderRepository(newFakeAPI(), "app")
+	seedOrders(t, repo, 5)
+	page, _ := domain.NewPageRequest(2, 2)
+
+	// Act
+	res, err := repo.FindPage(ctx, nil, page)
+	n, countErr := repo.CountByCustomer(ctx, "c1")
+
+	// Assert
+	if err != nil || countErr != nil {
+		t.Fatalf("FindPage() error = %v, CountByCustomer() error = %v", err, countErr)
+	}
+	if res.TotalItems() != 5 || len(res.Items()) != 2 || res.Items()[0].ID() != "o2" {
+		t.Errorf("FindPage() total = %d items = %d", res.TotalItems(), len(res.Items()))
+	}
+	if n != 5 {
+		t.Errorf("CountByCustomer() = %d, want 5", n)
+	}
+}
+
+func TestTimeKey_SortsChronologically(t *testing.T) {
+	base := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	earlier := TimeKey(base.Add(100*time.Millisecond), "b")
+	later := TimeKey(base.Add(120*time.Millisecond), "a")
+
+	if earlier >= later {
+		t.Errorf("TimeKey order: %s >= %s", earlier, later)
+	}
+}
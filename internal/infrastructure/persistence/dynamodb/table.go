@@ -0,0 +1,108 @@
+package dynamodb
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	awsdynamodb "github.com/aws/aws-sdk-go-v2/service/dynamodb"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb/types"
+)
+
+// keySeparator joins the parts of single-table keys.
+const keySeparator = "#"
+
+// sortableTime is a fixed-width UTC layout whose lexical order matches
+// chronological order, unlike time.RFC3339Nano which trims zeros.
+const sortableTime = "2006-01-02T15:04:05.000000000Z"
+
+// Table names a table and its primary key attributes.
+type Table struct {
+	Name         string
+	PartitionKey string // Default: "pk"
+	SortKey      string // Default: "sk"
+}
+
+// Index names a global secondary index and its key attributes.
+type Index struct {
+	Name         string // Default: "gsi1"
+	PartitionKey string // Default: "gsi1pk"
+	SortKey      string // Default: "gsi1sk"
+}
+
+// withDefaults returns t with zero attribute names defaulted.
+func (t Table) withDefaults() Table {
+	if t.PartitionKey == "" {
+		t.PartitionKey = "pk"
+	}
+	if t.SortKey == "" {
+		t.SortKey = "sk"
+	}
+	return t
+}
+
+// withDefaults returns i with zero names defaulted.
+func (i Index) withDefaults() Index {
+	if i.Name == "" {
+		i.Name = "gsi1"
+	}
+	if i.PartitionKey == "" {
+		i.PartitionKey = "gsi1pk"
+	}
+	if i.SortKey == "" {
+		i.SortKey = "gsi1sk"
+	}
+	return i
+}
+
+// Key joins parts into a single-table key, e.g. Key("ORDER", id) is
+// "ORDER#<id>".
+func Key(parts ...string) string {
+	return strings.Join(parts, keySeparator)
+}
+
+// TimeKey returns a sort key ordering by t, then id to break ties.
+func TimeKey(t time.Time, id string) string {
+	return Key(t.UTC().Format(sortableTime), id)
+}
+
+// TableCreator is the subset of *dynamodb.Client used by CreateTable.
+type TableCreator interface {
+	CreateTable(ctx context.Context, in *awsdynamodb.CreateTableInput, optFns ...func(*awsdynamodb.Options)) (*awsdynamodb.CreateTableOutput, error)
+}
+
+// CreateTable creates an on-demand table with the default key and
+// collection index layout, for development and tests. Production tables
+// belong in infrastructure-as-code.
+func CreateTable(ctx context.Context, api TableCreator, name string) error {
+	t := Table{Name: name}.withDefaults()
+	i := Index{}.withDefaults()
+
+	attrs := make([]types.AttributeDefinition, 0, 4)
+	for _, a := range []string{t.PartitionKey, t.SortKey, i.PartitionKey, i.SortKey} {
+		attrs = append(attrs, types.AttributeDefinition{AttributeName: aws.String(a), AttributeType: types.ScalarAttributeTypeS})
+	}
+	_, err := api.CreateTable(ctx, &awsdynamodb.CreateTableInput{
+		TableName:            aws.String(t.Name),
+		BillingMode:          types.BillingModePayPerRequest,
+		AttributeDefinitions: attrs,
+		KeySchema: []types.KeySchemaElement{
+			{AttributeName: aws.String(t.PartitionKey), KeyType: types.KeyTypeHash},
+			{AttributeName: aws.String(t.SortKey), KeyType: types.KeyTypeRange},
+		},
+		GlobalSecondaryIndexes: []types.GlobalSecondaryIndex{{
+			IndexName: aws.String(i.Name),
+			KeySchema: []types.KeySchemaElement{
+				{AttributeName: aws.String(i.PartitionKey), KeyType: types.KeyTypeHash},
+				{AttributeName: aws.String(i.SortKey), KeyType: types.KeyTypeRange},
+			},
+			Projection: &types.Projection{ProjectionType: types.ProjectionTypeAll},
+		}},
+	})
+	if err != nil {
+		return fmt.Errorf("create table %s: %w", name, err)
+	}
+	return nil
+}
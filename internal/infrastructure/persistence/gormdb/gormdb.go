@@ -0,0 +1,90 @@
+// Package gormdb is the optional GORM persistence adapter for teams that
+// prefer an ORM over sqlc or hand-written SQL. It provides a generic
+// Repository base implementing domain.Repository with specification
+// translation, soft delete, optimistic locking and pagination, and joins
+// the sqldb.TxManager transaction carried by the context.
+package gormdb
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+
+	gormmysql "gorm.io/driver/mysql"
+	gormpostgres "gorm.io/driver/postgres"
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+
+	"github.com/blackhorseya/go-ddd/internal/infrastructure/config"
+	"github.com/blackhorseya/go-ddd/internal/infrastructure/persistence/mysql"
+	"github.com/blackhorseya/go-ddd/internal/infrastructure/persistence/postgres"
+	"github.com/blackhorseya/go-ddd/internal/infrastructure/persistence/sqldb"
+)
+
+// Open creates a GORM handle on a pool opened from cfg by the postgres or
+// mysql package, so validation and pool settings match the SQL repositories.
+// Close the pool (DB().Close()) on shutdown.
+func Open(cfg config.Database) (*gorm.DB, error) {
+	d, err := sqldb.DialectFor(cfg.Driver)
+	if err != nil {
+		return nil, err
+	}
+
+	var db *sql.DB
+	switch d {
+	case sqldb.MySQL:
+		db, err = mysql.Open(cfg)
+	default:
+		db, err = postgres.Open(cfg)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	gdb, err := New(db, d)
+	if err != nil {
+		_ = db.Close()
+		return nil, err
+	}
+	return gdb, nil
+}
+
+// New wraps an existing pool. GORM's own logger and default write
+// transactions are disabled: logging goes through contextx and transactions
+// through sqldb.TxManager.
+func New(db *sql.DB, d sqldb.Dialect) (*gorm.DB, error) {
+	var dialector gorm.Dialector
+	switch d {
+	case sqldb.MySQL:
+		dialector = gormmysql.New(gormmysql.Config{Conn: db, SkipInitializeWithVersion: true})
+	case sqldb.Postgres:
+		dialector = gormpostgres.New(gormpostgres.Config{Conn: db})
+	default:
+		return nil, fmt.Errorf("%w: %q", sqldb.ErrUnsupportedDialect, d)
+	}
+	return Wrap(dialector)
+}
+
+// Wrap opens a GORM handle for any dialector with the package defaults,
+// e.g. SQLite in tests.
+func Wrap(dialector gorm.Dialector) (*gorm.DB, error) {
+	gdb, err := gorm.Open(dialector, &gorm.Config{
+		Logger:                 logger.Discard,
+		SkipDefaultTransaction: true,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("open gorm: %w", err)
+	}
+	return gdb, nil
+}
+
+// Conn returns db bound to ctx. When ctx carries a sqldb transaction the
+// returned handle runs its statements in it, so GORM repositories take part
+// in a sqldb.TxManager UnitOfWork alongside SQL repositories.
+func Conn(ctx context.Context, db *gorm.DB) *gorm.DB {
+	tx := db.WithContext(ctx)
+	if sqlTx := sqldb.TxFromContext(ctx); sqlTx != nil {
+		tx.Statement.ConnPool = sqlTx
+	}
+	return tx
+}
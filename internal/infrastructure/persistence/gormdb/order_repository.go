@@ -0,0 +1,106 @@
+package gormdb
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+
+	"gorm.io/gorm"
+
+	"github.com/blackhorseya/go-ddd/internal/domain"
+	"github.com/blackhorseya/go-ddd/internal/domain/order"
+	"github.com/blackhorseya/go-ddd/internal/domain/valueobject"
+	"github.com/blackhorseya/go-ddd/internal/infrastructure/persistence/sqldb"
+)
+
+// orderModel is the GORM model of the orders table (see sqlc/schema.sql).
+type orderModel struct {
+	ID          string `gorm:"primaryKey"`
+	CustomerID  string
+	Status      string
+	TotalAmount int64
+	Currency    string
+	Version     int
+	CreatedAt   time.Time `gorm:"autoCreateTime:false"`
+	UpdatedAt   time.Time `gorm:"autoUpdateTime:false"`
+	CreatedBy   string
+	UpdatedBy   string
+	DeletedAt   sql.NullTime
+}
+
+// TableName implements gorm's tabler interface.
+func (orderModel) TableName() string { return "orders" }
+
+// OrderRepository is the order.Repository backed by GORM.
+type OrderRepository struct {
+	*Repository[*order.Order, string, orderModel]
+}
+
+var _ order.Repository = (*OrderRepository)(nil)
+
+// NewOrderRepository creates an OrderRepository on db.
+func NewOrderRepository(db *gorm.DB) *OrderRepository {
+	specs := sqldb.NewSpecTranslator[*order.Order]()
+	sqldb.HandleSpec(specs, func(s order.StatusSpec) (sqldb.Clause, error) {
+		return sqldb.Expr("status = ?", string(s.Status)), nil
+	})
+	sqldb.HandleSpec(specs, func(s order.CustomerSpec) (sqldb.Clause, error) {
+		return sqldb.Expr("customer_id = ?", s.CustomerID), nil
+	})
+
+	return &OrderRepository{NewRepository(db, Config[*order.Order, string, orderModel]{
+		ToModel:  toOrderModel,
+		ToDomain: toOrder,
+		Specs:    specs,
+		SortColumns: map[string]string{
+			"created_at": "created_at",
+			"total":      "total_amount",
+		},
+		KeyOf: func(m orderModel) []any { return []any{m.CreatedAt, m.ID} },
+	})}
+}
+
+// CountByCustomer returns the number of live orders of a customer.
+func (r *OrderRepository) CountByCustomer(ctx context.Context, customerID string) (int64, error) {
+	var n int64
+	err := r.DB(ctx).Model(&orderModel{}).
+		Where("customer_id = ? AND deleted_at IS NULL", customerID).
+		Count(&n).Error
+	if err != nil {
+		return 0, fmt.Errorf("count orders of customer %s: %w", customerID, err)
+	}
+	return n, nil
+}
+
+// toOrderModel maps an order to its model.
+func toOrderModel(o *order.Order, version int) orderModel {
+	return orderModel{
+		ID:          o.ID(),
+		CustomerID:  o.CustomerID(),
+		Status:      string(o.Status()),
+		TotalAmount: o.Total().Amount(),
+		Currency:    o.Total().Currency().Code(),
+		Version:     version,
+		CreatedAt:   o.CreatedAt(),
+		UpdatedAt:   o.UpdatedAt(),
+		CreatedBy:   o.CreatedBy(),
+		UpdatedBy:   o.UpdatedBy(),
+		DeletedAt:   sqldb.NullTime(o.DeletedAt()),
+	}
+}
+
+// toOrder reconstitutes an order from its model.
+func toOrder(m orderModel) (*order.Order, error) {
+	total, err := valueobject.NewMoney(m.TotalAmount, m.Currency)
+	if err != nil {
+		return nil, fmt.Errorf("order %s: %w", m.ID, err)
+	}
+
+	root := domain.RestoreAggregateRoot(m.ID, m.CreatedAt, m.UpdatedAt, m.Version)
+	root.Entity = root.Entity.WithAudit(m.CreatedBy, m.UpdatedBy)
+	if m.DeletedAt.Valid {
+		root.Entity = root.Entity.WithDeletedAt(m.DeletedAt.Time)
+	}
+	return order.Restore(root, m.CustomerID, order.Status(m.Status), total), nil
+}
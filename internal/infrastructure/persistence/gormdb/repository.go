@@ -0,0 +1,279 @@
+package gormdb
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"gorm.io/gorm"
+
+	"github.com/blackhorseya/go-ddd/internal/domain"
+	"github.com/blackhorseya/go-ddd/internal/infrastructure/persistence/sqldb"
+)
+
+// Column conventions shared by models used with Repository, matching the
+// SQL repositories: id primary key, integer version, audit columns and a
+// nullable deleted_at timestamp.
+const (
+	idColumn        = "id"
+	versionColumn   = "version"
+	createdAtColumn = "created_at"
+	createdByColumn = "created_by"
+	updatedAtColumn = "updated_at"
+	updatedByColumn = "updated_by"
+)
+
+// Aggregate is what Repository needs from an aggregate root. Pointers to
+// types embedding domain.AggregateRoot satisfy it.
+type Aggregate[ID comparable] interface {
+	ID() ID
+	Version() int
+	IncrementVersion()
+	sqldb.Auditable
+}
+
+// Config configures a Repository for aggregate T stored as GORM model M.
+type Config[T Aggregate[ID], ID comparable, M any] struct {
+	// ToModel converts an aggregate to its model, storing version as the row version.
+	ToModel func(aggregate T, version int) M
+
+	// ToDomain reconstitutes an aggregate from its model.
+	ToDomain func(M) (T, error)
+
+	// Specs translates specifications into WHERE clauses.
+	// Optional: without it only nil specifications are accepted.
+	Specs *sqldb.SpecTranslator[T]
+
+	// SortColumns maps sortable domain fields to columns and is the sort whitelist.
+	SortColumns map[string]string
+
+	// DefaultSort is applied when a page request has no sort.
+	// Default: created_at descending (see sqldb.PagerConfig)
+	DefaultSort domain.SortOption
+
+	// KeysetColumns are the keyset pagination columns; the last one must be unique.
+	// Default: created_at, id
+	KeysetColumns []string
+
+	// KeyOf returns the values of KeysetColumns for a model, used to build
+	// the next cursor. Required for FindCursor.
+	KeyOf func(M) []any
+}
+
+// Repository is a generic domain.Repository backed by GORM. Embed it in
+// aggregate repositories and add aggregate-specific queries alongside.
+type Repository[T Aggregate[ID], ID comparable, M any] struct {
+	db    *gorm.DB
+	cfg   Config[T, ID, M]
+	pager *sqldb.Pager
+	now   func() time.Time
+}
+
+// NewRepository creates a Repository, applying defaults for zero config values.
+func NewRepository[T Aggregate[ID], ID comparable, M any](db *gorm.DB, cfg Config[T, ID, M]) *Repository[T, ID, M] {
+	pager := sqldb.NewPager(dialectOf(db), sqldb.PagerConfig{
+		SortColumns:   cfg.SortColumns,
+		DefaultSort:   cfg.DefaultSort,
+		KeysetColumns: cfg.KeysetColumns,
+	})
+	return &Repository[T, ID, M]{db: db, cfg: cfg, pager: pager, now: time.Now}
+}
+
+// DB returns the GORM handle bound to ctx and its transaction, for
+// aggregate-specific queries in embedding repositories.
+func (r *Repository[T, ID, M]) DB(ctx context.Context) *gorm.DB {
+	return Conn(ctx, r.db)
+}
+
+// Get returns the live aggregate with the given ID, or domain.ErrNotFound.
+func (r *Repository[T, ID, M]) Get(ctx context.Context, id ID) (T, error) {
+	var zero T
+	var m M
+	err := r.live(ctx).Where(idColumn+" = ?", id).Take(&m).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return zero, domain.ErrNotFound
+	}
+	if err != nil {
+		return zero, fmt.Errorf("get %v: %w", id, err)
+	}
+	return r.cfg.ToDomain(m)
+}
+
+// Save inserts a new aggregate (version 0) or updates a live one whose
+// stored version still matches, returning domain.ErrConcurrentModification
+// otherwise. On success the aggregate version is incremented.
+func (r *Repository[T, ID, M]) Save(ctx context.Context, aggregate T) error {
+	version := aggregate.Version()
+
+	if version == 0 {
+		sqldb.BeforeInsert(ctx, aggregate)
+		m := r.cfg.ToModel(aggregate, 1)
+		if err := r.DB(ctx).Create(&m).Error; err != nil {
+			return fmt.Errorf("insert %v: %w", aggregate.ID(), err)
+		}
+		aggregate.IncrementVersion()
+		return nil
+	}
+
+	sqldb.BeforeUpdate(ctx, aggregate)
+	m := r.cfg.ToModel(aggregate, version+1)
+	res := r.live(ctx).
+		Where(idColumn+" = ? AND "+versionColumn+" = ?", aggregate.ID(), version).
+		Select("*").
+		Omit(idColumn, createdAtColumn, createdByColumn, sqldb.DeletedAtColumn).
+		Updates(&m)
+	if res.Error != nil {
+		return fmt.Errorf("update %v: %w", aggregate.ID(), res.Error)
+	}
+	if res.RowsAffected == 0 {
+		return domain.ErrConcurrentModification
+	}
+	aggregate.IncrementVersion()
+	return nil
+}
+
+// Delete soft-deletes the aggregate and bumps its version, or returns domain.ErrNotFound.
+func (r *Repository[T, ID, M]) Delete(ctx context.Context, id ID) error {
+	now := r.now().UTC()
+	res := r.live(ctx).
+		Where(idColumn+" = ?", id).
+		Updates(map[string]any{
+			sqldb.DeletedAtColumn: now,
+			updatedAtColumn:       now,
+			updatedByColumn:       sqldb.Actor(ctx),
+			versionColumn:         gorm.Expr(versionColumn + " + 1"),
+		})
+	if res.Error != nil {
+		return fmt.Errorf("delete %v: %w", id, res.Error)
+	}
+	if res.RowsAffected == 0 {
+		return domain.ErrNotFound
+	}
+	return nil
+}
+
+// FindPage returns the live aggregates satisfying spec as an offset page
+// with a total count. Sort fields must be listed in SortColumns.
+func (r *Repository[T, ID, M]) FindPage(ctx context.Context, spec domain.Specification[T], page domain.PageRequest) (domain.PageResult[T], error) {
+	pq, err := r.pager.Page(page)
+	if err != nil {
+		return domain.PageResult[T]{}, err
+	}
+	q, err := r.query(ctx, spec)
+	if err != nil {
+		return domain.PageResult[T]{}, err
+	}
+
+	var total int64
+	if err := q.Session(&gorm.Session{}).Count(&total).Error; err != nil {
+		return domain.PageResult[T]{}, fmt.Errorf("count: %w", err)
+	}
+
+	var models []M
+	if err := q.Order(pq.OrderBy).Limit(page.Limit()).Offset(page.Offset()).Find(&models).Error; err != nil {
+		return domain.PageResult[T]{}, fmt.Errorf("find page: %w", err)
+	}
+
+	items, err := r.toDomain(models)
+	if err != nil {
+		return domain.PageResult[T]{}, err
+	}
+	return domain.NewPageResult(items, page.Page(), page.PageSize(), total), nil
+}
+
+// FindCursor returns the live aggregates satisfying spec as a keyset page
+// over KeysetColumns. The direction comes from the request's sort on the
+// first keyset column, falling back to DefaultSort. Offset cursors
+// (domain.IsOffsetCursor) are served with OFFSET.
+func (r *Repository[T, ID, M]) FindCursor(ctx context.Context, spec domain.Specification[T], req domain.CursorRequest) (domain.CursorResult[T], error) {
+	cq, err := r.pager.Cursor(req)
+	if err != nil {
+		return domain.CursorResult[T]{}, err
+	}
+	q, err := r.query(ctx, spec)
+	if err != nil {
+		return domain.CursorResult[T]{}, err
+	}
+	if cq.Where.SQL != "" {
+		q = q.Where(cq.Where.SQL, cq.Where.Args...)
+	}
+
+	if cq.Offset > 0 {
+		q = q.Offset(cq.Offset)
+	}
+
+	var models []M
+	if err := q.Order(cq.OrderBy).Limit(cq.Limit).Find(&models).Error; err != nil {
+		return domain.CursorResult[T]{}, fmt.Errorf("find cursor: %w", err)
+	}
+	if domain.IsOffsetCursor(req.Cursor()) {
+		items, err := r.toDomain(models)
+		if err != nil {
+			return domain.CursorResult[T]{}, err
+		}
+		return domain.NewOffsetCursorResult(items, req)
+	}
+
+	models, hasMore := sqldb.TrimPage(models, req.Limit())
+	items, err := r.toDomain(models)
+	if err != nil {
+		return domain.CursorResult[T]{}, err
+	}
+
+	var next string
+	if hasMore {
+		cursor, err := domain.NewKeysetCursor(r.cfg.KeyOf(models[len(models)-1])...)
+		if err != nil {
+			return domain.CursorResult[T]{}, err
+		}
+		if next, err = req.NextCursor(cursor.Strings()...); err != nil {
+			return domain.CursorResult[T]{}, err
+		}
+	}
+	return domain.NewCursorResult(items, next, "", hasMore), nil
+}
+
+// live returns a query on the model's table excluding soft-deleted rows.
+func (r *Repository[T, ID, M]) live(ctx context.Context) *gorm.DB {
+	deleted := sqldb.DeletedClause(domain.ExcludeDeleted)
+	return r.DB(ctx).Model(new(M)).Where(deleted.SQL, deleted.Args...)
+}
+
+// query returns a live query filtered by spec.
+func (r *Repository[T, ID, M]) query(ctx context.Context, spec domain.Specification[T]) (*gorm.DB, error) {
+	q := r.live(ctx)
+	if spec == nil {
+		return q, nil
+	}
+	if r.cfg.Specs == nil {
+		return nil, fmt.Errorf("%w: %T", sqldb.ErrUnsupportedSpec, spec)
+	}
+	c, err := r.cfg.Specs.Translate(spec)
+	if err != nil {
+		return nil, err
+	}
+	return q.Where(c.SQL, c.Args...), nil
+}
+
+// dialectOf returns the dialect used to quote identifiers for db.
+// Dialects other than MySQL (including SQLite in tests) use Postgres quoting.
+func dialectOf(db *gorm.DB) sqldb.Dialect {
+	if db.Dialector != nil && db.Dialector.Name() == string(sqldb.MySQL) {
+		return sqldb.MySQL
+	}
+	return sqldb.Postgres
+}
+
+// toDomain maps models to aggregates.
+func (r *Repository[T, ID, M]) toDomain(models []M) ([]T, error) {
+	items := make([]T, 0, len(models))
+	for _, m := range models {
+		item, err := r.cfg.ToDomain(m)
+		if err != nil {
+			return nil, err
+		}
+		items = append(items, item)
+	}
+	return items, nil
+}
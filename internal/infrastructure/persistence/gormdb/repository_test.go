@@ -0,0 +1,298 @@
+package gormdb
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/glebarez/sqlite"
+
+	"github.com/blackhorseya/go-ddd/internal/domain"
+	"github.com/blackhorseya/go-ddd/internal/domain/order"
+	"github.com/blackhorseya/go-ddd/internal/domain/valueobject"
+	"github.com/blackhorseya/go-ddd/internal/infrastructure/persistence/sqldb"
+)
+
+// newTestRepository returns an OrderRepository on a fresh in-memory SQLite
+// database and a TxManager on the same pool.
+func newTestRepository(t *testing.T) (*OrderRepository, *sqldb.TxManager) {
+	t.Helper()
+	db, err := Wrap(sqlite.Open("file:" + t.Name() + "?mode=memory&cache=shared"))
+	if err != nil {
+		t.Fatalf("Wrap() error = %v", err)
+	}
+	if err := db.AutoMigrate(&orderModel{}); err != nil {
+		t.Fatalf("AutoMigrate() error = %v", err)
+	}
+	pool, err := db.DB()
+	if err != nil {
+		t.Fatalf("DB() error = %v", err)
+	}
+	pool.SetMaxOpenConns(1)
+	t.Cleanup(func() { _ = pool.Close() })
+
+	return NewOrderRepository(db), sqldb.NewTxManager(pool, nil)
+}
+
+// seedOrders saves n pending orders o0..o(n-1) with increasing totals and creation times.
+func seedOrders(t *testing.T, repo *OrderRepository, n int) []*order.Order {
+	t.Helper()
+	orders := make([]*order.Order, n)
+	for i := range n {
+		total, err := valueobject.NewMoney(int64(100*(i+1)), "USD")
+		if err != nil {
+			t.Fatalf("NewMoney() error = %v", err)
+		}
+		o, err := order.NewOrder("o"+strconv.Itoa(i), "c1", total)
+		if err != nil {
+			t.Fatalf("NewOrder() error = %v", err)
+		}
+		if err := repo.Save(context.Background(), o); err != nil {
+			t.Fatalf("Save(%s) error = %v", o.ID(), err)
+		}
+		orders[i] = o
+		time.Sleep(time.Millisecond)
+	}
+	return orders
+}
+
+// ids returns the IDs of orders.
+func ids(orders []*order.Order) []string {
+	out := make([]string, len(orders))
+	for i, o := range orders {
+		out[i] = o.ID()
+	}
+	return out
+}
+
+func TestRepository_SaveAndGet(t *testing.T) {
+	// Arrange
+	ctx := context.Background()
+	repo, _ := newTestRepository(t)
+	saved := seedOrders(t, repo, 1)[0]
+
+	// Act
+	got, err := repo.Get(ctx, "o0")
+
+	// Assert
+	if err != nil {
+		t.Fatalf("Get() error = %v", err)
+	}
+	if saved.Version() != 1 || got.Version() != 1 {
+		t.Errorf("Version() = %d/%d, want 1", saved.Version(), got.Version())
+	}
+	if got.CustomerID() != "c1" || got.Total() != saved.Total() || got.CreatedBy() != sqldb.SystemActor {
+		t.Errorf("Get() = %+v, want %+v", got, saved)
+	}
+	if _, err := repo.Get(ctx, "missing"); !errors.Is(err, domain.ErrNotFound) {
+		t.Errorf("Get(missing) error = %v, want %v", err, domain.ErrNotFound)
+	}
+}
+
+func TestRepository_OptimisticLocking(t *testing.T) {
+	// Arrange
+	ctx := context.Background()
+	repo, _ := newTestRepository(t)
+	seedOrders(t, repo, 1)
+	first, _ := repo.Get(ctx, "o0")
+	stale, _ := repo.Get(ctx, "o0")
+
+	// Act
+	_ = first.Confirm()
+	errFirst := repo.Save(ctx, first)
+	_ = stale.Cancel()
+	errStale := repo.Save(ctx, stale)
+
+	// Assert
+	if errFirst != nil {
+		t.Fatalf("Save(first) error = %v", errFirst)
+	}
+	if !errors.Is(errStale, domain.ErrConcurrentModification) {
+		t.Errorf("Save(stale) error = %v, want %v", errStale, domain.ErrConcurrentModification)
+	}
+	got, _ := repo.Get(ctx, "o0")
+	if got.Status() != order.StatusConfirmed || got.Version() != 2 {
+		t.Errorf("stored = (%s, v%d), want (confirmed, v2)", got.Status(), got.Version())
+	}
+}
+
+func TestRepository_Delete(t *testing.T) {
+	// Arrange
+	ctx := context.Background()
+	repo, _ := newTestRepository(t)
+	seedOrders(t, repo, 2)
+
+	// Act
+	err := repo.Delete(ctx, "o0")
+
+	// Assert
+	if err != nil {
+		t.Fatalf("Delete() error = %v", err)
+	}
+	if _, err := repo.Get(ctx, "o0"); !errors.Is(err, domain.ErrNotFound) {
+		t.Errorf("Get(deleted) error = %v, want %v", err, domain.ErrNotFound)
+	}
+	if err := repo.Delete(ctx, "o0"); !errors.Is(err, domain.ErrNotFound) {
+		t.Errorf("Delete(deleted) error = %v, want %v", err, domain.ErrNotFound)
+	}
+	if n, _ := repo.CountByCustomer(ctx, "c1"); n != 1 {
+		t.Errorf("CountByCustomer() = %d, want 1", n)
+	}
+}
+
+func TestRepository_FindPage(t *testing.T) {
+	ctx := context.Background()
+	repo, _ := newTestRepository(t)
+	orders := seedOrders(t, repo, 5)
+	_ = orders[4].Confirm()
+	if err := repo.Save(ctx, orders[4]); err != nil {
+		t.Fatalf("Save() error = %v", err)
+	}
+
+	tests := []struct {
+		name      string
+		spec      domain.Specification[*order.Order]
+		sort      []domain.SortOption
+		wantIDs   []string
+		wantTotal int64
+		wantErr   error
+	}{
+		{name: "default sort", wantIDs: []string{"o4", "o3"}, wantTotal: 5},
+		{
+			name:      "spec and whitelisted sort",
+			spec:      order.StatusSpec{Status: order.StatusPending},
+			sort:      []domain.SortOption{domain.NewSortOption("total", domain.SortAsc)},
+			wantIDs:   []string{"o0", "o1"},
+			wantTotal: 4,
+		},
+		{
+			name:    "unknown sort field",
+			sort:    []domain.SortOption{domain.NewSortOption("customer_id", domain.SortAsc)},
+			wantErr: domain.ErrInvalidSortField,
+		},
+		{
+			name:    "unsupported spec",
+			spec:    domain.SpecFunc[*order.Order](func(*order.Order) bool { return true }),
+			wantErr: sqldb.ErrUnsupportedSpec,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Arrange
+			page, _ := domain.NewPageRequest(1, 2)
+
+			// Act
+			got, err := repo.FindPage(ctx, tt.spec, page.WithSort(tt.sort...))
+
+			// Assert
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("FindPage() error = %v, want %v", err, tt.wantErr)
+			}
+			if err != nil {
+				return
+			}
+			if gotIDs := ids(got.Items()); !slices.Equal(gotIDs, tt.wantIDs) {
+				t.Errorf("FindPage() items = %v, want %v", gotIDs, tt.wantIDs)
+			}
+			if got.TotalItems() != tt.wantTotal {
+				t.Errorf("TotalItems() = %d, want %d", got.TotalItems(), tt.wantTotal)
+			}
+		})
+	}
+}
+
+func TestRepository_FindCursor(t *testing.T) {
+	// Arrange
+	ctx := context.Background()
+	repo, _ := newTestRepository(t)
+	seedOrders(t, repo, 5)
+	req, _ := domain.NewCursorRequest("", 3)
+
+	// Act
+	first, err := repo.FindCursor(ctx, nil, req)
+	if err != nil {
+		t.Fatalf("FindCursor() error = %v", err)
+	}
+	req, _ = domain.NewCursorRequest(first.NextCursor(), 3)
+	second, err := repo.FindCursor(ctx, nil, req)
+	if err != nil {
+		t.Fatalf("FindCursor(next) error = %v", err)
+	}
+
+	// Assert
+	if got := ids(first.Items()); !slices.Equal(got, []string{"o4", "o3", "o2"}) || !first.HasMore() {
+		t.Errorf("first page = %v (hasMore %v)", got, first.HasMore())
+	}
+	if got := ids(second.Items()); !slices.Equal(got, []string{"o1", "o0"}) || second.HasMore() {
+		t.Errorf("second page = %v (hasMore %v)", got, second.HasMore())
+	}
+
+	asc := req.WithSort(domain.NewSortOption("total", domain.SortAsc))
+	if _, err := repo.FindCursor(ctx, nil, asc); !errors.Is(err, domain.ErrInvalidSortField) {
+		t.Errorf("FindCursor(sort total) error = %v, want %v", err, domain.ErrInvalidSortField)
+	}
+}
+
+func TestRepository_FindCursor_OffsetCursor(t *testing.T) {
+	// Arrange
+	ctx := context.Background()
+	repo, _ := newTestRepository(t)
+	seedOrders(t, repo, 5)
+	page, _ := domain.NewPageRequest(1, 2)
+	req := domain.CursorRequestFromPage(page.WithSort(domain.NewSortOption("total", domain.SortAsc)))
+
+	// Act
+	var pages [][]string
+	var prev string
+	for {
+		res, err := repo.FindCursor(ctx, nil, req)
+		if err != nil {
+			t.Fatalf("FindCursor(%q) error = %v", req.Cursor(), err)
+		}
+		pages = append(pages, ids(res.Items()))
+		prev = res.PrevCursor()
+		if !res.HasMore() {
+			break
+		}
+		req, _ = domain.NewCursorRequest(res.NextCursor(), 2)
+		req = req.WithSort(domain.NewSortOption("total", domain.SortAsc))
+	}
+
+	// Assert
+	want := [][]string{{"o0", "o1"}, {"o2", "o3"}, {"o4"}}
+	if !slices.EqualFunc(pages, want, slices.Equal) {
+		t.Errorf("pages = %v, want %v", pages, want)
+	}
+	if offset, err := domain.DecodeOffsetCursor(prev); err != nil || offset != 2 {
+		t.Errorf("last PrevCursor() offset = %d, %v, want 2", offset, err)
+	}
+}
+
+func TestRepository_JoinsUnitOfWork(t *testing.T) {
+	// Arrange
+	ctx := context.Background()
+	repo, uow := newTestRepository(t)
+	total, _ := valueobject.NewMoney(100, "USD")
+	o, _ := order.NewOrder("o1", "c1", total)
+	errAbort := errors.New("abort")
+
+	// Act
+	err := uow.Do(ctx, func(ctx context.Context) error {
+		if err := repo.Save(ctx, o); err != nil {
+			return err
+		}
+		return errAbort
+	})
+
+	// Assert
+	if !errors.Is(err, errAbort) {
+		t.Fatalf("Do() error = %v, want %v", err, errAbort)
+	}
+	if _, err := repo.Get(ctx, "o1"); !errors.Is(err, domain.ErrNotFound) {
+		t.Errorf("Get() after rollback error = %v, want %v", err, domain.ErrNotFound)
+	}
+}
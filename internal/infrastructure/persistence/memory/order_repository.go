@@ -0,0 +1,41 @@
+package memory
+
+import (
+	"cmp"
+	"context"
+
+	"github.com/blackhorseya/go-ddd/internal/domain"
+	"github.com/blackhorseya/go-ddd/internal/domain/order"
+)
+
+// OrderRepository is the order.Repository held in memory.
+type OrderRepository struct {
+	*Repository[*order.Order, string]
+}
+
+var _ order.Repository = (*OrderRepository)(nil)
+
+// NewOrderRepository creates an empty OrderRepository.
+func NewOrderRepository() *OrderRepository {
+	return &OrderRepository{NewRepository(Config[*order.Order, string]{
+		Clone: cloneOrder,
+		SortFields: map[string]func(a, b *order.Order) int{
+			"total": func(a, b *order.Order) int {
+				return cmp.Compare(a.Total().Amount(), b.Total().Amount())
+			},
+		},
+	})}
+}
+
+// CountByCustomer returns the number of live orders of a customer.
+func (r *OrderRepository) CountByCustomer(ctx context.Context, customerID string) (int64, error) {
+	return r.Count(ctx, order.CustomerSpec{CustomerID: customerID})
+}
+
+// cloneOrder copies an order the way a database round trip would,
+// dropping its pending events.
+func cloneOrder(o *order.Order) *order.Order {
+	root := domain.RestoreAggregateRoot(o.ID(), o.CreatedAt(), o.UpdatedAt(), o.Version())
+	root.Entity = root.Entity.WithAudit(o.CreatedBy(), o.UpdatedBy())
+	return order.Restore(root, o.CustomerID(), o.Status(), o.Total())
+}
@@ -0,0 +1,310 @@
+package memory
+
+import (
+	"cmp"
+	"context"
+	"fmt"
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/blackhorseya/go-ddd/internal/domain"
+	"github.com/blackhorseya/go-ddd/internal/infrastructure/persistence/sqldb"
+)
+
+// Aggregate is what Repository needs from an aggregate root. Pointers to
+// types embedding domain.AggregateRoot satisfy it.
+type Aggregate[ID comparable] interface {
+	ID() ID
+	Version() int
+	IncrementVersion()
+	CreatedAt() time.Time
+	sqldb.Auditable
+}
+
+// Config configures a Repository for aggregate T.
+type Config[T Aggregate[ID], ID comparable] struct {
+	// Clone copies an aggregate, so callers never share state with the
+	// store: changes are only visible after Save, as with a database.
+	// Optional: without it the store keeps the saved pointers.
+	Clone func(T) T
+
+	// SortFields compare aggregates by the sortable domain fields and are
+	// the sort whitelist. "created_at" is always sortable.
+	SortFields map[string]func(a, b T) int
+
+	// DefaultSort is applied when a request has no sort.
+	// Default: created_at descending
+	DefaultSort domain.SortOption
+}
+
+// entry is a stored aggregate. seq records insertion order and breaks
+// ties between equal sort keys.
+type entry[T any] struct {
+	aggregate T
+	version   int
+	deleted   bool
+	seq       int64
+}
+
+// Repository is a generic domain.Repository held in memory. Embed it in
+// aggregate repositories and add aggregate-specific queries alongside.
+type Repository[T Aggregate[ID], ID comparable] struct {
+	cfg Config[T, ID]
+
+	mu      sync.RWMutex
+	entries map[ID]entry[T]
+	seq     int64
+}
+
+// NewRepository creates an empty Repository, applying defaults for zero
+// config values.
+func NewRepository[T Aggregate[ID], ID comparable](cfg Config[T, ID]) *Repository[T, ID] {
+	fields := map[string]func(a, b T) int{
+		"created_at": func(a, b T) int { return a.CreatedAt().Compare(b.CreatedAt()) },
+	}
+	for name, compare := range cfg.SortFields {
+		fields[name] = compare
+	}
+	cfg.SortFields = fields
+	if cfg.DefaultSort.Field() == "" {
+		cfg.DefaultSort = domain.NewSortOption("created_at", domain.SortDesc)
+	}
+	if cfg.Clone == nil {
+		cfg.Clone = func(aggregate T) T { return aggregate }
+	}
+	return &Repository[T, ID]{cfg: cfg, entries: make(map[ID]entry[T])}
+}
+
+// Get returns a copy of the live aggregate with the given ID, or domain.ErrNotFound.
+func (r *Repository[T, ID]) Get(_ context.Context, id ID) (T, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	e, ok := r.entries[id]
+	if !ok || e.deleted {
+		var zero T
+		return zero, domain.ErrNotFound
+	}
+	return r.cfg.Clone(e.aggregate), nil
+}
+
+// Save inserts a new aggregate (version 0) or replaces a live one whose
+// stored version still matches, returning domain.ErrConcurrentModification
+// otherwise, including when a new aggregate's ID is taken. On success the
+// aggregate version is incremented.
+func (r *Repository[T, ID]) Save(ctx context.Context, aggregate T) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	id := aggregate.ID()
+	prev, exists := r.entries[id]
+	version := aggregate.Version()
+	if version == 0 {
+		if exists {
+			return domain.ErrConcurrentModification
+		}
+		sqldb.BeforeInsert(ctx, aggregate)
+		r.seq++
+		prev.seq = r.seq
+	} else {
+		if !exists || prev.deleted || prev.version != version {
+			return domain.ErrConcurrentModification
+		}
+		sqldb.BeforeUpdate(ctx, aggregate)
+	}
+
+	aggregate.IncrementVersion()
+	r.put(ctx, id, entry[T]{aggregate: r.cfg.Clone(aggregate), version: version + 1, seq: prev.seq})
+	return nil
+}
+
+// Delete soft-deletes the aggregate and bumps its version, or returns domain.ErrNotFound.
+func (r *Repository[T, ID]) Delete(ctx context.Context, id ID) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	e, ok := r.entries[id]
+	if !ok || e.deleted {
+		return domain.ErrNotFound
+	}
+	e.deleted = true
+	e.version++
+	r.put(ctx, id, e)
+	return nil
+}
+
+// FindPage returns the live aggregates satisfying spec as an offset page
+// with a total count.
+func (r *Repository[T, ID]) FindPage(_ context.Context, spec domain.Specification[T], page domain.PageRequest) (domain.PageResult[T], error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	matches, err := r.find(spec, page.Sort())
+	if err != nil {
+		return domain.PageResult[T]{}, err
+	}
+	start := min(page.Offset(), len(matches))
+	end := min(start+page.Limit(), len(matches))
+	return domain.NewPageResult(r.clones(matches[start:end]), page.Page(), page.PageSize(), int64(len(matches))), nil
+}
+
+// FindCursor returns the live aggregates satisfying spec as a keyset page.
+// The cursor identifies the last aggregate of the previous page, so pages
+// stay stable while aggregates are added or removed. Offset cursors
+// (domain.IsOffsetCursor) skip that many aggregates instead.
+func (r *Repository[T, ID]) FindCursor(_ context.Context, spec domain.Specification[T], req domain.CursorRequest) (domain.CursorResult[T], error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	matches, err := r.find(spec, req.Sort())
+	if err != nil {
+		return domain.CursorResult[T]{}, err
+	}
+	if domain.IsOffsetCursor(req.Cursor()) {
+		offset, _ := domain.DecodeOffsetCursor(req.Cursor())
+		start := min(offset, len(matches))
+		end := min(start+req.Limit()+1, len(matches))
+		return domain.NewOffsetCursorResult(r.clones(matches[start:end]), req)
+	}
+	if req.HasCursor() {
+		last, err := r.decodeCursor(req.Cursor())
+		if err != nil {
+			return domain.CursorResult[T]{}, err
+		}
+		compare := r.compare(req.Sort())
+		i, _ := slices.BinarySearchFunc(matches, last, func(e, last entry[T]) int {
+			if compare(e, last) <= 0 {
+				return -1
+			}
+			return 1
+		})
+		matches = matches[i:]
+	}
+
+	page, hasMore := sqldb.TrimPage(matches, req.Limit())
+	var next string
+	if hasMore {
+		if next, err = r.encodeCursor(req, page[len(page)-1]); err != nil {
+			return domain.CursorResult[T]{}, err
+		}
+	}
+	return domain.NewCursorResult(r.clones(page), next, "", hasMore), nil
+}
+
+// Count returns the number of live aggregates satisfying spec.
+func (r *Repository[T, ID]) Count(_ context.Context, spec domain.Specification[T]) (int64, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	var n int64
+	for _, e := range r.entries {
+		if !e.deleted && (spec == nil || spec.IsSatisfiedBy(e.aggregate)) {
+			n++
+		}
+	}
+	return n, nil
+}
+
+// Len returns the number of stored aggregates, including soft-deleted ones.
+func (r *Repository[T, ID]) Len() int {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	return len(r.entries)
+}
+
+// put stores e under id, recording how to restore the previous state when
+// ctx carries a transaction. The caller holds r.mu.
+func (r *Repository[T, ID]) put(ctx context.Context, id ID, e entry[T]) {
+	prev, existed := r.entries[id]
+	r.entries[id] = e
+	if t := txFrom(ctx); t != nil {
+		t.record(func() {
+			r.mu.Lock()
+			defer r.mu.Unlock()
+			if existed {
+				r.entries[id] = prev
+			} else {
+				delete(r.entries, id)
+			}
+		})
+	}
+}
+
+// find returns the live entries satisfying spec in sort order. The caller
+// holds r.mu.
+func (r *Repository[T, ID]) find(spec domain.Specification[T], sort []domain.SortOption) ([]entry[T], error) {
+	if err := domain.ValidateSortFields(sort, r.sortFields()...); err != nil {
+		return nil, err
+	}
+	var matches []entry[T]
+	for _, e := range r.entries {
+		if !e.deleted && (spec == nil || spec.IsSatisfiedBy(e.aggregate)) {
+			matches = append(matches, e)
+		}
+	}
+	slices.SortFunc(matches, r.compare(sort))
+	return matches, nil
+}
+
+// compare orders entries by sort, or DefaultSort when it is empty, then by
+// insertion order.
+func (r *Repository[T, ID]) compare(sort []domain.SortOption) func(a, b entry[T]) int {
+	if len(sort) == 0 {
+		sort = []domain.SortOption{r.cfg.DefaultSort}
+	}
+	return func(a, b entry[T]) int {
+		for _, s := range sort {
+			c := r.cfg.SortFields[s.Field()](a.aggregate, b.aggregate)
+			if !s.IsAscending() {
+				c = -c
+			}
+			if c != 0 {
+				return c
+			}
+		}
+		return cmp.Compare(a.seq, b.seq)
+	}
+}
+
+func (r *Repository[T, ID]) sortFields() []string {
+	fields := make([]string, 0, len(r.cfg.SortFields))
+	for name := range r.cfg.SortFields {
+		fields = append(fields, name)
+	}
+	return fields
+}
+
+// encodeCursor encodes the insertion sequence of e as the keyset cursor of
+// the page following req.
+func (r *Repository[T, ID]) encodeCursor(req domain.CursorRequest, e entry[T]) (string, error) {
+	cursor, _ := domain.NewKeysetCursor(e.seq)
+	return req.NextCursor(cursor.Strings()...)
+}
+
+// decodeCursor returns the entry, live or deleted, identified by cursor.
+// The caller holds r.mu.
+func (r *Repository[T, ID]) decodeCursor(cursor string) (entry[T], error) {
+	k, err := domain.DecodeKeysetCursor(cursor)
+	if err != nil {
+		return entry[T]{}, err
+	}
+	seq, ok := k.Values()[0].(int64)
+	if k.Len() != 1 || !ok {
+		return entry[T]{}, domain.ErrInvalidCursor
+	}
+	for _, e := range r.entries {
+		if e.seq == seq {
+			return e, nil
+		}
+	}
+	return entry[T]{}, fmt.Errorf("%w: unknown position", domain.ErrInvalidCursor)
+}
+
+// clones copies the aggregates of entries for the caller.
+func (r *Repository[T, ID]) clones(entries []entry[T]) []T {
+	items := make([]T, len(entries))
+	for i, e := range entries {
+		items[i] = r.cfg.Clone(e.aggregate)
+	}
+	return items
+}
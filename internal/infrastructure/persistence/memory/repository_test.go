@@ -0,0 +1,295 @@
+package memory
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"strconv"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/blackhorseya/go-ddd/internal/domain"
+	"github.com/blackhorseya/go-ddd/internal/domain/order"
+	"github.com/blackhorseya/go-ddd/internal/domain/valueobject"
+)
+
+// seedOrders saves n pending orders o0..o(n-1) with increasing totals and creation times.
+func seedOrders(t *testing.T, repo *OrderRepository, n int) []*order.Order {
+	t.Helper()
+	orders := make([]*order.Order, n)
+	for i := range n {
+		total, err := valueobject.NewMoney(int64(100*(i+1)), "USD")
+		if err != nil {
+			t.Fatalf("NewMoney() error = %v", err)
+		}
+		o, err := order.NewOrder("o"+strconv.Itoa(i), "c1", total)
+		if err != nil {
+			t.Fatalf("NewOrder() error = %v", err)
+		}
+		if err := repo.Save(context.Background(), o); err != nil {
+			t.Fatalf("Save(%s) error = %v", o.ID(), err)
+		}
+		orders[i] = o
+		time.Sleep(time.Millisecond)
+	}
+	return orders
+}
+
+// ids returns the IDs of orders.
+func ids(orders []*order.Order) []string {
+	out := make([]string, len(orders))
+	for i, o := range orders {
+		out[i] = o.ID()
+	}
+	return out
+}
+
+func TestRepository_SaveAndGet(t *testing.T) {
+	// Arrange
+	ctx := context.Background()
+	repo := NewOrderRepository()
+	saved := seedOrders(t, repo, 1)[0]
+
+	// Act
+	got, err := repo.Get(ctx, "o0")
+
+	// Assert
+	if err != nil {
+		t.Fatalf("Get() error = %v", err)
+	}
+	if got == saved {
+		t.Error("Get() returned the saved pointer, want a copy")
+	}
+	if got.Version() != 1 || got.CustomerID() != "c1" || got.CreatedBy() == "" {
+		t.Errorf("Get() = version %d, customer %q, created by %q", got.Version(), got.CustomerID(), got.CreatedBy())
+	}
+
+	// Unsaved changes stay with the caller
+	_ = got.Confirm()
+	again, _ := repo.Get(ctx, "o0")
+	if again.Status() != order.StatusPending {
+		t.Errorf("status after unsaved Confirm = %s, want %s", again.Status(), order.StatusPending)
+	}
+	if _, err := repo.Get(ctx, "missing"); !errors.Is(err, domain.ErrNotFound) {
+		t.Errorf("Get(missing) error = %v, want %v", err, domain.ErrNotFound)
+	}
+}
+
+func TestRepository_OptimisticLocking(t *testing.T) {
+	// Arrange
+	ctx := context.Background()
+	repo := NewOrderRepository()
+	seedOrders(t, repo, 1)
+	first, _ := repo.Get(ctx, "o0")
+	second, _ := repo.Get(ctx, "o0")
+
+	// Act
+	_ = first.Confirm()
+	errFirst := repo.Save(ctx, first)
+	_ = second.Cancel()
+	errSecond := repo.Save(ctx, second)
+
+	// Assert
+	if errFirst != nil {
+		t.Fatalf("Save(first) error = %v", errFirst)
+	}
+	if !errors.Is(errSecond, domain.ErrConcurrentModification) {
+		t.Errorf("Save(stale) error = %v, want %v", errSecond, domain.ErrConcurrentModification)
+	}
+	dup, _ := order.NewOrder("o0", "c2", first.Total())
+	if err := repo.Save(ctx, dup); !errors.Is(err, domain.ErrConcurrentModification) {
+		t.Errorf("Save(duplicate) error = %v, want %v", err, domain.ErrConcurrentModification)
+	}
+}
+
+func TestRepository_Delete(t *testing.T) {
+	// Arrange
+	ctx := context.Background()
+	repo := NewOrderRepository()
+	seedOrders(t, repo, 2)
+
+	// Act
+	err := repo.Delete(ctx, "o0")
+
+	// Assert
+	if err != nil {
+		t.Fatalf("Delete() error = %v", err)
+	}
+	if _, err := repo.Get(ctx, "o0"); !errors.Is(err, domain.ErrNotFound) {
+		t.Errorf("Get(deleted) error = %v, want %v", err, domain.ErrNotFound)
+	}
+	if err := repo.Delete(ctx, "o0"); !errors.Is(err, domain.ErrNotFound) {
+		t.Errorf("Delete(deleted) error = %v, want %v", err, domain.ErrNotFound)
+	}
+	if n, _ := repo.CountByCustomer(ctx, "c1"); n != 1 {
+		t.Errorf("CountByCustomer() = %d, want 1", n)
+	}
+	if repo.Len() != 2 {
+		t.Errorf("Len() = %d, want 2", repo.Len())
+	}
+}
+
+func TestRepository_FindPage(t *testing.T) {
+	ctx := context.Background()
+	repo := NewOrderRepository()
+	orders := seedOrders(t, repo, 5)
+	_ = orders[1].Confirm()
+	if err := repo.Save(ctx, orders[1]); err != nil {
+		t.Fatalf("Save() error = %v", err)
+	}
+
+	tests := []struct {
+		name      string
+		spec      domain.Specification[*order.Order]
+		page      int
+		sort      []domain.SortOption
+		wantIDs   []string
+		wantTotal int64
+		wantErr   error
+	}{
+		{name: "default sort newest first", page: 1, wantIDs: []string{"o4", "o3"}, wantTotal: 5},
+		{name: "last page", page: 3, wantIDs: []string{"o0"}, wantTotal: 5},
+		{name: "past the end", page: 4, wantIDs: []string{}, wantTotal: 5},
+		{
+			name:      "sort by total ascending",
+			page:      1,
+			sort:      []domain.SortOption{domain.NewSortOption("total", domain.SortAsc)},
+			wantIDs:   []string{"o0", "o1"},
+			wantTotal: 5,
+		},
+		{
+			name:      "specification",
+			spec:      order.StatusSpec{Status: order.StatusPending},
+			page:      1,
+			wantIDs:   []string{"o4", "o3"},
+			wantTotal: 4,
+		},
+		{
+			name:    "unknown sort field",
+			page:    1,
+			sort:    []domain.SortOption{domain.NewSortOption("status", domain.SortAsc)},
+			wantErr: domain.ErrInvalidSortField,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Arrange
+			req, _ := domain.NewPageRequest(tt.page, 2)
+			req = req.WithSort(tt.sort...)
+
+			// Act
+			got, err := repo.FindPage(ctx, tt.spec, req)
+
+			// Assert
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("FindPage() error = %v, want %v", err, tt.wantErr)
+			}
+			if tt.wantErr != nil {
+				return
+			}
+			if ids := ids(got.Items()); !slices.Equal(ids, tt.wantIDs) {
+				t.Errorf("FindPage() ids = %v, want %v", ids, tt.wantIDs)
+			}
+			if got.TotalItems() != tt.wantTotal {
+				t.Errorf("FindPage() total = %d, want %d", got.TotalItems(), tt.wantTotal)
+			}
+		})
+	}
+}
+
+func TestRepository_FindCursor(t *testing.T) {
+	// Arrange
+	ctx := context.Background()
+	repo := NewOrderRepository()
+	seedOrders(t, repo, 5)
+	req, _ := domain.NewCursorRequest("", 3)
+
+	// Act
+	first, err := repo.FindCursor(ctx, nil, req)
+	if err != nil {
+		t.Fatalf("FindCursor() error = %v", err)
+	}
+	// Deleting the last item of a page does not shift the next one
+	if err := repo.Delete(ctx, "o2"); err != nil {
+		t.Fatalf("Delete() error = %v", err)
+	}
+	req, _ = domain.NewCursorRequest(first.NextCursor(), 3)
+	second, err := repo.FindCursor(ctx, nil, req)
+	if err != nil {
+		t.Fatalf("FindCursor(next) error = %v", err)
+	}
+
+	// Assert
+	if got := ids(first.Items()); !slices.Equal(got, []string{"o4", "o3", "o2"}) || !first.HasMore() {
+		t.Errorf("first page = %v (hasMore %v)", got, first.HasMore())
+	}
+	if got := ids(second.Items()); !slices.Equal(got, []string{"o1", "o0"}) || second.HasMore() {
+		t.Errorf("second page = %v (hasMore %v)", got, second.HasMore())
+	}
+
+	bad, _ := domain.NewCursorRequest(domain.EncodeCursor("i:99"), 3)
+	if _, err := repo.FindCursor(ctx, nil, bad); !errors.Is(err, domain.ErrInvalidCursor) {
+		t.Errorf("FindCursor(unknown cursor) error = %v, want %v", err, domain.ErrInvalidCursor)
+	}
+}
+
+func TestRepository_FindCursor_OffsetCursor(t *testing.T) {
+	// Arrange
+	ctx := context.Background()
+	repo := NewOrderRepository()
+	seedOrders(t, repo, 5)
+	page, _ := domain.NewPageRequest(1, 2)
+	req := domain.CursorRequestFromPage(page.WithSort(domain.NewSortOption("total", domain.SortAsc)))
+
+	// Act
+	var pages [][]string
+	var prev string
+	for {
+		res, err := repo.FindCursor(ctx, nil, req)
+		if err != nil {
+			t.Fatalf("FindCursor(%q) error = %v", req.Cursor(), err)
+		}
+		pages = append(pages, ids(res.Items()))
+		prev = res.PrevCursor()
+		if !res.HasMore() {
+			break
+		}
+		req, _ = domain.NewCursorRequest(res.NextCursor(), 2)
+		req = req.WithSort(domain.NewSortOption("total", domain.SortAsc))
+	}
+
+	// Assert
+	want := [][]string{{"o0", "o1"}, {"o2", "o3"}, {"o4"}}
+	if !slices.EqualFunc(pages, want, slices.Equal) {
+		t.Errorf("pages = %v, want %v", pages, want)
+	}
+	if offset, err := domain.DecodeOffsetCursor(prev); err != nil || offset != 2 {
+		t.Errorf("last PrevCursor() offset = %d, %v, want 2", offset, err)
+	}
+}
+
+func TestRepository_ConcurrentSaves(t *testing.T) {
+	// Arrange
+	ctx := context.Background()
+	repo := NewOrderRepository()
+	total, _ := valueobject.NewMoney(100, "USD")
+
+	// Act
+	var wg sync.WaitGroup
+	for i := range 50 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			o, _ := order.NewOrder("o"+strconv.Itoa(i), "c1", total)
+			_ = repo.Save(ctx, o)
+			_, _ = repo.FindPage(ctx, nil, domain.NewPageRequestWithDefaults())
+		}()
+	}
+	wg.Wait()
+
+	// Assert
+	if n, _ := repo.Count(ctx, nil); n != 50 {
+		t.Errorf("Count() = %d, want 50", n)
+	}
+}
@@ -0,0 +1,89 @@
+// Package memory provides thread-safe in-memory implementations of the
+// generic domain.Repository and domain.UnitOfWork, so use case tests run
+// without a database.
+//
+// Repositories keep the semantics of the SQL and DynamoDB adapters:
+// optimistic locking, soft deletion, audit hooks, specifications and both
+// pagination styles. Writes made through a context from UnitOfWork.Do are
+// undone when fn fails.
+package memory
+
+import (
+	"context"
+	"sync"
+
+	"github.com/blackhorseya/go-ddd/internal/domain"
+)
+
+// txKeyType is the context key for the active transaction.
+type txKeyType struct{}
+
+var txKey = txKeyType{}
+
+// tx records how to undo the writes made in a transaction.
+type tx struct {
+	mu   sync.Mutex
+	undo []func()
+}
+
+func (t *tx) record(undo func()) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.undo = append(t.undo, undo)
+}
+
+// rollback undoes the recorded writes, latest first.
+func (t *tx) rollback() {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	for i := len(t.undo) - 1; i >= 0; i-- {
+		t.undo[i]()
+	}
+	t.undo = nil
+}
+
+// UnitOfWork is a domain.UnitOfWork for memory repositories. Transactions
+// are serialized; writes are visible to readers outside the transaction
+// before it ends, i.e. the isolation level is read uncommitted.
+type UnitOfWork struct {
+	mu sync.Mutex
+}
+
+var _ domain.UnitOfWork = (*UnitOfWork)(nil)
+
+// NewUnitOfWork creates a UnitOfWork.
+func NewUnitOfWork() *UnitOfWork {
+	return &UnitOfWork{}
+}
+
+// Do runs fn in a transaction bound to the context and undoes its writes
+// if fn returns an error. If ctx already carries a transaction, fn joins it
+// and the outermost Do decides. A panic in fn rolls back and is re-raised.
+func (u *UnitOfWork) Do(ctx context.Context, fn func(ctx context.Context) error) error {
+	if txFrom(ctx) != nil {
+		return fn(ctx)
+	}
+
+	u.mu.Lock()
+	defer u.mu.Unlock()
+
+	t := &tx{}
+	defer func() {
+		if rec := recover(); rec != nil {
+			t.rollback()
+			panic(rec)
+		}
+	}()
+
+	if err := fn(context.WithValue(ctx, txKey, t)); err != nil {
+		t.rollback()
+		return err
+	}
+	return nil
+}
+
+// txFrom returns the transaction bound to ctx, or nil.
+func txFrom(ctx context.Context) *tx {
+	t, _ := ctx.Value(txKey).(*tx)
+	return t
+}
@@ -0,0 +1,115 @@
+package memory
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/blackhorseya/go-ddd/internal/domain"
+	"github.com/blackhorseya/go-ddd/internal/domain/order"
+)
+
+func TestUnitOfWork_Do(t *testing.T) {
+	errFail := errors.New("fail")
+
+	tests := []struct {
+		name       string
+		fn         func(ctx context.Context, repo *OrderRepository) error
+		wantErr    error
+		wantStatus order.Status
+		wantO1     bool
+	}{
+		{
+			name: "commit keeps writes",
+			fn: func(ctx context.Context, repo *OrderRepository) error {
+				o, _ := repo.Get(ctx, "o0")
+				_ = o.Confirm()
+				return repo.Save(ctx, o)
+			},
+			wantStatus: order.StatusConfirmed,
+			wantO1:     true,
+		},
+		{
+			name: "error undoes updates, inserts and deletes",
+			fn: func(ctx context.Context, repo *OrderRepository) error {
+				o, _ := repo.Get(ctx, "o0")
+				_ = o.Confirm()
+				if err := repo.Save(ctx, o); err != nil {
+					return err
+				}
+				_ = o.Cancel()
+				if err := repo.Delete(ctx, "o1"); err != nil {
+					return err
+				}
+				dup, _ := order.NewOrder("o9", "c1", o.Total())
+				if err := repo.Save(ctx, dup); err != nil {
+					return err
+				}
+				return errFail
+			},
+			wantErr:    errFail,
+			wantStatus: order.StatusPending,
+			wantO1:     true,
+		},
+		{
+			name: "nested Do joins the outer transaction",
+			fn: func(ctx context.Context, repo *OrderRepository) error {
+				_ = NewUnitOfWork().Do(ctx, func(ctx context.Context) error {
+					return repo.Delete(ctx, "o1")
+				})
+				return errFail
+			},
+			wantErr:    errFail,
+			wantStatus: order.StatusPending,
+			wantO1:     true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Arrange
+			ctx := context.Background()
+			repo := NewOrderRepository()
+			seedOrders(t, repo, 2)
+			uow := NewUnitOfWork()
+
+			// Act
+			err := uow.Do(ctx, func(ctx context.Context) error { return tt.fn(ctx, repo) })
+
+			// Assert
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("Do() error = %v, want %v", err, tt.wantErr)
+			}
+			o, _ := repo.Get(ctx, "o0")
+			if o.Status() != tt.wantStatus {
+				t.Errorf("status = %s, want %s", o.Status(), tt.wantStatus)
+			}
+			if _, err := repo.Get(ctx, "o1"); (err == nil) != tt.wantO1 {
+				t.Errorf("Get(o1) error = %v", err)
+			}
+			if _, err := repo.Get(ctx, "o9"); !errors.Is(err, domain.ErrNotFound) {
+				t.Errorf("Get(o9) error = %v, want %v", err, domain.ErrNotFound)
+			}
+		})
+	}
+}
+
+func TestUnitOfWork_PanicRollsBack(t *testing.T) {
+	// Arrange
+	ctx := context.Background()
+	repo := NewOrderRepository()
+	seedOrders(t, repo, 1)
+
+	// Act
+	func() {
+		defer func() { _ = recover() }()
+		_ = NewUnitOfWork().Do(ctx, func(ctx context.Context) error {
+			_ = repo.Delete(ctx, "o0")
+			panic("boom")
+		})
+	}()
+
+	// Assert
+	if _, err := repo.Get(ctx, "o0"); err != nil {
+		t.Errorf("Get() after panic error = %v", err)
+	}
+}
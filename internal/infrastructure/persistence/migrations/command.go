@@ -0,0 +1,93 @@
+package migrations
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"text/tabwriter"
+
+	"github.com/blackhorseya/go-ddd/internal/infrastructure/config"
+)
+
+// ErrUsage is returned for an unknown or malformed migrate subcommand.
+var ErrUsage = errors.New("usage: migrate up | down [N] | status")
+
+// Command runs the `migrate` CLI subcommand against cfg:
+//
+//	migrate up        apply all pending migrations
+//	migrate down [N]  roll back the last N migrations (default 1)
+//	migrate status    print the applied version and each migration
+func Command(ctx context.Context, cfg config.Database, args []string, w io.Writer) error {
+	if len(args) == 0 {
+		return ErrUsage
+	}
+
+	steps := 1
+	switch args[0] {
+	case "up", "status":
+		if len(args) != 1 {
+			return ErrUsage
+		}
+	case "down":
+		if len(args) > 2 {
+			return ErrUsage
+		}
+		if len(args) == 2 {
+			n, err := strconv.Atoi(args[1])
+			if err != nil || n < 1 {
+				return fmt.Errorf("%w: invalid step count %q", ErrUsage, args[1])
+			}
+			steps = n
+		}
+	default:
+		return ErrUsage
+	}
+
+	r, err := Open(cfg)
+	if err != nil {
+		return err
+	}
+	defer r.Close()
+
+	return run(ctx, r, args[0], steps, w)
+}
+
+// run executes a parsed subcommand.
+func run(ctx context.Context, r *Runner, cmd string, steps int, w io.Writer) error {
+	switch cmd {
+	case "up":
+		return r.Up(ctx)
+	case "down":
+		return r.Down(ctx, steps)
+	default:
+		st, err := r.Status()
+		if err != nil {
+			return err
+		}
+		return PrintStatus(w, st)
+	}
+}
+
+// PrintStatus writes st as a table.
+func PrintStatus(w io.Writer, st Status) error {
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(tw, "VERSION\tNAME\tSTATUS")
+	for _, m := range st.Migrations {
+		state := "pending"
+		if m.Applied {
+			state = "applied"
+		}
+		if m.Applied && m.Version == st.Version && st.Dirty {
+			state = "dirty"
+		}
+		fmt.Fprintf(tw, "%06d\t%s\t%s\n", m.Version, m.Name, state)
+	}
+	if err := tw.Flush(); err != nil {
+		return err
+	}
+
+	_, err := fmt.Fprintf(w, "\ncurrent version: %d\n", st.Version)
+	return err
+}
@@ -0,0 +1,196 @@
+// Package migrations applies the embedded PostgreSQL schema migrations with
+// golang-migrate. Migration files live in sql/ as numbered pairs
+// (000001_create_orders.up.sql / .down.sql) and are compiled into the binary,
+// so deployments need no migration files on disk.
+package migrations
+
+import (
+	"context"
+	"embed"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+
+	"github.com/golang-migrate/migrate/v4"
+	"github.com/golang-migrate/migrate/v4/database"
+	pgxmigrate "github.com/golang-migrate/migrate/v4/database/pgx/v5"
+	"github.com/golang-migrate/migrate/v4/source"
+	"github.com/golang-migrate/migrate/v4/source/iofs"
+
+	"github.com/blackhorseya/go-ddd/internal/infrastructure/config"
+	"github.com/blackhorseya/go-ddd/internal/infrastructure/persistence/postgres"
+	"github.com/blackhorseya/go-ddd/internal/infrastructure/persistence/sqldb"
+	"github.com/blackhorseya/go-ddd/pkg/contextx"
+)
+
+// ErrUnsupportedDriver is returned for database drivers without embedded migrations.
+var ErrUnsupportedDriver = errors.New("embedded migrations support postgres only")
+
+//go:embed sql/*.sql
+var files embed.FS
+
+// FS holds the embedded migration files.
+var FS, _ = fs.Sub(files, "sql")
+
+// Migration is a migration file pair and whether it is applied.
+type Migration struct {
+	Version uint
+	Name    string
+	Applied bool
+}
+
+// Status is the migration state of a database.
+type Status struct {
+	// Version is the last applied migration; 0 when none is applied.
+	Version uint
+
+	// Dirty reports a migration that failed halfway and needs manual repair.
+	Dirty bool
+
+	// Migrations lists every known migration in version order.
+	Migrations []Migration
+}
+
+// Runner applies migrations to a database. It owns its connection pool;
+// Close releases it.
+type Runner struct {
+	m    *migrate.Migrate
+	fsys fs.FS
+}
+
+// Open opens a dedicated pool for cfg and prepares the embedded migrations.
+// Concurrent runners are serialized by a database lock.
+func Open(cfg config.Database) (*Runner, error) {
+	d, err := sqldb.DialectFor(cfg.Driver)
+	if err != nil {
+		return nil, err
+	}
+	if d != sqldb.Postgres {
+		return nil, fmt.Errorf("%w: %q", ErrUnsupportedDriver, cfg.Driver)
+	}
+
+	db, err := postgres.Open(cfg)
+	if err != nil {
+		return nil, err
+	}
+	drv, err := pgxmigrate.WithInstance(db, &pgxmigrate.Config{})
+	if err != nil {
+		_ = db.Close()
+		return nil, fmt.Errorf("open migration driver: %w", err)
+	}
+
+	r, err := New(drv, FS)
+	if err != nil {
+		_ = drv.Close()
+		return nil, err
+	}
+	return r, nil
+}
+
+// New creates a Runner applying the migrations in fsys through drv.
+func New(drv database.Driver, fsys fs.FS) (*Runner, error) {
+	src, err := iofs.New(fsys, ".")
+	if err != nil {
+		return nil, fmt.Errorf("open migration source: %w", err)
+	}
+	m, err := migrate.NewWithInstance("iofs", src, "db", drv)
+	if err != nil {
+		return nil, fmt.Errorf("create migrator: %w", err)
+	}
+	return &Runner{m: m, fsys: fsys}, nil
+}
+
+// Up applies all pending migrations.
+func (r *Runner) Up(ctx context.Context) error {
+	err := r.m.Up()
+	if errors.Is(err, migrate.ErrNoChange) {
+		contextx.From(ctx).Info("database schema is up to date")
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("migrate up: %w", err)
+	}
+	return r.logVersion(ctx, "migrations applied")
+}
+
+// Down rolls back the last steps migrations, or all applied ones if fewer.
+func (r *Runner) Down(ctx context.Context, steps int) error {
+	if steps < 1 {
+		return fmt.Errorf("migrate down: steps must be positive, got %d", steps)
+	}
+	err := r.m.Steps(-steps)
+	if errors.Is(err, migrate.ErrNoChange) || errors.Is(err, migrate.ErrNilVersion) || errors.Is(err, os.ErrNotExist) {
+		contextx.From(ctx).Info("no migration to roll back")
+		return nil
+	}
+	var short migrate.ErrShortLimit
+	if err != nil && !errors.As(err, &short) {
+		return fmt.Errorf("migrate down: %w", err)
+	}
+	return r.logVersion(ctx, "migrations rolled back")
+}
+
+// Status returns the applied version and the known migrations.
+func (r *Runner) Status() (Status, error) {
+	version, dirty, err := r.m.Version()
+	if err != nil && !errors.Is(err, migrate.ErrNilVersion) {
+		return Status{}, fmt.Errorf("read migration version: %w", err)
+	}
+
+	entries, err := fs.ReadDir(r.fsys, ".")
+	if err != nil {
+		return Status{}, fmt.Errorf("read migration source: %w", err)
+	}
+
+	st := Status{Version: version, Dirty: dirty}
+	for _, e := range entries {
+		mig, err := source.Parse(e.Name())
+		if err != nil || mig.Direction != source.Up {
+			continue
+		}
+		st.Migrations = append(st.Migrations, Migration{
+			Version: mig.Version,
+			Name:    mig.Identifier,
+			Applied: mig.Version <= version,
+		})
+	}
+	return st, nil
+}
+
+// Close releases the source and the database pool.
+func (r *Runner) Close() error {
+	srcErr, dbErr := r.m.Close()
+	return errors.Join(srcErr, dbErr)
+}
+
+// logVersion logs msg with the current schema version.
+func (r *Runner) logVersion(ctx context.Context, msg string) error {
+	version, dirty, err := r.m.Version()
+	if err != nil && !errors.Is(err, migrate.ErrNilVersion) {
+		return fmt.Errorf("read migration version: %w", err)
+	}
+	contextx.From(ctx).Info(msg, "version", version, "dirty", dirty)
+	return nil
+}
+
+// AutoMigrate applies pending migrations at startup when
+// database.auto_migrate is enabled. It never runs in production, where
+// migrations are a deliberate deployment step (`migrate up`).
+func AutoMigrate(ctx context.Context, cfg *config.Config) error {
+	if !cfg.Database.AutoMigrate {
+		return nil
+	}
+	if cfg.IsProduction() {
+		contextx.From(ctx).Warn("database.auto_migrate is ignored in production")
+		return nil
+	}
+
+	r, err := Open(cfg.Database)
+	if err != nil {
+		return err
+	}
+	defer r.Close()
+
+	return r.Up(ctx)
+}
@@ -0,0 +1,193 @@
+package migrations
+
+import (
+	"bytes"
+	"context"
+	"database/sql"
+	"errors"
+	"io/fs"
+	"os"
+	"strings"
+	"testing"
+
+	sqlitemigrate "github.com/golang-migrate/migrate/v4/database/sqlite"
+
+	"github.com/blackhorseya/go-ddd/internal/infrastructure/config"
+)
+
+// newTestRunner returns a Runner applying testdata migrations to an in-memory SQLite database.
+func newTestRunner(t *testing.T) *Runner {
+	t.Helper()
+	db, err := sql.Open("sqlite", ":memory:")
+	if err != nil {
+		t.Fatalf("sql.Open() error = %v", err)
+	}
+	db.SetMaxOpenConns(1)
+
+	drv, err := sqlitemigrate.WithInstance(db, &sqlitemigrate.Config{})
+	if err != nil {
+		t.Fatalf("WithInstance() error = %v", err)
+	}
+	r, err := New(drv, os.DirFS("testdata"))
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+	t.Cleanup(func() { _ = r.Close() })
+	return r
+}
+
+func TestRunner_UpDownStatus(t *testing.T) {
+	// Arrange
+	ctx := context.Background()
+	r := newTestRunner(t)
+
+	// Act & Assert
+	st, err := r.Status()
+	if err != nil {
+		t.Fatalf("Status() error = %v", err)
+	}
+	if st.Version != 0 || len(st.Migrations) != 2 || st.Migrations[0].Applied {
+		t.Fatalf("initial Status() = %+v, want version 0 with 2 pending", st)
+	}
+
+	if err := r.Up(ctx); err != nil {
+		t.Fatalf("Up() error = %v", err)
+	}
+	if err := r.Up(ctx); err != nil {
+		t.Fatalf("Up() again error = %v", err)
+	}
+	st, _ = r.Status()
+	if st.Version != 2 || !st.Migrations[1].Applied || st.Migrations[1].Name != "add_widget_name" {
+		t.Fatalf("Status() after up = %+v, want version 2 all applied", st)
+	}
+
+	if err := r.Down(ctx, 1); err != nil {
+		t.Fatalf("Down(1) error = %v", err)
+	}
+	st, _ = r.Status()
+	if st.Version != 1 || st.Migrations[1].Applied {
+		t.Fatalf("Status() after down = %+v, want version 1", st)
+	}
+
+	if err := r.Down(ctx, 5); err != nil {
+		t.Fatalf("Down(5) error = %v", err)
+	}
+	if err := r.Down(ctx, 1); err != nil {
+		t.Fatalf("Down(1) on empty schema error = %v", err)
+	}
+	st, _ = r.Status()
+	if st.Version != 0 {
+		t.Errorf("Status() after full down = %+v, want version 0", st)
+	}
+}
+
+func TestFS_Pairs(t *testing.T) {
+	// Arrange
+	entries, err := fs.ReadDir(FS, ".")
+	if err != nil {
+		t.Fatalf("ReadDir() error = %v", err)
+	}
+
+	// Act
+	names := make(map[string]bool, len(entries))
+	for _, e := range entries {
+		names[e.Name()] = true
+	}
+
+	// Assert
+	if len(names) == 0 {
+		t.Fatal("no embedded migrations")
+	}
+	for name := range names {
+		if up, ok := strings.CutSuffix(name, ".up.sql"); ok && !names[up+".down.sql"] {
+			t.Errorf("%s has no down migration", name)
+		}
+	}
+}
+
+func TestCommand_Usage(t *testing.T) {
+	tests := []struct {
+		name string
+		args []string
+	}{
+		{name: "no subcommand", args: nil},
+		{name: "unknown subcommand", args: []string{"sideways"}},
+		{name: "up with argument", args: []string{"up", "1"}},
+		{name: "invalid step count", args: []string{"down", "zero"}},
+		{name: "negative step count", args: []string{"down", "-1"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Act
+			err := Command(context.Background(), config.Database{}, tt.args, &bytes.Buffer{})
+
+			// Assert
+			if !errors.Is(err, ErrUsage) {
+				t.Errorf("Command(%v) error = %v, want %v", tt.args, err, ErrUsage)
+			}
+		})
+	}
+}
+
+func TestCommand_UnsupportedDriver(t *testing.T) {
+	// Act
+	err := Command(context.Background(), config.Database{Driver: "mysql"}, []string{"status"}, &bytes.Buffer{})
+
+	// Assert
+	if !errors.Is(err, ErrUnsupportedDriver) {
+		t.Errorf("Command() error = %v, want %v", err, ErrUnsupportedDriver)
+	}
+}
+
+func TestPrintStatus(t *testing.T) {
+	// Arrange
+	var buf bytes.Buffer
+	st := Status{
+		Version: 2,
+		Dirty:   true,
+		Migrations: []Migration{
+			{Version: 1, Name: "create_orders", Applied: true},
+			{Version: 2, Name: "add_index", Applied: true},
+			{Version: 3, Name: "add_column"},
+		},
+	}
+
+	// Act
+	err := PrintStatus(&buf, st)
+
+	// Assert
+	if err != nil {
+		t.Fatalf("PrintStatus() error = %v", err)
+	}
+	want := "VERSION  NAME           STATUS\n" +
+		"000001   create_orders  applied\n" +
+		"000002   add_index      dirty\n" +
+		"000003   add_column     pending\n" +
+		"\ncurrent version: 2\n"
+	if buf.String() != want {
+		t.Errorf("PrintStatus() =\n%s\nwant\n%s", buf.String(), want)
+	}
+}
+
+func TestAutoMigrate_Skipped(t *testing.T) {
+	tests := []struct {
+		name string
+		cfg  config.Config
+	}{
+		{name: "disabled", cfg: config.Config{App: config.App{Env: "development"}}},
+		{name: "production", cfg: config.Config{App: config.App{Env: "production"}, Database: config.Database{AutoMigrate: true}}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Act: the empty database config would fail if AutoMigrate connected.
+			err := AutoMigrate(context.Background(), &tt.cfg)
+
+			// Assert
+			if err != nil {
+				t.Errorf("AutoMigrate() error = %v, want nil", err)
+			}
+		})
+	}
+}
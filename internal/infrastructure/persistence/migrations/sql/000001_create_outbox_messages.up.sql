@@ -0,0 +1,18 @@
+-- Transactional outbox table (PostgreSQL).
+-- Domain events are inserted in the same transaction as the aggregate and
+-- published asynchronously by the outbox relay.
+CREATE TABLE IF NOT EXISTS outbox_messages (
+    id           BIGSERIAL PRIMARY KEY,
+    aggregate_id TEXT        NOT NULL,
+    event_name   TEXT        NOT NULL,
+    payload      JSONB       NOT NULL,
+    occurred_at  TIMESTAMPTZ NOT NULL,
+    created_at   TIMESTAMPTZ NOT NULL DEFAULT now(),
+    published_at TIMESTAMPTZ,
+    attempts     INT         NOT NULL DEFAULT 0,
+    last_error   TEXT
+);
+
+CREATE INDEX IF NOT EXISTS idx_outbox_messages_pending
+    ON outbox_messages (id)
+    WHERE published_at IS NULL;
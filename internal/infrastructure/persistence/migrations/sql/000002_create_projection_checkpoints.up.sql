@@ -0,0 +1,7 @@
+-- Projection checkpoints (PostgreSQL).
+-- Each projection stores the ID of the last outbox message it applied.
+CREATE TABLE IF NOT EXISTS projection_checkpoints (
+    name       TEXT        PRIMARY KEY,
+    position   BIGINT      NOT NULL,
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT now()
+);
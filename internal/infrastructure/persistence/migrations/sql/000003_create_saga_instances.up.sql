@@ -0,0 +1,14 @@
+-- Saga instances (PostgreSQL).
+CREATE TABLE IF NOT EXISTS saga_instances (
+    id         TEXT        PRIMARY KEY,
+    saga       TEXT        NOT NULL,
+    status     TEXT        NOT NULL,
+    step       INT         NOT NULL DEFAULT 0,
+    data       JSONB       NOT NULL,
+    error      TEXT        NOT NULL DEFAULT '',
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT now()
+);
+
+CREATE INDEX IF NOT EXISTS idx_saga_instances_active
+    ON saga_instances (id)
+    WHERE status IN ('running', 'compensating');
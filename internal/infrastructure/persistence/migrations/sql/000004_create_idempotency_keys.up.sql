@@ -0,0 +1,12 @@
+-- Idempotency keys (PostgreSQL).
+-- A row is pending while a command runs and completed once it succeeds.
+CREATE TABLE IF NOT EXISTS idempotency_keys (
+    key          TEXT        PRIMARY KEY,
+    completed    BOOLEAN     NOT NULL DEFAULT FALSE,
+    locked_at    TIMESTAMPTZ NOT NULL DEFAULT now(),
+    completed_at TIMESTAMPTZ
+);
+
+CREATE INDEX IF NOT EXISTS idx_idempotency_keys_completed_at
+    ON idempotency_keys (completed_at)
+    WHERE completed;
@@ -0,0 +1,12 @@
+-- Export jobs (PostgreSQL).
+CREATE TABLE IF NOT EXISTS export_jobs (
+    id          TEXT        PRIMARY KEY,
+    exporter    TEXT        NOT NULL,
+    format      TEXT        NOT NULL,
+    status      TEXT        NOT NULL,
+    rows        INT         NOT NULL DEFAULT 0,
+    key         TEXT        NOT NULL,
+    error       TEXT        NOT NULL DEFAULT '',
+    created_at  TIMESTAMPTZ NOT NULL DEFAULT now(),
+    finished_at TIMESTAMPTZ
+);
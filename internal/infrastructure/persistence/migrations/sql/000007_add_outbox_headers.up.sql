@@ -0,0 +1,3 @@
+-- Message headers (request, correlation, tenant, user IDs and trace context)
+-- captured when the event is recorded and forwarded by the outbox relay.
+ALTER TABLE outbox_messages ADD COLUMN IF NOT EXISTS headers JSONB NOT NULL DEFAULT '{}';
@@ -0,0 +1,3 @@
+ALTER TABLE projection_checkpoints DROP COLUMN IF EXISTS tx_id;
+DROP INDEX IF EXISTS idx_outbox_messages_tx;
+ALTER TABLE outbox_messages DROP COLUMN IF EXISTS tx_id;
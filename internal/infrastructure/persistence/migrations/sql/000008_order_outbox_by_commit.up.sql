@@ -0,0 +1,17 @@
+-- Read the outbox in commit order (see outbox.Store.FetchAfter).
+-- Every message records the transaction that wrote it, and projection
+-- checkpoints store that transaction ID next to the message ID.
+--
+-- The file runs as one implicit transaction, so existing messages and
+-- checkpoints are stamped with the same transaction ID and every
+-- checkpoint resumes exactly where it stopped.
+ALTER TABLE outbox_messages
+    ADD COLUMN IF NOT EXISTS tx_id BIGINT NOT NULL DEFAULT pg_current_xact_id()::text::bigint;
+
+CREATE INDEX IF NOT EXISTS idx_outbox_messages_tx
+    ON outbox_messages (tx_id, id);
+
+ALTER TABLE projection_checkpoints
+    ADD COLUMN IF NOT EXISTS tx_id BIGINT NOT NULL DEFAULT 0;
+
+UPDATE projection_checkpoints SET tx_id = pg_current_xact_id()::text::bigint;
@@ -0,0 +1,2 @@
+ALTER TABLE idempotency_keys DROP COLUMN IF EXISTS result;
+ALTER TABLE idempotency_keys DROP COLUMN IF EXISTS payload_hash;
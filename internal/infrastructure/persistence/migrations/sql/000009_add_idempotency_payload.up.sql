@@ -0,0 +1,4 @@
+-- Payload hash and stored result of idempotent commands, so a key reused
+-- for a different command is rejected and duplicates replay the result.
+ALTER TABLE idempotency_keys ADD COLUMN IF NOT EXISTS payload_hash TEXT NOT NULL DEFAULT '';
+ALTER TABLE idempotency_keys ADD COLUMN IF NOT EXISTS result BYTEA;
@@ -0,0 +1 @@
+ALTER TABLE saga_instances DROP COLUMN IF EXISTS version;
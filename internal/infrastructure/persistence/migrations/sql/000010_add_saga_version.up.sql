@@ -0,0 +1,3 @@
+-- Optimistic lock on saga instances: a save only applies to the version
+-- it was loaded at, so two orchestrators cannot both advance an instance.
+ALTER TABLE saga_instances ADD COLUMN IF NOT EXISTS version INT NOT NULL DEFAULT 0;
@@ -0,0 +1,5 @@
+DROP INDEX IF EXISTS idx_outbox_messages_pending;
+CREATE INDEX IF NOT EXISTS idx_outbox_messages_pending
+    ON outbox_messages (id)
+    WHERE published_at IS NULL;
+ALTER TABLE outbox_messages DROP COLUMN IF EXISTS parked_at;
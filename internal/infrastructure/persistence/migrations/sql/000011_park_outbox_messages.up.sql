@@ -0,0 +1,8 @@
+-- Park messages that keep failing to publish (see outbox.Store.MarkParked)
+-- so the relay skips them instead of retrying them forever.
+ALTER TABLE outbox_messages ADD COLUMN IF NOT EXISTS parked_at TIMESTAMPTZ;
+
+DROP INDEX IF EXISTS idx_outbox_messages_pending;
+CREATE INDEX IF NOT EXISTS idx_outbox_messages_pending
+    ON outbox_messages (id)
+    WHERE published_at IS NULL AND parked_at IS NULL;
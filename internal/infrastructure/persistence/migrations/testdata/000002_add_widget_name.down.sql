@@ -0,0 +1 @@
+ALTER TABLE widgets DROP COLUMN name;
@@ -0,0 +1,108 @@
+// Package mysql opens MySQL connection pools from the shared database
+// configuration. Use sqldb.MySQL to write dialect-neutral queries.
+package mysql
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"net"
+	"strconv"
+
+	mysqldriver "github.com/go-sql-driver/mysql"
+
+	"github.com/blackhorseya/go-ddd/internal/infrastructure/config"
+	"github.com/blackhorseya/go-ddd/internal/infrastructure/persistence/sqltrace"
+)
+
+// DriverName is the database/sql driver used for MySQL.
+const DriverName = "mysql"
+
+// ErrInvalidConfig is returned when the database configuration cannot be used.
+var ErrInvalidConfig = errors.New("invalid mysql config")
+
+// tlsModes maps the libpq-style ssl_mode config values to the driver's tls parameter.
+var tlsModes = map[string]string{
+	"":            "false",
+	"disable":     "false",
+	"allow":       "preferred",
+	"prefer":      "preferred",
+	"require":     "skip-verify",
+	"verify-ca":   "true",
+	"verify-full": "true",
+}
+
+// DSN returns the go-sql-driver DSN for cfg, e.g.
+// "app:secret@tcp(localhost:3306)/app?parseTime=true&tls=false".
+// Times are parsed into time.Time and stored in UTC.
+func DSN(cfg config.Database) (string, error) {
+	tls, ok := tlsModes[cfg.SSLMode]
+	if !ok {
+		return "", fmt.Errorf("%w: ssl_mode %q", ErrInvalidConfig, cfg.SSLMode)
+	}
+
+	c := mysqldriver.NewConfig()
+	c.User = cfg.User
+	c.Passwd = cfg.Password
+	c.Net = "tcp"
+	c.Addr = net.JoinHostPort(cfg.Host, strconv.Itoa(cfg.Port))
+	c.DBName = cfg.Name
+	c.ParseTime = true
+	c.TLSConfig = tls
+	return c.FormatDSN(), nil
+}
+
+// Open creates a connection pool from cfg and applies its pool settings.
+// Statements are traced and logged when slower than slow_query_threshold
+// (see sqltrace). Connections are established lazily; use Connect to verify connectivity.
+// Close the returned pool on shutdown.
+func Open(cfg config.Database) (*sql.DB, error) {
+	if cfg.Driver != DriverName {
+		return nil, fmt.Errorf("%w: driver %q", ErrInvalidConfig, cfg.Driver)
+	}
+	if cfg.Host == "" || cfg.Name == "" {
+		return nil, fmt.Errorf("%w: host and name are required", ErrInvalidConfig)
+	}
+	dsn, err := DSN(cfg)
+	if err != nil {
+		return nil, err
+	}
+	mc, err := mysqldriver.ParseDSN(dsn)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidConfig, err)
+	}
+	connector, err := mysqldriver.NewConnector(mc)
+	if err != nil {
+		return nil, fmt.Errorf("open mysql: %w", err)
+	}
+
+	db := sql.OpenDB(sqltrace.Wrap(connector, sqltrace.Options{SlowThreshold: cfg.SlowQueryThreshold}))
+	if cfg.MaxOpenConns > 0 {
+		db.SetMaxOpenConns(cfg.MaxOpenConns)
+	}
+	if cfg.MaxIdleConns > 0 {
+		db.SetMaxIdleConns(cfg.MaxIdleConns)
+	}
+	if cfg.ConnMaxLifetime > 0 {
+		db.SetConnMaxLifetime(cfg.ConnMaxLifetime)
+	}
+	if cfg.ConnMaxIdleTime > 0 {
+		db.SetConnMaxIdleTime(cfg.ConnMaxIdleTime)
+	}
+	return db, nil
+}
+
+// Connect opens a pool like Open and pings the server, closing the pool
+// if it is unreachable.
+func Connect(ctx context.Context, cfg config.Database) (*sql.DB, error) {
+	db, err := Open(cfg)
+	if err != nil {
+		return nil, err
+	}
+	if err := db.PingContext(ctx); err != nil {
+		_ = db.Close()
+		return nil, fmt.Errorf("ping mysql %s:%d: %w", cfg.Host, cfg.Port, err)
+	}
+	return db, nil
+}
@@ -0,0 +1,95 @@
+package mysql
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/blackhorseya/go-ddd/internal/infrastructure/config"
+)
+
+func validConfig() config.Database {
+	return config.Database{
+		Driver:       "mysql",
+		Host:         "localhost",
+		Port:         3306,
+		User:         "app",
+		Password:     "p@ss:word",
+		Name:         "app",
+		SSLMode:      "disable",
+		MaxOpenConns: 4,
+	}
+}
+
+func TestDSN(t *testing.T) {
+	tests := []struct {
+		name    string
+		sslMode string
+		want    string
+		wantErr bool
+	}{
+		{
+			name:    "tls disabled",
+			sslMode: "disable",
+			want:    "app:p@ss:word@tcp(localhost:3306)/app?parseTime=true&tls=false",
+		},
+		{
+			name:    "verified tls",
+			sslMode: "verify-full",
+			want:    "app:p@ss:word@tcp(localhost:3306)/app?parseTime=true&tls=true",
+		},
+		{
+			name:    "unknown ssl mode",
+			sslMode: "on",
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Arrange
+			cfg := validConfig()
+			cfg.SSLMode = tt.sslMode
+
+			// Act
+			got, err := DSN(cfg)
+
+			// Assert
+			if tt.wantErr {
+				if !errors.Is(err, ErrInvalidConfig) {
+					t.Fatalf("DSN() error = %v, want %v", err, ErrInvalidConfig)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("DSN() error = %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("DSN() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestOpen(t *testing.T) {
+	// Arrange
+	cfg := validConfig()
+	cfg.ConnMaxLifetime = time.Minute
+
+	// Act
+	db, err := Open(cfg)
+
+	// Assert
+	if err != nil {
+		t.Fatalf("Open() error = %v", err)
+	}
+	defer db.Close()
+	if got := db.Stats().MaxOpenConnections; got != 4 {
+		t.Errorf("MaxOpenConnections = %d, want 4", got)
+	}
+
+	cfg.Driver = "postgres"
+	if _, err := Open(cfg); !errors.Is(err, ErrInvalidConfig) {
+		t.Errorf("Open(postgres) error = %v, want %v", err, ErrInvalidConfig)
+	}
+}
@@ -0,0 +1,182 @@
+// Package poolstats exports connection pool statistics of database and
+// Redis clients as OpenTelemetry metrics, so pool saturation is visible
+// before requests start failing:
+//   - pool.connections.open: gauge of open connections
+//   - pool.connections.idle: gauge of idle connections
+//   - pool.connections.in_use: gauge of connections in use
+//   - pool.connections.max: gauge of the pool size limit (0 is unlimited)
+//   - pool.wait.count: counter of requests that waited for a connection
+//   - pool.wait.duration: counter of seconds spent waiting for a connection
+//
+// Every measurement carries a "pool" attribute naming the source. A
+// Reporter samples its sources on a ticker; run it as a background
+// component.
+package poolstats
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+	goredis "github.com/redis/go-redis/v9"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/metric"
+)
+
+// instrumentationName is the OpenTelemetry meter name.
+const instrumentationName = "github.com/blackhorseya/go-ddd/internal/infrastructure/persistence/poolstats"
+
+// DefaultInterval is the sampling interval used when none is configured.
+const DefaultInterval = 15 * time.Second
+
+// Stats is a snapshot of a connection pool. WaitCount and WaitDuration
+// are cumulative since the pool was created.
+type Stats struct {
+	Open         int64
+	Idle         int64
+	InUse        int64
+	Max          int64
+	WaitCount    int64
+	WaitDuration time.Duration
+}
+
+// Source names a pool and reads its statistics.
+type Source struct {
+	Name  string
+	Stats func() Stats
+}
+
+// SQL reads the statistics of a database/sql pool.
+func SQL(name string, db *sql.DB) Source {
+	return Source{Name: name, Stats: func() Stats {
+		s := db.Stats()
+		return Stats{
+			Open:         int64(s.OpenConnections),
+			Idle:         int64(s.Idle),
+			InUse:        int64(s.InUse),
+			Max:          int64(s.MaxOpenConnections),
+			WaitCount:    s.WaitCount,
+			WaitDuration: s.WaitDuration,
+		}
+	}}
+}
+
+// PGX reads the statistics of a pgx pool. Waits are acquisitions that
+// found the pool empty.
+func PGX(name string, pool *pgxpool.Pool) Source {
+	return Source{Name: name, Stats: func() Stats {
+		s := pool.Stat()
+		return Stats{
+			Open:         int64(s.TotalConns()),
+			Idle:         int64(s.IdleConns()),
+			InUse:        int64(s.AcquiredConns()),
+			Max:          int64(s.MaxConns()),
+			WaitCount:    s.EmptyAcquireCount(),
+			WaitDuration: s.EmptyAcquireWaitTime(),
+		}
+	}}
+}
+
+// Redis reads the statistics of a go-redis client; cluster clients report
+// the sum over their node pools.
+func Redis(name string, client goredis.UniversalClient) Source {
+	return Source{Name: name, Stats: func() Stats {
+		s := client.PoolStats()
+		return Stats{
+			Open:         int64(s.TotalConns),
+			Idle:         int64(s.IdleConns),
+			InUse:        int64(s.TotalConns) - int64(s.IdleConns),
+			WaitCount:    int64(s.WaitCount),
+			WaitDuration: time.Duration(s.WaitDurationNs),
+		}
+	}}
+}
+
+// Reporter samples pool statistics on a ticker and records them.
+type Reporter struct {
+	interval time.Duration
+	sources  []Source
+	last     map[string]Stats
+
+	open         metric.Int64Gauge
+	idle         metric.Int64Gauge
+	inUse        metric.Int64Gauge
+	max          metric.Int64Gauge
+	waitCount    metric.Int64Counter
+	waitDuration metric.Float64Counter
+}
+
+// NewReporter creates a Reporter for sources using the global meter
+// provider. A non-positive interval uses DefaultInterval. Instrument
+// creation errors are ignored so a broken provider never blocks startup.
+func NewReporter(interval time.Duration, sources ...Source) *Reporter {
+	return newReporter(otel.Meter(instrumentationName), interval, sources...)
+}
+
+func newReporter(meter metric.Meter, interval time.Duration, sources ...Source) *Reporter {
+	if interval <= 0 {
+		interval = DefaultInterval
+	}
+	r := &Reporter{interval: interval, sources: sources, last: make(map[string]Stats)}
+	r.open, _ = meter.Int64Gauge("pool.connections.open",
+		metric.WithDescription("Number of open connections in the pool"),
+	)
+	r.idle, _ = meter.Int64Gauge("pool.connections.idle",
+		metric.WithDescription("Number of idle connections in the pool"),
+	)
+	r.inUse, _ = meter.Int64Gauge("pool.connections.in_use",
+		metric.WithDescription("Number of connections in use"),
+	)
+	r.max, _ = meter.Int64Gauge("pool.connections.max",
+		metric.WithDescription("Maximum number of open connections; 0 is unlimited"),
+	)
+	r.waitCount, _ = meter.Int64Counter("pool.wait.count",
+		metric.WithDescription("Number of requests that waited for a connection"),
+	)
+	r.waitDuration, _ = meter.Float64Counter("pool.wait.duration",
+		metric.WithDescription("Time spent waiting for a connection"),
+		metric.WithUnit("s"),
+	)
+	return r
+}
+
+// Run samples every source immediately and then once per interval until
+// ctx is done. It always returns nil.
+func (r *Reporter) Run(ctx context.Context) error {
+	ticker := time.NewTicker(r.interval)
+	defer ticker.Stop()
+	for {
+		r.sample(ctx)
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+		}
+	}
+}
+
+// sample records the current statistics of every source. Cumulative
+// statistics are recorded as the increase since the previous sample.
+func (r *Reporter) sample(ctx context.Context) {
+	for _, src := range r.sources {
+		s := src.Stats()
+		attrs := metric.WithAttributes(attribute.String("pool", src.Name))
+		if r.open != nil {
+			r.open.Record(ctx, s.Open, attrs)
+			r.idle.Record(ctx, s.Idle, attrs)
+			r.inUse.Record(ctx, s.InUse, attrs)
+			r.max.Record(ctx, s.Max, attrs)
+		}
+
+		prev := r.last[src.Name]
+		r.last[src.Name] = s
+		if d := s.WaitCount - prev.WaitCount; d > 0 && r.waitCount != nil {
+			r.waitCount.Add(ctx, d, attrs)
+		}
+		if d := s.WaitDuration - prev.WaitDuration; d > 0 && r.waitDuration != nil {
+			r.waitDuration.Add(ctx, d.Seconds(), attrs)
+		}
+	}
+}
@@ -0,0 +1,124 @@
+package poolstats
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"go.opentelemetry.io/otel/metric"
+	"go.opentelemetry.io/otel/metric/noop"
+)
+
+// recorder keeps the last value recorded per instrument and pool.
+type recorder map[string]float64
+
+func (r recorder) record(name string, v float64, opts []metric.RecordOption) {
+	attrs := metric.NewRecordConfig(opts).Attributes()
+	pool, _ := attrs.Value("pool")
+	r[name+"/"+pool.AsString()] = v
+}
+
+func (r recorder) add(name string, v float64, opts []metric.AddOption) {
+	attrs := metric.NewAddConfig(opts).Attributes()
+	pool, _ := attrs.Value("pool")
+	r[name+"/"+pool.AsString()] += v
+}
+
+type fakeMeter struct {
+	noop.Meter
+	rec recorder
+}
+
+type fakeGauge struct {
+	noop.Int64Gauge
+	name string
+	rec  recorder
+}
+
+func (g fakeGauge) Record(_ context.Context, v int64, opts ...metric.RecordOption) {
+	g.rec.record(g.name, float64(v), opts)
+}
+
+type fakeCounter struct {
+	noop.Int64Counter
+	name string
+	rec  recorder
+}
+
+func (c fakeCounter) Add(_ context.Context, v int64, opts ...metric.AddOption) {
+	c.rec.add(c.name, float64(v), opts)
+}
+
+type fakeFloatCounter struct {
+	noop.Float64Counter
+	name string
+	rec  recorder
+}
+
+func (c fakeFloatCounter) Add(_ context.Context, v float64, opts ...metric.AddOption) {
+	c.rec.add(c.name, v, opts)
+}
+
+func (m fakeMeter) Int64Gauge(name string, _ ...metric.Int64GaugeOption) (metric.Int64Gauge, error) {
+	return fakeGauge{name: name, rec: m.rec}, nil
+}
+
+func (m fakeMeter) Int64Counter(name string, _ ...metric.Int64CounterOption) (metric.Int64Counter, error) {
+	return fakeCounter{name: name, rec: m.rec}, nil
+}
+
+func (m fakeMeter) Float64Counter(name string, _ ...metric.Float64CounterOption) (metric.Float64Counter, error) {
+	return fakeFloatCounter{name: name, rec: m.rec}, nil
+}
+
+func TestReporter_Sample(t *testing.T) {
+	// Arrange
+	rec := recorder{}
+	stats := []Stats{
+		{Open: 4, Idle: 1, InUse: 3, Max: 10, WaitCount: 2, WaitDuration: time.Second},
+		{Open: 10, Idle: 0, InUse: 10, Max: 10, WaitCount: 7, WaitDuration: 3 * time.Second},
+	}
+	var i int
+	src := Source{Name: "database", Stats: func() Stats { return stats[i] }}
+	r := newReporter(fakeMeter{rec: rec}, time.Minute, src)
+
+	// Act
+	r.sample(context.Background())
+	i++
+	r.sample(context.Background())
+
+	// Assert
+	want := map[string]float64{
+		"pool.connections.open/database":   10,
+		"pool.connections.idle/database":   0,
+		"pool.connections.in_use/database": 10,
+		"pool.connections.max/database":    10,
+		"pool.wait.count/database":         7,
+		"pool.wait.duration/database":      3,
+	}
+	for name, v := range want {
+		if rec[name] != v {
+			t.Errorf("%s = %v, want %v", name, rec[name], v)
+		}
+	}
+}
+
+func TestReporter_RunStopsWithContext(t *testing.T) {
+	// Arrange
+	rec := recorder{}
+	src := Source{Name: "redis", Stats: func() Stats { return Stats{Open: 1} }}
+	r := newReporter(fakeMeter{rec: rec}, time.Hour, src)
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	// Act
+	err := r.Run(ctx)
+
+	// Assert
+	if err != nil {
+		t.Fatalf("Run() error = %v", err)
+	}
+	if _, ok := rec["pool.connections.open/redis"]; !ok {
+		t.Error("Run() did not sample before stopping")
+	}
+}
@@ -0,0 +1,139 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/jackc/pgx/v5/pgxpool"
+
+	"github.com/blackhorseya/go-ddd/internal/domain"
+	"github.com/blackhorseya/go-ddd/internal/infrastructure/config"
+)
+
+// ============================================================================
+// Native pgx pool (原生 pgx 連線池與交易傳遞)
+// ============================================================================
+
+// DBTX is implemented by *pgxpool.Pool, *pgx.Conn and pgx.Tx. It matches the
+// interface sqlc generates for the pgx/v5 driver.
+type DBTX interface {
+	Exec(ctx context.Context, sql string, args ...any) (pgconn.CommandTag, error)
+	Query(ctx context.Context, sql string, args ...any) (pgx.Rows, error)
+	QueryRow(ctx context.Context, sql string, args ...any) pgx.Row
+}
+
+// Beginner starts pgx transactions; *pgxpool.Pool implements it.
+type Beginner interface {
+	BeginTx(ctx context.Context, opts pgx.TxOptions) (pgx.Tx, error)
+}
+
+// NewPool creates a native pgx pool from cfg and pings the server.
+// MaxOpenConns, ConnMaxLifetime and ConnMaxIdleTime map to the pool
+// settings; MaxIdleConns has no pgxpool equivalent. Close the pool on shutdown.
+func NewPool(ctx context.Context, cfg config.Database) (*pgxpool.Pool, error) {
+	if err := validate(cfg); err != nil {
+		return nil, err
+	}
+
+	pcfg, err := pgxpool.ParseConfig(cfg.DSN())
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidConfig, err)
+	}
+	if cfg.MaxOpenConns > 0 {
+		pcfg.MaxConns = int32(min(cfg.MaxOpenConns, 1<<31-1))
+	}
+	if cfg.ConnMaxLifetime > 0 {
+		pcfg.MaxConnLifetime = cfg.ConnMaxLifetime
+	}
+	if cfg.ConnMaxIdleTime > 0 {
+		pcfg.MaxConnIdleTime = cfg.ConnMaxIdleTime
+	}
+
+	pool, err := pgxpool.NewWithConfig(ctx, pcfg)
+	if err != nil {
+		return nil, fmt.Errorf("open postgres pool: %w", err)
+	}
+	if err := pool.Ping(ctx); err != nil {
+		pool.Close()
+		return nil, fmt.Errorf("ping postgres %s:%d: %w", cfg.Host, cfg.Port, err)
+	}
+	return pool, nil
+}
+
+// txKeyType is the context key for the active pgx transaction.
+type txKeyType struct{}
+
+var txKey = txKeyType{}
+
+// TxManager is a domain.UnitOfWork backed by a native pgx pool. It is the
+// pgx counterpart of sqldb.TxManager; the two do not share transactions.
+type TxManager struct {
+	db   Beginner
+	opts pgx.TxOptions
+}
+
+var _ domain.UnitOfWork = (*TxManager)(nil)
+
+// NewTxManager creates a TxManager using opts for every transaction.
+func NewTxManager(db Beginner, opts pgx.TxOptions) *TxManager {
+	return &TxManager{db: db, opts: opts}
+}
+
+// Do runs fn in a transaction bound to the context.
+// If ctx already carries a transaction, fn joins it and the outermost Do
+// decides whether to commit. A panic in fn rolls back and is re-raised.
+func (m *TxManager) Do(ctx context.Context, fn func(ctx context.Context) error) (err error) {
+	if TxFromContext(ctx) != nil {
+		return fn(ctx)
+	}
+
+	tx, err := m.db.BeginTx(ctx, m.opts)
+	if err != nil {
+		return fmt.Errorf("begin transaction: %w", err)
+	}
+
+	defer func() {
+		if rec := recover(); rec != nil {
+			_ = tx.Rollback(context.WithoutCancel(ctx))
+			panic(rec)
+		}
+	}()
+
+	if err := fn(WithTx(ctx, tx)); err != nil {
+		if rbErr := tx.Rollback(context.WithoutCancel(ctx)); rbErr != nil && !errors.Is(rbErr, pgx.ErrTxClosed) {
+			return errors.Join(err, fmt.Errorf("rollback transaction: %w", rbErr))
+		}
+		return err
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return fmt.Errorf("commit transaction: %w", err)
+	}
+	return nil
+}
+
+// WithTx returns a new context carrying the pgx transaction.
+func WithTx(ctx context.Context, tx pgx.Tx) context.Context {
+	return context.WithValue(ctx, txKey, tx)
+}
+
+// TxFromContext returns the pgx transaction bound to ctx, or nil.
+func TxFromContext(ctx context.Context) pgx.Tx {
+	if tx, ok := ctx.Value(txKey).(pgx.Tx); ok {
+		return tx
+	}
+	return nil
+}
+
+// Conn returns the transaction bound to ctx, or db when there is none:
+//
+//	_, err := postgres.Conn(ctx, r.pool).Exec(ctx, query, args...)
+func Conn(ctx context.Context, db DBTX) DBTX {
+	if tx := TxFromContext(ctx); tx != nil {
+		return tx
+	}
+	return db
+}
@@ -0,0 +1,148 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"testing"
+
+	"github.com/jackc/pgx/v5"
+)
+
+// fakeTx records Commit and Rollback; other pgx.Tx methods are not used.
+type fakeTx struct {
+	pgx.Tx
+	log *[]string
+}
+
+func (t *fakeTx) Commit(context.Context) error   { *t.log = append(*t.log, "commit"); return nil }
+func (t *fakeTx) Rollback(context.Context) error { *t.log = append(*t.log, "rollback"); return nil }
+
+// fakeBeginner hands out fakeTx values and records Begin calls.
+type fakeBeginner struct{ log []string }
+
+func (b *fakeBeginner) BeginTx(context.Context, pgx.TxOptions) (pgx.Tx, error) {
+	b.log = append(b.log, "begin")
+	return &fakeTx{log: &b.log}, nil
+}
+
+func TestTxManager_Do(t *testing.T) {
+	errBoom := errors.New("boom")
+
+	tests := []struct {
+		name    string
+		fn      func(ctx context.Context) error
+		wantErr error
+		wantLog []string
+	}{
+		{
+			name:    "commits on success",
+			fn:      func(context.Context) error { return nil },
+			wantLog: []string{"begin", "commit"},
+		},
+		{
+			name:    "rolls back on error",
+			fn:      func(context.Context) error { return errBoom },
+			wantErr: errBoom,
+			wantLog: []string{"begin", "rollback"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Arrange
+			db := &fakeBeginner{}
+			m := NewTxManager(db, pgx.TxOptions{})
+
+			// Act
+			err := m.Do(context.Background(), func(ctx context.Context) error {
+				if TxFromContext(ctx) == nil {
+					t.Error("TxFromContext() = nil inside Do")
+				}
+				return tt.fn(ctx)
+			})
+
+			// Assert
+			if !errors.Is(err, tt.wantErr) {
+				t.Errorf("Do() error = %v, want %v", err, tt.wantErr)
+			}
+			if !slices.Equal(db.log, tt.wantLog) {
+				t.Errorf("calls = %v, want %v", db.log, tt.wantLog)
+			}
+		})
+	}
+}
+
+func TestTxManager_DoNested(t *testing.T) {
+	// Arrange
+	db := &fakeBeginner{}
+	m := NewTxManager(db, pgx.TxOptions{})
+
+	// Act
+	err := m.Do(context.Background(), func(ctx context.Context) error {
+		outer := TxFromContext(ctx)
+		return m.Do(ctx, func(ctx context.Context) error {
+			if TxFromContext(ctx) != outer {
+				t.Error("nested Do started a new transaction")
+			}
+			return nil
+		})
+	})
+
+	// Assert
+	if err != nil {
+		t.Fatalf("Do() error = %v", err)
+	}
+	if want := []string{"begin", "commit"}; !slices.Equal(db.log, want) {
+		t.Errorf("calls = %v, want %v", db.log, want)
+	}
+}
+
+func TestTxManager_DoPanic(t *testing.T) {
+	// Arrange
+	db := &fakeBeginner{}
+	m := NewTxManager(db, pgx.TxOptions{})
+
+	// Act
+	func() {
+		defer func() {
+			if recover() == nil {
+				t.Error("Do() did not re-raise the panic")
+			}
+		}()
+		_ = m.Do(context.Background(), func(context.Context) error { panic("boom") })
+	}()
+
+	// Assert
+	if want := []string{"begin", "rollback"}; !slices.Equal(db.log, want) {
+		t.Errorf("calls = %v, want %v", db.log, want)
+	}
+}
+
+func TestConn(t *testing.T) {
+	// Arrange
+	tx := &fakeTx{log: new([]string)}
+	ctx := WithTx(context.Background(), tx)
+
+	// Act & Assert
+	if got := Conn(ctx, nil); got != tx {
+		t.Errorf("Conn() with tx = %v, want the transaction", got)
+	}
+	if got := Conn(context.Background(), nil); got != nil {
+		t.Errorf("Conn() without tx = %v, want the pool", got)
+	}
+}
+
+func TestNewPool_InvalidConfig(t *testing.T) {
+	// Arrange
+	cfg := validConfig()
+	cfg.Host = ""
+
+	// Act
+	_, err := NewPool(context.Background(), cfg)
+
+	// Assert
+	if !errors.Is(err, ErrInvalidConfig) {
+		t.Errorf("NewPool() error = %v, want %v", err, ErrInvalidConfig)
+	}
+}
@@ -0,0 +1,90 @@
+// Package postgres opens PostgreSQL connection pools through the pgx driver.
+// Pools are exposed as *sql.DB so they work with sqldb, outbox and the
+// other database/sql based stores. NewPool opens a native pgxpool instead,
+// with a TxManager that binds the active pgx.Tx to the context.
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"slices"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/stdlib"
+
+	"github.com/blackhorseya/go-ddd/internal/infrastructure/config"
+	"github.com/blackhorseya/go-ddd/internal/infrastructure/persistence/sqltrace"
+)
+
+// DriverName is the database/sql driver used for PostgreSQL.
+const DriverName = "pgx"
+
+// ErrInvalidConfig is returned when the database configuration cannot be used.
+var ErrInvalidConfig = errors.New("invalid postgres config")
+
+// sslModes are the libpq sslmode values accepted by pgx.
+var sslModes = []string{"disable", "allow", "prefer", "require", "verify-ca", "verify-full"}
+
+// Open creates a connection pool from cfg and applies its pool settings.
+// Statements are traced and logged when slower than slow_query_threshold
+// (see sqltrace). Connections are established lazily; use Connect to verify connectivity.
+// Close the returned pool on shutdown.
+func Open(cfg config.Database) (*sql.DB, error) {
+	if err := validate(cfg); err != nil {
+		return nil, err
+	}
+
+	pc, err := pgx.ParseConfig(cfg.DSN())
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidConfig, err)
+	}
+	db := sql.OpenDB(sqltrace.Wrap(stdlib.GetConnector(*pc), sqltrace.Options{SlowThreshold: cfg.SlowQueryThreshold}))
+	configure(db, cfg)
+	return db, nil
+}
+
+// Connect opens a pool like Open and pings the server, closing the pool
+// if it is unreachable.
+func Connect(ctx context.Context, cfg config.Database) (*sql.DB, error) {
+	db, err := Open(cfg)
+	if err != nil {
+		return nil, err
+	}
+	if err := db.PingContext(ctx); err != nil {
+		_ = db.Close()
+		return nil, fmt.Errorf("ping postgres %s:%d: %w", cfg.Host, cfg.Port, err)
+	}
+	return db, nil
+}
+
+// configure applies the pool settings of cfg; zero values keep the database/sql defaults.
+func configure(db *sql.DB, cfg config.Database) {
+	if cfg.MaxOpenConns > 0 {
+		db.SetMaxOpenConns(cfg.MaxOpenConns)
+	}
+	if cfg.MaxIdleConns > 0 {
+		db.SetMaxIdleConns(cfg.MaxIdleConns)
+	}
+	if cfg.ConnMaxLifetime > 0 {
+		db.SetConnMaxLifetime(cfg.ConnMaxLifetime)
+	}
+	if cfg.ConnMaxIdleTime > 0 {
+		db.SetConnMaxIdleTime(cfg.ConnMaxIdleTime)
+	}
+}
+
+// validate rejects configurations that would only fail on first use.
+func validate(cfg config.Database) error {
+	if cfg.Driver != "" && cfg.Driver != "postgres" {
+		return fmt.Errorf("%w: driver %q", ErrInvalidConfig, cfg.Driver)
+	}
+	if cfg.Host == "" || cfg.Name == "" {
+		return fmt.Errorf("%w: host and name are required", ErrInvalidConfig)
+	}
+	if cfg.SSLMode != "" && !slices.Contains(sslModes, cfg.SSLMode) {
+		return fmt.Errorf("%w: ssl_mode %q", ErrInvalidConfig, cfg.SSLMode)
+	}
+	return nil
+}
@@ -0,0 +1,85 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/blackhorseya/go-ddd/internal/infrastructure/config"
+)
+
+func validConfig() config.Database {
+	return config.Database{
+		Driver:          "postgres",
+		Host:            "127.0.0.1",
+		Port:            1, // nothing listens here
+		User:            "postgres",
+		Name:            "app",
+		SSLMode:         "disable",
+		MaxOpenConns:    7,
+		MaxIdleConns:    3,
+		ConnMaxLifetime: time.Minute,
+		ConnMaxIdleTime: 30 * time.Second,
+	}
+}
+
+func TestOpen(t *testing.T) {
+	// Arrange
+	cfg := validConfig()
+
+	// Act
+	db, err := Open(cfg)
+
+	// Assert
+	if err != nil {
+		t.Fatalf("Open() error = %v", err)
+	}
+	defer db.Close()
+	if got := db.Stats().MaxOpenConnections; got != 7 {
+		t.Errorf("MaxOpenConnections = %d, want 7", got)
+	}
+}
+
+func TestOpen_InvalidConfig(t *testing.T) {
+	tests := []struct {
+		name   string
+		modify func(*config.Database)
+	}{
+		{name: "mysql driver", modify: func(c *config.Database) { c.Driver = "mysql" }},
+		{name: "missing host", modify: func(c *config.Database) { c.Host = "" }},
+		{name: "missing name", modify: func(c *config.Database) { c.Name = "" }},
+		{name: "unknown ssl mode", modify: func(c *config.Database) { c.SSLMode = "on" }},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Arrange
+			cfg := validConfig()
+			tt.modify(&cfg)
+
+			// Act
+			_, err := Open(cfg)
+
+			// Assert
+			if !errors.Is(err, ErrInvalidConfig) {
+				t.Errorf("Open() error = %v, want %v", err, ErrInvalidConfig)
+			}
+		})
+	}
+}
+
+func TestConnect_Unreachable(t *testing.T) {
+	// Arrange
+	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
+	defer cancel()
+
+	// Act
+	db, err := Connect(ctx, validConfig())
+
+	// Assert
+	if err == nil {
+		_ = db.Close()
+		t.Fatal("Connect() error = nil, want unreachable error")
+	}
+}
@@ -0,0 +1,125 @@
+// Package redis provides the Redis client with tracing and pool settings.
+// Distributed locks live in pkg/lockx.
+package redis
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"net"
+	"os"
+	"strconv"
+
+	goredis "github.com/redis/go-redis/v9"
+
+	"github.com/blackhorseya/go-ddd/internal/infrastructure/config"
+	"github.com/blackhorseya/go-ddd/pkg/healthx"
+)
+
+// Topologies accepted by config.Redis.Mode.
+const (
+	ModeStandalone = "standalone"
+	ModeSentinel   = "sentinel"
+	ModeCluster    = "cluster"
+)
+
+// ErrInvalidConfig is returned when the Redis configuration cannot be used.
+var ErrInvalidConfig = errors.New("invalid redis config")
+
+// NewClient creates a traced Redis client for the configured topology.
+// The connection is established lazily on first use; use Connect to verify
+// connectivity. Close the client on shutdown.
+func NewClient(cfg config.Redis) (goredis.UniversalClient, error) {
+	addrs := cfg.Addrs
+	if len(addrs) == 0 {
+		addrs = []string{net.JoinHostPort(cfg.Host, strconv.Itoa(cfg.Port))}
+	}
+	tlsCfg, err := tlsConfig(cfg)
+	if err != nil {
+		return nil, err
+	}
+
+	opts := &goredis.UniversalOptions{
+		Addrs:        addrs,
+		Username:     cfg.Username,
+		Password:     cfg.Password,
+		DB:           cfg.DB,
+		PoolSize:     cfg.PoolSize,
+		MinIdleConns: cfg.MinIdleConns,
+		DialTimeout:  cfg.DialTimeout,
+		ReadTimeout:  cfg.ReadTimeout,
+		WriteTimeout: cfg.WriteTimeout,
+		TLSConfig:    tlsCfg,
+	}
+
+	var client goredis.UniversalClient
+	switch cfg.Mode {
+	case "", ModeStandalone:
+		client = goredis.NewClient(opts.Simple())
+	case ModeSentinel:
+		if cfg.MasterName == "" {
+			return nil, fmt.Errorf("%w: master_name is required in sentinel mode", ErrInvalidConfig)
+		}
+		opts.MasterName = cfg.MasterName
+		opts.SentinelPassword = cfg.SentinelPassword
+		client = goredis.NewFailoverClient(opts.Failover())
+	case ModeCluster:
+		client = goredis.NewClusterClient(opts.Cluster())
+	default:
+		return nil, fmt.Errorf("%w: mode %q", ErrInvalidConfig, cfg.Mode)
+	}
+
+	client.AddHook(tracingHook{})
+	return client, nil
+}
+
+// Connect creates a client like NewClient and pings the server, closing
+// the client if it is unreachable.
+func Connect(ctx context.Context, cfg config.Redis) (goredis.UniversalClient, error) {
+	client, err := NewClient(cfg)
+	if err != nil {
+		return nil, err
+	}
+	if err := client.Ping(ctx).Err(); err != nil {
+		_ = client.Close()
+		return nil, fmt.Errorf("ping redis: %w", err)
+	}
+	return client, nil
+}
+
+// Checker returns a readiness checker that pings Redis through client, so
+// it covers every topology and TLS.
+func Checker(client goredis.UniversalClient) healthx.Checker {
+	return healthx.NewChecker("redis", func(ctx context.Context) error {
+		return client.Ping(ctx).Err()
+	})
+}
+
+// tlsConfig builds the TLS settings, or nil when TLS is disabled. Without
+// a configured server name, each connection verifies the host it dials,
+// which covers sentinel, cluster and addrs setups.
+func tlsConfig(cfg config.Redis) (*tls.Config, error) {
+	if !cfg.TLS.Enabled {
+		return nil, nil
+	}
+
+	tlsCfg := &tls.Config{
+		MinVersion:         tls.VersionTLS12,
+		ServerName:         cfg.TLS.ServerName,
+		InsecureSkipVerify: cfg.TLS.InsecureSkipVerify,
+	}
+	if cfg.TLS.CAFile != "" {
+		pem, err := os.ReadFile(cfg.TLS.CAFile)
+		if err != nil {
+			return nil, fmt.Errorf("%w: read ca_file: %v", ErrInvalidConfig, err)
+		}
+		pool := x509.NewCertPool()
+		if !pool.AppendCertsFromPEM(pem) {
+			return nil, fmt.Errorf("%w: ca_file contains no certificates", ErrInvalidConfig)
+		}
+		tlsCfg.RootCAs = pool
+	}
+	return tlsCfg, nil
+}
@@ -0,0 +1,298 @@
+package redis
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"errors"
+	"math/big"
+	"net"
+	"os"
+	"path/filepath"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/alicebob/miniredis/v2"
+	goredis "github.com/redis/go-redis/v9"
+	"go.opentelemetry.io/otel"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+
+	"github.com/blackhorseya/go-ddd/internal/infrastructure/config"
+)
+
+func miniredisConfig(t *testing.T) (config.Redis, *miniredis.Miniredis) {
+	t.Helper()
+	mr := miniredis.RunT(t)
+	host, port, err := net.SplitHostPort(mr.Addr())
+	if err != nil {
+		t.Fatalf("SplitHostPort() error = %v", err)
+	}
+	p, _ := strconv.Atoi(port)
+	return config.Redis{Mode: ModeStandalone, Host: host, Port: p}, mr
+}
+
+func TestNewClient(t *testing.T) {
+	dir := t.TempDir()
+	badCA := filepath.Join(dir, "ca.pem")
+	if err := os.WriteFile(badCA, []byte("not a certificate"), 0o600); err != nil {
+		t.Fatalf("WriteFile() error = %v", err)
+	}
+
+	tests := []struct {
+		name     string
+		cfg      config.Redis
+		wantType any
+		wantErr  error
+	}{
+		{
+			name:     "standalone",
+			cfg:      config.Redis{Host: "localhost", Port: 6379},
+			wantType: &goredis.Client{},
+		},
+		{
+			name:     "sentinel",
+			cfg:      config.Redis{Mode: ModeSentinel, Addrs: []string{"s1:26379"}, MasterName: "mymaster"},
+			wantType: &goredis.Client{},
+		},
+		{
+			name:     "cluster",
+			cfg:      config.Redis{Mode: ModeCluster, Addrs: []string{"n1:6379", "n2:6379"}},
+			wantType: &goredis.ClusterClient{},
+		},
+		{
+			name:    "sentinel without master name",
+			cfg:     config.Redis{Mode: ModeSentinel, Addrs: []string{"s1:26379"}},
+			wantErr: ErrInvalidConfig,
+		},
+		{
+			name:    "unknown mode",
+			cfg:     config.Redis{Mode: "ring"},
+			wantErr: ErrInvalidConfig,
+		},
+		{
+			name:    "invalid ca file",
+			cfg:     config.Redis{Host: "localhost", TLS: config.RedisTLS{Enabled: true, CAFile: badCA}},
+			wantErr: ErrInvalidConfig,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Act
+			client, err := NewClient(tt.cfg)
+
+			// Assert
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("NewClient() error = %v, want %v", err, tt.wantErr)
+			}
+			if tt.wantErr != nil {
+				return
+			}
+			defer client.Close()
+			switch tt.wantType.(type) {
+			case *goredis.Client:
+				if _, ok := client.(*goredis.Client); !ok {
+					t.Errorf("NewClient() = %T, want *redis.Client", client)
+				}
+			case *goredis.ClusterClient:
+				if _, ok := client.(*goredis.ClusterClient); !ok {
+					t.Errorf("NewClient() = %T, want *redis.ClusterClient", client)
+				}
+			}
+		})
+	}
+}
+
+func TestTLSConfig(t *testing.T) {
+	// Act
+	disabled, err := tlsConfig(config.Redis{Host: "cache"})
+	if err != nil {
+		t.Fatalf("tlsConfig() error = %v", err)
+	}
+	enabled, err := tlsConfig(config.Redis{Host: "cache", TLS: config.RedisTLS{Enabled: true}})
+	if err != nil {
+		t.Fatalf("tlsConfig() error = %v", err)
+	}
+
+	// Assert
+	if disabled != nil {
+		t.Errorf("tlsConfig() = %v, want nil when disabled", disabled)
+	}
+	if enabled == nil || enabled.ServerName != "" {
+		t.Errorf("tlsConfig() = %+v, want ServerName derived from the dialled address", enabled)
+	}
+}
+
+func TestNewClient_TLSAddrs(t *testing.T) {
+	tests := []struct {
+		name string
+		cfg  func(addr string) config.Redis
+	}{
+		{
+			name: "addrs",
+			cfg: func(addr string) config.Redis {
+				return config.Redis{Host: "localhost", Port: 6379, Addrs: []string{addr}}
+			},
+		},
+		{
+			name: "server name",
+			cfg: func(addr string) config.Redis {
+				return config.Redis{Host: "localhost", Port: 6379, Addrs: []string{addr},
+					TLS: config.RedisTLS{ServerName: "127.0.0.1"}}
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Arrange: the certificate is valid for 127.0.0.1 only, not
+			// for the configured host
+			caFile, serverTLS := testCertificate(t)
+			mr, err := miniredis.RunTLS(serverTLS)
+			if err != nil {
+				t.Fatalf("RunTLS() error = %v", err)
+			}
+			defer mr.Close()
+			cfg := tt.cfg(mr.Addr())
+			cfg.TLS.Enabled = true
+			cfg.TLS.CAFile = caFile
+
+			// Act
+			client, err := Connect(context.Background(), cfg)
+
+			// Assert
+			if err != nil {
+				t.Fatalf("Connect() error = %v", err)
+			}
+			_ = client.Close()
+		})
+	}
+}
+
+func TestTLSConfig_Sentinel(t *testing.T) {
+	// Act
+	got, err := tlsConfig(config.Redis{
+		Mode:       ModeSentinel,
+		Host:       "localhost",
+		Addrs:      []string{"s1:26379", "s2:26379"},
+		MasterName: "mymaster",
+		TLS:        config.RedisTLS{Enabled: true},
+	})
+
+	// Assert
+	if err != nil {
+		t.Fatalf("tlsConfig() error = %v", err)
+	}
+	if got.ServerName != "" {
+		t.Errorf("ServerName = %q, want empty so sentinels and masters verify their own host", got.ServerName)
+	}
+}
+
+// testCertificate creates a self-signed certificate for 127.0.0.1 and
+// returns its PEM file and a server TLS config using it.
+func testCertificate(t *testing.T) (string, *tls.Config) {
+	t.Helper()
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatalf("GenerateKey() error = %v", err)
+	}
+	tmpl := &x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		Subject:               pkix.Name{CommonName: "redis"},
+		IPAddresses:           []net.IP{net.ParseIP("127.0.0.1")},
+		NotBefore:             time.Now().Add(-time.Hour),
+		NotAfter:              time.Now().Add(time.Hour),
+		KeyUsage:              x509.KeyUsageDigitalSignature | x509.KeyUsageCertSign,
+		ExtKeyUsage:           []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth},
+		BasicConstraintsValid: true,
+		IsCA:                  true,
+	}
+	der, err := x509.CreateCertificate(rand.Reader, tmpl, tmpl, &key.PublicKey, key)
+	if err != nil {
+		t.Fatalf("CreateCertificate() error = %v", err)
+	}
+
+	caFile := filepath.Join(t.TempDir(), "ca.pem")
+	if err := os.WriteFile(caFile, pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}), 0o600); err != nil {
+		t.Fatalf("WriteFile() error = %v", err)
+	}
+	cert := tls.Certificate{Certificate: [][]byte{der}, PrivateKey: key}
+	return caFile, &tls.Config{Certificates: []tls.Certificate{cert}, MinVersion: tls.VersionTLS12}
+}
+
+func TestConnect(t *testing.T) {
+	// Arrange
+	ctx := context.Background()
+	cfg, mr := miniredisConfig(t)
+
+	// Act
+	client, err := Connect(ctx, cfg)
+	if err != nil {
+		t.Fatalf("Connect() error = %v", err)
+	}
+	defer client.Close()
+	healthy := Checker(client).Check(ctx)
+	mr.Close()
+	unhealthy := Checker(client).Check(ctx)
+
+	// Assert
+	if healthy != nil {
+		t.Errorf("Check() error = %v, want nil", healthy)
+	}
+	if unhealthy == nil {
+		t.Error("Check() error = nil after server stopped")
+	}
+	if _, err := Connect(ctx, cfg); err == nil {
+		t.Error("Connect() error = nil for unreachable server")
+	}
+}
+
+func TestTracingHook(t *testing.T) {
+	// Arrange
+	recorder := tracetest.NewSpanRecorder()
+	provider := sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder))
+	prev := otel.GetTracerProvider()
+	otel.SetTracerProvider(provider)
+	t.Cleanup(func() { otel.SetTracerProvider(prev) })
+
+	ctx := context.Background()
+	cfg, _ := miniredisConfig(t)
+	client, err := NewClient(cfg)
+	if err != nil {
+		t.Fatalf("NewClient() error = %v", err)
+	}
+	defer client.Close()
+
+	// Act
+	_ = client.Set(ctx, "k", "secret", 0).Err()
+	missing := client.Get(ctx, "missing").Err()
+	_, _ = client.Pipelined(ctx, func(p goredis.Pipeliner) error {
+		p.Incr(ctx, "n")
+		p.Incr(ctx, "n")
+		return nil
+	})
+
+	// Assert
+	if !errors.Is(missing, goredis.Nil) {
+		t.Fatalf("Get() error = %v, want redis.Nil", missing)
+	}
+	names := map[string]bool{}
+	for _, s := range recorder.Ended() {
+		names[s.Name()] = true
+		if s.Name() == "redis get" && s.Status().Code != 0 {
+			t.Errorf("redis get status = %v, want unset for a missing key", s.Status())
+		}
+	}
+	for _, want := range []string{"redis dial", "redis set", "redis get", "redis pipeline"} {
+		if !names[want] {
+			t.Errorf("missing span %q in %v", want, names)
+		}
+	}
+}
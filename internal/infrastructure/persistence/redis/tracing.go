@@ -0,0 +1,80 @@
+package redis
+
+import (
+	"context"
+	"errors"
+	"net"
+	"strings"
+
+	goredis "github.com/redis/go-redis/v9"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// instrumentationName is the OpenTelemetry tracer name.
+const instrumentationName = "github.com/blackhorseya/go-ddd/internal/infrastructure/persistence/redis"
+
+// tracingHook records a client span per command and pipeline. Only command
+// names are recorded; arguments may hold user data.
+type tracingHook struct{}
+
+var _ goredis.Hook = tracingHook{}
+
+func (tracingHook) DialHook(next goredis.DialHook) goredis.DialHook {
+	return func(ctx context.Context, network, addr string) (net.Conn, error) {
+		ctx, span := start(ctx, "redis dial")
+		defer span.End()
+
+		conn, err := next(ctx, network, addr)
+		record(span, err)
+		return conn, err
+	}
+}
+
+func (tracingHook) ProcessHook(next goredis.ProcessHook) goredis.ProcessHook {
+	return func(ctx context.Context, cmd goredis.Cmder) error {
+		ctx, span := start(ctx, "redis "+cmd.Name(), attribute.String("db.operation", cmd.Name()))
+		defer span.End()
+
+		err := next(ctx, cmd)
+		record(span, err)
+		return err
+	}
+}
+
+func (tracingHook) ProcessPipelineHook(next goredis.ProcessPipelineHook) goredis.ProcessPipelineHook {
+	return func(ctx context.Context, cmds []goredis.Cmder) error {
+		names := make([]string, len(cmds))
+		for i, cmd := range cmds {
+			names[i] = cmd.Name()
+		}
+		ctx, span := start(ctx, "redis pipeline",
+			attribute.String("db.operation", strings.Join(names, " ")),
+			attribute.Int("db.redis.num_cmd", len(cmds)),
+		)
+		defer span.End()
+
+		err := next(ctx, cmds)
+		record(span, err)
+		return err
+	}
+}
+
+func start(ctx context.Context, name string, attrs ...attribute.KeyValue) (context.Context, trace.Span) {
+	attrs = append(attrs, attribute.String("db.system", "redis"))
+	return otel.Tracer(instrumentationName).Start(ctx, name,
+		trace.WithSpanKind(trace.SpanKindClient),
+		trace.WithAttributes(attrs...),
+	)
+}
+
+// record marks span as failed; a missing key (redis.Nil) is not an error.
+func record(span trace.Span, err error) {
+	if err == nil || errors.Is(err, goredis.Nil) {
+		return
+	}
+	span.RecordError(err)
+	span.SetStatus(codes.Error, err.Error())
+}
@@ -0,0 +1,52 @@
+package seed
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"io"
+
+	"github.com/blackhorseya/go-ddd/internal/infrastructure/config"
+	"github.com/blackhorseya/go-ddd/internal/infrastructure/persistence/mysql"
+	"github.com/blackhorseya/go-ddd/internal/infrastructure/persistence/postgres"
+	"github.com/blackhorseya/go-ddd/internal/infrastructure/persistence/sqldb"
+)
+
+// ErrUsage is returned for malformed seed subcommand arguments.
+var ErrUsage = errors.New("usage: seed [env]")
+
+// Command runs the `seed` CLI subcommand, loading the embedded fixtures for
+// env (default app.env) into the configured database.
+func Command(ctx context.Context, cfg *config.Config, args []string, w io.Writer) error {
+	if len(args) > 1 {
+		return ErrUsage
+	}
+	env := cfg.App.Env
+	if len(args) == 1 {
+		env = args[0]
+	}
+
+	d, err := sqldb.DialectFor(cfg.Database.Driver)
+	if err != nil {
+		return err
+	}
+	var db *sql.DB
+	switch d {
+	case sqldb.MySQL:
+		db, err = mysql.Connect(ctx, cfg.Database)
+	default:
+		db, err = postgres.Connect(ctx, cfg.Database)
+	}
+	if err != nil {
+		return err
+	}
+	defer db.Close()
+
+	res, err := New(db, d, FS).Run(ctx, env)
+	if err != nil {
+		return err
+	}
+	_, err = fmt.Fprintf(w, "seeded %s: %d files, %d rows inserted\n", env, res.Files, res.Rows)
+	return err
+}
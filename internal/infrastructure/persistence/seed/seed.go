@@ -0,0 +1,197 @@
+// Package seed loads fixture data per environment. Fixtures live in
+// data/<env>/ (plus data/common/ for every environment) as YAML row sets or
+// SQL scripts and are applied in file name order in one transaction:
+//
+//	# data/development/001_orders.yaml
+//	table: orders
+//	key: [id]
+//	rows:
+//	  - id: o-1
+//	    status: pending
+//
+// Seeding is idempotent: YAML rows are inserted with the dialect's
+// "insert unless the key exists" form, and SQL scripts must be written the
+// same way (e.g. ON CONFLICT DO NOTHING). Integration tests call Run with
+// their own fs.FS to get reproducible datasets.
+package seed
+
+import (
+	"context"
+	"database/sql"
+	"embed"
+	"errors"
+	"fmt"
+	"io/fs"
+	"path"
+	"slices"
+	"strings"
+
+	"go.yaml.in/yaml/v3"
+
+	"github.com/blackhorseya/go-ddd/internal/infrastructure/persistence/sqldb"
+	"github.com/blackhorseya/go-ddd/pkg/contextx"
+)
+
+// CommonDir holds fixtures loaded for every environment.
+const CommonDir = "common"
+
+// ErrInvalidFixture is returned for fixture files that cannot be applied.
+var ErrInvalidFixture = errors.New("invalid seed fixture")
+
+//go:embed data
+var files embed.FS
+
+// FS holds the embedded fixtures, one directory per environment.
+var FS, _ = fs.Sub(files, "data")
+
+// Fixture is a YAML row set.
+type Fixture struct {
+	// Table is the target table. It is not escaped.
+	Table string `yaml:"table"`
+
+	// Key lists the columns identifying a row; existing rows are left untouched.
+	Key []string `yaml:"key"`
+
+	// Rows are column → value maps.
+	Rows []map[string]any `yaml:"rows"`
+}
+
+// Result summarizes a seeding run.
+type Result struct {
+	Files int
+	Rows  int64
+}
+
+// Seeder applies fixtures to a database.
+type Seeder struct {
+	db      *sql.DB
+	dialect sqldb.Dialect
+	fsys    fs.FS
+}
+
+// New creates a Seeder reading fixtures from fsys.
+func New(db *sql.DB, d sqldb.Dialect, fsys fs.FS) *Seeder {
+	return &Seeder{db: db, dialect: d, fsys: fsys}
+}
+
+// Run applies the common fixtures followed by those of env in one
+// transaction. A missing directory is skipped.
+func (s *Seeder) Run(ctx context.Context, env string) (Result, error) {
+	names, err := s.fixtures(env)
+	if err != nil {
+		return Result{}, err
+	}
+
+	var res Result
+	err = sqldb.NewTxManager(s.db, nil).Do(ctx, func(ctx context.Context) error {
+		tx := sqldb.Conn(ctx, s.db)
+		for _, name := range names {
+			n, err := s.apply(ctx, tx, name)
+			if err != nil {
+				return fmt.Errorf("seed %s: %w", name, err)
+			}
+			res.Files++
+			res.Rows += n
+		}
+		return nil
+	})
+	if err != nil {
+		return Result{}, err
+	}
+
+	contextx.From(ctx).Info("seed data loaded", "env", env, "files", res.Files, "rows", res.Rows)
+	return res, nil
+}
+
+// fixtures returns the fixture paths for env in application order.
+func (s *Seeder) fixtures(env string) ([]string, error) {
+	var names []string
+	for _, dir := range []string{CommonDir, env} {
+		entries, err := fs.ReadDir(s.fsys, dir)
+		if errors.Is(err, fs.ErrNotExist) {
+			continue
+		}
+		if err != nil {
+			return nil, fmt.Errorf("read seed dir %s: %w", dir, err)
+		}
+		for _, e := range entries {
+			switch path.Ext(e.Name()) {
+			case ".yaml", ".yml", ".sql":
+				names = append(names, path.Join(dir, e.Name()))
+			}
+		}
+	}
+	return names, nil
+}
+
+// apply runs one fixture file and returns the number of inserted rows.
+func (s *Seeder) apply(ctx context.Context, q sqldb.Querier, name string) (int64, error) {
+	data, err := fs.ReadFile(s.fsys, name)
+	if err != nil {
+		return 0, err
+	}
+
+	if path.Ext(name) == ".sql" {
+		res, err := q.ExecContext(ctx, string(data))
+		if err != nil {
+			return 0, err
+		}
+		n, _ := res.RowsAffected()
+		return n, nil
+	}
+
+	var f Fixture
+	if err := yaml.Unmarshal(data, &f); err != nil {
+		return 0, fmt.Errorf("%w: %v", ErrInvalidFixture, err)
+	}
+	if f.Table == "" || len(f.Key) == 0 {
+		return 0, fmt.Errorf("%w: table and key are required", ErrInvalidFixture)
+	}
+
+	var total int64
+	for i, row := range f.Rows {
+		query, args, err := s.insert(f, row)
+		if err != nil {
+			return 0, fmt.Errorf("row %d: %w", i, err)
+		}
+		res, err := q.ExecContext(ctx, query, args...)
+		if err != nil {
+			return 0, fmt.Errorf("row %d: %w", i, err)
+		}
+		n, _ := res.RowsAffected()
+		total += n
+	}
+	return total, nil
+}
+
+// insert builds the idempotent INSERT for a row. Columns are sorted so the
+// statement is deterministic; every key column must be present.
+func (s *Seeder) insert(f Fixture, row map[string]any) (string, []any, error) {
+	for _, k := range f.Key {
+		if _, ok := row[k]; !ok {
+			return "", nil, fmt.Errorf("%w: missing key column %q", ErrInvalidFixture, k)
+		}
+	}
+
+	cols := make([]string, 0, len(row))
+	for col := range row {
+		cols = append(cols, col)
+	}
+	slices.Sort(cols)
+
+	args := make([]any, len(cols))
+	for i, col := range cols {
+		args[i] = row[col]
+	}
+	placeholders := strings.TrimSuffix(strings.Repeat("?, ", len(cols)), ", ")
+
+	var query string
+	switch s.dialect {
+	case sqldb.MySQL:
+		query = fmt.Sprintf("INSERT IGNORE INTO %s (%s) VALUES (%s)", f.Table, strings.Join(cols, ", "), placeholders)
+	default:
+		query = fmt.Sprintf("INSERT INTO %s (%s) VALUES (%s) ON CONFLICT (%s) DO NOTHING",
+			f.Table, strings.Join(cols, ", "), placeholders, strings.Join(f.Key, ", "))
+	}
+	return s.dialect.Rebind(query, 0), args, nil
+}
@@ -0,0 +1,144 @@
+package seed
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"testing"
+	"testing/fstest"
+
+	_ "github.com/glebarez/go-sqlite"
+
+	"github.com/blackhorseya/go-ddd/internal/infrastructure/persistence/sqldb"
+)
+
+// newTestDB returns an in-memory SQLite database with a widgets table.
+func newTestDB(t *testing.T) *sql.DB {
+	t.Helper()
+	db, err := sql.Open("sqlite", ":memory:")
+	if err != nil {
+		t.Fatalf("sql.Open() error = %v", err)
+	}
+	db.SetMaxOpenConns(1)
+	t.Cleanup(func() { _ = db.Close() })
+
+	if _, err := db.Exec(`CREATE TABLE widgets (id TEXT PRIMARY KEY, name TEXT NOT NULL, size INT)`); err != nil {
+		t.Fatalf("create table error = %v", err)
+	}
+	return db
+}
+
+func TestSeeder_Run(t *testing.T) {
+	// Arrange
+	ctx := context.Background()
+	db := newTestDB(t)
+	fsys := fstest.MapFS{
+		"common/001_widgets.yaml": {Data: []byte("table: widgets\nkey: [id]\nrows:\n  - {id: w1, name: common}\n")},
+		"test/001_widgets.yaml":   {Data: []byte("table: widgets\nkey: [id]\nrows:\n  - {id: w2, name: test, size: 3}\n")},
+		"test/002_widgets.sql":    {Data: []byte("INSERT INTO widgets (id, name) VALUES ('w3', 'sql') ON CONFLICT (id) DO NOTHING")},
+		"test/README.md":          {Data: []byte("ignored")},
+		"development/001.yaml":    {Data: []byte("table: widgets\nkey: [id]\nrows:\n  - {id: dev, name: dev}\n")},
+	}
+	s := New(db, sqldb.Postgres, fsys)
+
+	// Act
+	first, err := s.Run(ctx, "test")
+	if err != nil {
+		t.Fatalf("Run() error = %v", err)
+	}
+	second, err := s.Run(ctx, "test")
+	if err != nil {
+		t.Fatalf("Run() again error = %v", err)
+	}
+
+	// Assert
+	if first.Files != 3 || first.Rows != 3 {
+		t.Errorf("first Run() = %+v, want 3 files, 3 rows", first)
+	}
+	if second.Rows != 0 {
+		t.Errorf("second Run() inserted %d rows, want 0", second.Rows)
+	}
+	var count int
+	if err := db.QueryRow(`SELECT count(*) FROM widgets`).Scan(&count); err != nil {
+		t.Fatalf("count error = %v", err)
+	}
+	if count != 3 {
+		t.Errorf("widgets = %d, want 3", count)
+	}
+}
+
+func TestSeeder_RunRollsBackOnError(t *testing.T) {
+	// Arrange
+	ctx := context.Background()
+	db := newTestDB(t)
+	fsys := fstest.MapFS{
+		"test/001_widgets.yaml": {Data: []byte("table: widgets\nkey: [id]\nrows:\n  - {id: w1, name: ok}\n")},
+		"test/002_broken.yaml":  {Data: []byte("table: widgets\nkey: [id]\nrows:\n  - {name: no-key}\n")},
+	}
+
+	// Act
+	_, err := New(db, sqldb.Postgres, fsys).Run(ctx, "test")
+
+	// Assert
+	if !errors.Is(err, ErrInvalidFixture) {
+		t.Fatalf("Run() error = %v, want %v", err, ErrInvalidFixture)
+	}
+	var count int
+	_ = db.QueryRow(`SELECT count(*) FROM widgets`).Scan(&count)
+	if count != 0 {
+		t.Errorf("widgets = %d after failed run, want 0", count)
+	}
+}
+
+func TestSeeder_Insert(t *testing.T) {
+	f := Fixture{Table: "widgets", Key: []string{"id"}}
+	row := map[string]any{"name": "a", "id": "w1"}
+
+	tests := []struct {
+		name      string
+		dialect   sqldb.Dialect
+		wantQuery string
+	}{
+		{
+			name:      "postgres",
+			dialect:   sqldb.Postgres,
+			wantQuery: "INSERT INTO widgets (id, name) VALUES ($1, $2) ON CONFLICT (id) DO NOTHING",
+		},
+		{
+			name:      "mysql",
+			dialect:   sqldb.MySQL,
+			wantQuery: "INSERT IGNORE INTO widgets (id, name) VALUES (?, ?)",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Act
+			query, args, err := New(nil, tt.dialect, nil).insert(f, row)
+
+			// Assert
+			if err != nil {
+				t.Fatalf("insert() error = %v", err)
+			}
+			if query != tt.wantQuery {
+				t.Errorf("insert() query = %q, want %q", query, tt.wantQuery)
+			}
+			if len(args) != 2 || args[0] != "w1" || args[1] != "a" {
+				t.Errorf("insert() args = %v, want [w1 a]", args)
+			}
+		})
+	}
+}
+
+func TestFS_Embedded(t *testing.T) {
+	// Act
+	names, err := New(nil, sqldb.Postgres, FS).fixtures("development")
+
+	// Assert
+	if err != nil {
+		t.Fatalf("fixtures() error = %v", err)
+	}
+	if len(names) == 0 {
+		t.Error("no embedded development fixtures")
+	}
+}
@@ -0,0 +1,317 @@
+package sqlc
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/blackhorseya/go-ddd/internal/domain"
+	"github.com/blackhorseya/go-ddd/internal/domain/order"
+	"github.com/blackhorseya/go-ddd/internal/domain/valueobject"
+	"github.com/blackhorseya/go-ddd/internal/infrastructure/persistence/sqlc/sqlcgen"
+	"github.com/blackhorseya/go-ddd/internal/infrastructure/persistence/sqldb"
+)
+
+// orderSortField is the only sort field of the order queries (newest first).
+const orderSortField = "created_at"
+
+// OrderRepository is the order.Repository backed by sqlc-generated queries.
+type OrderRepository struct {
+	q *sqlcgen.Queries
+}
+
+var _ order.Repository = (*OrderRepository)(nil)
+
+// NewOrderRepository creates an OrderRepository on db.
+func NewOrderRepository(db *sql.DB) *OrderRepository {
+	return &OrderRepository{q: sqlcgen.New(db)}
+}
+
+// Get returns the order with the given ID, or domain.ErrNotFound.
+func (r *OrderRepository) Get(ctx context.Context, id string) (*order.Order, error) {
+	row, err := queries(ctx, r.q).GetOrder(ctx, id)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, domain.ErrNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("get order %s: %w", id, err)
+	}
+	return toOrder(row)
+}
+
+// Save inserts a new order (version 0) or updates an existing one with
+// optimistic locking. On success the aggregate version is incremented.
+func (r *OrderRepository) Save(ctx context.Context, o *order.Order) error {
+	q := queries(ctx, r.q)
+
+	if o.Version() == 0 {
+		sqldb.BeforeInsert(ctx, o)
+		err := q.InsertOrder(ctx, sqlcgen.InsertOrderParams{
+			ID:          o.ID(),
+			CustomerID:  o.CustomerID(),
+			Status:      string(o.Status()),
+			TotalAmount: o.Total().Amount(),
+			Currency:    o.Total().Currency().Code(),
+			Version:     1,
+			CreatedAt:   o.CreatedAt(),
+			UpdatedAt:   o.UpdatedAt(),
+			CreatedBy:   o.CreatedBy(),
+			UpdatedBy:   o.UpdatedBy(),
+		})
+		if err != nil {
+			return fmt.Errorf("insert order %s: %w", o.ID(), err)
+		}
+		o.IncrementVersion()
+		return nil
+	}
+
+	sqldb.BeforeUpdate(ctx, o)
+	n, err := q.UpdateOrder(ctx, sqlcgen.UpdateOrderParams{
+		Status:      string(o.Status()),
+		TotalAmount: o.Total().Amount(),
+		Currency:    o.Total().Currency().Code(),
+		UpdatedAt:   o.UpdatedAt(),
+		UpdatedBy:   o.UpdatedBy(),
+		ID:          o.ID(),
+		Version:     int32(o.Version()),
+	})
+	if err != nil {
+		return fmt.Errorf("update order %s: %w", o.ID(), err)
+	}
+	if n == 0 {
+		return domain.ErrConcurrentModification
+	}
+	o.IncrementVersion()
+	return nil
+}
+
+// Delete soft-deletes the order, or returns domain.ErrNotFound.
+func (r *OrderRepository) Delete(ctx context.Context, id string) error {
+	n, err := queries(ctx, r.q).SoftDeleteOrder(ctx, sqlcgen.SoftDeleteOrderParams{
+		DeletedAt: time.Now().UTC(),
+		UpdatedBy: sqldb.Actor(ctx),
+		ID:        id,
+	})
+	if err != nil {
+		return fmt.Errorf("delete order %s: %w", id, err)
+	}
+	if n == 0 {
+		return domain.ErrNotFound
+	}
+	return nil
+}
+
+// FindPage returns an offset page of orders, newest first.
+func (r *OrderRepository) FindPage(ctx context.Context, spec domain.Specification[*order.Order], page domain.PageRequest) (domain.PageResult[*order.Order], error) {
+	if err := validateOrderSort(page.Sort()); err != nil {
+		return domain.PageResult[*order.Order]{}, err
+	}
+	f, err := orderFilterFor(spec)
+	if err != nil {
+		return domain.PageResult[*order.Order]{}, err
+	}
+
+	q := queries(ctx, r.q)
+	total, err := q.CountOrders(ctx, sqlcgen.CountOrdersParams{Status: f.status, CustomerID: f.customerID})
+	if err != nil {
+		return domain.PageResult[*order.Order]{}, fmt.Errorf("count orders: %w", err)
+	}
+	rows, err := q.ListOrders(ctx, sqlcgen.ListOrdersParams{
+		Status:     f.status,
+		CustomerID: f.customerID,
+		RowLimit:   int32(page.Limit()),
+		RowOffset:  int32(page.Offset()),
+	})
+	if err != nil {
+		return domain.PageResult[*order.Order]{}, fmt.Errorf("list orders: %w", err)
+	}
+
+	items, err := toOrders(rows)
+	if err != nil {
+		return domain.PageResult[*order.Order]{}, err
+	}
+	return domain.NewPageResult(items, page.Page(), page.PageSize(), total), nil
+}
+
+// FindCursor returns a keyset page of orders, newest first. The cursor
+// carries the (created_at, id) key of the last returned order; offset
+// cursors (domain.IsOffsetCursor) are served with OFFSET.
+func (r *OrderRepository) FindCursor(ctx context.Context, spec domain.Specification[*order.Order], req domain.CursorRequest) (domain.CursorResult[*order.Order], error) {
+	if err := validateOrderSort(req.Sort()); err != nil {
+		return domain.CursorResult[*order.Order]{}, err
+	}
+	f, err := orderFilterFor(spec)
+	if err != nil {
+		return domain.CursorResult[*order.Order]{}, err
+	}
+
+	if domain.IsOffsetCursor(req.Cursor()) {
+		offset, _ := domain.DecodeOffsetCursor(req.Cursor())
+		rows, err := queries(ctx, r.q).ListOrders(ctx, sqlcgen.ListOrdersParams{
+			Status:     f.status,
+			CustomerID: f.customerID,
+			RowLimit:   int32(req.Limit() + 1),
+			RowOffset:  int32(offset),
+		})
+		if err != nil {
+			return domain.CursorResult[*order.Order]{}, fmt.Errorf("list orders: %w", err)
+		}
+		items, err := toOrders(rows)
+		if err != nil {
+			return domain.CursorResult[*order.Order]{}, err
+		}
+		return domain.NewOffsetCursorResult(items, req)
+	}
+
+	params := sqlcgen.ListOrdersAfterParams{
+		Status:     f.status,
+		CustomerID: f.customerID,
+		RowLimit:   int32(req.Limit() + 1),
+	}
+	if req.HasCursor() {
+		createdAt, id, err := decodeOrderCursor(req.Cursor())
+		if err != nil {
+			return domain.CursorResult[*order.Order]{}, err
+		}
+		params.AfterCreatedAt = sql.NullTime{Time: createdAt, Valid: true}
+		params.AfterID = nullString(id)
+	}
+
+	rows, err := queries(ctx, r.q).ListOrdersAfter(ctx, params)
+	if err != nil {
+		return domain.CursorResult[*order.Order]{}, fmt.Errorf("list orders: %w", err)
+	}
+
+	hasMore := len(rows) > req.Limit()
+	if hasMore {
+		rows = rows[:req.Limit()]
+	}
+	items, err := toOrders(rows)
+	if err != nil {
+		return domain.CursorResult[*order.Order]{}, err
+	}
+
+	var next string
+	if hasMore {
+		last := rows[len(rows)-1]
+		cursor, err := domain.NewKeysetCursor(last.CreatedAt, last.ID)
+		if err != nil {
+			return domain.CursorResult[*order.Order]{}, err
+		}
+		if next, err = req.NextCursor(cursor.Strings()...); err != nil {
+			return domain.CursorResult[*order.Order]{}, err
+		}
+	}
+	return domain.NewCursorResult(items, next, "", hasMore), nil
+}
+
+// CountByCustomer returns the number of live orders of a customer.
+func (r *OrderRepository) CountByCustomer(ctx context.Context, customerID string) (int64, error) {
+	n, err := queries(ctx, r.q).CountOrders(ctx, sqlcgen.CountOrdersParams{CustomerID: nullString(customerID)})
+	if err != nil {
+		return 0, fmt.Errorf("count orders of customer %s: %w", customerID, err)
+	}
+	return n, nil
+}
+
+// ============================================================================
+// Mapping (sqlcgen ↔ domain)
+// ============================================================================
+
+// orderFilter holds the nullable filter parameters shared by the order queries.
+type orderFilter struct {
+	status     sql.NullString
+	customerID sql.NullString
+}
+
+// orderFilterFor maps order specifications onto query parameters. Only
+// StatusSpec, CustomerSpec and their conjunction are supported.
+func orderFilterFor(spec domain.Specification[*order.Order]) (orderFilter, error) {
+	var f orderFilter
+	switch s := spec.(type) {
+	case nil:
+	case order.StatusSpec:
+		f.status = nullString(string(s.Status))
+	case order.CustomerSpec:
+		f.customerID = nullString(s.CustomerID)
+	case domain.AndSpecification[*order.Order]:
+		for _, inner := range s.Specs() {
+			g, err := orderFilterFor(inner)
+			if err != nil {
+				return orderFilter{}, err
+			}
+			if g.status.Valid {
+				f.status = g.status
+			}
+			if g.customerID.Valid {
+				f.customerID = g.customerID
+			}
+		}
+	default:
+		return orderFilter{}, fmt.Errorf("%w: %T", sqldb.ErrUnsupportedSpec, spec)
+	}
+	return f, nil
+}
+
+// validateOrderSort accepts no sort or the fixed created_at descending order.
+func validateOrderSort(sort []domain.SortOption) error {
+	if err := domain.ValidateSortFields(sort, orderSortField); err != nil {
+		return err
+	}
+	for _, s := range sort {
+		if s.IsAscending() {
+			return fmt.Errorf("%w: %q must be descending", domain.ErrInvalidSortField, s.Field())
+		}
+	}
+	return nil
+}
+
+// decodeOrderCursor returns the (created_at, id) key carried by cursor.
+func decodeOrderCursor(cursor string) (time.Time, string, error) {
+	k, err := domain.DecodeKeysetCursor(cursor)
+	if err != nil {
+		return time.Time{}, "", err
+	}
+	if k.Len() != 2 {
+		return time.Time{}, "", domain.ErrInvalidCursor
+	}
+	createdAt, ok := k.Values()[0].(time.Time)
+	if !ok {
+		return time.Time{}, "", domain.ErrInvalidCursor
+	}
+	id, ok := k.Values()[1].(string)
+	if !ok {
+		return time.Time{}, "", domain.ErrInvalidCursor
+	}
+	return createdAt, id, nil
+}
+
+// toOrder reconstitutes an order aggregate from a generated row.
+func toOrder(row sqlcgen.Order) (*order.Order, error) {
+	total, err := valueobject.NewMoney(row.TotalAmount, row.Currency)
+	if err != nil {
+		return nil, fmt.Errorf("order %s: %w", row.ID, err)
+	}
+
+	root := domain.RestoreAggregateRoot(row.ID, row.CreatedAt, row.UpdatedAt, int(row.Version))
+	root.Entity = root.Entity.WithAudit(row.CreatedBy, row.UpdatedBy)
+	if row.DeletedAt.Valid {
+		root.Entity = root.Entity.WithDeletedAt(row.DeletedAt.Time)
+	}
+	return order.Restore(root, row.CustomerID, order.Status(row.Status), total), nil
+}
+
+// toOrders maps generated rows to aggregates.
+func toOrders(rows []sqlcgen.Order) ([]*order.Order, error) {
+	items := make([]*order.Order, 0, len(rows))
+	for _, row := range rows {
+		o, err := toOrder(row)
+		if err != nil {
+			return nil, err
+		}
+		items = append(items, o)
+	}
+	return items, nil
+}
@@ -0,0 +1,144 @@
+package sqlc
+
+import (
+	"database/sql"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/blackhorseya/go-ddd/internal/domain"
+	"github.com/blackhorseya/go-ddd/internal/domain/order"
+	"github.com/blackhorseya/go-ddd/internal/infrastructure/persistence/sqlc/sqlcgen"
+	"github.com/blackhorseya/go-ddd/internal/infrastructure/persistence/sqldb"
+)
+
+func TestOrderFilterFor(t *testing.T) {
+	tests := []struct {
+		name    string
+		spec    domain.Specification[*order.Order]
+		want    orderFilter
+		wantErr error
+	}{
+		{name: "nil spec", spec: nil, want: orderFilter{}},
+		{
+			name: "status",
+			spec: order.StatusSpec{Status: order.StatusPending},
+			want: orderFilter{status: nullString("pending")},
+		},
+		{
+			name: "status and customer",
+			spec: domain.And[*order.Order](order.StatusSpec{Status: order.StatusConfirmed}, order.CustomerSpec{CustomerID: "c1"}),
+			want: orderFilter{status: nullString("confirmed"), customerID: nullString("c1")},
+		},
+		{
+			name:    "unsupported",
+			spec:    domain.Or[*order.Order](order.StatusSpec{Status: order.StatusPending}),
+			wantErr: sqldb.ErrUnsupportedSpec,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Act
+			got, err := orderFilterFor(tt.spec)
+
+			// Assert
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("orderFilterFor() error = %v, want %v", err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("orderFilterFor() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestValidateOrderSort(t *testing.T) {
+	tests := []struct {
+		name    string
+		sort    []domain.SortOption
+		wantErr error
+	}{
+		{name: "no sort", sort: nil},
+		{name: "created_at desc", sort: []domain.SortOption{domain.NewSortOption("created_at", domain.SortDesc)}},
+		{name: "created_at asc", sort: []domain.SortOption{domain.NewSortOption("created_at", domain.SortAsc)}, wantErr: domain.ErrInvalidSortField},
+		{name: "unknown field", sort: []domain.SortOption{domain.NewSortOption("total", domain.SortDesc)}, wantErr: domain.ErrInvalidSortField},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Act
+			err := validateOrderSort(tt.sort)
+
+			// Assert
+			if !errors.Is(err, tt.wantErr) {
+				t.Errorf("validateOrderSort() error = %v, want %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestDecodeOrderCursor(t *testing.T) {
+	// Arrange
+	createdAt := time.Date(2024, 1, 2, 3, 4, 5, 6, time.UTC)
+	cursor, err := domain.NewKeysetCursor(createdAt, "o1")
+	if err != nil {
+		t.Fatalf("NewKeysetCursor() error = %v", err)
+	}
+
+	// Act
+	gotTime, gotID, err := decodeOrderCursor(cursor.Encode())
+
+	// Assert
+	if err != nil {
+		t.Fatalf("decodeOrderCursor() error = %v", err)
+	}
+	if !gotTime.Equal(createdAt) || gotID != "o1" {
+		t.Errorf("decodeOrderCursor() = (%v, %q), want (%v, %q)", gotTime, gotID, createdAt, "o1")
+	}
+
+	wrong, _ := domain.NewKeysetCursor("o1")
+	if _, _, err := decodeOrderCursor(wrong.Encode()); !errors.Is(err, domain.ErrInvalidCursor) {
+		t.Errorf("decodeOrderCursor(single key) error = %v, want %v", err, domain.ErrInvalidCursor)
+	}
+}
+
+func TestToOrder(t *testing.T) {
+	// Arrange
+	now := time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)
+	row := sqlcgen.Order{
+		ID:          "o1",
+		CustomerID:  "c1",
+		Status:      "confirmed",
+		TotalAmount: 1250,
+		Currency:    "USD",
+		Version:     3,
+		CreatedAt:   now,
+		UpdatedAt:   now,
+		CreatedBy:   "u1",
+		UpdatedBy:   "u2",
+		DeletedAt:   sql.NullTime{Time: now, Valid: true},
+	}
+
+	// Act
+	o, err := toOrder(row)
+
+	// Assert
+	if err != nil {
+		t.Fatalf("toOrder() error = %v", err)
+	}
+	if o.ID() != "o1" || o.CustomerID() != "c1" || o.Status() != order.StatusConfirmed {
+		t.Errorf("toOrder() = %+v", o)
+	}
+	if o.Total().Amount() != 1250 || o.Total().Currency().Code() != "USD" {
+		t.Errorf("Total() = %v, want 1250 USD", o.Total())
+	}
+	if o.Version() != 3 || o.CreatedBy() != "u1" || o.UpdatedBy() != "u2" || !o.IsDeleted() {
+		t.Errorf("toOrder() lost version, audit or deletion: %+v", o)
+	}
+
+	row.Currency = "XX"
+	if _, err := toOrder(row); err == nil {
+		t.Error("toOrder() with invalid currency should fail")
+	}
+}
@@ -0,0 +1,54 @@
+-- name: GetOrder :one
+SELECT * FROM orders
+WHERE id = @id AND deleted_at IS NULL;
+
+-- name: InsertOrder :exec
+INSERT INTO orders (
+    id, customer_id, status, total_amount, currency, version,
+    created_at, updated_at, created_by, updated_by
+) VALUES (
+    @id, @customer_id, @status, @total_amount, @currency, @version,
+    @created_at, @updated_at, @created_by, @updated_by
+);
+
+-- name: UpdateOrder :execrows
+UPDATE orders
+SET status       = @status,
+    total_amount = @total_amount,
+    currency     = @currency,
+    updated_at   = @updated_at,
+    updated_by   = @updated_by,
+    version      = version + 1
+WHERE id = @id AND version = @version AND deleted_at IS NULL;
+
+-- name: SoftDeleteOrder :execrows
+UPDATE orders
+SET deleted_at = @deleted_at::timestamptz,
+    updated_at = @deleted_at::timestamptz,
+    updated_by = @updated_by,
+    version    = version + 1
+WHERE id = @id AND deleted_at IS NULL;
+
+-- name: ListOrders :many
+SELECT * FROM orders
+WHERE deleted_at IS NULL
+  AND (sqlc.narg('status')::text IS NULL OR status = sqlc.narg('status'))
+  AND (sqlc.narg('customer_id')::text IS NULL OR customer_id = sqlc.narg('customer_id'))
+ORDER BY created_at DESC, id DESC
+LIMIT @row_limit OFFSET @row_offset;
+
+-- name: CountOrders :one
+SELECT count(*) FROM orders
+WHERE deleted_at IS NULL
+  AND (sqlc.narg('status')::text IS NULL OR status = sqlc.narg('status'))
+  AND (sqlc.narg('customer_id')::text IS NULL OR customer_id = sqlc.narg('customer_id'));
+
+-- name: ListOrdersAfter :many
+SELECT * FROM orders
+WHERE deleted_at IS NULL
+  AND (sqlc.narg('status')::text IS NULL OR status = sqlc.narg('status'))
+  AND (sqlc.narg('customer_id')::text IS NULL OR customer_id = sqlc.narg('customer_id'))
+  AND (sqlc.narg('after_created_at')::timestamptz IS NULL
+       OR (created_at, id) < (sqlc.narg('after_created_at')::timestamptz, sqlc.narg('after_id')::text))
+ORDER BY created_at DESC, id DESC
+LIMIT @row_limit;
@@ -0,0 +1,17 @@
+-- Orders (PostgreSQL).
+CREATE TABLE IF NOT EXISTS orders (
+    id           TEXT        PRIMARY KEY,
+    customer_id  TEXT        NOT NULL,
+    status       TEXT        NOT NULL,
+    total_amount BIGINT      NOT NULL,
+    currency     TEXT        NOT NULL,
+    version      INT         NOT NULL DEFAULT 0,
+    created_at   TIMESTAMPTZ NOT NULL,
+    updated_at   TIMESTAMPTZ NOT NULL,
+    created_by   TEXT        NOT NULL DEFAULT '',
+    updated_by   TEXT        NOT NULL DEFAULT '',
+    deleted_at   TIMESTAMPTZ
+);
+
+CREATE INDEX IF NOT EXISTS orders_customer_idx ON orders (customer_id) WHERE deleted_at IS NULL;
+CREATE INDEX IF NOT EXISTS orders_keyset_idx ON orders (created_at DESC, id DESC) WHERE deleted_at IS NULL;
@@ -0,0 +1,44 @@
+// Package sqlc is the sqlc-based persistence option: queries are written as
+// SQL in queries/, compiled by sqlc into the typed sqlcgen package, and the
+// repositories here adapt the generated rows to domain aggregates and to
+// PageRequest/CursorRequest.
+//
+// Layout:
+//
+//	schema.sql        DDL read by sqlc (and embedded as Schema)
+//	queries/*.sql     annotated queries (-- name: GetOrder :one)
+//	sqlcgen/          generated code, do not edit (task sqlc:generate)
+//	*_repository.go   domain repository adapters
+//
+// sqlc queries are static: specifications map onto nullable query
+// parameters and each query has a fixed sort order. Use the hand-written
+// sqldb helpers when a repository needs arbitrary filters or sorting.
+package sqlc
+
+import (
+	"context"
+	"database/sql"
+	_ "embed"
+
+	"github.com/blackhorseya/go-ddd/internal/infrastructure/persistence/sqlc/sqlcgen"
+	"github.com/blackhorseya/go-ddd/internal/infrastructure/persistence/sqldb"
+)
+
+// Schema is the DDL for the tables used by the sqlc repositories.
+//
+//go:embed schema.sql
+var Schema string
+
+// queries returns the generated queries bound to the UnitOfWork
+// transaction in ctx, or to the pool when there is none.
+func queries(ctx context.Context, q *sqlcgen.Queries) *sqlcgen.Queries {
+	if tx := sqldb.TxFromContext(ctx); tx != nil {
+		return q.WithTx(tx)
+	}
+	return q
+}
+
+// nullString maps "" to NULL, i.e. "no filter" in the generated queries.
+func nullString(s string) sql.NullString {
+	return sql.NullString{String: s, Valid: s != ""}
+}
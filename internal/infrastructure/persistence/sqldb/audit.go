@@ -0,0 +1,36 @@
+package sqldb
+
+import (
+	"context"
+
+	"github.com/blackhorseya/go-ddd/pkg/contextx"
+)
+
+// SystemActor is recorded when the context carries no user, e.g. in background jobs.
+const SystemActor = "system"
+
+// Auditable is implemented by entities embedding domain.Entity.
+type Auditable interface {
+	AuditCreate(actor string)
+	AuditUpdate(actor string)
+}
+
+// BeforeInsert fills CreatedBy, UpdatedBy and UpdatedAt from the user in ctx.
+// Repositories call it before inserting a new entity.
+func BeforeInsert(ctx context.Context, e Auditable) {
+	e.AuditCreate(Actor(ctx))
+}
+
+// BeforeUpdate fills UpdatedBy and UpdatedAt from the user in ctx.
+// Repositories call it before updating an existing entity.
+func BeforeUpdate(ctx context.Context, e Auditable) {
+	e.AuditUpdate(Actor(ctx))
+}
+
+// Actor returns the user ID from ctx (contextx.GetUserID), or SystemActor.
+func Actor(ctx context.Context) string {
+	if id := contextx.GetUserID(ctx); id != "" {
+		return id
+	}
+	return SystemActor
+}
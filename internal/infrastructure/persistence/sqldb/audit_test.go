@@ -0,0 +1,29 @@
+package sqldb
+
+import (
+	"context"
+	"testing"
+
+	"github.com/blackhorseya/go-ddd/internal/domain"
+	"github.com/blackhorseya/go-ddd/pkg/contextx"
+)
+
+func TestAuditHooks(t *testing.T) {
+	// Arrange
+	e, err := domain.NewEntity("order-1")
+	if err != nil {
+		t.Fatalf("NewEntity() error = %v", err)
+	}
+
+	// Act
+	BeforeInsert(contextx.WithUserID(context.Background(), "alice"), &e)
+	BeforeUpdate(context.Background(), &e)
+
+	// Assert
+	if e.CreatedBy() != "alice" {
+		t.Errorf("CreatedBy() = %q, want alice", e.CreatedBy())
+	}
+	if e.UpdatedBy() != SystemActor {
+		t.Errorf("UpdatedBy() = %q, want %q", e.UpdatedBy(), SystemActor)
+	}
+}
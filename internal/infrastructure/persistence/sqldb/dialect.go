@@ -0,0 +1,68 @@
+package sqldb
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// ErrUnsupportedDialect is returned for database drivers without a dialect.
+var ErrUnsupportedDialect = errors.New("unsupported sql dialect")
+
+// Dialect captures the SQL differences between supported databases.
+// Queries are written with "?" placeholders and rebound per dialect.
+type Dialect string
+
+const (
+	// Postgres uses "$n" placeholders and double-quoted identifiers.
+	Postgres Dialect = "postgres"
+
+	// MySQL uses "?" placeholders and backquoted identifiers.
+	MySQL Dialect = "mysql"
+)
+
+// DialectFor returns the dialect of a config driver name ("postgres" or "mysql").
+// An empty driver selects Postgres.
+func DialectFor(driver string) (Dialect, error) {
+	switch Dialect(strings.ToLower(driver)) {
+	case "", Postgres:
+		return Postgres, nil
+	case MySQL:
+		return MySQL, nil
+	default:
+		return "", fmt.Errorf("%w: %q", ErrUnsupportedDialect, driver)
+	}
+}
+
+// Rebind converts "?" placeholders to the dialect's syntax. For Postgres
+// they are numbered from offset+1; MySQL keeps "?" and ignores offset.
+func (d Dialect) Rebind(query string, offset int) string {
+	if d == MySQL {
+		return query
+	}
+	return Rebind(query, offset)
+}
+
+// Where renders c as a WHERE clause (including the keyword) with
+// placeholders numbered from offset+1.
+func (d Dialect) Where(c Clause, offset int) string {
+	return " WHERE " + d.Rebind(c.SQL, offset)
+}
+
+// Quote quotes an identifier such as a table or column name.
+func (d Dialect) Quote(ident string) string {
+	if d == MySQL {
+		return "`" + strings.ReplaceAll(ident, "`", "``") + "`"
+	}
+	return `"` + strings.ReplaceAll(ident, `"`, `""`) + `"`
+}
+
+// LimitOffset returns the paging clause for offset pagination, e.g.
+// LimitOffset(req.FetchLimit(), req.Offset()). Both dialects accept
+// "LIMIT n OFFSET m"; the placeholders are rebound with the rest of the query.
+func (d Dialect) LimitOffset(limit, offset int) Clause {
+	if offset <= 0 {
+		return Expr("LIMIT ?", limit)
+	}
+	return Expr("LIMIT ? OFFSET ?", limit, offset)
+}
@@ -0,0 +1,78 @@
+package sqldb
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestDialectFor(t *testing.T) {
+	tests := []struct {
+		driver  string
+		want    Dialect
+		wantErr bool
+	}{
+		{driver: "", want: Postgres},
+		{driver: "postgres", want: Postgres},
+		{driver: "MySQL", want: MySQL},
+		{driver: "sqlite", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.driver, func(t *testing.T) {
+			// Act
+			got, err := DialectFor(tt.driver)
+
+			// Assert
+			if tt.wantErr {
+				if !errors.Is(err, ErrUnsupportedDialect) {
+					t.Errorf("DialectFor() error = %v, want %v", err, ErrUnsupportedDialect)
+				}
+				return
+			}
+			if err != nil || got != tt.want {
+				t.Errorf("DialectFor() = %q, %v, want %q", got, err, tt.want)
+			}
+		})
+	}
+}
+
+func TestDialect_Query(t *testing.T) {
+	// Arrange
+	where := Expr("status = ? AND total > ?", "paid", 100)
+	page := Postgres.LimitOffset(21, 40)
+
+	tests := []struct {
+		name    string
+		dialect Dialect
+		want    string
+	}{
+		{
+			name:    "postgres",
+			dialect: Postgres,
+			want:    `SELECT * FROM "orders" WHERE status = $1 AND total > $2 ORDER BY id LIMIT $3 OFFSET $4`,
+		},
+		{
+			name:    "mysql",
+			dialect: MySQL,
+			want:    "SELECT * FROM `orders` WHERE status = ? AND total > ? ORDER BY id LIMIT ? OFFSET ?",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Act
+			got := "SELECT * FROM " + tt.dialect.Quote("orders") +
+				tt.dialect.Where(where, 0) +
+				" ORDER BY id " + tt.dialect.Rebind(page.SQL, len(where.Args))
+
+			// Assert
+			if got != tt.want {
+				t.Errorf("query = %q, want %q", got, tt.want)
+			}
+		})
+	}
+
+	if got := MySQL.LimitOffset(10, 0); got.SQL != "LIMIT ?" || len(got.Args) != 1 {
+		t.Errorf("LimitOffset(10, 0) = %+v, want LIMIT ? with one arg", got)
+	}
+}
@@ -0,0 +1,48 @@
+package sqldb
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/blackhorseya/go-ddd/internal/domain"
+)
+
+// KeysetWhere builds the row comparison selecting rows after the cursor
+// (forward) or before it (backward) for columns sorted in direction:
+//
+//	KeysetWhere([]string{"created_at", "id"}, cursor, domain.SortDesc, false)
+//	// (created_at, id) < (?, ?)
+//
+// Columns must come from a whitelist; they are not escaped. Returns
+// domain.ErrInvalidCursor if the cursor does not match the columns.
+func KeysetWhere(columns []string, cursor domain.KeysetCursor, direction domain.SortDirection, backward bool) (Clause, error) {
+	if len(columns) == 0 || len(columns) != cursor.Len() {
+		return Clause{}, fmt.Errorf("%w: expected %d keyset values, got %d", domain.ErrInvalidCursor, len(columns), cursor.Len())
+	}
+
+	op := ">"
+	if (direction == domain.SortDesc) != backward {
+		op = "<"
+	}
+
+	placeholders := strings.TrimSuffix(strings.Repeat("?, ", len(columns)), ", ")
+	return Clause{
+		SQL:  fmt.Sprintf("(%s) %s (%s)", strings.Join(columns, ", "), op, placeholders),
+		Args: cursor.Values(),
+	}, nil
+}
+
+// KeysetOrderBy returns the ORDER BY list for keyset pagination. Backward
+// pages are fetched in reverse order; callers reverse the rows afterwards.
+func KeysetOrderBy(columns []string, direction domain.SortDirection, backward bool) string {
+	dir := "ASC"
+	if (direction == domain.SortDesc) != backward {
+		dir = "DESC"
+	}
+
+	parts := make([]string, len(columns))
+	for i, col := range columns {
+		parts[i] = col + " " + dir
+	}
+	return strings.Join(parts, ", ")
+}
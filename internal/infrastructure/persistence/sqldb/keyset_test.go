@@ -0,0 +1,63 @@
+package sqldb
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/blackhorseya/go-ddd/internal/domain"
+)
+
+func TestKeysetWhere(t *testing.T) {
+	ts := time.Date(2024, 1, 15, 10, 30, 0, 0, time.UTC)
+	cursor, err := domain.NewKeysetCursor(ts, "abc")
+	if err != nil {
+		t.Fatalf("NewKeysetCursor() error = %v", err)
+	}
+
+	tests := []struct {
+		name      string
+		direction domain.SortDirection
+		backward  bool
+		wantSQL   string
+		wantOrder string
+	}{
+		{"asc forward", domain.SortAsc, false, "(created_at, id) > (?, ?)", "created_at ASC, id ASC"},
+		{"asc backward", domain.SortAsc, true, "(created_at, id) < (?, ?)", "created_at DESC, id DESC"},
+		{"desc forward", domain.SortDesc, false, "(created_at, id) < (?, ?)", "created_at DESC, id DESC"},
+		{"desc backward", domain.SortDesc, true, "(created_at, id) > (?, ?)", "created_at ASC, id ASC"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			columns := []string{"created_at", "id"}
+
+			// Act
+			c, err := KeysetWhere(columns, cursor, tt.direction, tt.backward)
+
+			// Assert
+			if err != nil {
+				t.Fatalf("KeysetWhere() error = %v", err)
+			}
+			if c.SQL != tt.wantSQL {
+				t.Errorf("KeysetWhere() sql = %q, want %q", c.SQL, tt.wantSQL)
+			}
+			if len(c.Args) != 2 || c.Args[0] != ts || c.Args[1] != "abc" {
+				t.Errorf("KeysetWhere() args = %v", c.Args)
+			}
+			if got := KeysetOrderBy(columns, tt.direction, tt.backward); got != tt.wantOrder {
+				t.Errorf("KeysetOrderBy() = %q, want %q", got, tt.wantOrder)
+			}
+		})
+	}
+}
+
+func TestKeysetWhere_Mismatch(t *testing.T) {
+	cursor, _ := domain.NewKeysetCursor("abc")
+
+	_, err := KeysetWhere([]string{"created_at", "id"}, cursor, domain.SortAsc, false)
+
+	if !errors.Is(err, domain.ErrInvalidCursor) {
+		t.Errorf("KeysetWhere() error = %v, want %v", err, domain.ErrInvalidCursor)
+	}
+}
@@ -0,0 +1,192 @@
+package sqldb
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/blackhorseya/go-ddd/internal/domain"
+)
+
+// PagerConfig configures a Pager for one table.
+type PagerConfig struct {
+	// SortColumns maps sortable domain fields to columns and is the sort
+	// whitelist; the default sort field is always allowed.
+	SortColumns map[string]string
+
+	// DefaultSort is applied when a request has no sort.
+	// Default: created_at descending
+	DefaultSort domain.SortOption
+
+	// KeysetColumns are the keyset pagination columns; the last one must be
+	// unique and also breaks ties on offset pages.
+	// Default: created_at, id
+	KeysetColumns []string
+}
+
+// Pager translates PageRequest and CursorRequest values into ORDER BY,
+// LIMIT/OFFSET and keyset WHERE clauses with quoted identifiers, so
+// repositories share one sort whitelist and paging implementation.
+type Pager struct {
+	dialect Dialect
+	cfg     PagerConfig
+}
+
+// NewPager creates a Pager, applying defaults for zero config values.
+func NewPager(d Dialect, cfg PagerConfig) *Pager {
+	if cfg.DefaultSort.Field() == "" {
+		cfg.DefaultSort = domain.NewSortOption("created_at", domain.SortDesc)
+	}
+	if len(cfg.KeysetColumns) == 0 {
+		cfg.KeysetColumns = []string{"created_at", "id"}
+	}
+	return &Pager{dialect: d, cfg: cfg}
+}
+
+// PageQuery holds the clauses of an offset page.
+type PageQuery struct {
+	// OrderBy is the ORDER BY list, without the keyword.
+	OrderBy string
+
+	// Limit is the LIMIT/OFFSET clause with "?" placeholders.
+	Limit Clause
+}
+
+// CursorQuery holds the clauses of a keyset page, or of an offset page when
+// the request carries an offset cursor (domain.IsOffsetCursor).
+type CursorQuery struct {
+	// Where selects rows after the cursor; its SQL is empty on the first
+	// page and for offset cursors.
+	Where Clause
+
+	// OrderBy is the ORDER BY list, without the keyword.
+	OrderBy string
+
+	// Limit is the number of rows to fetch: the page size plus one, so
+	// HasMore can be detected with TrimPage or domain.NewOffsetCursorResult.
+	Limit int
+
+	// Offset is the number of rows to skip for an offset cursor.
+	Offset int
+}
+
+// Page builds the clauses of an offset page.
+func (p *Pager) Page(req domain.PageRequest) (PageQuery, error) {
+	orderBy, err := p.OrderBy(req.Sort())
+	if err != nil {
+		return PageQuery{}, err
+	}
+	return PageQuery{OrderBy: orderBy, Limit: p.dialect.LimitOffset(req.Limit(), req.Offset())}, nil
+}
+
+// Cursor builds the clauses of a keyset page. The direction comes from the
+// request's sort on the first keyset column, falling back to DefaultSort;
+// other sorts return domain.ErrInvalidSortField.
+//
+// An offset cursor, as made by domain.CursorRequestFromPage, builds an
+// OFFSET query sorted like Page instead; build its result with
+// domain.NewOffsetCursorResult.
+func (p *Pager) Cursor(req domain.CursorRequest) (CursorQuery, error) {
+	if domain.IsOffsetCursor(req.Cursor()) {
+		offset, err := domain.DecodeOffsetCursor(req.Cursor())
+		if err != nil {
+			return CursorQuery{}, err
+		}
+		orderBy, err := p.OrderBy(req.Sort())
+		if err != nil {
+			return CursorQuery{}, err
+		}
+		return CursorQuery{OrderBy: orderBy, Limit: req.Limit() + 1, Offset: offset}, nil
+	}
+
+	direction, err := p.keysetDirection(req.Sort())
+	if err != nil {
+		return CursorQuery{}, err
+	}
+
+	columns := p.quoteAll(p.cfg.KeysetColumns)
+	q := CursorQuery{
+		OrderBy: KeysetOrderBy(columns, direction, false),
+		Limit:   req.Limit() + 1,
+	}
+	if req.HasCursor() {
+		cursor, err := domain.DecodeKeysetCursor(req.Cursor())
+		if err != nil {
+			return CursorQuery{}, err
+		}
+		if q.Where, err = KeysetWhere(columns, cursor, direction, false); err != nil {
+			return CursorQuery{}, err
+		}
+	}
+	return q, nil
+}
+
+// OrderBy builds the ORDER BY list from whitelisted sort options, ending
+// with the unique keyset column so offset pages are stable.
+func (p *Pager) OrderBy(sort []domain.SortOption) (string, error) {
+	if len(sort) == 0 {
+		sort = []domain.SortOption{p.cfg.DefaultSort}
+	}
+	unique := p.cfg.KeysetColumns[len(p.cfg.KeysetColumns)-1]
+
+	parts := make([]string, 0, len(sort)+1)
+	hasUnique := false
+	for _, s := range sort {
+		col, ok := p.column(s.Field())
+		if !ok {
+			return "", fmt.Errorf("%w: %q", domain.ErrInvalidSortField, s.Field())
+		}
+		dir := " ASC"
+		if !s.IsAscending() {
+			dir = " DESC"
+		}
+		parts = append(parts, p.dialect.Quote(col)+dir)
+		hasUnique = hasUnique || col == unique
+	}
+	if !hasUnique {
+		parts = append(parts, p.dialect.Quote(unique)+" ASC")
+	}
+	return strings.Join(parts, ", "), nil
+}
+
+// keysetDirection returns the keyset direction. Only a single sort on the
+// first keyset column is accepted.
+func (p *Pager) keysetDirection(sort []domain.SortOption) (domain.SortDirection, error) {
+	switch len(sort) {
+	case 0:
+		return p.cfg.DefaultSort.Direction(), nil
+	case 1:
+		if col, ok := p.column(sort[0].Field()); ok && col == p.cfg.KeysetColumns[0] {
+			return sort[0].Direction(), nil
+		}
+	}
+	return "", fmt.Errorf("%w: cursor pages sort by %s only", domain.ErrInvalidSortField, p.cfg.KeysetColumns[0])
+}
+
+// column resolves a sort field through SortColumns; the default sort field
+// is always allowed.
+func (p *Pager) column(field string) (string, bool) {
+	if col, ok := p.cfg.SortColumns[field]; ok {
+		return col, true
+	}
+	if field == p.cfg.DefaultSort.Field() {
+		return field, true
+	}
+	return "", false
+}
+
+func (p *Pager) quoteAll(columns []string) []string {
+	quoted := make([]string, len(columns))
+	for i, col := range columns {
+		quoted[i] = p.dialect.Quote(col)
+	}
+	return quoted
+}
+
+// TrimPage drops the extra row fetched by a CursorQuery and reports whether
+// there are more rows after the page.
+func TrimPage[T any](rows []T, limit int) ([]T, bool) {
+	if len(rows) > limit {
+		return rows[:limit], true
+	}
+	return rows, false
+}
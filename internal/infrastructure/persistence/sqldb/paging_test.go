@@ -0,0 +1,184 @@
+package sqldb
+
+import (
+	"errors"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/blackhorseya/go-ddd/internal/domain"
+)
+
+func newOrderPager(d Dialect) *Pager {
+	return NewPager(d, PagerConfig{
+		SortColumns: map[string]string{"total": "total_amount", "customerId": "customer_id"},
+	})
+}
+
+func TestPager_Page(t *testing.T) {
+	tests := []struct {
+		name      string
+		dialect   Dialect
+		sort      []domain.SortOption
+		wantOrder string
+		wantErr   error
+	}{
+		{
+			name:      "default sort",
+			dialect:   Postgres,
+			wantOrder: `"created_at" DESC, "id" ASC`,
+		},
+		{
+			name:      "whitelisted fields",
+			dialect:   MySQL,
+			sort:      []domain.SortOption{domain.NewSortOption("total", domain.SortAsc), domain.NewSortOption("customerId", domain.SortDesc)},
+			wantOrder: "`total_amount` ASC, `customer_id` DESC, `id` ASC",
+		},
+		{
+			name:    "field outside whitelist",
+			dialect: Postgres,
+			sort:    []domain.SortOption{domain.NewSortOption("password", domain.SortAsc)},
+			wantErr: domain.ErrInvalidSortField,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Arrange
+			req, err := domain.NewPageRequest(3, 10)
+			if err != nil {
+				t.Fatalf("NewPageRequest() error = %v", err)
+			}
+
+			// Act
+			q, err := newOrderPager(tt.dialect).Page(req.WithSort(tt.sort...))
+
+			// Assert
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("Page() error = %v, want %v", err, tt.wantErr)
+			}
+			if tt.wantErr != nil {
+				return
+			}
+			if q.OrderBy != tt.wantOrder {
+				t.Errorf("OrderBy = %q, want %q", q.OrderBy, tt.wantOrder)
+			}
+			if q.Limit.SQL != "LIMIT ? OFFSET ?" || !slices.Equal(q.Limit.Args, []any{10, 20}) {
+				t.Errorf("Limit = %+v, want LIMIT 10 OFFSET 20", q.Limit)
+			}
+		})
+	}
+}
+
+func TestPager_Cursor(t *testing.T) {
+	ts := time.Date(2024, 1, 15, 10, 30, 0, 0, time.UTC)
+	cursor, err := domain.NewKeysetCursor(ts, "abc")
+	if err != nil {
+		t.Fatalf("NewKeysetCursor() error = %v", err)
+	}
+
+	tests := []struct {
+		name       string
+		cursor     string
+		sort       []domain.SortOption
+		wantWhere  string
+		wantOrder  string
+		wantOffset int
+		wantErr    error
+	}{
+		{
+			name:      "first page",
+			wantOrder: `"created_at" DESC, "id" DESC`,
+		},
+		{
+			name:      "next page ascending",
+			cursor:    cursor.Encode(),
+			sort:      []domain.SortOption{domain.NewSortOption("created_at", domain.SortAsc)},
+			wantWhere: `("created_at", "id") > (?, ?)`,
+			wantOrder: `"created_at" ASC, "id" ASC`,
+		},
+		{
+			name:    "sort on another column",
+			sort:    []domain.SortOption{domain.NewSortOption("total", domain.SortAsc)},
+			wantErr: domain.ErrInvalidSortField,
+		},
+		{
+			name:      "offset cursor first page",
+			cursor:    domain.EncodeOffsetCursor(0),
+			wantOrder: `"created_at" DESC, "id" ASC`,
+		},
+		{
+			name:       "offset cursor sorted on another column",
+			cursor:     domain.EncodeOffsetCursor(10),
+			sort:       []domain.SortOption{domain.NewSortOption("total", domain.SortAsc)},
+			wantOrder:  `"total_amount" ASC, "id" ASC`,
+			wantOffset: 10,
+		},
+		{
+			name:    "offset cursor with unknown sort",
+			cursor:  domain.EncodeOffsetCursor(10),
+			sort:    []domain.SortOption{domain.NewSortOption("secret", domain.SortAsc)},
+			wantErr: domain.ErrInvalidSortField,
+		},
+		{
+			name:    "malformed cursor",
+			cursor:  "not-a-cursor",
+			wantErr: domain.ErrInvalidCursor,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Arrange
+			req, err := domain.NewCursorRequest(tt.cursor, 5)
+			if err != nil {
+				t.Fatalf("NewCursorRequest() error = %v", err)
+			}
+
+			// Act
+			q, err := newOrderPager(Postgres).Cursor(req.WithSort(tt.sort...))
+
+			// Assert
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("Cursor() error = %v, want %v", err, tt.wantErr)
+			}
+			if tt.wantErr != nil {
+				return
+			}
+			if q.Where.SQL != tt.wantWhere {
+				t.Errorf("Where = %q, want %q", q.Where.SQL, tt.wantWhere)
+			}
+			if q.OrderBy != tt.wantOrder {
+				t.Errorf("OrderBy = %q, want %q", q.OrderBy, tt.wantOrder)
+			}
+			if q.Limit != 6 || q.Offset != tt.wantOffset {
+				t.Errorf("Limit, Offset = %d, %d, want 6, %d", q.Limit, q.Offset, tt.wantOffset)
+			}
+		})
+	}
+}
+
+func TestTrimPage(t *testing.T) {
+	tests := []struct {
+		name     string
+		rows     []int
+		wantRows []int
+		wantMore bool
+	}{
+		{"extra row", []int{1, 2, 3}, []int{1, 2}, true},
+		{"exact page", []int{1, 2}, []int{1, 2}, false},
+		{"short page", []int{1}, []int{1}, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Act
+			rows, more := TrimPage(tt.rows, 2)
+
+			// Assert
+			if !slices.Equal(rows, tt.wantRows) || more != tt.wantMore {
+				t.Errorf("TrimPage() = %v, %v, want %v, %v", rows, more, tt.wantRows, tt.wantMore)
+			}
+		})
+	}
+}
@@ -0,0 +1,134 @@
+package sqldb
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+
+	"github.com/blackhorseya/go-ddd/internal/domain"
+	"github.com/blackhorseya/go-ddd/pkg/contextx"
+)
+
+// DeletedAtColumn is the soft-delete column convention: a nullable timestamp.
+const DeletedAtColumn = "deleted_at"
+
+// Default purge settings.
+const (
+	DefaultPurgeRetention = 30 * 24 * time.Hour
+	DefaultPurgeInterval  = time.Hour
+)
+
+// DeletedClause returns the condition for a soft-delete filter, to be
+// combined with the query's other conditions:
+//
+//	List(ctx, filter)  ->  WHERE status = ? AND deleted_at IS NULL
+func DeletedClause(filter domain.DeletedFilter) Clause {
+	switch filter {
+	case domain.IncludeDeleted:
+		return Expr("1=1")
+	case domain.OnlyDeleted:
+		return Expr(DeletedAtColumn + " IS NOT NULL")
+	default:
+		return Expr(DeletedAtColumn + " IS NULL")
+	}
+}
+
+// NullTime converts a zero time to NULL, e.g. for Entity.DeletedAt.
+func NullTime(t time.Time) sql.NullTime {
+	return sql.NullTime{Time: t, Valid: !t.IsZero()}
+}
+
+// PurgeHook is called after rows are purged from a table.
+type PurgeHook func(ctx context.Context, table string, purged int64)
+
+// PurgerConfig configures the Purger.
+type PurgerConfig struct {
+	// Tables lists the soft-deletable tables to purge. Names are not escaped.
+	Tables []string
+
+	// Retention is how long soft-deleted rows are kept. Default: 30 days
+	Retention time.Duration
+
+	// Interval is the delay between purge runs. Default: 1h
+	Interval time.Duration
+
+	// OnPurge is called for each table with the number of purged rows,
+	// e.g. to clean up related files or emit metrics. Optional.
+	OnPurge PurgeHook
+
+	// Dialect selects the placeholder syntax. Default: Postgres
+	Dialect Dialect
+}
+
+// Purger permanently deletes rows that were soft-deleted longer than the retention.
+type Purger struct {
+	db  *sql.DB
+	cfg PurgerConfig
+	now func() time.Time
+}
+
+// NewPurger creates a Purger, applying defaults for zero config values.
+func NewPurger(db *sql.DB, cfg PurgerConfig) *Purger {
+	if cfg.Retention <= 0 {
+		cfg.Retention = DefaultPurgeRetention
+	}
+	if cfg.Interval <= 0 {
+		cfg.Interval = DefaultPurgeInterval
+	}
+	if cfg.Dialect == "" {
+		cfg.Dialect = Postgres
+	}
+	return &Purger{db: db, cfg: cfg, now: time.Now}
+}
+
+// Run purges on every interval until ctx is cancelled.
+func (p *Purger) Run(ctx context.Context) error {
+	logger := contextx.From(ctx)
+	logger.Info("soft delete purger started",
+		"tables", p.cfg.Tables,
+		"retention", p.cfg.Retention.String(),
+	)
+
+	ticker := time.NewTicker(p.cfg.Interval)
+	defer ticker.Stop()
+
+	for {
+		if _, err := p.PurgeOnce(ctx); err != nil {
+			logger.Error("soft delete purge failed", "error", err)
+		}
+
+		select {
+		case <-ctx.Done():
+			logger.Info("soft delete purger stopped")
+			return nil
+		case <-ticker.C:
+		}
+	}
+}
+
+// PurgeOnce deletes expired soft-deleted rows from every table and returns
+// the total number of purged rows. It stops at the first failing table.
+func (p *Purger) PurgeOnce(ctx context.Context) (int64, error) {
+	cutoff := p.now().Add(-p.cfg.Retention)
+
+	var total int64
+	for _, table := range p.cfg.Tables {
+		query := p.cfg.Dialect.Rebind(fmt.Sprintf("DELETE FROM %s WHERE %s IS NOT NULL AND %s < ?", table, DeletedAtColumn, DeletedAtColumn), 0)
+		res, err := p.db.ExecContext(ctx, query, cutoff)
+		if err != nil {
+			return total, fmt.Errorf("purge %s: %w", table, err)
+		}
+
+		n, err := res.RowsAffected()
+		if err != nil {
+			return total, fmt.Errorf("purge %s: rows affected: %w", table, err)
+		}
+		total += n
+
+		if p.cfg.OnPurge != nil {
+			p.cfg.OnPurge(ctx, table, n)
+		}
+	}
+	return total, nil
+}
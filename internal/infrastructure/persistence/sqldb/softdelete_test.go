@@ -0,0 +1,60 @@
+package sqldb
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/blackhorseya/go-ddd/internal/domain"
+)
+
+func TestDeletedClause(t *testing.T) {
+	tests := []struct {
+		filter domain.DeletedFilter
+		want   string
+	}{
+		{domain.ExcludeDeleted, "deleted_at IS NULL"},
+		{domain.IncludeDeleted, "1=1"},
+		{domain.OnlyDeleted, "deleted_at IS NOT NULL"},
+	}
+
+	for _, tt := range tests {
+		if got := DeletedClause(tt.filter).SQL; got != tt.want {
+			t.Errorf("DeletedClause(%d) = %q, want %q", tt.filter, got, tt.want)
+		}
+	}
+}
+
+func TestPurger_PurgeOnce(t *testing.T) {
+	// Arrange
+	db, d := newTestDB(t)
+	hooked := map[string]int64{}
+	p := NewPurger(db, PurgerConfig{
+		Tables:    []string{"orders", "users"},
+		Retention: 24 * time.Hour,
+		OnPurge: func(_ context.Context, table string, n int64) {
+			hooked[table] = n
+		},
+	})
+
+	// Act
+	total, err := p.PurgeOnce(context.Background())
+
+	// Assert
+	if err != nil {
+		t.Fatalf("PurgeOnce() error = %v", err)
+	}
+	if total != 2 {
+		t.Errorf("PurgeOnce() = %d, want 2", total)
+	}
+	if hooked["orders"] != 1 || hooked["users"] != 1 {
+		t.Errorf("OnPurge calls = %v", hooked)
+	}
+	want := []string{
+		"exec DELETE FROM orders WHERE deleted_at IS NOT NULL AND deleted_at < $1",
+		"exec DELETE FROM users WHERE deleted_at IS NOT NULL AND deleted_at < $1",
+	}
+	if got := d.calls(); !equal(got, want) {
+		t.Errorf("calls = %v, want %v", got, want)
+	}
+}
@@ -0,0 +1,124 @@
+package sqldb
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+
+	"github.com/blackhorseya/go-ddd/internal/domain"
+)
+
+// ErrUnsupportedSpec is returned when a specification has no registered rule.
+var ErrUnsupportedSpec = errors.New("unsupported specification")
+
+// Clause is a SQL boolean expression using "?" placeholders.
+type Clause struct {
+	SQL  string
+	Args []any
+}
+
+// Expr creates a Clause, e.g. Expr("status = ?", "paid").
+func Expr(sql string, args ...any) Clause {
+	return Clause{SQL: sql, Args: args}
+}
+
+// SpecTranslator converts domain specifications into SQL WHERE clauses.
+// And, Or and Not are handled structurally; leaf specifications need a
+// rule registered with HandleSpec. A translator is not safe for concurrent
+// registration but may be shared once configured.
+type SpecTranslator[T any] struct {
+	rules map[reflect.Type]func(domain.Specification[T]) (Clause, error)
+}
+
+// NewSpecTranslator creates an empty translator.
+func NewSpecTranslator[T any]() *SpecTranslator[T] {
+	return &SpecTranslator[T]{rules: make(map[reflect.Type]func(domain.Specification[T]) (Clause, error))}
+}
+
+// HandleSpec registers the SQL rule for leaf specifications of type S.
+//
+//	sqldb.HandleSpec(t, func(s order.StatusIs) (sqldb.Clause, error) {
+//		return sqldb.Expr("status = ?", s.Status()), nil
+//	})
+func HandleSpec[T any, S domain.Specification[T]](t *SpecTranslator[T], fn func(S) (Clause, error)) {
+	t.rules[reflect.TypeFor[S]()] = func(spec domain.Specification[T]) (Clause, error) {
+		return fn(spec.(S))
+	}
+}
+
+// Translate converts spec into a Clause.
+func (t *SpecTranslator[T]) Translate(spec domain.Specification[T]) (Clause, error) {
+	switch s := spec.(type) {
+	case domain.AndSpecification[T]:
+		return t.join(s.Specs(), " AND ", "1=1")
+	case domain.OrSpecification[T]:
+		return t.join(s.Specs(), " OR ", "1=0")
+	case domain.NotSpecification[T]:
+		inner, err := t.Translate(s.Spec())
+		if err != nil {
+			return Clause{}, err
+		}
+		return Clause{SQL: "NOT (" + inner.SQL + ")", Args: inner.Args}, nil
+	}
+
+	rule, ok := t.rules[reflect.TypeOf(spec)]
+	if !ok {
+		return Clause{}, fmt.Errorf("%w: %T", ErrUnsupportedSpec, spec)
+	}
+	return rule(spec)
+}
+
+// Where translates spec into a PostgreSQL WHERE clause (including the
+// keyword) with placeholders numbered from offset+1.
+func (t *SpecTranslator[T]) Where(spec domain.Specification[T], offset int) (string, []any, error) {
+	return t.WhereFor(Postgres, spec, offset)
+}
+
+// WhereFor is like Where for the given dialect.
+func (t *SpecTranslator[T]) WhereFor(d Dialect, spec domain.Specification[T], offset int) (string, []any, error) {
+	c, err := t.Translate(spec)
+	if err != nil {
+		return "", nil, err
+	}
+	return d.Where(c, offset), c.Args, nil
+}
+
+// join translates specs and joins them with op. An empty list yields empty.
+func (t *SpecTranslator[T]) join(specs []domain.Specification[T], op, empty string) (Clause, error) {
+	if len(specs) == 0 {
+		return Clause{SQL: empty}, nil
+	}
+
+	parts := make([]string, 0, len(specs))
+	var args []any
+	for _, spec := range specs {
+		c, err := t.Translate(spec)
+		if err != nil {
+			return Clause{}, err
+		}
+		parts = append(parts, "("+c.SQL+")")
+		args = append(args, c.Args...)
+	}
+	return Clause{SQL: strings.Join(parts, op), Args: args}, nil
+}
+
+// Rebind replaces "?" placeholders with PostgreSQL "$n" placeholders,
+// numbering from offset+1. Literal question marks must be passed as args.
+func Rebind(query string, offset int) string {
+	var b strings.Builder
+	b.Grow(len(query) + 8)
+
+	n := offset
+	for _, r := range query {
+		if r == '?' {
+			n++
+			b.WriteByte('$')
+			b.WriteString(strconv.Itoa(n))
+			continue
+		}
+		b.WriteRune(r)
+	}
+	return b.String()
+}
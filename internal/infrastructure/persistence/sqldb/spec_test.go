@@ -0,0 +1,89 @@
+package sqldb
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/blackhorseya/go-ddd/internal/domain"
+)
+
+type order struct {
+	status string
+	total  int
+}
+
+type statusIs struct{ status string }
+
+func (s statusIs) IsSatisfiedBy(o order) bool { return o.status == s.status }
+
+type totalAbove struct{ min int }
+
+func (s totalAbove) IsSatisfiedBy(o order) bool { return o.total > s.min }
+
+func newOrderTranslator() *SpecTranslator[order] {
+	t := NewSpecTranslator[order]()
+	HandleSpec(t, func(s statusIs) (Clause, error) { return Expr("status = ?", s.status), nil })
+	HandleSpec(t, func(s totalAbove) (Clause, error) { return Expr("total > ?", s.min), nil })
+	return t
+}
+
+func TestSpecTranslator_Where(t *testing.T) {
+	tests := []struct {
+		name     string
+		spec     domain.Specification[order]
+		offset   int
+		wantSQL  string
+		wantArgs []any
+	}{
+		{
+			name:     "leaf",
+			spec:     statusIs{"paid"},
+			wantSQL:  " WHERE status = $1",
+			wantArgs: []any{"paid"},
+		},
+		{
+			name: "and or not",
+			spec: domain.And[order](
+				totalAbove{100},
+				domain.Or[order](statusIs{"paid"}, domain.Not[order](statusIs{"cancelled"})),
+			),
+			offset:   1,
+			wantSQL:  " WHERE (total > $2) AND ((status = $3) OR (NOT (status = $4)))",
+			wantArgs: []any{100, "paid", "cancelled"},
+		},
+		{
+			name:    "empty or",
+			spec:    domain.Or[order](),
+			wantSQL: " WHERE 1=0",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Act
+			sql, args, err := newOrderTranslator().Where(tt.spec, tt.offset)
+
+			// Assert
+			if err != nil {
+				t.Fatalf("Where() error = %v", err)
+			}
+			if sql != tt.wantSQL {
+				t.Errorf("Where() sql = %q, want %q", sql, tt.wantSQL)
+			}
+			if !reflect.DeepEqual(args, tt.wantArgs) {
+				t.Errorf("Where() args = %v, want %v", args, tt.wantArgs)
+			}
+		})
+	}
+}
+
+func TestSpecTranslator_Unsupported(t *testing.T) {
+	spec := domain.Not[order](domain.SpecFunc[order](func(order) bool { return true }))
+
+	_, err := newOrderTranslator().Translate(spec)
+
+	if !errors.Is(err, ErrUnsupportedSpec) {
+		t.Errorf("Translate() error = %v, want %v", err, ErrUnsupportedSpec)
+	}
+}
@@ -0,0 +1,96 @@
+// Package sqldb provides database/sql helpers shared by SQL repositories:
+// a UnitOfWork implementation that binds a transaction to the context,
+// translation of specifications, page and cursor requests into SQL, optimistic
+// locking, soft-delete and audit hooks.
+package sqldb
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+
+	"github.com/blackhorseya/go-ddd/internal/domain"
+)
+
+// Querier is implemented by *sql.DB and *sql.Tx.
+type Querier interface {
+	ExecContext(ctx context.Context, query string, args ...any) (sql.Result, error)
+	QueryContext(ctx context.Context, query string, args ...any) (*sql.Rows, error)
+	QueryRowContext(ctx context.Context, query string, args ...any) *sql.Row
+}
+
+// txKeyType is the context key for the active transaction.
+type txKeyType struct{}
+
+var txKey = txKeyType{}
+
+// TxManager is a domain.UnitOfWork backed by database/sql.
+type TxManager struct {
+	db   *sql.DB
+	opts *sql.TxOptions
+}
+
+var _ domain.UnitOfWork = (*TxManager)(nil)
+
+// NewTxManager creates a TxManager. opts may be nil for driver defaults.
+func NewTxManager(db *sql.DB, opts *sql.TxOptions) *TxManager {
+	return &TxManager{db: db, opts: opts}
+}
+
+// Do runs fn in a transaction bound to the context.
+// If ctx already carries a transaction, fn joins it and the outermost Do
+// decides whether to commit. A panic in fn rolls back and is re-raised.
+func (m *TxManager) Do(ctx context.Context, fn func(ctx context.Context) error) (err error) {
+	if TxFromContext(ctx) != nil {
+		return fn(ctx)
+	}
+
+	tx, err := m.db.BeginTx(ctx, m.opts)
+	if err != nil {
+		return fmt.Errorf("begin transaction: %w", err)
+	}
+
+	defer func() {
+		if rec := recover(); rec != nil {
+			_ = tx.Rollback()
+			panic(rec)
+		}
+	}()
+
+	if err := fn(WithTx(ctx, tx)); err != nil {
+		if rbErr := tx.Rollback(); rbErr != nil && !errors.Is(rbErr, sql.ErrTxDone) {
+			return errors.Join(err, fmt.Errorf("rollback transaction: %w", rbErr))
+		}
+		return err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("commit transaction: %w", err)
+	}
+	return nil
+}
+
+// WithTx returns a new context carrying the transaction.
+func WithTx(ctx context.Context, tx *sql.Tx) context.Context {
+	return context.WithValue(ctx, txKey, tx)
+}
+
+// TxFromContext returns the transaction bound to ctx, or nil.
+func TxFromContext(ctx context.Context) *sql.Tx {
+	if tx, ok := ctx.Value(txKey).(*sql.Tx); ok {
+		return tx
+	}
+	return nil
+}
+
+// Conn returns the transaction bound to ctx, or db when there is none.
+// Repositories use it so they participate in a UnitOfWork transparently:
+//
+//	_, err := sqldb.Conn(ctx, r.db).ExecContext(ctx, query, args...)
+func Conn(ctx context.Context, db *sql.DB) Querier {
+	if tx := TxFromContext(ctx); tx != nil {
+		return tx
+	}
+	return db
+}
@@ -0,0 +1,175 @@
+package sqldb
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"errors"
+	"strings"
+	"sync"
+	"testing"
+)
+
+// recordingDriver is a minimal database/sql driver that records transaction calls.
+type recordingDriver struct {
+	mu  sync.Mutex
+	log []string
+}
+
+func (d *recordingDriver) record(s string) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	d.log = append(d.log, s)
+}
+
+func (d *recordingDriver) calls() []string {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	return append([]string(nil), d.log...)
+}
+
+func (d *recordingDriver) Open(string) (driver.Conn, error) { return &recordingConn{d: d}, nil }
+
+type recordingConn struct{ d *recordingDriver }
+
+func (c *recordingConn) Prepare(string) (driver.Stmt, error) { return nil, errors.New("not supported") }
+func (c *recordingConn) Close() error                        { return nil }
+func (c *recordingConn) Begin() (driver.Tx, error) {
+	c.d.record("begin")
+	return &recordingTx{d: c.d}, nil
+}
+
+func (c *recordingConn) ExecContext(_ context.Context, query string, _ []driver.NamedValue) (driver.Result, error) {
+	c.d.record("exec " + query)
+	if strings.Contains(query, "stale") {
+		return driver.RowsAffected(0), nil
+	}
+	return driver.RowsAffected(1), nil
+}
+
+type recordingTx struct{ d *recordingDriver }
+
+func (t *recordingTx) Commit() error   { t.d.record("commit"); return nil }
+func (t *recordingTx) Rollback() error { t.d.record("rollback"); return nil }
+
+func newTestDB(t *testing.T) (*sql.DB, *recordingDriver) {
+	t.Helper()
+	d := &recordingDriver{}
+	db := sql.OpenDB(connector{d})
+	db.SetMaxOpenConns(1)
+	t.Cleanup(func() { _ = db.Close() })
+	return db, d
+}
+
+type connector struct{ d *recordingDriver }
+
+func (c connector) Connect(context.Context) (driver.Conn, error) { return c.d.Open("") }
+func (c connector) Driver() driver.Driver                        { return c.d }
+
+func equal(a, b []string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
+
+func TestTxManager_Do(t *testing.T) {
+	errBoom := errors.New("boom")
+
+	tests := []struct {
+		name    string
+		fn      func(ctx context.Context, db *sql.DB) error
+		wantErr error
+		want    []string
+	}{
+		{
+			name: "commits on success",
+			fn: func(ctx context.Context, db *sql.DB) error {
+				_, err := Conn(ctx, db).ExecContext(ctx, "a")
+				return err
+			},
+			want: []string{"begin", "exec a", "commit"},
+		},
+		{
+			name: "rolls back on error",
+			fn: func(ctx context.Context, db *sql.DB) error {
+				_, _ = Conn(ctx, db).ExecContext(ctx, "a")
+				return errBoom
+			},
+			wantErr: errBoom,
+			want:    []string{"begin", "exec a", "rollback"},
+		},
+		{
+			name: "nested calls join the outer transaction",
+			fn: func(ctx context.Context, db *sql.DB) error {
+				return NewTxManager(db, nil).Do(ctx, func(ctx context.Context) error {
+					_, err := Conn(ctx, db).ExecContext(ctx, "inner")
+					return err
+				})
+			},
+			want: []string{"begin", "exec inner", "commit"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Arrange
+			db, d := newTestDB(t)
+			m := NewTxManager(db, nil)
+
+			// Act
+			err := m.Do(context.Background(), func(ctx context.Context) error {
+				return tt.fn(ctx, db)
+			})
+
+			// Assert
+			if !errors.Is(err, tt.wantErr) {
+				t.Errorf("Do() error = %v, want %v", err, tt.wantErr)
+			}
+			if got := d.calls(); !equal(got, tt.want) {
+				t.Errorf("calls = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestTxManager_Do_Panic(t *testing.T) {
+	db, d := newTestDB(t)
+	m := NewTxManager(db, nil)
+
+	defer func() {
+		if recover() == nil {
+			t.Fatal("expected panic to be re-raised")
+		}
+		if got, want := d.calls(), []string{"begin", "rollback"}; !equal(got, want) {
+			t.Errorf("calls = %v, want %v", got, want)
+		}
+	}()
+
+	_ = m.Do(context.Background(), func(context.Context) error {
+		panic("boom")
+	})
+}
+
+func TestConn(t *testing.T) {
+	db, _ := newTestDB(t)
+
+	if got := Conn(context.Background(), db); got != db {
+		t.Errorf("Conn() without tx = %v, want db", got)
+	}
+
+	tx, err := db.Begin()
+	if err != nil {
+		t.Fatalf("Begin() error = %v", err)
+	}
+	defer func() { _ = tx.Rollback() }()
+
+	if got := Conn(WithTx(context.Background(), tx), db); got != tx {
+		t.Errorf("Conn() with tx = %v, want tx", got)
+	}
+}
@@ -0,0 +1,34 @@
+package sqldb
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/blackhorseya/go-ddd/internal/domain"
+)
+
+// ExecVersioned runs an optimistic-locking statement whose WHERE clause
+// checks the loaded version, and returns domain.ErrConcurrentModification
+// when no row matched:
+//
+//	err := sqldb.ExecVersioned(ctx, sqldb.Conn(ctx, r.db),
+//		`UPDATE orders SET status = $1, version = version + 1 WHERE id = $2 AND version = $3`,
+//		o.Status(), o.ID(), o.Version())
+//	if err == nil {
+//		o.IncrementVersion()
+//	}
+func ExecVersioned(ctx context.Context, q Querier, query string, args ...any) error {
+	res, err := q.ExecContext(ctx, query, args...)
+	if err != nil {
+		return err
+	}
+
+	n, err := res.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("rows affected: %w", err)
+	}
+	if n == 0 {
+		return domain.ErrConcurrentModification
+	}
+	return nil
+}
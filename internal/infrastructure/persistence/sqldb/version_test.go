@@ -0,0 +1,32 @@
+package sqldb
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/blackhorseya/go-ddd/internal/domain"
+)
+
+func TestExecVersioned(t *testing.T) {
+	tests := []struct {
+		name    string
+		query   string
+		wantErr error
+	}{
+		{"row updated", "update current", nil},
+		{"no row matched", "update stale", domain.ErrConcurrentModification},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db, _ := newTestDB(t)
+
+			err := ExecVersioned(context.Background(), db, tt.query, "order-1", 3)
+
+			if !errors.Is(err, tt.wantErr) {
+				t.Errorf("ExecVersioned() error = %v, want %v", err, tt.wantErr)
+			}
+		})
+	}
+}
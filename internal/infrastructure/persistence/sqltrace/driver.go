@@ -0,0 +1,283 @@
+package sqltrace
+
+import (
+	"context"
+	"database/sql/driver"
+	"errors"
+	"io"
+	"reflect"
+	"time"
+)
+
+// connector wraps a driver.Connector so its connections are instrumented.
+type connector struct {
+	driver.Connector
+	opts Options
+}
+
+func (c *connector) Connect(ctx context.Context) (driver.Conn, error) {
+	cn, err := c.Connector.Connect(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return &conn{Conn: cn, opts: c.opts}, nil
+}
+
+// Close closes the wrapped connector if it holds resources.
+func (c *connector) Close() error {
+	if cl, ok := c.Connector.(io.Closer); ok {
+		return cl.Close()
+	}
+	return nil
+}
+
+// conn forwards to the driver connection, timing statements. Optional
+// interfaces the driver lacks return driver.ErrSkip so database/sql falls
+// back to its default behavior.
+type conn struct {
+	driver.Conn
+	opts Options
+}
+
+var (
+	_ driver.ConnBeginTx            = (*conn)(nil)
+	_ driver.ConnPrepareContext     = (*conn)(nil)
+	_ driver.ExecerContext          = (*conn)(nil)
+	_ driver.QueryerContext         = (*conn)(nil)
+	_ driver.Pinger                 = (*conn)(nil)
+	_ driver.SessionResetter        = (*conn)(nil)
+	_ driver.Validator              = (*conn)(nil)
+	_ driver.NamedValueChecker      = (*conn)(nil)
+	_ driver.StmtExecContext        = (*stmt)(nil)
+	_ driver.StmtQueryContext       = (*stmt)(nil)
+	_ driver.RowsNextResultSet      = (*rows)(nil)
+	_ driver.RowsColumnTypeScanType = (*rows)(nil)
+)
+
+func (c *conn) BeginTx(ctx context.Context, opts driver.TxOptions) (driver.Tx, error) {
+	if b, ok := c.Conn.(driver.ConnBeginTx); ok {
+		return b.BeginTx(ctx, opts)
+	}
+	return c.Conn.Begin()
+}
+
+func (c *conn) PrepareContext(ctx context.Context, query string) (driver.Stmt, error) {
+	var (
+		s   driver.Stmt
+		err error
+	)
+	if p, ok := c.Conn.(driver.ConnPrepareContext); ok {
+		s, err = p.PrepareContext(ctx, query)
+	} else {
+		s, err = c.Conn.Prepare(query)
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &stmt{Stmt: s, query: query, opts: c.opts}, nil
+}
+
+func (c *conn) ExecContext(ctx context.Context, query string, args []driver.NamedValue) (driver.Result, error) {
+	e, ok := c.Conn.(driver.ExecerContext)
+	if !ok {
+		return nil, driver.ErrSkip
+	}
+	start := time.Now()
+	res, err := e.ExecContext(ctx, query, args)
+	if errors.Is(err, driver.ErrSkip) {
+		return nil, err
+	}
+	c.opts.observe(ctx, "exec", query, start, rowsAffected(res, err), err)
+	return res, err
+}
+
+func (c *conn) QueryContext(ctx context.Context, query string, args []driver.NamedValue) (driver.Rows, error) {
+	q, ok := c.Conn.(driver.QueryerContext)
+	if !ok {
+		return nil, driver.ErrSkip
+	}
+	start := time.Now()
+	r, err := q.QueryContext(ctx, query, args)
+	if errors.Is(err, driver.ErrSkip) {
+		return nil, err
+	}
+	if err != nil {
+		c.opts.observe(ctx, "query", query, start, -1, err)
+		return nil, err
+	}
+	return &rows{Rows: r, ctx: ctx, query: query, start: start, opts: c.opts}, nil
+}
+
+func (c *conn) Ping(ctx context.Context) error {
+	if p, ok := c.Conn.(driver.Pinger); ok {
+		return p.Ping(ctx)
+	}
+	return nil
+}
+
+func (c *conn) ResetSession(ctx context.Context) error {
+	if r, ok := c.Conn.(driver.SessionResetter); ok {
+		return r.ResetSession(ctx)
+	}
+	return nil
+}
+
+func (c *conn) IsValid() bool {
+	if v, ok := c.Conn.(driver.Validator); ok {
+		return v.IsValid()
+	}
+	return true
+}
+
+func (c *conn) CheckNamedValue(nv *driver.NamedValue) error {
+	if ch, ok := c.Conn.(driver.NamedValueChecker); ok {
+		return ch.CheckNamedValue(nv)
+	}
+	return driver.ErrSkip
+}
+
+// stmt times prepared statement executions.
+type stmt struct {
+	driver.Stmt
+	query string
+	opts  Options
+}
+
+func (s *stmt) ExecContext(ctx context.Context, args []driver.NamedValue) (driver.Result, error) {
+	start := time.Now()
+	var (
+		res driver.Result
+		err error
+	)
+	if e, ok := s.Stmt.(driver.StmtExecContext); ok {
+		res, err = e.ExecContext(ctx, args)
+	} else {
+		res, err = s.Stmt.Exec(values(args))
+	}
+	s.opts.observe(ctx, "exec", s.query, start, rowsAffected(res, err), err)
+	return res, err
+}
+
+func (s *stmt) QueryContext(ctx context.Context, args []driver.NamedValue) (driver.Rows, error) {
+	start := time.Now()
+	var (
+		r   driver.Rows
+		err error
+	)
+	if q, ok := s.Stmt.(driver.StmtQueryContext); ok {
+		r, err = q.QueryContext(ctx, args)
+	} else {
+		r, err = s.Stmt.Query(values(args))
+	}
+	if err != nil {
+		s.opts.observe(ctx, "query", s.query, start, -1, err)
+		return nil, err
+	}
+	return &rows{Rows: r, ctx: ctx, query: s.query, start: start, opts: s.opts}, nil
+}
+
+func (s *stmt) CheckNamedValue(nv *driver.NamedValue) error {
+	if ch, ok := s.Stmt.(driver.NamedValueChecker); ok {
+		return ch.CheckNamedValue(nv)
+	}
+	return driver.ErrSkip
+}
+
+// rows counts fetched rows and records the query when closed, so the
+// duration covers reading the result set.
+type rows struct {
+	driver.Rows
+	ctx   context.Context
+	query string
+	start time.Time
+	opts  Options
+	n     int64
+	err   error
+}
+
+func (r *rows) Next(dest []driver.Value) error {
+	err := r.Rows.Next(dest)
+	switch {
+	case err == nil:
+		r.n++
+	case !errors.Is(err, io.EOF):
+		r.err = err
+	}
+	return err
+}
+
+func (r *rows) Close() error {
+	err := r.Rows.Close()
+	r.opts.observe(r.ctx, "query", r.query, r.start, r.n, r.err)
+	return err
+}
+
+func (r *rows) HasNextResultSet() bool {
+	if n, ok := r.Rows.(driver.RowsNextResultSet); ok {
+		return n.HasNextResultSet()
+	}
+	return false
+}
+
+func (r *rows) NextResultSet() error {
+	if n, ok := r.Rows.(driver.RowsNextResultSet); ok {
+		return n.NextResultSet()
+	}
+	return io.EOF
+}
+
+func (r *rows) ColumnTypeScanType(index int) reflect.Type {
+	if t, ok := r.Rows.(driver.RowsColumnTypeScanType); ok {
+		return t.ColumnTypeScanType(index)
+	}
+	return reflect.TypeFor[any]()
+}
+
+func (r *rows) ColumnTypeDatabaseTypeName(index int) string {
+	if t, ok := r.Rows.(driver.RowsColumnTypeDatabaseTypeName); ok {
+		return t.ColumnTypeDatabaseTypeName(index)
+	}
+	return ""
+}
+
+func (r *rows) ColumnTypeLength(index int) (int64, bool) {
+	if t, ok := r.Rows.(driver.RowsColumnTypeLength); ok {
+		return t.ColumnTypeLength(index)
+	}
+	return 0, false
+}
+
+func (r *rows) ColumnTypeNullable(index int) (bool, bool) {
+	if t, ok := r.Rows.(driver.RowsColumnTypeNullable); ok {
+		return t.ColumnTypeNullable(index)
+	}
+	return false, false
+}
+
+func (r *rows) ColumnTypePrecisionScale(index int) (int64, int64, bool) {
+	if t, ok := r.Rows.(driver.RowsColumnTypePrecisionScale); ok {
+		return t.ColumnTypePrecisionScale(index)
+	}
+	return 0, 0, false
+}
+
+// rowsAffected returns the affected row count, or -1 if unknown.
+func rowsAffected(res driver.Result, err error) int64 {
+	if err != nil || res == nil {
+		return -1
+	}
+	n, err := res.RowsAffected()
+	if err != nil {
+		return -1
+	}
+	return n
+}
+
+// values converts named arguments for drivers without context support.
+func values(args []driver.NamedValue) []driver.Value {
+	v := make([]driver.Value, len(args))
+	for i, a := range args {
+		v[i] = a.Value
+	}
+	return v
+}
@@ -0,0 +1,96 @@
+// Package sqltrace instruments database/sql drivers. Wrap a driver.Connector
+// and every statement is recorded as an event on the span in the query
+// context, and statements slower than the configured threshold are logged:
+//
+//	db := sql.OpenDB(sqltrace.Wrap(connector, sqltrace.Options{SlowThreshold: 200 * time.Millisecond}))
+//
+// Statements are sanitized before they leave the process: string and
+// numeric literals are replaced by "?" and bind arguments are never recorded.
+package sqltrace
+
+import (
+	"context"
+	"database/sql/driver"
+	"regexp"
+	"strings"
+	"time"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+
+	"github.com/blackhorseya/go-ddd/pkg/contextx"
+)
+
+// Options configures the hooks.
+type Options struct {
+	// SlowThreshold logs statements taking at least this long; 0 disables logging.
+	SlowThreshold time.Duration
+}
+
+// Wrap returns a connector whose connections run the hooks around every
+// statement executed through c.
+func Wrap(c driver.Connector, opts Options) driver.Connector {
+	return &connector{Connector: c, opts: opts}
+}
+
+// ============================================================================
+// Hooks (慢查詢日誌與 Span 事件)
+// ============================================================================
+
+// queryEvent is the span event name recorded for each statement.
+const queryEvent = "db.query"
+
+// observe records a finished statement. rows is -1 when unknown.
+func (o Options) observe(ctx context.Context, op, query string, start time.Time, rows int64, err error) {
+	duration := time.Since(start)
+	span := trace.SpanFromContext(ctx)
+	slow := o.SlowThreshold > 0 && duration >= o.SlowThreshold
+	if !span.IsRecording() && !slow {
+		return
+	}
+
+	statement := Sanitize(query)
+	if span.IsRecording() {
+		attrs := []attribute.KeyValue{
+			attribute.String("db.operation", op),
+			attribute.String("db.statement", statement),
+			attribute.Int64("db.duration_ms", duration.Milliseconds()),
+			attribute.Bool("db.slow", slow),
+		}
+		if rows >= 0 {
+			attrs = append(attrs, attribute.Int64("db.rows", rows))
+		}
+		if err != nil {
+			attrs = append(attrs, attribute.String("error", err.Error()))
+		}
+		span.AddEvent(queryEvent, trace.WithAttributes(attrs...))
+	}
+
+	if slow {
+		args := []any{"operation", op, "statement", statement, "duration", duration.String(), "rows", rows}
+		if err != nil {
+			args = append(args, "error", err)
+		}
+		contextx.From(ctx).Warn("slow query", args...)
+	}
+}
+
+var (
+	stringLiteral  = regexp.MustCompile(`'(?:[^']|'')*'`)
+	numericLiteral = regexp.MustCompile(`\$?\b\d+(?:\.\d+)?\b`)
+	whitespace     = regexp.MustCompile(`\s+`)
+)
+
+// Sanitize replaces string and numeric literals in query with "?" and
+// collapses whitespace, so statements can be logged without leaking data.
+// Placeholders such as $1 are kept.
+func Sanitize(query string) string {
+	s := stringLiteral.ReplaceAllString(query, "?")
+	s = numericLiteral.ReplaceAllStringFunc(s, func(m string) string {
+		if strings.HasPrefix(m, "$") {
+			return m
+		}
+		return "?"
+	})
+	return strings.TrimSpace(whitespace.ReplaceAllString(s, " "))
+}
@@ -0,0 +1,164 @@
+package sqltrace
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"sync"
+	"testing"
+	"time"
+
+	_ "github.com/glebarez/go-sqlite"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+
+	"github.com/blackhorseya/go-ddd/pkg/contextx"
+)
+
+// dsnConnector adapts a driver and DSN to driver.Connector.
+type dsnConnector struct {
+	drv driver.Driver
+	dsn string
+}
+
+func (c dsnConnector) Connect(context.Context) (driver.Conn, error) { return c.drv.Open(c.dsn) }
+func (c dsnConnector) Driver() driver.Driver                        { return c.drv }
+
+// recordingLogger captures warnings.
+type recordingLogger struct {
+	mu    sync.Mutex
+	warns []string
+}
+
+func (l *recordingLogger) Debug(string, ...any) {}
+func (l *recordingLogger) Info(string, ...any)  {}
+func (l *recordingLogger) Error(string, ...any) {}
+func (l *recordingLogger) Warn(msg string, _ ...any) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.warns = append(l.warns, msg)
+}
+
+func openDB(t *testing.T, opts Options) *sql.DB {
+	t.Helper()
+	base, err := sql.Open("sqlite", ":memory:")
+	if err != nil {
+		t.Fatalf("sql.Open() error = %v", err)
+	}
+	drv := base.Driver()
+	_ = base.Close()
+
+	db := sql.OpenDB(Wrap(dsnConnector{drv: drv, dsn: ":memory:"}, opts))
+	db.SetMaxOpenConns(1)
+	t.Cleanup(func() { _ = db.Close() })
+	return db
+}
+
+func TestSanitize(t *testing.T) {
+	tests := []struct {
+		name  string
+		query string
+		want  string
+	}{
+		{"string literal", "SELECT * FROM users WHERE email = 'a@b.c'", "SELECT * FROM users WHERE email = ?"},
+		{"escaped quote", "UPDATE t SET name = 'O''Brien'", "UPDATE t SET name = ?"},
+		{"numbers", "SELECT * FROM t LIMIT 10 OFFSET 2.5", "SELECT * FROM t LIMIT ? OFFSET ?"},
+		{"placeholders kept", "SELECT * FROM t2 WHERE id = $1 AND v = ?", "SELECT * FROM t2 WHERE id = $1 AND v = ?"},
+		{"whitespace", "SELECT\n\t  id\nFROM t", "SELECT id FROM t"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Act
+			got := Sanitize(tt.query)
+
+			// Assert
+			if got != tt.want {
+				t.Errorf("Sanitize() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestWrap_SpanEvents(t *testing.T) {
+	// Arrange
+	db := openDB(t, Options{})
+	recorder := tracetest.NewSpanRecorder()
+	provider := sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder))
+	ctx, span := provider.Tracer("test").Start(context.Background(), "op")
+
+	// Act
+	if _, err := db.ExecContext(ctx, `CREATE TABLE t (id INT, name TEXT)`); err != nil {
+		t.Fatalf("create error = %v", err)
+	}
+	if _, err := db.ExecContext(ctx, `INSERT INTO t VALUES (1, 'secret'), (2, 'other')`); err != nil {
+		t.Fatalf("insert error = %v", err)
+	}
+	var names []string
+	rows, err := db.QueryContext(ctx, `SELECT name FROM t ORDER BY id`)
+	if err != nil {
+		t.Fatalf("query error = %v", err)
+	}
+	for rows.Next() {
+		var n string
+		_ = rows.Scan(&n)
+		names = append(names, n)
+	}
+	_ = rows.Close()
+	span.End()
+
+	// Assert
+	if len(names) != 2 {
+		t.Fatalf("names = %v, want 2 rows", names)
+	}
+	events := recorder.Ended()[0].Events()
+	if len(events) != 3 {
+		t.Fatalf("events = %d, want 3", len(events))
+	}
+	attrs := map[string]string{}
+	for _, kv := range events[1].Attributes {
+		attrs[string(kv.Key)] = kv.Value.Emit()
+	}
+	if attrs["db.statement"] != "INSERT INTO t VALUES (?, ?), (?, ?)" {
+		t.Errorf("db.statement = %q, want sanitized insert", attrs["db.statement"])
+	}
+	if attrs["db.rows"] != "2" {
+		t.Errorf("insert db.rows = %q, want 2", attrs["db.rows"])
+	}
+	for _, kv := range events[2].Attributes {
+		if kv.Key == "db.rows" && kv.Value.AsInt64() != 2 {
+			t.Errorf("query db.rows = %d, want 2", kv.Value.AsInt64())
+		}
+	}
+}
+
+func TestWrap_SlowQueryLog(t *testing.T) {
+	tests := []struct {
+		name      string
+		threshold time.Duration
+		wantWarns int
+	}{
+		{"disabled", 0, 0},
+		{"below threshold", time.Hour, 0},
+		{"above threshold", time.Nanosecond, 1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Arrange
+			db := openDB(t, Options{SlowThreshold: tt.threshold})
+			logger := &recordingLogger{}
+			ctx := contextx.WithLogger(context.Background(), logger)
+
+			// Act
+			if _, err := db.ExecContext(ctx, `CREATE TABLE t (id INT)`); err != nil {
+				t.Fatalf("exec error = %v", err)
+			}
+
+			// Assert
+			if len(logger.warns) != tt.wantWarns {
+				t.Errorf("warnings = %v, want %d", logger.warns, tt.wantWarns)
+			}
+		})
+	}
+}
@@ -0,0 +1,67 @@
+// Package policy implements the authz.Authorizer port with casbin.
+// Policies are loaded from a CSV file or from any casbin adapter, such as
+// a database adapter, and can be reloaded without a restart.
+package policy
+
+import (
+	"context"
+	_ "embed"
+	"fmt"
+
+	"github.com/casbin/casbin/v2"
+	"github.com/casbin/casbin/v2/model"
+	"github.com/casbin/casbin/v2/persist"
+	fileadapter "github.com/casbin/casbin/v2/persist/file-adapter"
+
+	"github.com/blackhorseya/go-ddd/internal/application/authz"
+)
+
+// DefaultModel is the RBAC model used when none is given.
+//
+//go:embed model.conf
+var DefaultModel string
+
+// Casbin is a casbin-backed authz.Authorizer. It is safe for concurrent use.
+type Casbin struct {
+	enforcer *casbin.SyncedEnforcer
+}
+
+var _ authz.Authorizer = (*Casbin)(nil)
+
+// NewCasbin creates an authorizer from a model definition and a policy
+// adapter. An empty modelText uses DefaultModel.
+func NewCasbin(modelText string, adapter persist.Adapter) (*Casbin, error) {
+	if modelText == "" {
+		modelText = DefaultModel
+	}
+
+	m, err := model.NewModelFromString(modelText)
+	if err != nil {
+		return nil, fmt.Errorf("parse casbin model: %w", err)
+	}
+
+	e, err := casbin.NewSyncedEnforcer(m, adapter)
+	if err != nil {
+		return nil, fmt.Errorf("create casbin enforcer: %w", err)
+	}
+	return &Casbin{enforcer: e}, nil
+}
+
+// NewCasbinFromFile creates an authorizer with DefaultModel and the
+// policies in a CSV file such as configs/policy.csv.
+func NewCasbinFromFile(policyPath string) (*Casbin, error) {
+	return NewCasbin("", fileadapter.NewAdapter(policyPath))
+}
+
+// Can reports whether subject may perform action on resource.
+func (c *Casbin) Can(_ context.Context, subject, action, resource string) (bool, error) {
+	return c.enforcer.Enforce(subject, resource, action)
+}
+
+// Reload reloads policies from the adapter.
+func (c *Casbin) Reload() error {
+	if err := c.enforcer.LoadPolicy(); err != nil {
+		return fmt.Errorf("reload casbin policy: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,94 @@
+package policy
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	stringadapter "github.com/casbin/casbin/v2/persist/string-adapter"
+)
+
+const testPolicy = `
+p, role:admin, *, *
+p, role:customer, orders/:id, read
+p, role:customer, *, orders.PlaceOrder
+g, alice, role:admin
+g, bob, role:customer
+`
+
+func TestCasbin_Can(t *testing.T) {
+	c, err := NewCasbin("", stringadapter.NewAdapter(testPolicy))
+	if err != nil {
+		t.Fatalf("NewCasbin() error = %v", err)
+	}
+
+	tests := []struct {
+		name     string
+		subject  string
+		action   string
+		resource string
+		want     bool
+	}{
+		{name: "admin any action", subject: "alice", action: "delete", resource: "orders/1", want: true},
+		{name: "customer reads order", subject: "bob", action: "read", resource: "orders/1", want: true},
+		{name: "customer cannot delete", subject: "bob", action: "delete", resource: "orders/1", want: false},
+		{name: "customer message permission", subject: "bob", action: "orders.PlaceOrder", resource: "*", want: true},
+		{name: "unknown subject", subject: "eve", action: "read", resource: "orders/1", want: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Act
+			got, err := c.Can(context.Background(), tt.subject, tt.action, tt.resource)
+
+			// Assert
+			if err != nil {
+				t.Fatalf("Can() error = %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("Can() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestCasbin_Reload(t *testing.T) {
+	// Arrange
+	path := filepath.Join(t.TempDir(), "policy.csv")
+	if err := os.WriteFile(path, []byte("p, bob, orders/:id, read\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	c, err := NewCasbinFromFile(path)
+	if err != nil {
+		t.Fatalf("NewCasbinFromFile() error = %v", err)
+	}
+
+	// Act
+	if err := os.WriteFile(path, []byte("p, bob, orders/:id, write\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	err = c.Reload()
+
+	// Assert
+	if err != nil {
+		t.Fatalf("Reload() error = %v", err)
+	}
+	if ok, _ := c.Can(context.Background(), "bob", "write", "orders/1"); !ok {
+		t.Error("Can(write) = false after reload, want true")
+	}
+	if ok, _ := c.Can(context.Background(), "bob", "read", "orders/1"); ok {
+		t.Error("Can(read) = true after reload, want false")
+	}
+}
+
+func TestNewCasbinFromFile_ExamplePolicy(t *testing.T) {
+	c, err := NewCasbinFromFile(filepath.Join("..", "..", "..", "configs", "policy.csv"))
+	if err != nil {
+		t.Fatalf("NewCasbinFromFile() error = %v", err)
+	}
+
+	if ok, _ := c.Can(context.Background(), "role:admin", "delete", "orders/1"); !ok {
+		t.Error("admin denied, want allowed")
+	}
+}
@@ -0,0 +1,52 @@
+package projection
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+
+	"github.com/blackhorseya/go-ddd/internal/infrastructure/outbox"
+	"github.com/blackhorseya/go-ddd/internal/infrastructure/persistence/sqldb"
+)
+
+// Checkpoints is the PostgreSQL CheckpointStore.
+// It joins the transaction bound to the context.
+type Checkpoints struct {
+	db *sql.DB
+}
+
+var _ CheckpointStore = (*Checkpoints)(nil)
+
+// NewCheckpoints creates a new Checkpoints store.
+func NewCheckpoints(db *sql.DB) *Checkpoints {
+	return &Checkpoints{db: db}
+}
+
+// Load returns the last applied position, or the zero Position if the
+// projection never ran.
+func (c *Checkpoints) Load(ctx context.Context, name string) (outbox.Position, error) {
+	const query = `SELECT tx_id, position FROM projection_checkpoints WHERE name = $1`
+
+	var position outbox.Position
+	err := sqldb.Conn(ctx, c.db).QueryRowContext(ctx, query, name).Scan(&position.TxID, &position.ID)
+	if errors.Is(err, sql.ErrNoRows) {
+		return outbox.Position{}, nil
+	}
+	if err != nil {
+		return outbox.Position{}, fmt.Errorf("load checkpoint %s: %w", name, err)
+	}
+	return position, nil
+}
+
+// Save stores the last applied position.
+func (c *Checkpoints) Save(ctx context.Context, name string, position outbox.Position) error {
+	const query = `INSERT INTO projection_checkpoints (name, tx_id, position) VALUES ($1, $2, $3)
+		ON CONFLICT (name) DO UPDATE
+		SET tx_id = EXCLUDED.tx_id, position = EXCLUDED.position, updated_at = now()`
+
+	if _, err := sqldb.Conn(ctx, c.db).ExecContext(ctx, query, name, position.TxID, position.ID); err != nil {
+		return fmt.Errorf("save checkpoint %s: %w", name, err)
+	}
+	return nil
+}
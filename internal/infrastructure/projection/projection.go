@@ -0,0 +1,224 @@
+// Package projection builds denormalized read models from domain events.
+// A Runner reads the event log (the outbox table) in commit order, applies
+// each event to every registered Projection, and checkpoints its position in
+// the same transaction, so read tables stay consistent with their checkpoint
+// and can be rebuilt from scratch at any time.
+//
+// Positions are outbox.Position values rather than message IDs: IDs are
+// allocated before commit, so an event with a lower ID can become visible
+// after a higher one was applied and an ID checkpoint would skip it.
+package projection
+
+import (
+	"context"
+	_ "embed"
+	"fmt"
+	"time"
+
+	"github.com/blackhorseya/go-ddd/internal/domain"
+	"github.com/blackhorseya/go-ddd/internal/infrastructure/outbox"
+	"github.com/blackhorseya/go-ddd/pkg/contextx"
+	"github.com/blackhorseya/go-ddd/pkg/runx"
+)
+
+// Schema is the DDL for the checkpoint table.
+//
+//go:embed schema.sql
+var Schema string
+
+// Default runner settings.
+const (
+	DefaultPollInterval = time.Second
+	DefaultBatchSize    = 100
+)
+
+// Projection applies domain events to a read model.
+// Handle must ignore events it does not care about. Both methods should
+// write through sqldb.Conn(ctx, db) so they join the runner's transaction.
+type Projection interface {
+	// Name identifies the projection and its checkpoint.
+	Name() string
+
+	// Handle applies one event to the read model.
+	Handle(ctx context.Context, msg outbox.Message) error
+
+	// Reset clears the read model before a rebuild.
+	Reset(ctx context.Context) error
+}
+
+// EventSource reads the event log in Position order. It must only return
+// events no later commit can sort before.
+type EventSource interface {
+	FetchAfter(ctx context.Context, after outbox.Position, limit int) ([]outbox.Message, error)
+}
+
+// CheckpointStore persists the last applied position of each projection.
+type CheckpointStore interface {
+	Load(ctx context.Context, name string) (outbox.Position, error)
+	Save(ctx context.Context, name string, position outbox.Position) error
+}
+
+// RunnerConfig configures the Runner.
+type RunnerConfig struct {
+	// PollInterval is the delay between polls once projections are caught up.
+	PollInterval time.Duration
+
+	// BatchSize is the maximum number of events fetched per projection per poll.
+	BatchSize int
+}
+
+// Runner keeps projections up to date with the event log.
+type Runner struct {
+	source      EventSource
+	checkpoints CheckpointStore
+	uow         domain.UnitOfWork
+	projections []Projection
+	cfg         RunnerConfig
+	started     runx.Signal
+}
+
+// NewRunner creates a Runner, applying defaults for zero config values.
+func NewRunner(
+	source EventSource,
+	checkpoints CheckpointStore,
+	uow domain.UnitOfWork,
+	cfg RunnerConfig,
+	projections ...Projection,
+) *Runner {
+	if cfg.PollInterval <= 0 {
+		cfg.PollInterval = DefaultPollInterval
+	}
+	if cfg.BatchSize <= 0 {
+		cfg.BatchSize = DefaultBatchSize
+	}
+	return &Runner{
+		source:      source,
+		checkpoints: checkpoints,
+		uow:         uow,
+		projections: projections,
+		cfg:         cfg,
+	}
+}
+
+// Started returns a channel closed after the first successful pass over
+// the projections.
+func (r *Runner) Started() <-chan struct{} {
+	return r.started.Done()
+}
+
+// Run applies new events until ctx is cancelled.
+func (r *Runner) Run(ctx context.Context) error {
+	logger := contextx.From(ctx)
+	logger.Info("projection runner started", "projections", len(r.projections))
+
+	ticker := time.NewTicker(r.cfg.PollInterval)
+	defer ticker.Stop()
+
+	for {
+		applied, err := r.RunOnce(ctx)
+		if err != nil {
+			logger.Error("projection failed", "error", err)
+		} else {
+			r.started.Fire()
+		}
+
+		// Keep catching up while full batches are applied.
+		if err == nil && applied > 0 && ctx.Err() == nil {
+			continue
+		}
+
+		select {
+		case <-ctx.Done():
+			logger.Info("projection runner stopped")
+			return nil
+		case <-ticker.C:
+		}
+	}
+}
+
+// RunOnce applies at most one batch to each projection and returns the
+// number of full batches applied, which is non-zero while any projection is
+// still catching up. Projections are independent: one failing does not
+// block the others, and the first error is returned.
+func (r *Runner) RunOnce(ctx context.Context) (int, error) {
+	var (
+		full     int
+		firstErr error
+	)
+	for _, p := range r.projections {
+		n, err := r.apply(ctx, p)
+		if err != nil && firstErr == nil {
+			firstErr = err
+		}
+		if n == r.cfg.BatchSize {
+			full++
+		}
+	}
+	return full, firstErr
+}
+
+// Rebuild resets a projection and replays the whole event log into it.
+// Stop the runner (or this projection) while rebuilding.
+func (r *Runner) Rebuild(ctx context.Context, name string) error {
+	p, err := r.projection(name)
+	if err != nil {
+		return err
+	}
+
+	err = r.uow.Do(ctx, func(ctx context.Context) error {
+		if err := p.Reset(ctx); err != nil {
+			return fmt.Errorf("reset projection %s: %w", name, err)
+		}
+		return r.checkpoints.Save(ctx, name, outbox.Position{})
+	})
+	if err != nil {
+		return err
+	}
+
+	for {
+		n, err := r.apply(ctx, p)
+		if err != nil {
+			return err
+		}
+		if n < r.cfg.BatchSize {
+			return nil
+		}
+	}
+}
+
+// apply applies the next batch of events to p, checkpointing after each
+// event in the same transaction, and returns the number of events applied.
+func (r *Runner) apply(ctx context.Context, p Projection) (int, error) {
+	position, err := r.checkpoints.Load(ctx, p.Name())
+	if err != nil {
+		return 0, fmt.Errorf("load checkpoint %s: %w", p.Name(), err)
+	}
+
+	msgs, err := r.source.FetchAfter(ctx, position, r.cfg.BatchSize)
+	if err != nil {
+		return 0, err
+	}
+
+	for i, msg := range msgs {
+		err := r.uow.Do(ctx, func(ctx context.Context) error {
+			if err := p.Handle(ctx, msg); err != nil {
+				return fmt.Errorf("projection %s: handle %s (id %d): %w", p.Name(), msg.EventName, msg.ID, err)
+			}
+			return r.checkpoints.Save(ctx, p.Name(), msg.Position())
+		})
+		if err != nil {
+			return i, err
+		}
+	}
+	return len(msgs), nil
+}
+
+// projection returns the registered projection with the given name.
+func (r *Runner) projection(name string) (Projection, error) {
+	for _, p := range r.projections {
+		if p.Name() == name {
+			return p, nil
+		}
+	}
+	return nil, fmt.Errorf("projection %s is not registered", name)
+}
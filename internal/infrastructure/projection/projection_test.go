@@ -0,0 +1,224 @@
+package projection
+
+import (
+	"context"
+	"errors"
+	"sort"
+	"testing"
+
+	"github.com/blackhorseya/go-ddd/internal/infrastructure/outbox"
+)
+
+// fakeSource is an in-memory event log holding committed messages.
+type fakeSource struct {
+	msgs []outbox.Message
+}
+
+func (s *fakeSource) FetchAfter(_ context.Context, after outbox.Position, limit int) ([]outbox.Message, error) {
+	msgs := append([]outbox.Message(nil), s.msgs...)
+	sort.Slice(msgs, func(i, j int) bool { return less(msgs[i].Position(), msgs[j].Position()) })
+
+	var out []outbox.Message
+	for _, m := range msgs {
+		if less(after, m.Position()) && len(out) < limit {
+			out = append(out, m)
+		}
+	}
+	return out, nil
+}
+
+// less orders positions by transaction, then by message ID.
+func less(a, b outbox.Position) bool {
+	if a.TxID != b.TxID {
+		return a.TxID < b.TxID
+	}
+	return a.ID < b.ID
+}
+
+// fakeCheckpoints is an in-memory CheckpointStore.
+type fakeCheckpoints map[string]outbox.Position
+
+func (c fakeCheckpoints) Load(_ context.Context, name string) (outbox.Position, error) {
+	return c[name], nil
+}
+
+func (c fakeCheckpoints) Save(_ context.Context, name string, position outbox.Position) error {
+	c[name] = position
+	return nil
+}
+
+// fakeUoW runs fn without a transaction.
+type fakeUoW struct{}
+
+func (fakeUoW) Do(ctx context.Context, fn func(ctx context.Context) error) error {
+	return fn(ctx)
+}
+
+// countProjection counts events per name and fails on the configured ID.
+type countProjection struct {
+	name   string
+	failID int64
+	counts map[string]int
+	resets int
+}
+
+func (p *countProjection) Name() string { return p.name }
+
+func (p *countProjection) Handle(_ context.Context, msg outbox.Message) error {
+	if msg.ID == p.failID {
+		return errors.New("read table unavailable")
+	}
+	if p.counts == nil {
+		p.counts = make(map[string]int)
+	}
+	p.counts[msg.EventName]++
+	return nil
+}
+
+func (p *countProjection) Reset(_ context.Context) error {
+	p.counts = nil
+	p.resets++
+	return nil
+}
+
+func newSource(n int) *fakeSource {
+	s := &fakeSource{}
+	for i := 1; i <= n; i++ {
+		s.msgs = append(s.msgs, outbox.Message{ID: int64(i), TxID: int64(i), EventName: "order.created"})
+	}
+	return s
+}
+
+func TestRunner_RunOnce(t *testing.T) {
+	tests := []struct {
+		name           string
+		failID         int64
+		wantErr        bool
+		wantCount      int
+		wantCheckpoint outbox.Position
+	}{
+		{
+			name:           "applies batch and checkpoints",
+			wantCount:      2,
+			wantCheckpoint: outbox.Position{TxID: 2, ID: 2},
+		},
+		{
+			name:           "stops at failing event",
+			failID:         2,
+			wantErr:        true,
+			wantCount:      1,
+			wantCheckpoint: outbox.Position{TxID: 1, ID: 1},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Arrange
+			p := &countProjection{name: "orders", failID: tt.failID}
+			checkpoints := fakeCheckpoints{}
+			r := NewRunner(newSource(3), checkpoints, fakeUoW{}, RunnerConfig{BatchSize: 2}, p)
+
+			// Act
+			_, err := r.RunOnce(context.Background())
+
+			// Assert
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("RunOnce() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if got := p.counts["order.created"]; got != tt.wantCount {
+				t.Errorf("handled = %d, want %d", got, tt.wantCount)
+			}
+			if got := checkpoints["orders"]; got != tt.wantCheckpoint {
+				t.Errorf("checkpoint = %+v, want %+v", got, tt.wantCheckpoint)
+			}
+		})
+	}
+}
+
+func TestRunner_RunOnce_ResumesFromCheckpoint(t *testing.T) {
+	// Arrange
+	p := &countProjection{name: "orders"}
+	checkpoints := fakeCheckpoints{"orders": {TxID: 2, ID: 2}}
+	r := NewRunner(newSource(3), checkpoints, fakeUoW{}, RunnerConfig{}, p)
+
+	// Act
+	full, err := r.RunOnce(context.Background())
+
+	// Assert
+	if err != nil {
+		t.Fatalf("RunOnce() error = %v", err)
+	}
+	if full != 0 {
+		t.Errorf("full batches = %d, want 0", full)
+	}
+	if got := p.counts["order.created"]; got != 1 {
+		t.Errorf("handled = %d, want 1", got)
+	}
+	if got := checkpoints["orders"]; got != (outbox.Position{TxID: 3, ID: 3}) {
+		t.Errorf("checkpoint = %+v, want {TxID:3 ID:3}", got)
+	}
+}
+
+func TestRunner_RunOnce_AppliesLateCommit(t *testing.T) {
+	// Arrange: message 4 took its ID first but its transaction commits
+	// after message 10 was applied.
+	p := &countProjection{name: "orders"}
+	source := &fakeSource{msgs: []outbox.Message{{ID: 10, TxID: 5, EventName: "order.created"}}}
+	checkpoints := fakeCheckpoints{}
+	r := NewRunner(source, checkpoints, fakeUoW{}, RunnerConfig{}, p)
+	if _, err := r.RunOnce(context.Background()); err != nil {
+		t.Fatalf("RunOnce() error = %v", err)
+	}
+	source.msgs = append(source.msgs, outbox.Message{ID: 4, TxID: 7, EventName: "order.paid"})
+
+	// Act
+	_, err := r.RunOnce(context.Background())
+
+	// Assert
+	if err != nil {
+		t.Fatalf("RunOnce() error = %v", err)
+	}
+	if got := p.counts["order.paid"]; got != 1 {
+		t.Errorf("late event handled = %d, want 1", got)
+	}
+	if got := checkpoints["orders"]; got != (outbox.Position{TxID: 7, ID: 4}) {
+		t.Errorf("checkpoint = %+v, want {TxID:7 ID:4}", got)
+	}
+}
+
+func TestRunner_Rebuild(t *testing.T) {
+	// Arrange
+	p := &countProjection{name: "orders", counts: map[string]int{"order.created": 99}}
+	checkpoints := fakeCheckpoints{"orders": {TxID: 5, ID: 5}}
+	r := NewRunner(newSource(5), checkpoints, fakeUoW{}, RunnerConfig{BatchSize: 2}, p)
+
+	// Act
+	err := r.Rebuild(context.Background(), "orders")
+
+	// Assert
+	if err != nil {
+		t.Fatalf("Rebuild() error = %v", err)
+	}
+	if p.resets != 1 {
+		t.Errorf("resets = %d, want 1", p.resets)
+	}
+	if got := p.counts["order.created"]; got != 5 {
+		t.Errorf("handled = %d, want 5", got)
+	}
+	if got := checkpoints["orders"]; got != (outbox.Position{TxID: 5, ID: 5}) {
+		t.Errorf("checkpoint = %+v, want {TxID:5 ID:5}", got)
+	}
+}
+
+func TestRunner_Rebuild_UnknownProjection(t *testing.T) {
+	// Arrange
+	r := NewRunner(newSource(1), fakeCheckpoints{}, fakeUoW{}, RunnerConfig{})
+
+	// Act
+	err := r.Rebuild(context.Background(), "missing")
+
+	// Assert
+	if err == nil {
+		t.Fatal("Rebuild() error = nil, want error")
+	}
+}
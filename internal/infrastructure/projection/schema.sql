@@ -0,0 +1,9 @@
+-- Projection checkpoints (PostgreSQL).
+-- Each projection stores the position (transaction and message ID) of the
+-- last outbox message it applied.
+CREATE TABLE IF NOT EXISTS projection_checkpoints (
+    name       TEXT        PRIMARY KEY,
+    tx_id      BIGINT      NOT NULL DEFAULT 0,
+    position   BIGINT      NOT NULL,
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT now()
+);
@@ -0,0 +1,129 @@
+// Package sagastore provides the PostgreSQL saga.Store.
+package sagastore
+
+import (
+	"context"
+	"database/sql"
+	_ "embed"
+	"errors"
+	"fmt"
+
+	"github.com/blackhorseya/go-ddd/internal/application/saga"
+)
+
+// Schema is the DDL for the saga instance table.
+//
+//go:embed schema.sql
+var Schema string
+
+// Store is the PostgreSQL saga.Store. Saga progress is saved outside the
+// steps' own transactions so it survives their rollback.
+type Store struct {
+	db *sql.DB
+}
+
+var _ saga.Store = (*Store)(nil)
+
+// NewStore creates a new Store.
+func NewStore(db *sql.DB) *Store {
+	return &Store{db: db}
+}
+
+// Create inserts a new instance, returning saga.ErrInstanceExists if the ID is taken.
+func (s *Store) Create(ctx context.Context, inst saga.Instance) error {
+	const query = `INSERT INTO saga_instances (id, saga, status, step, data, error, version, updated_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
+		ON CONFLICT (id) DO NOTHING`
+
+	res, err := s.db.ExecContext(ctx, query,
+		inst.ID, inst.Saga, inst.Status, inst.Step, inst.Data, inst.Error, inst.Version, inst.UpdatedAt)
+	if err != nil {
+		return fmt.Errorf("insert saga instance %s: %w", inst.ID, err)
+	}
+	return expectOne(res, saga.ErrInstanceExists)
+}
+
+// Save updates an existing instance if its version matches, returning
+// saga.ErrStaleInstance otherwise.
+func (s *Store) Save(ctx context.Context, inst saga.Instance) error {
+	const query = `UPDATE saga_instances
+		SET status = $2, step = $3, data = $4, error = $5, updated_at = $6, version = version + 1
+		WHERE id = $1 AND version = $7`
+
+	res, err := s.db.ExecContext(ctx, query,
+		inst.ID, inst.Status, inst.Step, inst.Data, inst.Error, inst.UpdatedAt, inst.Version)
+	if err != nil {
+		return fmt.Errorf("update saga instance %s: %w", inst.ID, err)
+	}
+	if err := expectOne(res, saga.ErrStaleInstance); !errors.Is(err, saga.ErrStaleInstance) {
+		return err
+	}
+
+	// Tell a missing instance from a concurrent update.
+	if _, err := s.Load(ctx, inst.ID); err != nil {
+		return err
+	}
+	return saga.ErrStaleInstance
+}
+
+// Load returns the instance with the given ID.
+func (s *Store) Load(ctx context.Context, id string) (saga.Instance, error) {
+	const query = `SELECT id, saga, status, step, data, error, version, updated_at
+		FROM saga_instances WHERE id = $1`
+
+	inst, err := scanInstance(s.db.QueryRowContext(ctx, query, id))
+	if errors.Is(err, sql.ErrNoRows) {
+		return saga.Instance{}, saga.ErrInstanceNotFound
+	}
+	if err != nil {
+		return saga.Instance{}, fmt.Errorf("load saga instance %s: %w", id, err)
+	}
+	return inst, nil
+}
+
+// ListActive returns running and compensating instances ordered by ID.
+func (s *Store) ListActive(ctx context.Context) ([]saga.Instance, error) {
+	const query = `SELECT id, saga, status, step, data, error, version, updated_at
+		FROM saga_instances
+		WHERE status IN ($1, $2)
+		ORDER BY id`
+
+	rows, err := s.db.QueryContext(ctx, query, saga.StatusRunning, saga.StatusCompensating)
+	if err != nil {
+		return nil, fmt.Errorf("query active saga instances: %w", err)
+	}
+	defer rows.Close()
+
+	var insts []saga.Instance
+	for rows.Next() {
+		inst, err := scanInstance(rows)
+		if err != nil {
+			return nil, fmt.Errorf("scan saga instance: %w", err)
+		}
+		insts = append(insts, inst)
+	}
+	return insts, rows.Err()
+}
+
+// scanner is implemented by *sql.Row and *sql.Rows.
+type scanner interface {
+	Scan(dest ...any) error
+}
+
+func scanInstance(row scanner) (saga.Instance, error) {
+	var inst saga.Instance
+	err := row.Scan(&inst.ID, &inst.Saga, &inst.Status, &inst.Step, &inst.Data, &inst.Error, &inst.Version, &inst.UpdatedAt)
+	return inst, err
+}
+
+// expectOne returns errNone if res affected no rows.
+func expectOne(res sql.Result, errNone error) error {
+	n, err := res.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if n == 0 {
+		return errNone
+	}
+	return nil
+}
@@ -0,0 +1,232 @@
+// Package scheduler runs recurring background jobs on cron schedules.
+// Jobs are registered in code by name and scheduled from configuration, so
+// schedules change without a rebuild. A job never overlaps with itself:
+// locally a run is skipped while the previous one is still going, and
+// across instances a distributed lock ensures a single run per tick.
+//
+// Every instance may run the scheduler: the per-tick lock, keyed by job
+// name and scheduled time and held for LockTTL, makes an instance whose
+// clock lags fire a tick that already ran as a no-op. To keep the cron
+// loop on a single replica instead, wrap Run in runx.Leader as the worker
+// does with worker.leader_election; the locks still guard the handover,
+// when the old and new leader may both see the same tick.
+package scheduler
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/robfig/cron/v3"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+
+	"github.com/blackhorseya/go-ddd/pkg/contextx"
+	"github.com/blackhorseya/go-ddd/pkg/runx"
+)
+
+// instrumentationName is the OpenTelemetry tracer name.
+const instrumentationName = "github.com/blackhorseya/go-ddd/internal/infrastructure/scheduler"
+
+// DefaultLockTTL bounds how long a distributed job lock is held.
+const DefaultLockTTL = 5 * time.Minute
+
+// JobFunc is the work performed by a job.
+type JobFunc func(ctx context.Context) error
+
+// Locker acquires distributed locks, e.g. *lockx.Locker.
+type Locker interface {
+	TryLock(ctx context.Context, key string, ttl time.Duration) (unlock func(), ok bool, err error)
+}
+
+// Config configures the Scheduler.
+type Config struct {
+	// Schedules maps job names to cron expressions such as "*/5 * * * *"
+	// or "@every 1m". Registered jobs without a schedule are disabled.
+	Schedules map[string]string
+
+	// LockTTL is how long a tick stays claimed after it fired; it should
+	// exceed the clock skew between instances. Default: 5m
+	LockTTL time.Duration
+}
+
+// job is a registered job.
+type job struct {
+	name    string
+	fn      JobFunc
+	running atomic.Bool
+}
+
+// Scheduler runs registered jobs on their configured schedules.
+type Scheduler struct {
+	cfg     Config
+	locker  Locker
+	now     func() time.Time
+	started runx.Signal
+
+	mu   sync.Mutex
+	jobs []*job
+}
+
+// New creates a Scheduler. A nil locker disables distributed locking,
+// which is only safe when a single instance runs the scheduler.
+func New(cfg Config, locker Locker) *Scheduler {
+	if cfg.LockTTL <= 0 {
+		cfg.LockTTL = DefaultLockTTL
+	}
+	return &Scheduler{cfg: cfg, locker: locker, now: time.Now}
+}
+
+// Register adds a job under name.
+func (s *Scheduler) Register(name string, fn JobFunc) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.jobs = append(s.jobs, &job{name: name, fn: fn})
+}
+
+// Started returns a channel closed once Run has scheduled the jobs.
+func (s *Scheduler) Started() <-chan struct{} {
+	return s.started.Done()
+}
+
+// Run schedules the registered jobs and blocks until ctx is cancelled,
+// then waits for running jobs to finish.
+func (s *Scheduler) Run(ctx context.Context) error {
+	logger := contextx.From(ctx)
+
+	parser := cron.NewParser(
+		cron.Minute | cron.Hour | cron.Dom | cron.Month | cron.Dow | cron.Descriptor,
+	)
+	c := cron.New()
+
+	s.mu.Lock()
+	for _, j := range s.jobs {
+		spec := s.cfg.Schedules[j.name]
+		if spec == "" {
+			logger.Info("job disabled, no schedule configured", "job", j.name)
+			continue
+		}
+		sched, err := parser.Parse(spec)
+		if err != nil {
+			s.mu.Unlock()
+			return fmt.Errorf("schedule job %s: %w", j.name, err)
+		}
+		// Cron expressions fire on whole minutes; "@every" intervals are
+		// aligned to the wall clock so all instances share their ticks.
+		period := time.Minute
+		if every, ok := sched.(cron.ConstantDelaySchedule); ok {
+			period = every.Delay
+			sched = alignedSchedule{every.Delay}
+		}
+		c.Schedule(sched, cron.FuncJob(func() { _ = s.runTick(ctx, j, period) }))
+		logger.Info("job scheduled", "job", j.name, "schedule", spec)
+	}
+	s.mu.Unlock()
+
+	c.Start()
+	s.started.Fire()
+	logger.Info("scheduler started")
+
+	<-ctx.Done()
+	<-c.Stop().Done()
+	logger.Info("scheduler stopped")
+	return nil
+}
+
+// RunJob runs the named job once, applying the same overlap prevention,
+// locking, tracing and logging as a scheduled run. It is useful for
+// triggering a job manually.
+func (s *Scheduler) RunJob(ctx context.Context, name string) error {
+	s.mu.Lock()
+	var found *job
+	for _, j := range s.jobs {
+		if j.name == name {
+			found = j
+		}
+	}
+	s.mu.Unlock()
+
+	if found == nil {
+		return fmt.Errorf("job %s is not registered", name)
+	}
+	return s.runJob(ctx, found, time.Time{})
+}
+
+// runTick runs j for the tick that just fired, the current time
+// truncated to the schedule's period.
+func (s *Scheduler) runTick(ctx context.Context, j *job, period time.Duration) error {
+	return s.runJob(ctx, j, s.now().Truncate(period))
+}
+
+// runJob executes one run of j for the scheduled tick, or a manual run
+// when tick is zero.
+func (s *Scheduler) runJob(ctx context.Context, j *job, tick time.Time) error {
+	logger := contextx.From(ctx).WithFields("job", j.name)
+
+	if !j.running.CompareAndSwap(false, true) {
+		logger.Warn("job skipped, previous run still in progress")
+		return nil
+	}
+	defer j.running.Store(false)
+
+	if s.locker != nil && !tick.IsZero() {
+		// The tick lock is not released when the job returns but LockTTL
+		// after the tick, so a lagging instance cannot run it again.
+		key := fmt.Sprintf("job:%s:%d", j.name, tick.Unix())
+		unlock, ok, err := s.locker.TryLock(ctx, key, s.cfg.LockTTL)
+		if err != nil {
+			logger.Error("job lock failed", "error", err)
+			return err
+		}
+		if !ok {
+			logger.Debug("job skipped, tick already claimed", "tick", tick)
+			return nil
+		}
+		time.AfterFunc(time.Until(tick.Add(s.cfg.LockTTL)), unlock)
+	}
+	if s.locker != nil {
+		unlock, ok, err := s.locker.TryLock(ctx, "job:"+j.name, s.cfg.LockTTL)
+		if err != nil {
+			logger.Error("job lock failed", "error", err)
+			return err
+		}
+		if !ok {
+			logger.Debug("job skipped, running on another instance")
+			return nil
+		}
+		defer unlock()
+	}
+
+	ctx = contextx.WithOperation(ctx, "job."+j.name)
+	ctx, span := otel.Tracer(instrumentationName).Start(ctx, "job "+j.name)
+	span.SetAttributes(attribute.String("job.name", j.name))
+	defer span.End()
+
+	start := time.Now()
+	err := j.fn(ctx)
+	logger = logger.WithFields("duration", time.Since(start).String())
+
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+		logger.Error("job failed", "error", err)
+		return err
+	}
+	logger.Info("job completed")
+	return nil
+}
+
+// alignedSchedule fires every interval on wall-clock multiples of it,
+// unlike cron's "@every", which counts from when the scheduler started.
+type alignedSchedule struct {
+	every time.Duration
+}
+
+// Next returns the first multiple of the interval after t.
+func (a alignedSchedule) Next(t time.Time) time.Time {
+	return t.Truncate(a.every).Add(a.every)
+}
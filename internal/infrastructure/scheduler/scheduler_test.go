@@ -0,0 +1,224 @@
+package scheduler
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+)
+
+// fakeLocker grants each key to one holder at a time.
+type fakeLocker struct {
+	mu   sync.Mutex
+	held map[string]bool
+}
+
+func (l *fakeLocker) TryLock(_ context.Context, key string, _ time.Duration) (func(), bool, error) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if l.held == nil {
+		l.held = make(map[string]bool)
+	}
+	if l.held[key] {
+		return nil, false, nil
+	}
+	l.held[key] = true
+	return func() {
+		l.mu.Lock()
+		defer l.mu.Unlock()
+		delete(l.held, key)
+	}, true, nil
+}
+
+func TestScheduler_RunJob(t *testing.T) {
+	tests := []struct {
+		name     string
+		lockHeld bool
+		jobErr   error
+		wantRuns int
+		wantErr  bool
+	}{
+		{name: "runs job", wantRuns: 1},
+		{name: "skips when locked elsewhere", lockHeld: true, wantRuns: 0},
+		{name: "returns job error", jobErr: errors.New("boom"), wantRuns: 1, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Arrange
+			locker := &fakeLocker{}
+			if tt.lockHeld {
+				_, _, _ = locker.TryLock(context.Background(), "job:cleanup", time.Minute)
+			}
+			runs := 0
+			s := New(Config{}, locker)
+			s.Register("cleanup", func(context.Context) error {
+				runs++
+				return tt.jobErr
+			})
+
+			// Act
+			err := s.RunJob(context.Background(), "cleanup")
+
+			// Assert
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("RunJob() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if runs != tt.wantRuns {
+				t.Errorf("runs = %d, want %d", runs, tt.wantRuns)
+			}
+		})
+	}
+}
+
+func TestScheduler_RunJob_NoOverlap(t *testing.T) {
+	// Arrange
+	started := make(chan struct{})
+	release := make(chan struct{})
+	runs := 0
+	s := New(Config{}, nil)
+	s.Register("report", func(context.Context) error {
+		runs++
+		close(started)
+		<-release
+		return nil
+	})
+
+	done := make(chan error)
+	go func() { done <- s.RunJob(context.Background(), "report") }()
+	<-started
+
+	// Act
+	err := s.RunJob(context.Background(), "report")
+	close(release)
+
+	// Assert
+	if err != nil {
+		t.Fatalf("overlapping RunJob() error = %v", err)
+	}
+	if err := <-done; err != nil {
+		t.Fatalf("first RunJob() error = %v", err)
+	}
+	if runs != 1 {
+		t.Errorf("runs = %d, want 1", runs)
+	}
+}
+
+func TestScheduler_SkewedTick(t *testing.T) {
+	// Arrange: two instances share a locker; the second one's clock lags,
+	// so it fires the 10:00 tick after the first has already finished it.
+	tick := time.Date(2024, 1, 2, 10, 0, 0, 0, time.UTC)
+	locker := &fakeLocker{}
+	runs := 0
+	newInstance := func(now time.Time) (*Scheduler, *job) {
+		s := New(Config{LockTTL: time.Hour}, locker)
+		s.now = func() time.Time { return now }
+		s.Register("cleanup", func(context.Context) error {
+			runs++
+			return nil
+		})
+		return s, s.jobs[0]
+	}
+	first, firstJob := newInstance(tick.Add(20 * time.Millisecond))
+	lagging, laggingJob := newInstance(tick.Add(3 * time.Second))
+
+	// Act
+	if err := first.runTick(context.Background(), firstJob, time.Minute); err != nil {
+		t.Fatalf("first runTick() error = %v", err)
+	}
+	if err := lagging.runTick(context.Background(), laggingJob, time.Minute); err != nil {
+		t.Fatalf("lagging runTick() error = %v", err)
+	}
+
+	// Assert
+	if runs != 1 {
+		t.Errorf("runs = %d, want 1 for a single tick", runs)
+	}
+
+	// The next tick runs again.
+	lagging.now = func() time.Time { return tick.Add(time.Minute + 3*time.Second) }
+	if err := lagging.runTick(context.Background(), laggingJob, time.Minute); err != nil {
+		t.Fatalf("next runTick() error = %v", err)
+	}
+	if runs != 2 {
+		t.Errorf("runs = %d, want 2 after the next tick", runs)
+	}
+}
+
+func TestAlignedSchedule_Next(t *testing.T) {
+	tests := []struct {
+		name  string
+		every time.Duration
+		from  time.Time
+		want  time.Time
+	}{
+		{
+			name:  "next multiple",
+			every: 5 * time.Minute,
+			from:  time.Date(2024, 1, 2, 10, 3, 20, 0, time.UTC),
+			want:  time.Date(2024, 1, 2, 10, 5, 0, 0, time.UTC),
+		},
+		{
+			name:  "on a multiple",
+			every: 5 * time.Minute,
+			from:  time.Date(2024, 1, 2, 10, 5, 0, 0, time.UTC),
+			want:  time.Date(2024, 1, 2, 10, 10, 0, 0, time.UTC),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Act
+			got := alignedSchedule{tt.every}.Next(tt.from)
+
+			// Assert
+			if !got.Equal(tt.want) {
+				t.Errorf("Next() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestScheduler_Run_InvalidSchedule(t *testing.T) {
+	// Arrange
+	s := New(Config{Schedules: map[string]string{"cleanup": "not a cron"}}, nil)
+	s.Register("cleanup", func(context.Context) error { return nil })
+
+	// Act
+	err := s.Run(context.Background())
+
+	// Assert
+	if err == nil {
+		t.Fatal("Run() error = nil, want error")
+	}
+}
+
+func TestScheduler_Run_StopsOnCancel(t *testing.T) {
+	// Arrange
+	ctx, cancel := context.WithCancel(context.Background())
+	s := New(Config{Schedules: map[string]string{"cleanup": "@every 1h"}}, nil)
+	s.Register("cleanup", func(context.Context) error { return nil })
+
+	done := make(chan error)
+	go func() { done <- s.Run(ctx) }()
+	select {
+	case <-s.Started():
+	case <-time.After(time.Second):
+		t.Fatal("Started() not closed after Run scheduled the jobs")
+	}
+
+	// Act
+	cancel()
+
+	// Assert
+	select {
+	case err := <-done:
+		if err != nil {
+			t.Fatalf("Run() error = %v", err)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("Run() did not stop")
+	}
+}
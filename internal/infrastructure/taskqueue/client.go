@@ -0,0 +1,58 @@
+package taskqueue
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hibiken/asynq"
+	goredis "github.com/redis/go-redis/v9"
+
+	"github.com/blackhorseya/go-ddd/internal/application/task"
+)
+
+// Client enqueues tasks on asynq.
+type Client struct {
+	client *asynq.Client
+}
+
+var _ task.Enqueuer = (*Client)(nil)
+
+// NewClient creates a Client on an existing Redis connection.
+func NewClient(redis goredis.UniversalClient) *Client {
+	return &Client{client: asynq.NewClientFromRedisClient(redis)}
+}
+
+// Enqueue submits a task, propagating contextx values and the trace context.
+func (c *Client) Enqueue(ctx context.Context, taskType string, payload []byte, opts task.Options) error {
+	t := asynq.NewTaskWithHeaders(taskType, payload, injectHeaders(ctx))
+	if _, err := c.client.EnqueueContext(ctx, t, options(opts)...); err != nil {
+		return fmt.Errorf("enqueue task %s: %w", taskType, err)
+	}
+	return nil
+}
+
+// Close closes the client. The shared Redis connection is not closed.
+func (c *Client) Close() error {
+	return c.client.Close()
+}
+
+// options converts task options to asynq options.
+func options(opts task.Options) []asynq.Option {
+	var out []asynq.Option
+	if opts.Queue != "" {
+		out = append(out, asynq.Queue(opts.Queue))
+	}
+	if opts.MaxRetry > 0 {
+		out = append(out, asynq.MaxRetry(opts.MaxRetry))
+	}
+	if opts.Timeout > 0 {
+		out = append(out, asynq.Timeout(opts.Timeout))
+	}
+	if opts.ProcessIn > 0 {
+		out = append(out, asynq.ProcessIn(opts.ProcessIn))
+	}
+	if opts.ID != "" {
+		out = append(out, asynq.TaskID(opts.ID))
+	}
+	return out
+}
@@ -0,0 +1,134 @@
+package taskqueue
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/hibiken/asynq"
+	goredis "github.com/redis/go-redis/v9"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+
+	"github.com/blackhorseya/go-ddd/internal/application/task"
+	"github.com/blackhorseya/go-ddd/pkg/contextx"
+	"github.com/blackhorseya/go-ddd/pkg/runx"
+)
+
+// instrumentationName is the OpenTelemetry tracer name.
+const instrumentationName = "github.com/blackhorseya/go-ddd/internal/infrastructure/taskqueue"
+
+// Config configures the Server.
+type Config struct {
+	// Concurrency is the number of tasks processed in parallel. Default: 10
+	Concurrency int
+
+	// Queues maps queue names to priorities. Default: {"default": 1}
+	Queues map[string]int
+
+	// RetryBaseDelay and RetryMaxDelay bound the exponential retry backoff.
+	// Defaults: 1s and 10m
+	RetryBaseDelay time.Duration
+	RetryMaxDelay  time.Duration
+
+	// ShutdownTimeout is how long running tasks may finish on shutdown.
+	ShutdownTimeout time.Duration
+}
+
+// Server processes tasks from asynq.
+type Server struct {
+	srv     *asynq.Server
+	mux     *asynq.ServeMux
+	started runx.Signal
+}
+
+var _ task.Registrar = (*Server)(nil)
+
+// NewServer creates a Server on an existing Redis connection.
+func NewServer(redis goredis.UniversalClient, cfg Config) *Server {
+	if cfg.Concurrency <= 0 {
+		cfg.Concurrency = DefaultConcurrency
+	}
+	if cfg.RetryBaseDelay <= 0 {
+		cfg.RetryBaseDelay = DefaultRetryBaseDelay
+	}
+	if cfg.RetryMaxDelay <= 0 {
+		cfg.RetryMaxDelay = DefaultRetryMaxDelay
+	}
+
+	backoff := Backoff(cfg.RetryBaseDelay, cfg.RetryMaxDelay)
+	srv := asynq.NewServerFromRedisClient(redis, asynq.Config{
+		Concurrency:     cfg.Concurrency,
+		Queues:          cfg.Queues,
+		ShutdownTimeout: cfg.ShutdownTimeout,
+		RetryDelayFunc: func(n int, _ error, _ *asynq.Task) time.Duration {
+			return backoff(n)
+		},
+		ErrorHandler: asynq.ErrorHandlerFunc(handleError),
+	})
+
+	return &Server{srv: srv, mux: asynq.NewServeMux()}
+}
+
+// HandleFunc registers h for tasks of taskType. The handler context carries
+// the enqueuer's contextx values and a span continuing its trace.
+func (s *Server) HandleFunc(taskType string, h task.HandlerFunc) {
+	s.mux.HandleFunc(taskType, func(ctx context.Context, t *asynq.Task) error {
+		ctx = extractHeaders(ctx, t.Headers())
+		ctx = contextx.WithOperation(ctx, "task."+taskType)
+
+		ctx, span := otel.Tracer(instrumentationName).Start(ctx, "task "+taskType,
+			trace.WithSpanKind(trace.SpanKindConsumer),
+			trace.WithAttributes(attribute.String("task.type", taskType)),
+		)
+		defer span.End()
+
+		err := h(ctx, t.Payload())
+		if err != nil {
+			span.RecordError(err)
+			span.SetStatus(codes.Error, err.Error())
+		}
+		if errors.Is(err, task.ErrSkipRetry) {
+			return fmt.Errorf("%w: %w", err, asynq.SkipRetry)
+		}
+		return err
+	})
+}
+
+// Started returns a channel closed once the server processes tasks.
+func (s *Server) Started() <-chan struct{} {
+	return s.started.Done()
+}
+
+// Run processes tasks until ctx is cancelled, then shuts down gracefully.
+func (s *Server) Run(ctx context.Context) error {
+	logger := contextx.From(ctx)
+
+	if err := s.srv.Start(s.mux); err != nil {
+		return fmt.Errorf("start task server: %w", err)
+	}
+	s.started.Fire()
+	logger.Info("task server started")
+
+	<-ctx.Done()
+	s.srv.Shutdown()
+	logger.Info("task server stopped")
+	return nil
+}
+
+// handleError logs task failures, flagging those moved to the dead-letter queue.
+func handleError(ctx context.Context, t *asynq.Task, err error) {
+	ctx = extractHeaders(ctx, t.Headers())
+	logger := contextx.From(ctx).WithFields("task", t.Type(), "error", err)
+
+	retried, _ := asynq.GetRetryCount(ctx)
+	maxRetry, _ := asynq.GetMaxRetry(ctx)
+	if retried >= maxRetry || errors.Is(err, asynq.SkipRetry) {
+		logger.Error("task dead-lettered", "retried", retried)
+		return
+	}
+	logger.Warn("task failed, will retry", "retried", retried, "max_retry", maxRetry)
+}
@@ -0,0 +1,48 @@
+// Package taskqueue implements the task port on asynq (Redis).
+// Tasks that exhaust their retries, or fail with task.ErrSkipRetry, are
+// archived by asynq, which serves as the dead-letter queue; inspect and
+// requeue them with the asynq CLI or web UI.
+package taskqueue
+
+import (
+	"context"
+	"time"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/propagation"
+
+	"github.com/blackhorseya/go-ddd/pkg/contextx"
+)
+
+// Default settings.
+const (
+	DefaultConcurrency    = 10
+	DefaultRetryBaseDelay = time.Second
+	DefaultRetryMaxDelay  = 10 * time.Minute
+)
+
+// injectHeaders copies contextx values and the trace context into headers.
+func injectHeaders(ctx context.Context) map[string]string {
+	headers := make(map[string]string)
+	contextx.InjectMap(ctx, headers)
+	otel.GetTextMapPropagator().Inject(ctx, propagation.MapCarrier(headers))
+	return headers
+}
+
+// extractHeaders restores the values written by injectHeaders into ctx.
+func extractHeaders(ctx context.Context, headers map[string]string) context.Context {
+	ctx = otel.GetTextMapPropagator().Extract(ctx, propagation.MapCarrier(headers))
+	return contextx.ExtractMap(ctx, headers)
+}
+
+// Backoff returns an exponential retry delay: base doubled per retry,
+// capped at maxDelay.
+func Backoff(base, maxDelay time.Duration) func(retried int) time.Duration {
+	return func(retried int) time.Duration {
+		d := base
+		for i := 0; i < retried && d < maxDelay; i++ {
+			d *= 2
+		}
+		return min(d, maxDelay)
+	}
+}
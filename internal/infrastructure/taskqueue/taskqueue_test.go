@@ -0,0 +1,78 @@
+package taskqueue
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/alicebob/miniredis/v2"
+	goredis "github.com/redis/go-redis/v9"
+
+	"github.com/blackhorseya/go-ddd/internal/application/task"
+	"github.com/blackhorseya/go-ddd/pkg/contextx"
+)
+
+func TestBackoff(t *testing.T) {
+	backoff := Backoff(time.Second, 10*time.Second)
+
+	tests := []struct {
+		retried int
+		want    time.Duration
+	}{
+		{retried: 0, want: time.Second},
+		{retried: 1, want: 2 * time.Second},
+		{retried: 3, want: 8 * time.Second},
+		{retried: 4, want: 10 * time.Second},
+		{retried: 50, want: 10 * time.Second},
+	}
+
+	for _, tt := range tests {
+		if got := backoff(tt.retried); got != tt.want {
+			t.Errorf("backoff(%d) = %v, want %v", tt.retried, got, tt.want)
+		}
+	}
+}
+
+func TestHeaders_RoundTrip(t *testing.T) {
+	// Arrange
+	ctx := contextx.WithRequestID(context.Background(), "req-1")
+	ctx = contextx.WithCorrelationID(ctx, "corr-1")
+	ctx = contextx.WithUserID(ctx, "user-1")
+
+	// Act
+	got := extractHeaders(context.Background(), injectHeaders(ctx))
+
+	// Assert
+	if v := contextx.GetRequestID(got); v != "req-1" {
+		t.Errorf("request ID = %q, want %q", v, "req-1")
+	}
+	if v := contextx.GetCorrelationID(got); v != "corr-1" {
+		t.Errorf("correlation ID = %q, want %q", v, "corr-1")
+	}
+	if v := contextx.GetUserID(got); v != "user-1" {
+		t.Errorf("user ID = %q, want %q", v, "user-1")
+	}
+}
+
+func TestClient_Enqueue(t *testing.T) {
+	// Arrange
+	mr := miniredis.RunT(t)
+	redis := goredis.NewClient(&goredis.Options{Addr: mr.Addr()})
+	t.Cleanup(func() { _ = redis.Close() })
+	c := NewClient(redis)
+
+	// Act
+	err := c.Enqueue(context.Background(), "email:receipt", []byte(`{}`), task.Options{Queue: "mail", ID: "t-1"})
+	dup := c.Enqueue(context.Background(), "email:receipt", []byte(`{}`), task.Options{Queue: "mail", ID: "t-1"})
+
+	// Assert
+	if err != nil {
+		t.Fatalf("Enqueue() error = %v", err)
+	}
+	if dup == nil {
+		t.Error("duplicate Enqueue() error = nil, want error")
+	}
+	if !mr.Exists("asynq:{mail}:t:t-1") {
+		t.Errorf("task not stored, keys = %v", mr.Keys())
+	}
+}
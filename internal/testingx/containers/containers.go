@@ -0,0 +1,200 @@
+// Package containers starts throwaway Postgres, Redis and Kafka containers
+// for integration tests with testcontainers-go.
+//
+// Each helper waits until the service accepts connections, registers the
+// container and its clients for cleanup, and returns configuration in the
+// shapes the application uses, so a test needs one line of setup:
+//
+//	env := containers.Postgres(t)
+//	repo := sqlc.NewOrderRepository(env.DB)
+//
+// Helpers skip the test when no Docker provider is available.
+package containers
+
+import (
+	"context"
+	"database/sql"
+	"net"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/docker/go-connections/nat"
+	goredis "github.com/redis/go-redis/v9"
+	kafkago "github.com/segmentio/kafka-go"
+	"github.com/testcontainers/testcontainers-go"
+	tckafka "github.com/testcontainers/testcontainers-go/modules/kafka"
+	tcpostgres "github.com/testcontainers/testcontainers-go/modules/postgres"
+	tcredis "github.com/testcontainers/testcontainers-go/modules/redis"
+
+	"github.com/blackhorseya/go-ddd/internal/infrastructure/config"
+	"github.com/blackhorseya/go-ddd/internal/infrastructure/messaging/kafka"
+	"github.com/blackhorseya/go-ddd/internal/infrastructure/persistence/migrations"
+	"github.com/blackhorseya/go-ddd/internal/infrastructure/persistence/postgres"
+	"github.com/blackhorseya/go-ddd/internal/infrastructure/persistence/redis"
+)
+
+// Images used by the helpers.
+const (
+	PostgresImage = "postgres:17"
+	RedisImage    = "redis:7-alpine"
+	KafkaImage    = "confluentinc/confluent-local:7.5.0"
+)
+
+// startTimeout bounds pulling, starting and waiting for a container.
+const startTimeout = 2 * time.Minute
+
+// PostgresEnv is a migrated Postgres database.
+type PostgresEnv struct {
+	Config config.Database
+	DB     *sql.DB
+}
+
+// Postgres starts a Postgres container, applies the embedded migrations
+// and returns an open pool to it.
+func Postgres(t *testing.T) PostgresEnv {
+	t.Helper()
+	testcontainers.SkipIfProviderIsNotHealthy(t)
+	ctx, cancel := context.WithTimeout(context.Background(), startTimeout)
+	defer cancel()
+
+	c, err := tcpostgres.Run(ctx, PostgresImage,
+		tcpostgres.WithDatabase("go_ddd_test"),
+		tcpostgres.WithUsername("test"),
+		tcpostgres.WithPassword("test"),
+		tcpostgres.BasicWaitStrategies(),
+	)
+	testcontainers.CleanupContainer(t, c)
+	if err != nil {
+		t.Fatalf("start postgres: %v", err)
+	}
+
+	host, port := endpoint(ctx, t, c, "5432/tcp")
+	cfg := config.Database{
+		Driver:   "postgres",
+		Host:     host,
+		Port:     port,
+		User:     "test",
+		Password: "test",
+		Name:     "go_ddd_test",
+		SSLMode:  "disable",
+	}
+
+	r, err := migrations.Open(cfg)
+	if err != nil {
+		t.Fatalf("open migrations: %v", err)
+	}
+	defer r.Close()
+	if err := r.Up(ctx); err != nil {
+		t.Fatalf("apply migrations: %v", err)
+	}
+
+	db, err := postgres.Connect(ctx, cfg)
+	if err != nil {
+		t.Fatalf("connect postgres: %v", err)
+	}
+	t.Cleanup(func() { _ = db.Close() })
+	return PostgresEnv{Config: cfg, DB: db}
+}
+
+// RedisEnv is an empty Redis server.
+type RedisEnv struct {
+	Config config.Redis
+	Client goredis.UniversalClient
+}
+
+// Redis starts a standalone Redis container and returns a client to it.
+func Redis(t *testing.T) RedisEnv {
+	t.Helper()
+	testcontainers.SkipIfProviderIsNotHealthy(t)
+	ctx, cancel := context.WithTimeout(context.Background(), startTimeout)
+	defer cancel()
+
+	c, err := tcredis.Run(ctx, RedisImage)
+	testcontainers.CleanupContainer(t, c)
+	if err != nil {
+		t.Fatalf("start redis: %v", err)
+	}
+
+	host, port := endpoint(ctx, t, c, "6379/tcp")
+	cfg := config.Redis{Mode: redis.ModeStandalone, Host: host, Port: port}
+	client, err := redis.Connect(ctx, cfg)
+	if err != nil {
+		t.Fatalf("connect redis: %v", err)
+	}
+	t.Cleanup(func() { _ = client.Close() })
+	return RedisEnv{Config: cfg, Client: client}
+}
+
+// KafkaEnv is a single-node Kafka cluster.
+type KafkaEnv struct {
+	Config kafka.Config
+}
+
+// Kafka starts a single-node KRaft Kafka container. Config uses a consumer
+// group unique to the test; create topics with CreateTopics before
+// consuming from them.
+func Kafka(t *testing.T) KafkaEnv {
+	t.Helper()
+	testcontainers.SkipIfProviderIsNotHealthy(t)
+	ctx, cancel := context.WithTimeout(context.Background(), startTimeout)
+	defer cancel()
+
+	c, err := tckafka.Run(ctx, KafkaImage, tckafka.WithClusterID("go-ddd-test"))
+	testcontainers.CleanupContainer(t, c)
+	if err != nil {
+		t.Fatalf("start kafka: %v", err)
+	}
+
+	brokers, err := c.Brokers(ctx)
+	if err != nil {
+		t.Fatalf("kafka brokers: %v", err)
+	}
+	return KafkaEnv{Config: kafka.Config{
+		Brokers:      brokers,
+		GroupID:      "test-" + strconv.FormatInt(time.Now().UnixNano(), 36),
+		RetryBackoff: 10 * time.Millisecond,
+	}}
+}
+
+// CreateTopics creates single-partition topics, including their dead-letter
+// topics, with Config.TopicPrefix applied.
+func (e KafkaEnv) CreateTopics(t *testing.T, names ...string) {
+	t.Helper()
+	conn, err := kafkago.Dial("tcp", e.Config.Brokers[0])
+	if err != nil {
+		t.Fatalf("dial kafka: %v", err)
+	}
+	defer conn.Close()
+
+	dlq := e.Config.DLQSuffix
+	if dlq == "" {
+		dlq = kafka.DefaultDLQSuffix
+	}
+	var topics []kafkago.TopicConfig
+	for _, name := range names {
+		for _, topic := range []string{e.Config.TopicPrefix + name, e.Config.TopicPrefix + name + dlq} {
+			topics = append(topics, kafkago.TopicConfig{Topic: topic, NumPartitions: 1, ReplicationFactor: 1})
+		}
+	}
+	if err := conn.CreateTopics(topics...); err != nil {
+		t.Fatalf("create topics: %v", err)
+	}
+}
+
+// endpoint returns the host address and mapped port of a container port.
+func endpoint(ctx context.Context, t *testing.T, c testcontainers.Container, port nat.Port) (string, int) {
+	t.Helper()
+	host, err := c.Host(ctx)
+	if err != nil {
+		t.Fatalf("container host: %v", err)
+	}
+	mapped, err := c.MappedPort(ctx, port)
+	if err != nil {
+		t.Fatalf("container port %s: %v", port, err)
+	}
+	if ip := net.ParseIP(host); ip != nil && ip.IsUnspecified() {
+		host = "localhost"
+	}
+	return host, mapped.Int()
+}
@@ -0,0 +1,197 @@
+// Package breakerx provides a circuit breaker for protecting calls to downstream services.
+// A breaker opens after a number of consecutive failures, rejects calls while open,
+// and lets a limited number of trial calls through once the open timeout elapses.
+package breakerx
+
+import (
+	"errors"
+	"sync"
+	"time"
+)
+
+// ErrOpen is returned when the breaker rejects a call.
+var ErrOpen = errors.New("circuit breaker is open")
+
+// State is the state of a circuit breaker.
+type State int
+
+const (
+	StateClosed State = iota
+	StateHalfOpen
+	StateOpen
+)
+
+// String returns the state name.
+func (s State) String() string {
+	switch s {
+	case StateClosed:
+		return "closed"
+	case StateHalfOpen:
+		return "half_open"
+	case StateOpen:
+		return "open"
+	default:
+		return "unknown"
+	}
+}
+
+// Default settings.
+const (
+	DefaultFailureThreshold    = 5
+	DefaultOpenTimeout         = 30 * time.Second
+	DefaultHalfOpenMaxRequests = 1
+)
+
+// Settings configures a Breaker.
+type Settings struct {
+	// Name identifies the downstream service, e.g. "payment-api".
+	Name string
+
+	// FailureThreshold is the number of consecutive failures that opens the breaker.
+	// Default: 5
+	FailureThreshold int
+
+	// OpenTimeout is how long the breaker stays open before allowing trial calls.
+	// Default: 30s
+	OpenTimeout time.Duration
+
+	// HalfOpenMaxRequests is the number of concurrent trial calls allowed when half-open.
+	// Default: 1
+	HalfOpenMaxRequests int
+}
+
+// Counts holds breaker statistics.
+type Counts struct {
+	Requests            uint64
+	Successes           uint64
+	Failures            uint64
+	Rejections          uint64
+	ConsecutiveFailures int
+}
+
+// Breaker is a circuit breaker. It is safe for concurrent use.
+type Breaker struct {
+	settings Settings
+	now      func() time.Time
+	metrics  *metrics
+
+	mu       sync.Mutex
+	state    State
+	openedAt time.Time
+	inFlight int
+	counts   Counts
+}
+
+// New creates a Breaker with the given settings, applying defaults for zero values.
+func New(s Settings) *Breaker {
+	if s.FailureThreshold <= 0 {
+		s.FailureThreshold = DefaultFailureThreshold
+	}
+	if s.OpenTimeout <= 0 {
+		s.OpenTimeout = DefaultOpenTimeout
+	}
+	if s.HalfOpenMaxRequests <= 0 {
+		s.HalfOpenMaxRequests = DefaultHalfOpenMaxRequests
+	}
+
+	b := &Breaker{settings: s, now: time.Now}
+	b.metrics = newMetrics(b)
+	return b
+}
+
+// Name returns the breaker name.
+func (b *Breaker) Name() string {
+	return b.settings.Name
+}
+
+// State returns the current state.
+func (b *Breaker) State() State {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	return b.currentState()
+}
+
+// Counts returns a snapshot of the breaker statistics.
+func (b *Breaker) Counts() Counts {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	return b.counts
+}
+
+// Allow reports whether a call may proceed.
+// On success it returns a done function that must be called with the call outcome.
+// It returns ErrOpen if the breaker rejects the call.
+func (b *Breaker) Allow() (func(success bool), error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	switch b.currentState() {
+	case StateOpen:
+		b.counts.Rejections++
+		b.metrics.rejected()
+		return nil, ErrOpen
+	case StateHalfOpen:
+		if b.inFlight >= b.settings.HalfOpenMaxRequests {
+			b.counts.Rejections++
+			b.metrics.rejected()
+			return nil, ErrOpen
+		}
+	}
+
+	b.inFlight++
+	b.counts.Requests++
+
+	var once sync.Once
+	return func(success bool) {
+		once.Do(func() { b.done(success) })
+	}, nil
+}
+
+// Execute runs fn if the breaker allows it and records the outcome.
+// Any non-nil error returned by fn counts as a failure.
+func (b *Breaker) Execute(fn func() error) error {
+	done, err := b.Allow()
+	if err != nil {
+		return err
+	}
+
+	err = fn()
+	done(err == nil)
+	return err
+}
+
+// done records the outcome of an allowed call.
+func (b *Breaker) done(success bool) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.inFlight--
+	state := b.currentState()
+
+	if success {
+		b.counts.Successes++
+		b.counts.ConsecutiveFailures = 0
+		if state == StateHalfOpen {
+			b.state = StateClosed
+		}
+		return
+	}
+
+	b.counts.Failures++
+	b.counts.ConsecutiveFailures++
+	if state == StateHalfOpen || b.counts.ConsecutiveFailures >= b.settings.FailureThreshold {
+		b.state = StateOpen
+		b.openedAt = b.now()
+	}
+}
+
+// currentState returns the state, moving from open to half-open once the timeout elapses.
+// Must be called with mu held.
+func (b *Breaker) currentState() State {
+	if b.state == StateOpen && b.now().Sub(b.openedAt) >= b.settings.OpenTimeout {
+		b.state = StateHalfOpen
+	}
+	return b.state
+}
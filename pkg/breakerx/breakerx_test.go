@@ -0,0 +1,135 @@
+package breakerx
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+var errDownstream = errors.New("downstream failed")
+
+// newTestBreaker returns a breaker with a controllable clock.
+func newTestBreaker(s Settings) (*Breaker, *time.Time) {
+	now := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	b := New(s)
+	b.now = func() time.Time { return now }
+	return b, &now
+}
+
+func TestNew_Defaults(t *testing.T) {
+	b := New(Settings{Name: "api"})
+
+	if b.settings.FailureThreshold != DefaultFailureThreshold {
+		t.Errorf("FailureThreshold = %d, want %d", b.settings.FailureThreshold, DefaultFailureThreshold)
+	}
+	if b.settings.OpenTimeout != DefaultOpenTimeout {
+		t.Errorf("OpenTimeout = %v, want %v", b.settings.OpenTimeout, DefaultOpenTimeout)
+	}
+	if b.State() != StateClosed {
+		t.Errorf("State() = %v, want %v", b.State(), StateClosed)
+	}
+}
+
+func TestBreaker_OpensAfterThreshold(t *testing.T) {
+	b, _ := newTestBreaker(Settings{Name: "api", FailureThreshold: 3})
+
+	for i := 0; i < 3; i++ {
+		_ = b.Execute(func() error { return errDownstream })
+	}
+
+	if b.State() != StateOpen {
+		t.Fatalf("State() = %v, want %v", b.State(), StateOpen)
+	}
+
+	called := false
+	err := b.Execute(func() error { called = true; return nil })
+	if !errors.Is(err, ErrOpen) {
+		t.Errorf("Execute() error = %v, want ErrOpen", err)
+	}
+	if called {
+		t.Error("fn should not be called while open")
+	}
+	if got := b.Counts().Rejections; got != 1 {
+		t.Errorf("Rejections = %d, want 1", got)
+	}
+}
+
+func TestBreaker_SuccessResetsConsecutiveFailures(t *testing.T) {
+	b, _ := newTestBreaker(Settings{Name: "api", FailureThreshold: 2})
+
+	_ = b.Execute(func() error { return errDownstream })
+	_ = b.Execute(func() error { return nil })
+	_ = b.Execute(func() error { return errDownstream })
+
+	if b.State() != StateClosed {
+		t.Errorf("State() = %v, want %v", b.State(), StateClosed)
+	}
+}
+
+func TestBreaker_HalfOpen(t *testing.T) {
+	tests := []struct {
+		name      string
+		trialErr  error
+		wantState State
+	}{
+		{"trial success closes", nil, StateClosed},
+		{"trial failure reopens", errDownstream, StateOpen},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			b, now := newTestBreaker(Settings{Name: "api", FailureThreshold: 1, OpenTimeout: time.Minute})
+			_ = b.Execute(func() error { return errDownstream })
+
+			*now = now.Add(time.Minute)
+			if b.State() != StateHalfOpen {
+				t.Fatalf("State() = %v, want %v", b.State(), StateHalfOpen)
+			}
+
+			_ = b.Execute(func() error { return tt.trialErr })
+
+			if b.State() != tt.wantState {
+				t.Errorf("State() = %v, want %v", b.State(), tt.wantState)
+			}
+		})
+	}
+}
+
+func TestBreaker_HalfOpenLimitsTrials(t *testing.T) {
+	b, now := newTestBreaker(Settings{Name: "api", FailureThreshold: 1, OpenTimeout: time.Second})
+	_ = b.Execute(func() error { return errDownstream })
+	*now = now.Add(time.Second)
+
+	done, err := b.Allow()
+	if err != nil {
+		t.Fatalf("first trial rejected: %v", err)
+	}
+	if _, err := b.Allow(); !errors.Is(err, ErrOpen) {
+		t.Errorf("second trial error = %v, want ErrOpen", err)
+	}
+
+	done(true)
+	done(true) // idempotent
+
+	if b.State() != StateClosed {
+		t.Errorf("State() = %v, want %v", b.State(), StateClosed)
+	}
+}
+
+func TestState_String(t *testing.T) {
+	tests := []struct {
+		state State
+		want  string
+	}{
+		{StateClosed, "closed"},
+		{StateHalfOpen, "half_open"},
+		{StateOpen, "open"},
+		{State(99), "unknown"},
+	}
+
+	for _, tt := range tests {
+		if got := tt.state.String(); got != tt.want {
+			t.Errorf("String() = %q, want %q", got, tt.want)
+		}
+	}
+}
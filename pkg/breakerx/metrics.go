@@ -0,0 +1,50 @@
+package breakerx
+
+import (
+	"context"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/metric"
+)
+
+// instrumentationName is the OpenTelemetry meter name.
+const instrumentationName = "github.com/blackhorseya/go-ddd/pkg/breakerx"
+
+// metrics exposes breaker state through OpenTelemetry metrics:
+//   - breaker.state: observable gauge (0 closed, 1 half-open, 2 open)
+//   - breaker.rejections: counter of calls rejected by the breaker
+type metrics struct {
+	attrs      metric.MeasurementOption
+	rejections metric.Int64Counter
+}
+
+// newMetrics registers instruments for b using the global meter provider.
+// Instrument creation errors are ignored so a broken provider never blocks calls.
+func newMetrics(b *Breaker) *metrics {
+	meter := otel.Meter(instrumentationName)
+	m := &metrics{
+		attrs: metric.WithAttributes(attribute.String("breaker", b.settings.Name)),
+	}
+
+	m.rejections, _ = meter.Int64Counter("breaker.rejections",
+		metric.WithDescription("Number of calls rejected by the circuit breaker"),
+	)
+
+	_, _ = meter.Int64ObservableGauge("breaker.state",
+		metric.WithDescription("Circuit breaker state: 0 closed, 1 half-open, 2 open"),
+		metric.WithInt64Callback(func(_ context.Context, o metric.Int64Observer) error {
+			o.Observe(int64(b.State()), m.attrs)
+			return nil
+		}),
+	)
+
+	return m
+}
+
+// rejected records a rejected call.
+func (m *metrics) rejected() {
+	if m.rejections != nil {
+		m.rejections.Add(context.Background(), 1, m.attrs)
+	}
+}
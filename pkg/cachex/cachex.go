@@ -0,0 +1,192 @@
+// Package cachex provides a small cache abstraction with TTL and tag-based
+// invalidation, with in-memory and Redis implementations, a singleflight
+// read-through Loader and namespaced key builders.
+// Values are opaque bytes so implementations can be backed by Redis or
+// any other shared store.
+package cachex
+
+import (
+	"container/list"
+	"context"
+	"errors"
+	"sync"
+	"time"
+)
+
+var (
+	// ErrMiss is returned by Get when the key is absent or expired.
+	ErrMiss = errors.New("cache miss")
+
+	// ErrInvalidationNotSent is returned by TwoLevel writes that succeeded
+	// but could not notify other instances.
+	ErrInvalidationNotSent = errors.New("cache invalidation not sent")
+)
+
+// Cache stores byte values with an optional TTL and invalidation tags.
+type Cache interface {
+	// Get returns the value for key, or ErrMiss.
+	Get(ctx context.Context, key string) ([]byte, error)
+
+	// Set stores value under key. A ttl <= 0 means no expiry.
+	// Tags group keys so they can be invalidated together.
+	Set(ctx context.Context, key string, value []byte, ttl time.Duration, tags ...string) error
+
+	// Delete removes the given keys.
+	Delete(ctx context.Context, keys ...string) error
+
+	// InvalidateTags removes every key stored with any of the given tags.
+	InvalidateTags(ctx context.Context, tags ...string) error
+}
+
+// entry is a cached value.
+type entry struct {
+	value     []byte
+	expiresAt time.Time
+	tags      []string
+	elem      *list.Element // position in the LRU list when bounded
+}
+
+// Memory is an in-process Cache. It is safe for concurrent use.
+// Expired entries are dropped lazily on access.
+type Memory struct {
+	now func() time.Time
+
+	mu      sync.Mutex
+	entries map[string]entry
+	tags    map[string]map[string]struct{}
+
+	// maxEntries bounds the cache; the least recently used entry is
+	// evicted first. Zero means unbounded.
+	maxEntries int
+	lru        *list.List
+}
+
+var _ Cache = (*Memory)(nil)
+
+// NewMemory creates an empty, unbounded in-memory cache.
+func NewMemory() *Memory {
+	return NewLRU(0)
+}
+
+// NewLRU creates an in-memory cache holding at most maxEntries entries,
+// evicting the least recently used. maxEntries <= 0 means unbounded.
+func NewLRU(maxEntries int) *Memory {
+	return &Memory{
+		now:        time.Now,
+		entries:    make(map[string]entry),
+		tags:       make(map[string]map[string]struct{}),
+		maxEntries: max(maxEntries, 0),
+		lru:        list.New(),
+	}
+}
+
+// Len returns the number of stored entries, including expired ones not yet dropped.
+func (m *Memory) Len() int {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return len(m.entries)
+}
+
+// Get returns the value for key, or ErrMiss.
+func (m *Memory) Get(_ context.Context, key string) ([]byte, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	e, ok := m.entries[key]
+	if !ok {
+		return nil, ErrMiss
+	}
+	if !e.expiresAt.IsZero() && !m.now().Before(e.expiresAt) {
+		m.remove(key)
+		return nil, ErrMiss
+	}
+	if e.elem != nil {
+		m.lru.MoveToFront(e.elem)
+	}
+	return e.value, nil
+}
+
+// Set stores value under key.
+func (m *Memory) Set(_ context.Context, key string, value []byte, ttl time.Duration, tags ...string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.remove(key)
+
+	e := entry{value: value, tags: tags}
+	if ttl > 0 {
+		e.expiresAt = m.now().Add(ttl)
+	}
+	if m.maxEntries > 0 {
+		e.elem = m.lru.PushFront(key)
+	}
+	m.entries[key] = e
+
+	for _, tag := range tags {
+		keys, ok := m.tags[tag]
+		if !ok {
+			keys = make(map[string]struct{})
+			m.tags[tag] = keys
+		}
+		keys[key] = struct{}{}
+	}
+
+	for m.maxEntries > 0 && len(m.entries) > m.maxEntries {
+		m.remove(m.lru.Back().Value.(string))
+	}
+	return nil
+}
+
+// Delete removes the given keys.
+func (m *Memory) Delete(_ context.Context, keys ...string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	for _, key := range keys {
+		m.remove(key)
+	}
+	return nil
+}
+
+// InvalidateTags removes every key stored with any of the given tags.
+func (m *Memory) InvalidateTags(_ context.Context, tags ...string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	for _, tag := range tags {
+		for key := range m.tags[tag] {
+			m.remove(key)
+		}
+		delete(m.tags, tag)
+	}
+	return nil
+}
+
+// clear removes every entry.
+func (m *Memory) clear() {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	clear(m.entries)
+	clear(m.tags)
+	m.lru.Init()
+}
+
+// remove deletes key and its tag index entries. Must be called with mu held.
+func (m *Memory) remove(key string) {
+	e, ok := m.entries[key]
+	if !ok {
+		return
+	}
+	delete(m.entries, key)
+	if e.elem != nil {
+		m.lru.Remove(e.elem)
+	}
+
+	for _, tag := range e.tags {
+		delete(m.tags[tag], key)
+		if len(m.tags[tag]) == 0 {
+			delete(m.tags, tag)
+		}
+	}
+}
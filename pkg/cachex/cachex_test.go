@@ -0,0 +1,121 @@
+package cachex
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestMemory_GetSet(t *testing.T) {
+	ctx := context.Background()
+	now := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name    string
+		ttl     time.Duration
+		elapsed time.Duration
+		wantErr error
+	}{
+		{name: "fresh entry", ttl: time.Minute, elapsed: 30 * time.Second},
+		{name: "expired entry", ttl: time.Minute, elapsed: time.Minute, wantErr: ErrMiss},
+		{name: "no expiry", elapsed: 24 * time.Hour},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Arrange
+			m := NewMemory()
+			m.now = func() time.Time { return now }
+			if err := m.Set(ctx, "k", []byte("v"), tt.ttl); err != nil {
+				t.Fatalf("Set() error = %v", err)
+			}
+			m.now = func() time.Time { return now.Add(tt.elapsed) }
+
+			// Act
+			got, err := m.Get(ctx, "k")
+
+			// Assert
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("Get() error = %v, want %v", err, tt.wantErr)
+			}
+			if tt.wantErr == nil && string(got) != "v" {
+				t.Errorf("Get() = %q, want %q", got, "v")
+			}
+		})
+	}
+}
+
+func TestMemory_InvalidateTags(t *testing.T) {
+	// Arrange
+	ctx := context.Background()
+	m := NewMemory()
+	_ = m.Set(ctx, "order:1", []byte("a"), 0, "orders", "order:1")
+	_ = m.Set(ctx, "order:2", []byte("b"), 0, "orders")
+	_ = m.Set(ctx, "user:1", []byte("c"), 0, "users")
+
+	// Act
+	err := m.InvalidateTags(ctx, "orders")
+
+	// Assert
+	if err != nil {
+		t.Fatalf("InvalidateTags() error = %v", err)
+	}
+	for _, key := range []string{"order:1", "order:2"} {
+		if _, err := m.Get(ctx, key); !errors.Is(err, ErrMiss) {
+			t.Errorf("Get(%q) error = %v, want ErrMiss", key, err)
+		}
+	}
+	if _, err := m.Get(ctx, "user:1"); err != nil {
+		t.Errorf("Get(user:1) error = %v", err)
+	}
+	if len(m.tags["order:1"]) != 0 {
+		t.Errorf("tag index not cleaned: %v", m.tags)
+	}
+}
+
+func TestMemory_Delete(t *testing.T) {
+	// Arrange
+	ctx := context.Background()
+	m := NewMemory()
+	_ = m.Set(ctx, "k", []byte("v"), 0, "t")
+
+	// Act
+	err := m.Delete(ctx, "k", "missing")
+
+	// Assert
+	if err != nil {
+		t.Fatalf("Delete() error = %v", err)
+	}
+	if _, err := m.Get(ctx, "k"); !errors.Is(err, ErrMiss) {
+		t.Errorf("Get() error = %v, want ErrMiss", err)
+	}
+	if len(m.tags) != 0 {
+		t.Errorf("tag index not cleaned: %v", m.tags)
+	}
+}
+
+func TestMemory_LRUEviction(t *testing.T) {
+	// Arrange
+	ctx := context.Background()
+	m := NewLRU(2)
+	_ = m.Set(ctx, "a", []byte("1"), 0, "t")
+	_ = m.Set(ctx, "b", []byte("2"), 0)
+	_, _ = m.Get(ctx, "a")
+
+	// Act
+	_ = m.Set(ctx, "c", []byte("3"), 0)
+
+	// Assert
+	if m.Len() != 2 {
+		t.Errorf("Len() = %d, want 2", m.Len())
+	}
+	if _, err := m.Get(ctx, "b"); !errors.Is(err, ErrMiss) {
+		t.Errorf("Get(b) error = %v, want ErrMiss", err)
+	}
+	for _, k := range []string{"a", "c"} {
+		if _, err := m.Get(ctx, k); err != nil {
+			t.Errorf("Get(%s) error = %v", k, err)
+		}
+	}
+}
@@ -0,0 +1,40 @@
+package cachex
+
+import "strings"
+
+// keySeparator joins key segments.
+const keySeparator = ":"
+
+// Namespace builds cache keys and tags under a common prefix so features
+// sharing a cache cannot collide:
+//
+//	orders := cachex.Namespace("orders")
+//	orders.Key("42")                  // "orders:42"
+//	orders.Sub("by-customer").Key(id) // "orders:by-customer:<id>"
+type Namespace string
+
+// Key returns the key for parts within the namespace.
+func (n Namespace) Key(parts ...string) string {
+	return Key(append([]string{string(n)}, parts...)...)
+}
+
+// Tag returns an invalidation tag within the namespace.
+func (n Namespace) Tag(parts ...string) string {
+	return n.Key(parts...)
+}
+
+// Sub returns a nested namespace.
+func (n Namespace) Sub(name string) Namespace {
+	return Namespace(n.Key(name))
+}
+
+// Key joins non-empty parts with ":".
+func Key(parts ...string) string {
+	kept := make([]string, 0, len(parts))
+	for _, p := range parts {
+		if p != "" {
+			kept = append(kept, p)
+		}
+	}
+	return strings.Join(kept, keySeparator)
+}
@@ -0,0 +1,69 @@
+package cachex
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"golang.org/x/sync/singleflight"
+
+	"github.com/blackhorseya/go-ddd/pkg/contextx"
+)
+
+// LoadFunc produces the value for a missing key.
+type LoadFunc func(ctx context.Context) ([]byte, error)
+
+// Loader adds read-through loading to a Cache. Concurrent misses on the
+// same key share one load (singleflight), so an expired hot key does not
+// stampede the backing store.
+type Loader struct {
+	cache Cache
+	group singleflight.Group
+}
+
+// NewLoader creates a Loader on c.
+func NewLoader(c Cache) *Loader {
+	return &Loader{cache: c}
+}
+
+// Cache returns the underlying cache.
+func (l *Loader) Cache() Cache { return l.cache }
+
+// GetOrLoad returns the cached value for key, or calls load, stores its
+// result with ttl and tags, and returns it. Cache failures are logged and
+// fall through to load so the cache never breaks a read; errors from load
+// are returned as is and not cached.
+//
+// The load runs detached from the caller's cancellation because other
+// callers may be waiting for it; a caller whose ctx is done stops waiting.
+func (l *Loader) GetOrLoad(ctx context.Context, key string, ttl time.Duration, load LoadFunc, tags ...string) ([]byte, error) {
+	b, err := l.cache.Get(ctx, key)
+	if err == nil {
+		return b, nil
+	}
+	if !errors.Is(err, ErrMiss) {
+		contextx.From(ctx).Warn("cache get failed", "key", key, "error", err)
+	}
+
+	ch := l.group.DoChan(key, func() (any, error) {
+		ctx := context.WithoutCancel(ctx)
+		b, err := load(ctx)
+		if err != nil {
+			return nil, err
+		}
+		if err := l.cache.Set(ctx, key, b, ttl, tags...); err != nil {
+			contextx.From(ctx).Warn("cache set failed", "key", key, "error", err)
+		}
+		return b, nil
+	})
+
+	select {
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	case res := <-ch:
+		if res.Err != nil {
+			return nil, res.Err
+		}
+		return res.Val.([]byte), nil
+	}
+}
@@ -0,0 +1,113 @@
+package cachex
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestLoader_GetOrLoad(t *testing.T) {
+	// Arrange
+	ctx := context.Background()
+	l := NewLoader(NewMemory())
+	var calls atomic.Int32
+	load := func(context.Context) ([]byte, error) {
+		calls.Add(1)
+		return []byte("v"), nil
+	}
+
+	// Act
+	first, err := l.GetOrLoad(ctx, "k", time.Minute, load, "t")
+	if err != nil {
+		t.Fatalf("GetOrLoad() error = %v", err)
+	}
+	second, err := l.GetOrLoad(ctx, "k", time.Minute, load, "t")
+	if err != nil {
+		t.Fatalf("second GetOrLoad() error = %v", err)
+	}
+	_ = l.Cache().InvalidateTags(ctx, "t")
+	_, _ = l.GetOrLoad(ctx, "k", time.Minute, load)
+
+	// Assert
+	if string(first) != "v" || string(second) != "v" {
+		t.Errorf("GetOrLoad() = %q, %q, want v", first, second)
+	}
+	if n := calls.Load(); n != 2 {
+		t.Errorf("load calls = %d, want 2 (miss, hit, miss after invalidation)", n)
+	}
+}
+
+func TestLoader_GetOrLoadSingleflight(t *testing.T) {
+	// Arrange
+	ctx := context.Background()
+	l := NewLoader(NewMemory())
+	release := make(chan struct{})
+	var calls atomic.Int32
+	load := func(context.Context) ([]byte, error) {
+		calls.Add(1)
+		<-release
+		return []byte("v"), nil
+	}
+
+	// Act
+	var wg sync.WaitGroup
+	for range 10 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if _, err := l.GetOrLoad(ctx, "hot", time.Minute, load); err != nil {
+				t.Errorf("GetOrLoad() error = %v", err)
+			}
+		}()
+	}
+	time.Sleep(20 * time.Millisecond)
+	close(release)
+	wg.Wait()
+
+	// Assert
+	if n := calls.Load(); n != 1 {
+		t.Errorf("load calls = %d, want 1", n)
+	}
+}
+
+func TestLoader_GetOrLoadError(t *testing.T) {
+	// Arrange
+	ctx := context.Background()
+	l := NewLoader(NewMemory())
+	errBoom := errors.New("boom")
+
+	// Act
+	_, err := l.GetOrLoad(ctx, "k", time.Minute, func(context.Context) ([]byte, error) { return nil, errBoom })
+
+	// Assert
+	if !errors.Is(err, errBoom) {
+		t.Errorf("GetOrLoad() error = %v, want %v", err, errBoom)
+	}
+	if _, err := l.Cache().Get(ctx, "k"); !errors.Is(err, ErrMiss) {
+		t.Errorf("Get() error = %v, want %v (errors are not cached)", err, ErrMiss)
+	}
+}
+
+func TestNamespace(t *testing.T) {
+	tests := []struct {
+		name string
+		got  string
+		want string
+	}{
+		{"key", Namespace("orders").Key("42"), "orders:42"},
+		{"sub namespace", Namespace("orders").Sub("by-customer").Key("c1"), "orders:by-customer:c1"},
+		{"tag", Namespace("orders").Tag("42"), "orders:42"},
+		{"empty parts skipped", Key("a", "", "b"), "a:b"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if tt.got != tt.want {
+				t.Errorf("got %q, want %q", tt.got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,123 @@
+package cachex
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	goredis "github.com/redis/go-redis/v9"
+)
+
+// tagScript adds ARGV[1] to the tag set KEYS[1] and keeps the set alive as
+// long as its longest-lived key: ARGV[2] is the key TTL in milliseconds,
+// 0 for no expiry.
+var tagScript = goredis.NewScript(`
+local created = redis.call("SADD", KEYS[1], ARGV[1]) == 1 and redis.call("SCARD", KEYS[1]) == 1
+local ttl = tonumber(ARGV[2])
+if ttl == 0 then
+	return redis.call("PERSIST", KEYS[1])
+end
+local current = redis.call("PTTL", KEYS[1])
+if created or (current >= 0 and current < ttl) then
+	return redis.call("PEXPIRE", KEYS[1], ttl)
+end
+return 0`)
+
+// popTagScript removes the tag set KEYS[1] and returns its members in one
+// step, so a key tagged while the tag is invalidated lands in a new set
+// instead of being dropped unseen.
+var popTagScript = goredis.NewScript(`
+local keys = redis.call("SMEMBERS", KEYS[1])
+redis.call("DEL", KEYS[1])
+return keys`)
+
+// Redis is a Cache shared between instances through Redis. Tags are Redis
+// sets of keys; a tag set lives as long as its longest-lived key, and
+// members whose keys already expired are harmless.
+//
+// Every command and script touches a single key, so the cache works on
+// Redis Cluster, where keys of one call must share a hash slot.
+type Redis struct {
+	client goredis.UniversalClient
+	prefix string
+}
+
+var _ Cache = (*Redis)(nil)
+
+// NewRedis creates a Redis cache. prefix namespaces every key and tag,
+// e.g. "cache:".
+func NewRedis(client goredis.UniversalClient, prefix string) *Redis {
+	return &Redis{client: client, prefix: prefix}
+}
+
+// Get returns the value for key, or ErrMiss.
+func (r *Redis) Get(ctx context.Context, key string) ([]byte, error) {
+	b, err := r.client.Get(ctx, r.prefix+key).Bytes()
+	if errors.Is(err, goredis.Nil) {
+		return nil, ErrMiss
+	}
+	if err != nil {
+		return nil, fmt.Errorf("cache get %s: %w", key, err)
+	}
+	return b, nil
+}
+
+// Set adds key to each tag set, then stores value under key. Tagging
+// first means a concurrent invalidation can leave a tagged key without a
+// value, which is harmless, but never a value it cannot find.
+func (r *Redis) Set(ctx context.Context, key string, value []byte, ttl time.Duration, tags ...string) error {
+	if ttl < 0 {
+		ttl = 0
+	}
+	if len(tags) > 0 {
+		_, err := r.client.Pipelined(ctx, func(p goredis.Pipeliner) error {
+			for _, tag := range tags {
+				tagScript.Eval(ctx, p, []string{r.tagKey(tag)}, key, ttl.Milliseconds())
+			}
+			return nil
+		})
+		if err != nil {
+			return fmt.Errorf("cache set %s: tag: %w", key, err)
+		}
+	}
+	if err := r.client.Set(ctx, r.prefix+key, value, ttl).Err(); err != nil {
+		return fmt.Errorf("cache set %s: %w", key, err)
+	}
+	return nil
+}
+
+// Delete removes the given keys, one DEL per key.
+func (r *Redis) Delete(ctx context.Context, keys ...string) error {
+	if len(keys) == 0 {
+		return nil
+	}
+	_, err := r.client.Pipelined(ctx, func(p goredis.Pipeliner) error {
+		for _, k := range keys {
+			p.Del(ctx, r.prefix+k)
+		}
+		return nil
+	})
+	if err != nil {
+		return fmt.Errorf("cache delete: %w", err)
+	}
+	return nil
+}
+
+// InvalidateTags removes every key stored with any of the given tags.
+func (r *Redis) InvalidateTags(ctx context.Context, tags ...string) error {
+	for _, tag := range tags {
+		keys, err := popTagScript.Run(ctx, r.client, []string{r.tagKey(tag)}).StringSlice()
+		if err != nil {
+			return fmt.Errorf("cache invalidate tag %s: %w", tag, err)
+		}
+		if err := r.Delete(ctx, keys...); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (r *Redis) tagKey(tag string) string {
+	return r.prefix + "tag" + keySeparator + tag
+}
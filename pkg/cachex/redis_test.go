@@ -0,0 +1,169 @@
+package cachex
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/alicebob/miniredis/v2"
+	goredis "github.com/redis/go-redis/v9"
+)
+
+func newTestRedis(t *testing.T) (*Redis, *miniredis.Miniredis) {
+	t.Helper()
+
+	mr := miniredis.RunT(t)
+	client := goredis.NewClient(&goredis.Options{Addr: mr.Addr()})
+	t.Cleanup(func() { _ = client.Close() })
+	return NewRedis(client, "cache:"), mr
+}
+
+func TestRedis_GetSet(t *testing.T) {
+	// Arrange
+	ctx := context.Background()
+	r, mr := newTestRedis(t)
+
+	// Act
+	if err := r.Set(ctx, "k", []byte("v"), time.Minute); err != nil {
+		t.Fatalf("Set() error = %v", err)
+	}
+	got, err := r.Get(ctx, "k")
+	prefixed := mr.Exists("cache:k")
+	mr.FastForward(time.Minute)
+	_, expiredErr := r.Get(ctx, "k")
+
+	// Assert
+	if err != nil || string(got) != "v" {
+		t.Errorf("Get() = %q, %v, want v", got, err)
+	}
+	if !prefixed {
+		t.Error("key cache:k not set")
+	}
+	if !errors.Is(expiredErr, ErrMiss) {
+		t.Errorf("Get() after expiry error = %v, want %v", expiredErr, ErrMiss)
+	}
+}
+
+func TestRedis_InvalidateTags(t *testing.T) {
+	// Arrange
+	ctx := context.Background()
+	r, mr := newTestRedis(t)
+	for _, set := range []error{
+		r.Set(ctx, "a", []byte("1"), time.Minute, "orders"),
+		r.Set(ctx, "b", []byte("2"), time.Hour, "orders", "customer:1"),
+		r.Set(ctx, "c", []byte("3"), 0, "customer:2"),
+	} {
+		if set != nil {
+			t.Fatalf("Set() error = %v", set)
+		}
+	}
+
+	// Act
+	err := r.InvalidateTags(ctx, "orders")
+
+	// Assert
+	if err != nil {
+		t.Fatalf("InvalidateTags() error = %v", err)
+	}
+	for _, key := range []string{"a", "b"} {
+		if _, err := r.Get(ctx, key); !errors.Is(err, ErrMiss) {
+			t.Errorf("Get(%q) error = %v, want %v", key, err, ErrMiss)
+		}
+	}
+	if _, err := r.Get(ctx, "c"); err != nil {
+		t.Errorf("Get(c) error = %v, want untouched", err)
+	}
+	if mr.Exists("cache:tag:orders") {
+		t.Error("tag set not removed")
+	}
+	if ttl := mr.TTL("cache:tag:customer:1"); ttl != time.Hour {
+		t.Errorf("tag TTL = %v, want the longest key TTL", ttl)
+	}
+}
+
+func TestRedis_Delete(t *testing.T) {
+	// Arrange
+	ctx := context.Background()
+	r, _ := newTestRedis(t)
+	_ = r.Set(ctx, "k", []byte("v"), 0)
+
+	// Act
+	err := r.Delete(ctx, "k")
+
+	// Assert
+	if err != nil {
+		t.Fatalf("Delete() error = %v", err)
+	}
+	if _, err := r.Get(ctx, "k"); !errors.Is(err, ErrMiss) {
+		t.Errorf("Get() error = %v, want %v", err, ErrMiss)
+	}
+}
+
+// commandRecorder is a go-redis hook recording the commands sent,
+// including those in pipelines.
+type commandRecorder struct {
+	cmds [][]any
+}
+
+func (h *commandRecorder) DialHook(next goredis.DialHook) goredis.DialHook { return next }
+
+func (h *commandRecorder) ProcessHook(next goredis.ProcessHook) goredis.ProcessHook {
+	return func(ctx context.Context, cmd goredis.Cmder) error {
+		h.cmds = append(h.cmds, cmd.Args())
+		return next(ctx, cmd)
+	}
+}
+
+func (h *commandRecorder) ProcessPipelineHook(next goredis.ProcessPipelineHook) goredis.ProcessPipelineHook {
+	return func(ctx context.Context, cmds []goredis.Cmder) error {
+		for _, cmd := range cmds {
+			h.cmds = append(h.cmds, cmd.Args())
+		}
+		return next(ctx, cmds)
+	}
+}
+
+func TestRedis_SingleKeyCommands(t *testing.T) {
+	// Arrange
+	ctx := context.Background()
+	mr := miniredis.RunT(t)
+	client := goredis.NewClient(&goredis.Options{Addr: mr.Addr()})
+	t.Cleanup(func() { _ = client.Close() })
+	rec := &commandRecorder{}
+	client.AddHook(rec)
+	r := NewRedis(client, "cache:")
+
+	// Act
+	errs := []error{
+		r.Set(ctx, "a", []byte("1"), time.Minute, "orders", "customer:1"),
+		r.Set(ctx, "b", []byte("2"), time.Minute, "orders"),
+		r.Delete(ctx, "a", "b"),
+		r.InvalidateTags(ctx, "orders", "customer:1"),
+	}
+
+	// Assert: cluster mode rejects transactions and calls spanning several
+	// keys, which may live in different slots
+	for _, err := range errs {
+		if err != nil {
+			t.Fatalf("error = %v", err)
+		}
+	}
+	for _, args := range rec.cmds {
+		name := strings.ToLower(fmt.Sprint(args[0]))
+		switch name {
+		case "multi", "exec":
+			t.Errorf("command %v, want no transaction", args)
+		case "del":
+			if len(args) != 2 {
+				t.Errorf("command %v, want one key per DEL", args)
+			}
+		case "eval", "evalsha":
+			if numKeys := fmt.Sprint(args[2]); numKeys != "1" {
+				t.Errorf("command %v, want one key per script", args)
+			}
+		}
+	}
+}
@@ -0,0 +1,212 @@
+package cachex
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	goredis "github.com/redis/go-redis/v9"
+
+	"github.com/blackhorseya/go-ddd/pkg/contextx"
+	"github.com/blackhorseya/go-ddd/pkg/idx"
+)
+
+// Default two-level cache options.
+const (
+	DefaultLocalEntries = 1000
+	DefaultLocalTTL     = time.Minute
+	DefaultChannel      = "cache:invalidate"
+)
+
+// Receive retry backoff of TwoLevel.Run.
+const (
+	receiveBackoffMin = 100 * time.Millisecond
+	receiveBackoffMax = 30 * time.Second
+)
+
+// TwoLevelOptions configures a TwoLevel cache.
+type TwoLevelOptions struct {
+	// LocalEntries bounds the in-process LRU.
+	// Default: 1000
+	LocalEntries int
+
+	// LocalTTL caps how long a local copy lives, bounding staleness if an
+	// invalidation message is lost.
+	// Default: 1m
+	LocalTTL time.Duration
+
+	// Channel is the Redis pub/sub channel for invalidation messages.
+	// Default: "cache:invalidate"
+	Channel string
+}
+
+// TwoLevel is a Cache that checks a small in-process LRU before a shared
+// remote cache. Writes go to both levels and broadcast an invalidation over
+// Redis pub/sub so other instances drop their local copies; run Run on
+// every instance to receive them.
+//
+// Remote values carry their tags, so local copies can be invalidated by tag
+// too. The remote cache must therefore be dedicated to the TwoLevel cache.
+type TwoLevel struct {
+	id      string // tags this instance's invalidations, see Run
+	local   *Memory
+	remote  Cache
+	client  goredis.UniversalClient
+	channel string
+	ttl     time.Duration
+}
+
+var _ Cache = (*TwoLevel)(nil)
+
+// NewTwoLevel creates a TwoLevel cache over remote, broadcasting
+// invalidations through client. Zero option values get defaults.
+func NewTwoLevel(remote Cache, client goredis.UniversalClient, opts TwoLevelOptions) *TwoLevel {
+	if opts.LocalEntries <= 0 {
+		opts.LocalEntries = DefaultLocalEntries
+	}
+	if opts.LocalTTL <= 0 {
+		opts.LocalTTL = DefaultLocalTTL
+	}
+	if opts.Channel == "" {
+		opts.Channel = DefaultChannel
+	}
+	return &TwoLevel{
+		id:      idx.ULID(),
+		local:   NewLRU(opts.LocalEntries),
+		remote:  remote,
+		client:  client,
+		channel: opts.Channel,
+		ttl:     opts.LocalTTL,
+	}
+}
+
+// remoteEntry is the stored form of a remote value.
+type remoteEntry struct {
+	Value []byte   `json:"v"`
+	Tags  []string `json:"t,omitempty"`
+}
+
+// invalidation is the pub/sub message telling instances to drop local copies.
+type invalidation struct {
+	Origin string   `json:"origin,omitempty"` // ID of the publishing instance
+	Keys   []string `json:"keys,omitempty"`
+	Tags   []string `json:"tags,omitempty"`
+}
+
+// Get returns the local copy of key, or loads it from the remote cache
+// and keeps a local copy.
+func (t *TwoLevel) Get(ctx context.Context, key string) ([]byte, error) {
+	if b, err := t.local.Get(ctx, key); err == nil {
+		return b, nil
+	}
+
+	b, err := t.remote.Get(ctx, key)
+	if err != nil {
+		return nil, err
+	}
+	var e remoteEntry
+	if err := json.Unmarshal(b, &e); err != nil {
+		return nil, fmt.Errorf("cache decode %s: %w", key, err)
+	}
+	_ = t.local.Set(ctx, key, e.Value, t.ttl, e.Tags...)
+	return e.Value, nil
+}
+
+// Set stores value in both levels and tells other instances to drop key.
+func (t *TwoLevel) Set(ctx context.Context, key string, value []byte, ttl time.Duration, tags ...string) error {
+	b, err := json.Marshal(remoteEntry{Value: value, Tags: tags})
+	if err != nil {
+		return err
+	}
+	if err := t.remote.Set(ctx, key, b, ttl, tags...); err != nil {
+		return err
+	}
+
+	localTTL := t.ttl
+	if ttl > 0 {
+		localTTL = min(ttl, t.ttl)
+	}
+	_ = t.local.Set(ctx, key, value, localTTL, tags...)
+	return t.publish(ctx, invalidation{Keys: []string{key}})
+}
+
+// Delete removes keys from both levels on every instance.
+func (t *TwoLevel) Delete(ctx context.Context, keys ...string) error {
+	_ = t.local.Delete(ctx, keys...)
+	if err := t.remote.Delete(ctx, keys...); err != nil {
+		return err
+	}
+	return t.publish(ctx, invalidation{Keys: keys})
+}
+
+// InvalidateTags removes tagged keys from both levels on every instance.
+func (t *TwoLevel) InvalidateTags(ctx context.Context, tags ...string) error {
+	_ = t.local.InvalidateTags(ctx, tags...)
+	if err := t.remote.InvalidateTags(ctx, tags...); err != nil {
+		return err
+	}
+	return t.publish(ctx, invalidation{Tags: tags})
+}
+
+// Run applies invalidations broadcast by other instances until ctx is
+// cancelled, skipping its own. The local cache is cleared whenever the
+// subscription is (re)established, since messages may have been missed
+// meanwhile. Receive errors are retried with exponential backoff.
+func (t *TwoLevel) Run(ctx context.Context) error {
+	sub := t.client.Subscribe(ctx, t.channel)
+	defer sub.Close()
+
+	logger := contextx.From(ctx)
+	backoff := receiveBackoffMin
+	for {
+		msg, err := sub.Receive(ctx)
+		if err != nil {
+			if ctx.Err() != nil {
+				return nil
+			}
+			logger.Warn("cache invalidation receive failed", "error", err, "retry_in", backoff)
+			select {
+			case <-ctx.Done():
+				return nil
+			case <-time.After(backoff):
+			}
+			backoff = min(2*backoff, receiveBackoffMax)
+			continue
+		}
+		backoff = receiveBackoffMin
+
+		switch m := msg.(type) {
+		case *goredis.Subscription:
+			if m.Kind == "subscribe" {
+				t.local.clear()
+			}
+		case *goredis.Message:
+			var inv invalidation
+			if err := json.Unmarshal([]byte(m.Payload), &inv); err != nil {
+				logger.Warn("cache invalidation malformed", "error", err)
+				continue
+			}
+			if inv.Origin == t.id {
+				continue
+			}
+			_ = t.local.Delete(ctx, inv.Keys...)
+			_ = t.local.InvalidateTags(ctx, inv.Tags...)
+		}
+	}
+}
+
+// publish broadcasts inv. The local level of this instance is already
+// up to date, so a failure only delays other instances until LocalTTL.
+func (t *TwoLevel) publish(ctx context.Context, inv invalidation) error {
+	inv.Origin = t.id
+	b, err := json.Marshal(inv)
+	if err != nil {
+		return err
+	}
+	if err := t.client.Publish(ctx, t.channel, b).Err(); err != nil {
+		return errors.Join(ErrInvalidationNotSent, err)
+	}
+	return nil
+}
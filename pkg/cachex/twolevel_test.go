@@ -0,0 +1,194 @@
+package cachex
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/alicebob/miniredis/v2"
+	goredis "github.com/redis/go-redis/v9"
+
+	"github.com/blackhorseya/go-ddd/pkg/contextx"
+)
+
+// newTestTwoLevel returns two running TwoLevel caches sharing one Redis.
+func newTestTwoLevel(t *testing.T) (*TwoLevel, *TwoLevel) {
+	t.Helper()
+
+	mr := miniredis.RunT(t)
+	ctx, cancel := context.WithCancel(context.Background())
+	t.Cleanup(cancel)
+
+	newInstance := func() *TwoLevel {
+		client := goredis.NewClient(&goredis.Options{Addr: mr.Addr()})
+		t.Cleanup(func() { _ = client.Close() })
+		c := NewTwoLevel(NewRedis(client, "cache:"), client, TwoLevelOptions{})
+		go func() { _ = c.Run(ctx) }()
+		return c
+	}
+	a, b := newInstance(), newInstance()
+
+	waitFor(t, func() bool { return mr.PubSubNumSub(DefaultChannel)[DefaultChannel] == 2 })
+	return a, b
+}
+
+// waitFor polls cond until it holds or the test times out.
+func waitFor(t *testing.T, cond func() bool) {
+	t.Helper()
+
+	deadline := time.Now().Add(2 * time.Second)
+	for !cond() {
+		if time.Now().After(deadline) {
+			t.Fatal("condition not met before deadline")
+		}
+		time.Sleep(5 * time.Millisecond)
+	}
+}
+
+func TestTwoLevel_GetSet(t *testing.T) {
+	// Arrange
+	ctx := context.Background()
+	a, b := newTestTwoLevel(t)
+
+	// Act
+	if err := a.Set(ctx, "k", []byte("v"), time.Minute, "t"); err != nil {
+		t.Fatalf("Set() error = %v", err)
+	}
+	got, err := b.Get(ctx, "k")
+
+	// Assert
+	if err != nil || string(got) != "v" {
+		t.Fatalf("Get() = %q, %v, want v", got, err)
+	}
+	if b.local.Len() != 1 {
+		t.Errorf("local Len() = %d, want 1", b.local.Len())
+	}
+	if _, err := a.Get(ctx, "missing"); !errors.Is(err, ErrMiss) {
+		t.Errorf("Get(missing) error = %v, want ErrMiss", err)
+	}
+}
+
+func TestTwoLevel_Invalidation(t *testing.T) {
+	tests := []struct {
+		name       string
+		invalidate func(ctx context.Context, c *TwoLevel) error
+		wantErr    error
+		want       string
+	}{
+		{
+			name: "set",
+			invalidate: func(ctx context.Context, c *TwoLevel) error {
+				return c.Set(ctx, "k", []byte("v2"), time.Minute, "t")
+			},
+			want: "v2",
+		},
+		{
+			name:       "delete",
+			invalidate: func(ctx context.Context, c *TwoLevel) error { return c.Delete(ctx, "k") },
+			wantErr:    ErrMiss,
+		},
+		{
+			name:       "tags",
+			invalidate: func(ctx context.Context, c *TwoLevel) error { return c.InvalidateTags(ctx, "t") },
+			wantErr:    ErrMiss,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Arrange
+			ctx := context.Background()
+			a, b := newTestTwoLevel(t)
+			_ = a.Set(ctx, "k", []byte("v1"), time.Minute, "t")
+			if _, err := b.Get(ctx, "k"); err != nil {
+				t.Fatalf("warm Get() error = %v", err)
+			}
+
+			// Act
+			if err := tt.invalidate(ctx, a); err != nil {
+				t.Fatalf("invalidate error = %v", err)
+			}
+
+			// Assert
+			waitFor(t, func() bool {
+				got, err := b.Get(ctx, "k")
+				return errors.Is(err, tt.wantErr) && (tt.wantErr != nil || string(got) == tt.want)
+			})
+		})
+	}
+}
+
+func TestTwoLevel_SkipsOwnInvalidations(t *testing.T) {
+	// Arrange
+	ctx := context.Background()
+	a, b := newTestTwoLevel(t)
+	_ = b.Set(ctx, "x", []byte("1"), time.Minute)
+	if _, err := a.Get(ctx, "x"); err != nil {
+		t.Fatalf("warm Get() error = %v", err)
+	}
+
+	// Act: a's own broadcast is followed by one from b, so once a has
+	// applied b's it has seen its own too
+	if err := a.Set(ctx, "k", []byte("v"), time.Minute); err != nil {
+		t.Fatalf("Set() error = %v", err)
+	}
+	if err := b.Delete(ctx, "x"); err != nil {
+		t.Fatalf("Delete() error = %v", err)
+	}
+	waitFor(t, func() bool {
+		_, err := a.local.Get(ctx, "x")
+		return errors.Is(err, ErrMiss)
+	})
+
+	// Assert
+	if got, err := a.local.Get(ctx, "k"); err != nil || string(got) != "v" {
+		t.Errorf("local Get(k) = %q, %v, want v kept", got, err)
+	}
+}
+
+// countingLogger counts warnings.
+type countingLogger struct {
+	mu    sync.Mutex
+	warns int
+}
+
+func (l *countingLogger) Debug(string, ...any) {}
+func (l *countingLogger) Info(string, ...any)  {}
+func (l *countingLogger) Error(string, ...any) {}
+
+func (l *countingLogger) Warn(string, ...any) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.warns++
+}
+
+func TestTwoLevel_Run_BacksOff(t *testing.T) {
+	// Arrange: nothing listens on the address
+	mr := miniredis.RunT(t)
+	addr := mr.Addr()
+	mr.Close()
+	client := goredis.NewClient(&goredis.Options{Addr: addr, MaxRetries: -1})
+	t.Cleanup(func() { _ = client.Close() })
+	c := NewTwoLevel(NewRedis(client, "cache:"), client, TwoLevelOptions{})
+
+	logger := &countingLogger{}
+	ctx, cancel := context.WithTimeout(contextx.WithLogger(context.Background(), logger), 500*time.Millisecond)
+	defer cancel()
+
+	// Act
+	start := time.Now()
+	err := c.Run(ctx)
+
+	// Assert: 100ms, 200ms and 400ms waits fit at most four attempts
+	if err != nil {
+		t.Fatalf("Run() error = %v", err)
+	}
+	if elapsed := time.Since(start); elapsed > time.Second {
+		t.Errorf("Run() returned after %v, want soon after ctx is done", elapsed)
+	}
+	if logger.warns == 0 || logger.warns > 4 {
+		t.Errorf("receive failures = %d, want 1 to 4 with backoff", logger.warns)
+	}
+}
@@ -6,6 +6,8 @@ import (
 	"context"
 	"log/slog"
 	"runtime"
+	"slices"
+	"sync/atomic"
 	"time"
 
 	"go.opentelemetry.io/otel/trace"
@@ -31,10 +33,16 @@ type (
 	requestIDKeyType     struct{}
 	traceIDKeyType       struct{}
 	userIDKeyType        struct{}
+	tenantIDKeyType      struct{}
 	correlationIDKeyType struct{}
 	operationKeyType     struct{}
 	serviceKeyType       struct{}
 	environmentKeyType   struct{}
+	localeKeyType        struct{}
+	clientIPKeyType      struct{}
+	userAgentKeyType     struct{}
+	rolesKeyType         struct{}
+	idempotencyKeyType   struct{}
 )
 
 var (
@@ -43,12 +51,25 @@ var (
 	requestIDKey     = requestIDKeyType{}
 	traceIDKey       = traceIDKeyType{}
 	userIDKey        = userIDKeyType{}
+	tenantIDKey      = tenantIDKeyType{}
 	correlationIDKey = correlationIDKeyType{}
 	operationKey     = operationKeyType{}
 	serviceKey       = serviceKeyType{}
 	environmentKey   = environmentKeyType{}
+	localeKey        = localeKeyType{}
+	clientIPKey      = clientIPKeyType{}
+	userAgentKey     = userAgentKeyType{}
+	rolesKey         = rolesKeyType{}
+	idempotencyKey   = idempotencyKeyType{}
 )
 
+// knownKeys lists every key above; Snapshot copies their values.
+var knownKeys = []any{
+	loggerKey, fieldsKey, requestIDKey, traceIDKey, userIDKey, tenantIDKey,
+	correlationIDKey, operationKey, serviceKey, environmentKey, localeKey,
+	clientIPKey, userAgentKey, rolesKey, idempotencyKey,
+}
+
 // defaultLogger is the fallback logger using slog.
 var defaultLogger Logger = &slogAdapter{slog.Default()}
 
@@ -116,6 +137,20 @@ func SetDefaultLogger(logger Logger) {
 	defaultLogger = logger
 }
 
+// includeLogFields controls whether log calls add LogFields; see SetIncludeLogFields.
+var includeLogFields = func() *atomic.Bool {
+	b := new(atomic.Bool)
+	b.Store(true)
+	return b
+}()
+
+// SetIncludeLogFields sets whether every log call includes LogFields
+// (request_id, trace_id, user_id, ...). It is enabled by default; keys
+// passed explicitly or via WithFields take precedence.
+func SetIncludeLogFields(enabled bool) {
+	includeLogFields.Store(enabled)
+}
+
 // Debug logs a debug message with optional structured arguments.
 func (ctx *Contextx) Debug(msg string, args ...any) {
 	ctx.logWithCaller(slog.LevelDebug, msg, args...)
@@ -139,9 +174,14 @@ func (ctx *Contextx) Error(msg string, args ...any) {
 // logWithCaller logs a message with the correct caller location.
 // It captures the caller 3 levels up: Callers() -> logWithCaller() -> Info/Debug/etc() -> business code
 func (ctx *Contextx) logWithCaller(level slog.Level, msg string, args ...any) {
-	// Merge context fields with provided args
+	// Merge context values, context fields and provided args, in that order
 	fields := fieldsFromContext(ctx.Context)
-	allArgs := append(fields, args...)
+	allArgs := make([]any, 0, len(fields)+len(args))
+	allArgs = append(allArgs, fields...)
+	allArgs = append(allArgs, args...)
+	if includeLogFields.Load() {
+		allArgs = append(ctx.missingLogFields(allArgs), allArgs...)
+	}
 
 	// Check for custom logger: in context or via SetDefaultLogger
 	// If a custom logger is set, use it (for testing and custom logger support)
@@ -178,6 +218,33 @@ func (ctx *Contextx) logWithCaller(level slog.Level, msg string, args ...any) {
 	_ = slog.Default().Handler().Handle(ctx.Context, r)
 }
 
+// missingLogFields returns the LogFields whose keys are not among args.
+func (ctx *Contextx) missingLogFields(args []any) []any {
+	logFields := ctx.LogFields()
+	if len(logFields) == 0 {
+		return nil
+	}
+
+	present := make(map[string]bool)
+	for i := 0; i < len(args); i++ {
+		switch a := args[i].(type) {
+		case slog.Attr:
+			present[a.Key] = true
+		case string:
+			present[a] = true
+			i++ // skip the value
+		}
+	}
+
+	missing := make([]any, 0, len(logFields))
+	for i := 0; i+1 < len(logFields); i += 2 {
+		if !present[logFields[i].(string)] {
+			missing = append(missing, logFields[i], logFields[i+1])
+		}
+	}
+	return missing
+}
+
 // WithLogger returns a new Contextx with the given logger attached.
 func (ctx *Contextx) WithLogger(logger Logger) *Contextx {
 	return From(WithLogger(ctx.Context, logger))
@@ -188,6 +255,65 @@ func (ctx *Contextx) WithFields(args ...any) *Contextx {
 	return From(WithFields(ctx.Context, args...))
 }
 
+// ============================================================================
+// Cancellation
+// ============================================================================
+
+// WithCancel returns a cancelable copy of the Contextx, like context.WithCancel.
+func (ctx *Contextx) WithCancel() (*Contextx, context.CancelFunc) {
+	c, cancel := context.WithCancel(ctx.Context)
+	return From(c), cancel
+}
+
+// WithTimeout returns a copy of the Contextx that is canceled after timeout,
+// like context.WithTimeout.
+func (ctx *Contextx) WithTimeout(timeout time.Duration) (*Contextx, context.CancelFunc) {
+	c, cancel := context.WithTimeout(ctx.Context, timeout)
+	return From(c), cancel
+}
+
+// WithDeadline returns a copy of the Contextx that is canceled at deadline,
+// like context.WithDeadline.
+func (ctx *Contextx) WithDeadline(deadline time.Time) (*Contextx, context.CancelFunc) {
+	c, cancel := context.WithDeadline(ctx.Context, deadline)
+	return From(c), cancel
+}
+
+// Detach returns a copy of the Contextx that keeps every value (logger,
+// fields, IDs, trace context) but is never canceled and has no deadline,
+// for goroutines and post-response work that must outlive the request.
+// Bound the detached work with WithTimeout where it could hang.
+func (ctx *Contextx) Detach() *Contextx {
+	return From(context.WithoutCancel(ctx.Context))
+}
+
+// Snapshot returns a new Contextx based on context.Background() holding
+// copies of the contextx values of ctx (logger, fields, IDs, values stored
+// with Set and the rest) and its OpenTelemetry span context, for handing
+// work to worker pools. Unlike Detach it keeps no reference to ctx, so the
+// request context and everything else it carries can be released.
+// Fields registered with RegisterLogField that read other values are kept
+// as plain fields.
+func (ctx *Contextx) Snapshot() *Contextx {
+	c := context.Background()
+	for _, list := range [][]any{knownKeys, allKeys()} {
+		for _, key := range list {
+			if v := ctx.Value(key); v != nil {
+				c = context.WithValue(c, key, v)
+			}
+		}
+	}
+	if includeLogFields.Load() {
+		if fields := unresolvedLogFields(ctx.Context, c); len(fields) > 0 {
+			c = WithFields(c, fields...)
+		}
+	}
+	if sc := trace.SpanContextFromContext(ctx.Context); sc.IsValid() {
+		c = trace.ContextWithSpanContext(c, sc)
+	}
+	return From(c)
+}
+
 // ============================================================================
 // Request ID
 // ============================================================================
@@ -283,6 +409,35 @@ func (ctx *Contextx) UserID() string {
 	return GetUserID(ctx.Context)
 }
 
+// ============================================================================
+// Tenant ID
+// ============================================================================
+
+// WithTenantID returns a new context with the tenant ID attached.
+func WithTenantID(c context.Context, tenantID string) context.Context {
+	return context.WithValue(c, tenantIDKey, tenantID)
+}
+
+// GetTenantID extracts the tenant ID from context.
+// Returns empty string if not found.
+func GetTenantID(c context.Context) string {
+	if v, ok := c.Value(tenantIDKey).(string); ok {
+		return v
+	}
+
+	return ""
+}
+
+// WithTenantID returns a new Contextx with the tenant ID attached.
+func (ctx *Contextx) WithTenantID(tenantID string) *Contextx {
+	return From(WithTenantID(ctx.Context, tenantID))
+}
+
+// TenantID returns the tenant ID from context.
+func (ctx *Contextx) TenantID() string {
+	return GetTenantID(ctx.Context)
+}
+
 // ============================================================================
 // Correlation ID (for cross-service tracing)
 // ============================================================================
@@ -399,6 +554,160 @@ func (ctx *Contextx) Environment() string {
 	return GetEnvironment(ctx.Context)
 }
 
+// ============================================================================
+// Locale (BCP 47 language tag, e.g. en, zh-TW)
+// ============================================================================
+
+// WithLocale returns a new context with the user's locale attached.
+func WithLocale(c context.Context, locale string) context.Context {
+	return context.WithValue(c, localeKey, locale)
+}
+
+// GetLocale extracts the user's locale from context.
+// Returns empty string if not found.
+func GetLocale(c context.Context) string {
+	if v, ok := c.Value(localeKey).(string); ok {
+		return v
+	}
+
+	return ""
+}
+
+// WithLocale returns a new Contextx with the user's locale attached.
+func (ctx *Contextx) WithLocale(locale string) *Contextx {
+	return From(WithLocale(ctx.Context, locale))
+}
+
+// Locale returns the user's locale from context.
+func (ctx *Contextx) Locale() string {
+	return GetLocale(ctx.Context)
+}
+
+// ============================================================================
+// Client IP and User Agent
+// ============================================================================
+
+// WithClientIP returns a new context with the client IP address attached.
+func WithClientIP(c context.Context, ip string) context.Context {
+	return context.WithValue(c, clientIPKey, ip)
+}
+
+// GetClientIP extracts the client IP address from context.
+// Returns empty string if not found.
+func GetClientIP(c context.Context) string {
+	if v, ok := c.Value(clientIPKey).(string); ok {
+		return v
+	}
+
+	return ""
+}
+
+// WithClientIP returns a new Contextx with the client IP address attached.
+func (ctx *Contextx) WithClientIP(ip string) *Contextx {
+	return From(WithClientIP(ctx.Context, ip))
+}
+
+// ClientIP returns the client IP address from context.
+func (ctx *Contextx) ClientIP() string {
+	return GetClientIP(ctx.Context)
+}
+
+// WithUserAgent returns a new context with the client user agent attached.
+func WithUserAgent(c context.Context, userAgent string) context.Context {
+	return context.WithValue(c, userAgentKey, userAgent)
+}
+
+// GetUserAgent extracts the client user agent from context.
+// Returns empty string if not found.
+func GetUserAgent(c context.Context) string {
+	if v, ok := c.Value(userAgentKey).(string); ok {
+		return v
+	}
+
+	return ""
+}
+
+// WithUserAgent returns a new Contextx with the client user agent attached.
+func (ctx *Contextx) WithUserAgent(userAgent string) *Contextx {
+	return From(WithUserAgent(ctx.Context, userAgent))
+}
+
+// UserAgent returns the client user agent from context.
+func (ctx *Contextx) UserAgent() string {
+	return GetUserAgent(ctx.Context)
+}
+
+// ============================================================================
+// Roles
+// ============================================================================
+
+// WithRoles returns a new context with the subject's roles attached.
+// The slice is copied, so later changes by the caller have no effect.
+func WithRoles(c context.Context, roles []string) context.Context {
+	return context.WithValue(c, rolesKey, slices.Clone(roles))
+}
+
+// GetRoles extracts a copy of the subject's roles from context.
+// Returns nil if not found.
+func GetRoles(c context.Context) []string {
+	if v, ok := c.Value(rolesKey).([]string); ok {
+		return slices.Clone(v)
+	}
+
+	return nil
+}
+
+// HasRole reports whether the subject in context has the given role.
+func HasRole(c context.Context, role string) bool {
+	v, _ := c.Value(rolesKey).([]string)
+	return slices.Contains(v, role)
+}
+
+// WithRoles returns a new Contextx with the subject's roles attached.
+func (ctx *Contextx) WithRoles(roles []string) *Contextx {
+	return From(WithRoles(ctx.Context, roles))
+}
+
+// Roles returns the subject's roles from context.
+func (ctx *Contextx) Roles() []string {
+	return GetRoles(ctx.Context)
+}
+
+// HasRole reports whether the subject in context has the given role.
+func (ctx *Contextx) HasRole(role string) bool {
+	return HasRole(ctx.Context, role)
+}
+
+// ============================================================================
+// Idempotency Key
+// ============================================================================
+
+// WithIdempotencyKey returns a new context with the idempotency key attached,
+// e.g. from the Idempotency-Key header.
+func WithIdempotencyKey(c context.Context, key string) context.Context {
+	return context.WithValue(c, idempotencyKey, key)
+}
+
+// GetIdempotencyKey extracts the idempotency key from context.
+// Returns empty string if not found.
+func GetIdempotencyKey(c context.Context) string {
+	if v, ok := c.Value(idempotencyKey).(string); ok {
+		return v
+	}
+
+	return ""
+}
+
+// WithIdempotencyKey returns a new Contextx with the idempotency key attached.
+func (ctx *Contextx) WithIdempotencyKey(key string) *Contextx {
+	return From(WithIdempotencyKey(ctx.Context, key))
+}
+
+// IdempotencyKey returns the idempotency key from context.
+func (ctx *Contextx) IdempotencyKey() string {
+	return GetIdempotencyKey(ctx.Context)
+}
+
 // ============================================================================
 // Convenience methods
 // ============================================================================
@@ -433,7 +742,8 @@ func (ctx *Contextx) SpanID() string {
 	return GetSpanID(ctx.Context)
 }
 
-// LogFields returns common context values as log fields.
+// LogFields returns common context values as log fields, followed by
+// those registered with RegisterLogField.
 // Useful for automatically including context info in logs.
 func (ctx *Contextx) LogFields() []any {
 	var fields []any
@@ -462,9 +772,21 @@ func (ctx *Contextx) LogFields() []any {
 		fields = append(fields, "user_id", uid)
 	}
 
+	if tid := ctx.TenantID(); tid != "" {
+		fields = append(fields, "tenant_id", tid)
+	}
+
 	if cid := ctx.CorrelationID(); cid != "" {
 		fields = append(fields, "correlation_id", cid)
 	}
 
-	return fields
+	if ip := ctx.ClientIP(); ip != "" {
+		fields = append(fields, "client_ip", ip)
+	}
+
+	if ua := ctx.UserAgent(); ua != "" {
+		fields = append(fields, "user_agent", ua)
+	}
+
+	return append(fields, customLogFields(ctx.Context)...)
 }
@@ -2,7 +2,12 @@ package contextx
 
 import (
 	"context"
+	"log/slog"
+	"slices"
 	"testing"
+	"time"
+
+	"go.opentelemetry.io/otel/trace"
 )
 
 // mockLogger is a test logger that captures log calls.
@@ -108,6 +113,55 @@ func TestWithFields(t *testing.T) {
 	}
 }
 
+func TestLogIncludesLogFields(t *testing.T) {
+	t.Run("typed values are logged", func(t *testing.T) {
+		mock := &mockLogger{}
+		ctx := Background().
+			WithLogger(mock).
+			WithRequestID("req-1").
+			WithUserID("user-1").
+			WithFields("order_id", "o-1")
+
+		ctx.Info("order placed", "amount", 42)
+
+		want := []any{"request_id", "req-1", "user_id", "user-1", "order_id", "o-1", "amount", 42}
+		if got := mock.infoCalls[0].args; !slices.Equal(got, want) {
+			t.Errorf("expected args %v, got %v", want, got)
+		}
+	})
+
+	t.Run("explicit keys take precedence", func(t *testing.T) {
+		mock := &mockLogger{}
+		ctx := Background().
+			WithLogger(mock).
+			WithRequestID("req-1").
+			WithTraceID("trace-ctx")
+
+		ctx.Warn("retrying", "trace_id", "trace-arg", slog.String("request_id", "req-attr"))
+
+		got := mock.warnCalls[0].args
+		if len(got) != 3 || got[0] != "trace_id" || got[1] != "trace-arg" {
+			t.Fatalf("expected only the explicit args, got %v", got)
+		}
+		if attr, ok := got[2].(slog.Attr); !ok || attr.Value.String() != "req-attr" {
+			t.Errorf("expected request_id attr req-attr, got %v", got[2])
+		}
+	})
+
+	t.Run("disabled", func(t *testing.T) {
+		SetIncludeLogFields(false)
+		t.Cleanup(func() { SetIncludeLogFields(true) })
+		mock := &mockLogger{}
+		ctx := Background().WithLogger(mock).WithRequestID("req-1")
+
+		ctx.Info("quiet")
+
+		if got := mock.infoCalls[0].args; len(got) != 0 {
+			t.Errorf("expected no args, got %v", got)
+		}
+	})
+}
+
 func TestContextxWithLogger(t *testing.T) {
 	mock := &mockLogger{}
 	ctx := Background().WithLogger(mock)
@@ -351,6 +405,35 @@ func TestCorrelationID(t *testing.T) {
 	})
 }
 
+func TestTenantID(t *testing.T) {
+	t.Run("WithTenantID and GetTenantID", func(t *testing.T) {
+		c := context.Background()
+		c = WithTenantID(c, "tenant-1")
+
+		got := GetTenantID(c)
+		if got != "tenant-1" {
+			t.Errorf("expected 'tenant-1', got %q", got)
+		}
+	})
+
+	t.Run("GetTenantID returns empty for missing", func(t *testing.T) {
+		c := context.Background()
+		got := GetTenantID(c)
+
+		if got != "" {
+			t.Errorf("expected empty string, got %q", got)
+		}
+	})
+
+	t.Run("Contextx methods", func(t *testing.T) {
+		ctx := Background().WithTenantID("tenant-2")
+
+		if ctx.TenantID() != "tenant-2" {
+			t.Errorf("expected 'tenant-2', got %q", ctx.TenantID())
+		}
+	})
+}
+
 // ============================================================================
 // LogFields Tests
 // ============================================================================
@@ -403,6 +486,21 @@ func TestLogFields(t *testing.T) {
 		}
 	})
 
+	t.Run("includes client metadata", func(t *testing.T) {
+		ctx := Background().WithClientIP("203.0.113.7").WithUserAgent("curl/8.5.0")
+		fields := ctx.LogFields()
+
+		want := []any{"client_ip", "203.0.113.7", "user_agent", "curl/8.5.0"}
+		if len(fields) != len(want) {
+			t.Fatalf("expected %d fields, got %d", len(want), len(fields))
+		}
+		for i := range want {
+			if fields[i] != want[i] {
+				t.Errorf("fields[%d] = %v, want %v", i, fields[i], want[i])
+			}
+		}
+	})
+
 	t.Run("returns partial fields", func(t *testing.T) {
 		ctx := Background().WithRequestID("req-only")
 		fields := ctx.LogFields()
@@ -544,6 +642,285 @@ func TestEnvironment(t *testing.T) {
 	})
 }
 
+// ============================================================================
+// Locale Tests
+// ============================================================================
+
+func TestLocale(t *testing.T) {
+	t.Run("WithLocale and GetLocale", func(t *testing.T) {
+		c := context.Background()
+		c = WithLocale(c, "zh-TW")
+
+		got := GetLocale(c)
+		if got != "zh-TW" {
+			t.Errorf("expected 'zh-TW', got %q", got)
+		}
+	})
+
+	t.Run("GetLocale returns empty for missing", func(t *testing.T) {
+		c := context.Background()
+		got := GetLocale(c)
+
+		if got != "" {
+			t.Errorf("expected empty string, got %q", got)
+		}
+	})
+
+	t.Run("Contextx methods", func(t *testing.T) {
+		ctx := Background().WithLocale("en")
+
+		if ctx.Locale() != "en" {
+			t.Errorf("expected 'en', got %q", ctx.Locale())
+		}
+	})
+}
+
+// ============================================================================
+// Client Metadata Tests
+// ============================================================================
+
+func TestClientMetadata(t *testing.T) {
+	t.Run("WithClientIP and GetClientIP", func(t *testing.T) {
+		c := context.Background()
+		c = WithClientIP(c, "198.51.100.1")
+
+		got := GetClientIP(c)
+		if got != "198.51.100.1" {
+			t.Errorf("expected '198.51.100.1', got %q", got)
+		}
+	})
+
+	t.Run("WithUserAgent and GetUserAgent", func(t *testing.T) {
+		c := context.Background()
+		c = WithUserAgent(c, "Mozilla/5.0")
+
+		got := GetUserAgent(c)
+		if got != "Mozilla/5.0" {
+			t.Errorf("expected 'Mozilla/5.0', got %q", got)
+		}
+	})
+
+	t.Run("returns empty for missing", func(t *testing.T) {
+		c := context.Background()
+
+		if got := GetClientIP(c); got != "" {
+			t.Errorf("expected empty client IP, got %q", got)
+		}
+		if got := GetUserAgent(c); got != "" {
+			t.Errorf("expected empty user agent, got %q", got)
+		}
+	})
+
+	t.Run("Contextx methods", func(t *testing.T) {
+		ctx := Background().WithClientIP("::1").WithUserAgent("go-test")
+
+		if ctx.ClientIP() != "::1" {
+			t.Errorf("expected '::1', got %q", ctx.ClientIP())
+		}
+		if ctx.UserAgent() != "go-test" {
+			t.Errorf("expected 'go-test', got %q", ctx.UserAgent())
+		}
+	})
+}
+
+// ============================================================================
+// Roles Tests
+// ============================================================================
+
+func TestRoles(t *testing.T) {
+	t.Run("WithRoles and GetRoles", func(t *testing.T) {
+		c := context.Background()
+		c = WithRoles(c, []string{"admin", "editor"})
+
+		got := GetRoles(c)
+		if !slices.Equal(got, []string{"admin", "editor"}) {
+			t.Errorf("expected [admin editor], got %v", got)
+		}
+	})
+
+	t.Run("GetRoles returns nil for missing", func(t *testing.T) {
+		c := context.Background()
+
+		if got := GetRoles(c); got != nil {
+			t.Errorf("expected nil, got %v", got)
+		}
+		if HasRole(c, "admin") {
+			t.Error("expected HasRole to be false without roles")
+		}
+	})
+
+	t.Run("HasRole", func(t *testing.T) {
+		c := WithRoles(context.Background(), []string{"viewer"})
+
+		if !HasRole(c, "viewer") {
+			t.Error("expected HasRole(viewer) to be true")
+		}
+		if HasRole(c, "admin") {
+			t.Error("expected HasRole(admin) to be false")
+		}
+	})
+
+	t.Run("roles are copied", func(t *testing.T) {
+		roles := []string{"viewer"}
+		c := WithRoles(context.Background(), roles)
+		roles[0] = "admin"
+		GetRoles(c)[0] = "admin"
+
+		if HasRole(c, "admin") {
+			t.Error("expected stored roles to be unaffected by caller changes")
+		}
+	})
+
+	t.Run("Contextx methods", func(t *testing.T) {
+		ctx := Background().WithRoles([]string{"operator"})
+
+		if !ctx.HasRole("operator") {
+			t.Error("expected HasRole(operator) to be true")
+		}
+		if got := ctx.Roles(); !slices.Equal(got, []string{"operator"}) {
+			t.Errorf("expected [operator], got %v", got)
+		}
+	})
+}
+
+// ============================================================================
+// Idempotency Key Tests
+// ============================================================================
+
+func TestIdempotencyKey(t *testing.T) {
+	t.Run("WithIdempotencyKey and GetIdempotencyKey", func(t *testing.T) {
+		c := context.Background()
+		c = WithIdempotencyKey(c, "key-1")
+
+		got := GetIdempotencyKey(c)
+		if got != "key-1" {
+			t.Errorf("expected 'key-1', got %q", got)
+		}
+	})
+
+	t.Run("GetIdempotencyKey returns empty for missing", func(t *testing.T) {
+		c := context.Background()
+		got := GetIdempotencyKey(c)
+
+		if got != "" {
+			t.Errorf("expected empty string, got %q", got)
+		}
+	})
+
+	t.Run("Contextx methods", func(t *testing.T) {
+		ctx := Background().WithIdempotencyKey("key-2")
+
+		if ctx.IdempotencyKey() != "key-2" {
+			t.Errorf("expected 'key-2', got %q", ctx.IdempotencyKey())
+		}
+	})
+}
+
+// ============================================================================
+// Cancellation Tests
+// ============================================================================
+
+func TestCancellation(t *testing.T) {
+	t.Run("WithCancel", func(t *testing.T) {
+		ctx, cancel := Background().WithRequestID("req-1").WithCancel()
+		cancel()
+
+		if ctx.Err() != context.Canceled {
+			t.Errorf("expected context.Canceled, got %v", ctx.Err())
+		}
+		if ctx.RequestID() != "req-1" {
+			t.Errorf("expected values to be kept, got %q", ctx.RequestID())
+		}
+	})
+
+	t.Run("WithTimeout", func(t *testing.T) {
+		ctx, cancel := Background().WithTimeout(time.Millisecond)
+		defer cancel()
+
+		<-ctx.Done()
+		if ctx.Err() != context.DeadlineExceeded {
+			t.Errorf("expected context.DeadlineExceeded, got %v", ctx.Err())
+		}
+	})
+
+	t.Run("WithDeadline", func(t *testing.T) {
+		deadline := time.Now().Add(time.Hour)
+		ctx, cancel := Background().WithUserID("user-1").WithDeadline(deadline)
+		defer cancel()
+
+		got, ok := ctx.Deadline()
+		if !ok || !got.Equal(deadline) {
+			t.Errorf("expected deadline %v, got %v (ok=%v)", deadline, got, ok)
+		}
+		if ctx.UserID() != "user-1" {
+			t.Errorf("expected values to be kept, got %q", ctx.UserID())
+		}
+	})
+}
+
+func TestDetach(t *testing.T) {
+	mock := &mockLogger{}
+	sc := trace.NewSpanContext(trace.SpanContextConfig{TraceID: trace.TraceID{1}, SpanID: trace.SpanID{2}})
+	parent, cancel := From(trace.ContextWithSpanContext(context.Background(), sc)).
+		WithLogger(mock).
+		WithRequestID("req-1").
+		WithFields("order_id", "o-1").
+		WithTimeout(time.Hour)
+	cancel()
+
+	ctx := parent.Detach()
+
+	if ctx.Err() != nil {
+		t.Errorf("expected detached context not to be canceled, got %v", ctx.Err())
+	}
+	if _, ok := ctx.Deadline(); ok {
+		t.Error("expected detached context to have no deadline")
+	}
+	if ctx.RequestID() != "req-1" {
+		t.Errorf("expected request ID to be kept, got %q", ctx.RequestID())
+	}
+	if got := trace.SpanContextFromContext(ctx).TraceID(); got != sc.TraceID() {
+		t.Errorf("expected trace context to be kept, got %v", got)
+	}
+	ctx.Info("after response")
+	if len(mock.infoCalls) != 1 || !slices.Contains(mock.infoCalls[0].args, "order_id") {
+		t.Errorf("expected logger and fields to be kept, got %v", mock.infoCalls)
+	}
+}
+
+func TestSnapshot(t *testing.T) {
+	mock := &mockLogger{}
+	sc := trace.NewSpanContext(trace.SpanContextConfig{TraceID: trace.TraceID{1}, SpanID: trace.SpanID{2}})
+	type privateKey struct{}
+	parent, cancel := From(trace.ContextWithSpanContext(context.Background(), sc)).
+		WithLogger(mock).
+		WithRequestID("req-1").
+		WithRoles([]string{"admin"}).
+		WithFields("order_id", "o-1").
+		WithCancel()
+	parent = From(context.WithValue(parent, privateKey{}, "request-scoped"))
+	cancel()
+
+	ctx := parent.Snapshot()
+
+	if ctx.Err() != nil {
+		t.Errorf("expected snapshot not to be canceled, got %v", ctx.Err())
+	}
+	if ctx.RequestID() != "req-1" || !ctx.HasRole("admin") {
+		t.Errorf("expected values to be copied, got %q and %v", ctx.RequestID(), ctx.Roles())
+	}
+	if got := trace.SpanContextFromContext(ctx).TraceID(); got != sc.TraceID() {
+		t.Errorf("expected span context to be copied, got %v", got)
+	}
+	if ctx.Value(privateKey{}) != nil {
+		t.Error("expected values outside contextx not to be copied")
+	}
+	ctx.Info("in worker")
+	if len(mock.infoCalls) != 1 || !slices.Contains(mock.infoCalls[0].args, "order_id") {
+		t.Errorf("expected logger and fields to be copied, got %v", mock.infoCalls)
+	}
+}
+
 // ============================================================================
 // Full Integration Test
 // ============================================================================
@@ -0,0 +1,79 @@
+package contextx
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"sync"
+)
+
+// builtinFields are the names LogFields reports for the built-in values.
+var builtinFields = []string{
+	"service", "environment", "operation", "request_id", "trace_id", "user_id",
+	"tenant_id", "correlation_id", "client_ip", "user_agent",
+}
+
+// logField is an application-defined LogFields entry.
+type logField struct {
+	name    string
+	extract func(context.Context) string
+}
+
+var (
+	logFieldsMu sync.RWMutex
+	logFields   []logField
+)
+
+// RegisterLogField adds an application-specific value to LogFields, and so
+// to every log call, after the built-in ones. extract returns the value
+// of ctx, or "" to omit it. Register fields during initialization:
+//
+//	var orderKey = contextx.NewKey[string]("order_id")
+//
+//	func init() {
+//		contextx.RegisterLogField("order_id", func(c context.Context) string {
+//			return contextx.GetOr(c, orderKey, "")
+//		})
+//	}
+//
+// It panics if name is empty, built in or already registered.
+func RegisterLogField(name string, extract func(context.Context) string) {
+	logFieldsMu.Lock()
+	defer logFieldsMu.Unlock()
+
+	if name == "" || slices.Contains(builtinFields, name) ||
+		slices.ContainsFunc(logFields, func(f logField) bool { return f.name == name }) {
+		panic(fmt.Sprintf("contextx: log field %q already defined", name))
+	}
+	logFields = append(logFields, logField{name: name, extract: extract})
+}
+
+// unresolvedLogFields returns the registered fields that have a value in
+// from but not in to, e.g. because extract reads a value Snapshot does not
+// copy.
+func unresolvedLogFields(from, to context.Context) []any {
+	logFieldsMu.RLock()
+	defer logFieldsMu.RUnlock()
+
+	var fields []any
+	for _, f := range logFields {
+		if v := f.extract(from); v != "" && f.extract(to) == "" {
+			fields = append(fields, f.name, v)
+		}
+	}
+	return fields
+}
+
+// customLogFields returns the registered fields that have a value in c.
+func customLogFields(c context.Context) []any {
+	logFieldsMu.RLock()
+	defer logFieldsMu.RUnlock()
+
+	var fields []any
+	for _, f := range logFields {
+		if v := f.extract(c); v != "" {
+			fields = append(fields, f.name, v)
+		}
+	}
+	return fields
+}
@@ -0,0 +1,101 @@
+package contextx
+
+import (
+	"context"
+	"slices"
+	"testing"
+)
+
+func TestRegisterLogField(t *testing.T) {
+	orderKey := NewKey[string]("order_id")
+	RegisterLogField("test_order_id", func(c context.Context) string {
+		return GetOr(c, orderKey, "")
+	})
+	t.Cleanup(func() {
+		logFieldsMu.Lock()
+		defer logFieldsMu.Unlock()
+		logFields = slices.DeleteFunc(logFields, func(f logField) bool { return f.name == "test_order_id" })
+	})
+
+	t.Run("included after built-in fields", func(t *testing.T) {
+		ctx := From(Set(Background().WithRequestID("req-1"), orderKey, "o-1"))
+
+		want := []any{"request_id", "req-1", "test_order_id", "o-1"}
+		if got := ctx.LogFields(); !slices.Equal(got, want) {
+			t.Errorf("expected %v, got %v", want, got)
+		}
+	})
+
+	t.Run("omitted when empty", func(t *testing.T) {
+		if got := Background().LogFields(); len(got) != 0 {
+			t.Errorf("expected no fields, got %v", got)
+		}
+	})
+
+	t.Run("included in log calls", func(t *testing.T) {
+		mock := &mockLogger{}
+		ctx := From(Set(Background().WithLogger(mock), orderKey, "o-2"))
+
+		ctx.Info("order shipped")
+
+		if got := mock.infoCalls[0].args; !slices.Equal(got, []any{"test_order_id", "o-2"}) {
+			t.Errorf("expected [test_order_id o-2], got %v", got)
+		}
+	})
+
+	t.Run("survives Snapshot", func(t *testing.T) {
+		mock := &mockLogger{}
+		parent, cancel := From(Set(Background().WithLogger(mock), orderKey, "o-3")).WithCancel()
+		cancel()
+
+		ctx := parent.Snapshot()
+		ctx.Info("order shipped")
+
+		if got, _ := Get(ctx, orderKey); got != "o-3" {
+			t.Errorf("expected Set value to be copied, got %q", got)
+		}
+		if got := mock.infoCalls[0].args; !slices.Equal(got, []any{"test_order_id", "o-3"}) {
+			t.Errorf("expected [test_order_id o-3], got %v", got)
+		}
+	})
+
+	t.Run("duplicate and built-in names panic", func(t *testing.T) {
+		for _, name := range []string{"test_order_id", "request_id", ""} {
+			func() {
+				defer func() {
+					if recover() == nil {
+						t.Errorf("expected RegisterLogField(%q) to panic", name)
+					}
+				}()
+				RegisterLogField(name, func(context.Context) string { return "" })
+			}()
+		}
+	})
+}
+
+func TestRegisterLogField_SnapshotUnknownValue(t *testing.T) {
+	// The field reads a value stored outside contextx, which Snapshot
+	// cannot copy
+	type tenantPlanKey struct{}
+	RegisterLogField("test_plan", func(c context.Context) string {
+		v, _ := c.Value(tenantPlanKey{}).(string)
+		return v
+	})
+	t.Cleanup(func() {
+		logFieldsMu.Lock()
+		defer logFieldsMu.Unlock()
+		logFields = slices.DeleteFunc(logFields, func(f logField) bool { return f.name == "test_plan" })
+	})
+	mock := &mockLogger{}
+	parent := From(context.WithValue(Background().WithLogger(mock), tenantPlanKey{}, "pro"))
+
+	ctx := parent.Snapshot()
+	ctx.Info("in worker")
+
+	if ctx.Value(tenantPlanKey{}) != nil {
+		t.Error("expected values outside contextx not to be copied")
+	}
+	if got := mock.infoCalls[0].args; !slices.Equal(got, []any{"test_plan", "pro"}) {
+		t.Errorf("expected [test_plan pro], got %v", got)
+	}
+}
@@ -0,0 +1,116 @@
+package contextx
+
+import (
+	"context"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+)
+
+// gRPC metadata keys: the canonical HTTP headers, lower-cased as gRPC
+// requires, so both transports share one propagation convention.
+const (
+	MetadataRequestID     = "x-request-id"
+	MetadataCorrelationID = "x-correlation-id"
+	MetadataTenantID      = "x-tenant-id"
+	MetadataUserID        = "x-user-id"
+)
+
+// InjectGRPC sets the canonical metadata keys in md from the values of c.
+// Values missing from c leave their keys untouched.
+func InjectGRPC(c context.Context, md metadata.MD) {
+	for key, value := range map[string]string{
+		MetadataRequestID:     GetRequestID(c),
+		MetadataCorrelationID: GetCorrelationID(c),
+		MetadataTenantID:      GetTenantID(c),
+		MetadataUserID:        GetUserID(c),
+	} {
+		if value != "" {
+			md.Set(key, value)
+		}
+	}
+}
+
+// ExtractGRPC returns c with the values of the canonical metadata keys in
+// md. As with ExtractHTTP, tenant and user IDs are identity claims to be
+// trusted only from trusted callers.
+func ExtractGRPC(c context.Context, md metadata.MD) context.Context {
+	get := func(key string) string {
+		if v := md.Get(key); len(v) > 0 {
+			return v[0]
+		}
+		return ""
+	}
+	if v := get(MetadataRequestID); v != "" {
+		c = WithRequestID(c, v)
+	}
+	if v := get(MetadataCorrelationID); v != "" {
+		c = WithCorrelationID(c, v)
+	}
+	if v := get(MetadataTenantID); v != "" {
+		c = WithTenantID(c, v)
+	}
+	if v := get(MetadataUserID); v != "" {
+		c = WithUserID(c, v)
+	}
+	return c
+}
+
+// outgoingGRPC returns c with its values added to the outgoing metadata.
+func outgoingGRPC(c context.Context) context.Context {
+	md, _ := metadata.FromOutgoingContext(c)
+	md = md.Copy()
+	InjectGRPC(c, md)
+	return metadata.NewOutgoingContext(c, md)
+}
+
+// incomingGRPC returns c with the request and correlation IDs of its
+// incoming metadata. Identity keys are ignored: the authentication
+// interceptor sets them.
+func incomingGRPC(c context.Context) context.Context {
+	md, _ := metadata.FromIncomingContext(c)
+	md = md.Copy()
+	md.Delete(MetadataTenantID)
+	md.Delete(MetadataUserID)
+	return ExtractGRPC(c, md)
+}
+
+// UnaryClientInterceptor propagates the values of each call's context as
+// outgoing metadata.
+func UnaryClientInterceptor() grpc.UnaryClientInterceptor {
+	return func(ctx context.Context, method string, req, reply any, cc *grpc.ClientConn, invoker grpc.UnaryInvoker, opts ...grpc.CallOption) error {
+		return invoker(outgoingGRPC(ctx), method, req, reply, cc, opts...)
+	}
+}
+
+// StreamClientInterceptor propagates the values of each stream's context
+// as outgoing metadata.
+func StreamClientInterceptor() grpc.StreamClientInterceptor {
+	return func(ctx context.Context, desc *grpc.StreamDesc, cc *grpc.ClientConn, method string, streamer grpc.Streamer, opts ...grpc.CallOption) (grpc.ClientStream, error) {
+		return streamer(outgoingGRPC(ctx), desc, cc, method, opts...)
+	}
+}
+
+// UnaryServerInterceptor restores the request and correlation IDs from
+// incoming metadata.
+func UnaryServerInterceptor() grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req any, _ *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+		return handler(incomingGRPC(ctx), req)
+	}
+}
+
+// StreamServerInterceptor restores the request and correlation IDs from
+// incoming metadata.
+func StreamServerInterceptor() grpc.StreamServerInterceptor {
+	return func(srv any, ss grpc.ServerStream, _ *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		return handler(srv, &serverStream{ServerStream: ss, ctx: incomingGRPC(ss.Context())})
+	}
+}
+
+// serverStream overrides the context of a grpc.ServerStream.
+type serverStream struct {
+	grpc.ServerStream
+	ctx context.Context
+}
+
+func (s *serverStream) Context() context.Context { return s.ctx }
@@ -0,0 +1,78 @@
+package contextx
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+)
+
+func TestInjectExtractGRPC(t *testing.T) {
+	c := WithRequestID(context.Background(), "req-1")
+	c = WithCorrelationID(c, "corr-1")
+	c = WithTenantID(c, "tenant-1")
+	c = WithUserID(c, "user-1")
+	md := metadata.MD{}
+
+	InjectGRPC(c, md)
+	got := From(ExtractGRPC(context.Background(), md))
+
+	for _, h := range []string{HeaderRequestID, HeaderCorrelationID, HeaderTenantID, HeaderUserID} {
+		if len(md.Get(strings.ToLower(h))) != 1 {
+			t.Errorf("expected metadata key matching header %s, got %v", h, md)
+		}
+	}
+	if got.RequestID() != "req-1" || got.CorrelationID() != "corr-1" ||
+		got.TenantID() != "tenant-1" || got.UserID() != "user-1" {
+		t.Errorf("unexpected values: %v", got.LogFields())
+	}
+}
+
+func TestUnaryClientInterceptor(t *testing.T) {
+	c := metadata.AppendToOutgoingContext(context.Background(), "authorization", "Bearer t")
+	c = WithRequestID(c, "req-1")
+
+	var md metadata.MD
+	invoker := func(ctx context.Context, _ string, _, _ any, _ *grpc.ClientConn, _ ...grpc.CallOption) error {
+		md, _ = metadata.FromOutgoingContext(ctx)
+		return nil
+	}
+
+	if err := UnaryClientInterceptor()(c, "/orders.v1.Orders/Get", nil, nil, nil, invoker); err != nil {
+		t.Fatalf("interceptor error = %v", err)
+	}
+
+	if got := md.Get(MetadataRequestID); len(got) != 1 || got[0] != "req-1" {
+		t.Errorf("expected x-request-id req-1, got %v", got)
+	}
+	if got := md.Get("authorization"); len(got) != 1 {
+		t.Errorf("expected existing metadata to be kept, got %v", md)
+	}
+}
+
+func TestUnaryServerInterceptor(t *testing.T) {
+	c := metadata.NewIncomingContext(context.Background(), metadata.Pairs(
+		MetadataRequestID, "req-1",
+		MetadataCorrelationID, "corr-1",
+		MetadataUserID, "admin",
+	))
+
+	var got *Contextx
+	handler := func(ctx context.Context, _ any) (any, error) {
+		got = From(ctx)
+		return nil, nil
+	}
+
+	if _, err := UnaryServerInterceptor()(c, nil, &grpc.UnaryServerInfo{}, handler); err != nil {
+		t.Fatalf("interceptor error = %v", err)
+	}
+
+	if got.RequestID() != "req-1" || got.CorrelationID() != "corr-1" {
+		t.Errorf("expected req-1/corr-1, got %q/%q", got.RequestID(), got.CorrelationID())
+	}
+	if got.UserID() != "" {
+		t.Errorf("expected identity metadata to be ignored, got %q", got.UserID())
+	}
+}
@@ -0,0 +1,70 @@
+package contextx
+
+import (
+	"context"
+	"net/http"
+)
+
+// Canonical HTTP headers carrying context values between services.
+// The trace context travels separately in the W3C traceparent header,
+// handled by the OpenTelemetry instrumentation.
+const (
+	HeaderRequestID     = "X-Request-ID"
+	HeaderCorrelationID = "X-Correlation-ID"
+	HeaderTenantID      = "X-Tenant-ID"
+	HeaderUserID        = "X-User-ID"
+)
+
+// InjectHTTP sets the canonical headers in h from the values of c.
+// Values missing from c leave their headers untouched.
+func InjectHTTP(c context.Context, h http.Header) {
+	for key, value := range map[string]string{
+		HeaderRequestID:     GetRequestID(c),
+		HeaderCorrelationID: GetCorrelationID(c),
+		HeaderTenantID:      GetTenantID(c),
+		HeaderUserID:        GetUserID(c),
+	} {
+		if value != "" {
+			h.Set(key, value)
+		}
+	}
+}
+
+// ExtractHTTP returns c with the values of the canonical headers in h.
+// Tenant and user IDs are identity claims: only extract them from trusted
+// callers, and strip them from requests arriving from the outside.
+func ExtractHTTP(c context.Context, h http.Header) context.Context {
+	if v := h.Get(HeaderRequestID); v != "" {
+		c = WithRequestID(c, v)
+	}
+	if v := h.Get(HeaderCorrelationID); v != "" {
+		c = WithCorrelationID(c, v)
+	}
+	if v := h.Get(HeaderTenantID); v != "" {
+		c = WithTenantID(c, v)
+	}
+	if v := h.Get(HeaderUserID); v != "" {
+		c = WithUserID(c, v)
+	}
+	return c
+}
+
+// Transport returns an http.RoundTripper that adds the canonical headers
+// from each request's context before delegating to base
+// (http.DefaultTransport if nil):
+//
+//	client := &http.Client{Transport: contextx.Transport(nil)}
+func Transport(base http.RoundTripper) http.RoundTripper {
+	if base == nil {
+		base = http.DefaultTransport
+	}
+	return roundTripperFunc(func(req *http.Request) (*http.Response, error) {
+		req = req.Clone(req.Context())
+		InjectHTTP(req.Context(), req.Header)
+		return base.RoundTrip(req)
+	})
+}
+
+type roundTripperFunc func(*http.Request) (*http.Response, error)
+
+func (f roundTripperFunc) RoundTrip(req *http.Request) (*http.Response, error) { return f(req) }
@@ -0,0 +1,70 @@
+package contextx
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestInjectExtractHTTP(t *testing.T) {
+	t.Run("round trip", func(t *testing.T) {
+		c := WithRequestID(context.Background(), "req-1")
+		c = WithCorrelationID(c, "corr-1")
+		c = WithTenantID(c, "tenant-1")
+		c = WithUserID(c, "user-1")
+		h := http.Header{}
+
+		InjectHTTP(c, h)
+		got := From(ExtractHTTP(context.Background(), h))
+
+		if h.Get("X-Request-ID") != "req-1" {
+			t.Errorf("expected X-Request-ID req-1, got %q", h.Get("X-Request-ID"))
+		}
+		if got.RequestID() != "req-1" || got.CorrelationID() != "corr-1" ||
+			got.TenantID() != "tenant-1" || got.UserID() != "user-1" {
+			t.Errorf("unexpected values: %v", got.LogFields())
+		}
+	})
+
+	t.Run("missing values leave headers untouched", func(t *testing.T) {
+		h := http.Header{}
+		h.Set(HeaderRequestID, "existing")
+
+		InjectHTTP(context.Background(), h)
+
+		if len(h) != 1 || h.Get(HeaderRequestID) != "existing" {
+			t.Errorf("expected headers to be unchanged, got %v", h)
+		}
+		if fields := From(ExtractHTTP(context.Background(), http.Header{})).LogFields(); len(fields) != 0 {
+			t.Errorf("expected no values, got %v", fields)
+		}
+	})
+}
+
+func TestTransport(t *testing.T) {
+	var got http.Header
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		got = r.Header
+	}))
+	defer srv.Close()
+
+	c := WithCorrelationID(context.Background(), "corr-1")
+	req, err := http.NewRequestWithContext(c, http.MethodGet, srv.URL, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	resp, err := (&http.Client{Transport: Transport(nil)}).Do(req)
+	if err != nil {
+		t.Fatalf("Do() error = %v", err)
+	}
+	resp.Body.Close()
+
+	if got.Get(HeaderCorrelationID) != "corr-1" {
+		t.Errorf("expected X-Correlation-ID corr-1, got %q", got.Get(HeaderCorrelationID))
+	}
+	if req.Header.Get(HeaderCorrelationID) != "" {
+		t.Error("expected the caller's request not to be modified")
+	}
+}
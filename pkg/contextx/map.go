@@ -0,0 +1,45 @@
+package contextx
+
+import "context"
+
+// Keys carrying context values in string maps such as message broker
+// headers (Kafka, NATS, RabbitMQ) and task metadata. They match the
+// LogFields names.
+const (
+	FieldRequestID     = "request_id"
+	FieldCorrelationID = "correlation_id"
+	FieldTenantID      = "tenant_id"
+	FieldUserID        = "user_id"
+)
+
+// InjectMap sets the values of c in m under the Field keys.
+// Values missing from c leave their keys untouched.
+func InjectMap(c context.Context, m map[string]string) {
+	for key, value := range map[string]string{
+		FieldRequestID:     GetRequestID(c),
+		FieldCorrelationID: GetCorrelationID(c),
+		FieldTenantID:      GetTenantID(c),
+		FieldUserID:        GetUserID(c),
+	} {
+		if value != "" {
+			m[key] = value
+		}
+	}
+}
+
+// ExtractMap returns c with the values stored in m by InjectMap.
+func ExtractMap(c context.Context, m map[string]string) context.Context {
+	if v := m[FieldRequestID]; v != "" {
+		c = WithRequestID(c, v)
+	}
+	if v := m[FieldCorrelationID]; v != "" {
+		c = WithCorrelationID(c, v)
+	}
+	if v := m[FieldTenantID]; v != "" {
+		c = WithTenantID(c, v)
+	}
+	if v := m[FieldUserID]; v != "" {
+		c = WithUserID(c, v)
+	}
+	return c
+}
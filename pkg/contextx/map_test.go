@@ -0,0 +1,48 @@
+package contextx
+
+import (
+	"context"
+	"maps"
+	"testing"
+)
+
+func TestInjectExtractMap(t *testing.T) {
+	t.Run("round trip", func(t *testing.T) {
+		c := WithRequestID(context.Background(), "req-1")
+		c = WithCorrelationID(c, "corr-1")
+		c = WithTenantID(c, "tenant-1")
+		c = WithUserID(c, "user-1")
+		m := map[string]string{"content_type": "application/json"}
+
+		InjectMap(c, m)
+		got := From(ExtractMap(context.Background(), m))
+
+		want := map[string]string{
+			"content_type":   "application/json",
+			"request_id":     "req-1",
+			"correlation_id": "corr-1",
+			"tenant_id":      "tenant-1",
+			"user_id":        "user-1",
+		}
+		if !maps.Equal(m, want) {
+			t.Errorf("expected %v, got %v", want, m)
+		}
+		if got.RequestID() != "req-1" || got.CorrelationID() != "corr-1" ||
+			got.TenantID() != "tenant-1" || got.UserID() != "user-1" {
+			t.Errorf("unexpected values: %v", got.LogFields())
+		}
+	})
+
+	t.Run("missing values", func(t *testing.T) {
+		m := map[string]string{}
+
+		InjectMap(context.Background(), m)
+
+		if len(m) != 0 {
+			t.Errorf("expected no keys, got %v", m)
+		}
+		if fields := From(ExtractMap(context.Background(), nil)).LogFields(); len(fields) != 0 {
+			t.Errorf("expected no values, got %v", fields)
+		}
+	})
+}
@@ -0,0 +1,70 @@
+package contextx
+
+import (
+	"context"
+	"sync"
+)
+
+var (
+	keysMu sync.RWMutex
+	keys   []any // every Key, copied by Snapshot
+)
+
+// Key is a typed context key for application-defined values. Declare one
+// per value, usually as a package-level variable:
+//
+//	var cartKey = contextx.NewKey[*Cart]("cart")
+//
+//	ctx = contextx.Set(ctx, cartKey, cart)
+//	cart, ok := contextx.Get(ctx, cartKey)
+//
+// Keys are compared by identity, so two keys never collide even if they
+// share a name and type. Every key is remembered so Snapshot can copy its
+// value; do not create keys per request.
+type Key[T any] struct {
+	name string
+}
+
+// NewKey returns a new key for values of type T. The name is only used
+// for debugging.
+func NewKey[T any](name string) *Key[T] {
+	k := &Key[T]{name: name}
+
+	keysMu.Lock()
+	defer keysMu.Unlock()
+	keys = append(keys, k)
+	return k
+}
+
+// allKeys returns every key created with NewKey.
+func allKeys() []any {
+	keysMu.RLock()
+	defer keysMu.RUnlock()
+
+	return keys[:len(keys):len(keys)]
+}
+
+// String returns the key name.
+func (k *Key[T]) String() string {
+	return "contextx.Key(" + k.name + ")"
+}
+
+// Set returns a new context with val stored under key.
+func Set[T any](c context.Context, key *Key[T], val T) context.Context {
+	return context.WithValue(c, key, val)
+}
+
+// Get returns the value stored under key, and whether it was found.
+func Get[T any](c context.Context, key *Key[T]) (T, bool) {
+	v, ok := c.Value(key).(T)
+	return v, ok
+}
+
+// GetOr returns the value stored under key, or def if not found.
+func GetOr[T any](c context.Context, key *Key[T], def T) T {
+	if v, ok := Get(c, key); ok {
+		return v
+	}
+
+	return def
+}
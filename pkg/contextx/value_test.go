@@ -0,0 +1,61 @@
+package contextx
+
+import (
+	"context"
+	"testing"
+)
+
+type cart struct {
+	items int
+}
+
+func TestTypedValues(t *testing.T) {
+	cartKey := NewKey[*cart]("cart")
+	limitKey := NewKey[int]("limit")
+
+	t.Run("Set and Get", func(t *testing.T) {
+		want := &cart{items: 3}
+		c := Set(context.Background(), cartKey, want)
+
+		got, ok := Get(c, cartKey)
+		if !ok || got != want {
+			t.Errorf("expected %v, got %v (ok=%v)", want, got, ok)
+		}
+	})
+
+	t.Run("Get returns zero for missing", func(t *testing.T) {
+		got, ok := Get(context.Background(), limitKey)
+
+		if ok || got != 0 {
+			t.Errorf("expected zero and false, got %d (ok=%v)", got, ok)
+		}
+	})
+
+	t.Run("GetOr", func(t *testing.T) {
+		c := Set(context.Background(), limitKey, 10)
+
+		if got := GetOr(c, limitKey, 50); got != 10 {
+			t.Errorf("expected 10, got %d", got)
+		}
+		if got := GetOr(context.Background(), limitKey, 50); got != 50 {
+			t.Errorf("expected default 50, got %d", got)
+		}
+	})
+
+	t.Run("keys with the same name do not collide", func(t *testing.T) {
+		other := NewKey[int]("limit")
+		c := Set(context.Background(), limitKey, 10)
+
+		if _, ok := Get(c, other); ok {
+			t.Error("expected a distinct key to find nothing")
+		}
+	})
+
+	t.Run("works with Contextx", func(t *testing.T) {
+		ctx := From(Set(Background().WithRequestID("req-1"), limitKey, 5))
+
+		if got := GetOr(ctx, limitKey, 0); got != 5 || ctx.RequestID() != "req-1" {
+			t.Errorf("expected 5 and req-1, got %d and %q", got, ctx.RequestID())
+		}
+	})
+}
@@ -0,0 +1,153 @@
+// Package errorx provides a typed error model shared by all layers.
+// An Error carries a Kind (what went wrong, used to pick the HTTP status or
+// gRPC code), a stable machine-readable code, a message that is safe to show
+// to clients, and an optional wrapped cause that is only logged.
+package errorx
+
+import (
+	"errors"
+	"net/http"
+
+	"google.golang.org/grpc/codes"
+)
+
+// Kind classifies an error.
+type Kind int
+
+const (
+	// KindInternal is an unexpected failure. It is the zero value.
+	KindInternal Kind = iota
+	// KindInvalid means the input violates a rule.
+	KindInvalid
+	// KindNotFound means the requested resource does not exist.
+	KindNotFound
+	// KindConflict means the request conflicts with the current state.
+	KindConflict
+	// KindUnauthorized means the caller is not authenticated.
+	KindUnauthorized
+	// KindForbidden means the caller is authenticated but not allowed.
+	KindForbidden
+)
+
+// kindInfo is a row of the kind mapping table.
+type kindInfo struct {
+	name   string
+	code   string
+	status int
+	grpc   codes.Code
+}
+
+// kinds maps each Kind to its default code, HTTP status and gRPC code.
+var kinds = map[Kind]kindInfo{
+	KindInternal:     {"internal", "INTERNAL_ERROR", http.StatusInternalServerError, codes.Internal},
+	KindInvalid:      {"invalid", "BAD_REQUEST", http.StatusBadRequest, codes.InvalidArgument},
+	KindNotFound:     {"not_found", "NOT_FOUND", http.StatusNotFound, codes.NotFound},
+	KindConflict:     {"conflict", "CONFLICT", http.StatusConflict, codes.AlreadyExists},
+	KindUnauthorized: {"unauthorized", "UNAUTHORIZED", http.StatusUnauthorized, codes.Unauthenticated},
+	KindForbidden:    {"forbidden", "FORBIDDEN", http.StatusForbidden, codes.PermissionDenied},
+}
+
+// info returns the mapping row for k, falling back to KindInternal.
+func (k Kind) info() kindInfo {
+	if i, ok := kinds[k]; ok {
+		return i
+	}
+	return kinds[KindInternal]
+}
+
+// String returns the kind name.
+func (k Kind) String() string { return k.info().name }
+
+// HTTPStatus returns the HTTP status for k.
+func (k Kind) HTTPStatus() int { return k.info().status }
+
+// GRPCCode returns the gRPC status code for k.
+func (k Kind) GRPCCode() codes.Code { return k.info().grpc }
+
+// DefaultCode returns the error code used when an Error has no code.
+func (k Kind) DefaultCode() string { return k.info().code }
+
+// Error is a typed error.
+type Error struct {
+	kind    Kind
+	code    string
+	message string
+	cause   error
+}
+
+// New creates an error. code is a stable identifier such as "ORDER_NOT_FOUND";
+// message is safe to return to clients.
+func New(kind Kind, code, message string) *Error {
+	return &Error{kind: kind, code: code, message: message}
+}
+
+// Wrap creates an error that wraps cause. The cause is reachable through
+// errors.Is/As and logged, but never shown to clients.
+func Wrap(cause error, kind Kind, code, message string) *Error {
+	return &Error{kind: kind, code: code, message: message, cause: cause}
+}
+
+// Convenience constructors for each kind.
+func Invalid(code, message string) *Error      { return New(KindInvalid, code, message) }
+func NotFound(code, message string) *Error     { return New(KindNotFound, code, message) }
+func Conflict(code, message string) *Error     { return New(KindConflict, code, message) }
+func Unauthorized(code, message string) *Error { return New(KindUnauthorized, code, message) }
+func Forbidden(code, message string) *Error    { return New(KindForbidden, code, message) }
+func Internal(code, message string) *Error     { return New(KindInternal, code, message) }
+
+// Getters
+func (e *Error) Kind() Kind      { return e.kind }
+func (e *Error) Message() string { return e.message }
+func (e *Error) Unwrap() error   { return e.cause }
+
+// Code returns the error code, or the kind's default code if none was set.
+func (e *Error) Code() string {
+	if e.code == "" {
+		return e.kind.DefaultCode()
+	}
+	return e.code
+}
+
+// WithCause returns a copy of e wrapping cause, so a sentinel error can be
+// returned with its underlying failure attached:
+//
+//	return ErrOrderNotFound.WithCause(err)
+func (e *Error) WithCause(cause error) *Error {
+	cp := *e
+	cp.cause = cause
+	return &cp
+}
+
+// Error returns "code: message" followed by the cause, if any.
+func (e *Error) Error() string {
+	s := e.Code() + ": " + e.message
+	if e.cause != nil {
+		s += ": " + e.cause.Error()
+	}
+	return s
+}
+
+// Is reports whether target is an *Error with the same kind and code,
+// so errors.Is matches sentinel errors after WithCause or wrapping.
+func (e *Error) Is(target error) bool {
+	t, ok := target.(*Error)
+	if !ok {
+		return false
+	}
+	return e.kind == t.kind && e.Code() == t.Code()
+}
+
+// As returns the first *Error in err's chain.
+func As(err error) (*Error, bool) {
+	var e *Error
+	ok := errors.As(err, &e)
+	return e, ok
+}
+
+// KindOf returns the kind of the first *Error in err's chain, or KindInternal.
+func KindOf(err error) Kind {
+	if e, ok := As(err); ok {
+		return e.kind
+	}
+	return KindInternal
+}
@@ -0,0 +1,83 @@
+package errorx
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"google.golang.org/grpc/codes"
+)
+
+func TestKind_Mapping(t *testing.T) {
+	tests := []struct {
+		kind       Kind
+		wantStatus int
+		wantGRPC   codes.Code
+		wantCode   string
+	}{
+		{KindInternal, http.StatusInternalServerError, codes.Internal, "INTERNAL_ERROR"},
+		{KindInvalid, http.StatusBadRequest, codes.InvalidArgument, "BAD_REQUEST"},
+		{KindNotFound, http.StatusNotFound, codes.NotFound, "NOT_FOUND"},
+		{KindConflict, http.StatusConflict, codes.AlreadyExists, "CONFLICT"},
+		{KindUnauthorized, http.StatusUnauthorized, codes.Unauthenticated, "UNAUTHORIZED"},
+		{KindForbidden, http.StatusForbidden, codes.PermissionDenied, "FORBIDDEN"},
+		{Kind(99), http.StatusInternalServerError, codes.Internal, "INTERNAL_ERROR"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.kind.String(), func(t *testing.T) {
+			if got := tt.kind.HTTPStatus(); got != tt.wantStatus {
+				t.Errorf("HTTPStatus() = %v, want %v", got, tt.wantStatus)
+			}
+			if got := tt.kind.GRPCCode(); got != tt.wantGRPC {
+				t.Errorf("GRPCCode() = %v, want %v", got, tt.wantGRPC)
+			}
+			if got := New(tt.kind, "", "msg").Code(); got != tt.wantCode {
+				t.Errorf("Code() = %v, want %v", got, tt.wantCode)
+			}
+		})
+	}
+}
+
+func TestError_Wrapping(t *testing.T) {
+	// Arrange
+	errOrderNotFound := NotFound("ORDER_NOT_FOUND", "order not found")
+	cause := errors.New("sql: no rows in result set")
+
+	// Act
+	err := fmt.Errorf("get order: %w", errOrderNotFound.WithCause(cause))
+
+	// Assert
+	if !errors.Is(err, errOrderNotFound) {
+		t.Error("errors.Is(err, sentinel) = false")
+	}
+	if !errors.Is(err, cause) {
+		t.Error("errors.Is(err, cause) = false")
+	}
+	if errors.Is(err, Conflict("ORDER_NOT_FOUND", "")) {
+		t.Error("errors.Is matched a different kind")
+	}
+	if KindOf(err) != KindNotFound {
+		t.Errorf("KindOf() = %v, want not_found", KindOf(err))
+	}
+	if KindOf(cause) != KindInternal {
+		t.Errorf("KindOf(plain) = %v, want internal", KindOf(cause))
+	}
+	if got := err.Error(); got != "get order: ORDER_NOT_FOUND: order not found: sql: no rows in result set" {
+		t.Errorf("Error() = %q", got)
+	}
+	if errOrderNotFound.Unwrap() != nil {
+		t.Error("WithCause() modified the sentinel")
+	}
+}
+
+func TestWrap(t *testing.T) {
+	cause := errors.New("duplicate key")
+
+	err := Wrap(cause, KindConflict, "EMAIL_TAKEN", "email already registered")
+
+	if err.Kind() != KindConflict || err.Message() != "email already registered" || !errors.Is(err, cause) {
+		t.Errorf("Wrap() = %v", err)
+	}
+}
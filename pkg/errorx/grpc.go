@@ -0,0 +1,41 @@
+package errorx
+
+import (
+	"context"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/status"
+)
+
+// GRPCStatus converts err into a gRPC status error using the kind mapping table.
+// Internal errors get a generic message; errors that already carry a gRPC
+// status are returned unchanged.
+func GRPCStatus(err error) error {
+	if err == nil {
+		return nil
+	}
+	if _, ok := status.FromError(err); ok {
+		return err
+	}
+
+	e, ok := As(err)
+	if !ok || e.kind == KindInternal {
+		return status.Error(KindInternal.GRPCCode(), "internal server error")
+	}
+	return status.Error(e.kind.GRPCCode(), e.Code()+": "+e.message)
+}
+
+// UnaryServerInterceptor converts handler errors with GRPCStatus.
+func UnaryServerInterceptor() grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req any, _ *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+		resp, err := handler(ctx, req)
+		return resp, GRPCStatus(err)
+	}
+}
+
+// StreamServerInterceptor converts stream handler errors with GRPCStatus.
+func StreamServerInterceptor() grpc.StreamServerInterceptor {
+	return func(srv any, ss grpc.ServerStream, _ *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		return GRPCStatus(handler(srv, ss))
+	}
+}
@@ -0,0 +1,54 @@
+package errorx
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestGRPCStatus(t *testing.T) {
+	tests := []struct {
+		name        string
+		err         error
+		wantCode    codes.Code
+		wantMessage string
+	}{
+		{"typed", Invalid("INVALID_EMAIL", "email is invalid"), codes.InvalidArgument, "INVALID_EMAIL: email is invalid"},
+		{"internal hidden", Internal("DB", "connection refused"), codes.Internal, "internal server error"},
+		{"plain hidden", errors.New("boom"), codes.Internal, "internal server error"},
+		{"status kept", status.Error(codes.Unavailable, "try later"), codes.Unavailable, "try later"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			st, ok := status.FromError(GRPCStatus(tt.err))
+
+			if !ok {
+				t.Fatal("GRPCStatus() did not return a status error")
+			}
+			if st.Code() != tt.wantCode || st.Message() != tt.wantMessage {
+				t.Errorf("status = (%v, %q), want (%v, %q)", st.Code(), st.Message(), tt.wantCode, tt.wantMessage)
+			}
+		})
+	}
+
+	if GRPCStatus(nil) != nil {
+		t.Error("GRPCStatus(nil) != nil")
+	}
+}
+
+func TestUnaryServerInterceptor(t *testing.T) {
+	handler := func(context.Context, any) (any, error) {
+		return nil, NotFound("", "order not found")
+	}
+
+	_, err := UnaryServerInterceptor()(context.Background(), nil, &grpc.UnaryServerInfo{}, handler)
+
+	if status.Code(err) != codes.NotFound {
+		t.Errorf("code = %v, want NotFound", status.Code(err))
+	}
+}
@@ -0,0 +1,253 @@
+// Package eventx provides an in-process event bus with typed subscriptions.
+// Handlers subscribe to a Go type, or an interface type to receive every
+// event implementing it; a panicking handler is isolated and reported as an
+// error. By default Publish runs handlers synchronously; with Workers set it
+// queues them on a worker pool and returns immediately. It is the default
+// dispatcher until a message broker is introduced.
+//
+//	bus := eventx.New(eventx.Options{})
+//	eventx.Subscribe(bus, func(ctx context.Context, e OrderPlaced) error { ... })
+//	err := bus.Publish(ctx, OrderPlaced{ID: "o-1"})
+package eventx
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+
+	"github.com/blackhorseya/go-ddd/pkg/contextx"
+)
+
+var (
+	// ErrPanic wraps a value recovered from a panicking handler.
+	ErrPanic = errors.New("event handler panicked")
+
+	// ErrClosed is returned by Publish after Close.
+	ErrClosed = errors.New("event bus closed")
+)
+
+// DefaultQueueSize is the async queue capacity per worker.
+const DefaultQueueSize = 64
+
+// Options configures a Bus.
+type Options struct {
+	// Workers, when positive, makes Publish asynchronous: deliveries run on
+	// this many goroutines and handler errors go to OnError.
+	// Default: 0 (synchronous)
+	Workers int
+
+	// QueueSize bounds pending async deliveries; Publish blocks when full,
+	// except from a handler of the same bus, which runs the delivery inline
+	// rather than wait for the worker it occupies.
+	// Default: 64 per worker
+	QueueSize int
+
+	// OnError receives async handler errors. Default: log a warning.
+	OnError func(ctx context.Context, event any, err error)
+}
+
+// handler is a type-erased subscription.
+type handler struct {
+	id int
+	fn func(ctx context.Context, event any) error
+}
+
+// workerKey marks the context of a delivery running on a worker with its
+// Bus.
+type workerKey struct{}
+
+// delivery is a queued async handler invocation.
+type delivery struct {
+	ctx   context.Context
+	event any
+	h     handler
+}
+
+// Bus dispatches events to subscribed handlers. It is safe for concurrent use.
+type Bus struct {
+	opts Options
+
+	mu       sync.RWMutex
+	handlers []handler
+	nextID   int
+	closed   bool
+
+	queue   chan delivery
+	sending sync.WaitGroup // Publish calls enqueueing deliveries
+	wg      sync.WaitGroup
+}
+
+// New creates a Bus and starts its workers, if any.
+func New(opts Options) *Bus {
+	if opts.OnError == nil {
+		opts.OnError = logError
+	}
+	b := &Bus{opts: opts}
+	if opts.Workers <= 0 {
+		return b
+	}
+
+	if opts.QueueSize <= 0 {
+		opts.QueueSize = DefaultQueueSize * opts.Workers
+	}
+	b.queue = make(chan delivery, opts.QueueSize)
+	b.wg.Add(opts.Workers)
+	for range opts.Workers {
+		go b.work()
+	}
+	return b
+}
+
+// Subscribe registers h for events whose dynamic type is E, or implements
+// E when E is an interface. Handlers run in subscription order. The
+// returned function removes the subscription.
+func Subscribe[E any](b *Bus, h func(ctx context.Context, event E) error) (unsubscribe func()) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.nextID++
+	id := b.nextID
+	b.handlers = append(b.handlers, handler{id: id, fn: func(ctx context.Context, event any) error {
+		e, ok := event.(E)
+		if !ok {
+			return nil
+		}
+		return h(ctx, e)
+	}})
+
+	return func() {
+		b.mu.Lock()
+		defer b.mu.Unlock()
+
+		for i, h := range b.handlers {
+			if h.id == id {
+				b.handlers = append(b.handlers[:i:i], b.handlers[i+1:]...)
+				return
+			}
+		}
+	}
+}
+
+// Publish delivers events to their handlers in order. Synchronously, every
+// handler runs and their errors are joined. Asynchronously, deliveries are
+// queued under a context detached from ctx's cancellation and Publish only
+// fails once the bus is closed.
+//
+// Handlers are those subscribed when Publish is called; the bus is not
+// locked while they run or while Publish waits for queue space, so they
+// may subscribe and publish themselves.
+func (b *Bus) Publish(ctx context.Context, events ...any) error {
+	b.mu.RLock()
+	if b.closed {
+		b.mu.RUnlock()
+		return ErrClosed
+	}
+	// Subscriptions copy on removal and only append beyond this slice, so
+	// it stays valid after unlocking.
+	handlers := b.handlers
+	if b.queue != nil {
+		b.sending.Add(1)
+	}
+	b.mu.RUnlock()
+
+	if b.queue != nil {
+		defer b.sending.Done()
+		b.enqueue(ctx, events, handlers)
+		return nil
+	}
+
+	var errs []error
+	for _, e := range events {
+		for _, h := range handlers {
+			if err := invoke(ctx, h, e); err != nil {
+				errs = append(errs, err)
+			}
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// Close stops accepting events and waits for queued deliveries to finish
+// or ctx to be done.
+func (b *Bus) Close(ctx context.Context) error {
+	b.mu.Lock()
+	if b.closed {
+		b.mu.Unlock()
+		return nil
+	}
+	b.closed = true
+	b.mu.Unlock()
+
+	done := make(chan struct{})
+	go func() {
+		if b.queue != nil {
+			// No Publish starts enqueueing once closed is set; wait for
+			// those already enqueueing before closing the queue
+			b.sending.Wait()
+			close(b.queue)
+		}
+		b.wg.Wait()
+		close(done)
+	}()
+	select {
+	case <-done:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// enqueue queues a delivery per event and handler. When the queue is full
+// and the caller is a handler running on one of b's workers, the delivery
+// runs inline: waiting could deadlock once every worker waits the same way.
+func (b *Bus) enqueue(ctx context.Context, events []any, handlers []handler) {
+	inWorker := ctx.Value(workerKey{}) == b
+	ctx = context.WithoutCancel(ctx)
+	for _, e := range events {
+		for _, h := range handlers {
+			d := delivery{ctx: ctx, event: e, h: h}
+			select {
+			case b.queue <- d:
+			default:
+				if inWorker {
+					b.deliver(d)
+				} else {
+					b.queue <- d
+				}
+			}
+		}
+	}
+}
+
+// work runs queued deliveries until the queue is closed.
+func (b *Bus) work() {
+	defer b.wg.Done()
+
+	for d := range b.queue {
+		b.deliver(d)
+	}
+}
+
+// deliver runs d on the calling worker, reporting errors to OnError.
+func (b *Bus) deliver(d delivery) {
+	ctx := context.WithValue(d.ctx, workerKey{}, b)
+	if err := invoke(ctx, d.h, d.event); err != nil {
+		b.opts.OnError(ctx, d.event, err)
+	}
+}
+
+// invoke runs h for event, converting a panic into an ErrPanic error.
+func invoke(ctx context.Context, h handler, event any) (err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			err = fmt.Errorf("%w: %v", ErrPanic, r)
+		}
+	}()
+	return h.fn(ctx, event)
+}
+
+// logError is the default OnError.
+func logError(ctx context.Context, event any, err error) {
+	contextx.From(ctx).Warn("event handler failed", "event", fmt.Sprintf("%T", event), "error", err)
+}
@@ -0,0 +1,203 @@
+package eventx
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"sync"
+	"testing"
+	"time"
+)
+
+var errBoom = errors.New("boom")
+
+type placed struct{ ID string }
+
+type cancelled struct{ ID string }
+
+type named interface{ Name() string }
+
+func (placed) Name() string { return "placed" }
+
+func TestBus_PublishSync(t *testing.T) {
+	// Arrange
+	ctx := context.Background()
+	b := New(Options{})
+
+	var got []string
+	Subscribe(b, func(_ context.Context, e placed) error {
+		got = append(got, "placed:"+e.ID)
+		return nil
+	})
+	Subscribe(b, func(_ context.Context, e named) error {
+		got = append(got, "named:"+e.Name())
+		return nil
+	})
+	unsubscribe := Subscribe(b, func(context.Context, cancelled) error {
+		t.Error("unsubscribed handler called")
+		return nil
+	})
+	unsubscribe()
+
+	// Act
+	err := b.Publish(ctx, placed{ID: "o-1"}, cancelled{ID: "o-2"})
+
+	// Assert
+	if err != nil {
+		t.Fatalf("Publish() error = %v", err)
+	}
+	want := []string{"placed:o-1", "named:placed"}
+	if !slices.Equal(got, want) {
+		t.Errorf("handled = %v, want %v", got, want)
+	}
+}
+
+func TestBus_PublishIsolatesFailures(t *testing.T) {
+	tests := []struct {
+		name    string
+		handler func(context.Context, placed) error
+		wantErr error
+	}{
+		{name: "error", handler: func(context.Context, placed) error { return errBoom }, wantErr: errBoom},
+		{name: "panic", handler: func(context.Context, placed) error { panic("boom") }, wantErr: ErrPanic},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Arrange
+			b := New(Options{})
+			called := false
+			Subscribe(b, tt.handler)
+			Subscribe(b, func(context.Context, placed) error {
+				called = true
+				return nil
+			})
+
+			// Act
+			err := b.Publish(context.Background(), placed{ID: "o-1"})
+
+			// Assert
+			if !errors.Is(err, tt.wantErr) {
+				t.Errorf("Publish() error = %v, want %v", err, tt.wantErr)
+			}
+			if !called {
+				t.Error("later handlers must still run")
+			}
+		})
+	}
+}
+
+func TestBus_PublishAsync(t *testing.T) {
+	// Arrange
+	var (
+		mu      sync.Mutex
+		handled int
+		failed  []error
+	)
+	b := New(Options{
+		Workers: 4,
+		OnError: func(_ context.Context, _ any, err error) {
+			mu.Lock()
+			defer mu.Unlock()
+			failed = append(failed, err)
+		},
+	})
+	Subscribe(b, func(ctx context.Context, e placed) error {
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+		if e.ID == "bad" {
+			panic("bad event")
+		}
+		mu.Lock()
+		defer mu.Unlock()
+		handled++
+		return nil
+	})
+	ctx, cancel := context.WithCancel(context.Background())
+
+	// Act
+	for range 10 {
+		if err := b.Publish(ctx, placed{ID: "ok"}); err != nil {
+			t.Fatalf("Publish() error = %v", err)
+		}
+	}
+	_ = b.Publish(ctx, placed{ID: "bad"})
+	cancel()
+	closeCtx, closeCancel := context.WithTimeout(context.Background(), time.Second)
+	defer closeCancel()
+	closeErr := b.Close(closeCtx)
+
+	// Assert
+	if closeErr != nil {
+		t.Fatalf("Close() error = %v", closeErr)
+	}
+	if handled != 10 {
+		t.Errorf("handled = %d, want 10", handled)
+	}
+	if len(failed) != 1 || !errors.Is(failed[0], ErrPanic) {
+		t.Errorf("failed = %v, want one ErrPanic", failed)
+	}
+	if err := b.Publish(context.Background(), placed{}); !errors.Is(err, ErrClosed) {
+		t.Errorf("Publish() after Close error = %v, want ErrClosed", err)
+	}
+}
+
+func TestBus_PublishFromHandlerWithFullQueue(t *testing.T) {
+	// Arrange: one worker and a one-slot queue
+	b := New(Options{Workers: 1, QueueSize: 1})
+	started, gate := make(chan struct{}), make(chan struct{})
+	var (
+		mu  sync.Mutex
+		got []string
+	)
+	Subscribe(b, func(ctx context.Context, e placed) error {
+		if e.ID == "first" {
+			close(started)
+			<-gate
+		}
+		// The worker publishes while the queue is full
+		return b.Publish(ctx, cancelled{ID: e.ID})
+	})
+	Subscribe(b, func(_ context.Context, e cancelled) error {
+		mu.Lock()
+		defer mu.Unlock()
+		got = append(got, e.ID)
+		return nil
+	})
+	ctx := context.Background()
+	if err := b.Publish(ctx, placed{ID: "first"}); err != nil {
+		t.Fatalf("Publish() error = %v", err)
+	}
+	<-started
+
+	// Act: with the worker busy and the rest of the first event filling
+	// the queue, a publisher blocks, and subscribing must not wait for it
+	published := make(chan error, 1)
+	go func() { published <- b.Publish(ctx, placed{ID: "second"}) }()
+	subscribed := make(chan struct{})
+	go func() {
+		Subscribe(b, func(context.Context, placed) error { return nil })
+		close(subscribed)
+	}()
+	select {
+	case <-subscribed:
+	case <-time.After(time.Second):
+		t.Fatal("Subscribe() blocked by a publisher waiting for queue space")
+	}
+	close(gate)
+
+	// Assert
+	if err := <-published; err != nil {
+		t.Fatalf("Publish() error = %v", err)
+	}
+	closeCtx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+	if err := b.Close(closeCtx); err != nil {
+		t.Fatalf("Close() error = %v", err)
+	}
+	slices.Sort(got)
+	if want := []string{"first", "second"}; !slices.Equal(got, want) {
+		t.Errorf("cancelled = %v, want %v", got, want)
+	}
+}
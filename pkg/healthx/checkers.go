@@ -0,0 +1,93 @@
+package healthx
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"net"
+	"strings"
+)
+
+// Pinger is implemented by database handles such as *sql.DB.
+type Pinger interface {
+	PingContext(ctx context.Context) error
+}
+
+// Database returns a checker that pings the database.
+func Database(db Pinger) Checker {
+	return NewChecker("database", func(ctx context.Context) error {
+		if err := db.PingContext(ctx); err != nil {
+			return fmt.Errorf("ping database: %w", err)
+		}
+		return nil
+	})
+}
+
+// Redis returns a checker that sends a PING command to the Redis server at addr.
+// If password is not empty, the connection is authenticated first.
+func Redis(addr, password string) Checker {
+	return NewChecker("redis", func(ctx context.Context) error {
+		conn, err := dial(ctx, addr)
+		if err != nil {
+			return fmt.Errorf("dial redis: %w", err)
+		}
+		defer conn.Close()
+
+		rd := bufio.NewReader(conn)
+		if password != "" {
+			if err := redisCommand(conn, rd, "+OK", "AUTH", password); err != nil {
+				return fmt.Errorf("auth redis: %w", err)
+			}
+		}
+		if err := redisCommand(conn, rd, "+PONG", "PING"); err != nil {
+			return fmt.Errorf("ping redis: %w", err)
+		}
+		return nil
+	})
+}
+
+// OTLP returns a checker that verifies the OTLP collector endpoint accepts connections.
+func OTLP(endpoint string) Checker {
+	return NewChecker("otlp", func(ctx context.Context) error {
+		conn, err := dial(ctx, endpoint)
+		if err != nil {
+			return fmt.Errorf("dial otlp collector: %w", err)
+		}
+		return conn.Close()
+	})
+}
+
+// dial opens a TCP connection that honours the context deadline for I/O.
+func dial(ctx context.Context, addr string) (net.Conn, error) {
+	var d net.Dialer
+	conn, err := d.DialContext(ctx, "tcp", addr)
+	if err != nil {
+		return nil, err
+	}
+	if deadline, ok := ctx.Deadline(); ok {
+		_ = conn.SetDeadline(deadline)
+	}
+	return conn, nil
+}
+
+// redisCommand writes a RESP command and verifies the reply line.
+func redisCommand(conn net.Conn, rd *bufio.Reader, want string, args ...string) error {
+	var b strings.Builder
+	fmt.Fprintf(&b, "*%d\r\n", len(args))
+	for _, arg := range args {
+		fmt.Fprintf(&b, "$%d\r\n%s\r\n", len(arg), arg)
+	}
+	if _, err := conn.Write([]byte(b.String())); err != nil {
+		return err
+	}
+
+	line, err := rd.ReadString('\n')
+	if err != nil {
+		return err
+	}
+	line = strings.TrimRight(line, "\r\n")
+	if line != want {
+		return fmt.Errorf("unexpected reply: %q", line)
+	}
+	return nil
+}
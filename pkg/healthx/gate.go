@@ -0,0 +1,93 @@
+package healthx
+
+import (
+	"context"
+	"fmt"
+	"sync"
+)
+
+// Gate tracks startup components and reports ready only after every
+// registered component has signaled readiness.
+// Components are kept in registration order so that later components can
+// wait for earlier ones (e.g. consumers waiting for migrations).
+// It is safe for concurrent use.
+type Gate struct {
+	mu         sync.Mutex
+	order      []string
+	components map[string]chan struct{}
+}
+
+// NewGate creates an empty Gate. An empty gate is ready.
+func NewGate() *Gate {
+	return &Gate{components: make(map[string]chan struct{})}
+}
+
+// Add registers a component that must signal readiness before the gate opens.
+// It returns a function that marks the component ready; calling it more than once is safe.
+// Adding a component that already exists returns the signal function for it.
+func (g *Gate) Add(name string) func() {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	if _, ok := g.components[name]; !ok {
+		g.components[name] = make(chan struct{})
+		g.order = append(g.order, name)
+	}
+
+	return func() { g.Ready(name) }
+}
+
+// Ready marks the named component as ready.
+// Unknown components are ignored.
+func (g *Gate) Ready(name string) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	ch, ok := g.components[name]
+	if !ok {
+		return
+	}
+	select {
+	case <-ch:
+	default:
+		close(ch)
+	}
+}
+
+// Pending returns the components that have not signaled readiness, in registration order.
+func (g *Gate) Pending() []string {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	var pending []string
+	for _, name := range g.order {
+		select {
+		case <-g.components[name]:
+		default:
+			pending = append(pending, name)
+		}
+	}
+	return pending
+}
+
+// IsReady returns true if all registered components have signaled readiness.
+func (g *Gate) IsReady() bool {
+	return len(g.Pending()) == 0
+}
+
+// Wait blocks until the named component is ready or ctx is done.
+func (g *Gate) Wait(ctx context.Context, name string) error {
+	g.mu.Lock()
+	ch, ok := g.components[name]
+	g.mu.Unlock()
+	if !ok {
+		return fmt.Errorf("unknown startup component: %s", name)
+	}
+
+	select {
+	case <-ch:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
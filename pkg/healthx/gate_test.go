@@ -0,0 +1,76 @@
+package healthx
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestGate_EmptyIsReady(t *testing.T) {
+	g := NewGate()
+
+	if !g.IsReady() {
+		t.Error("expected empty gate to be ready")
+	}
+}
+
+func TestGate_Pending(t *testing.T) {
+	g := NewGate()
+	migrations := g.Add("migrations")
+	g.Add("cache")
+	g.Add("consumers")
+
+	if got := g.Pending(); !reflect.DeepEqual(got, []string{"migrations", "cache", "consumers"}) {
+		t.Errorf("Pending() = %v", got)
+	}
+
+	migrations()
+	migrations() // idempotent
+	g.Ready("consumers")
+
+	if got := g.Pending(); !reflect.DeepEqual(got, []string{"cache"}) {
+		t.Errorf("Pending() = %v, want [cache]", got)
+	}
+	if g.IsReady() {
+		t.Error("expected gate to be not ready")
+	}
+
+	g.Ready("cache")
+	if !g.IsReady() {
+		t.Error("expected gate to be ready")
+	}
+}
+
+func TestGate_Wait(t *testing.T) {
+	g := NewGate()
+	ready := g.Add("migrations")
+
+	go func() {
+		time.Sleep(10 * time.Millisecond)
+		ready()
+	}()
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+
+	if err := g.Wait(ctx, "migrations"); err != nil {
+		t.Errorf("Wait() error = %v", err)
+	}
+}
+
+func TestGate_WaitCancelled(t *testing.T) {
+	g := NewGate()
+	g.Add("cache")
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	if err := g.Wait(ctx, "cache"); !errors.Is(err, context.Canceled) {
+		t.Errorf("Wait() error = %v, want context.Canceled", err)
+	}
+	if err := g.Wait(ctx, "unknown"); err == nil {
+		t.Error("expected error for unknown component")
+	}
+}
@@ -0,0 +1,256 @@
+// Package healthx provides dependency health checking for readiness probes.
+// Checkers are registered in a Registry, which runs them concurrently with
+// per-check timeouts and aggregates the results into a Report. A failing
+// critical check takes the service out of rotation; a failing degraded
+// check is reported without failing readiness. Results can be cached so
+// frequent probes do not hammer dependencies.
+package healthx
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"time"
+)
+
+// DefaultTimeout is the timeout applied to a check when none is configured.
+const DefaultTimeout = 5 * time.Second
+
+// ErrTimeout is returned when a check does not complete within its timeout.
+var ErrTimeout = errors.New("health check timed out")
+
+// Status represents the health status of a check or of the whole registry.
+type Status string
+
+const (
+	StatusUp   Status = "up"
+	StatusDown Status = "down"
+
+	// StatusDegraded is the report status when only degraded checks fail.
+	StatusDegraded Status = "degraded"
+)
+
+// Severity sets how a failing check affects the report.
+type Severity string
+
+const (
+	// SeverityCritical checks guard dependencies the service cannot work
+	// without; a failure marks the report down. It is the default.
+	SeverityCritical Severity = "critical"
+
+	// SeverityDegraded checks guard optional dependencies, e.g. a trace
+	// collector; a failure marks the report degraded but still ready.
+	SeverityDegraded Severity = "degraded"
+)
+
+// Checker checks the health of a single dependency.
+type Checker interface {
+	// Name returns the unique name of the dependency, e.g. "database".
+	Name() string
+
+	// Check returns nil when the dependency is healthy.
+	Check(ctx context.Context) error
+}
+
+// CheckFunc adapts a function to the Checker interface.
+type CheckFunc func(ctx context.Context) error
+
+// namedCheck is a Checker backed by a CheckFunc.
+type namedCheck struct {
+	name string
+	fn   CheckFunc
+}
+
+// NewChecker creates a Checker with the given name and check function.
+func NewChecker(name string, fn CheckFunc) Checker {
+	return &namedCheck{name: name, fn: fn}
+}
+
+func (c *namedCheck) Name() string                    { return c.name }
+func (c *namedCheck) Check(ctx context.Context) error { return c.fn(ctx) }
+
+// CheckResult is the outcome of a single check.
+type CheckResult struct {
+	Status   Status        `json:"status"`
+	Severity Severity      `json:"severity"`
+	Error    string        `json:"error,omitempty"`
+	Duration time.Duration `json:"duration"`
+
+	// CheckedAt is when the check ran; it predates the report for cached results.
+	CheckedAt time.Time `json:"checked_at"`
+}
+
+// Report is the aggregated outcome of all registered checks.
+type Report struct {
+	Status Status                 `json:"status"`
+	Checks map[string]CheckResult `json:"checks,omitempty"`
+}
+
+// IsUp returns true if all critical checks passed, i.e. the service can
+// take traffic, possibly degraded.
+func (r Report) IsUp() bool {
+	return r.Status != StatusDown
+}
+
+// CheckOption configures a registered check.
+type CheckOption func(*entry)
+
+// WithTimeout sets the timeout for a single check.
+func WithTimeout(d time.Duration) CheckOption {
+	return func(e *entry) {
+		if d > 0 {
+			e.timeout = d
+		}
+	}
+}
+
+// WithSeverity sets the severity of a single check.
+func WithSeverity(s Severity) CheckOption {
+	return func(e *entry) {
+		e.severity = s
+	}
+}
+
+// WithCacheTTL reuses a check result for d, so probes arriving more often
+// than d share one dependency round trip. Concurrent checks of an expired
+// entry wait for a single run.
+func WithCacheTTL(d time.Duration) CheckOption {
+	return func(e *entry) {
+		e.cacheTTL = d
+	}
+}
+
+// entry is a registered checker with its options and last result.
+type entry struct {
+	checker  Checker
+	timeout  time.Duration
+	severity Severity
+	cacheTTL time.Duration
+
+	mu   sync.Mutex
+	last CheckResult
+}
+
+// Registry holds the registered checkers.
+// It is safe for concurrent use.
+type Registry struct {
+	mu      sync.RWMutex
+	entries []*entry
+}
+
+// NewRegistry creates an empty Registry.
+func NewRegistry() *Registry {
+	return &Registry{}
+}
+
+// Register adds a checker to the registry.
+// A checker with the same name replaces the previously registered one.
+func (r *Registry) Register(c Checker, opts ...CheckOption) {
+	e := &entry{checker: c, timeout: DefaultTimeout, severity: SeverityCritical}
+	for _, opt := range opts {
+		opt(e)
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for i, existing := range r.entries {
+		if existing.checker.Name() == c.Name() {
+			r.entries[i] = e
+			return
+		}
+	}
+	r.entries = append(r.entries, e)
+}
+
+// Names returns the names of all registered checkers in registration order.
+func (r *Registry) Names() []string {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	names := make([]string, 0, len(r.entries))
+	for _, e := range r.entries {
+		names = append(names, e.checker.Name())
+	}
+	return names
+}
+
+// Check runs all registered checkers concurrently and returns the aggregated
+// report. The report status is down if any critical check fails, degraded
+// if only degraded checks fail, and up otherwise.
+func (r *Registry) Check(ctx context.Context) Report {
+	r.mu.RLock()
+	entries := make([]*entry, len(r.entries))
+	copy(entries, r.entries)
+	r.mu.RUnlock()
+
+	report := Report{
+		Status: StatusUp,
+		Checks: make(map[string]CheckResult, len(entries)),
+	}
+
+	var (
+		mu sync.Mutex
+		wg sync.WaitGroup
+	)
+	for _, e := range entries {
+		wg.Add(1)
+		go func(e *entry) {
+			defer wg.Done()
+
+			result := e.check(ctx)
+
+			mu.Lock()
+			defer mu.Unlock()
+			report.Checks[e.checker.Name()] = result
+			switch {
+			case result.Status == StatusUp:
+			case e.severity == SeverityCritical:
+				report.Status = StatusDown
+			case report.Status == StatusUp:
+				report.Status = StatusDegraded
+			}
+		}(e)
+	}
+	wg.Wait()
+
+	return report
+}
+
+// check returns the cached result while it is fresh and runs the check otherwise.
+func (e *entry) check(ctx context.Context) CheckResult {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	if e.cacheTTL > 0 && !e.last.CheckedAt.IsZero() && time.Since(e.last.CheckedAt) < e.cacheTTL {
+		return e.last
+	}
+	e.last = run(ctx, e)
+	return e.last
+}
+
+// run executes a single check, enforcing its timeout even if the checker
+// ignores context cancellation.
+func run(ctx context.Context, e *entry) CheckResult {
+	ctx, cancel := context.WithTimeout(ctx, e.timeout)
+	defer cancel()
+
+	start := time.Now()
+	errCh := make(chan error, 1)
+	go func() {
+		errCh <- e.checker.Check(ctx)
+	}()
+
+	var err error
+	select {
+	case err = <-errCh:
+	case <-ctx.Done():
+		err = ErrTimeout
+	}
+
+	result := CheckResult{Status: StatusUp, Severity: e.severity, Duration: time.Since(start), CheckedAt: start}
+	if err != nil {
+		result.Status = StatusDown
+		result.Error = err.Error()
+	}
+	return result
+}
@@ -0,0 +1,251 @@
+package healthx
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"net"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestRegistry_Check(t *testing.T) {
+	tests := []struct {
+		name       string
+		checkers   []Checker
+		wantStatus Status
+	}{
+		{
+			name:       "empty registry is up",
+			checkers:   nil,
+			wantStatus: StatusUp,
+		},
+		{
+			name: "all checks pass",
+			checkers: []Checker{
+				NewChecker("a", func(context.Context) error { return nil }),
+				NewChecker("b", func(context.Context) error { return nil }),
+			},
+			wantStatus: StatusUp,
+		},
+		{
+			name: "one check fails",
+			checkers: []Checker{
+				NewChecker("a", func(context.Context) error { return nil }),
+				NewChecker("b", func(context.Context) error { return errors.New("boom") }),
+			},
+			wantStatus: StatusDown,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := NewRegistry()
+			for _, c := range tt.checkers {
+				r.Register(c)
+			}
+
+			report := r.Check(context.Background())
+
+			if report.Status != tt.wantStatus {
+				t.Errorf("Status = %v, want %v", report.Status, tt.wantStatus)
+			}
+			if len(report.Checks) != len(tt.checkers) {
+				t.Errorf("len(Checks) = %d, want %d", len(report.Checks), len(tt.checkers))
+			}
+		})
+	}
+}
+
+func TestRegistry_CheckReportsError(t *testing.T) {
+	r := NewRegistry()
+	r.Register(NewChecker("db", func(context.Context) error { return errors.New("connection refused") }))
+
+	report := r.Check(context.Background())
+
+	result := report.Checks["db"]
+	if result.Status != StatusDown {
+		t.Errorf("Status = %v, want %v", result.Status, StatusDown)
+	}
+	if result.Error != "connection refused" {
+		t.Errorf("Error = %q, want %q", result.Error, "connection refused")
+	}
+}
+
+func TestRegistry_Timeout(t *testing.T) {
+	r := NewRegistry()
+	block := make(chan struct{})
+	defer close(block)
+
+	// Checker ignores context cancellation; the registry must still time out.
+	r.Register(NewChecker("slow", func(context.Context) error {
+		<-block
+		return nil
+	}), WithTimeout(10*time.Millisecond))
+
+	start := time.Now()
+	report := r.Check(context.Background())
+
+	if time.Since(start) > time.Second {
+		t.Fatal("check was not bounded by its timeout")
+	}
+	if report.IsUp() {
+		t.Fatal("expected report to be down")
+	}
+	if got := report.Checks["slow"].Error; got != ErrTimeout.Error() {
+		t.Errorf("Error = %q, want %q", got, ErrTimeout.Error())
+	}
+}
+
+func TestRegistry_Severity(t *testing.T) {
+	failing := func(context.Context) error { return errors.New("boom") }
+	passing := func(context.Context) error { return nil }
+
+	tests := []struct {
+		name       string
+		critical   CheckFunc
+		degraded   CheckFunc
+		wantStatus Status
+		wantUp     bool
+	}{
+		{name: "all pass", critical: passing, degraded: passing, wantStatus: StatusUp, wantUp: true},
+		{name: "degraded fails", critical: passing, degraded: failing, wantStatus: StatusDegraded, wantUp: true},
+		{name: "critical fails", critical: failing, degraded: passing, wantStatus: StatusDown, wantUp: false},
+		{name: "both fail", critical: failing, degraded: failing, wantStatus: StatusDown, wantUp: false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Arrange
+			r := NewRegistry()
+			r.Register(NewChecker("db", tt.critical))
+			r.Register(NewChecker("otlp", tt.degraded), WithSeverity(SeverityDegraded))
+
+			// Act
+			report := r.Check(context.Background())
+
+			// Assert
+			if report.Status != tt.wantStatus || report.IsUp() != tt.wantUp {
+				t.Errorf("Status = %v (up %v), want %v (up %v)", report.Status, report.IsUp(), tt.wantStatus, tt.wantUp)
+			}
+			if got := report.Checks["otlp"].Severity; got != SeverityDegraded {
+				t.Errorf("otlp Severity = %v, want %v", got, SeverityDegraded)
+			}
+			if got := report.Checks["db"].Severity; got != SeverityCritical {
+				t.Errorf("db Severity = %v, want %v", got, SeverityCritical)
+			}
+		})
+	}
+}
+
+func TestRegistry_CacheTTL(t *testing.T) {
+	// Arrange
+	var calls atomic.Int32
+	r := NewRegistry()
+	r.Register(NewChecker("db", func(context.Context) error {
+		calls.Add(1)
+		time.Sleep(10 * time.Millisecond)
+		return nil
+	}), WithCacheTTL(time.Hour))
+
+	// Act
+	var wg sync.WaitGroup
+	for range 10 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			r.Check(context.Background())
+		}()
+	}
+	wg.Wait()
+	report := r.Check(context.Background())
+
+	// Assert
+	if n := calls.Load(); n != 1 {
+		t.Errorf("checker ran %d times, want 1", n)
+	}
+	if report.Checks["db"].CheckedAt.IsZero() {
+		t.Error("CheckedAt not set")
+	}
+}
+
+func TestRegistry_RegisterReplacesByName(t *testing.T) {
+	r := NewRegistry()
+	r.Register(NewChecker("db", func(context.Context) error { return errors.New("old") }))
+	r.Register(NewChecker("db", func(context.Context) error { return nil }))
+
+	if names := r.Names(); len(names) != 1 || names[0] != "db" {
+		t.Fatalf("Names() = %v, want [db]", names)
+	}
+	if report := r.Check(context.Background()); !report.IsUp() {
+		t.Error("expected replaced checker to be used")
+	}
+}
+
+type stubPinger struct{ err error }
+
+func (p stubPinger) PingContext(context.Context) error { return p.err }
+
+func TestDatabase(t *testing.T) {
+	if err := Database(stubPinger{}).Check(context.Background()); err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+
+	err := Database(stubPinger{err: errors.New("down")}).Check(context.Background())
+	if err == nil || !strings.Contains(err.Error(), "ping database") {
+		t.Errorf("expected ping database error, got %v", err)
+	}
+}
+
+func TestRedis(t *testing.T) {
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("listen: %v", err)
+	}
+	defer ln.Close()
+
+	go func() {
+		conn, err := ln.Accept()
+		if err != nil {
+			return
+		}
+		defer conn.Close()
+
+		rd := bufio.NewReader(conn)
+		for {
+			line, err := rd.ReadString('\n')
+			if err != nil {
+				return
+			}
+			if strings.TrimSpace(line) == "PING" {
+				_, _ = conn.Write([]byte("+PONG\r\n"))
+			}
+		}
+	}()
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+
+	if err := Redis(ln.Addr().String(), "").Check(ctx); err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+}
+
+func TestOTLP(t *testing.T) {
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("listen: %v", err)
+	}
+	addr := ln.Addr().String()
+
+	if err := OTLP(addr).Check(context.Background()); err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+
+	ln.Close()
+	if err := OTLP(addr).Check(context.Background()); err == nil {
+		t.Error("expected error for closed endpoint")
+	}
+}
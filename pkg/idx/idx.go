@@ -0,0 +1,74 @@
+// Package idx generates time-sortable unique identifiers.
+// ULID and UUIDv7 both start with a millisecond timestamp, so IDs sort by
+// creation time, which keeps cursor pagination over IDs stable.
+package idx
+
+import (
+	"fmt"
+	"sync"
+
+	"github.com/google/uuid"
+	"github.com/oklog/ulid/v2"
+)
+
+// Generator produces unique identifiers.
+type Generator interface {
+	NewID() string
+}
+
+// ULIDGenerator produces ULIDs such as "01ARZ3NDEKTSV4RRFFQ69G5FAV".
+// IDs generated within the same millisecond are monotonically increasing.
+type ULIDGenerator struct{}
+
+// NewULIDGenerator creates a ULID generator.
+func NewULIDGenerator() ULIDGenerator {
+	return ULIDGenerator{}
+}
+
+// NewID returns a new ULID.
+func (ULIDGenerator) NewID() string {
+	return ulid.Make().String()
+}
+
+// UUIDv7Generator produces RFC 9562 version 7 UUIDs.
+type UUIDv7Generator struct{}
+
+// NewUUIDv7Generator creates a UUIDv7 generator.
+func NewUUIDv7Generator() UUIDv7Generator {
+	return UUIDv7Generator{}
+}
+
+// NewID returns a new UUIDv7. It panics if the random source fails,
+// which does not happen with crypto/rand on supported platforms.
+func (UUIDv7Generator) NewID() string {
+	return uuid.Must(uuid.NewV7()).String()
+}
+
+// SequenceGenerator produces deterministic, sortable IDs for tests:
+// "<prefix>-000001", "<prefix>-000002", ... It is safe for concurrent use.
+type SequenceGenerator struct {
+	prefix string
+
+	mu   sync.Mutex
+	next int
+}
+
+// NewSequenceGenerator creates a deterministic generator.
+func NewSequenceGenerator(prefix string) *SequenceGenerator {
+	return &SequenceGenerator{prefix: prefix}
+}
+
+// NewID returns the next ID in the sequence.
+func (g *SequenceGenerator) NewID() string {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	g.next++
+	return fmt.Sprintf("%s-%06d", g.prefix, g.next)
+}
+
+// ULID returns a new ULID string.
+func ULID() string { return ULIDGenerator{}.NewID() }
+
+// UUIDv7 returns a new UUIDv7 string.
+func UUIDv7() string { return UUIDv7Generator{}.NewID() }
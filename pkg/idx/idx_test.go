@@ -0,0 +1,63 @@
+package idx
+
+import (
+	"slices"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/oklog/ulid/v2"
+)
+
+func TestGenerators_Sortable(t *testing.T) {
+	tests := []struct {
+		name  string
+		gen   Generator
+		valid func(string) error
+	}{
+		{"ulid", NewULIDGenerator(), func(s string) error { _, err := ulid.ParseStrict(s); return err }},
+		{"uuidv7", NewUUIDv7Generator(), func(s string) error { _, err := uuid.Parse(s); return err }},
+		{"sequence", NewSequenceGenerator("order"), func(string) error { return nil }},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Act
+			ids := make([]string, 1000)
+			for i := range ids {
+				ids[i] = tt.gen.NewID()
+			}
+
+			// Assert
+			if !slices.IsSorted(ids) {
+				t.Error("IDs are not sorted in generation order")
+			}
+			if len(slices.Compact(slices.Clone(ids))) != len(ids) {
+				t.Error("IDs are not unique")
+			}
+			if err := tt.valid(ids[0]); err != nil {
+				t.Errorf("invalid ID %q: %v", ids[0], err)
+			}
+		})
+	}
+}
+
+func TestUUIDv7_Version(t *testing.T) {
+	id, err := uuid.Parse(UUIDv7())
+	if err != nil {
+		t.Fatalf("Parse() error = %v", err)
+	}
+	if id.Version() != 7 {
+		t.Errorf("Version() = %d, want 7", id.Version())
+	}
+}
+
+func TestSequenceGenerator(t *testing.T) {
+	g := NewSequenceGenerator("user")
+
+	if got := g.NewID(); got != "user-000001" {
+		t.Errorf("NewID() = %q, want user-000001", got)
+	}
+	if got := g.NewID(); got != "user-000002" {
+		t.Errorf("NewID() = %q, want user-000002", got)
+	}
+}
@@ -0,0 +1,239 @@
+// Package lockx provides distributed locks on Redis using the Redlock
+// algorithm (redsync). Locks expire after a TTL so a crashed holder never
+// blocks others, and held locks are extended automatically while the
+// protected work runs:
+//
+//	err := locker.WithLock(ctx, "report:daily", func(ctx context.Context) error {
+//		return generateReport(ctx) // ctx is cancelled if the lock is lost
+//	})
+package lockx
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/go-redsync/redsync/v4"
+	redsyncredis "github.com/go-redsync/redsync/v4/redis"
+	redsyncgoredis "github.com/go-redsync/redsync/v4/redis/goredis/v9"
+	goredis "github.com/redis/go-redis/v9"
+)
+
+var (
+	// ErrNotAcquired is returned when the lock is held by someone else.
+	ErrNotAcquired = errors.New("lock not acquired")
+
+	// ErrLockLost is the cancellation cause of WithLock's context when the
+	// lock could not be extended and may now be held by someone else.
+	ErrLockLost = errors.New("lock lost")
+)
+
+// Default options.
+const (
+	DefaultTTL        = 30 * time.Second
+	DefaultRetryDelay = 100 * time.Millisecond
+	DefaultKeyPrefix  = "lock:"
+)
+
+// Options configures a Locker.
+type Options struct {
+	// TTL is how long a lock lives without being extended.
+	// Default: 30s
+	TTL time.Duration
+
+	// ExtendInterval is how often held locks are extended; negative
+	// disables extension.
+	// Default: TTL / 3
+	ExtendInterval time.Duration
+
+	// RetryDelay is the wait between attempts in Acquire.
+	// Default: 100ms
+	RetryDelay time.Duration
+
+	// KeyPrefix namespaces lock keys.
+	// Default: "lock:"
+	KeyPrefix string
+}
+
+// Locker acquires distributed locks. With several clients pointing at
+// independent Redis masters a lock needs a majority of them (Redlock);
+// a single client is the common case.
+type Locker struct {
+	rs      *redsync.Redsync
+	opts    Options
+	metrics *metrics
+}
+
+// New creates a Locker on clients, applying defaults for zero option values.
+func New(opts Options, clients ...goredis.UniversalClient) *Locker {
+	if opts.TTL <= 0 {
+		opts.TTL = DefaultTTL
+	}
+	if opts.ExtendInterval == 0 {
+		opts.ExtendInterval = opts.TTL / 3
+	}
+	if opts.RetryDelay <= 0 {
+		opts.RetryDelay = DefaultRetryDelay
+	}
+	if opts.KeyPrefix == "" {
+		opts.KeyPrefix = DefaultKeyPrefix
+	}
+
+	pools := make([]redsyncredis.Pool, len(clients))
+	for i, c := range clients {
+		pools[i] = redsyncgoredis.NewPool(c)
+	}
+	return &Locker{rs: redsync.New(pools...), opts: opts, metrics: newMetrics()}
+}
+
+// TryAcquire acquires the lock named key without waiting, returning
+// ErrNotAcquired if it is held by someone else.
+func (l *Locker) TryAcquire(ctx context.Context, key string) (*Lock, error) {
+	return l.tryAcquire(ctx, key, l.opts.TTL)
+}
+
+// Acquire waits until the lock named key is acquired or ctx is done, in
+// which case the error wraps both ErrNotAcquired and the context error.
+func (l *Locker) Acquire(ctx context.Context, key string) (*Lock, error) {
+	timer := time.NewTimer(0)
+	defer timer.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return nil, fmt.Errorf("%w: %s: %w", ErrNotAcquired, key, ctx.Err())
+		case <-timer.C:
+		}
+
+		lock, err := l.TryAcquire(ctx, key)
+		if !errors.Is(err, ErrNotAcquired) {
+			return lock, err
+		}
+		timer.Reset(l.opts.RetryDelay)
+	}
+}
+
+// WithLock runs fn while holding the lock named key, waiting for it like
+// Acquire. The context passed to fn is cancelled with ErrLockLost as cause
+// if the lock cannot be extended. The lock is released when fn returns.
+func (l *Locker) WithLock(ctx context.Context, key string, fn func(ctx context.Context) error) error {
+	lock, err := l.Acquire(ctx, key)
+	if err != nil {
+		return err
+	}
+	defer lock.Release(ctx)
+
+	ctx, cancel := context.WithCancelCause(ctx)
+	defer cancel(nil)
+	go func() {
+		select {
+		case <-lock.Lost():
+			cancel(ErrLockLost)
+		case <-ctx.Done():
+		}
+	}()
+
+	return fn(ctx)
+}
+
+// TryLock acquires the lock named key for ttl without waiting, keeping it
+// extended until unlock is called. It matches the Locker interfaces of the
+// scheduler and outbox relay.
+func (l *Locker) TryLock(ctx context.Context, key string, ttl time.Duration) (unlock func(), ok bool, err error) {
+	if ttl <= 0 {
+		ttl = l.opts.TTL
+	}
+	lock, err := l.tryAcquire(ctx, key, ttl)
+	if errors.Is(err, ErrNotAcquired) {
+		return nil, false, nil
+	}
+	if err != nil {
+		return nil, false, err
+	}
+	return func() { _ = lock.Release(ctx) }, true, nil
+}
+
+func (l *Locker) tryAcquire(ctx context.Context, key string, ttl time.Duration) (*Lock, error) {
+	mu := l.rs.NewMutex(l.opts.KeyPrefix+key, redsync.WithExpiry(ttl), redsync.WithTries(1))
+
+	err := mu.TryLockContext(ctx)
+	var taken *redsync.ErrTaken
+	switch {
+	case err == nil:
+	case errors.Is(err, redsync.ErrFailed), errors.As(err, &taken):
+		l.metrics.contended(key)
+		return nil, fmt.Errorf("%w: %s", ErrNotAcquired, key)
+	default:
+		return nil, fmt.Errorf("acquire lock %s: %w", key, err)
+	}
+
+	l.metrics.acquired(key)
+	lock := &Lock{key: key, mu: mu, metrics: l.metrics, acquiredAt: time.Now(),
+		stop: make(chan struct{}), lost: make(chan struct{})}
+	interval := l.opts.ExtendInterval
+	if interval > 0 && interval >= ttl {
+		interval = ttl / 3
+	}
+	if interval > 0 {
+		go lock.keepAlive(context.WithoutCancel(ctx), interval)
+	}
+	return lock, nil
+}
+
+// Lock is a held distributed lock.
+type Lock struct {
+	key        string
+	mu         *redsync.Mutex
+	metrics    *metrics
+	acquiredAt time.Time
+
+	once sync.Once
+	stop chan struct{}
+	lost chan struct{}
+}
+
+// Key returns the lock name, without the key prefix.
+func (lk *Lock) Key() string { return lk.key }
+
+// Lost returns a channel closed when the lock could not be extended.
+func (lk *Lock) Lost() <-chan struct{} { return lk.lost }
+
+// Release stops extending the lock and deletes it if it is still ours.
+// It is safe to call more than once; only the first call releases.
+func (lk *Lock) Release(ctx context.Context) error {
+	var err error
+	lk.once.Do(func() {
+		close(lk.stop)
+		lk.metrics.released(lk.key, time.Since(lk.acquiredAt))
+
+		// Release even if the caller's context is already cancelled.
+		ctx, cancel := context.WithTimeout(context.WithoutCancel(ctx), time.Second)
+		defer cancel()
+		if _, uerr := lk.mu.UnlockContext(ctx); uerr != nil && !errors.Is(uerr, redsync.ErrLockAlreadyExpired) {
+			err = fmt.Errorf("release lock %s: %w", lk.key, uerr)
+		}
+	})
+	return err
+}
+
+// keepAlive extends the lock every interval until released or an
+// extension fails.
+func (lk *Lock) keepAlive(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-lk.stop:
+			return
+		case <-ticker.C:
+			if ok, _ := lk.mu.ExtendContext(ctx); !ok {
+				lk.metrics.lostLock(lk.key)
+				close(lk.lost)
+				return
+			}
+		}
+	}
+}
@@ -0,0 +1,176 @@
+package lockx
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/alicebob/miniredis/v2"
+	goredis "github.com/redis/go-redis/v9"
+)
+
+func newTestLocker(t *testing.T, opts Options) (*Locker, *miniredis.Miniredis) {
+	t.Helper()
+
+	mr := miniredis.RunT(t)
+	client := goredis.NewClient(&goredis.Options{Addr: mr.Addr()})
+	t.Cleanup(func() { _ = client.Close() })
+	return New(opts, client), mr
+}
+
+func TestLocker_TryAcquire(t *testing.T) {
+	// Arrange
+	ctx := context.Background()
+	l, mr := newTestLocker(t, Options{})
+
+	// Act
+	lock, err := l.TryAcquire(ctx, "job")
+	_, secondErr := l.TryAcquire(ctx, "job")
+
+	// Assert
+	if err != nil {
+		t.Fatalf("TryAcquire() error = %v", err)
+	}
+	if !errors.Is(secondErr, ErrNotAcquired) {
+		t.Fatalf("second TryAcquire() error = %v, want %v", secondErr, ErrNotAcquired)
+	}
+	if !mr.Exists("lock:job") {
+		t.Error("lock key lock:job not set")
+	}
+
+	if err := lock.Release(ctx); err != nil {
+		t.Fatalf("Release() error = %v", err)
+	}
+	if err := lock.Release(ctx); err != nil {
+		t.Fatalf("second Release() error = %v", err)
+	}
+	again, err := l.TryAcquire(ctx, "job")
+	if err != nil {
+		t.Fatalf("TryAcquire() after release error = %v", err)
+	}
+	_ = again.Release(ctx)
+}
+
+func TestLocker_Acquire(t *testing.T) {
+	// Arrange
+	ctx := context.Background()
+	l, _ := newTestLocker(t, Options{RetryDelay: 10 * time.Millisecond})
+	held, err := l.TryAcquire(ctx, "job")
+	if err != nil {
+		t.Fatalf("TryAcquire() error = %v", err)
+	}
+
+	// Act
+	timeoutCtx, cancel := context.WithTimeout(ctx, 50*time.Millisecond)
+	defer cancel()
+	_, timeoutErr := l.Acquire(timeoutCtx, "job")
+
+	go func() {
+		time.Sleep(30 * time.Millisecond)
+		_ = held.Release(ctx)
+	}()
+	lock, err := l.Acquire(ctx, "job")
+
+	// Assert
+	if !errors.Is(timeoutErr, ErrNotAcquired) || !errors.Is(timeoutErr, context.DeadlineExceeded) {
+		t.Errorf("Acquire() with deadline error = %v, want not acquired and deadline exceeded", timeoutErr)
+	}
+	if err != nil {
+		t.Fatalf("Acquire() error = %v", err)
+	}
+	_ = lock.Release(ctx)
+}
+
+func TestLocker_WithLock(t *testing.T) {
+	// Arrange
+	ctx := context.Background()
+	l, mr := newTestLocker(t, Options{})
+	errBoom := errors.New("boom")
+
+	// Act
+	err := l.WithLock(ctx, "job", func(ctx context.Context) error {
+		if !mr.Exists("lock:job") {
+			t.Error("lock not held inside WithLock")
+		}
+		return errBoom
+	})
+
+	// Assert
+	if !errors.Is(err, errBoom) {
+		t.Errorf("WithLock() error = %v, want %v", err, errBoom)
+	}
+	if mr.Exists("lock:job") {
+		t.Error("lock still held after WithLock")
+	}
+}
+
+func TestLocker_WithLockLost(t *testing.T) {
+	// Arrange
+	ctx := context.Background()
+	l, mr := newTestLocker(t, Options{TTL: time.Second, ExtendInterval: 10 * time.Millisecond})
+
+	// Act
+	err := l.WithLock(ctx, "job", func(ctx context.Context) error {
+		mr.Del("lock:job") // the lock expired and was taken over
+		select {
+		case <-ctx.Done():
+			return context.Cause(ctx)
+		case <-time.After(time.Second):
+			return nil
+		}
+	})
+
+	// Assert
+	if !errors.Is(err, ErrLockLost) {
+		t.Errorf("WithLock() error = %v, want %v", err, ErrLockLost)
+	}
+}
+
+func TestLocker_KeepAlive(t *testing.T) {
+	// Arrange
+	ctx := context.Background()
+	l, mr := newTestLocker(t, Options{TTL: time.Second, ExtendInterval: 10 * time.Millisecond})
+	lock, err := l.TryAcquire(ctx, "job")
+	if err != nil {
+		t.Fatalf("TryAcquire() error = %v", err)
+	}
+	defer lock.Release(ctx)
+
+	// Act
+	mr.FastForward(900 * time.Millisecond)
+	time.Sleep(50 * time.Millisecond)
+
+	// Assert
+	if ttl := mr.TTL("lock:job"); ttl < 500*time.Millisecond {
+		t.Errorf("TTL = %v after extension, want close to 1s", ttl)
+	}
+	select {
+	case <-lock.Lost():
+		t.Error("lock reported lost while extensions succeed")
+	default:
+	}
+}
+
+func TestLocker_TryLock(t *testing.T) {
+	// Arrange
+	ctx := context.Background()
+	l, _ := newTestLocker(t, Options{})
+
+	// Act
+	unlock, ok, err := l.TryLock(ctx, "job", time.Minute)
+	_, second, secondErr := l.TryLock(ctx, "job", time.Minute)
+
+	// Assert
+	if err != nil || !ok {
+		t.Fatalf("TryLock() = %v, %v, want acquired", ok, err)
+	}
+	if secondErr != nil || second {
+		t.Fatalf("second TryLock() = %v, %v, want not acquired", second, secondErr)
+	}
+	unlock()
+	_, again, err := l.TryLock(ctx, "job", time.Minute)
+	if err != nil || !again {
+		t.Errorf("TryLock() after unlock = %v, %v, want acquired", again, err)
+	}
+}
@@ -0,0 +1,74 @@
+package lockx
+
+import (
+	"context"
+	"time"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/metric"
+)
+
+// instrumentationName is the OpenTelemetry meter name.
+const instrumentationName = "github.com/blackhorseya/go-ddd/pkg/lockx"
+
+// metrics exposes lock activity through OpenTelemetry metrics:
+//   - lock.acquired: counter of acquired locks
+//   - lock.contended: counter of attempts that found the lock held
+//   - lock.lost: counter of locks that could not be extended
+//   - lock.held.duration: histogram of seconds between acquire and release
+type metrics struct {
+	acquiredCount  metric.Int64Counter
+	contendedCount metric.Int64Counter
+	lostCount      metric.Int64Counter
+	held           metric.Float64Histogram
+}
+
+// newMetrics registers instruments using the global meter provider.
+// Instrument creation errors are ignored so a broken provider never blocks locking.
+func newMetrics() *metrics {
+	meter := otel.Meter(instrumentationName)
+	m := &metrics{}
+	m.acquiredCount, _ = meter.Int64Counter("lock.acquired",
+		metric.WithDescription("Number of distributed locks acquired"),
+	)
+	m.contendedCount, _ = meter.Int64Counter("lock.contended",
+		metric.WithDescription("Number of lock attempts that found the lock held"),
+	)
+	m.lostCount, _ = meter.Int64Counter("lock.lost",
+		metric.WithDescription("Number of held locks that could not be extended"),
+	)
+	m.held, _ = meter.Float64Histogram("lock.held.duration",
+		metric.WithDescription("Time a distributed lock was held"),
+		metric.WithUnit("s"),
+	)
+	return m
+}
+
+func attrs(key string) metric.MeasurementOption {
+	return metric.WithAttributes(attribute.String("lock", key))
+}
+
+func (m *metrics) acquired(key string) {
+	if m.acquiredCount != nil {
+		m.acquiredCount.Add(context.Background(), 1, attrs(key))
+	}
+}
+
+func (m *metrics) contended(key string) {
+	if m.contendedCount != nil {
+		m.contendedCount.Add(context.Background(), 1, attrs(key))
+	}
+}
+
+func (m *metrics) lostLock(key string) {
+	if m.lostCount != nil {
+		m.lostCount.Add(context.Background(), 1, attrs(key))
+	}
+}
+
+func (m *metrics) released(key string, held time.Duration) {
+	if m.held != nil {
+		m.held.Record(context.Background(), held.Seconds(), attrs(key))
+	}
+}
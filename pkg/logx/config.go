@@ -2,6 +2,8 @@
 // with configuration support and contextx integration.
 package logx
 
+import "time"
+
 // Format defines log output format.
 type Format string
 
@@ -16,6 +18,7 @@ type Output string
 const (
 	OutputStdout Output = "stdout"
 	OutputStderr Output = "stderr"
+	OutputFile   Output = "file"
 )
 
 // Level defines log level.
@@ -39,20 +42,50 @@ type Config struct {
 	// Default: json
 	Format string `mapstructure:"format" json:"format" yaml:"format"`
 
-	// Output is the output destination: stdout, stderr.
+	// Output is the output destination: stdout, stderr, file.
 	// Default: stdout
 	Output string `mapstructure:"output" json:"output" yaml:"output"`
 
+	// File configures the rotating log file used when Output is "file".
+	File FileConfig `mapstructure:"file" json:"file" yaml:"file"`
+
 	// AddSource adds source file and line number to log entries.
 	// Default: false (disabled for performance in production)
 	AddSource bool `mapstructure:"add_source" json:"add_source" yaml:"add_source"`
 }
 
+// FileConfig defines the log file and its rotation. The file is rotated
+// once it would grow past MaxSize; rotated files are named after the
+// rotation time, e.g. app-2024-01-02T15-04-05.000.log.
+type FileConfig struct {
+	// Path is the log file; its directory is created if missing. Required.
+	Path string `mapstructure:"path" json:"path" yaml:"path"`
+
+	// MaxSize is the size in bytes at which the file is rotated, rounded
+	// up to whole MiB.
+	// Default: 100 MiB
+	MaxSize int64 `mapstructure:"max_size" json:"max_size" yaml:"max_size"`
+
+	// MaxAge removes rotated files older than this, rounded up to whole
+	// days. Zero keeps them regardless of age.
+	// Default: 0
+	MaxAge time.Duration `mapstructure:"max_age" json:"max_age" yaml:"max_age"`
+
+	// MaxBackups is the number of rotated files kept. Zero keeps them all.
+	// Default: 0
+	MaxBackups int `mapstructure:"max_backups" json:"max_backups" yaml:"max_backups"`
+
+	// Compress gzips rotated files.
+	// Default: false
+	Compress bool `mapstructure:"compress" json:"compress" yaml:"compress"`
+}
+
 // Default values.
 const (
-	DefaultLevel  = "info"
-	DefaultFormat = "json"
-	DefaultOutput = "stdout"
+	DefaultLevel       = "info"
+	DefaultFormat      = "json"
+	DefaultOutput      = "stdout"
+	DefaultFileMaxSize = 100 << 20
 )
 
 // defaultConfig returns configuration with default values.
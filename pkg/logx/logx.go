@@ -6,12 +6,21 @@ import (
 	"log/slog"
 	"os"
 	"strings"
+	"time"
+
+	"gopkg.in/natefinch/lumberjack.v2"
 )
 
 // Logger wraps slog.Logger and implements the Logger interface.
 // It satisfies contextx.Logger through Go's structural typing (duck typing).
 type Logger struct {
 	*slog.Logger
+
+	// level is shared with derived loggers so SetLevel affects them all.
+	level *slog.LevelVar
+
+	// closer releases the log file when Output is "file".
+	closer io.Closer
 }
 
 // New creates a new Logger based on the provided configuration.
@@ -26,13 +35,16 @@ func New(cfg *Config) (*Logger, error) {
 		return nil, fmt.Errorf("logx: %w", err)
 	}
 
-	writer, err := getWriter(cfg.Output)
+	writer, err := getWriter(cfg.Output, cfg.File)
 	if err != nil {
 		return nil, fmt.Errorf("logx: %w", err)
 	}
 
+	levelVar := new(slog.LevelVar)
+	levelVar.Set(level)
+
 	opts := &slog.HandlerOptions{
-		Level:       level,
+		Level:       levelVar,
 		AddSource:   cfg.AddSource,
 		ReplaceAttr: shortenSource,
 	}
@@ -42,7 +54,12 @@ func New(cfg *Config) (*Logger, error) {
 		return nil, fmt.Errorf("logx: %w", err)
 	}
 
-	return &Logger{slog.New(handler)}, nil
+	l := &Logger{Logger: slog.New(handler), level: levelVar}
+	if c, ok := writer.(io.Closer); ok {
+		l.closer = c
+	}
+
+	return l, nil
 }
 
 // MustNew creates a new Logger and panics if configuration is invalid.
@@ -79,17 +96,45 @@ func parseLevel(s string) (slog.Level, error) {
 }
 
 // getWriter returns the appropriate io.Writer based on output configuration.
-func getWriter(output string) (io.Writer, error) {
+func getWriter(output string, file FileConfig) (io.Writer, error) {
 	switch strings.ToLower(output) {
 	case "stdout", "":
 		return os.Stdout, nil
 	case "stderr":
 		return os.Stderr, nil
+	case "file":
+		return fileWriter(file)
 	default:
 		return nil, fmt.Errorf("unsupported output: %s", output)
 	}
 }
 
+// fileWriter returns a writer that rotates the file at cfg.Path. The file
+// is opened on the first write.
+func fileWriter(cfg FileConfig) (io.Writer, error) {
+	if cfg.Path == "" {
+		return nil, fmt.Errorf("file output requires a path")
+	}
+	if cfg.MaxSize < 0 || cfg.MaxAge < 0 || cfg.MaxBackups < 0 {
+		return nil, fmt.Errorf("file rotation limits must not be negative")
+	}
+
+	maxSize := cfg.MaxSize
+	if maxSize == 0 {
+		maxSize = DefaultFileMaxSize
+	}
+	const day = 24 * time.Hour
+
+	return &lumberjack.Logger{
+		Filename:   cfg.Path,
+		MaxSize:    int((maxSize + 1<<20 - 1) >> 20),
+		MaxAge:     int((cfg.MaxAge + day - 1) / day),
+		MaxBackups: cfg.MaxBackups,
+		LocalTime:  true,
+		Compress:   cfg.Compress,
+	}, nil
+}
+
 // createHandler creates the appropriate slog.Handler based on format.
 func createHandler(format string, w io.Writer, opts *slog.HandlerOptions) (slog.Handler, error) {
 	switch strings.ToLower(format) {
@@ -155,12 +200,36 @@ func (l *Logger) Error(msg string, args ...any) {
 
 // With returns a new Logger with the given attributes.
 func (l *Logger) With(args ...any) *Logger {
-	return &Logger{l.Logger.With(args...)}
+	return &Logger{Logger: l.Logger.With(args...), level: l.level, closer: l.closer}
 }
 
 // WithGroup returns a new Logger with the given group name.
 func (l *Logger) WithGroup(name string) *Logger {
-	return &Logger{l.Logger.WithGroup(name)}
+	return &Logger{Logger: l.Logger.WithGroup(name), level: l.level, closer: l.closer}
+}
+
+// SetLevel changes the minimum level at runtime, e.g. on configuration
+// reload. It applies to this logger and every logger derived from it.
+func (l *Logger) SetLevel(level string) error {
+	if l.level == nil {
+		return fmt.Errorf("logx: logger was not created by New")
+	}
+	lv, err := parseLevel(level)
+	if err != nil {
+		return fmt.Errorf("logx: %w", err)
+	}
+	l.level.Set(lv)
+	return nil
+}
+
+// Close closes the log file when Output is "file" and is a no-op
+// otherwise. Loggers derived with With and WithGroup share the file, so
+// close it once, on shutdown.
+func (l *Logger) Close() error {
+	if l.closer == nil {
+		return nil
+	}
+	return l.closer.Close()
 }
 
 // SetAsDefault sets this logger as the default slog logger.
@@ -2,12 +2,17 @@ package logx
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
 	"log/slog"
+	"os"
+	"path/filepath"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/blackhorseya/go-ddd/pkg/contextx"
+	"gopkg.in/natefinch/lumberjack.v2"
 )
 
 func TestNew(t *testing.T) {
@@ -71,7 +76,7 @@ func TestNew(t *testing.T) {
 
 	t.Run("invalid output returns error", func(t *testing.T) {
 		cfg := &Config{
-			Output: "file",
+			Output: "syslog",
 		}
 
 		_, err := New(cfg)
@@ -85,6 +90,81 @@ func TestNew(t *testing.T) {
 	})
 }
 
+func TestFileOutput(t *testing.T) {
+	t.Run("writes to the file", func(t *testing.T) {
+		path := filepath.Join(t.TempDir(), "logs", "app.log")
+		l, err := New(&Config{Output: "file", File: FileConfig{Path: path}})
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		l.With("key", "value").Info("to file")
+		if err := l.Close(); err != nil {
+			t.Fatalf("close: %v", err)
+		}
+
+		data, err := os.ReadFile(path)
+		if err != nil {
+			t.Fatalf("read log file: %v", err)
+		}
+		if !strings.Contains(string(data), `"msg":"to file"`) {
+			t.Errorf("expected log entry in file, got: %s", data)
+		}
+	})
+
+	t.Run("missing path returns error", func(t *testing.T) {
+		_, err := New(&Config{Output: "file"})
+		if err == nil || !strings.Contains(err.Error(), "requires a path") {
+			t.Errorf("expected missing path error, got: %v", err)
+		}
+	})
+
+	t.Run("negative limit returns error", func(t *testing.T) {
+		_, err := New(&Config{Output: "file", File: FileConfig{Path: "app.log", MaxBackups: -1}})
+		if err == nil {
+			t.Error("expected error for negative max backups")
+		}
+	})
+
+	t.Run("close is a no-op for stdout", func(t *testing.T) {
+		if err := MustNew(nil).Close(); err != nil {
+			t.Errorf("unexpected error: %v", err)
+		}
+	})
+}
+
+func TestFileWriterLimits(t *testing.T) {
+	tests := []struct {
+		name        string
+		cfg         FileConfig
+		wantMaxSize int
+		wantMaxAge  int
+	}{
+		{"defaults", FileConfig{Path: "app.log"}, 100, 0},
+		{"exact units", FileConfig{Path: "app.log", MaxSize: 10 << 20, MaxAge: 7 * 24 * time.Hour}, 10, 7},
+		{"rounds up", FileConfig{Path: "app.log", MaxSize: 1, MaxAge: time.Hour}, 1, 1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Act
+			w, err := fileWriter(tt.cfg)
+
+			// Assert
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			lj := w.(*lumberjack.Logger)
+			if lj.MaxSize != tt.wantMaxSize {
+				t.Errorf("MaxSize = %d, want %d", lj.MaxSize, tt.wantMaxSize)
+			}
+			if lj.MaxAge != tt.wantMaxAge {
+				t.Errorf("MaxAge = %d, want %d", lj.MaxAge, tt.wantMaxAge)
+			}
+		})
+	}
+}
+
 func TestMustNew(t *testing.T) {
 	t.Run("valid config does not panic", func(t *testing.T) {
 		defer func() {
@@ -195,13 +275,34 @@ func TestLoggerWithGroup(t *testing.T) {
 	}
 }
 
+func TestLoggerSetLevel(t *testing.T) {
+	l := MustNew(&Config{Level: "info"})
+	child := l.With("key", "value")
+
+	if child.Enabled(context.Background(), slog.LevelDebug) {
+		t.Fatal("debug should be disabled at info level")
+	}
+
+	if err := l.SetLevel("debug"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if !child.Enabled(context.Background(), slog.LevelDebug) {
+		t.Error("SetLevel should apply to derived loggers")
+	}
+
+	if err := l.SetLevel("verbose"); err == nil {
+		t.Error("expected error for invalid level")
+	}
+}
+
 func TestJSONFormat(t *testing.T) {
 	var buf bytes.Buffer
 
 	handler := slog.NewJSONHandler(&buf, &slog.HandlerOptions{
 		Level: slog.LevelDebug,
 	})
-	l := &Logger{slog.New(handler)}
+	l := &Logger{Logger: slog.New(handler)}
 
 	l.Info("test message", "key", "value")
 
@@ -225,7 +326,7 @@ func TestTextFormat(t *testing.T) {
 	handler := slog.NewTextHandler(&buf, &slog.HandlerOptions{
 		Level: slog.LevelDebug,
 	})
-	l := &Logger{slog.New(handler)}
+	l := &Logger{Logger: slog.New(handler)}
 
 	l.Info("test message", "key", "value")
 
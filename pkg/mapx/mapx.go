@@ -0,0 +1,151 @@
+// Package mapx provides generic helpers for converting collections between layers,
+// e.g. entities to DTOs in use cases and request DTOs to domain values in adapters.
+//
+// MapPage and MapCursor flatten paginated results (such as domain.PageResult
+// and domain.CursorResult) into plain Page and Cursor values for adapters.
+// They accept any type with the matching accessors, so this package stays
+// free of domain types and can be reused anywhere.
+package mapx
+
+import "fmt"
+
+// Slice converts every element of in with fn.
+// A nil input yields nil so that "absent" and "empty" stay distinguishable in JSON.
+func Slice[S, D any](in []S, fn func(S) D) []D {
+	if in == nil {
+		return nil
+	}
+	out := make([]D, len(in))
+	for i, v := range in {
+		out[i] = fn(v)
+	}
+	return out
+}
+
+// TrySlice converts every element of in with fn, stopping at the first error.
+// The returned error wraps the failure together with the element index.
+func TrySlice[S, D any](in []S, fn func(S) (D, error)) ([]D, error) {
+	if in == nil {
+		return nil, nil
+	}
+	out := make([]D, len(in))
+	for i, v := range in {
+		d, err := fn(v)
+		if err != nil {
+			return nil, fmt.Errorf("mapx: element %d: %w", i, err)
+		}
+		out[i] = d
+	}
+	return out, nil
+}
+
+// Ptrs converts a slice of pointers with fn, skipping nil elements.
+func Ptrs[S, D any](in []*S, fn func(*S) D) []D {
+	if in == nil {
+		return nil
+	}
+	out := make([]D, 0, len(in))
+	for _, v := range in {
+		if v != nil {
+			out = append(out, fn(v))
+		}
+	}
+	return out
+}
+
+// KeyBy indexes in by the key returned from fn. Later elements win on duplicate keys.
+func KeyBy[K comparable, V any](in []V, fn func(V) K) map[K]V {
+	out := make(map[K]V, len(in))
+	for _, v := range in {
+		out[fn(v)] = v
+	}
+	return out
+}
+
+// PageSource is an offset-paginated result, such as domain.PageResult.
+type PageSource[T any] interface {
+	Items() []T
+	Page() int
+	PageSize() int
+	TotalItems() int64
+	TotalPages() int
+	HasTotal() bool
+	HasNext() bool
+}
+
+// Page is an offset page with its items converted for another layer.
+// TotalItems and TotalPages are 0 when HasTotal is false.
+type Page[T any] struct {
+	Items      []T
+	Page       int
+	PageSize   int
+	TotalItems int64
+	TotalPages int
+	HasTotal   bool
+	HasNext    bool
+}
+
+// MapPage converts the items of src with fn and keeps its page metadata.
+func MapPage[S, D any](src PageSource[S], fn func(S) D) Page[D] {
+	return newPage(src, Slice(src.Items(), fn))
+}
+
+// TryMapPage is MapPage for fallible conversions, stopping at the first error.
+func TryMapPage[S, D any](src PageSource[S], fn func(S) (D, error)) (Page[D], error) {
+	items, err := TrySlice(src.Items(), fn)
+	if err != nil {
+		return Page[D]{}, err
+	}
+	return newPage(src, items), nil
+}
+
+func newPage[S, D any](src PageSource[S], items []D) Page[D] {
+	return Page[D]{
+		Items:      items,
+		Page:       src.Page(),
+		PageSize:   src.PageSize(),
+		TotalItems: src.TotalItems(),
+		TotalPages: src.TotalPages(),
+		HasTotal:   src.HasTotal(),
+		HasNext:    src.HasNext(),
+	}
+}
+
+// CursorSource is a cursor-paginated result, such as domain.CursorResult.
+type CursorSource[T any] interface {
+	Items() []T
+	NextCursor() string
+	PrevCursor() string
+	HasMore() bool
+}
+
+// Cursor is a cursor page with its items converted for another layer.
+type Cursor[T any] struct {
+	Items      []T
+	NextCursor string
+	PrevCursor string
+	HasMore    bool
+}
+
+// MapCursor converts the items of src with fn and keeps its cursors.
+func MapCursor[S, D any](src CursorSource[S], fn func(S) D) Cursor[D] {
+	return newCursor(src, Slice(src.Items(), fn))
+}
+
+// TryMapCursor is MapCursor for fallible conversions, stopping at the first error.
+func TryMapCursor[S, D any](src CursorSource[S], fn func(S) (D, error)) (Cursor[D], error) {
+	items, err := TrySlice(src.Items(), fn)
+	if err != nil {
+		return Cursor[D]{}, err
+	}
+	return newCursor(src, items), nil
+}
+
+func newCursor[S, D any](src CursorSource[S], items []D) Cursor[D] {
+	return Cursor[D]{
+		Items:      items,
+		NextCursor: src.NextCursor(),
+		PrevCursor: src.PrevCursor(),
+		HasMore:    src.HasMore(),
+	}
+}
@@ -0,0 +1,191 @@
+package mapx
+
+import (
+	"errors"
+	"reflect"
+	"strconv"
+	"testing"
+)
+
+func TestSlice(t *testing.T) {
+	tests := []struct {
+		name string
+		in   []int
+		want []string
+	}{
+		{name: "nil stays nil", in: nil, want: nil},
+		{name: "empty stays empty", in: []int{}, want: []string{}},
+		{name: "converts elements", in: []int{1, 2, 3}, want: []string{"1", "2", "3"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Act
+			got := Slice(tt.in, strconv.Itoa)
+
+			// Assert
+			if (got == nil) != (tt.want == nil) {
+				t.Fatalf("Slice() nil = %v, want nil = %v", got == nil, tt.want == nil)
+			}
+			if len(got) != len(tt.want) {
+				t.Fatalf("Slice() = %v, want %v", got, tt.want)
+			}
+			for i := range got {
+				if got[i] != tt.want[i] {
+					t.Errorf("Slice()[%d] = %q, want %q", i, got[i], tt.want[i])
+				}
+			}
+		})
+	}
+}
+
+func TestTrySlice(t *testing.T) {
+	// Arrange
+	errBad := errors.New("bad")
+	parse := func(s string) (int, error) {
+		if s == "x" {
+			return 0, errBad
+		}
+		return strconv.Atoi(s)
+	}
+
+	// Act
+	got, err := TrySlice([]string{"1", "2"}, parse)
+
+	// Assert
+	if err != nil || len(got) != 2 || got[1] != 2 {
+		t.Fatalf("TrySlice() = %v, %v, want [1 2], nil", got, err)
+	}
+
+	// Act
+	got, err = TrySlice([]string{"1", "x", "3"}, parse)
+
+	// Assert
+	if !errors.Is(err, errBad) {
+		t.Fatalf("TrySlice() error = %v, want %v", err, errBad)
+	}
+	if got != nil {
+		t.Errorf("TrySlice() = %v on error, want nil", got)
+	}
+	if want := "mapx: element 1: bad"; err.Error() != want {
+		t.Errorf("TrySlice() error = %q, want %q", err.Error(), want)
+	}
+}
+
+func TestPtrs(t *testing.T) {
+	// Arrange
+	one, two := 1, 2
+
+	// Act
+	got := Ptrs([]*int{&one, nil, &two}, func(n *int) int { return *n * 10 })
+
+	// Assert
+	if len(got) != 2 || got[0] != 10 || got[1] != 20 {
+		t.Errorf("Ptrs() = %v, want [10 20]", got)
+	}
+}
+
+func TestKeyBy(t *testing.T) {
+	// Arrange
+	type user struct {
+		id   string
+		name string
+	}
+	users := []user{{"1", "a"}, {"2", "b"}, {"1", "c"}}
+
+	// Act
+	got := KeyBy(users, func(u user) string { return u.id })
+
+	// Assert
+	if len(got) != 2 {
+		t.Fatalf("KeyBy() len = %d, want 2", len(got))
+	}
+	if got["1"].name != "c" {
+		t.Errorf("KeyBy()[1] = %q, want later element %q", got["1"].name, "c")
+	}
+}
+
+// page is a PageSource and CursorSource stand-in for domain results.
+type page struct {
+	items      []int
+	totalItems int64
+	hasTotal   bool
+}
+
+func (p page) Items() []int       { return p.items }
+func (p page) Page() int          { return 2 }
+func (p page) PageSize() int      { return 2 }
+func (p page) TotalItems() int64  { return p.totalItems }
+func (p page) TotalPages() int    { return int((p.totalItems + 1) / 2) }
+func (p page) HasTotal() bool     { return p.hasTotal }
+func (p page) HasNext() bool      { return true }
+func (p page) NextCursor() string { return "next" }
+func (p page) PrevCursor() string { return "prev" }
+func (p page) HasMore() bool      { return true }
+
+func TestMapPage(t *testing.T) {
+	// Arrange
+	src := page{items: []int{3, 4}, totalItems: 5, hasTotal: true}
+
+	// Act
+	got := MapPage[int](src, strconv.Itoa)
+
+	// Assert
+	want := Page[string]{Items: []string{"3", "4"}, Page: 2, PageSize: 2, TotalItems: 5, TotalPages: 3, HasTotal: true, HasNext: true}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("MapPage() = %+v, want %+v", got, want)
+	}
+}
+
+func TestTryMapPage(t *testing.T) {
+	// Arrange
+	errBad := errors.New("bad")
+	fn := func(n int) (string, error) {
+		if n == 4 {
+			return "", errBad
+		}
+		return strconv.Itoa(n), nil
+	}
+
+	// Act
+	got, err := TryMapPage[int](page{items: []int{1, 2}}, fn)
+
+	// Assert
+	if err != nil || len(got.Items) != 2 || got.HasTotal || !got.HasNext {
+		t.Fatalf("TryMapPage() = %+v, %v, want two items without total", got, err)
+	}
+
+	// Act
+	_, err = TryMapPage[int](page{items: []int{3, 4}}, fn)
+
+	// Assert
+	if !errors.Is(err, errBad) {
+		t.Errorf("TryMapPage() error = %v, want %v", err, errBad)
+	}
+}
+
+func TestMapCursor(t *testing.T) {
+	// Act
+	got := MapCursor[int](page{items: []int{9}}, strconv.Itoa)
+
+	// Assert
+	if len(got.Items) != 1 || got.Items[0] != "9" {
+		t.Fatalf("MapCursor() items = %v, want [9]", got.Items)
+	}
+	if got.NextCursor != "next" || got.PrevCursor != "prev" || !got.HasMore {
+		t.Errorf("MapCursor() = %+v, want cursors next/prev with more", got)
+	}
+}
+
+func TestTryMapCursor(t *testing.T) {
+	// Arrange
+	errBad := errors.New("bad")
+
+	// Act
+	_, err := TryMapCursor[int](page{items: []int{1}}, func(int) (string, error) { return "", errBad })
+
+	// Assert
+	if !errors.Is(err, errBad) {
+		t.Errorf("TryMapCursor() error = %v, want %v", err, errBad)
+	}
+}
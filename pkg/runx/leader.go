@@ -0,0 +1,157 @@
+// Package runx runs long-lived components. Leader wraps a component so it
+// runs on exactly one replica at a time, handing over to another replica
+// when the leader stops or loses its lease:
+//
+//	elector := runx.NewLockElector(locker)
+//	run := runx.Leader(elector, runx.LeaderOptions{
+//		Name:      "outbox-relay",
+//		OnElected: func(ctx context.Context) { log.Info("leading outbox relay") },
+//	}, relay.Run)
+package runx
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/blackhorseya/go-ddd/pkg/lockx"
+)
+
+// ErrLeadershipLost is the cancellation cause of a leader's context when
+// its lease could not be renewed and another replica may take over.
+var ErrLeadershipLost = errors.New("leadership lost")
+
+// Elector campaigns for leadership of a named role.
+type Elector interface {
+	// Campaign blocks until this replica leads name or ctx is done.
+	Campaign(ctx context.Context, name string) (Leadership, error)
+}
+
+// Leadership is a held leadership term.
+type Leadership interface {
+	// Lost is closed when the lease could not be renewed.
+	Lost() <-chan struct{}
+
+	// Resign ends the term so another replica can be elected at once.
+	Resign(ctx context.Context) error
+}
+
+// LeaderOptions configures Leader.
+type LeaderOptions struct {
+	// Name identifies the role; replicas sharing a name elect one leader.
+	Name string
+
+	// OnElected is called when this replica gains leadership, before the
+	// component starts.
+	OnElected func(ctx context.Context)
+
+	// OnLost is called when this replica's term ends, after the component
+	// stopped, whether the lease was lost or the replica is shutting down.
+	OnLost func(ctx context.Context)
+
+	// RetryDelay is the wait before campaigning again after an election
+	// error. Default: 1s
+	RetryDelay time.Duration
+}
+
+// Leader returns a component that campaigns for opts.Name and runs run
+// while leading. When leadership is lost, run's context is cancelled with
+// ErrLeadershipLost as cause and the replica campaigns again; so does a
+// run that returns nil early. It returns nil once ctx is done, or run's
+// error if run fails while leading.
+func Leader(e Elector, opts LeaderOptions, run func(ctx context.Context) error) func(ctx context.Context) error {
+	if opts.RetryDelay <= 0 {
+		opts.RetryDelay = time.Second
+	}
+	return func(ctx context.Context) error {
+		for ctx.Err() == nil {
+			lead, err := e.Campaign(ctx, opts.Name)
+			if err != nil {
+				if ctx.Err() != nil {
+					break
+				}
+				if !sleep(ctx, opts.RetryDelay) {
+					break
+				}
+				continue
+			}
+
+			lost, err := term(ctx, lead, opts, run)
+			if err != nil && !lost {
+				return err
+			}
+		}
+		return nil
+	}
+}
+
+// term runs one leadership term and reports whether it ended because the
+// lease was lost.
+func term(ctx context.Context, lead Leadership, opts LeaderOptions, run func(ctx context.Context) error) (lost bool, err error) {
+	if opts.OnElected != nil {
+		opts.OnElected(ctx)
+	}
+
+	runCtx, cancel := context.WithCancelCause(ctx)
+	defer cancel(nil)
+	go func() {
+		select {
+		case <-lead.Lost():
+			cancel(ErrLeadershipLost)
+		case <-runCtx.Done():
+		}
+	}()
+
+	err = run(runCtx)
+	lost = errors.Is(context.Cause(runCtx), ErrLeadershipLost)
+
+	_ = lead.Resign(ctx)
+	if opts.OnLost != nil {
+		opts.OnLost(ctx)
+	}
+	if ctx.Err() != nil {
+		return lost, nil
+	}
+	return lost, err
+}
+
+func sleep(ctx context.Context, d time.Duration) bool {
+	t := time.NewTimer(d)
+	defer t.Stop()
+	select {
+	case <-ctx.Done():
+		return false
+	case <-t.C:
+		return true
+	}
+}
+
+// LockElector elects leaders with lockx distributed locks: the leader holds
+// the lock named "leader:<name>" and keeps extending it, so a crashed
+// leader is replaced once the lock TTL expires.
+type LockElector struct {
+	locker *lockx.Locker
+}
+
+var _ Elector = (*LockElector)(nil)
+
+// NewLockElector creates an Elector on locker.
+func NewLockElector(locker *lockx.Locker) *LockElector {
+	return &LockElector{locker: locker}
+}
+
+// Campaign waits until the leader lock for name is acquired.
+func (e *LockElector) Campaign(ctx context.Context, name string) (Leadership, error) {
+	lock, err := e.locker.Acquire(ctx, "leader:"+name)
+	if err != nil {
+		return nil, err
+	}
+	return lockLeadership{lock}, nil
+}
+
+type lockLeadership struct {
+	lock *lockx.Lock
+}
+
+func (l lockLeadership) Lost() <-chan struct{}            { return l.lock.Lost() }
+func (l lockLeadership) Resign(ctx context.Context) error { return l.lock.Release(ctx) }
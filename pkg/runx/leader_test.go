@@ -0,0 +1,154 @@
+package runx
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/alicebob/miniredis/v2"
+	goredis "github.com/redis/go-redis/v9"
+
+	"github.com/blackhorseya/go-ddd/pkg/lockx"
+)
+
+// fakeElector grants leadership immediately; terms are revoked by closing
+// the lost channel of the current term.
+type fakeElector struct {
+	mu       sync.Mutex
+	terms    []*fakeTerm
+	failures int
+}
+
+type fakeTerm struct {
+	lost     chan struct{}
+	resigned bool
+}
+
+func (e *fakeElector) Campaign(ctx context.Context, _ string) (Leadership, error) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	if e.failures > 0 {
+		e.failures--
+		return nil, errors.New("redis unavailable")
+	}
+	t := &fakeTerm{lost: make(chan struct{})}
+	e.terms = append(e.terms, t)
+	return t, ctx.Err()
+}
+
+func (e *fakeElector) term(i int) *fakeTerm {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	return e.terms[i]
+}
+
+func (t *fakeTerm) Lost() <-chan struct{}        { return t.lost }
+func (t *fakeTerm) Resign(context.Context) error { t.resigned = true; return nil }
+
+func TestLeader_LostLeadershipRestartsCampaign(t *testing.T) {
+	// Arrange
+	e := &fakeElector{failures: 1}
+	events := make(chan string, 10)
+	causes := make(chan error, 10)
+	run := Leader(e, LeaderOptions{
+		Name:       "relay",
+		OnElected:  func(context.Context) { events <- "elected" },
+		OnLost:     func(context.Context) { events <- "lost" },
+		RetryDelay: time.Millisecond,
+	}, func(ctx context.Context) error {
+		<-ctx.Done()
+		causes <- context.Cause(ctx)
+		return ctx.Err()
+	})
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan error, 1)
+
+	// Act
+	go func() { done <- run(ctx) }()
+	next := func() string {
+		select {
+		case ev := <-events:
+			return ev
+		case <-time.After(time.Second):
+			t.Fatal("timed out waiting for leadership event")
+			return ""
+		}
+	}
+
+	// Assert
+	if ev := next(); ev != "elected" {
+		t.Fatalf("event = %q, want elected", ev)
+	}
+	close(e.term(0).lost)
+	if ev := next(); ev != "lost" {
+		t.Fatalf("event = %q, want lost", ev)
+	}
+	if cause := <-causes; !errors.Is(cause, ErrLeadershipLost) {
+		t.Errorf("run cause = %v, want %v", cause, ErrLeadershipLost)
+	}
+	if ev := next(); ev != "elected" {
+		t.Fatalf("event = %q, want re-elected", ev)
+	}
+
+	cancel()
+	if err := <-done; err != nil {
+		t.Errorf("Leader() error = %v, want nil on shutdown", err)
+	}
+	if !e.term(1).resigned {
+		t.Error("leadership not resigned on shutdown")
+	}
+}
+
+func TestLeader_RunError(t *testing.T) {
+	// Arrange
+	boom := errors.New("boom")
+	e := &fakeElector{}
+	run := Leader(e, LeaderOptions{Name: "cron"}, func(context.Context) error { return boom })
+
+	// Act
+	err := run(context.Background())
+
+	// Assert
+	if !errors.Is(err, boom) {
+		t.Errorf("Leader() error = %v, want %v", err, boom)
+	}
+	if !e.term(0).resigned {
+		t.Error("leadership not resigned after failure")
+	}
+}
+
+func TestLockElector_SingleLeader(t *testing.T) {
+	// Arrange
+	mr := miniredis.RunT(t)
+	newElector := func() *LockElector {
+		client := goredis.NewClient(&goredis.Options{Addr: mr.Addr()})
+		t.Cleanup(func() { _ = client.Close() })
+		return NewLockElector(lockx.New(lockx.Options{RetryDelay: time.Millisecond}, client))
+	}
+	a, b := newElector(), newElector()
+	ctx := context.Background()
+
+	// Act
+	lead, err := a.Campaign(ctx, "relay")
+	if err != nil {
+		t.Fatalf("Campaign(a) error = %v", err)
+	}
+	waitCtx, cancel := context.WithTimeout(ctx, 20*time.Millisecond)
+	_, blockedErr := b.Campaign(waitCtx, "relay")
+	cancel()
+
+	// Assert
+	if blockedErr == nil {
+		t.Fatal("Campaign(b) while a leads succeeded, want it to wait")
+	}
+	if err := lead.Resign(ctx); err != nil {
+		t.Fatalf("Resign() error = %v", err)
+	}
+	next, err := b.Campaign(ctx, "relay")
+	if err != nil {
+		t.Fatalf("Campaign(b) after resign error = %v", err)
+	}
+	_ = next.Resign(ctx)
+}
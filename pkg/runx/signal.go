@@ -0,0 +1,33 @@
+package runx
+
+import "sync"
+
+// Signal is a one-shot event, such as a component finishing its startup.
+// The zero value is ready to use and it is safe for concurrent use.
+type Signal struct {
+	mu   sync.Mutex
+	once sync.Once
+	ch   chan struct{}
+}
+
+// Done returns a channel closed once Fire is called.
+func (s *Signal) Done() <-chan struct{} {
+	return s.channel()
+}
+
+// Fire closes the Done channel; later calls do nothing.
+func (s *Signal) Fire() {
+	ch := s.channel()
+	s.once.Do(func() { close(ch) })
+}
+
+// channel returns the Done channel, creating it on first use.
+func (s *Signal) channel() chan struct{} {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.ch == nil {
+		s.ch = make(chan struct{})
+	}
+	return s.ch
+}
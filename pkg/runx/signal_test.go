@@ -0,0 +1,30 @@
+package runx
+
+import "testing"
+
+func TestSignal(t *testing.T) {
+	// Arrange
+	var s Signal
+	done := s.Done()
+
+	// Assert: not fired yet
+	select {
+	case <-done:
+		t.Fatal("Done() closed before Fire()")
+	default:
+	}
+
+	// Act
+	s.Fire()
+	s.Fire()
+
+	// Assert
+	select {
+	case <-done:
+	default:
+		t.Fatal("Done() not closed after Fire()")
+	}
+	if s.Done() != done {
+		t.Error("Done() returned a different channel after Fire()")
+	}
+}
@@ -0,0 +1,231 @@
+package storagex
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"mime"
+	"net/http"
+	"net/url"
+	"os"
+	"path"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// tempPrefix marks in-flight uploads, which List skips.
+const tempPrefix = ".upload-"
+
+// Local is a Storage on the local filesystem for development and
+// single-node deployments. Presigned URLs carry an expiry and an HMAC
+// signature and are served by Local itself as an http.Handler.
+type Local struct {
+	dir     string
+	baseURL string
+	key     []byte
+	now     func() time.Time
+}
+
+var (
+	_ Storage      = (*Local)(nil)
+	_ http.Handler = (*Local)(nil)
+)
+
+// NewLocal creates a Local store rooted at dir. baseURL is the public URL
+// where the store is mounted, e.g. "http://localhost:8080/files", and
+// signingKey authenticates presigned URLs.
+func NewLocal(dir, baseURL string, signingKey []byte) *Local {
+	return &Local{
+		dir:     dir,
+		baseURL: strings.TrimRight(baseURL, "/"),
+		key:     signingKey,
+		now:     time.Now,
+	}
+}
+
+// Put writes the object to a temporary file and renames it into place,
+// so readers never observe a partial object.
+func (l *Local) Put(_ context.Context, key string, r io.Reader, _ string) error {
+	name, err := l.path(key)
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(name), 0o750); err != nil {
+		return fmt.Errorf("create object directory: %w", err)
+	}
+
+	tmp, err := os.CreateTemp(filepath.Dir(name), tempPrefix+"*")
+	if err != nil {
+		return fmt.Errorf("create object %s: %w", key, err)
+	}
+	defer os.Remove(tmp.Name())
+
+	if _, err := io.Copy(tmp, r); err != nil {
+		_ = tmp.Close()
+		return fmt.Errorf("write object %s: %w", key, err)
+	}
+	if err := tmp.Close(); err != nil {
+		return fmt.Errorf("write object %s: %w", key, err)
+	}
+	if err := os.Rename(tmp.Name(), name); err != nil {
+		return fmt.Errorf("write object %s: %w", key, err)
+	}
+	return nil
+}
+
+// Get opens the object file. The content type is derived from the key's
+// extension because the filesystem does not record it.
+func (l *Local) Get(_ context.Context, key string) (io.ReadCloser, Object, error) {
+	name, err := l.path(key)
+	if err != nil {
+		return nil, Object{}, err
+	}
+	f, err := os.Open(name)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, Object{}, fmt.Errorf("%w: %s", ErrNotFound, key)
+	}
+	if err != nil {
+		return nil, Object{}, fmt.Errorf("open object %s: %w", key, err)
+	}
+	info, err := f.Stat()
+	if err != nil {
+		_ = f.Close()
+		return nil, Object{}, fmt.Errorf("stat object %s: %w", key, err)
+	}
+	return f, l.object(key, info), nil
+}
+
+// Delete removes the object file.
+func (l *Local) Delete(_ context.Context, key string) error {
+	name, err := l.path(key)
+	if err != nil {
+		return err
+	}
+	if err := os.Remove(name); err != nil && !errors.Is(err, os.ErrNotExist) {
+		return fmt.Errorf("delete object %s: %w", key, err)
+	}
+	return nil
+}
+
+// List walks the directory tree, pruning directories outside prefix.
+func (l *Local) List(ctx context.Context, prefix string, fn func(Object) error) error {
+	err := filepath.WalkDir(l.dir, func(name string, d fs.DirEntry, err error) error {
+		if err != nil {
+			if errors.Is(err, os.ErrNotExist) && name == l.dir {
+				return fs.SkipAll
+			}
+			return err
+		}
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		rel, err := filepath.Rel(l.dir, name)
+		if err != nil {
+			return err
+		}
+		key := filepath.ToSlash(rel)
+		if d.IsDir() {
+			// Prune directories that cannot contain a matching key.
+			if key != "." && !strings.HasPrefix(key+"/", prefix) && !strings.HasPrefix(prefix, key+"/") {
+				return fs.SkipDir
+			}
+			return nil
+		}
+		if strings.HasPrefix(d.Name(), tempPrefix) || !strings.HasPrefix(key, prefix) {
+			return nil
+		}
+		info, err := d.Info()
+		if err != nil {
+			return err
+		}
+		return fn(l.object(key, info))
+	})
+	if err != nil {
+		return fmt.Errorf("list objects %q: %w", prefix, err)
+	}
+	return nil
+}
+
+// PresignGet returns a signed download URL valid for ttl.
+func (l *Local) PresignGet(_ context.Context, key string, ttl time.Duration) (string, error) {
+	name, err := l.path(key)
+	if err != nil {
+		return "", err
+	}
+	if _, err := os.Stat(name); errors.Is(err, os.ErrNotExist) {
+		return "", fmt.Errorf("%w: %s", ErrNotFound, key)
+	}
+
+	expires := strconv.FormatInt(l.now().Add(ttl).Unix(), 10)
+	q := url.Values{
+		"expires":   {expires},
+		"signature": {l.sign(key, expires)},
+	}
+	return l.baseURL + "/" + key + "?" + q.Encode(), nil
+}
+
+// ServeHTTP serves an object for a valid, unexpired presigned URL.
+// The request path is the object key; mount it with http.StripPrefix.
+func (l *Local) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	key := strings.TrimPrefix(r.URL.Path, "/")
+	expires := r.URL.Query().Get("expires")
+	signature := r.URL.Query().Get("signature")
+
+	exp, err := strconv.ParseInt(expires, 10, 64)
+	if err != nil || l.now().Unix() > exp ||
+		!hmac.Equal([]byte(signature), []byte(l.sign(key, expires))) {
+		http.Error(w, "invalid or expired signature", http.StatusForbidden)
+		return
+	}
+
+	name, err := l.path(key)
+	if err != nil {
+		http.NotFound(w, r)
+		return
+	}
+	f, err := os.Open(name)
+	if err != nil {
+		http.NotFound(w, r)
+		return
+	}
+	defer f.Close()
+
+	info, err := f.Stat()
+	if err != nil {
+		http.NotFound(w, r)
+		return
+	}
+	w.Header().Set("Content-Disposition", `attachment; filename="`+path.Base(key)+`"`)
+	http.ServeContent(w, r, path.Base(key), info.ModTime(), f)
+}
+
+// sign returns the hex HMAC-SHA256 of key and expiry.
+func (l *Local) sign(key, expires string) string {
+	mac := hmac.New(sha256.New, l.key)
+	mac.Write([]byte(key + "\n" + expires))
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+func (l *Local) object(key string, info fs.FileInfo) Object {
+	return Object{
+		Key:         key,
+		Size:        info.Size(),
+		ContentType: mime.TypeByExtension(path.Ext(key)),
+		ModTime:     info.ModTime(),
+	}
+}
+
+// path maps a key to a file under dir, rejecting keys that escape it.
+func (l *Local) path(key string) (string, error) {
+	if err := checkKey(key); err != nil {
+		return "", err
+	}
+	return filepath.Join(l.dir, filepath.FromSlash(key)), nil
+}
@@ -0,0 +1,127 @@
+package storagex
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestLocal_PresignedDownload(t *testing.T) {
+	// Arrange
+	now := time.Unix(1_700_000_000, 0)
+	l := NewLocal(t.TempDir(), "http://files.test/files/", []byte("secret"))
+	l.now = func() time.Time { return now }
+
+	ctx := context.Background()
+	if err := l.Put(ctx, "exports/orders/1.csv", strings.NewReader("id\n1\n"), "text/csv"); err != nil {
+		t.Fatalf("Put() error = %v", err)
+	}
+
+	// Act
+	raw, err := l.PresignGet(ctx, "exports/orders/1.csv", time.Minute)
+
+	// Assert
+	if err != nil {
+		t.Fatalf("PresignGet() error = %v", err)
+	}
+	u, _ := url.Parse(raw)
+	if got, want := u.Host+u.Path, "files.test/files/exports/orders/1.csv"; got != want {
+		t.Errorf("PresignGet() = %q, want %q", got, want)
+	}
+
+	serve := func(path string) *httptest.ResponseRecorder {
+		w := httptest.NewRecorder()
+		http.StripPrefix("/files", l).ServeHTTP(w, httptest.NewRequest(http.MethodGet, path, nil))
+		return w
+	}
+
+	w := serve(u.RequestURI())
+	body, _ := io.ReadAll(w.Body)
+	if w.Code != http.StatusOK || string(body) != "id\n1\n" {
+		t.Errorf("download = %d %q, want 200 %q", w.Code, body, "id\n1\n")
+	}
+
+	tampered := strings.Replace(u.RequestURI(), "1.csv", "2.csv", 1)
+	if w := serve(tampered); w.Code != http.StatusForbidden {
+		t.Errorf("tampered download status = %d, want %d", w.Code, http.StatusForbidden)
+	}
+
+	now = now.Add(2 * time.Minute)
+	if w := serve(u.RequestURI()); w.Code != http.StatusForbidden {
+		t.Errorf("expired download status = %d, want %d", w.Code, http.StatusForbidden)
+	}
+}
+
+func TestLocal_Errors(t *testing.T) {
+	l := NewLocal(t.TempDir(), "http://files.test", []byte("secret"))
+	ctx := context.Background()
+
+	if _, err := l.PresignGet(ctx, "missing.csv", time.Minute); !errors.Is(err, ErrNotFound) {
+		t.Errorf("PresignGet(missing) error = %v, want %v", err, ErrNotFound)
+	}
+
+	for _, key := range []string{"", "../escape.csv", "a/../../b", "/abs.csv"} {
+		if err := l.Put(ctx, key, strings.NewReader("x"), ""); !errors.Is(err, ErrInvalidKey) {
+			t.Errorf("Put(%q) error = %v, want %v", key, err, ErrInvalidKey)
+		}
+	}
+}
+
+func TestLocal_GetDeleteList(t *testing.T) {
+	// Arrange
+	l := NewLocal(t.TempDir(), "http://files.test", []byte("secret"))
+	ctx := context.Background()
+	for _, key := range []string{"exports/a.csv", "exports/b.csv", "uploads/c.png"} {
+		if err := l.Put(ctx, key, strings.NewReader(key), ""); err != nil {
+			t.Fatalf("Put(%q) error = %v", key, err)
+		}
+	}
+
+	// Act
+	rc, obj, err := l.Get(ctx, "exports/a.csv")
+
+	// Assert
+	if err != nil {
+		t.Fatalf("Get() error = %v", err)
+	}
+	body, _ := io.ReadAll(rc)
+	_ = rc.Close()
+	if string(body) != "exports/a.csv" || obj.Size != int64(len(body)) || !strings.HasPrefix(obj.ContentType, "text/csv") {
+		t.Errorf("Get() = %q %+v", body, obj)
+	}
+
+	var keys []string
+	err = l.List(ctx, "exports/", func(o Object) error {
+		keys = append(keys, o.Key)
+		return nil
+	})
+	if err != nil || strings.Join(keys, ",") != "exports/a.csv,exports/b.csv" {
+		t.Errorf("List(exports/) = %v, %v", keys, err)
+	}
+
+	if err := l.Delete(ctx, "exports/a.csv"); err != nil {
+		t.Fatalf("Delete() error = %v", err)
+	}
+	if err := l.Delete(ctx, "exports/a.csv"); err != nil {
+		t.Errorf("Delete(missing) error = %v, want nil", err)
+	}
+	if _, _, err := l.Get(ctx, "exports/a.csv"); !errors.Is(err, ErrNotFound) {
+		t.Errorf("Get(deleted) error = %v, want %v", err, ErrNotFound)
+	}
+
+	stop := errors.New("stop")
+	calls := 0
+	err = l.List(ctx, "", func(Object) error {
+		calls++
+		return stop
+	})
+	if !errors.Is(err, stop) || calls != 1 {
+		t.Errorf("List() stop = %v after %d calls, want %v after 1", err, calls, stop)
+	}
+}
@@ -0,0 +1,280 @@
+package storagex
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	v4 "github.com/aws/aws-sdk-go-v2/aws/signer/v4"
+	awsconfig "github.com/aws/aws-sdk-go-v2/config"
+	"github.com/aws/aws-sdk-go-v2/service/s3"
+	"github.com/aws/aws-sdk-go-v2/service/s3/types"
+	"github.com/aws/smithy-go"
+)
+
+const (
+	// minPartSize is the smallest part S3 accepts in a multipart upload.
+	minPartSize = 5 << 20
+
+	defaultPartSize = 8 << 20
+)
+
+// S3Config configures an S3-compatible backend such as AWS S3 or MinIO.
+type S3Config struct {
+	// Bucket holds all objects.
+	Bucket string
+
+	// Region is the bucket region; credentials come from the default AWS chain.
+	Region string
+
+	// Endpoint overrides the service endpoint, e.g. "http://localhost:9000"
+	// for MinIO. Empty uses AWS.
+	Endpoint string
+
+	// UsePathStyle addresses the bucket in the path instead of the host,
+	// as most S3-compatible servers require.
+	UsePathStyle bool
+
+	// PartSize is the multipart upload part size and the memory buffered per
+	// Put. Values below 5 MiB are raised to it; defaults to 8 MiB.
+	PartSize int64
+}
+
+// S3API is the subset of *s3.Client used by S3.
+type S3API interface {
+	s3.ListObjectsV2APIClient
+	PutObject(ctx context.Context, in *s3.PutObjectInput, optFns ...func(*s3.Options)) (*s3.PutObjectOutput, error)
+	GetObject(ctx context.Context, in *s3.GetObjectInput, optFns ...func(*s3.Options)) (*s3.GetObjectOutput, error)
+	HeadObject(ctx context.Context, in *s3.HeadObjectInput, optFns ...func(*s3.Options)) (*s3.HeadObjectOutput, error)
+	DeleteObject(ctx context.Context, in *s3.DeleteObjectInput, optFns ...func(*s3.Options)) (*s3.DeleteObjectOutput, error)
+	CreateMultipartUpload(ctx context.Context, in *s3.CreateMultipartUploadInput, optFns ...func(*s3.Options)) (*s3.CreateMultipartUploadOutput, error)
+	UploadPart(ctx context.Context, in *s3.UploadPartInput, optFns ...func(*s3.Options)) (*s3.UploadPartOutput, error)
+	CompleteMultipartUpload(ctx context.Context, in *s3.CompleteMultipartUploadInput, optFns ...func(*s3.Options)) (*s3.CompleteMultipartUploadOutput, error)
+	AbortMultipartUpload(ctx context.Context, in *s3.AbortMultipartUploadInput, optFns ...func(*s3.Options)) (*s3.AbortMultipartUploadOutput, error)
+}
+
+// presigner is the subset of *s3.PresignClient used by S3.
+type presigner interface {
+	PresignGetObject(ctx context.Context, in *s3.GetObjectInput, optFns ...func(*s3.PresignOptions)) (*v4.PresignedHTTPRequest, error)
+}
+
+// S3 is a Storage on an S3-compatible bucket. Put streams the reader in
+// parts, so objects of unknown size are uploaded with bounded memory.
+type S3 struct {
+	api      S3API
+	presign  presigner
+	bucket   string
+	partSize int64
+}
+
+var _ Storage = (*S3)(nil)
+
+// NewS3 creates an S3 backend using the default AWS credential chain.
+func NewS3(ctx context.Context, cfg S3Config) (*S3, error) {
+	awsCfg, err := awsconfig.LoadDefaultConfig(ctx, awsconfig.WithRegion(cfg.Region))
+	if err != nil {
+		return nil, fmt.Errorf("load aws config: %w", err)
+	}
+	client := s3.NewFromConfig(awsCfg, func(o *s3.Options) {
+		if cfg.Endpoint != "" {
+			o.BaseEndpoint = aws.String(cfg.Endpoint)
+		}
+		o.UsePathStyle = cfg.UsePathStyle
+	})
+	return newS3(client, s3.NewPresignClient(client), cfg.Bucket, cfg.PartSize), nil
+}
+
+func newS3(api S3API, p presigner, bucket string, partSize int64) *S3 {
+	if partSize <= 0 {
+		partSize = defaultPartSize
+	}
+	return &S3{api: api, presign: p, bucket: bucket, partSize: max(partSize, minPartSize)}
+}
+
+// Put uploads objects smaller than one part with a single PutObject and
+// larger ones as a multipart upload, aborted if any part fails.
+func (s *S3) Put(ctx context.Context, key string, r io.Reader, contentType string) error {
+	if err := checkKey(key); err != nil {
+		return err
+	}
+
+	buf := make([]byte, s.partSize)
+	n, err := io.ReadFull(r, buf)
+	if errors.Is(err, io.EOF) || errors.Is(err, io.ErrUnexpectedEOF) {
+		_, err = s.api.PutObject(ctx, &s3.PutObjectInput{
+			Bucket:        aws.String(s.bucket),
+			Key:           aws.String(key),
+			Body:          bytes.NewReader(buf[:n]),
+			ContentLength: aws.Int64(int64(n)),
+			ContentType:   optional(contentType),
+		})
+		if err != nil {
+			return fmt.Errorf("put object %s: %w", key, err)
+		}
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("read object %s: %w", key, err)
+	}
+
+	created, err := s.api.CreateMultipartUpload(ctx, &s3.CreateMultipartUploadInput{
+		Bucket:      aws.String(s.bucket),
+		Key:         aws.String(key),
+		ContentType: optional(contentType),
+	})
+	if err != nil {
+		return fmt.Errorf("create multipart upload %s: %w", key, err)
+	}
+	if err := s.uploadParts(ctx, key, created.UploadId, r, buf[:n]); err != nil {
+		_, _ = s.api.AbortMultipartUpload(context.WithoutCancel(ctx), &s3.AbortMultipartUploadInput{
+			Bucket:   aws.String(s.bucket),
+			Key:      aws.String(key),
+			UploadId: created.UploadId,
+		})
+		return fmt.Errorf("upload object %s: %w", key, err)
+	}
+	return nil
+}
+
+// uploadParts uploads first and the rest of r part by part, then completes
+// the upload.
+func (s *S3) uploadParts(ctx context.Context, key string, uploadID *string, r io.Reader, first []byte) error {
+	var parts []types.CompletedPart
+	part := first
+	for number := int32(1); len(part) > 0; number++ {
+		out, err := s.api.UploadPart(ctx, &s3.UploadPartInput{
+			Bucket:        aws.String(s.bucket),
+			Key:           aws.String(key),
+			UploadId:      uploadID,
+			PartNumber:    aws.Int32(number),
+			Body:          bytes.NewReader(part),
+			ContentLength: aws.Int64(int64(len(part))),
+		})
+		if err != nil {
+			return fmt.Errorf("part %d: %w", number, err)
+		}
+		parts = append(parts, types.CompletedPart{ETag: out.ETag, PartNumber: aws.Int32(number)})
+
+		n, err := io.ReadFull(r, part[:cap(part)])
+		if err != nil && !errors.Is(err, io.EOF) && !errors.Is(err, io.ErrUnexpectedEOF) {
+			return err
+		}
+		part = part[:n]
+	}
+
+	_, err := s.api.CompleteMultipartUpload(ctx, &s3.CompleteMultipartUploadInput{
+		Bucket:          aws.String(s.bucket),
+		Key:             aws.String(key),
+		UploadId:        uploadID,
+		MultipartUpload: &types.CompletedMultipartUpload{Parts: parts},
+	})
+	return err
+}
+
+// Get streams the object body; the caller must close it.
+func (s *S3) Get(ctx context.Context, key string) (io.ReadCloser, Object, error) {
+	if err := checkKey(key); err != nil {
+		return nil, Object{}, err
+	}
+	out, err := s.api.GetObject(ctx, &s3.GetObjectInput{
+		Bucket: aws.String(s.bucket),
+		Key:    aws.String(key),
+	})
+	if err != nil {
+		return nil, Object{}, wrapS3Error("get object", key, err)
+	}
+	return out.Body, Object{
+		Key:         key,
+		Size:        aws.ToInt64(out.ContentLength),
+		ContentType: aws.ToString(out.ContentType),
+		ModTime:     aws.ToTime(out.LastModified),
+	}, nil
+}
+
+// Delete removes the object; S3 reports success for missing keys.
+func (s *S3) Delete(ctx context.Context, key string) error {
+	if err := checkKey(key); err != nil {
+		return err
+	}
+	_, err := s.api.DeleteObject(ctx, &s3.DeleteObjectInput{
+		Bucket: aws.String(s.bucket),
+		Key:    aws.String(key),
+	})
+	if err != nil {
+		return fmt.Errorf("delete object %s: %w", key, err)
+	}
+	return nil
+}
+
+// PresignGet checks that the object exists and returns a SigV4 presigned
+// GET URL valid for ttl.
+func (s *S3) PresignGet(ctx context.Context, key string, ttl time.Duration) (string, error) {
+	if err := checkKey(key); err != nil {
+		return "", err
+	}
+	_, err := s.api.HeadObject(ctx, &s3.HeadObjectInput{
+		Bucket: aws.String(s.bucket),
+		Key:    aws.String(key),
+	})
+	if err != nil {
+		return "", wrapS3Error("head object", key, err)
+	}
+
+	req, err := s.presign.PresignGetObject(ctx, &s3.GetObjectInput{
+		Bucket: aws.String(s.bucket),
+		Key:    aws.String(key),
+	}, s3.WithPresignExpires(ttl))
+	if err != nil {
+		return "", fmt.Errorf("presign object %s: %w", key, err)
+	}
+	return req.URL, nil
+}
+
+// List pages through ListObjectsV2 in key order.
+func (s *S3) List(ctx context.Context, prefix string, fn func(Object) error) error {
+	pages := s3.NewListObjectsV2Paginator(s.api, &s3.ListObjectsV2Input{
+		Bucket: aws.String(s.bucket),
+		Prefix: optional(prefix),
+	})
+	for pages.HasMorePages() {
+		page, err := pages.NextPage(ctx)
+		if err != nil {
+			return fmt.Errorf("list objects %q: %w", prefix, err)
+		}
+		for _, o := range page.Contents {
+			err := fn(Object{
+				Key:     aws.ToString(o.Key),
+				Size:    aws.ToInt64(o.Size),
+				ModTime: aws.ToTime(o.LastModified),
+			})
+			if err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// wrapS3Error maps S3's missing-key errors to ErrNotFound.
+func wrapS3Error(op, key string, err error) error {
+	var noSuchKey *types.NoSuchKey
+	var notFound *types.NotFound
+	var apiErr smithy.APIError
+	if errors.As(err, &noSuchKey) || errors.As(err, &notFound) ||
+		(errors.As(err, &apiErr) && apiErr.ErrorCode() == "NotFound") {
+		return fmt.Errorf("%w: %s", ErrNotFound, key)
+	}
+	return fmt.Errorf("%s %s: %w", op, key, err)
+}
+
+// optional returns nil for an empty string so S3 applies its default.
+func optional(s string) *string {
+	if s == "" {
+		return nil
+	}
+	return aws.String(s)
+}
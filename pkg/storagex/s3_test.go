@@ -0,0 +1,244 @@
+package storagex
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"sort"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/credentials"
+	"github.com/aws/aws-sdk-go-v2/service/s3"
+	"github.com/aws/aws-sdk-go-v2/service/s3/types"
+)
+
+// fakeS3 is an in-memory S3API with multipart uploads and paged listings.
+type fakeS3 struct {
+	mu       sync.Mutex
+	objects  map[string][]byte
+	uploads  map[string]map[int32][]byte
+	aborted  int
+	failPart int32
+	pageSize int32
+}
+
+func newFakeS3() *fakeS3 {
+	return &fakeS3{objects: map[string][]byte{}, uploads: map[string]map[int32][]byte{}, pageSize: 2}
+}
+
+func (f *fakeS3) PutObject(_ context.Context, in *s3.PutObjectInput, _ ...func(*s3.Options)) (*s3.PutObjectOutput, error) {
+	b, err := io.ReadAll(in.Body)
+	if err != nil {
+		return nil, err
+	}
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.objects[*in.Key] = b
+	return &s3.PutObjectOutput{}, nil
+}
+
+func (f *fakeS3) GetObject(_ context.Context, in *s3.GetObjectInput, _ ...func(*s3.Options)) (*s3.GetObjectOutput, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	b, ok := f.objects[*in.Key]
+	if !ok {
+		return nil, &types.NoSuchKey{}
+	}
+	return &s3.GetObjectOutput{Body: io.NopCloser(bytes.NewReader(b)), ContentLength: aws.Int64(int64(len(b)))}, nil
+}
+
+func (f *fakeS3) HeadObject(_ context.Context, in *s3.HeadObjectInput, _ ...func(*s3.Options)) (*s3.HeadObjectOutput, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if _, ok := f.objects[*in.Key]; !ok {
+		return nil, &types.NotFound{}
+	}
+	return &s3.HeadObjectOutput{}, nil
+}
+
+func (f *fakeS3) DeleteObject(_ context.Context, in *s3.DeleteObjectInput, _ ...func(*s3.Options)) (*s3.DeleteObjectOutput, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	delete(f.objects, *in.Key)
+	return &s3.DeleteObjectOutput{}, nil
+}
+
+func (f *fakeS3) ListObjectsV2(_ context.Context, in *s3.ListObjectsV2Input, _ ...func(*s3.Options)) (*s3.ListObjectsV2Output, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	var keys []string
+	for k := range f.objects {
+		if strings.HasPrefix(k, aws.ToString(in.Prefix)) && k > aws.ToString(in.ContinuationToken) {
+			keys = append(keys, k)
+		}
+	}
+	sort.Strings(keys)
+	out := &s3.ListObjectsV2Output{}
+	if len(keys) > int(f.pageSize) {
+		keys = keys[:f.pageSize]
+		out.IsTruncated = aws.Bool(true)
+		out.NextContinuationToken = aws.String(keys[len(keys)-1])
+	}
+	for _, k := range keys {
+		out.Contents = append(out.Contents, types.Object{Key: aws.String(k), Size: aws.Int64(int64(len(f.objects[k])))})
+	}
+	return out, nil
+}
+
+func (f *fakeS3) CreateMultipartUpload(_ context.Context, in *s3.CreateMultipartUploadInput, _ ...func(*s3.Options)) (*s3.CreateMultipartUploadOutput, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.uploads[*in.Key] = map[int32][]byte{}
+	return &s3.CreateMultipartUploadOutput{UploadId: in.Key}, nil
+}
+
+func (f *fakeS3) UploadPart(_ context.Context, in *s3.UploadPartInput, _ ...func(*s3.Options)) (*s3.UploadPartOutput, error) {
+	if *in.PartNumber == f.failPart {
+		return nil, errors.New("connection reset")
+	}
+	b, err := io.ReadAll(in.Body)
+	if err != nil {
+		return nil, err
+	}
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.uploads[*in.UploadId][*in.PartNumber] = b
+	return &s3.UploadPartOutput{ETag: aws.String("etag")}, nil
+}
+
+func (f *fakeS3) CompleteMultipartUpload(_ context.Context, in *s3.CompleteMultipartUploadInput, _ ...func(*s3.Options)) (*s3.CompleteMultipartUploadOutput, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	var b []byte
+	for _, p := range in.MultipartUpload.Parts {
+		b = append(b, f.uploads[*in.UploadId][*p.PartNumber]...)
+	}
+	f.objects[*in.Key] = b
+	delete(f.uploads, *in.UploadId)
+	return &s3.CompleteMultipartUploadOutput{}, nil
+}
+
+func (f *fakeS3) AbortMultipartUpload(_ context.Context, in *s3.AbortMultipartUploadInput, _ ...func(*s3.Options)) (*s3.AbortMultipartUploadOutput, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	delete(f.uploads, *in.UploadId)
+	f.aborted++
+	return &s3.AbortMultipartUploadOutput{}, nil
+}
+
+func newTestS3(api *fakeS3) *S3 {
+	client := s3.New(s3.Options{
+		Region:      "us-east-1",
+		Credentials: credentials.NewStaticCredentialsProvider("key", "secret", ""),
+	})
+	s := newS3(api, s3.NewPresignClient(client), "files", 0)
+	s.partSize = 4
+	return s
+}
+
+func TestS3_Put(t *testing.T) {
+	tests := []struct {
+		name      string
+		body      string
+		wantParts bool
+	}{
+		{name: "empty object", body: ""},
+		{name: "single part", body: "abc"},
+		{name: "exact part", body: "abcd", wantParts: true},
+		{name: "multipart", body: "abcdefghij", wantParts: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Arrange
+			api := newFakeS3()
+			s := newTestS3(api)
+
+			// Act
+			err := s.Put(context.Background(), "a.txt", strings.NewReader(tt.body), "text/plain")
+
+			// Assert
+			if err != nil {
+				t.Fatalf("Put() error = %v", err)
+			}
+			if got := string(api.objects["a.txt"]); got != tt.body {
+				t.Errorf("stored = %q, want %q", got, tt.body)
+			}
+		})
+	}
+}
+
+func TestS3_PutAbortsFailedUpload(t *testing.T) {
+	// Arrange
+	api := newFakeS3()
+	api.failPart = 2
+	s := newTestS3(api)
+
+	// Act
+	err := s.Put(context.Background(), "a.txt", strings.NewReader("abcdefghij"), "")
+
+	// Assert
+	if err == nil {
+		t.Fatal("Put() error = nil, want part failure")
+	}
+	if _, ok := api.objects["a.txt"]; ok || api.aborted != 1 || len(api.uploads) != 0 {
+		t.Errorf("object stored = %v, aborted = %d, pending = %d", ok, api.aborted, len(api.uploads))
+	}
+}
+
+func TestS3_GetDeleteListPresign(t *testing.T) {
+	// Arrange
+	api := newFakeS3()
+	s := newTestS3(api)
+	ctx := context.Background()
+	for _, key := range []string{"exports/a.csv", "exports/b.csv", "exports/c.csv", "uploads/d.png"} {
+		if err := s.Put(ctx, key, strings.NewReader(key), ""); err != nil {
+			t.Fatalf("Put(%q) error = %v", key, err)
+		}
+	}
+
+	// Act
+	rc, obj, err := s.Get(ctx, "exports/a.csv")
+
+	// Assert
+	if err != nil {
+		t.Fatalf("Get() error = %v", err)
+	}
+	body, _ := io.ReadAll(rc)
+	_ = rc.Close()
+	if string(body) != "exports/a.csv" || obj.Size != int64(len(body)) {
+		t.Errorf("Get() = %q %+v", body, obj)
+	}
+
+	var keys []string
+	err = s.List(ctx, "exports/", func(o Object) error {
+		keys = append(keys, o.Key)
+		return nil
+	})
+	if err != nil || strings.Join(keys, ",") != "exports/a.csv,exports/b.csv,exports/c.csv" {
+		t.Errorf("List(exports/) = %v, %v", keys, err)
+	}
+
+	u, err := s.PresignGet(ctx, "exports/a.csv", time.Minute)
+	if err != nil || !strings.Contains(u, "/exports/a.csv?") || !strings.Contains(u, "X-Amz-Expires=60") {
+		t.Errorf("PresignGet() = %q, %v", u, err)
+	}
+
+	if err := s.Delete(ctx, "exports/a.csv"); err != nil {
+		t.Fatalf("Delete() error = %v", err)
+	}
+	if _, _, err := s.Get(ctx, "exports/a.csv"); !errors.Is(err, ErrNotFound) {
+		t.Errorf("Get(deleted) error = %v, want %v", err, ErrNotFound)
+	}
+	if _, err := s.PresignGet(ctx, "exports/a.csv", time.Minute); !errors.Is(err, ErrNotFound) {
+		t.Errorf("PresignGet(deleted) error = %v, want %v", err, ErrNotFound)
+	}
+	if err := s.Put(ctx, "../x", strings.NewReader("x"), ""); !errors.Is(err, ErrInvalidKey) {
+		t.Errorf("Put(../x) error = %v, want %v", err, ErrInvalidKey)
+	}
+}
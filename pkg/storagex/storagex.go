@@ -0,0 +1,62 @@
+// Package storagex provides object storage with S3-compatible and
+// local-filesystem backends behind a single Storage interface.
+//
+// Objects are addressed by slash-separated keys such as
+// "uploads/avatars/01J....png". Reads and writes stream through io.Reader
+// and io.ReadCloser so large files never have to fit in memory, and
+// WithTracing wraps any backend with OpenTelemetry spans.
+package storagex
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"path"
+	"time"
+)
+
+var (
+	// ErrNotFound is returned when an object does not exist.
+	ErrNotFound = errors.New("object not found")
+
+	// ErrInvalidKey is returned for keys that are empty or not in canonical
+	// slash-separated form, e.g. "../escape" or "/abs".
+	ErrInvalidKey = errors.New("invalid object key")
+)
+
+// Object describes a stored object.
+type Object struct {
+	Key         string
+	Size        int64
+	ContentType string
+	ModTime     time.Time
+}
+
+// Storage stores objects by key.
+type Storage interface {
+	// Put streams the object read from r, replacing any existing object.
+	Put(ctx context.Context, key string, r io.Reader, contentType string) error
+
+	// Get opens the object for reading; the caller must close the reader.
+	Get(ctx context.Context, key string) (io.ReadCloser, Object, error)
+
+	// Delete removes the object. Deleting a missing object is not an error.
+	Delete(ctx context.Context, key string) error
+
+	// PresignGet returns a URL that downloads the object until ttl elapses.
+	PresignGet(ctx context.Context, key string, ttl time.Duration) (string, error)
+
+	// List calls fn for each object whose key starts with prefix.
+	// Listing stops at the first error returned by fn.
+	List(ctx context.Context, prefix string, fn func(Object) error) error
+}
+
+// checkKey rejects keys that are empty or not in canonical form, so a key
+// can never escape a Local root or alias another object.
+func checkKey(key string) error {
+	if key == "" || path.Clean("/"+key) != "/"+key {
+		return fmt.Errorf("%w: %q", ErrInvalidKey, key)
+	}
+	return nil
+}
@@ -0,0 +1,118 @@
+package storagex
+
+import (
+	"context"
+	"errors"
+	"io"
+	"time"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// instrumentationName is the OpenTelemetry tracer name.
+const instrumentationName = "github.com/blackhorseya/go-ddd/pkg/storagex"
+
+// tracing records a client span per Storage call. Only keys and sizes are
+// recorded; object contents never are.
+type tracing struct {
+	next    Storage
+	backend string
+}
+
+var _ Storage = tracing{}
+
+// WithTracing wraps s so every call records an OpenTelemetry span tagged
+// with backend, e.g. "s3" or "local".
+func WithTracing(s Storage, backend string) Storage {
+	return tracing{next: s, backend: backend}
+}
+
+func (t tracing) Put(ctx context.Context, key string, r io.Reader, contentType string) error {
+	ctx, span := t.start(ctx, "put", attribute.String("storage.key", key))
+	defer span.End()
+
+	cr := &countingReader{r: r}
+	err := t.next.Put(ctx, key, cr, contentType)
+	span.SetAttributes(attribute.Int64("storage.size", cr.n))
+	record(span, err)
+	return err
+}
+
+// Get ends its span when the object is opened; reading the body is not
+// part of it.
+func (t tracing) Get(ctx context.Context, key string) (io.ReadCloser, Object, error) {
+	ctx, span := t.start(ctx, "get", attribute.String("storage.key", key))
+	defer span.End()
+
+	rc, obj, err := t.next.Get(ctx, key)
+	span.SetAttributes(attribute.Int64("storage.size", obj.Size))
+	record(span, err)
+	return rc, obj, err
+}
+
+func (t tracing) Delete(ctx context.Context, key string) error {
+	ctx, span := t.start(ctx, "delete", attribute.String("storage.key", key))
+	defer span.End()
+
+	err := t.next.Delete(ctx, key)
+	record(span, err)
+	return err
+}
+
+func (t tracing) PresignGet(ctx context.Context, key string, ttl time.Duration) (string, error) {
+	ctx, span := t.start(ctx, "presign", attribute.String("storage.key", key))
+	defer span.End()
+
+	u, err := t.next.PresignGet(ctx, key, ttl)
+	record(span, err)
+	return u, err
+}
+
+func (t tracing) List(ctx context.Context, prefix string, fn func(Object) error) error {
+	ctx, span := t.start(ctx, "list", attribute.String("storage.prefix", prefix))
+	defer span.End()
+
+	var n int
+	err := t.next.List(ctx, prefix, func(o Object) error {
+		n++
+		return fn(o)
+	})
+	span.SetAttributes(attribute.Int("storage.objects", n))
+	record(span, err)
+	return err
+}
+
+func (t tracing) start(ctx context.Context, op string, attrs ...attribute.KeyValue) (context.Context, trace.Span) {
+	attrs = append(attrs,
+		attribute.String("storage.backend", t.backend),
+		attribute.String("storage.operation", op),
+	)
+	return otel.Tracer(instrumentationName).Start(ctx, "storage "+op,
+		trace.WithSpanKind(trace.SpanKindClient),
+		trace.WithAttributes(attrs...),
+	)
+}
+
+// record marks span as failed; a missing object is not an error.
+func record(span trace.Span, err error) {
+	if err == nil || errors.Is(err, ErrNotFound) {
+		return
+	}
+	span.RecordError(err)
+	span.SetStatus(codes.Error, err.Error())
+}
+
+// countingReader counts the bytes read through it.
+type countingReader struct {
+	r io.Reader
+	n int64
+}
+
+func (c *countingReader) Read(p []byte) (int, error) {
+	n, err := c.r.Read(p)
+	c.n += int64(n)
+	return n, err
+}
@@ -0,0 +1,60 @@
+package storagex
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+)
+
+func TestWithTracing(t *testing.T) {
+	// Arrange
+	recorder := tracetest.NewSpanRecorder()
+	prev := otel.GetTracerProvider()
+	otel.SetTracerProvider(sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder)))
+	t.Cleanup(func() { otel.SetTracerProvider(prev) })
+
+	s := WithTracing(NewLocal(t.TempDir(), "http://files.test", []byte("secret")), "local")
+	ctx := context.Background()
+
+	// Act
+	putErr := s.Put(ctx, "a.txt", strings.NewReader("hello"), "")
+	_, _, getErr := s.Get(ctx, "missing.txt")
+	delErr := s.Delete(ctx, "../escape")
+
+	// Assert
+	if putErr != nil || !errors.Is(getErr, ErrNotFound) || !errors.Is(delErr, ErrInvalidKey) {
+		t.Fatalf("errors = %v, %v, %v", putErr, getErr, delErr)
+	}
+	spans := recorder.Ended()
+	if len(spans) != 3 {
+		t.Fatalf("spans = %d, want 3", len(spans))
+	}
+
+	put := spans[0]
+	if put.Name() != "storage put" || !hasAttr(put.Attributes(), attribute.Int64("storage.size", 5)) ||
+		!hasAttr(put.Attributes(), attribute.String("storage.backend", "local")) {
+		t.Errorf("put span = %s %v", put.Name(), put.Attributes())
+	}
+	if got := spans[1].Status().Code; got != codes.Unset {
+		t.Errorf("not found status = %v, want %v", got, codes.Unset)
+	}
+	if got := spans[2].Status().Code; got != codes.Error {
+		t.Errorf("invalid key status = %v, want %v", got, codes.Error)
+	}
+}
+
+func hasAttr(attrs []attribute.KeyValue, want attribute.KeyValue) bool {
+	for _, a := range attrs {
+		if a == want {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,134 @@
+package e2e_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	httpserver "github.com/blackhorseya/go-ddd/internal/adapter/http"
+	"github.com/blackhorseya/go-ddd/internal/adapter/http/request"
+	"github.com/blackhorseya/go-ddd/internal/adapter/http/response"
+	"github.com/blackhorseya/go-ddd/internal/domain"
+	"github.com/blackhorseya/go-ddd/internal/domain/order"
+	"github.com/blackhorseya/go-ddd/internal/domain/valueobject"
+	"github.com/blackhorseya/go-ddd/internal/infrastructure/persistence/memory"
+	"github.com/blackhorseya/go-ddd/pkg/mapx"
+)
+
+// listResponse is the cursor list envelope returned by /orders.
+type listResponse struct {
+	Data []string `json:"data"`
+	Meta struct {
+		Cursor response.Cursor `json:"cursor"`
+	} `json:"meta"`
+	Error *response.Error `json:"error"`
+}
+
+// newOrdersServer serves GET /orders from a repository holding n orders,
+// signing cursors with key and expiring them after ttl.
+func newOrdersServer(t *testing.T, key []byte, ttl time.Duration, n int) *httpserver.Server {
+	t.Helper()
+	repo := memory.NewOrderRepository()
+	for i := range n {
+		total, err := valueobject.NewMoney(int64(100*(i+1)), "USD")
+		require.NoError(t, err)
+		o, err := order.NewOrder("o"+strconv.Itoa(i), "c1", total)
+		require.NoError(t, err)
+		require.NoError(t, repo.Save(context.Background(), o))
+	}
+
+	server := httpserver.NewServer(httpserver.ServerConfig{
+		Mode:       gin.TestMode,
+		CursorKeys: [][]byte{key},
+		CursorTTL:  ttl,
+	}, "e2e", nil, nil)
+	server.Router().GET("/orders", func(c *gin.Context) {
+		req, err := request.NewCursorRequestFromQuery(c.Request.URL.Query(), server.Cursors())
+		if err != nil {
+			response.FromError(c, err)
+			return
+		}
+		page, err := repo.FindCursor(c.Request.Context(), nil, req)
+		if err != nil {
+			response.FromError(c, err)
+			return
+		}
+		response.CursorPage(c, mapx.MapCursor(page, (*order.Order).ID))
+	})
+	return server
+}
+
+// list requests one page of orders.
+func list(t *testing.T, server *httpserver.Server, query url.Values) (int, listResponse) {
+	t.Helper()
+	w := httptest.NewRecorder()
+	server.Router().ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/orders?"+query.Encode(), nil))
+	var body listResponse
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &body))
+	return w.Code, body
+}
+
+func TestOrders_SignedCursorPagination(t *testing.T) {
+	// Arrange
+	key := []byte("cursor-key")
+	server := newOrdersServer(t, key, time.Hour, 5)
+	codec := domain.NewCursorCodec(request.CursorVersion, time.Hour, key)
+
+	// Act
+	var pages [][]string
+	query := url.Values{request.QueryPageSize: {"2"}}
+	for {
+		status, body := list(t, server, query)
+		require.Equal(t, http.StatusOK, status, "error: %+v", body.Error)
+		pages = append(pages, body.Data)
+		if !body.Meta.Cursor.HasMore {
+			break
+		}
+
+		// Assert: every issued cursor is versioned and signed.
+		_, err := codec.Decode(body.Meta.Cursor.NextCursor)
+		require.NoError(t, err, "next cursor %q", body.Meta.Cursor.NextCursor)
+		query.Set(request.QueryCursor, body.Meta.Cursor.NextCursor)
+	}
+
+	// Assert
+	assert.Equal(t, [][]string{{"o4", "o3"}, {"o2", "o1"}, {"o0"}}, pages)
+}
+
+func TestOrders_RejectsForeignCursors(t *testing.T) {
+	// Arrange
+	key := []byte("cursor-key")
+	server := newOrdersServer(t, key, time.Minute, 3)
+	_, first := list(t, server, url.Values{request.QueryPageSize: {"1"}})
+	plain, err := server.Cursors().Open(first.Meta.Cursor.NextCursor)
+	require.NoError(t, err)
+	forged, err := domain.NewCursorCodec(request.CursorVersion, 0, []byte("other-key")).Encode("i:1")
+	require.NoError(t, err)
+	stale, err := domain.NewCursorCodec(request.CursorVersion+1, 0, key).Encode("i:1")
+	require.NoError(t, err)
+	issued := strconv.FormatInt(time.Now().Add(-time.Hour).Unix(), 10)
+	expired, err := domain.EncodeSignedCursor(key, "v"+strconv.Itoa(request.CursorVersion), issued, "i:1")
+	require.NoError(t, err)
+
+	cursors := map[string]string{"unsigned": plain, "forged": forged, "other version": stale, "expired": expired}
+	for name, cursor := range cursors {
+		t.Run(name, func(t *testing.T) {
+			// Act
+			status, body := list(t, server, url.Values{request.QueryCursor: {cursor}})
+
+			// Assert
+			assert.Equal(t, http.StatusBadRequest, status)
+			require.NotNil(t, body.Error)
+			assert.Equal(t, response.CodeBadRequest, body.Error.Code)
+		})
+	}
+}
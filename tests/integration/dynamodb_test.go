@@ -0,0 +1,95 @@
+//go:build integration
+
+package integration_test
+
+import (
+	"context"
+	"os"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/blackhorseya/go-ddd/internal/domain"
+	"github.com/blackhorseya/go-ddd/internal/domain/order"
+	"github.com/blackhorseya/go-ddd/internal/domain/valueobject"
+	"github.com/blackhorseya/go-ddd/internal/infrastructure/config"
+	"github.com/blackhorseya/go-ddd/internal/infrastructure/persistence/dynamodb"
+)
+
+// dynamoDBConfig reads DynamoDB Local from TEST_DYNAMODB_ENDPOINT, as set by
+// `task test:integration:dynamodb`, and skips the test when it is absent.
+func dynamoDBConfig(t *testing.T) config.DynamoDB {
+	t.Helper()
+	endpoint := os.Getenv("TEST_DYNAMODB_ENDPOINT")
+	if endpoint == "" {
+		t.Skip("TEST_DYNAMODB_ENDPOINT not set")
+	}
+	return config.DynamoDB{
+		Region:   "us-east-1",
+		Endpoint: endpoint,
+		Table:    "orders-" + strconv.FormatInt(time.Now().UnixNano(), 36),
+	}
+}
+
+func TestDynamoDB_OrderRepository(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+
+	cfg := dynamoDBConfig(t)
+	client, err := dynamodb.NewClient(ctx, cfg)
+	require.NoError(t, err)
+	require.NoError(t, dynamodb.CreateTable(ctx, client, cfg.Table))
+
+	repo := dynamodb.NewOrderRepository(client, cfg.Table)
+	for i := range 5 {
+		total, err := valueobject.NewMoney(int64(100*(i+1)), "USD")
+		require.NoError(t, err)
+		o, err := order.NewOrder("o"+strconv.Itoa(i), "c1", total)
+		require.NoError(t, err)
+		require.NoError(t, repo.Save(ctx, o))
+		time.Sleep(time.Millisecond)
+	}
+
+	// Update with optimistic locking
+	o, err := repo.Get(ctx, "o0")
+	require.NoError(t, err)
+	stale, err := repo.Get(ctx, "o0")
+	require.NoError(t, err)
+	require.NoError(t, o.Confirm())
+	require.NoError(t, repo.Save(ctx, o))
+	require.NoError(t, stale.Cancel())
+	assert.ErrorIs(t, repo.Save(ctx, stale), domain.ErrConcurrentModification)
+
+	// Offset page with a specification
+	page, err := repo.FindPage(ctx, order.StatusSpec{Status: order.StatusPending}, mustPage(t, 1, 2))
+	require.NoError(t, err)
+	assert.Equal(t, int64(4), page.TotalItems())
+	assert.Equal(t, []string{"o4", "o3"}, orderIDs(page.Items()))
+
+	// Keyset pages from LastEvaluatedKey
+	req, err := domain.NewCursorRequest("", 3)
+	require.NoError(t, err)
+	first, err := repo.FindCursor(ctx, nil, req)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"o4", "o3", "o2"}, orderIDs(first.Items()))
+	require.True(t, first.HasMore())
+
+	req, err = domain.NewCursorRequest(first.NextCursor(), 3)
+	require.NoError(t, err)
+	second, err := repo.FindCursor(ctx, nil, req)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"o1", "o0"}, orderIDs(second.Items()))
+	assert.False(t, second.HasMore())
+
+	// Soft delete
+	require.NoError(t, repo.Delete(ctx, "o1"))
+	_, err = repo.Get(ctx, "o1")
+	assert.ErrorIs(t, err, domain.ErrNotFound)
+	assert.ErrorIs(t, repo.Delete(ctx, "o1"), domain.ErrNotFound)
+	n, err := repo.CountByCustomer(ctx, "c1")
+	require.NoError(t, err)
+	assert.Equal(t, int64(4), n)
+}
@@ -0,0 +1,49 @@
+//go:build integration
+
+package integration_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/blackhorseya/go-ddd/internal/application/message"
+	"github.com/blackhorseya/go-ddd/internal/infrastructure/messaging/kafka"
+	"github.com/blackhorseya/go-ddd/internal/testingx/containers"
+)
+
+func TestKafka_PublishConsume(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), time.Minute)
+	defer cancel()
+
+	env := containers.Kafka(t)
+	env.CreateTopics(t, "order.placed")
+	require.NoError(t, kafka.Checker(env.Config.Brokers).Check(ctx))
+
+	producer := kafka.NewProducer(env.Config)
+	defer producer.Close()
+	sent := message.NewEnvelope("order.placed", 1, "o1", []byte(`{"id":"o1"}`))
+	require.NoError(t, producer.Publish(ctx, sent))
+
+	received := make(chan message.Envelope, 1)
+	consumer := kafka.NewConsumer(env.Config)
+	consumer.Subscribe("order.placed", func(_ context.Context, env message.Envelope) error {
+		received <- env
+		return nil
+	})
+	runCtx, stop := context.WithCancel(ctx)
+	defer stop()
+	go func() { _ = consumer.Run(runCtx) }()
+
+	select {
+	case got := <-received:
+		assert.Equal(t, sent.ID, got.ID)
+		assert.Equal(t, "o1", got.Key)
+		assert.JSONEq(t, `{"id":"o1"}`, string(got.Payload))
+	case <-ctx.Done():
+		t.Fatal("message not consumed")
+	}
+}
@@ -0,0 +1,114 @@
+//go:build integration
+
+package integration_test
+
+import (
+	"context"
+	"database/sql"
+	"os"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/blackhorseya/go-ddd/internal/domain"
+	"github.com/blackhorseya/go-ddd/internal/infrastructure/config"
+	"github.com/blackhorseya/go-ddd/internal/infrastructure/persistence/mysql"
+	"github.com/blackhorseya/go-ddd/internal/infrastructure/persistence/sqldb"
+)
+
+// mysqlConfig reads the test database from TEST_MYSQL_* variables, as set
+// by `task test:integration:mysql`, and skips the test when they are absent.
+func mysqlConfig(t *testing.T) config.Database {
+	t.Helper()
+	host := os.Getenv("TEST_MYSQL_HOST")
+	if host == "" {
+		t.Skip("TEST_MYSQL_HOST not set")
+	}
+	port, _ := strconv.Atoi(os.Getenv("TEST_MYSQL_PORT"))
+	if port == 0 {
+		port = 3306
+	}
+	return config.Database{
+		Driver:   "mysql",
+		Host:     host,
+		Port:     port,
+		User:     os.Getenv("TEST_MYSQL_USER"),
+		Password: os.Getenv("TEST_MYSQL_PASSWORD"),
+		Name:     os.Getenv("TEST_MYSQL_DATABASE"),
+		SSLMode:  "disable",
+	}
+}
+
+func TestMySQL_DialectQueries(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+
+	db, err := mysql.Connect(ctx, mysqlConfig(t))
+	require.NoError(t, err)
+	defer db.Close()
+
+	d := sqldb.MySQL
+	_, err = db.ExecContext(ctx, `DROP TABLE IF EXISTS it_orders`)
+	require.NoError(t, err)
+	_, err = db.ExecContext(ctx, `CREATE TABLE it_orders (
+		id         VARCHAR(26) PRIMARY KEY,
+		status     VARCHAR(20) NOT NULL,
+		created_at DATETIME(6) NOT NULL,
+		deleted_at DATETIME(6) NULL
+	)`)
+	require.NoError(t, err)
+
+	base := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	for i := range 5 {
+		_, err := db.ExecContext(ctx, d.Rebind(`INSERT INTO it_orders (id, status, created_at) VALUES (?, ?, ?)`, 0),
+			"o"+strconv.Itoa(i), "paid", base.Add(time.Duration(i)*time.Hour))
+		require.NoError(t, err)
+	}
+
+	// Offset paging
+	where := sqldb.Expr("status = ?", "paid")
+	page := d.LimitOffset(2, 2)
+	query := "SELECT id FROM " + d.Quote("it_orders") + d.Where(where, 0) +
+		" ORDER BY id " + d.Rebind(page.SQL, len(where.Args))
+	assert.Equal(t, []string{"o2", "o3"}, queryIDs(ctx, t, db, query, append(where.Args, page.Args...)...))
+
+	// Keyset paging
+	cursor, err := domain.NewKeysetCursor(base.Add(2*time.Hour), "o2")
+	require.NoError(t, err)
+	after, err := sqldb.KeysetWhere([]string{"created_at", "id"}, cursor, domain.SortAsc, false)
+	require.NoError(t, err)
+	query = "SELECT id FROM it_orders" + d.Where(after, 0) +
+		" ORDER BY " + sqldb.KeysetOrderBy([]string{"created_at", "id"}, domain.SortAsc, false)
+	assert.Equal(t, []string{"o3", "o4"}, queryIDs(ctx, t, db, query, after.Args...))
+
+	// Soft-delete purge
+	_, err = db.ExecContext(ctx, `UPDATE it_orders SET deleted_at = ? WHERE id = ?`, base, "o0")
+	require.NoError(t, err)
+	purged, err := sqldb.NewPurger(db, sqldb.PurgerConfig{
+		Tables:    []string{"it_orders"},
+		Retention: time.Hour,
+		Dialect:   sqldb.MySQL,
+	}).PurgeOnce(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), purged)
+}
+
+// queryIDs runs query and returns the selected ids.
+func queryIDs(ctx context.Context, t *testing.T, db *sql.DB, query string, args ...any) []string {
+	t.Helper()
+	rows, err := db.QueryContext(ctx, query, args...)
+	require.NoError(t, err)
+	defer rows.Close()
+
+	var ids []string
+	for rows.Next() {
+		var id string
+		require.NoError(t, rows.Scan(&id))
+		ids = append(ids, id)
+	}
+	require.NoError(t, rows.Err())
+	return ids
+}
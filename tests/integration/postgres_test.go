@@ -0,0 +1,320 @@
+//go:build integration
+
+package integration_test
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"os"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/blackhorseya/go-ddd/internal/domain"
+	"github.com/blackhorseya/go-ddd/internal/domain/order"
+	"github.com/blackhorseya/go-ddd/internal/domain/valueobject"
+	"github.com/blackhorseya/go-ddd/internal/infrastructure/config"
+	"github.com/blackhorseya/go-ddd/internal/infrastructure/outbox"
+	"github.com/blackhorseya/go-ddd/internal/infrastructure/persistence/postgres"
+	"github.com/blackhorseya/go-ddd/internal/infrastructure/persistence/seed"
+	"github.com/blackhorseya/go-ddd/internal/infrastructure/persistence/sqlc"
+	"github.com/blackhorseya/go-ddd/internal/infrastructure/persistence/sqldb"
+	"github.com/blackhorseya/go-ddd/internal/infrastructure/projection"
+	"github.com/blackhorseya/go-ddd/internal/testingx/containers"
+)
+
+// postgresConfig reads the test database from TEST_POSTGRES_* variables, as
+// set by `task test:integration:postgres`, and otherwise starts a container.
+func postgresConfig(t *testing.T) config.Database {
+	t.Helper()
+	host := os.Getenv("TEST_POSTGRES_HOST")
+	if host == "" {
+		return containers.Postgres(t).Config
+	}
+	port, _ := strconv.Atoi(os.Getenv("TEST_POSTGRES_PORT"))
+	if port == 0 {
+		port = 5432
+	}
+	return config.Database{
+		Driver:   "postgres",
+		Host:     host,
+		Port:     port,
+		User:     os.Getenv("TEST_POSTGRES_USER"),
+		Password: os.Getenv("TEST_POSTGRES_PASSWORD"),
+		Name:     os.Getenv("TEST_POSTGRES_DATABASE"),
+		SSLMode:  "disable",
+	}
+}
+
+func TestSQLC_OrderRepository(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+
+	db, err := postgres.Connect(ctx, postgresConfig(t))
+	require.NoError(t, err)
+	defer db.Close()
+
+	_, err = db.ExecContext(ctx, `DROP TABLE IF EXISTS orders`)
+	require.NoError(t, err)
+	_, err = db.ExecContext(ctx, sqlc.Schema)
+	require.NoError(t, err)
+
+	repo := sqlc.NewOrderRepository(db)
+	uow := sqldb.NewTxManager(db, nil)
+
+	for i := range 5 {
+		total, err := valueobject.NewMoney(int64(100*(i+1)), "USD")
+		require.NoError(t, err)
+		o, err := order.NewOrder("o"+strconv.Itoa(i), "c1", total)
+		require.NoError(t, err)
+		require.NoError(t, uow.Do(ctx, func(ctx context.Context) error {
+			return repo.Save(ctx, o)
+		}))
+		time.Sleep(time.Millisecond)
+	}
+
+	// Update with optimistic locking
+	o, err := repo.Get(ctx, "o0")
+	require.NoError(t, err)
+	stale, err := repo.Get(ctx, "o0")
+	require.NoError(t, err)
+	require.NoError(t, o.Confirm())
+	require.NoError(t, repo.Save(ctx, o))
+	require.NoError(t, stale.Cancel())
+	assert.ErrorIs(t, repo.Save(ctx, stale), domain.ErrConcurrentModification)
+
+	// Offset page with a specification
+	page, err := repo.FindPage(ctx, order.StatusSpec{Status: order.StatusPending}, mustPage(t, 1, 2))
+	require.NoError(t, err)
+	assert.Equal(t, int64(4), page.TotalItems())
+	assert.Equal(t, []string{"o4", "o3"}, orderIDs(page.Items()))
+
+	// Keyset pages
+	req, err := domain.NewCursorRequest("", 3)
+	require.NoError(t, err)
+	first, err := repo.FindCursor(ctx, nil, req)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"o4", "o3", "o2"}, orderIDs(first.Items()))
+	require.True(t, first.HasMore())
+
+	req, err = domain.NewCursorRequest(first.NextCursor(), 3)
+	require.NoError(t, err)
+	second, err := repo.FindCursor(ctx, nil, req)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"o1", "o0"}, orderIDs(second.Items()))
+	assert.False(t, second.HasMore())
+
+	// Offset cursor pages
+	offset, err := repo.FindCursor(ctx, nil, domain.CursorRequestFromPage(mustPage(t, 2, 2)))
+	require.NoError(t, err)
+	assert.Equal(t, []string{"o2", "o1"}, orderIDs(offset.Items()))
+	require.True(t, offset.HasMore())
+	req, err = domain.NewCursorRequest(offset.NextCursor(), 2)
+	require.NoError(t, err)
+	last, err := repo.FindCursor(ctx, nil, req)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"o0"}, orderIDs(last.Items()))
+	assert.False(t, last.HasMore())
+
+	// Soft delete
+	require.NoError(t, repo.Delete(ctx, "o1"))
+	_, err = repo.Get(ctx, "o1")
+	assert.ErrorIs(t, err, domain.ErrNotFound)
+	n, err := repo.CountByCustomer(ctx, "c1")
+	require.NoError(t, err)
+	assert.Equal(t, int64(4), n)
+}
+
+func TestSeed_TestEnvironment(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+
+	db, err := postgres.Connect(ctx, postgresConfig(t))
+	require.NoError(t, err)
+	defer db.Close()
+
+	_, err = db.ExecContext(ctx, `DROP TABLE IF EXISTS orders`)
+	require.NoError(t, err)
+	_, err = db.ExecContext(ctx, sqlc.Schema)
+	require.NoError(t, err)
+
+	seeder := seed.New(db, sqldb.Postgres, seed.FS)
+	first, err := seeder.Run(ctx, "test")
+	require.NoError(t, err)
+	assert.Equal(t, int64(2), first.Rows)
+
+	// Re-running leaves the dataset unchanged
+	second, err := seeder.Run(ctx, "test")
+	require.NoError(t, err)
+	assert.Equal(t, int64(0), second.Rows)
+
+	repo := sqlc.NewOrderRepository(db)
+	n, err := repo.CountByCustomer(ctx, "test-customer")
+	require.NoError(t, err)
+	assert.Equal(t, int64(2), n)
+	o, err := repo.Get(ctx, "test-order-2")
+	require.NoError(t, err)
+	assert.Equal(t, order.StatusConfirmed, o.Status())
+}
+
+func TestPostgres_TxManager(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+
+	pool, err := postgres.NewPool(ctx, postgresConfig(t))
+	require.NoError(t, err)
+	defer pool.Close()
+
+	_, err = pool.Exec(ctx, `DROP TABLE IF EXISTS tx_probe`)
+	require.NoError(t, err)
+	_, err = pool.Exec(ctx, `CREATE TABLE tx_probe (id INT PRIMARY KEY)`)
+	require.NoError(t, err)
+
+	insert := func(ctx context.Context, id int) error {
+		_, err := postgres.Conn(ctx, pool).Exec(ctx, `INSERT INTO tx_probe (id) VALUES ($1)`, id)
+		return err
+	}
+	uow := postgres.NewTxManager(pool, pgx.TxOptions{})
+
+	require.NoError(t, uow.Do(ctx, func(ctx context.Context) error {
+		if err := insert(ctx, 1); err != nil {
+			return err
+		}
+		return insert(ctx, 2)
+	}))
+
+	// A failing statement rolls back the whole unit of work
+	err = uow.Do(ctx, func(ctx context.Context) error {
+		if err := insert(ctx, 3); err != nil {
+			return err
+		}
+		return insert(ctx, 1)
+	})
+	require.Error(t, err)
+
+	var n int
+	require.NoError(t, pool.QueryRow(ctx, `SELECT count(*) FROM tx_probe`).Scan(&n))
+	assert.Equal(t, 2, n)
+}
+
+func TestOutbox_FetchAfter_LateCommit(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+
+	db, err := postgres.Connect(ctx, postgresConfig(t))
+	require.NoError(t, err)
+	defer db.Close()
+
+	_, err = db.ExecContext(ctx, `DROP TABLE IF EXISTS outbox_messages, projection_checkpoints`)
+	require.NoError(t, err)
+	_, err = db.ExecContext(ctx, outbox.Schema)
+	require.NoError(t, err)
+	_, err = db.ExecContext(ctx, projection.Schema)
+	require.NoError(t, err)
+
+	store := outbox.NewStore(db)
+	checkpoints := projection.NewCheckpoints(db)
+	insert := func(tx *sql.Tx, aggregateID string) {
+		_, err := tx.ExecContext(ctx, `INSERT INTO outbox_messages (aggregate_id, event_name, payload, occurred_at)
+			VALUES ($1, 'order.created', '{}', now())`, aggregateID)
+		require.NoError(t, err)
+	}
+
+	// The early transaction takes ID 1 and commits after ID 2.
+	early, err := db.BeginTx(ctx, nil)
+	require.NoError(t, err)
+	defer func() { _ = early.Rollback() }()
+	insert(early, "order-early")
+
+	late, err := db.BeginTx(ctx, nil)
+	require.NoError(t, err)
+	insert(late, "order-late")
+	require.NoError(t, late.Commit())
+
+	// ID 2 is held back while ID 1 can still commit before it.
+	msgs, err := store.FetchAfter(ctx, outbox.Position{}, 10)
+	require.NoError(t, err)
+	assert.Empty(t, msgs)
+
+	require.NoError(t, early.Commit())
+
+	msgs, err = store.FetchAfter(ctx, outbox.Position{}, 10)
+	require.NoError(t, err)
+	require.Len(t, msgs, 2)
+	assert.Equal(t, "order-early", msgs[0].AggregateID)
+	assert.Equal(t, "order-late", msgs[1].AggregateID)
+
+	// A checkpoint resumes after the last applied position.
+	require.NoError(t, checkpoints.Save(ctx, "orders", msgs[0].Position()))
+	position, err := checkpoints.Load(ctx, "orders")
+	require.NoError(t, err)
+	assert.Equal(t, msgs[0].Position(), position)
+
+	msgs, err = store.FetchAfter(ctx, position, 10)
+	require.NoError(t, err)
+	require.Len(t, msgs, 1)
+	assert.Equal(t, "order-late", msgs[0].AggregateID)
+}
+
+func TestOutbox_MarkParked(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+
+	db, err := postgres.Connect(ctx, postgresConfig(t))
+	require.NoError(t, err)
+	defer db.Close()
+
+	_, err = db.ExecContext(ctx, `DROP TABLE IF EXISTS outbox_messages`)
+	require.NoError(t, err)
+	_, err = db.ExecContext(ctx, outbox.Schema)
+	require.NoError(t, err)
+
+	store := outbox.NewStore(db)
+	require.NoError(t, store.Add(ctx,
+		outbox.Message{AggregateID: "order-1", EventName: "order.created", Payload: []byte(`{}`), OccurredAt: time.Now()},
+		outbox.Message{AggregateID: "order-2", EventName: "order.created", Payload: []byte(`{}`), OccurredAt: time.Now()},
+	))
+	msgs, err := store.FetchPending(ctx, 10)
+	require.NoError(t, err)
+	require.Len(t, msgs, 2)
+
+	require.NoError(t, store.MarkParked(ctx, msgs[0].ID, errors.New("poison")))
+
+	// Parked messages are skipped but keep their attempt and error.
+	pending, err := store.FetchPending(ctx, 10)
+	require.NoError(t, err)
+	require.Len(t, pending, 1)
+	assert.Equal(t, "order-2", pending[0].AggregateID)
+
+	var (
+		attempts  int
+		lastError string
+	)
+	require.NoError(t, db.QueryRowContext(ctx,
+		`SELECT attempts, last_error FROM outbox_messages WHERE id = $1 AND parked_at IS NOT NULL`,
+		msgs[0].ID).Scan(&attempts, &lastError))
+	assert.Equal(t, 1, attempts)
+	assert.Equal(t, "poison", lastError)
+}
+
+// mustPage builds a PageRequest or fails the test.
+func mustPage(t *testing.T, page, size int) domain.PageRequest {
+	t.Helper()
+	p, err := domain.NewPageRequest(page, size)
+	require.NoError(t, err)
+	return p
+}
+
+// orderIDs returns the IDs of orders.
+func orderIDs(orders []*order.Order) []string {
+	ids := make([]string, len(orders))
+	for i, o := range orders {
+		ids[i] = o.ID()
+	}
+	return ids
+}
@@ -0,0 +1,35 @@
+//go:build integration
+
+package integration_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/blackhorseya/go-ddd/internal/testingx/containers"
+	"github.com/blackhorseya/go-ddd/pkg/lockx"
+)
+
+func TestRedis_Lock(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+
+	env := containers.Redis(t)
+	locker := lockx.New(lockx.Options{}, env.Client)
+
+	lock, err := locker.TryAcquire(ctx, "orders:o1")
+	require.NoError(t, err)
+
+	// A second holder is turned away until the lock is released
+	_, err = locker.TryAcquire(ctx, "orders:o1")
+	assert.ErrorIs(t, err, lockx.ErrNotAcquired)
+
+	require.NoError(t, lock.Release(ctx))
+	lock, err = locker.TryAcquire(ctx, "orders:o1")
+	require.NoError(t, err)
+	require.NoError(t, lock.Release(ctx))
+}